@@ -55,10 +55,11 @@ func main() {
 
 	router := bootstrap.NewServer(deps)
 
-	// start background schedule sync + score polling; cancelled on shutdown
+	// start background schedule sync, score polling and scheduled contest locks; cancelled on shutdown
 	scoresCtx, stopScores := context.WithCancel(context.Background())
 	defer stopScores()
 	bootstrap.StartScoresWorker(scoresCtx, deps)
+	bootstrap.StartLifecycleWorker(scoresCtx, deps)
 
 	srv := &http.Server{
 		Addr:              fmt.Sprintf(":%d", deps.Config.Server.Port),
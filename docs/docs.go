@@ -15,6 +15,50 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/avatars/{key}": {
+            "get": {
+                "description": "Returns an avatar without authentication so it can back an image tag. The url comes from a visible profile's avatarUrl and changes on every upload or visibility change",
+                "produces": [
+                    "image/png",
+                    "image/jpeg",
+                    "image/gif",
+                    "image/webp"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get an avatar image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Avatar key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
         "/contact": {
             "post": {
                 "description": "Submit a contact form message",
@@ -80,6 +124,12 @@ const docTemplate = `{
                 ],
                 "summary": "Create a new Contest",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Client-generated key; retries with the same key replay the first response for 24h",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
                     {
                         "description": "Contest",
                         "name": "contest",
@@ -109,6 +159,93 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.APIError"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/contests/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Recreates an exported contest under the caller's ownership with new IDs. Invites are informational only and are not recreated",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contests"
+                ],
+                "summary": "Import a contest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Client-generated key; retries with the same key replay the first response for 24h",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Exported contest document",
+                        "name": "document",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ContestExport"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContestSwagger"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -139,6 +276,12 @@ const docTemplate = `{
                         "description": "Filter contests by name (case-insensitive)",
                         "name": "search",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return contests owned by this organization, including ones the user administers without joining",
+                        "name": "org",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -151,6 +294,12 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -230,6 +379,71 @@ const docTemplate = `{
             }
         },
         "/contests/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a contest with its squares and quarter results. The ETag header carries the contest version; send it back in If-None-Match to get a 304 when nothing changed, or in If-Match on writes to avoid overwriting someone else's change",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contests"
+                ],
+                "summary": "Get a contest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous read",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContestSwagger"
+                        }
+                    },
+                    "304": {
+                        "description": "Contest unchanged"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            },
             "delete": {
                 "security": [
                     {
@@ -251,6 +465,12 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the contest version being deleted",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -275,6 +495,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.APIError"
                         }
                     },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContestConflictErrorSwagger"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -289,7 +515,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates the values of a contest",
+                "description": "Updates the values of a contest. Send the contest's ETag in If-Match to reject the update with 412 if the contest changed since it was read",
                 "consumes": [
                     "application/json"
                 ],
@@ -308,6 +534,12 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "ETag of the contest version being edited",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
                     {
                         "description": "Contest update data",
                         "name": "contest",
@@ -343,6 +575,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.APIError"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContestConflictErrorSwagger"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContestConflictErrorSwagger"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -352,21 +596,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/contests/{id}/invites": {
+        "/contests/{id}/analytics": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Owner gets all invite links for a contest",
+                "description": "Returns per-square win probabilities for each quarter and the expected share of the pot, based on historical last-digit frequencies. Every square has equal odds until the labels are drawn",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "invites"
+                    "contests"
                 ],
-                "summary": "Get all invites for a contest",
+                "summary": "Get square win probabilities for a contest",
                 "parameters": [
                     {
                         "type": "string",
@@ -380,10 +624,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.ContestInvite"
-                            }
+                            "$ref": "#/definitions/model.ContestAnalyticsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
                         }
                     },
                     "403": {
@@ -405,24 +652,23 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/contests/{id}/bans": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Owner creates an invite link with specified role and square limit",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Owner only; most recent bans first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "invites"
+                    "participants"
                 ],
-                "summary": "Create an invite link for a contest",
+                "summary": "List users banned from a contest",
                 "parameters": [
                     {
                         "type": "string",
@@ -430,22 +676,16 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Invite details",
-                        "name": "invite",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.CreateInviteRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.ContestInvite"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ContestBan"
+                            }
                         }
                     },
                     "400": {
@@ -460,12 +700,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.APIError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/model.APIError"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -473,20 +707,24 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/contests/{id}/invites/{inviteId}": {
-            "delete": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Owner deletes an invite link",
+                "description": "Owner removes a user and blocks them from rejoining through any invite link or watching a public contest. Their squares are cleared before kickoff and ghosted after unless squares is set; their live connections are closed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "invites"
+                    "participants"
                 ],
-                "summary": "Delete an invite link",
+                "summary": "Ban a user from a contest",
                 "parameters": [
                     {
                         "type": "string",
@@ -496,16 +734,27 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Invite ID",
-                        "name": "inviteId",
-                        "in": "path",
-                        "required": true
+                        "description": "Ban details",
+                        "name": "ban",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BanParticipantRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContestBan"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
                     },
                     "403": {
                         "description": "Forbidden",
@@ -519,6 +768,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.APIError"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -528,21 +783,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/contests/{id}/participants": {
-            "get": {
+        "/contests/{id}/bans/{userId}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns all participants and their roles. Any participant can view. Public contests allow any authenticated user",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Owner only; the user can rejoin through an invite afterwards but gets nothing back automatically",
                 "tags": [
                     "participants"
                 ],
-                "summary": "Get all participants for a contest",
+                "summary": "Lift a contest ban",
                 "parameters": [
                     {
                         "type": "string",
@@ -550,17 +802,18 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Banned user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.ContestParticipant"
-                            }
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -589,18 +842,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/contests/{id}/participants/{userId}": {
-            "delete": {
+        "/contests/{id}/board.png": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Owner removes a participant, or a participant removes themselves; the removed participant's squares are cleared. The owner cannot be removed.",
+                "description": "Renders the 10x10 grid with team names, labels, square initials and highlighted quarter winners. Anyone who can view the contest can fetch it. The ETag changes whenever the board does",
+                "produces": [
+                    "image/png"
+                ],
                 "tags": [
-                    "participants"
+                    "contests"
                 ],
-                "summary": "Remove a participant from a contest",
+                "summary": "Get the board as a PNG image",
                 "parameters": [
                     {
                         "type": "string",
@@ -611,15 +867,20 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Target user ID",
-                        "name": "userId",
-                        "in": "path",
-                        "required": true
+                        "description": "ETag from a previous fetch",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "Board unchanged"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -646,24 +907,23 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/contests/{id}/board.svg": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Owner updates a participant's role or max squares",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Same board as the PNG, as scalable vector graphics",
                 "produces": [
-                    "application/json"
+                    "image/svg+xml"
                 ],
                 "tags": [
-                    "participants"
+                    "contests"
                 ],
-                "summary": "Update a participant's role or square limit",
+                "summary": "Get the board as an SVG image",
                 "parameters": [
                     {
                         "type": "string",
@@ -674,28 +934,21 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Target user ID",
-                        "name": "userId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update details",
-                        "name": "participant",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.UpdateParticipantRequest"
-                        }
+                        "description": "ETag from a previous fetch",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.ContestParticipant"
+                            "type": "file"
                         }
                     },
+                    "304": {
+                        "description": "Board unchanged"
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -723,24 +976,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/contests/{id}/quarter-result": {
-            "post": {
+        "/contests/{id}/export": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Records the score and winner for a specific quarter",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns a versioned JSON document with the contest, its labels, squares, quarter results, participants and invite settings. Only the contest owner can export",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "contests"
                 ],
-                "summary": "Record quarter result",
+                "summary": "Export a contest",
                 "parameters": [
                     {
                         "type": "string",
@@ -748,22 +998,13 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Quarter result data",
-                        "name": "quarterResult",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.QuarterResultRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.QuarterResult"
+                            "$ref": "#/definitions/model.ContestExport"
                         }
                     },
                     "400": {
@@ -793,21 +1034,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/contests/{id}/quarter-result/rollback": {
-            "post": {
+        "/contests/{id}/invites": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes the most recently recorded quarter result and reverts the contest to the prior quarter. Only for manual (non game-linked) contests.",
+                "description": "Owner gets all invite links for a contest, each with its funnel of preview views, redemptions and timestamps",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "contests"
+                    "invites"
                 ],
-                "summary": "Roll back the last quarter result",
+                "summary": "Get all invites for a contest",
                 "parameters": [
                     {
                         "type": "string",
@@ -821,13 +1062,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.QuarterResult"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/model.APIError"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ContestInvite"
+                            }
                         }
                     },
                     "403": {
@@ -849,16 +1087,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/contests/{id}/squares/clear-mine": {
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Clears the value and owner of every square owned by the authenticated user. Only the caller's own squares are affected.",
+                "description": "Owner creates an invite link with specified role and square limit. Optional allowed domains and emails limit who can redeem it by their verified email. Reserved squares are held for the invitee until the invite is redeemed, expires or is deleted",
                 "consumes": [
                     "application/json"
                 ],
@@ -866,9 +1102,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contests"
+                    "invites"
                 ],
-                "summary": "Clear all of the caller's squares in a contest",
+                "summary": "Create an invite link for a contest",
                 "parameters": [
                     {
                         "type": "string",
@@ -876,16 +1112,22 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Invite details",
+                        "name": "invite",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateInviteRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Square"
-                            }
+                            "$ref": "#/definitions/model.ContestInvite"
                         }
                     },
                     "400": {
@@ -906,6 +1148,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.APIError"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -915,21 +1163,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/contests/{id}/squares/{squareId}/claim": {
+        "/contests/{id}/invites/email": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Claims a square for the authenticated user using their profile default initials",
+                "description": "Creates a single-use invite bound to each address and emails it. Only a signed-in user whose verified email matches can redeem it. Delivery status starts as pending and moves to sent or failed, then opened and redeemed",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "contests"
+                    "invites"
                 ],
-                "summary": "Claim a single square in a contest",
+                "summary": "Invite people to a contest by email",
                 "parameters": [
                     {
                         "type": "string",
@@ -939,18 +1190,23 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Square ID",
-                        "name": "squareId",
-                        "in": "path",
-                        "required": true
+                        "description": "Recipients and invite details",
+                        "name": "invite",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateEmailInvitesRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Square"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ContestInvite"
+                            }
                         }
                     },
                     "400": {
@@ -986,24 +1242,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/contests/{id}/squares/{squareId}/clear": {
-            "post": {
+        "/contests/{id}/invites/{inviteId}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Clears a square's value and owner, making it available for anyone to claim",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Owner deletes an invite link",
                 "tags": [
-                    "contests"
+                    "invites"
                 ],
-                "summary": "Clear square value and owner",
+                "summary": "Delete an invite link",
                 "parameters": [
                     {
                         "type": "string",
@@ -1014,24 +1264,15 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Square ID",
-                        "name": "squareId",
+                        "description": "Invite ID",
+                        "name": "inviteId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.Square"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/model.APIError"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "403": {
                         "description": "Forbidden",
@@ -1052,23 +1293,24 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/contests/{id}/start": {
-            "post": {
+            },
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Starts the contest, transitioning from ACTIVE to Q1 and randomizing labels",
+                "description": "Owner adjusts an invite's role, square limit, usage limit or expiry, or pauses it without deleting it. Changes only apply to future redemptions",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "contests"
+                    "invites"
                 ],
-                "summary": "Start contest",
+                "summary": "Update an invite link",
                 "parameters": [
                     {
                         "type": "string",
@@ -1076,13 +1318,29 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Invite ID",
+                        "name": "inviteId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "invite",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateInviteRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.ContestSwagger"
+                            "$ref": "#/definitions/model.ContestInvite"
                         }
                     },
                     "400": {
@@ -1091,6 +1349,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.APIError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1106,110 +1370,106 @@ const docTemplate = `{
                 }
             }
         },
-        "/games/upcoming": {
+        "/contests/{id}/participants": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns NFL games available to link a contest to when creating one",
+                "description": "Returns all participants with their roles. Any participant can view. Public contests allow any authenticated user; payment status is only included for the owner",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "games"
+                    "participants"
+                ],
+                "summary": "Get all participants for a contest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get upcoming games",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.Game"
+                                "$ref": "#/definitions/model.ContestParticipant"
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/model.APIError"
                         }
-                    }
-                }
-            }
-        },
-        "/health/live": {
-            "get": {
-                "description": "Returns UP if the service process is running",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "health"
-                ],
-                "summary": "Liveness check",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/model.LivenessResponse"
+                            "$ref": "#/definitions/model.APIError"
                         }
-                    }
-                }
-            }
-        },
-        "/health/ready": {
-            "get": {
-                "description": "Checks database, NATS, and OIDC provider connectivity",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "health"
-                ],
-                "summary": "Readiness check",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/model.ReadinessResponse"
+                            "$ref": "#/definitions/model.APIError"
                         }
                     },
-                    "503": {
-                        "description": "Service Unavailable",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/model.ReadinessResponse"
+                            "$ref": "#/definitions/model.APIError"
                         }
                     }
                 }
-            }
-        },
-        "/invites/{token}": {
-            "get": {
-                "description": "Returns contest name and invite details without authentication",
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Owner adds one of their friends directly as a participant with a square limit, without an invite link",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "invites"
+                    "participants"
                 ],
-                "summary": "Preview an invite link",
+                "summary": "Add a friend to a contest",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Invite token",
-                        "name": "token",
+                        "description": "Contest ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Friend and square limit",
+                        "name": "participant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AddFriendParticipantRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.InvitePreviewResponse"
+                            "$ref": "#/definitions/model.ContestParticipant"
                         }
                     },
                     "400": {
@@ -1218,12 +1478,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.APIError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.APIError"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1233,35 +1505,51 @@ const docTemplate = `{
                 }
             }
         },
-        "/invites/{token}/redeem": {
+        "/contests/{id}/participants/import": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Authenticated user joins a contest via invite token",
+                "description": "Re-validates the uploaded CSV against the contest and, if every row is valid, adds and updates participants and claims their squares in one transaction. A file with row errors is rejected with the preview so the errors can be fixed",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "invites"
+                    "participants"
                 ],
-                "summary": "Redeem an invite link",
+                "summary": "Apply a participant CSV import",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Invite token",
-                        "name": "token",
+                        "description": "Contest ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client-generated key; retries with the same key replay the first response for 24h",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "file",
+                        "description": "CSV file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.ContestParticipant"
+                            "$ref": "#/definitions/model.ParticipantImportPreview"
                         }
                     },
                     "400": {
@@ -1270,6 +1558,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.APIError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1282,6 +1576,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.APIError"
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.ParticipantImportPreview"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1291,29 +1591,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/leaderboard": {
-            "get": {
-                "description": "Returns players ranked by quarter wins. Display names only, no emails",
+        "/contests/{id}/participants/import/preview": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Owner uploads a CSV with email, role and max_squares columns and optional row and col columns. Returns the participants that would be added or updated, the squares that would be claimed and any row errors, without changing anything",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "leaderboard"
+                    "participants"
                 ],
-                "summary": "Get the all-time leaderboard",
+                "summary": "Preview a participant CSV import",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Number of players to return (1-100, default 10)",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "CSV file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.LeaderboardResponse"
+                            "$ref": "#/definitions/model.ParticipantImportPreview"
                         }
                     },
                     "400": {
@@ -1322,6 +1638,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.APIError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1331,58 +1665,65 @@ const docTemplate = `{
                 }
             }
         },
-        "/leaderboard/me": {
-            "get": {
+        "/contests/{id}/participants/me/style": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns the authenticated user's rank, total ranked players, and quarter wins",
+                "description": "Participant sets a display value and a palette colour their squares use in this contest instead of their profile initials. Existing squares are restyled and keep the override when the profile default changes; the clear flags fall back to the defaults. A body that sets and clears nothing is rejected",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "leaderboard"
+                    "participants"
+                ],
+                "summary": "Set the caller's square style for a contest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Square style",
+                        "name": "style",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateSquareStyleRequest"
+                        }
+                    }
                 ],
-                "summary": "Get the current user's leaderboard rank",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.LeaderboardRankResponse"
+                            "$ref": "#/definitions/model.ContestParticipant"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/model.APIError"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.APIError"
                         }
-                    }
-                }
-            }
-        },
-        "/stats": {
-            "get": {
-                "description": "Returns public stats including contests created today, squares claimed today, and total active contests",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "stats"
-                ],
-                "summary": "Get platform stats",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/model.StatsResponse"
+                            "$ref": "#/definitions/model.APIError"
                         }
                     },
                     "500": {
@@ -1394,28 +1735,55 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/me": {
+        "/contests/{id}/participants/payments": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns the profile of the authenticated user, creating it on first access",
+                "description": "Owner only; tallies paid and unpaid square owners and the money collected",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "participants"
                 ],
-                "summary": "Get the current user's profile",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.UserProfileResponse"
-                        }
-                    },
+                "summary": "Get a contest's payment summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaymentSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1423,24 +1791,54 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/contests/{id}/participants/{userId}": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Anonymizes contest history under the ghost identity and deletes the account; blocked while the user owns or participates in any active contest",
+                "description": "Owner removes a participant, or a participant removes themselves; the removed participant's squares are cleared. The owner cannot be removed.",
                 "tags": [
-                    "users"
+                    "participants"
+                ],
+                "summary": "Remove a participant from a contest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Delete the current user's account",
                 "responses": {
                     "204": {
                         "description": "No Content"
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.APIError"
                         }
@@ -1459,7 +1857,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates the authenticated user's default initials and applies them to their squares in active contests",
+                "description": "Owner updates a participant's role or max squares",
                 "consumes": [
                     "application/json"
                 ],
@@ -1467,17 +1865,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "participants"
                 ],
-                "summary": "Update the current user's profile",
+                "summary": "Update a participant's role or square limit",
                 "parameters": [
                     {
-                        "description": "Profile",
-                        "name": "profile",
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update details",
+                        "name": "participant",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UpdateUserProfileRequest"
+                            "$ref": "#/definitions/model.UpdateParticipantRequest"
                         }
                     }
                 ],
@@ -1485,7 +1897,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UserProfileResponse"
+                            "$ref": "#/definitions/model.ContestParticipant"
                         }
                     },
                     "400": {
@@ -1494,38 +1906,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.APIError"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/model.APIError"
                         }
-                    }
-                }
-            }
-        },
-        "/users/me/active-contests": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Returns the non-terminal contests the user owns or participates in, which block account deletion",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "users"
-                ],
-                "summary": "Get the current user's active contests",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.UserActiveContest"
-                            }
+                            "$ref": "#/definitions/model.APIError"
                         }
                     },
                     "500": {
@@ -1537,26 +1927,72 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/me/stats": {
-            "get": {
+        "/contests/{id}/participants/{userId}/payment": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns contest and square stats for the authenticated user",
+                "description": "Owner marks a participant paid or unpaid and optionally records the amount. Marking unpaid resets the amount unless one is given. Allowed until the contest is deleted",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "participants"
+                ],
+                "summary": "Record a participant's payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment details",
+                        "name": "payment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdatePaymentRequest"
+                        }
+                    }
                 ],
-                "summary": "Get the current user's stats",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UserStatsResponse"
+                            "$ref": "#/definitions/model.ContestParticipant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
                         }
                     },
                     "500": {
@@ -1568,18 +2004,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/ws/contests/{id}": {
-            "get": {
+        "/contests/{id}/quarter-result": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Establishes a persistent WebSocket connection to receive real-time updates for a specific contest",
+                "description": "Records the score and winner for a specific quarter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "ws"
+                    "contests"
                 ],
-                "summary": "Connect to WebSocket for real-time contest updates",
+                "summary": "Record quarter result",
                 "parameters": [
                     {
                         "type": "string",
@@ -1587,13 +2029,34 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the contest version being scored",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client-generated key; retries with the same key replay the first response for 24h",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Quarter result data",
+                        "name": "quarterResult",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.QuarterResultRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "101": {
-                        "description": "WebSocket connection upgraded",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/model.QuarterResult"
                         }
                     },
                     "400": {
@@ -1602,12 +2065,36 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.APIError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.APIError"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContestConflictErrorSwagger"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContestConflictErrorSwagger"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1616,79 +2103,3392 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "model.APIError": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 400
-                },
-                "message": {
-                    "type": "string",
-                    "example": "invalid request"
+        },
+        "/contests/{id}/quarter-result/rollback": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes the most recently recorded quarter result and reverts the contest to the prior quarter. Only for manual (non game-linked) contests.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contests"
+                ],
+                "summary": "Roll back the last quarter result",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the contest version being rolled back",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.QuarterResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContestConflictErrorSwagger"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContestConflictErrorSwagger"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/contests/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Brings a deleted contest back in the state it was deleted from. Only the contest owner can restore, and only within the restore window",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contests"
+                ],
+                "summary": "Restore deleted contest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the deleted contest version being restored",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContestSwagger"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContestConflictErrorSwagger"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContestConflictErrorSwagger"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/contests/{id}/sheet.pdf": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Print-ready PDF with the grid, full owner names, numbers once drawn, the quarter results table and a payout summary once any quarter is scored. Anyone who can view the contest can fetch it",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "contests"
+                ],
+                "summary": "Get a printable contest sheet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous fetch",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "Sheet unchanged"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/contests/{id}/spectators": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the contest's spectator links, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "spectators"
+                ],
+                "summary": "Get spectator links for a contest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ContestSpectatorToken"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a revocable, optionally expiring read-only link for people without an account. It never makes anyone a participant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "spectators"
+                ],
+                "summary": "Create a spectator link for a contest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Spectator link details",
+                        "name": "spectator",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateSpectatorTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContestSpectatorToken"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/contests/{id}/spectators/{tokenId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes the link and closes any spectator connections using it",
+                "tags": [
+                    "spectators"
+                ],
+                "summary": "Revoke a spectator link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Spectator link ID",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/contests/{id}/squares/claim": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Claims every listed square for the authenticated user in one transaction. Either all squares are claimed or none are, and the total may not exceed the caller's square limit",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contests"
+                ],
+                "summary": "Claim several squares in a contest at once",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Squares to claim",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ClaimSquaresRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the contest version the squares were picked from",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Square"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContestConflictErrorSwagger"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/contests/{id}/squares/clear-mine": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Clears the value and owner of every square owned by the authenticated user. Only the caller's own squares are affected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contests"
+                ],
+                "summary": "Clear all of the caller's squares in a contest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the contest version being cleared",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Square"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContestConflictErrorSwagger"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/contests/{id}/squares/{squareId}/assign": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Gives a square to a participant on the owner's behalf. A square another participant holds is only replaced when override is set; otherwise 409. The participant's square limit still applies",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contests"
+                ],
+                "summary": "Assign a square to a participant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Square ID",
+                        "name": "squareId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Participant to receive the square",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AssignSquareRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the square version being assigned",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Square"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/contests/{id}/squares/{squareId}/claim": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Claims a square for the authenticated user using their profile default initials. Returns 409 if another participant claimed it first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contests"
+                ],
+                "summary": "Claim a single square in a contest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Square ID",
+                        "name": "squareId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the square version being claimed",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Square"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/contests/{id}/squares/{squareId}/clear": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Clears a square's value and owner, making it available for anyone to claim",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contests"
+                ],
+                "summary": "Clear square value and owner",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Square ID",
+                        "name": "squareId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the square version being cleared",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Square"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/contests/{id}/start": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Starts the contest, transitioning from ACTIVE to Q1 and randomizing labels. The payment policy runs first: require rejects the start while any square owner is unpaid, clear_unpaid frees their squares for the fill policy",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contests"
+                ],
+                "summary": "Start contest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the contest version being started",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContestSwagger"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContestConflictErrorSwagger"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContestConflictErrorSwagger"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/contests/{id}/swaps": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the pending swap requests the authenticated user has sent or received in a contest",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "swaps"
+                ],
+                "summary": "List pending square swaps",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.SquareSwap"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Offers one of the caller's squares in exchange for a square held by another participant. The swap only happens once the other participant accepts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "swaps"
+                ],
+                "summary": "Request a square swap",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Squares to exchange",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateSwapRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.SquareSwap"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/contests/{id}/swaps/{swapId}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Exchanges the two squares in a pending swap request. Only the recipient can accept, and only while the contest is still open",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "swaps"
+                ],
+                "summary": "Accept a square swap",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Swap ID",
+                        "name": "swapId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Square"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/contests/{id}/swaps/{swapId}/decline": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Declines a pending swap request when called by the recipient, or withdraws it when called by the requester",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "swaps"
+                ],
+                "summary": "Decline or withdraw a square swap",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Swap ID",
+                        "name": "swapId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SquareSwap"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/upcoming": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns NFL games available to link a contest to when creating one",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Get upcoming games",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Game"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/live": {
+            "get": {
+                "description": "Returns UP if the service process is running",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LivenessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "Checks database, NATS, and OIDC provider connectivity",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReadinessResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReadinessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/invites/{token}": {
+            "get": {
+                "description": "Returns contest name and invite details without authentication, including any domain restriction so users know before signing in",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invites"
+                ],
+                "summary": "Preview an invite link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invite token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.InvitePreviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/invites/{token}/open.gif": {
+            "get": {
+                "description": "Records the first open of a personal invite email. Always returns a 1x1 gif so nothing about the invite leaks",
+                "produces": [
+                    "image/gif"
+                ],
+                "tags": [
+                    "invites"
+                ],
+                "summary": "Invite email open tracking pixel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invite token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
+        "/invites/{token}/redeem": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Authenticated user joins a contest via invite token. Any squares the invite reserved are claimed for them in the same step",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invites"
+                ],
+                "summary": "Redeem an invite link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invite token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client-generated key; retries with the same key replay the first response for 24h",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContestParticipant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/leaderboard": {
+            "get": {
+                "description": "Returns players ranked by quarter wins. Display names only, no emails",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leaderboard"
+                ],
+                "summary": "Get the all-time leaderboard",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of players to return (1-100, default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LeaderboardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/leaderboard/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the authenticated user's rank, total ranked players, and quarter wins",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leaderboard"
+                ],
+                "summary": "Get the current user's leaderboard rank",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LeaderboardRankResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns every organization the authenticated user belongs to",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Get the caller's organizations",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Organization"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates an organization with the caller as its first admin. Admins can manage every contest the organization owns",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Create an organization",
+                "parameters": [
+                    {
+                        "description": "Organization details",
+                        "name": "organization",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateOrganizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Organization"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{orgId}/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the members of an organization. Only members can see the list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Get organization members",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "orgId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.OrganizationMember"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin adds a user to the organization as a member or admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Add an organization member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "orgId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Member details",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AddOrgMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrganizationMember"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{orgId}/members/{userId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin promotes a member to admin or demotes an admin. The last admin cannot be demoted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Change an organization member's role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "orgId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New role",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateOrgMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrganizationMember"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin removes a member, or a member leaves the organization. The last admin cannot be removed",
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Remove an organization member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "orgId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/spectate/{token}": {
+            "get": {
+                "description": "Returns the contest without authentication. Participant emails are removed; display names and initials remain",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "spectators"
+                ],
+                "summary": "View a contest through a spectator link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Spectator token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ContestSwagger"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats": {
+            "get": {
+                "description": "Returns public stats including contests created today, squares claimed today, and total active contests",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Get platform stats",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StatsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the profile of the authenticated user, creating it on first access",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get the current user's profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserProfileResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Anonymizes contest history under the ghost identity and deletes the account; blocked while the user owns or participates in any active contest",
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete the current user's account",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates any of the authenticated user's default initials, display name and profile visibility; initials and name changes apply to their squares in active contests",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update the current user's profile",
+                "parameters": [
+                    {
+                        "description": "Profile",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateUserProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/active-contests": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the non-terminal contests the user owns or participates in, which block account deletion",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get the current user's active contests",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.UserActiveContest"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/avatar": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the authenticated user's avatar with a png, jpeg, gif or webp image of at most 512KB",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Upload the current user's avatar",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Avatar image",
+                        "name": "avatar",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes the authenticated user's avatar",
+                "tags": [
+                    "users"
+                ],
+                "summary": "Remove the current user's avatar",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/friends": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns every accepted friend of the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friends"
+                ],
+                "summary": "Get the caller's friends",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Friend"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/friends/requests": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the friend requests the caller has received and the ones they are still waiting on",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friends"
+                ],
+                "summary": "Get pending friend requests",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.FriendRequestsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sends a friend request by email. If that user already asked the caller, their request is accepted instead",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friends"
+                ],
+                "summary": "Send a friend request",
+                "parameters": [
+                    {
+                        "description": "User to befriend",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.FriendRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Friendship"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/friends/requests/{userId}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Accepts the pending friend request the given user sent the caller",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friends"
+                ],
+                "summary": "Accept a friend request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Requester user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Friendship"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/friends/{userId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Unfriends a user, or declines or cancels a pending request with them",
+                "tags": [
+                    "friends"
+                ],
+                "summary": "Remove a friend",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Friend user ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns contest and square stats for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get the current user's stats",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserStatsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Searches the caller's friends and the people they share a contest with. Friends are listed first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friends"
+                ],
+                "summary": "Search users by display name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Display name to search for (2-50 characters)",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.UserSearchResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns another player's display name, avatar, lifetime stats and recent contests. Friends-only and private profiles are reported as not found to everyone they are hidden from",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get a user's public profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PublicProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/ws/contests/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Establishes a persistent WebSocket connection to receive real-time updates for a specific contest",
+                "tags": [
+                    "ws"
+                ],
+                "summary": "Connect to WebSocket for real-time contest updates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "WebSocket connection upgraded",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/ws/spectate/{token}": {
+            "get": {
+                "description": "Read-only WebSocket stream for people without an account. Chat and participant changes are not sent, participant emails are removed, and incoming messages are ignored. The connection closes when the link is revoked or expires",
+                "tags": [
+                    "ws"
+                ],
+                "summary": "Watch a contest through a spectator link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Spectator token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "WebSocket connection upgraded",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "model.APIError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "message": {
+                    "type": "string",
+                    "example": "invalid request"
+                },
+                "requestId": {
+                    "type": "string",
+                    "example": "7db692fa-c767-468f-af3d-9231b0f88c69"
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2025-10-05T13:45:00Z"
+                }
+            }
+        },
+        "model.AddFriendParticipantRequest": {
+            "type": "object",
+            "required": [
+                "maxSquares",
+                "userId"
+            ],
+            "properties": {
+                "maxSquares": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                },
+                "userId": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "model.AddOrgMemberRequest": {
+            "type": "object",
+            "required": [
+                "userId"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "member"
+                    ]
+                },
+                "userId": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "model.AssignSquareRequest": {
+            "type": "object",
+            "required": [
+                "userId"
+            ],
+            "properties": {
+                "override": {
+                    "type": "boolean"
+                },
+                "userId": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "model.BanParticipantRequest": {
+            "type": "object",
+            "required": [
+                "userId"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "squares": {
+                    "description": "empty clears before kickoff and ghosts after",
+                    "type": "string",
+                    "enum": [
+                        "ghost",
+                        "clear"
+                    ]
+                },
+                "userId": {
+                    "type": "string",
+                    "maxLength": 254
+                }
+            }
+        },
+        "model.ClaimSquaresRequest": {
+            "type": "object",
+            "required": [
+                "squareIds"
+            ],
+            "properties": {
+                "squareIds": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ContactRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "message",
+                "name",
+                "subject",
+                "turnstileToken"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "message": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "minLength": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "subject": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 1
+                },
+                "turnstileToken": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "minLength": 1
+                }
+            }
+        },
+        "model.ContactResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ContestAnalyticsResponse": {
+            "type": "object",
+            "properties": {
+                "contestId": {
+                    "type": "string"
+                },
+                "labelsAssigned": {
+                    "type": "boolean"
+                },
+                "sampleGames": {
+                    "type": "integer"
+                },
+                "squares": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SquareAnalyticsEntry"
+                    }
+                }
+            }
+        },
+        "model.ContestBan": {
+            "type": "object",
+            "properties": {
+                "bannedBy": {
+                    "type": "string"
+                },
+                "contestId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ContestConflictErrorSwagger": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "contest": {
+                    "$ref": "#/definitions/model.ContestSwagger"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "invalid request"
+                },
+                "requestId": {
+                    "type": "string",
+                    "example": "7db692fa-c767-468f-af3d-9231b0f88c69"
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2025-10-05T13:45:00Z"
+                }
+            }
+        },
+        "model.ContestExport": {
+            "type": "object",
+            "required": [
+                "contest",
+                "schemaVersion"
+            ],
+            "properties": {
+                "contest": {
+                    "$ref": "#/definitions/model.ExportedContest"
+                },
+                "exportedAt": {
+                    "type": "string"
+                },
+                "invites": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ExportedInvite"
+                    }
+                },
+                "participants": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "$ref": "#/definitions/model.ExportedParticipant"
+                    }
+                },
+                "quarterResults": {
+                    "type": "array",
+                    "maxItems": 4,
+                    "items": {
+                        "$ref": "#/definitions/model.ExportedQuarterResult"
+                    }
+                },
+                "schemaVersion": {
+                    "type": "integer"
+                },
+                "squares": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "$ref": "#/definitions/model.ExportedSquare"
+                    }
+                }
+            }
+        },
+        "model.ContestInvite": {
+            "type": "object",
+            "properties": {
+                "allowedDomains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "allowedEmails": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "contestId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "emailStatus": {
+                    "$ref": "#/definitions/model.InviteEmailStatus"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "funnel": {
+                    "$ref": "#/definitions/model.InviteFunnel"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "maxSquares": {
+                    "type": "integer"
+                },
+                "maxUses": {
+                    "type": "integer"
+                },
+                "openedAt": {
+                    "type": "string"
+                },
+                "paused": {
+                    "type": "boolean"
+                },
+                "redeemedAt": {
+                    "type": "string"
+                },
+                "reservedSquares": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SquarePosition"
+                    }
+                },
+                "role": {
+                    "$ref": "#/definitions/model.ParticipantRole"
+                },
+                "sentAt": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "uses": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ContestParticipant": {
+            "type": "object",
+            "properties": {
+                "amountPaidCents": {
+                    "type": "integer"
+                },
+                "color": {
+                    "type": "string"
+                },
+                "contestId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "displayValue": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "inviteId": {
+                    "type": "string"
+                },
+                "joinedAt": {
+                    "type": "string"
+                },
+                "maxSquares": {
+                    "type": "integer"
+                },
+                "paid": {
+                    "type": "boolean"
+                },
+                "paidAt": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/model.ParticipantRole"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ContestSpectatorToken": {
+            "type": "object",
+            "properties": {
+                "contestId": {
+                    "type": "string"
                 },
-                "requestId": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ContestSwagger": {
+            "type": "object",
+            "properties": {
+                "archivedAt": {
+                    "type": "string"
+                },
+                "awayTeam": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "fillPolicy": {
+                    "type": "string"
+                },
+                "homeTeam": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "importedAt": {
+                    "type": "string"
+                },
+                "lockAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "orgId": {
+                    "type": "string"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "paymentPolicy": {
+                    "type": "string"
+                },
+                "quarterResults": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.QuarterResult"
+                    }
+                },
+                "rollover": {
+                    "type": "boolean"
+                },
+                "squares": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Square"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "xLabels": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "yLabels": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "model.CreateContestRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "owner"
+            ],
+            "properties": {
+                "addOrgMembers": {
+                    "type": "boolean"
+                },
+                "awayTeam": {
                     "type": "string",
-                    "example": "7db692fa-c767-468f-af3d-9231b0f88c69"
+                    "maxLength": 20
                 },
-                "timestamp": {
+                "fillPolicy": {
                     "type": "string",
-                    "example": "2025-10-05T13:45:00Z"
+                    "enum": [
+                        "none",
+                        "random",
+                        "house",
+                        "rollover"
+                    ]
+                },
+                "gameId": {
+                    "type": "string"
+                },
+                "homeTeam": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "lockAt": {
+                    "type": "string"
+                },
+                "maxSquares": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "memberSquares": {
+                    "description": "per member when addOrgMembers is set; 0 adds them as viewers",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 1
+                },
+                "orgId": {
+                    "type": "string"
+                },
+                "owner": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "paymentPolicy": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "require",
+                        "clear_unpaid"
+                    ]
+                },
+                "rollover": {
+                    "type": "boolean"
+                },
+                "visibility": {
+                    "type": "string",
+                    "enum": [
+                        "private",
+                        "public"
+                    ]
+                }
+            }
+        },
+        "model.CreateEmailInvitesRequest": {
+            "type": "object",
+            "required": [
+                "emails",
+                "role"
+            ],
+            "properties": {
+                "emails": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "expiresIn": {
+                    "description": "minutes, 0 = no expiry",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "maxSquares": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "participant",
+                        "viewer"
+                    ]
+                }
+            }
+        },
+        "model.CreateInviteRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "allowedDomains": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "allowedEmails": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "expiresIn": {
+                    "description": "minutes, 0 = no expiry",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "maxSquares": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "maxUses": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "reservedSquares": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "$ref": "#/definitions/model.SquarePosition"
+                    }
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "participant",
+                        "viewer"
+                    ]
+                }
+            }
+        },
+        "model.CreateOrganizationRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 1
+                }
+            }
+        },
+        "model.CreateSpectatorTokenRequest": {
+            "type": "object",
+            "properties": {
+                "expiresIn": {
+                    "description": "minutes, 0 = no expiry",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "label": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "model.CreateSwapRequest": {
+            "type": "object",
+            "required": [
+                "fromSquareId",
+                "toSquareId"
+            ],
+            "properties": {
+                "fromSquareId": {
+                    "type": "string"
+                },
+                "toSquareId": {
+                    "type": "string"
                 }
             }
         },
-        "model.ContactRequest": {
+        "model.ExportedContest": {
             "type": "object",
             "required": [
-                "email",
-                "message",
                 "name",
-                "subject",
-                "turnstileToken"
+                "status"
             ],
             "properties": {
-                "email": {
+                "awayTeam": {
                     "type": "string",
-                    "maxLength": 255
+                    "maxLength": 20
                 },
-                "message": {
+                "fillPolicy": {
                     "type": "string",
-                    "maxLength": 2000,
-                    "minLength": 1
+                    "enum": [
+                        "none",
+                        "random",
+                        "house",
+                        "rollover"
+                    ]
+                },
+                "homeTeam": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lockAt": {
+                    "type": "string"
                 },
                 "name": {
                     "type": "string",
-                    "maxLength": 100,
+                    "maxLength": 20,
                     "minLength": 1
                 },
-                "subject": {
+                "owner": {
                     "type": "string",
-                    "maxLength": 200,
-                    "minLength": 1
+                    "maxLength": 255
                 },
-                "turnstileToken": {
+                "paymentPolicy": {
                     "type": "string",
-                    "maxLength": 2000,
-                    "minLength": 1
-                }
-            }
-        },
-        "model.ContactResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string"
+                    "enum": [
+                        "none",
+                        "require",
+                        "clear_unpaid"
+                    ]
+                },
+                "rollover": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "ACTIVE",
+                        "Q1",
+                        "Q2",
+                        "Q3",
+                        "Q4",
+                        "FINISHED"
+                    ]
+                },
+                "visibility": {
+                    "type": "string",
+                    "enum": [
+                        "private",
+                        "public"
+                    ]
+                },
+                "xLabels": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "yLabels": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         },
-        "model.ContestInvite": {
+        "model.ExportedInvite": {
             "type": "object",
             "properties": {
-                "contestId": {
-                    "type": "string"
+                "allowedDomains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "allowedEmails": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "createdAt": {
                     "type": "string"
@@ -1699,22 +5499,16 @@ const docTemplate = `{
                 "expiresAt": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
-                },
                 "maxSquares": {
                     "type": "integer"
                 },
                 "maxUses": {
                     "type": "integer"
                 },
-                "role": {
-                    "$ref": "#/definitions/model.ParticipantRole"
-                },
-                "token": {
-                    "type": "string"
+                "paused": {
+                    "type": "boolean"
                 },
-                "updatedAt": {
+                "role": {
                     "type": "string"
                 },
                 "uses": {
@@ -1722,167 +5516,200 @@ const docTemplate = `{
                 }
             }
         },
-        "model.ContestParticipant": {
+        "model.ExportedParticipant": {
             "type": "object",
+            "required": [
+                "role",
+                "userId"
+            ],
             "properties": {
-                "contestId": {
-                    "type": "string"
-                },
-                "createdAt": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "inviteId": {
-                    "type": "string"
+                "amountPaidCents": {
+                    "type": "integer",
+                    "maximum": 100000000,
+                    "minimum": 0
                 },
                 "joinedAt": {
                     "type": "string"
                 },
                 "maxSquares": {
-                    "type": "integer"
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
                 },
-                "role": {
-                    "$ref": "#/definitions/model.ParticipantRole"
+                "paid": {
+                    "type": "boolean"
                 },
-                "updatedAt": {
+                "paidAt": {
                     "type": "string"
                 },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "participant",
+                        "viewer"
+                    ]
+                },
                 "userId": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 255
                 }
             }
         },
-        "model.ContestSwagger": {
+        "model.ExportedQuarterResult": {
             "type": "object",
             "properties": {
-                "awayTeam": {
-                    "type": "string"
-                },
-                "createdAt": {
-                    "type": "string"
-                },
-                "createdBy": {
-                    "type": "string"
-                },
-                "homeTeam": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
+                "awayTeamScore": {
+                    "type": "integer",
+                    "maximum": 9999,
+                    "minimum": 0
                 },
-                "name": {
-                    "type": "string"
+                "carriedOver": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "owner": {
-                    "type": "string"
+                "fallback": {
+                    "type": "boolean"
                 },
-                "quarterResults": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.QuarterResult"
-                    }
+                "homeTeamScore": {
+                    "type": "integer",
+                    "maximum": 9999,
+                    "minimum": 0
                 },
-                "squares": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.Square"
-                    }
+                "quarter": {
+                    "type": "integer",
+                    "maximum": 4,
+                    "minimum": 1
                 },
-                "status": {
-                    "type": "string"
+                "rolledOver": {
+                    "type": "boolean"
                 },
-                "updatedAt": {
-                    "type": "string"
+                "winner": {
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "updatedBy": {
-                    "type": "string"
+                "winnerCol": {
+                    "type": "integer",
+                    "maximum": 9,
+                    "minimum": -1
                 },
-                "xLabels": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "winnerName": {
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "yLabels": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "winnerRow": {
+                    "type": "integer",
+                    "maximum": 9,
+                    "minimum": -1
                 }
             }
         },
-        "model.CreateContestRequest": {
+        "model.ExportedSquare": {
             "type": "object",
-            "required": [
-                "name",
-                "owner"
-            ],
             "properties": {
-                "awayTeam": {
-                    "type": "string",
-                    "maxLength": 20
+                "col": {
+                    "type": "integer",
+                    "maximum": 9,
+                    "minimum": 0
                 },
-                "gameId": {
-                    "type": "string"
+                "owner": {
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "homeTeam": {
+                "ownerName": {
                     "type": "string",
-                    "maxLength": 20
+                    "maxLength": 255
                 },
-                "maxSquares": {
+                "row": {
                     "type": "integer",
-                    "maximum": 100,
+                    "maximum": 9,
                     "minimum": 0
                 },
-                "name": {
+                "value": {
                     "type": "string",
-                    "maxLength": 20,
-                    "minLength": 1
-                },
-                "owner": {
+                    "maxLength": 3
+                }
+            }
+        },
+        "model.Friend": {
+            "type": "object",
+            "properties": {
+                "displayName": {
                     "type": "string",
-                    "maxLength": 255
+                    "example": "Max"
                 },
-                "visibility": {
+                "since": {
+                    "type": "string"
+                },
+                "userId": {
                     "type": "string",
-                    "enum": [
-                        "private",
-                        "public"
-                    ]
+                    "example": "friend@example.com"
+                }
+            }
+        },
+        "model.FriendRequest": {
+            "type": "object",
+            "required": [
+                "userId"
+            ],
+            "properties": {
+                "userId": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "model.FriendRequestsResponse": {
+            "type": "object",
+            "properties": {
+                "incoming": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Friendship"
+                    }
+                },
+                "outgoing": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Friendship"
+                    }
                 }
             }
         },
-        "model.CreateInviteRequest": {
+        "model.Friendship": {
             "type": "object",
-            "required": [
-                "role"
-            ],
             "properties": {
-                "expiresIn": {
-                    "description": "minutes, 0 = no expiry",
-                    "type": "integer",
-                    "minimum": 0
+                "acceptedAt": {
+                    "type": "string"
                 },
-                "maxSquares": {
-                    "type": "integer",
-                    "maximum": 100,
-                    "minimum": 0
+                "addressee": {
+                    "type": "string"
                 },
-                "maxUses": {
-                    "type": "integer",
-                    "minimum": 0
+                "createdAt": {
+                    "type": "string"
                 },
-                "role": {
-                    "type": "string",
-                    "enum": [
-                        "participant",
-                        "viewer"
-                    ]
+                "id": {
+                    "type": "string"
+                },
+                "requester": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.FriendshipStatus"
                 }
             }
         },
+        "model.FriendshipStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "accepted"
+            ],
+            "x-enum-varnames": [
+                "FriendshipStatusPending",
+                "FriendshipStatusAccepted"
+            ]
+        },
         "model.Game": {
             "type": "object",
             "properties": {
@@ -1981,21 +5808,92 @@ const docTemplate = `{
                 "GameStatusFinal"
             ]
         },
+        "model.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "line": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.InviteEmailStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "sent",
+                "failed",
+                "opened",
+                "redeemed"
+            ],
+            "x-enum-varnames": [
+                "InviteEmailStatusPending",
+                "InviteEmailStatusSent",
+                "InviteEmailStatusFailed",
+                "InviteEmailStatusOpened",
+                "InviteEmailStatusRedeemed"
+            ]
+        },
+        "model.InviteFunnel": {
+            "type": "object",
+            "properties": {
+                "conversionRate": {
+                    "type": "number"
+                },
+                "firstViewedAt": {
+                    "type": "string"
+                },
+                "lastRedeemedAt": {
+                    "type": "string"
+                },
+                "lastViewedAt": {
+                    "type": "string"
+                },
+                "redemptions": {
+                    "type": "integer"
+                },
+                "views": {
+                    "type": "integer"
+                }
+            }
+        },
         "model.InvitePreviewResponse": {
             "type": "object",
             "properties": {
+                "allowedDomains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "contestId": {
                     "type": "string"
                 },
                 "contestName": {
                     "type": "string"
                 },
+                "email": {
+                    "description": "set when the invite is bound to one recipient",
+                    "type": "string"
+                },
                 "maxSquares": {
                     "type": "integer"
                 },
                 "owner": {
                     "type": "string"
                 },
+                "reservedSquares": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SquarePosition"
+                    }
+                },
+                "restricted": {
+                    "description": "the allowed address list itself stays private",
+                    "type": "boolean"
+                },
                 "role": {
                     "type": "string"
                 }
@@ -2062,58 +5960,261 @@ const docTemplate = `{
             "type": "object",
             "properties": {
                 "status": {
-                    "type": "string"
+                    "type": "string"
+                }
+            }
+        },
+        "model.OrgRole": {
+            "type": "string",
+            "enum": [
+                "admin",
+                "member"
+            ],
+            "x-enum-varnames": [
+                "OrgRoleAdmin",
+                "OrgRoleMember"
+            ]
+        },
+        "model.Organization": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.OrganizationMember": {
+            "type": "object",
+            "properties": {
+                "addedBy": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "orgId": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/model.OrgRole"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PaginatedContestResponseSwagger": {
+            "type": "object",
+            "properties": {
+                "contests": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ContestSwagger"
+                    }
+                },
+                "hasNext": {
+                    "type": "boolean"
+                },
+                "hasPrevious": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "totalPages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ParticipantImportAction": {
+            "type": "string",
+            "enum": [
+                "add",
+                "update",
+                "unchanged"
+            ],
+            "x-enum-varnames": [
+                "ParticipantImportAdd",
+                "ParticipantImportUpdate",
+                "ParticipantImportUnchanged"
+            ]
+        },
+        "model.ParticipantImportChange": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/model.ParticipantImportAction"
+                },
+                "maxSquares": {
+                    "type": "integer"
+                },
+                "previousMaxSquares": {
+                    "type": "integer"
+                },
+                "previousRole": {
+                    "$ref": "#/definitions/model.ParticipantRole"
+                },
+                "role": {
+                    "$ref": "#/definitions/model.ParticipantRole"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ParticipantImportPreview": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ImportRowError"
+                    }
+                },
+                "participants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ParticipantImportChange"
+                    }
+                },
+                "squares": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SquareImportClaim"
+                    }
+                },
+                "totalAllocated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ParticipantRole": {
+            "type": "string",
+            "enum": [
+                "owner",
+                "participant",
+                "viewer"
+            ],
+            "x-enum-varnames": [
+                "ParticipantRoleOwner",
+                "ParticipantRoleParticipant",
+                "ParticipantRoleViewer"
+            ]
+        },
+        "model.PaymentSummary": {
+            "type": "object",
+            "properties": {
+                "collectedCents": {
+                    "type": "integer"
+                },
+                "paid": {
+                    "type": "integer"
+                },
+                "squareOwners": {
+                    "type": "integer"
+                },
+                "unpaid": {
+                    "type": "integer"
+                },
+                "unpaidSquares": {
+                    "type": "integer"
+                },
+                "unpaidUserIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.PublicProfileContest": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string",
+                    "example": "2026-07-11T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "test"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "participant"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "FINISHED"
                 }
             }
         },
-        "model.PaginatedContestResponseSwagger": {
+        "model.PublicProfileResponse": {
             "type": "object",
             "properties": {
-                "contests": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.ContestSwagger"
-                    }
-                },
-                "hasNext": {
-                    "type": "boolean"
+                "avatarUrl": {
+                    "type": "string",
+                    "example": "/users/550e8400-e29b-41d4-a716-446655440000/avatar?v=1783728000"
                 },
-                "hasPrevious": {
-                    "type": "boolean"
+                "displayName": {
+                    "type": "string",
+                    "example": "Max"
                 },
-                "limit": {
-                    "type": "integer"
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "page": {
-                    "type": "integer"
+                "memberSince": {
+                    "type": "string",
+                    "example": "2026-07-11T00:00:00Z"
                 },
-                "total": {
-                    "type": "integer"
+                "recentContests": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PublicProfileContest"
+                    }
                 },
-                "totalPages": {
-                    "type": "integer"
+                "stats": {
+                    "$ref": "#/definitions/model.UserStatsResponse"
                 }
             }
         },
-        "model.ParticipantRole": {
-            "type": "string",
-            "enum": [
-                "owner",
-                "participant",
-                "viewer"
-            ],
-            "x-enum-varnames": [
-                "ParticipantRoleOwner",
-                "ParticipantRoleParticipant",
-                "ParticipantRoleViewer"
-            ]
-        },
         "model.QuarterResult": {
             "type": "object",
             "properties": {
                 "awayTeamScore": {
                     "type": "integer"
                 },
+                "carriedOver": {
+                    "type": "integer"
+                },
                 "contestId": {
                     "type": "string"
                 },
@@ -2123,6 +6224,9 @@ const docTemplate = `{
                 "createdBy": {
                     "type": "string"
                 },
+                "fallback": {
+                    "type": "boolean"
+                },
                 "homeTeamScore": {
                     "type": "integer"
                 },
@@ -2132,6 +6236,9 @@ const docTemplate = `{
                 "quarter": {
                     "type": "integer"
                 },
+                "rolledOver": {
+                    "type": "boolean"
+                },
                 "updatedAt": {
                     "type": "string"
                 },
@@ -2187,9 +6294,15 @@ const docTemplate = `{
         "model.Square": {
             "type": "object",
             "properties": {
+                "analytics": {
+                    "$ref": "#/definitions/model.SquareAnalytics"
+                },
                 "col": {
                     "type": "integer"
                 },
+                "color": {
+                    "type": "string"
+                },
                 "contestId": {
                     "type": "string"
                 },
@@ -2208,6 +6321,9 @@ const docTemplate = `{
                 "ownerName": {
                     "type": "string"
                 },
+                "reservedInviteId": {
+                    "type": "string"
+                },
                 "row": {
                     "type": "integer"
                 },
@@ -2219,6 +6335,113 @@ const docTemplate = `{
                 },
                 "value": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.SquareAnalytics": {
+            "type": "object",
+            "properties": {
+                "expectedPayout": {
+                    "type": "number"
+                },
+                "expectedWins": {
+                    "type": "number"
+                },
+                "winProbabilities": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "model.SquareAnalyticsEntry": {
+            "type": "object",
+            "properties": {
+                "analytics": {
+                    "$ref": "#/definitions/model.SquareAnalytics"
+                },
+                "awayDigit": {
+                    "type": "integer"
+                },
+                "col": {
+                    "type": "integer"
+                },
+                "homeDigit": {
+                    "type": "integer"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                },
+                "squareId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.SquareImportClaim": {
+            "type": "object",
+            "properties": {
+                "col": {
+                    "type": "integer"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.SquarePosition": {
+            "type": "object",
+            "properties": {
+                "col": {
+                    "type": "integer",
+                    "maximum": 9,
+                    "minimum": 0
+                },
+                "row": {
+                    "type": "integer",
+                    "maximum": 9,
+                    "minimum": 0
+                }
+            }
+        },
+        "model.SquareSwap": {
+            "type": "object",
+            "properties": {
+                "contestId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "fromSquareId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "recipient": {
+                    "type": "string"
+                },
+                "requester": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.SwapStatus"
+                },
+                "toSquareId": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
                 }
             }
         },
@@ -2239,6 +6462,21 @@ const docTemplate = `{
                 }
             }
         },
+        "model.SwapStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "accepted",
+                "declined",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "SwapStatusPending",
+                "SwapStatusAccepted",
+                "SwapStatusDeclined",
+                "SwapStatusCancelled"
+            ]
+        },
         "model.UpdateContestRequest": {
             "type": "object",
             "properties": {
@@ -2246,10 +6484,36 @@ const docTemplate = `{
                     "type": "string",
                     "maxLength": 20
                 },
+                "clearLockAt": {
+                    "type": "boolean"
+                },
+                "fillPolicy": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "random",
+                        "house",
+                        "rollover"
+                    ]
+                },
                 "homeTeam": {
                     "type": "string",
                     "maxLength": 20
                 },
+                "lockAt": {
+                    "type": "string"
+                },
+                "paymentPolicy": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "require",
+                        "clear_unpaid"
+                    ]
+                },
+                "rollover": {
+                    "type": "boolean"
+                },
                 "visibility": {
                     "type": "string",
                     "enum": [
@@ -2259,6 +6523,50 @@ const docTemplate = `{
                 }
             }
         },
+        "model.UpdateInviteRequest": {
+            "type": "object",
+            "properties": {
+                "expiresIn": {
+                    "description": "minutes from now, 0 = no expiry",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "maxSquares": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "maxUses": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "paused": {
+                    "type": "boolean"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "participant",
+                        "viewer"
+                    ]
+                }
+            }
+        },
+        "model.UpdateOrgMemberRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "member"
+                    ]
+                }
+            }
+        },
         "model.UpdateParticipantRequest": {
             "type": "object",
             "properties": {
@@ -2276,16 +6584,73 @@ const docTemplate = `{
                 }
             }
         },
-        "model.UpdateUserProfileRequest": {
+        "model.UpdatePaymentRequest": {
             "type": "object",
             "required": [
-                "defaultInitials"
+                "paid"
             ],
+            "properties": {
+                "amountPaidCents": {
+                    "type": "integer",
+                    "maximum": 100000000,
+                    "minimum": 0
+                },
+                "paid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.UpdateSquareStyleRequest": {
+            "type": "object",
+            "properties": {
+                "clearColor": {
+                    "type": "boolean"
+                },
+                "clearDisplayValue": {
+                    "type": "boolean"
+                },
+                "color": {
+                    "type": "string",
+                    "enum": [
+                        "red",
+                        "orange",
+                        "amber",
+                        "green",
+                        "teal",
+                        "blue",
+                        "indigo",
+                        "purple",
+                        "pink",
+                        "slate"
+                    ]
+                },
+                "displayValue": {
+                    "type": "string",
+                    "maxLength": 3,
+                    "minLength": 1
+                }
+            }
+        },
+        "model.UpdateUserProfileRequest": {
+            "type": "object",
             "properties": {
                 "defaultInitials": {
                     "type": "string",
                     "maxLength": 3,
                     "minLength": 1
+                },
+                "displayName": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 1
+                },
+                "profileVisibility": {
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "friends",
+                        "private"
+                    ]
                 }
             }
         },
@@ -2313,6 +6678,10 @@ const docTemplate = `{
         "model.UserProfileResponse": {
             "type": "object",
             "properties": {
+                "avatarUrl": {
+                    "type": "string",
+                    "example": "/users/550e8400-e29b-41d4-a716-446655440000/avatar?v=1783728000"
+                },
                 "createdAt": {
                     "type": "string",
                     "example": "2026-07-11T00:00:00Z"
@@ -2328,6 +6697,30 @@ const docTemplate = `{
                 "email": {
                     "type": "string",
                     "example": "user@example.com"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "profileVisibility": {
+                    "type": "string",
+                    "example": "friends"
+                }
+            }
+        },
+        "model.UserSearchResult": {
+            "type": "object",
+            "properties": {
+                "displayName": {
+                    "type": "string",
+                    "example": "Max"
+                },
+                "friend": {
+                    "type": "boolean"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "friend@example.com"
                 }
             }
         },
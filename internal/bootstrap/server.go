@@ -39,20 +39,33 @@ func setupRoutes(r *gin.Engine, deps *Dependencies) {
 	contestRepo := repository.NewContestRepository(db)
 	contactRepo := repository.NewContactRepository(db)
 	inviteRepo := repository.NewInviteRepository(db)
+	spectatorRepo := repository.NewSpectatorRepository(db)
 	participantRepo := repository.NewParticipantRepository(db)
 	gameRepo := repository.NewGameRepository(db)
+	orgRepo := repository.NewOrganizationRepository(db)
+	friendRepo := repository.NewFriendRepository(db)
 
 	userRepo := repository.NewUserRepository(db)
+	idempotencyRepo := repository.NewIdempotencyRepository(db)
 
 	natsService := service.NewNatsService(deps.NATS)
-	userService := service.NewUserService(userRepo, natsService, deps.OIDCVerifier)
+	userService := service.NewUserService(userRepo, friendRepo, repository.NewPostgresAvatarStore(db), natsService, deps.OIDCVerifier)
+	idempotencyService := service.NewIdempotencyService(idempotencyRepo)
 
 	participantService := service.NewParticipantService(participantRepo, contestRepo, natsService)
-	contestService := service.NewContestService(contestRepo, participantRepo, gameRepo, userRepo, natsService, participantService)
-	gameService := service.NewGameService(gameRepo, contestRepo, natsService)
-	wsService := service.NewWebSocketService(deps.NATS, userService, participantService)
+	analyticsService := service.NewAnalyticsService(contestRepo, gameRepo, participantService)
+	contestService := service.NewContestService(contestRepo, participantRepo, gameRepo, userRepo, orgRepo, natsService, participantService, analyticsService, deps.Config.Lifecycle)
+	gameService := service.NewGameService(gameRepo, contestRepo, participantRepo, userRepo, natsService, analyticsService)
+	spectatorService := service.NewSpectatorService(spectatorRepo, contestRepo, participantService, natsService)
+	wsService := service.NewWebSocketService(deps.NATS, userService, participantService, spectatorService)
 	contactService := service.NewContactService(contactRepo, deps.Config)
-	inviteService := service.NewInviteService(inviteRepo, participantRepo, contestRepo, participantService, natsService)
+	swapService := service.NewSwapService(contestRepo, participantService, natsService)
+	inviteService := service.NewInviteService(inviteRepo, participantRepo, contestRepo, userRepo, participantService, natsService, service.NewSMTPMailer(deps.Config.SMTP), deps.Config.Server)
+	exportService := service.NewExportService(contestRepo, participantRepo, inviteRepo, participantService)
+	boardService := service.NewBoardService(contestRepo, participantService)
+	orgService := service.NewOrganizationService(orgRepo)
+	friendService := service.NewFriendService(friendRepo, userRepo, contestRepo, participantRepo, participantService, natsService)
+	participantImportService := service.NewParticipantImportService(contestRepo, participantRepo, userRepo, participantService, natsService)
 
 	statsRepo := repository.NewStatsRepository(db)
 	statsService := service.NewStatsService(statsRepo)
@@ -61,13 +74,21 @@ func setupRoutes(r *gin.Engine, deps *Dependencies) {
 	leaderboardService := service.NewLeaderboardService(leaderboardRepo)
 
 	contestHandler := handler.NewContestHandler(contestService)
-	wsHandler := handler.NewWebSocketHandler(wsService, contestRepo, participantService, deps.Config.Server.AllowedOrigins, deps.NATS)
+	wsHandler := handler.NewWebSocketHandler(wsService, contestRepo, participantService, spectatorService, deps.Config.Server.AllowedOrigins, deps.NATS)
 	contactHandler := handler.NewContactHandler(contactService)
 	statsHandler := handler.NewStatsHandler(statsService)
 	leaderboardHandler := handler.NewLeaderboardHandler(leaderboardService)
 	inviteHandler := handler.NewInviteHandler(inviteService)
+	spectatorHandler := handler.NewSpectatorHandler(spectatorService)
+	analyticsHandler := handler.NewAnalyticsHandler(analyticsService)
+	swapHandler := handler.NewSwapHandler(swapService)
+	exportHandler := handler.NewExportHandler(exportService)
+	boardHandler := handler.NewBoardHandler(boardService)
+	orgHandler := handler.NewOrganizationHandler(orgService)
+	friendHandler := handler.NewFriendHandler(friendService)
 	gameHandler := handler.NewGameHandler(gameService)
 	participantHandler := handler.NewParticipantHandler(participantService)
+	participantImportHandler := handler.NewParticipantImportHandler(participantImportService)
 	userHandler := handler.NewUserHandler(userService)
 	healthHandler := handler.NewHealthHandler(db, deps.NATS, deps.OIDCVerifier)
 
@@ -75,16 +96,30 @@ func setupRoutes(r *gin.Engine, deps *Dependencies) {
 	routes.RegisterStatsRoutes(r.Group("/stats"), statsHandler)
 	routes.RegisterLeaderboardRoutes(r.Group("/leaderboard"), leaderboardHandler, userService)
 	routes.RegisterContactRoute(r.Group("/contact"), contactHandler, deps.Config.Server.ContactRateLimit)
-	routes.RegisterContestRoutes(r.Group("/contests"), contestHandler, userService)
+	routes.RegisterContestRoutes(r.Group("/contests"), contestHandler, userService, idempotencyService)
 	routes.RegisterWebSocketRoutes(r.Group("/ws"), wsHandler, userService)
 
-	routes.RegisterInviteRoutes(r.Group("/invites"), inviteHandler, userService)
+	routes.RegisterInviteRoutes(r.Group("/invites"), inviteHandler, userService, idempotencyService)
 	routes.RegisterContestInviteRoutes(r.Group("/contests/:id/invites"), inviteHandler, userService)
+	routes.RegisterSpectatorRoutes(r.Group("/spectate"), spectatorHandler)
+	routes.RegisterContestSpectatorRoutes(r.Group("/contests/:id/spectators"), spectatorHandler, userService)
+	routes.RegisterAnalyticsRoutes(r.Group("/contests/:id/analytics"), analyticsHandler, userService)
+	routes.RegisterSwapRoutes(r.Group("/contests/:id/swaps"), swapHandler, userService)
+	routes.RegisterExportRoutes(r.Group("/contests"), exportHandler, userService, idempotencyService)
+	routes.RegisterBoardRoutes(r.Group("/contests"), boardHandler, userService)
 
 	routes.RegisterGameRoutes(r.Group("/games"), gameHandler, userService)
+	routes.RegisterOrganizationRoutes(r.Group("/organizations"), orgHandler, userService)
 
 	routes.RegisterMyContestsRoute(r.Group("/contests/me"), participantHandler, userService)
 	routes.RegisterParticipantRoutes(r.Group("/contests/:id/participants"), participantHandler, userService)
+	routes.RegisterParticipantImportRoutes(r.Group("/contests/:id/participants"), participantImportHandler, userService, idempotencyService)
+	routes.RegisterContestFriendRoutes(r.Group("/contests/:id/participants"), friendHandler, userService)
+	routes.RegisterBanRoutes(r.Group("/contests/:id/bans"), participantHandler, userService)
 
 	routes.RegisterUserRoutes(r.Group("/users/me"), userHandler, userService)
+	routes.RegisterFriendRoutes(r.Group("/users/me/friends"), friendHandler, userService)
+	routes.RegisterUserSearchRoute(r.Group("/users/search"), friendHandler, userService)
+	routes.RegisterPublicUserRoutes(r.Group("/users"), userHandler, userService)
+	routes.RegisterAvatarRoutes(r.Group("/avatars"), userHandler)
 }
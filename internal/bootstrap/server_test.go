@@ -31,15 +31,54 @@ func TestNewServer_WiresRoutesWithoutInfra(t *testing.T) {
 		"POST /contact",
 		"PUT /contests",
 		"GET /contests/owner/:owner",
+		"GET /contests/:id",
+		"POST /contests/:id/restore",
+		"GET /contests/:id/export",
+		"POST /contests/import",
+		"GET /contests/:id/board.png",
+		"GET /contests/:id/board.svg",
+		"GET /contests/:id/sheet.pdf",
 		"GET /contests/me",
 		"GET /contests/:id/participants",
+		"GET /contests/:id/participants/payments",
+		"POST /contests/:id/participants",
+		"PUT /contests/:id/participants/:userId/payment",
+		"PUT /contests/:id/participants/me/style",
+		"POST /contests/:id/participants/import/preview",
+		"POST /contests/:id/participants/import",
+		"POST /contests/:id/bans",
+		"GET /contests/:id/bans",
+		"DELETE /contests/:id/bans/:userId",
 		"POST /contests/:id/invites",
+		"POST /contests/:id/invites/email",
+		"PATCH /contests/:id/invites/:inviteId",
 		"GET /invites/:token",
+		"GET /invites/:token/open.gif",
+		"GET /spectate/:token",
+		"POST /contests/:id/spectators",
+		"DELETE /contests/:id/spectators/:tokenId",
+		"POST /organizations",
+		"GET /organizations",
+		"GET /organizations/:orgId/members",
+		"POST /organizations/:orgId/members",
+		"PUT /organizations/:orgId/members/:userId",
+		"DELETE /organizations/:orgId/members/:userId",
 		"GET /ws/contests/:id",
+		"GET /ws/spectate/:token",
 		"GET /users/me",
 		"DELETE /users/me",
 		"GET /users/me/stats",
 		"GET /users/me/active-contests",
+		"GET /users/me/friends",
+		"GET /users/me/friends/requests",
+		"POST /users/me/friends/requests",
+		"POST /users/me/friends/requests/:userId/accept",
+		"DELETE /users/me/friends/:userId",
+		"GET /users/search",
+		"PUT /users/me/avatar",
+		"DELETE /users/me/avatar",
+		"GET /users/:id",
+		"GET /avatars/:key",
 	}
 
 	for _, route := range expected {
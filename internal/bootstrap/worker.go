@@ -19,8 +19,11 @@ func StartScoresWorker(ctx context.Context, deps *Dependencies) {
 
 	gameRepo := repository.NewGameRepository(deps.DB)
 	contestRepo := repository.NewContestRepository(deps.DB)
+	participantRepo := repository.NewParticipantRepository(deps.DB)
+	userRepo := repository.NewUserRepository(deps.DB)
 	natsService := service.NewNatsService(deps.NATS)
-	gameService := service.NewGameService(gameRepo, contestRepo, natsService)
+	analyticsService := service.NewAnalyticsService(contestRepo, gameRepo, service.NewParticipantService(participantRepo, contestRepo, natsService))
+	gameService := service.NewGameService(gameRepo, contestRepo, participantRepo, userRepo, natsService, analyticsService)
 
 	runner := worker.NewRunner(deps.DB, gameService, cfg)
 
@@ -29,3 +32,28 @@ func StartScoresWorker(ctx context.Context, deps *Dependencies) {
 
 	slog.Info("scores worker started", "active_interval", cfg.ActiveInterval, "idle_interval", cfg.IdleInterval)
 }
+
+func StartLifecycleWorker(ctx context.Context, deps *Dependencies) {
+	cfg := deps.Config.Lifecycle
+	if !cfg.Enabled {
+		slog.Info("lifecycle worker disabled")
+		return
+	}
+
+	contestRepo := repository.NewContestRepository(deps.DB)
+	participantRepo := repository.NewParticipantRepository(deps.DB)
+	gameRepo := repository.NewGameRepository(deps.DB)
+	userRepo := repository.NewUserRepository(deps.DB)
+	natsService := service.NewNatsService(deps.NATS)
+	participantService := service.NewParticipantService(participantRepo, contestRepo, natsService)
+	analyticsService := service.NewAnalyticsService(contestRepo, gameRepo, participantService)
+	contestService := service.NewContestService(contestRepo, participantRepo, gameRepo, userRepo, repository.NewOrganizationRepository(deps.DB), natsService, participantService, analyticsService, cfg)
+	idempotencyService := service.NewIdempotencyService(repository.NewIdempotencyRepository(deps.DB))
+
+	runner := worker.NewLifecycleRunner(deps.DB, contestService, idempotencyService, cfg)
+
+	ctx = util.ContextWithLogger(ctx, slog.Default().With("component", "lifecycle-worker"))
+	runner.Start(ctx)
+
+	slog.Info("lifecycle worker started", "interval", cfg.Interval)
+}
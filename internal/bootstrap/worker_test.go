@@ -49,3 +49,36 @@ func TestStartScoresWorker_Enabled(t *testing.T) {
 	// let the background goroutines observe cancellation and return
 	time.Sleep(100 * time.Millisecond)
 }
+
+func TestStartLifecycleWorker_Disabled(t *testing.T) {
+	deps := &Dependencies{Config: &model.AppConfig{}}
+	deps.Config.Lifecycle.Enabled = false
+
+	assert.NotPanics(t, func() {
+		StartLifecycleWorker(context.Background(), deps)
+	})
+}
+
+func TestStartLifecycleWorker_Enabled(t *testing.T) {
+	sqlDB, _, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB, PreferSimpleProtocol: true}),
+		&gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	deps := &Dependencies{Config: &model.AppConfig{}, DB: gdb}
+	deps.Config.Lifecycle.Enabled = true
+	deps.Config.Lifecycle.Interval = time.Hour
+
+	// a cancelled context makes the loop start and then exit before locking anything
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.NotPanics(t, func() {
+		StartLifecycleWorker(ctx, deps)
+	})
+
+	time.Sleep(100 * time.Millisecond)
+}
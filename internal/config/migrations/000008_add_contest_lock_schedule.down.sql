@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_contests_lock_at;
+
+ALTER TABLE contests DROP COLUMN IF EXISTS lock_at;
@@ -0,0 +1,3 @@
+ALTER TABLE contests ADD COLUMN IF NOT EXISTS lock_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS idx_contests_lock_at ON contests (lock_at) WHERE lock_at IS NOT NULL AND status = 'ACTIVE';
@@ -0,0 +1 @@
+ALTER TABLE contests DROP COLUMN IF EXISTS fill_policy;
@@ -0,0 +1 @@
+ALTER TABLE contests ADD COLUMN IF NOT EXISTS fill_policy text NOT NULL DEFAULT 'none';
@@ -0,0 +1,5 @@
+ALTER TABLE quarter_results DROP COLUMN IF EXISTS fallback;
+ALTER TABLE quarter_results DROP COLUMN IF EXISTS carried_over;
+ALTER TABLE quarter_results DROP COLUMN IF EXISTS rolled_over;
+
+ALTER TABLE contests DROP COLUMN IF EXISTS rollover;
@@ -0,0 +1,5 @@
+ALTER TABLE contests ADD COLUMN IF NOT EXISTS rollover boolean NOT NULL DEFAULT false;
+
+ALTER TABLE quarter_results ADD COLUMN IF NOT EXISTS rolled_over boolean NOT NULL DEFAULT false;
+ALTER TABLE quarter_results ADD COLUMN IF NOT EXISTS carried_over integer NOT NULL DEFAULT 0;
+ALTER TABLE quarter_results ADD COLUMN IF NOT EXISTS fallback boolean NOT NULL DEFAULT false;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS square_swaps (
+    id             uuid PRIMARY KEY,
+    contest_id     uuid NOT NULL REFERENCES contests (id) ON DELETE CASCADE,
+    from_square_id uuid NOT NULL REFERENCES squares (id) ON DELETE CASCADE,
+    to_square_id   uuid NOT NULL REFERENCES squares (id) ON DELETE CASCADE,
+    requester      text NOT NULL,
+    recipient      text NOT NULL,
+    status         text NOT NULL DEFAULT 'pending',
+    created_at     timestamptz,
+    updated_at     timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_square_swaps_contest_id ON square_swaps (contest_id);
+CREATE INDEX IF NOT EXISTS idx_square_swaps_pending ON square_swaps (contest_id, status) WHERE status = 'pending';
@@ -0,0 +1,3 @@
+ALTER TABLE squares DROP COLUMN IF EXISTS version;
+
+ALTER TABLE contests DROP COLUMN IF EXISTS version;
@@ -0,0 +1,3 @@
+ALTER TABLE contests ADD COLUMN IF NOT EXISTS version integer NOT NULL DEFAULT 1;
+
+ALTER TABLE squares ADD COLUMN IF NOT EXISTS version integer NOT NULL DEFAULT 1;
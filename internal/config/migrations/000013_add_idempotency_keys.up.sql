@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    user_id       text NOT NULL,
+    key           text NOT NULL,
+    request_hash  text NOT NULL,
+    status_code   integer NOT NULL DEFAULT 0,
+    response_body bytea,
+    response_headers jsonb,
+    created_at    timestamptz NOT NULL,
+    expires_at    timestamptz NOT NULL,
+    PRIMARY KEY (user_id, key)
+);
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_contests_deleted_at;
+ALTER TABLE contests DROP COLUMN IF EXISTS pre_delete_status;
+ALTER TABLE contests DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,8 @@
+ALTER TABLE contests ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+ALTER TABLE contests ADD COLUMN IF NOT EXISTS pre_delete_status text;
+
+-- contests deleted before this migration can't be restored, but still age out through the purge
+-- the clock starts at migration time so an old edit date doesn't purge them on the first sweep
+UPDATE contests SET deleted_at = NOW() WHERE status = 'DELETED' AND deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_contests_deleted_at ON contests (deleted_at) WHERE status = 'DELETED';
@@ -0,0 +1,5 @@
+DROP VIEW IF EXISTS contest_quarter_winners;
+DROP VIEW IF EXISTS contest_square_owners;
+DROP INDEX IF EXISTS idx_contests_archive_due;
+DROP TABLE IF EXISTS contest_archives;
+ALTER TABLE contests DROP COLUMN IF EXISTS archived_at;
@@ -0,0 +1,25 @@
+ALTER TABLE contests ADD COLUMN IF NOT EXISTS archived_at timestamptz;
+
+-- jsonb values this size are TOASTed, so postgres stores the snapshot compressed
+CREATE TABLE IF NOT EXISTS contest_archives (
+    contest_id  uuid PRIMARY KEY REFERENCES contests (id) ON DELETE CASCADE,
+    snapshot    jsonb NOT NULL,
+    archived_at timestamptz NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_contests_archive_due ON contests (updated_at) WHERE status = 'FINISHED' AND archived_at IS NULL;
+
+-- reporting queries read these so archived contests keep counting
+CREATE OR REPLACE VIEW contest_square_owners AS
+    SELECT contest_id, owner FROM squares
+    UNION ALL
+    SELECT a.contest_id, sq ->> 'owner'
+    FROM contest_archives a
+    CROSS JOIN LATERAL jsonb_array_elements(a.snapshot -> 'squares') sq;
+
+CREATE OR REPLACE VIEW contest_quarter_winners AS
+    SELECT contest_id, winner FROM quarter_results
+    UNION ALL
+    SELECT a.contest_id, qr ->> 'winner'
+    FROM contest_archives a
+    CROSS JOIN LATERAL jsonb_array_elements(a.snapshot -> 'quarterResults') qr;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS contest_spectator_tokens (
+    id uuid PRIMARY KEY,
+    contest_id uuid NOT NULL REFERENCES contests (id) ON DELETE CASCADE,
+    token text NOT NULL,
+    label text,
+    created_by text NOT NULL,
+    expires_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_contest_spectator_tokens_token ON contest_spectator_tokens (token);
+CREATE INDEX IF NOT EXISTS idx_contest_spectator_tokens_contest_id ON contest_spectator_tokens (contest_id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_contest_invites_contest_email;
+ALTER TABLE contest_invites DROP COLUMN IF EXISTS redeemed_at;
+ALTER TABLE contest_invites DROP COLUMN IF EXISTS opened_at;
+ALTER TABLE contest_invites DROP COLUMN IF EXISTS sent_at;
+ALTER TABLE contest_invites DROP COLUMN IF EXISTS email_status;
+ALTER TABLE contest_invites DROP COLUMN IF EXISTS email;
@@ -0,0 +1,6 @@
+ALTER TABLE contest_invites ADD COLUMN IF NOT EXISTS email text NOT NULL DEFAULT '';
+ALTER TABLE contest_invites ADD COLUMN IF NOT EXISTS email_status text NOT NULL DEFAULT '';
+ALTER TABLE contest_invites ADD COLUMN IF NOT EXISTS sent_at timestamptz;
+ALTER TABLE contest_invites ADD COLUMN IF NOT EXISTS opened_at timestamptz;
+ALTER TABLE contest_invites ADD COLUMN IF NOT EXISTS redeemed_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_contest_invites_contest_email ON contest_invites (contest_id, email) WHERE email <> '';
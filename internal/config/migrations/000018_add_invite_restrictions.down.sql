@@ -0,0 +1,2 @@
+ALTER TABLE contest_invites DROP COLUMN IF EXISTS allowed_emails;
+ALTER TABLE contest_invites DROP COLUMN IF EXISTS allowed_domains;
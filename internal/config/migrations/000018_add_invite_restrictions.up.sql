@@ -0,0 +1,2 @@
+ALTER TABLE contest_invites ADD COLUMN IF NOT EXISTS allowed_domains jsonb;
+ALTER TABLE contest_invites ADD COLUMN IF NOT EXISTS allowed_emails jsonb;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS contest_invite_events;
+ALTER TABLE contest_invites DROP COLUMN IF EXISTS paused;
@@ -0,0 +1,18 @@
+ALTER TABLE contest_invites ADD COLUMN IF NOT EXISTS paused boolean NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS contest_invite_events (
+    id uuid PRIMARY KEY,
+    invite_id uuid NOT NULL REFERENCES contest_invites (id) ON DELETE CASCADE,
+    contest_id uuid NOT NULL REFERENCES contests (id) ON DELETE CASCADE,
+    kind text NOT NULL,
+    user_id text,
+    visitor text,
+    viewed_on date,
+    created_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_contest_invite_events_invite_id ON contest_invite_events (invite_id);
+CREATE INDEX IF NOT EXISTS idx_contest_invite_events_contest_id ON contest_invite_events (contest_id);
+CREATE INDEX IF NOT EXISTS idx_contest_invite_events_created_at ON contest_invite_events (created_at);
+
+-- a visitor's views count once per invite per day, so reloads and link scanners don't inflate the funnel
+CREATE UNIQUE INDEX IF NOT EXISTS idx_contest_invite_events_daily_view ON contest_invite_events (invite_id, visitor, viewed_on) WHERE kind = 'view';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_squares_reserved_invite_id;
+ALTER TABLE squares DROP COLUMN IF EXISTS reserved_invite_id;
@@ -0,0 +1,2 @@
+ALTER TABLE squares ADD COLUMN IF NOT EXISTS reserved_invite_id uuid REFERENCES contest_invites (id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_squares_reserved_invite_id ON squares (reserved_invite_id) WHERE reserved_invite_id IS NOT NULL;
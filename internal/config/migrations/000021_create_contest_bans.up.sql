@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS contest_bans (
+    id uuid PRIMARY KEY,
+    contest_id uuid NOT NULL REFERENCES contests (id) ON DELETE CASCADE,
+    user_id text NOT NULL,
+    reason text NOT NULL DEFAULT '',
+    banned_by text NOT NULL,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_contest_bans_contest_user ON contest_bans (contest_id, lower(user_id));
@@ -0,0 +1,5 @@
+ALTER TABLE contests DROP COLUMN IF EXISTS payment_policy;
+
+ALTER TABLE contest_participants DROP COLUMN IF EXISTS paid_at;
+ALTER TABLE contest_participants DROP COLUMN IF EXISTS amount_paid_cents;
+ALTER TABLE contest_participants DROP COLUMN IF EXISTS paid;
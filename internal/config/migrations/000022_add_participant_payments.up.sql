@@ -0,0 +1,5 @@
+ALTER TABLE contest_participants ADD COLUMN IF NOT EXISTS paid boolean NOT NULL DEFAULT false;
+ALTER TABLE contest_participants ADD COLUMN IF NOT EXISTS amount_paid_cents integer NOT NULL DEFAULT 0;
+ALTER TABLE contest_participants ADD COLUMN IF NOT EXISTS paid_at timestamptz;
+
+ALTER TABLE contests ADD COLUMN IF NOT EXISTS payment_policy text NOT NULL DEFAULT 'none';
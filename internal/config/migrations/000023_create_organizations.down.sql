@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_contests_org_id;
+ALTER TABLE contests DROP COLUMN IF EXISTS org_id;
+
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS organizations (
+    id uuid PRIMARY KEY,
+    name text NOT NULL,
+    created_by text NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS organization_members (
+    id uuid PRIMARY KEY,
+    org_id uuid NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    user_id text NOT NULL,
+    role text NOT NULL DEFAULT 'member',
+    added_by text NOT NULL,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organization_members_org_user ON organization_members (org_id, lower(user_id));
+CREATE INDEX IF NOT EXISTS idx_organization_members_user ON organization_members (lower(user_id));
+
+ALTER TABLE contests ADD COLUMN IF NOT EXISTS org_id uuid REFERENCES organizations (id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_contests_org_id ON contests (org_id) WHERE org_id IS NOT NULL;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS friendships (
+    id uuid PRIMARY KEY,
+    requester text NOT NULL,
+    addressee text NOT NULL,
+    status text NOT NULL DEFAULT 'pending',
+    accepted_at timestamptz,
+    created_at timestamptz
+);
+-- one row per pair regardless of who asked first
+CREATE UNIQUE INDEX IF NOT EXISTS idx_friendships_pair ON friendships (least(lower(requester), lower(addressee)), greatest(lower(requester), lower(addressee)));
+CREATE INDEX IF NOT EXISTS idx_friendships_requester ON friendships (lower(requester));
+CREATE INDEX IF NOT EXISTS idx_friendships_addressee ON friendships (lower(addressee));
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS user_avatars;
+ALTER TABLE users DROP COLUMN IF EXISTS avatar_updated_at;
+ALTER TABLE users DROP COLUMN IF EXISTS profile_visibility;
@@ -0,0 +1,10 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS profile_visibility text NOT NULL DEFAULT 'friends';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar_updated_at timestamptz;
+
+-- avatars live outside the users row so profile reads never pull image bytes
+CREATE TABLE IF NOT EXISTS user_avatars (
+    user_id uuid PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    content_type text NOT NULL,
+    data bytea NOT NULL,
+    updated_at timestamptz NOT NULL
+);
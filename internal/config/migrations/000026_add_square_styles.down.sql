@@ -0,0 +1,3 @@
+ALTER TABLE squares DROP COLUMN IF EXISTS color;
+ALTER TABLE contest_participants DROP COLUMN IF EXISTS color;
+ALTER TABLE contest_participants DROP COLUMN IF EXISTS display_value;
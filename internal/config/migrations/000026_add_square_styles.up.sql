@@ -0,0 +1,4 @@
+-- per-contest overrides; null falls back to the profile initials and the default colour
+ALTER TABLE contest_participants ADD COLUMN IF NOT EXISTS display_value text;
+ALTER TABLE contest_participants ADD COLUMN IF NOT EXISTS color text;
+ALTER TABLE squares ADD COLUMN IF NOT EXISTS color text NOT NULL DEFAULT '';
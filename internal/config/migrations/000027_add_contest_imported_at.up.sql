@@ -0,0 +1,2 @@
+-- imported contests carry results nobody played here, so stats and the leaderboard leave them out
+ALTER TABLE contests ADD COLUMN IF NOT EXISTS imported_at timestamptz;
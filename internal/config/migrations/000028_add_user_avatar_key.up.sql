@@ -0,0 +1,6 @@
+-- avatars are served at an unguessable url so <img> tags can load them without a bearer token
+ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar_key text;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_avatar_key ON users (avatar_key) WHERE avatar_key IS NOT NULL;
+
+UPDATE users SET avatar_key = replace(gen_random_uuid()::text, '-', '')
+WHERE avatar_updated_at IS NOT NULL AND avatar_key IS NULL;
@@ -4,10 +4,13 @@ import "errors"
 
 // authorization errors for contest and square actions
 var (
-	ErrUnauthorizedContestEdit   = errors.New("only the contest owner can update this contest")
-	ErrUnauthorizedContestDelete = errors.New("only the contest owner can delete this contest")
-	ErrUnauthorizedSquareEdit    = errors.New("only the square owner can update this square")
-	ErrMissingInitials           = errors.New("set your default initials in your profile before claiming a square")
+	ErrUnauthorizedContestEdit    = errors.New("only the contest owner can update this contest")
+	ErrUnauthorizedContestDelete  = errors.New("only the contest owner can delete this contest")
+	ErrUnauthorizedContestRestore = errors.New("only the contest owner can restore this contest")
+	ErrUnauthorizedContestExport  = errors.New("only the contest owner can export this contest")
+	ErrUnauthorizedSquareEdit     = errors.New("only the square owner can update this square")
+	ErrMissingInitials            = errors.New("set your default initials in your profile before claiming a square")
+	ErrUnauthorizedSwap           = errors.New("you cannot act on this swap request")
 )
 
 // validation errors for contest, team, and square attributes
@@ -23,10 +26,48 @@ var (
 	ErrContestNotEditable         = errors.New("contest is not in an editable state")
 	ErrContestFinalized           = errors.New("contest is finished or deleted and cannot be modified")
 	ErrContestNotReady            = errors.New("all squares must be claimed before the contest can be started")
+	ErrUnpaidSquareOwners         = errors.New("every square owner must be marked paid before the contest can be started")
 	ErrSquareNotEditable          = errors.New("squares can only be edited when contest is active")
+	ErrSquareAlreadyClaimed       = errors.New("square has already been claimed")
 	ErrContestAlreadyExists       = errors.New("contest already exists with this name")
 	ErrQuarterResultAlreadyExists = errors.New("result of this quarter has already been recorded")
 	ErrNoQuarterResultToRollback  = errors.New("there is no recorded quarter result to roll back")
+	ErrInvalidLockTime            = errors.New("lock time must be in the future")
+	ErrInvalidSwap                = errors.New("swaps must be between squares held by two different participants")
+	ErrSwapNotPending             = errors.New("swap request is no longer pending")
+	ErrSwapOutdated               = errors.New("one of the squares in this swap has changed hands")
+	ErrContestVersionConflict     = errors.New("contest was changed by another request, reload and try again")
+	ErrContestVersionMismatch     = errors.New("contest has changed since the version you last saw")
+	ErrSquareVersionConflict      = errors.New("square was changed by another request, reload and try again")
+	ErrSquareVersionMismatch      = errors.New("square has changed since the version you last saw")
+	ErrInvalidIfMatch             = errors.New("if-match must be a version etag returned by this api")
+	ErrContestNotRestorable       = errors.New("contest cannot be restored")
+	ErrContestRestoreExpired      = errors.New("the window to restore this contest has passed")
+)
+
+// idempotency key errors for retried requests
+var (
+	ErrInvalidIdempotencyKey    = errors.New("idempotency-key must be 1-255 characters")
+	ErrIdempotencyKeyReused     = errors.New("idempotency-key was already used for a different request")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency-key is still being processed")
+)
+
+// contest export and import errors
+var (
+	ErrUnsupportedExportVersion = errors.New("export schema version is not supported")
+	ErrInvalidContestExport     = errors.New("export document is not a consistent contest")
+)
+
+// participant csv import errors
+var (
+	ErrInvalidImportFile = errors.New("upload a csv file with email, role and max_squares columns")
+	ErrImportRejected    = errors.New("import has errors, fix them and upload the file again")
+)
+
+// spectator link errors
+var (
+	ErrSpectatorTokenNotFound = errors.New("spectator link not found")
+	ErrSpectatorTokenExpired  = errors.New("spectator link has expired")
 )
 
 // database errors for service availability
@@ -38,6 +79,7 @@ var (
 var (
 	ErrContestNotFound       = errors.New("contest not found")
 	ErrSquareNotFound        = errors.New("square not found")
+	ErrSwapNotFound          = errors.New("swap request not found")
 	ErrUserNotFound          = errors.New("user not found")
 	ErrAccountActiveContests = errors.New("you must delete or leave your active contests before deleting your account")
 	ErrInvalidRequestBody    = errors.New("invalid request body")
@@ -58,6 +100,7 @@ var (
 	ErrInvalidTurnstile      = errors.New("invalid or expired captcha")
 	ErrTurnstileVerification = errors.New("failed to verify turnstile token")
 	ErrEmailNotification     = errors.New("failed to send contact email notification")
+	ErrInviteEmail           = errors.New("failed to send invite email")
 )
 
 // game, contest, and invite related errors
@@ -67,15 +110,57 @@ var (
 	ErrInviteNotFound          = errors.New("invite not found")
 	ErrInviteExpired           = errors.New("invite link has expired")
 	ErrInviteMaxUsesReached    = errors.New("invite link has reached its usage limit")
+	ErrInvitePaused            = errors.New("invite link is paused")
+	ErrInviteMaxUsesTooLow     = errors.New("max uses cannot be below the number of times the invite was used")
+	ErrInviteEmailMismatch     = errors.New("this invite was sent to a different email address")
+	ErrInviteEmailNotAllowed   = errors.New("your email address is not allowed to use this invite")
+	ErrInviteeAlreadyJoined    = errors.New("an invited address already belongs to a participant in this contest")
+	ErrSquareNotReservable     = errors.New("a requested square is already claimed or reserved")
+	ErrTooManyReservedSquares  = errors.New("an invite cannot reserve more squares than it allots")
 	ErrNotEnoughSquares        = errors.New("not enough squares remaining in this contest")
 	ErrAlreadyParticipant      = errors.New("you are already a participant in this contest")
 	ErrNotParticipant          = errors.New("not a participant in this contest")
 	ErrInsufficientRole        = errors.New("insufficient permissions for this action")
 	ErrCannotRemoveOwner       = errors.New("cannot remove the contest owner")
 	ErrCannotChangeOwner       = errors.New("cannot change the owner's role")
+	ErrCannotBanOwner          = errors.New("cannot ban the contest owner")
+	ErrCannotBanSelf           = errors.New("you cannot ban yourself")
+	ErrBannedFromContest       = errors.New("you have been banned from this contest")
+	ErrAlreadyBanned           = errors.New("user is already banned from this contest")
+	ErrBanNotFound             = errors.New("ban not found")
 	ErrSquareLimitReached      = errors.New("you have reached your square limit for this contest")
 	ErrSquareLimitTooLow       = errors.New("new limit cannot be below the number of squares already claimed")
 	ErrInvalidSquareCount      = errors.New("participants must be allotted at least one square")
 	ErrViewerCannotHaveSquares = errors.New("viewers cannot be allotted squares")
+	ErrEmptySquareStyleUpdate  = errors.New("at least one square style field must be provided")
 	ErrWinnerNotDeterminable   = errors.New("winner cannot be determined for the given score")
 )
+
+// organization membership errors
+var (
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrOrgMemberNotFound    = errors.New("organization member not found")
+	ErrNotOrgMember         = errors.New("not a member of this organization")
+	ErrNotOrgAdmin          = errors.New("only organization admins can do this")
+	ErrAlreadyOrgMember     = errors.New("user is already a member of this organization")
+	ErrLastOrgAdmin         = errors.New("an organization must keep at least one admin")
+)
+
+// friend graph errors
+var (
+	ErrCannotFriendSelf      = errors.New("you cannot send a friend request to yourself")
+	ErrAlreadyFriends        = errors.New("you are already friends with this user")
+	ErrFriendRequestExists   = errors.New("a friend request is already pending")
+	ErrFriendRequestNotFound = errors.New("friend request not found")
+	ErrFriendNotFound        = errors.New("friend not found")
+	ErrNotFriend             = errors.New("only friends can be added directly to a contest")
+	ErrInvalidSearchQuery    = errors.New("search query must be 2-50 characters")
+)
+
+// user profile errors
+var (
+	ErrEmptyProfileUpdate = errors.New("at least one profile field must be provided")
+	ErrAvatarTooLarge     = errors.New("avatar must be 512KB or smaller")
+	ErrUnsupportedAvatar  = errors.New("avatar must be a png, jpeg, gif or webp image")
+	ErrAvatarNotFound     = errors.New("avatar not found")
+)
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/service"
+	"github.com/maxmorhardt/squares-api/internal/util"
+	"gorm.io/gorm"
+)
+
+type AnalyticsHandler interface {
+	GetContestAnalytics(c *gin.Context)
+}
+
+type analyticsHandler struct {
+	analyticsService service.AnalyticsService
+}
+
+func NewAnalyticsHandler(analyticsService service.AnalyticsService) AnalyticsHandler {
+	return &analyticsHandler{
+		analyticsService: analyticsService,
+	}
+}
+
+// @Summary Get square win probabilities for a contest
+// @Description Returns per-square win probabilities for each quarter and the expected share of the pot, based on historical last-digit frequencies. Every square has equal odds until the labels are drawn
+// @Tags contests
+// @Produce json
+// @Param id path string true "Contest ID"
+// @Success 200 {object} model.ContestAnalyticsResponse
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/analytics [get]
+func (h *analyticsHandler) GetContestAnalytics(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		log.Warn("invalid contest id", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid contest ID", c))
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	analytics, err := h.analyticsService.GetContestAnalytics(c.Request.Context(), contestID, user)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(errs.ErrContestNotFound), c))
+		case errors.Is(err, errs.ErrNotParticipant), errors.Is(err, errs.ErrInsufficientRole), errors.Is(err, errs.ErrBannedFromContest):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(errs.ErrInsufficientRole), c))
+		default:
+			log.Error("failed to get contest analytics", "error", err)
+			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to get contest analytics", c))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/mocks"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestGetContestAnalytics_Success(t *testing.T) {
+	contestID := uuid.New()
+	svc := mocks.NewAnalyticsService(t)
+	svc.EXPECT().GetContestAnalytics(mock.Anything, contestID, "user1").Return(&model.ContestAnalyticsResponse{
+		ContestID:      contestID,
+		LabelsAssigned: true,
+		Squares:        []model.SquareAnalyticsEntry{{Row: 0, Col: 0}},
+	}, nil)
+	h := NewAnalyticsHandler(svc)
+
+	r := gin.New()
+	r.Use(authenticatedMiddleware("user1"))
+	r.GET("/contests/:id/analytics", h.GetContestAnalytics)
+
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/contests/%s/analytics", contestID), http.NoBody)
+	w := doRequest(r, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp model.ContestAnalyticsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.True(t, resp.LabelsAssigned)
+	assert.Len(t, resp.Squares, 1)
+}
+
+func TestGetContestAnalytics_InvalidID(t *testing.T) {
+	h := NewAnalyticsHandler(mocks.NewAnalyticsService(t))
+	r := gin.New()
+	r.Use(authenticatedMiddleware("user1"))
+	r.GET("/contests/:id/analytics", h.GetContestAnalytics)
+
+	req, _ := http.NewRequest(http.MethodGet, "/contests/bad-id/analytics", http.NoBody)
+	w := doRequest(r, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetContestAnalytics_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  error
+		code int
+	}{
+		{name: "not found", err: gorm.ErrRecordNotFound, code: http.StatusNotFound},
+		{name: "not participant", err: errs.ErrNotParticipant, code: http.StatusForbidden},
+		{name: "insufficient role", err: errs.ErrInsufficientRole, code: http.StatusForbidden},
+		{name: "banned", err: errs.ErrBannedFromContest, code: http.StatusForbidden},
+		{name: "unexpected", err: assert.AnError, code: http.StatusInternalServerError},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := mocks.NewAnalyticsService(t)
+			svc.EXPECT().GetContestAnalytics(mock.Anything, mock.Anything, mock.Anything).Return(nil, tc.err)
+			h := NewAnalyticsHandler(svc)
+
+			r := gin.New()
+			r.Use(authenticatedMiddleware("user1"))
+			r.GET("/contests/:id/analytics", h.GetContestAnalytics)
+
+			req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/contests/%s/analytics", uuid.New()), http.NoBody)
+			w := doRequest(r, req)
+			assert.Equal(t, tc.code, w.Code)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/service"
+	"github.com/maxmorhardt/squares-api/internal/util"
+	"gorm.io/gorm"
+)
+
+type BoardHandler interface {
+	GetBoardPNG(c *gin.Context)
+	GetBoardSVG(c *gin.Context)
+	GetSheetPDF(c *gin.Context)
+}
+
+type boardHandler struct {
+	boardService service.BoardService
+}
+
+func NewBoardHandler(boardService service.BoardService) BoardHandler {
+	return &boardHandler{
+		boardService: boardService,
+	}
+}
+
+// @Summary Get the board as a PNG image
+// @Description Renders the 10x10 grid with team names, labels, square initials and highlighted quarter winners. Anyone who can view the contest can fetch it. The ETag changes whenever the board does
+// @Tags contests
+// @Produce png
+// @Param id path string true "Contest ID"
+// @Param If-None-Match header string false "ETag from a previous fetch"
+// @Success 200 {file} file
+// @Success 304 "Board unchanged"
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/board.png [get]
+func (h *boardHandler) GetBoardPNG(c *gin.Context) {
+	h.getBoard(c, model.BoardFormatPNG)
+}
+
+// @Summary Get the board as an SVG image
+// @Description Same board as the PNG, as scalable vector graphics
+// @Tags contests
+// @Produce image/svg+xml
+// @Param id path string true "Contest ID"
+// @Param If-None-Match header string false "ETag from a previous fetch"
+// @Success 200 {file} file
+// @Success 304 "Board unchanged"
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/board.svg [get]
+func (h *boardHandler) GetBoardSVG(c *gin.Context) {
+	h.getBoard(c, model.BoardFormatSVG)
+}
+
+// @Summary Get a printable contest sheet
+// @Description Print-ready PDF with the grid, full owner names, numbers once drawn, the quarter results table and a payout summary once any quarter is scored. Anyone who can view the contest can fetch it
+// @Tags contests
+// @Produce application/pdf
+// @Param id path string true "Contest ID"
+// @Param If-None-Match header string false "ETag from a previous fetch"
+// @Success 200 {file} file
+// @Success 304 "Sheet unchanged"
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/sheet.pdf [get]
+func (h *boardHandler) GetSheetPDF(c *gin.Context) {
+	h.getBoard(c, model.BoardFormatPDF)
+}
+
+func (h *boardHandler) getBoard(c *gin.Context, format model.BoardFormat) {
+	log := util.LoggerFromGinContext(c)
+
+	contestIDParam := c.Param("id")
+	contestID, err := uuid.Parse(contestIDParam)
+	if err != nil {
+		log.Warn("invalid contest id", "param", contestIDParam, "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid contest ID format", c))
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	board, err := h.boardService.RenderBoard(c.Request.Context(), contestID, user, format)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(errs.ErrContestNotFound), c))
+		case errors.Is(err, errs.ErrNotParticipant), errors.Is(err, errs.ErrInsufficientRole), errors.Is(err, errs.ErrBannedFromContest):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(errs.ErrInsufficientRole), c))
+		default:
+			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to render board", c))
+		}
+		return
+	}
+
+	// private because the board can show a private contest; no-cache so clients revalidate with the etag
+	c.Header("ETag", board.ETag)
+	c.Header("Cache-Control", "private, no-cache")
+
+	if strings.TrimPrefix(c.GetHeader("If-None-Match"), "W/") == board.ETag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, board.ContentType, board.Data)
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/mocks"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func boardRouter(svc *mocks.BoardService) *gin.Engine {
+	h := NewBoardHandler(svc)
+	r := gin.New()
+	r.Use(authenticatedMiddleware("user1"))
+	r.GET("/contests/:id/board.png", h.GetBoardPNG)
+	r.GET("/contests/:id/board.svg", h.GetBoardSVG)
+	r.GET("/contests/:id/sheet.pdf", h.GetSheetPDF)
+	return r
+}
+
+func TestGetBoard_PNG(t *testing.T) {
+	contestID := uuid.New()
+	svc := mocks.NewBoardService(t)
+	svc.EXPECT().RenderBoard(mock.Anything, contestID, "user1", model.BoardFormatPNG).
+		Return(&model.BoardImage{Data: []byte("png"), ContentType: "image/png", ETag: `"2-0-png"`}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/contests/"+contestID.String()+"/board.png", http.NoBody)
+	w := doRequest(boardRouter(svc), req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+	assert.Equal(t, `"2-0-png"`, w.Header().Get("ETag"))
+	assert.Equal(t, "png", w.Body.String())
+}
+
+func TestGetBoard_SVG(t *testing.T) {
+	contestID := uuid.New()
+	svc := mocks.NewBoardService(t)
+	svc.EXPECT().RenderBoard(mock.Anything, contestID, "user1", model.BoardFormatSVG).
+		Return(&model.BoardImage{Data: []byte("<svg/>"), ContentType: "image/svg+xml", ETag: `"2-0-svg"`}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/contests/"+contestID.String()+"/board.svg", http.NoBody)
+	w := doRequest(boardRouter(svc), req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "image/svg+xml", w.Header().Get("Content-Type"))
+}
+
+func TestGetSheet_PDF(t *testing.T) {
+	contestID := uuid.New()
+	svc := mocks.NewBoardService(t)
+	svc.EXPECT().RenderBoard(mock.Anything, contestID, "user1", model.BoardFormatPDF).
+		Return(&model.BoardImage{Data: []byte("%PDF-"), ContentType: "application/pdf", ETag: `"2-0-pdf"`}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/contests/"+contestID.String()+"/sheet.pdf", http.NoBody)
+	w := doRequest(boardRouter(svc), req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.Equal(t, `"2-0-pdf"`, w.Header().Get("ETag"))
+}
+
+func TestGetBoard_NotModified(t *testing.T) {
+	svc := mocks.NewBoardService(t)
+	svc.EXPECT().RenderBoard(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(&model.BoardImage{Data: []byte("png"), ContentType: "image/png", ETag: `"2-0-png"`}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/contests/"+uuid.New().String()+"/board.png", http.NoBody)
+	req.Header.Set("If-None-Match", `W/"2-0-png"`)
+	w := doRequest(boardRouter(svc), req)
+
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestGetBoard_InvalidID(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "/contests/bad/board.png", http.NoBody)
+	w := doRequest(boardRouter(mocks.NewBoardService(t)), req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetBoard_NotFound(t *testing.T) { getBoardErr(t, gorm.ErrRecordNotFound, http.StatusNotFound) }
+func TestGetBoard_Forbidden(t *testing.T) {
+	getBoardErr(t, errs.ErrNotParticipant, http.StatusForbidden)
+}
+func TestGetBoard_InternalError(t *testing.T) {
+	getBoardErr(t, errs.ErrDatabaseUnavailable, http.StatusInternalServerError)
+}
+
+func getBoardErr(t *testing.T, svcErr error, wantCode int) {
+	t.Helper()
+	svc := mocks.NewBoardService(t)
+	svc.EXPECT().RenderBoard(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, svcErr)
+
+	req, _ := http.NewRequest(http.MethodGet, "/contests/"+uuid.New().String()+"/board.svg", http.NoBody)
+	w := doRequest(boardRouter(svc), req)
+	assert.Equal(t, wantCode, w.Code)
+}
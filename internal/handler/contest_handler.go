@@ -17,10 +17,12 @@ import (
 
 type ContestHandler interface {
 	GetContestsByOwner(c *gin.Context)
+	GetContest(c *gin.Context)
 
 	CreateContest(c *gin.Context)
 	UpdateContest(c *gin.Context)
 	DeleteContest(c *gin.Context)
+	RestoreContest(c *gin.Context)
 	StartContest(c *gin.Context)
 	RecordQuarterResult(c *gin.Context)
 	RollbackLastQuarterResult(c *gin.Context)
@@ -28,6 +30,8 @@ type ContestHandler interface {
 	ClaimSquare(c *gin.Context)
 	ClearSquare(c *gin.Context)
 	ClearMySquares(c *gin.Context)
+	ClaimSquares(c *gin.Context)
+	AssignSquare(c *gin.Context)
 }
 
 type contestHandler struct {
@@ -98,6 +102,58 @@ func (h *contestHandler) GetContestsByOwner(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// @Summary Get a contest
+// @Description Returns a contest with its squares and quarter results. The ETag header carries the contest version; send it back in If-None-Match to get a 304 when nothing changed, or in If-Match on writes to avoid overwriting someone else's change
+// @Tags contests
+// @Produce json
+// @Param id path string true "Contest ID"
+// @Param If-None-Match header string false "ETag from a previous read"
+// @Success 200 {object} model.ContestSwagger
+// @Success 304 "Contest unchanged"
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id} [get]
+func (h *contestHandler) GetContest(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	// parse contest id from path
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		log.Warn("invalid contest id", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid contest ID format", c))
+		return
+	}
+
+	// get authenticated user and load the contest
+	user := c.GetString(model.UserKey)
+	contest, err := h.contestService.GetContest(c.Request.Context(), contestID, user)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(errs.ErrContestNotFound), c))
+		case errors.Is(err, errs.ErrNotParticipant), errors.Is(err, errs.ErrInsufficientRole), errors.Is(err, errs.ErrBannedFromContest):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(errs.ErrInsufficientRole), c))
+		default:
+			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, util.CapitalizeFirstLetter(errs.ErrDatabaseUnavailable), c))
+		}
+		return
+	}
+
+	etag := util.FormatETag(contest.Version)
+	c.Header("ETag", etag)
+
+	// the client already holds this version
+	if version, ok := util.ParseETag(c.GetHeader("If-None-Match")); ok && version == contest.Version {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.JSON(http.StatusOK, contest)
+}
+
 func (h *contestHandler) extractPaginationParams(c *gin.Context) (page, limit int, err error) {
 	// get page parameter
 	pageStr := c.Query("page")
@@ -136,10 +192,14 @@ func (h *contestHandler) extractPaginationParams(c *gin.Context) (page, limit in
 // @Tags contests
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the first response for 24h"
 // @Param contest body model.CreateContestRequest true "Contest"
 // @Success 200 {object} model.ContestSwagger
 // @Failure 400 {object} model.APIError
 // @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 409 {object} model.APIError
+// @Failure 422 {object} model.APIError
 // @Failure 500 {object} model.APIError
 // @Security BearerAuth
 // @Router /contests [put]
@@ -169,9 +229,11 @@ func (h *contestHandler) CreateContest(c *gin.Context) {
 		switch {
 		case errors.Is(err, errs.ErrDatabaseUnavailable):
 			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, util.CapitalizeFirstLetter(err), c))
-		case errors.Is(err, errs.ErrContestAlreadyExists):
+		case errors.Is(err, errs.ErrContestAlreadyExists), errors.Is(err, errs.ErrInvalidLockTime), errors.Is(err, errs.ErrNotEnoughSquares):
 			c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(err), c))
-		case errors.Is(err, errs.ErrGameNotFound):
+		case errors.Is(err, errs.ErrNotOrgMember):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrGameNotFound), errors.Is(err, errs.ErrOrganizationNotFound):
 			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(err), c))
 		default:
 			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to create contest", c))
@@ -179,20 +241,24 @@ func (h *contestHandler) CreateContest(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", util.FormatETag(contest.Version))
 	c.JSON(http.StatusOK, contest)
 }
 
 // @Summary Update contest
-// @Description Updates the values of a contest
+// @Description Updates the values of a contest. Send the contest's ETag in If-Match to reject the update with 412 if the contest changed since it was read
 // @Tags contests
 // @Accept json
 // @Produce json
 // @Param id path string true "Contest ID"
+// @Param If-Match header string false "ETag of the contest version being edited"
 // @Param contest body model.UpdateContestRequest true "Contest update data"
 // @Success 200 {object} model.ContestSwagger
 // @Failure 400 {object} model.APIError
 // @Failure 403 {object} model.APIError
 // @Failure 404 {object} model.APIError
+// @Failure 409 {object} model.ContestConflictErrorSwagger
+// @Failure 412 {object} model.ContestConflictErrorSwagger
 // @Failure 500 {object} model.APIError
 // @Security BearerAuth
 // @Router /contests/{id} [patch]
@@ -235,6 +301,8 @@ func (h *contestHandler) UpdateContest(c *gin.Context) {
 			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
 		case errors.Is(err, errs.ErrUnauthorizedContestEdit):
 			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrContestVersionConflict), errors.Is(err, errs.ErrContestVersionMismatch):
+			h.respondVersionConflict(c, contestID, err)
 		case errors.Is(err, errs.ErrDatabaseUnavailable):
 			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, util.CapitalizeFirstLetter(err), c))
 		default:
@@ -243,6 +311,7 @@ func (h *contestHandler) UpdateContest(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", util.FormatETag(updatedContest.Version))
 	c.JSON(http.StatusOK, updatedContest)
 }
 
@@ -251,10 +320,12 @@ func (h *contestHandler) UpdateContest(c *gin.Context) {
 // @Tags contests
 // @Produce json
 // @Param id path string true "Contest ID"
+// @Param If-Match header string false "ETag of the contest version being deleted"
 // @Success 204 "Contest deleted successfully"
 // @Failure 400 {object} model.APIError
 // @Failure 403 {object} model.APIError
 // @Failure 404 {object} model.APIError
+// @Failure 412 {object} model.ContestConflictErrorSwagger
 // @Failure 500 {object} model.APIError
 // @Security BearerAuth
 // @Router /contests/{id} [delete]
@@ -286,6 +357,8 @@ func (h *contestHandler) DeleteContest(c *gin.Context) {
 			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
 		case errors.Is(err, errs.ErrUnauthorizedContestDelete):
 			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrContestVersionMismatch):
+			h.respondVersionConflict(c, contestID, err)
 		default:
 			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to delete contest", c))
 		}
@@ -295,14 +368,69 @@ func (h *contestHandler) DeleteContest(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// @Summary Restore deleted contest
+// @Description Brings a deleted contest back in the state it was deleted from. Only the contest owner can restore, and only within the restore window
+// @Tags contests
+// @Produce json
+// @Param id path string true "Contest ID"
+// @Param If-Match header string false "ETag of the deleted contest version being restored"
+// @Success 200 {object} model.ContestSwagger
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 409 {object} model.ContestConflictErrorSwagger
+// @Failure 410 {object} model.APIError
+// @Failure 412 {object} model.ContestConflictErrorSwagger
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/restore [post]
+func (h *contestHandler) RestoreContest(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	// parse contest id from path
+	contestIDParam := c.Param("id")
+	contestID, err := uuid.Parse(contestIDParam)
+	if err != nil {
+		log.Warn("invalid contest id", "param", contestIDParam, "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid contest ID format", c))
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	contest, err := h.contestService.RestoreContest(c.Request.Context(), contestID, user)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(errs.ErrContestNotFound), c))
+		case errors.Is(err, errs.ErrUnauthorizedContestRestore):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrContestRestoreExpired), errors.Is(err, errs.ErrContestNotRestorable):
+			c.JSON(http.StatusGone, model.NewAPIError(http.StatusGone, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrContestAlreadyExists):
+			c.JSON(http.StatusConflict, model.NewAPIError(http.StatusConflict, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrContestVersionConflict), errors.Is(err, errs.ErrContestVersionMismatch):
+			h.respondVersionConflict(c, contestID, err)
+		default:
+			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to restore contest", c))
+		}
+		return
+	}
+
+	c.Header("ETag", util.FormatETag(contest.Version))
+	c.JSON(http.StatusOK, contest)
+}
+
 // @Summary Start contest
-// @Description Starts the contest, transitioning from ACTIVE to Q1 and randomizing labels
+// @Description Starts the contest, transitioning from ACTIVE to Q1 and randomizing labels. The payment policy runs first: require rejects the start while any square owner is unpaid, clear_unpaid frees their squares for the fill policy
 // @Tags contests
 // @Produce json
 // @Param id path string true "Contest ID"
+// @Param If-Match header string false "ETag of the contest version being started"
 // @Success 200 {object} model.ContestSwagger
 // @Failure 400 {object} model.APIError
 // @Failure 404 {object} model.APIError
+// @Failure 409 {object} model.ContestConflictErrorSwagger
+// @Failure 412 {object} model.ContestConflictErrorSwagger
 // @Failure 500 {object} model.APIError
 // @Security BearerAuth
 // @Router /contests/{id}/start [post]
@@ -328,14 +456,18 @@ func (h *contestHandler) StartContest(c *gin.Context) {
 	user := c.GetString(model.UserKey)
 	contest, err := h.contestService.StartContest(c.Request.Context(), contestID, user)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
 			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(errs.ErrContestNotFound), c))
-		} else {
+		case errors.Is(err, errs.ErrContestVersionConflict), errors.Is(err, errs.ErrContestVersionMismatch):
+			h.respondVersionConflict(c, contestID, err)
+		default:
 			c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(err), c))
 		}
 		return
 	}
 
+	c.Header("ETag", util.FormatETag(contest.Version))
 	c.JSON(http.StatusOK, contest)
 }
 
@@ -345,11 +477,16 @@ func (h *contestHandler) StartContest(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Contest ID"
+// @Param If-Match header string false "ETag of the contest version being scored"
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the first response for 24h"
 // @Param quarterResult body model.QuarterResultRequest true "Quarter result data"
 // @Success 200 {object} model.QuarterResult
 // @Failure 400 {object} model.APIError
 // @Failure 403 {object} model.APIError
 // @Failure 404 {object} model.APIError
+// @Failure 409 {object} model.ContestConflictErrorSwagger
+// @Failure 412 {object} model.ContestConflictErrorSwagger
+// @Failure 422 {object} model.APIError
 // @Failure 500 {object} model.APIError
 // @Security BearerAuth
 // @Router /contests/{id}/quarter-result [post]
@@ -399,6 +536,8 @@ func (h *contestHandler) RecordQuarterResult(c *gin.Context) {
 		case errors.Is(err, errs.ErrQuarterResultAlreadyExists):
 			log.Warn("quarter results already exists for given quarter")
 			c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(errs.ErrQuarterResultAlreadyExists), c))
+		case errors.Is(err, errs.ErrContestVersionConflict), errors.Is(err, errs.ErrContestVersionMismatch):
+			h.respondVersionConflict(c, contestID, err)
 		default:
 			log.Error("failed to record quarter result", "error", err)
 			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to record quarter result", c))
@@ -414,10 +553,13 @@ func (h *contestHandler) RecordQuarterResult(c *gin.Context) {
 // @Tags contests
 // @Produce json
 // @Param id path string true "Contest ID"
+// @Param If-Match header string false "ETag of the contest version being rolled back"
 // @Success 200 {object} model.QuarterResult
 // @Failure 400 {object} model.APIError
 // @Failure 403 {object} model.APIError
 // @Failure 404 {object} model.APIError
+// @Failure 409 {object} model.ContestConflictErrorSwagger
+// @Failure 412 {object} model.ContestConflictErrorSwagger
 // @Failure 500 {object} model.APIError
 // @Security BearerAuth
 // @Router /contests/{id}/quarter-result/rollback [post]
@@ -456,6 +598,8 @@ func (h *contestHandler) RollbackLastQuarterResult(c *gin.Context) {
 		case errors.Is(err, errs.ErrNoQuarterResultToRollback):
 			log.Warn("no quarter result to roll back", "contest_id", contestID)
 			c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrContestVersionConflict), errors.Is(err, errs.ErrContestVersionMismatch):
+			h.respondVersionConflict(c, contestID, err)
 		default:
 			log.Error("failed to roll back quarter result", "error", err)
 			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to roll back quarter result", c))
@@ -466,21 +610,44 @@ func (h *contestHandler) RollbackLastQuarterResult(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// answers a lost version race (409) or a stale If-Match (412) with the contest as it stands now
+func (h *contestHandler) respondVersionConflict(c *gin.Context, contestID uuid.UUID, err error) {
+	log := util.LoggerFromGinContext(c)
+
+	status := http.StatusConflict
+	if errors.Is(err, errs.ErrContestVersionMismatch) {
+		status = http.StatusPreconditionFailed
+	}
+
+	resp := model.ContestConflictError{APIError: model.NewAPIError(status, util.CapitalizeFirstLetter(err), c)}
+	contest, getErr := h.contestService.GetContest(c.Request.Context(), contestID, c.GetString(model.UserKey))
+	if getErr != nil {
+		log.Warn("failed to load current contest for version conflict", "contest_id", contestID, "error", getErr)
+	} else {
+		c.Header("ETag", util.FormatETag(contest.Version))
+		resp.Contest = contest
+	}
+
+	c.JSON(status, resp)
+}
+
 // ====================
 // Square Actions
 // ====================
 
 // @Summary Claim a single square in a contest
-// @Description Claims a square for the authenticated user using their profile default initials
+// @Description Claims a square for the authenticated user using their profile default initials. Returns 409 if another participant claimed it first
 // @Tags contests
 // @Produce json
 // @Param id path string true "Contest ID"
 // @Param squareId path string true "Square ID"
+// @Param If-Match header string false "ETag of the square version being claimed"
 // @Success 200 {object} model.Square
 // @Failure 400 {object} model.APIError
 // @Failure 403 {object} model.APIError
 // @Failure 404 {object} model.APIError
 // @Failure 409 {object} model.APIError
+// @Failure 412 {object} model.APIError
 // @Failure 500 {object} model.APIError
 // @Security BearerAuth
 // @Router /contests/{id}/squares/{squareId}/claim [post]
@@ -528,10 +695,12 @@ func (h *contestHandler) ClaimSquare(c *gin.Context) {
 			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(errs.ErrSquareNotFound), c))
 		case errors.Is(err, errs.ErrSquareNotEditable):
 			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
-		case errors.Is(err, errs.ErrUnauthorizedSquareEdit):
+		case errors.Is(err, errs.ErrUnauthorizedSquareEdit), errors.Is(err, errs.ErrNotParticipant):
 			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
-		case errors.Is(err, errs.ErrMissingInitials):
+		case errors.Is(err, errs.ErrSquareAlreadyClaimed), errors.Is(err, errs.ErrMissingInitials):
 			c.JSON(http.StatusConflict, model.NewAPIError(http.StatusConflict, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrSquareVersionMismatch):
+			c.JSON(http.StatusPreconditionFailed, model.NewAPIError(http.StatusPreconditionFailed, util.CapitalizeFirstLetter(err), c))
 		case errors.Is(err, errs.ErrClaimsNotFound):
 			c.JSON(http.StatusUnauthorized, model.NewAPIError(http.StatusUnauthorized, util.CapitalizeFirstLetter(err), c))
 		case errors.Is(err, errs.ErrDatabaseUnavailable):
@@ -542,6 +711,7 @@ func (h *contestHandler) ClaimSquare(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", util.FormatETag(claimedSquare.Version))
 	c.JSON(http.StatusOK, claimedSquare)
 }
 
@@ -552,10 +722,13 @@ func (h *contestHandler) ClaimSquare(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Contest ID"
 // @Param squareId path string true "Square ID"
+// @Param If-Match header string false "ETag of the square version being cleared"
 // @Success 200 {object} model.Square
 // @Failure 400 {object} model.APIError
 // @Failure 403 {object} model.APIError
 // @Failure 404 {object} model.APIError
+// @Failure 409 {object} model.APIError
+// @Failure 412 {object} model.APIError
 // @Failure 500 {object} model.APIError
 // @Security BearerAuth
 // @Router /contests/{id}/squares/{squareId}/clear [post]
@@ -603,6 +776,10 @@ func (h *contestHandler) ClearSquare(c *gin.Context) {
 			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
 		case errors.Is(err, errs.ErrUnauthorizedSquareEdit):
 			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrSquareVersionConflict):
+			c.JSON(http.StatusConflict, model.NewAPIError(http.StatusConflict, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrSquareVersionMismatch):
+			c.JSON(http.StatusPreconditionFailed, model.NewAPIError(http.StatusPreconditionFailed, util.CapitalizeFirstLetter(err), c))
 		case errors.Is(err, errs.ErrDatabaseUnavailable):
 			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, util.CapitalizeFirstLetter(err), c))
 		default:
@@ -611,6 +788,7 @@ func (h *contestHandler) ClearSquare(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", util.FormatETag(clearedSquare.Version))
 	c.JSON(http.StatusOK, clearedSquare)
 }
 
@@ -620,10 +798,12 @@ func (h *contestHandler) ClearSquare(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Contest ID"
+// @Param If-Match header string false "ETag of the contest version being cleared"
 // @Success 200 {array} model.Square
 // @Failure 400 {object} model.APIError
 // @Failure 403 {object} model.APIError
 // @Failure 404 {object} model.APIError
+// @Failure 412 {object} model.ContestConflictErrorSwagger
 // @Failure 500 {object} model.APIError
 // @Security BearerAuth
 // @Router /contests/{id}/squares/clear-mine [post]
@@ -654,6 +834,8 @@ func (h *contestHandler) ClearMySquares(c *gin.Context) {
 			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(errs.ErrContestNotFound), c))
 		case errors.Is(err, errs.ErrSquareNotEditable):
 			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrContestVersionMismatch):
+			h.respondVersionConflict(c, contestID, err)
 		case errors.Is(err, errs.ErrDatabaseUnavailable):
 			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, util.CapitalizeFirstLetter(err), c))
 		default:
@@ -664,3 +846,153 @@ func (h *contestHandler) ClearMySquares(c *gin.Context) {
 
 	c.JSON(http.StatusOK, clearedSquares)
 }
+
+// @Summary Claim several squares in a contest at once
+// @Description Claims every listed square for the authenticated user in one transaction. Either all squares are claimed or none are, and the total may not exceed the caller's square limit
+// @Tags contests
+// @Accept json
+// @Produce json
+// @Param id path string true "Contest ID"
+// @Param request body model.ClaimSquaresRequest true "Squares to claim"
+// @Param If-Match header string false "ETag of the contest version the squares were picked from"
+// @Success 200 {array} model.Square
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 409 {object} model.APIError
+// @Failure 412 {object} model.ContestConflictErrorSwagger
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/squares/claim [post]
+func (h *contestHandler) ClaimSquares(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	// parse contest id from path
+	contestIDParam := c.Param("id")
+	if contestIDParam == "" {
+		log.Warn("contest id not provided")
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Contest ID is required", c))
+		return
+	}
+
+	contestID, err := uuid.Parse(contestIDParam)
+	if err != nil {
+		log.Warn("invalid contest id", "param", contestIDParam, "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid contest ID format", c))
+		return
+	}
+
+	var req model.ClaimSquaresRequest
+	if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
+		log.Warn("invalid request body", "error", bindErr)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(errs.ErrInvalidRequestBody), c))
+		return
+	}
+
+	// get authenticated user and claim the batch
+	user := c.GetString(model.UserKey)
+	claimedSquares, err := h.contestService.ClaimSquares(c.Request.Context(), contestID, req.SquareIDs, user)
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrContestVersionMismatch):
+			h.respondVersionConflict(c, contestID, err)
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(errs.ErrSquareNotFound), c))
+		case errors.Is(err, errs.ErrSquareNotEditable), errors.Is(err, errs.ErrUnauthorizedSquareEdit), errors.Is(err, errs.ErrNotParticipant):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrSquareAlreadyClaimed), errors.Is(err, errs.ErrMissingInitials):
+			c.JSON(http.StatusConflict, model.NewAPIError(http.StatusConflict, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrClaimsNotFound):
+			c.JSON(http.StatusUnauthorized, model.NewAPIError(http.StatusUnauthorized, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrDatabaseUnavailable):
+			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, util.CapitalizeFirstLetter(err), c))
+		default:
+			c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(err), c))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, claimedSquares)
+}
+
+// @Summary Assign a square to a participant
+// @Description Gives a square to a participant on the owner's behalf. A square another participant holds is only replaced when override is set; otherwise 409. The participant's square limit still applies
+// @Tags contests
+// @Accept json
+// @Produce json
+// @Param id path string true "Contest ID"
+// @Param squareId path string true "Square ID"
+// @Param request body model.AssignSquareRequest true "Participant to receive the square"
+// @Param If-Match header string false "ETag of the square version being assigned"
+// @Success 200 {object} model.Square
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 409 {object} model.APIError
+// @Failure 412 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/squares/{squareId}/assign [post]
+func (h *contestHandler) AssignSquare(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	// parse contest id from path
+	contestIDParam := c.Param("id")
+	if contestIDParam == "" {
+		log.Warn("contest id not provided")
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Contest ID is required", c))
+		return
+	}
+
+	contestID, err := uuid.Parse(contestIDParam)
+	if err != nil {
+		log.Warn("invalid contest id", "param", contestIDParam, "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid contest ID format", c))
+		return
+	}
+
+	// parse square id from path
+	squareIDParam := c.Param("squareId")
+	if squareIDParam == "" {
+		log.Warn("square id not provided")
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Square ID is required", c))
+		return
+	}
+
+	squareID, err := uuid.Parse(squareIDParam)
+	if err != nil {
+		log.Warn("invalid square id", "param", squareIDParam, "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid square ID format", c))
+		return
+	}
+
+	var req model.AssignSquareRequest
+	if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
+		log.Warn("invalid request body", "error", bindErr)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(errs.ErrInvalidRequestBody), c))
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	assignedSquare, err := h.contestService.AssignSquare(c.Request.Context(), contestID, squareID, &req, user)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(errs.ErrSquareNotFound), c))
+		case errors.Is(err, errs.ErrSquareNotEditable), errors.Is(err, errs.ErrUnauthorizedContestEdit):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrSquareAlreadyClaimed):
+			c.JSON(http.StatusConflict, model.NewAPIError(http.StatusConflict, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrSquareVersionMismatch):
+			c.JSON(http.StatusPreconditionFailed, model.NewAPIError(http.StatusPreconditionFailed, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrDatabaseUnavailable):
+			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, util.CapitalizeFirstLetter(err), c))
+		default:
+			c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(err), c))
+		}
+		return
+	}
+
+	c.Header("ETag", util.FormatETag(assignedSquare.Version))
+	c.JSON(http.StatusOK, assignedSquare)
+}
@@ -120,6 +120,86 @@ func TestGetContestsByOwner_TrimsSearchQuery(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
+// ====================
+// GetContest
+// ====================
+
+func TestGetContest_Success(t *testing.T) {
+	contestID := uuid.New()
+	svc := mocks.NewContestService(t)
+	svc.EXPECT().GetContest(mock.Anything, contestID, "user1").
+		Return(&model.Contest{ID: contestID, Name: "C1", Version: 4}, nil)
+	h := NewContestHandler(svc)
+
+	r := gin.New()
+	r.Use(authenticatedMiddleware("user1"))
+	r.GET("/contests/:id", h.GetContest)
+
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/contests/%s", contestID), http.NoBody)
+	w := doRequest(r, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+	var resp model.Contest
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 4, resp.Version)
+}
+
+func TestGetContest_NotModified(t *testing.T) {
+	contestID := uuid.New()
+	svc := mocks.NewContestService(t)
+	svc.EXPECT().GetContest(mock.Anything, mock.Anything, mock.Anything).
+		Return(&model.Contest{ID: contestID, Version: 4}, nil)
+	h := NewContestHandler(svc)
+
+	r := gin.New()
+	r.Use(authenticatedMiddleware("user1"))
+	r.GET("/contests/:id", h.GetContest)
+
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/contests/%s", contestID), http.NoBody)
+	req.Header.Set("If-None-Match", `"4"`)
+	w := doRequest(r, req)
+
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.Bytes())
+}
+
+func TestGetContest_InvalidID(t *testing.T) {
+	h := NewContestHandler(mocks.NewContestService(t))
+	r := gin.New()
+	r.Use(authenticatedMiddleware("user1"))
+	r.GET("/contests/:id", h.GetContest)
+
+	req, _ := http.NewRequest(http.MethodGet, "/contests/not-a-uuid", http.NoBody)
+	w := doRequest(r, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetContest_NotFound(t *testing.T) {
+	getContestErr(t, gorm.ErrRecordNotFound, http.StatusNotFound)
+}
+func TestGetContest_Forbidden(t *testing.T) {
+	getContestErr(t, errs.ErrNotParticipant, http.StatusForbidden)
+}
+func TestGetContest_DatabaseUnavailable(t *testing.T) {
+	getContestErr(t, errs.ErrDatabaseUnavailable, http.StatusInternalServerError)
+}
+
+func getContestErr(t *testing.T, svcErr error, wantCode int) {
+	t.Helper()
+	svc := mocks.NewContestService(t)
+	svc.EXPECT().GetContest(mock.Anything, mock.Anything, mock.Anything).Return(nil, svcErr)
+	h := NewContestHandler(svc)
+
+	r := gin.New()
+	r.Use(authenticatedMiddleware("user1"))
+	r.GET("/contests/:id", h.GetContest)
+
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/contests/%s", uuid.New()), http.NoBody)
+	w := doRequest(r, req)
+	assert.Equal(t, wantCode, w.Code)
+}
+
 // ====================
 // CreateContest
 // ====================
@@ -180,6 +260,15 @@ func TestCreateContest_DatabaseUnavailable(t *testing.T) {
 func TestCreateContest_InternalError(t *testing.T) {
 	createContestErr(t, assert.AnError, http.StatusInternalServerError)
 }
+func TestCreateContest_NotOrgMember(t *testing.T) {
+	createContestErr(t, errs.ErrNotOrgMember, http.StatusForbidden)
+}
+func TestCreateContest_OrgNotFound(t *testing.T) {
+	createContestErr(t, errs.ErrOrganizationNotFound, http.StatusNotFound)
+}
+func TestCreateContest_OrgMembersExceedPool(t *testing.T) {
+	createContestErr(t, errs.ErrNotEnoughSquares, http.StatusBadRequest)
+}
 
 func createContestErr(t *testing.T, svcErr error, wantCode int) {
 	t.Helper()
@@ -203,7 +292,7 @@ func TestUpdateContest_Success(t *testing.T) {
 	contestID := uuid.New()
 	svc := mocks.NewContestService(t)
 	svc.EXPECT().UpdateContest(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-		Return(&model.Contest{ID: contestID, Name: "Updated", Status: model.ContestStatusActive}, nil)
+		Return(&model.Contest{ID: contestID, Name: "Updated", Status: model.ContestStatusActive, Version: 2}, nil)
 	h := NewContestHandler(svc)
 
 	r := gin.New()
@@ -213,6 +302,7 @@ func TestUpdateContest_Success(t *testing.T) {
 	home := "Eagles"
 	w := doRequest(r, jsonReq(http.MethodPatch, fmt.Sprintf("/contests/%s", contestID), model.UpdateContestRequest{HomeTeam: &home}))
 	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"2"`, w.Header().Get("ETag"))
 }
 
 func TestUpdateContest_InvalidID(t *testing.T) {
@@ -255,6 +345,56 @@ func TestUpdateContest_OtherError(t *testing.T) {
 	updateContestErr(t, "owner1", errs.ErrContestNotEditable, http.StatusBadRequest)
 }
 
+func TestUpdateContest_VersionConflict(t *testing.T) {
+	updateContestConflict(t, errs.ErrContestVersionConflict, http.StatusConflict)
+}
+func TestUpdateContest_VersionMismatch(t *testing.T) {
+	updateContestConflict(t, errs.ErrContestVersionMismatch, http.StatusPreconditionFailed)
+}
+
+// a rejected write comes back with the contest as it stands now
+func updateContestConflict(t *testing.T, svcErr error, wantCode int) {
+	t.Helper()
+	contestID := uuid.New()
+	svc := mocks.NewContestService(t)
+	svc.EXPECT().UpdateContest(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, svcErr)
+	svc.EXPECT().GetContest(mock.Anything, contestID, "owner1").
+		Return(&model.Contest{ID: contestID, HomeTeam: "Bills", Version: 6}, nil)
+	h := NewContestHandler(svc)
+
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.PATCH("/contests/:id", h.UpdateContest)
+
+	home := "Eagles"
+	w := doRequest(r, jsonReq(http.MethodPatch, fmt.Sprintf("/contests/%s", contestID), model.UpdateContestRequest{HomeTeam: &home}))
+
+	assert.Equal(t, wantCode, w.Code)
+	assert.Equal(t, `"6"`, w.Header().Get("ETag"))
+	var resp model.ContestConflictError
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, wantCode, resp.Code)
+	require.NotNil(t, resp.Contest)
+	assert.Equal(t, "Bills", resp.Contest.HomeTeam)
+}
+
+func TestUpdateContest_VersionConflictReloadFails(t *testing.T) {
+	svc := mocks.NewContestService(t)
+	svc.EXPECT().UpdateContest(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errs.ErrContestVersionConflict)
+	svc.EXPECT().GetContest(mock.Anything, mock.Anything, mock.Anything).Return(nil, errs.ErrDatabaseUnavailable)
+	h := NewContestHandler(svc)
+
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.PATCH("/contests/:id", h.UpdateContest)
+
+	home := "Eagles"
+	w := doRequest(r, jsonReq(http.MethodPatch, fmt.Sprintf("/contests/%s", uuid.New()), model.UpdateContestRequest{HomeTeam: &home}))
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Empty(t, w.Header().Get("ETag"))
+}
+
 func updateContestErr(t *testing.T, user string, svcErr error, wantCode int) {
 	t.Helper()
 	svc := mocks.NewContestService(t)
@@ -312,6 +452,24 @@ func TestDeleteContest_InternalError(t *testing.T) {
 	deleteContestErr(t, "owner1", assert.AnError, http.StatusInternalServerError)
 }
 
+func TestDeleteContest_VersionMismatch(t *testing.T) {
+	contestID := uuid.New()
+	svc := mocks.NewContestService(t)
+	svc.EXPECT().DeleteContest(mock.Anything, mock.Anything, mock.Anything).Return(errs.ErrContestVersionMismatch)
+	svc.EXPECT().GetContest(mock.Anything, contestID, mock.Anything).
+		Return(&model.Contest{ID: contestID, Status: model.ContestStatusActive, Version: 7}, nil)
+	h := NewContestHandler(svc)
+
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.DELETE("/contests/:id", h.DeleteContest)
+
+	req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/contests/%s", contestID), http.NoBody)
+	w := doRequest(r, req)
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	assert.Equal(t, `"7"`, w.Header().Get("ETag"))
+}
+
 func deleteContestErr(t *testing.T, user string, svcErr error, wantCode int) {
 	t.Helper()
 	svc := mocks.NewContestService(t)
@@ -327,6 +485,71 @@ func deleteContestErr(t *testing.T, user string, svcErr error, wantCode int) {
 	assert.Equal(t, wantCode, w.Code)
 }
 
+// ====================
+// RestoreContest
+// ====================
+
+func TestRestoreContest_Success(t *testing.T) {
+	svc := mocks.NewContestService(t)
+	svc.EXPECT().RestoreContest(mock.Anything, mock.Anything, "owner1").Return(&model.Contest{Status: model.ContestStatusQ1, Version: 6}, nil)
+	h := NewContestHandler(svc)
+
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.POST("/contests/:id/restore", h.RestoreContest)
+
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/contests/%s/restore", uuid.New()), http.NoBody)
+	w := doRequest(r, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"6"`, w.Header().Get("ETag"))
+	assert.Contains(t, w.Body.String(), `"status":"Q1"`)
+}
+
+func TestRestoreContest_InvalidID(t *testing.T) {
+	h := NewContestHandler(mocks.NewContestService(t))
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.POST("/contests/:id/restore", h.RestoreContest)
+
+	req, _ := http.NewRequest(http.MethodPost, "/contests/bad-id/restore", http.NoBody)
+	w := doRequest(r, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRestoreContest_NotFound(t *testing.T) {
+	restoreContestErr(t, gorm.ErrRecordNotFound, http.StatusNotFound)
+}
+func TestRestoreContest_Forbidden(t *testing.T) {
+	restoreContestErr(t, errs.ErrUnauthorizedContestRestore, http.StatusForbidden)
+}
+func TestRestoreContest_WindowPassed(t *testing.T) {
+	restoreContestErr(t, errs.ErrContestRestoreExpired, http.StatusGone)
+}
+func TestRestoreContest_NotRestorable(t *testing.T) {
+	restoreContestErr(t, errs.ErrContestNotRestorable, http.StatusGone)
+}
+func TestRestoreContest_NameTaken(t *testing.T) {
+	restoreContestErr(t, errs.ErrContestAlreadyExists, http.StatusConflict)
+}
+func TestRestoreContest_InternalError(t *testing.T) {
+	restoreContestErr(t, errs.ErrDatabaseUnavailable, http.StatusInternalServerError)
+}
+
+func restoreContestErr(t *testing.T, svcErr error, wantCode int) {
+	t.Helper()
+	svc := mocks.NewContestService(t)
+	svc.EXPECT().RestoreContest(mock.Anything, mock.Anything, mock.Anything).Return(nil, svcErr)
+	h := NewContestHandler(svc)
+
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.POST("/contests/:id/restore", h.RestoreContest)
+
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/contests/%s/restore", uuid.New()), http.NoBody)
+	w := doRequest(r, req)
+	assert.Equal(t, wantCode, w.Code)
+}
+
 // ====================
 // StartContest
 // ====================
@@ -365,6 +588,24 @@ func TestStartContest_OtherError(t *testing.T) {
 	startContestErr(t, errs.ErrContestNotEditable, http.StatusBadRequest)
 }
 
+func TestStartContest_VersionMismatch(t *testing.T) {
+	contestID := uuid.New()
+	svc := mocks.NewContestService(t)
+	svc.EXPECT().StartContest(mock.Anything, mock.Anything, mock.Anything).Return(nil, errs.ErrContestVersionMismatch)
+	svc.EXPECT().GetContest(mock.Anything, contestID, mock.Anything).
+		Return(&model.Contest{ID: contestID, Status: model.ContestStatusActive, Version: 3}, nil)
+	h := NewContestHandler(svc)
+
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.POST("/contests/:id/start", h.StartContest)
+
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/contests/%s/start", contestID), http.NoBody)
+	w := doRequest(r, req)
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+}
+
 func startContestErr(t *testing.T, svcErr error, wantCode int) {
 	t.Helper()
 	svc := mocks.NewContestService(t)
@@ -558,6 +799,15 @@ func TestClaimSquare_SquareNotEditable(t *testing.T) {
 func TestClaimSquare_UnauthorizedSquareEdit(t *testing.T) {
 	claimSquareErr(t, "stranger", errs.ErrUnauthorizedSquareEdit, http.StatusForbidden)
 }
+func TestClaimSquare_AlreadyClaimed(t *testing.T) {
+	claimSquareErr(t, "owner1", errs.ErrSquareAlreadyClaimed, http.StatusConflict)
+}
+func TestClaimSquare_NotParticipant(t *testing.T) {
+	claimSquareErr(t, "stranger", errs.ErrNotParticipant, http.StatusForbidden)
+}
+func TestClaimSquare_VersionMismatch(t *testing.T) {
+	claimSquareErr(t, "owner1", errs.ErrSquareVersionMismatch, http.StatusPreconditionFailed)
+}
 func TestClaimSquare_MissingInitials(t *testing.T) {
 	claimSquareErr(t, "owner1", errs.ErrMissingInitials, http.StatusConflict)
 }
@@ -586,6 +836,106 @@ func claimSquareErr(t *testing.T, user string, svcErr error, wantCode int) {
 	assert.Equal(t, wantCode, w.Code)
 }
 
+// ====================
+// ClaimSquares
+// ====================
+
+func TestClaimSquares_Success(t *testing.T) {
+	contestID, a, b := uuid.New(), uuid.New(), uuid.New()
+	svc := mocks.NewContestService(t)
+	svc.EXPECT().ClaimSquares(mock.Anything, contestID, []uuid.UUID{a, b}, "owner1").
+		Return([]model.Square{{ID: a, Owner: "owner1"}, {ID: b, Owner: "owner1"}}, nil)
+	h := NewContestHandler(svc)
+
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.POST("/contests/:id/squares/claim", h.ClaimSquares)
+
+	body, _ := json.Marshal(model.ClaimSquaresRequest{SquareIDs: []uuid.UUID{a, b}})
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/contests/%s/squares/claim", contestID), bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := doRequest(r, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestClaimSquares_EmptyBody(t *testing.T) {
+	h := NewContestHandler(mocks.NewContestService(t))
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.POST("/contests/:id/squares/claim", h.ClaimSquares)
+
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/contests/%s/squares/claim", uuid.New()), bytes.NewReader([]byte(`{"squareIds":[]}`)))
+	req.Header.Set("Content-Type", "application/json")
+	w := doRequest(r, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestClaimSquares_AlreadyClaimed(t *testing.T) {
+	claimSquaresErr(t, errs.ErrSquareAlreadyClaimed, http.StatusConflict)
+}
+func TestClaimSquares_LimitReached(t *testing.T) {
+	claimSquaresErr(t, errs.ErrSquareLimitReached, http.StatusBadRequest)
+}
+func TestClaimSquares_NotParticipant(t *testing.T) {
+	claimSquaresErr(t, errs.ErrNotParticipant, http.StatusForbidden)
+}
+
+func claimSquaresErr(t *testing.T, svcErr error, wantCode int) {
+	t.Helper()
+	svc := mocks.NewContestService(t)
+	svc.EXPECT().ClaimSquares(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, svcErr)
+	h := NewContestHandler(svc)
+
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.POST("/contests/:id/squares/claim", h.ClaimSquares)
+
+	body, _ := json.Marshal(model.ClaimSquaresRequest{SquareIDs: []uuid.UUID{uuid.New()}})
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/contests/%s/squares/claim", uuid.New()), bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := doRequest(r, req)
+	assert.Equal(t, wantCode, w.Code)
+}
+
+// ====================
+// AssignSquare
+// ====================
+
+func TestAssignSquare_Success(t *testing.T) {
+	contestID, squareID := uuid.New(), uuid.New()
+	svc := mocks.NewContestService(t)
+	svc.EXPECT().AssignSquare(mock.Anything, contestID, squareID, &model.AssignSquareRequest{UserID: "player1"}, "owner1").
+		Return(&model.Square{ID: squareID, Owner: "player1"}, nil)
+	h := NewContestHandler(svc)
+
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.POST("/contests/:id/squares/:squareId/assign", h.AssignSquare)
+
+	body, _ := json.Marshal(model.AssignSquareRequest{UserID: "player1"})
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/contests/%s/squares/%s/assign", contestID, squareID), bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := doRequest(r, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAssignSquare_Forbidden(t *testing.T) {
+	svc := mocks.NewContestService(t)
+	svc.EXPECT().AssignSquare(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errs.ErrUnauthorizedContestEdit)
+	h := NewContestHandler(svc)
+
+	r := gin.New()
+	r.Use(authenticatedMiddleware("stranger"))
+	r.POST("/contests/:id/squares/:squareId/assign", h.AssignSquare)
+
+	body, _ := json.Marshal(model.AssignSquareRequest{UserID: "player1"})
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/contests/%s/squares/%s/assign", uuid.New(), uuid.New()), bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := doRequest(r, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
 // ====================
 // ClearSquare
 // ====================
@@ -634,6 +984,9 @@ func TestClearSquare_Forbidden(t *testing.T) {
 func TestClearSquare_SquareNotEditable(t *testing.T) {
 	clearSquareErr(t, "owner1", errs.ErrSquareNotEditable, http.StatusForbidden)
 }
+func TestClearSquare_VersionConflict(t *testing.T) {
+	clearSquareErr(t, "owner1", errs.ErrSquareVersionConflict, http.StatusConflict)
+}
 func TestClearSquare_DatabaseUnavailable(t *testing.T) {
 	clearSquareErr(t, "owner1", errs.ErrDatabaseUnavailable, http.StatusInternalServerError)
 }
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/service"
+	"github.com/maxmorhardt/squares-api/internal/util"
+	"gorm.io/gorm"
+)
+
+type ExportHandler interface {
+	ExportContest(c *gin.Context)
+	ImportContest(c *gin.Context)
+}
+
+type exportHandler struct {
+	exportService service.ExportService
+}
+
+func NewExportHandler(exportService service.ExportService) ExportHandler {
+	return &exportHandler{
+		exportService: exportService,
+	}
+}
+
+// @Summary Export a contest
+// @Description Returns a versioned JSON document with the contest, its labels, squares, quarter results, participants and invite settings. Only the contest owner can export
+// @Tags contests
+// @Produce json
+// @Param id path string true "Contest ID"
+// @Success 200 {object} model.ContestExport
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/export [get]
+func (h *exportHandler) ExportContest(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	contestIDParam := c.Param("id")
+	contestID, err := uuid.Parse(contestIDParam)
+	if err != nil {
+		log.Warn("invalid contest id", "param", contestIDParam, "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid contest ID format", c))
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	doc, err := h.exportService.ExportContest(c.Request.Context(), contestID, user)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(errs.ErrContestNotFound), c))
+		case errors.Is(err, errs.ErrUnauthorizedContestExport):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
+		default:
+			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to export contest", c))
+		}
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="contest-%s.json"`, contestID))
+	c.JSON(http.StatusOK, doc)
+}
+
+// @Summary Import a contest
+// @Description Recreates an exported contest under the caller's ownership with new IDs. Invites are informational only and are not recreated
+// @Tags contests
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the first response for 24h"
+// @Param document body model.ContestExport true "Exported contest document"
+// @Success 201 {object} model.ContestSwagger
+// @Failure 400 {object} model.APIError
+// @Failure 409 {object} model.APIError
+// @Failure 422 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/import [post]
+func (h *exportHandler) ImportContest(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	var doc model.ContestExport
+	if err := c.ShouldBindJSON(&doc); err != nil {
+		log.Warn("failed to bind contest export json", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(errs.ErrInvalidRequestBody), c))
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	contest, err := h.exportService.ImportContest(c.Request.Context(), &doc, user)
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrUnsupportedExportVersion):
+			c.JSON(http.StatusUnprocessableEntity, model.NewAPIError(http.StatusUnprocessableEntity, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrInvalidContestExport):
+			c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrContestAlreadyExists):
+			c.JSON(http.StatusConflict, model.NewAPIError(http.StatusConflict, util.CapitalizeFirstLetter(err), c))
+		default:
+			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to import contest", c))
+		}
+		return
+	}
+
+	c.Header("ETag", util.FormatETag(contest.Version))
+	c.JSON(http.StatusCreated, contest)
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/mocks"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// ====================
+// ExportContest
+// ====================
+
+func TestExportContest_Success(t *testing.T) {
+	contestID := uuid.New()
+	svc := mocks.NewExportService(t)
+	svc.EXPECT().ExportContest(mock.Anything, contestID, "owner1").
+		Return(&model.ContestExport{SchemaVersion: model.ContestExportSchemaVersion, Contest: model.ExportedContest{ID: contestID, Name: "C1"}}, nil)
+	h := NewExportHandler(svc)
+
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.GET("/contests/:id/export", h.ExportContest)
+
+	req, _ := http.NewRequest(http.MethodGet, "/contests/"+contestID.String()+"/export", http.NoBody)
+	w := doRequest(r, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Disposition"), contestID.String())
+	var resp model.ContestExport
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, model.ContestExportSchemaVersion, resp.SchemaVersion)
+	assert.Equal(t, "C1", resp.Contest.Name)
+}
+
+func TestExportContest_InvalidID(t *testing.T) {
+	h := NewExportHandler(mocks.NewExportService(t))
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.GET("/contests/:id/export", h.ExportContest)
+
+	req, _ := http.NewRequest(http.MethodGet, "/contests/bad/export", http.NoBody)
+	w := doRequest(r, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestExportContest_NotFound(t *testing.T) {
+	exportContestErr(t, gorm.ErrRecordNotFound, http.StatusNotFound)
+}
+func TestExportContest_Forbidden(t *testing.T) {
+	exportContestErr(t, errs.ErrUnauthorizedContestExport, http.StatusForbidden)
+}
+func TestExportContest_InternalError(t *testing.T) {
+	exportContestErr(t, errs.ErrDatabaseUnavailable, http.StatusInternalServerError)
+}
+
+func exportContestErr(t *testing.T, svcErr error, wantCode int) {
+	t.Helper()
+	svc := mocks.NewExportService(t)
+	svc.EXPECT().ExportContest(mock.Anything, mock.Anything, mock.Anything).Return(nil, svcErr)
+	h := NewExportHandler(svc)
+
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.GET("/contests/:id/export", h.ExportContest)
+
+	req, _ := http.NewRequest(http.MethodGet, "/contests/"+uuid.New().String()+"/export", http.NoBody)
+	w := doRequest(r, req)
+	assert.Equal(t, wantCode, w.Code)
+}
+
+// ====================
+// ImportContest
+// ====================
+
+func validExport() model.ContestExport {
+	labels := []int8{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	return model.ContestExport{
+		SchemaVersion: model.ContestExportSchemaVersion,
+		Contest:       model.ExportedContest{Name: "Imported", XLabels: labels, YLabels: labels, Status: "Q1"},
+		Squares:       []model.ExportedSquare{{Row: 1, Col: 2, Value: "AB", Owner: "a@b.com"}},
+		Participants:  []model.ExportedParticipant{{UserID: "a@b.com", Role: "participant", MaxSquares: 5}},
+	}
+}
+
+func TestImportContest_Success(t *testing.T) {
+	contestID := uuid.New()
+	svc := mocks.NewExportService(t)
+	svc.EXPECT().ImportContest(mock.Anything, mock.Anything, "owner1").
+		Return(&model.Contest{ID: contestID, Name: "Imported", Owner: "owner1", Version: 1}, nil)
+	h := NewExportHandler(svc)
+
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.POST("/contests/import", h.ImportContest)
+
+	w := doRequest(r, jsonReq(http.MethodPost, "/contests/import", validExport()))
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+	var resp model.Contest
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, contestID, resp.ID)
+}
+
+func TestImportContest_InvalidBody(t *testing.T) {
+	h := NewExportHandler(mocks.NewExportService(t))
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.POST("/contests/import", h.ImportContest)
+
+	req, _ := http.NewRequest(http.MethodPost, "/contests/import", bytes.NewReader([]byte(`{invalid`)))
+	req.Header.Set("Content-Type", "application/json")
+	w := doRequest(r, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestImportContest_BadLabels(t *testing.T) {
+	h := NewExportHandler(mocks.NewExportService(t))
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.POST("/contests/import", h.ImportContest)
+
+	doc := validExport()
+	doc.Contest.XLabels = doc.Contest.XLabels[:5]
+	w := doRequest(r, jsonReq(http.MethodPost, "/contests/import", doc))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestImportContest_UnsupportedVersion(t *testing.T) {
+	importContestErr(t, errs.ErrUnsupportedExportVersion, http.StatusUnprocessableEntity)
+}
+func TestImportContest_Inconsistent(t *testing.T) {
+	importContestErr(t, errs.ErrInvalidContestExport, http.StatusBadRequest)
+}
+func TestImportContest_AlreadyExists(t *testing.T) {
+	importContestErr(t, errs.ErrContestAlreadyExists, http.StatusConflict)
+}
+func TestImportContest_InternalError(t *testing.T) {
+	importContestErr(t, errs.ErrDatabaseUnavailable, http.StatusInternalServerError)
+}
+
+func importContestErr(t *testing.T, svcErr error, wantCode int) {
+	t.Helper()
+	svc := mocks.NewExportService(t)
+	svc.EXPECT().ImportContest(mock.Anything, mock.Anything, mock.Anything).Return(nil, svcErr)
+	h := NewExportHandler(svc)
+
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.POST("/contests/import", h.ImportContest)
+
+	w := doRequest(r, jsonReq(http.MethodPost, "/contests/import", validExport()))
+	assert.Equal(t, wantCode, w.Code)
+}
@@ -0,0 +1,234 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/service"
+	"github.com/maxmorhardt/squares-api/internal/util"
+	"gorm.io/gorm"
+)
+
+type FriendHandler interface {
+	GetFriends(c *gin.Context)
+	GetFriendRequests(c *gin.Context)
+	SendFriendRequest(c *gin.Context)
+	AcceptFriendRequest(c *gin.Context)
+	RemoveFriend(c *gin.Context)
+	SearchUsers(c *gin.Context)
+	AddFriendToContest(c *gin.Context)
+}
+
+type friendHandler struct {
+	friendService service.FriendService
+}
+
+func NewFriendHandler(friendService service.FriendService) FriendHandler {
+	return &friendHandler{
+		friendService: friendService,
+	}
+}
+
+// @Summary Get the caller's friends
+// @Description Returns every accepted friend of the authenticated user
+// @Tags friends
+// @Produce json
+// @Success 200 {array} model.Friend
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /users/me/friends [get]
+func (h *friendHandler) GetFriends(c *gin.Context) {
+	user := c.GetString(model.UserKey)
+	friends, err := h.friendService.GetFriends(c.Request.Context(), user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to get friends", c))
+		return
+	}
+
+	c.JSON(http.StatusOK, friends)
+}
+
+// @Summary Get pending friend requests
+// @Description Returns the friend requests the caller has received and the ones they are still waiting on
+// @Tags friends
+// @Produce json
+// @Success 200 {object} model.FriendRequestsResponse
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /users/me/friends/requests [get]
+func (h *friendHandler) GetFriendRequests(c *gin.Context) {
+	user := c.GetString(model.UserKey)
+	requests, err := h.friendService.GetRequests(c.Request.Context(), user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to get friend requests", c))
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// @Summary Send a friend request
+// @Description Sends a friend request by email. If that user already asked the caller, their request is accepted instead
+// @Tags friends
+// @Accept json
+// @Produce json
+// @Param request body model.FriendRequest true "User to befriend"
+// @Success 200 {object} model.Friendship
+// @Failure 400 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 409 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /users/me/friends/requests [post]
+func (h *friendHandler) SendFriendRequest(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	var req model.FriendRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warn("failed to bind friend request json", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(errs.ErrInvalidRequestBody), c))
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	friendship, err := h.friendService.SendRequest(c.Request.Context(), user, req.UserID)
+	if err != nil {
+		respondFriendError(c, err, "Failed to send friend request")
+		return
+	}
+
+	c.JSON(http.StatusOK, friendship)
+}
+
+// @Summary Accept a friend request
+// @Description Accepts the pending friend request the given user sent the caller
+// @Tags friends
+// @Produce json
+// @Param userId path string true "Requester user ID"
+// @Success 200 {object} model.Friendship
+// @Failure 404 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /users/me/friends/requests/{userId}/accept [post]
+func (h *friendHandler) AcceptFriendRequest(c *gin.Context) {
+	user := c.GetString(model.UserKey)
+	friendship, err := h.friendService.AcceptRequest(c.Request.Context(), user, c.Param("userId"))
+	if err != nil {
+		respondFriendError(c, err, "Failed to accept friend request")
+		return
+	}
+
+	c.JSON(http.StatusOK, friendship)
+}
+
+// @Summary Remove a friend
+// @Description Unfriends a user, or declines or cancels a pending request with them
+// @Tags friends
+// @Param userId path string true "Friend user ID"
+// @Success 204
+// @Failure 404 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /users/me/friends/{userId} [delete]
+func (h *friendHandler) RemoveFriend(c *gin.Context) {
+	user := c.GetString(model.UserKey)
+	if err := h.friendService.RemoveFriend(c.Request.Context(), user, c.Param("userId")); err != nil {
+		respondFriendError(c, err, "Failed to remove friend")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Search users by display name
+// @Description Searches the caller's friends and the people they share a contest with. Friends are listed first
+// @Tags friends
+// @Produce json
+// @Param q query string true "Display name to search for (2-50 characters)"
+// @Success 200 {array} model.UserSearchResult
+// @Failure 400 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /users/search [get]
+func (h *friendHandler) SearchUsers(c *gin.Context) {
+	user := c.GetString(model.UserKey)
+	results, err := h.friendService.SearchUsers(c.Request.Context(), user, c.Query("q"))
+	if err != nil {
+		respondFriendError(c, err, "Failed to search users")
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// @Summary Add a friend to a contest
+// @Description Owner adds one of their friends directly as a participant with a square limit, without an invite link
+// @Tags participants
+// @Accept json
+// @Produce json
+// @Param id path string true "Contest ID"
+// @Param participant body model.AddFriendParticipantRequest true "Friend and square limit"
+// @Success 200 {object} model.ContestParticipant
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 409 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/participants [post]
+func (h *friendHandler) AddFriendToContest(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		log.Warn("invalid contest id", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid contest ID", c))
+		return
+	}
+
+	var req model.AddFriendParticipantRequest
+	if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
+		log.Warn("failed to bind add friend participant json", "error", bindErr)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(errs.ErrInvalidRequestBody), c))
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	participant, err := h.friendService.AddFriendToContest(c.Request.Context(), contestID, &req, user)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(errs.ErrContestNotFound), c))
+		case errors.Is(err, errs.ErrNotParticipant), errors.Is(err, errs.ErrInsufficientRole):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(errs.ErrInsufficientRole), c))
+		case errors.Is(err, errs.ErrNotFriend), errors.Is(err, errs.ErrBannedFromContest):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrAlreadyParticipant):
+			c.JSON(http.StatusConflict, model.NewAPIError(http.StatusConflict, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrNotEnoughSquares), errors.Is(err, errs.ErrContestFinalized):
+			c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(err), c))
+		default:
+			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to add friend to contest", c))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, participant)
+}
+
+func respondFriendError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, errs.ErrUserNotFound), errors.Is(err, errs.ErrFriendRequestNotFound), errors.Is(err, errs.ErrFriendNotFound):
+		c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(err), c))
+	case errors.Is(err, errs.ErrAlreadyFriends), errors.Is(err, errs.ErrFriendRequestExists):
+		c.JSON(http.StatusConflict, model.NewAPIError(http.StatusConflict, util.CapitalizeFirstLetter(err), c))
+	case errors.Is(err, errs.ErrCannotFriendSelf), errors.Is(err, errs.ErrInvalidSearchQuery):
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(err), c))
+	default:
+		c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, fallback, c))
+	}
+}
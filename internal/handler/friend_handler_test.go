@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/mocks"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func friendRouter(svc *mocks.FriendService) *gin.Engine {
+	h := NewFriendHandler(svc)
+	r := gin.New()
+	r.Use(authenticatedMiddleware("user1"))
+	r.GET("/users/me/friends", h.GetFriends)
+	r.GET("/users/me/friends/requests", h.GetFriendRequests)
+	r.POST("/users/me/friends/requests", h.SendFriendRequest)
+	r.POST("/users/me/friends/requests/:userId/accept", h.AcceptFriendRequest)
+	r.DELETE("/users/me/friends/:userId", h.RemoveFriend)
+	r.GET("/users/search", h.SearchUsers)
+	r.POST("/contests/:id/participants", h.AddFriendToContest)
+	return r
+}
+
+func TestGetFriends_Success(t *testing.T) {
+	svc := mocks.NewFriendService(t)
+	svc.EXPECT().GetFriends(mock.Anything, "user1").Return([]model.Friend{{UserID: "f@x.com", DisplayName: "Fay"}}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/users/me/friends", http.NoBody)
+	w := doRequest(friendRouter(svc), req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp []model.Friend
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "Fay", resp[0].DisplayName)
+}
+
+func TestGetFriendRequests_Success(t *testing.T) {
+	svc := mocks.NewFriendService(t)
+	svc.EXPECT().GetRequests(mock.Anything, "user1").Return(&model.FriendRequestsResponse{Incoming: []model.Friendship{{Requester: "f@x.com"}}}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/users/me/friends/requests", http.NoBody)
+	w := doRequest(friendRouter(svc), req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestSendFriendRequest_Success(t *testing.T) {
+	svc := mocks.NewFriendService(t)
+	svc.EXPECT().SendRequest(mock.Anything, "user1", "f@x.com").Return(&model.Friendship{Status: model.FriendshipStatusPending}, nil)
+
+	w := doRequest(friendRouter(svc), jsonReq(http.MethodPost, "/users/me/friends/requests", model.FriendRequest{UserID: "f@x.com"}))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestSendFriendRequest_InvalidEmail(t *testing.T) {
+	w := doRequest(friendRouter(mocks.NewFriendService(t)), jsonReq(http.MethodPost, "/users/me/friends/requests", model.FriendRequest{UserID: "not-an-email"}))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSendFriendRequest_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{errs.ErrUserNotFound, http.StatusNotFound},
+		{errs.ErrAlreadyFriends, http.StatusConflict},
+		{errs.ErrFriendRequestExists, http.StatusConflict},
+		{errs.ErrCannotFriendSelf, http.StatusBadRequest},
+		{errs.ErrDatabaseUnavailable, http.StatusInternalServerError},
+	} {
+		svc := mocks.NewFriendService(t)
+		svc.EXPECT().SendRequest(mock.Anything, mock.Anything, mock.Anything).Return(nil, tc.err)
+
+		w := doRequest(friendRouter(svc), jsonReq(http.MethodPost, "/users/me/friends/requests", model.FriendRequest{UserID: "f@x.com"}))
+		assert.Equal(t, tc.code, w.Code, tc.err.Error())
+	}
+}
+
+func TestAcceptFriendRequest_NotFound(t *testing.T) {
+	svc := mocks.NewFriendService(t)
+	svc.EXPECT().AcceptRequest(mock.Anything, "user1", "f@x.com").Return(nil, errs.ErrFriendRequestNotFound)
+
+	req, _ := http.NewRequest(http.MethodPost, "/users/me/friends/requests/f@x.com/accept", http.NoBody)
+	w := doRequest(friendRouter(svc), req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRemoveFriend_Success(t *testing.T) {
+	svc := mocks.NewFriendService(t)
+	svc.EXPECT().RemoveFriend(mock.Anything, "user1", "f@x.com").Return(nil)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/users/me/friends/f@x.com", http.NoBody)
+	w := doRequest(friendRouter(svc), req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestSearchUsers_InvalidQuery(t *testing.T) {
+	svc := mocks.NewFriendService(t)
+	svc.EXPECT().SearchUsers(mock.Anything, "user1", "m").Return(nil, errs.ErrInvalidSearchQuery)
+
+	req, _ := http.NewRequest(http.MethodGet, "/users/search?q=m", http.NoBody)
+	w := doRequest(friendRouter(svc), req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAddFriendToContest_Success(t *testing.T) {
+	contestID := uuid.New()
+	svc := mocks.NewFriendService(t)
+	svc.EXPECT().AddFriendToContest(mock.Anything, contestID, &model.AddFriendParticipantRequest{UserID: "f@x.com", MaxSquares: 5}, "user1").
+		Return(&model.ContestParticipant{UserID: "f@x.com", MaxSquares: 5}, nil)
+
+	w := doRequest(friendRouter(svc), jsonReq(http.MethodPost, "/contests/"+contestID.String()+"/participants", model.AddFriendParticipantRequest{UserID: "f@x.com", MaxSquares: 5}))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAddFriendToContest_ZeroSquares(t *testing.T) {
+	w := doRequest(friendRouter(mocks.NewFriendService(t)), jsonReq(http.MethodPost, "/contests/"+uuid.New().String()+"/participants", model.AddFriendParticipantRequest{UserID: "f@x.com"}))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAddFriendToContest_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{gorm.ErrRecordNotFound, http.StatusNotFound},
+		{errs.ErrInsufficientRole, http.StatusForbidden},
+		{errs.ErrNotFriend, http.StatusForbidden},
+		{errs.ErrBannedFromContest, http.StatusForbidden},
+		{errs.ErrAlreadyParticipant, http.StatusConflict},
+		{errs.ErrNotEnoughSquares, http.StatusBadRequest},
+		{errs.ErrContestFinalized, http.StatusBadRequest},
+		{errs.ErrDatabaseUnavailable, http.StatusInternalServerError},
+	} {
+		svc := mocks.NewFriendService(t)
+		svc.EXPECT().AddFriendToContest(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, tc.err)
+
+		w := doRequest(friendRouter(svc), jsonReq(http.MethodPost, "/contests/"+uuid.New().String()+"/participants", model.AddFriendParticipantRequest{UserID: "f@x.com", MaxSquares: 1}))
+		assert.Equal(t, tc.code, w.Code, tc.err.Error())
+	}
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"encoding/base64"
 	"errors"
 	"net/http"
 
@@ -13,11 +14,17 @@ import (
 	"gorm.io/gorm"
 )
 
+// 1x1 transparent gif served as the open-tracking pixel in invite emails
+var trackingPixel, _ = base64.StdEncoding.DecodeString("R0lGODlhAQABAIAAAAAAAP///yH5BAEAAAAALAAAAAABAAEAAAIBRAA7")
+
 type InviteHandler interface {
 	CreateInvite(c *gin.Context)
+	CreateEmailInvites(c *gin.Context)
 	GetInvitePreview(c *gin.Context)
+	TrackInviteOpen(c *gin.Context)
 	RedeemInvite(c *gin.Context)
 	GetInvites(c *gin.Context)
+	UpdateInvite(c *gin.Context)
 	DeleteInvite(c *gin.Context)
 }
 
@@ -32,7 +39,7 @@ func NewInviteHandler(inviteService service.InviteService) InviteHandler {
 }
 
 // @Summary Create an invite link for a contest
-// @Description Owner creates an invite link with specified role and square limit
+// @Description Owner creates an invite link with specified role and square limit. Optional allowed domains and emails limit who can redeem it by their verified email. Reserved squares are held for the invitee until the invite is redeemed, expires or is deleted
 // @Tags invites
 // @Accept json
 // @Produce json
@@ -42,6 +49,7 @@ func NewInviteHandler(inviteService service.InviteService) InviteHandler {
 // @Failure 400 {object} model.APIError
 // @Failure 403 {object} model.APIError
 // @Failure 404 {object} model.APIError
+// @Failure 409 {object} model.APIError
 // @Failure 500 {object} model.APIError
 // @Security BearerAuth
 // @Router /contests/{id}/invites [post]
@@ -68,12 +76,14 @@ func (h *inviteHandler) CreateInvite(c *gin.Context) {
 		switch {
 		case errors.Is(err, gorm.ErrRecordNotFound):
 			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(errs.ErrContestNotFound), c))
-		case errors.Is(err, errs.ErrContestFinalized):
+		case errors.Is(err, errs.ErrContestFinalized), errors.Is(err, errs.ErrSquareNotEditable):
 			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
 		case errors.Is(err, errs.ErrInsufficientRole):
 			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
-		case errors.Is(err, errs.ErrInvalidSquareCount):
+		case errors.Is(err, errs.ErrInvalidSquareCount), errors.Is(err, errs.ErrViewerCannotHaveSquares), errors.Is(err, errs.ErrTooManyReservedSquares):
 			c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrSquareNotReservable):
+			c.JSON(http.StatusConflict, model.NewAPIError(http.StatusConflict, util.CapitalizeFirstLetter(err), c))
 		default:
 			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to create invite", c))
 		}
@@ -83,8 +93,82 @@ func (h *inviteHandler) CreateInvite(c *gin.Context) {
 	c.JSON(http.StatusOK, invite)
 }
 
+// @Summary Invite people to a contest by email
+// @Description Creates a single-use invite bound to each address and emails it. Only a signed-in user whose verified email matches can redeem it. Delivery status starts as pending and moves to sent or failed, then opened and redeemed
+// @Tags invites
+// @Accept json
+// @Produce json
+// @Param id path string true "Contest ID"
+// @Param invite body model.CreateEmailInvitesRequest true "Recipients and invite details"
+// @Success 200 {array} model.ContestInvite
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 409 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/invites/email [post]
+func (h *inviteHandler) CreateEmailInvites(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		log.Warn("invalid contest id", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid contest ID", c))
+		return
+	}
+
+	var req model.CreateEmailInvitesRequest
+	if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
+		log.Warn("failed to bind create email invites json", "error", bindErr)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(errs.ErrInvalidRequestBody), c))
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	invites, err := h.inviteService.CreateEmailInvites(c.Request.Context(), contestID, &req, user)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(errs.ErrContestNotFound), c))
+		case errors.Is(err, errs.ErrContestFinalized):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrNotParticipant), errors.Is(err, errs.ErrInsufficientRole):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(errs.ErrInsufficientRole), c))
+		case errors.Is(err, errs.ErrInvalidSquareCount):
+			c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrInviteeAlreadyJoined):
+			c.JSON(http.StatusConflict, model.NewAPIError(http.StatusConflict, util.CapitalizeFirstLetter(err), c))
+		default:
+			log.Error("failed to create email invites", "error", err)
+			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to create invites", c))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, invites)
+}
+
+// @Summary Invite email open tracking pixel
+// @Description Records the first open of a personal invite email. Always returns a 1x1 gif so nothing about the invite leaks
+// @Tags invites
+// @Produce image/gif
+// @Param token path string true "Invite token"
+// @Success 200 {file} binary
+// @Router /invites/{token}/open.gif [get]
+func (h *inviteHandler) TrackInviteOpen(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	if err := h.inviteService.MarkInviteOpened(c.Request.Context(), c.Param("token")); err != nil && !errors.Is(err, errs.ErrInviteNotFound) {
+		log.Warn("failed to track invite open", "error", err)
+	}
+
+	c.Header("Cache-Control", "no-store, max-age=0")
+	c.Data(http.StatusOK, "image/gif", trackingPixel)
+}
+
 // @Summary Preview an invite link
-// @Description Returns contest name and invite details without authentication
+// @Description Returns contest name and invite details without authentication, including any domain restriction so users know before signing in
 // @Tags invites
 // @Produce json
 // @Param token path string true "Invite token"
@@ -102,12 +186,13 @@ func (h *inviteHandler) GetInvitePreview(c *gin.Context) {
 		return
 	}
 
-	preview, err := h.inviteService.GetInvitePreview(c.Request.Context(), token)
+	// the client address and agent only dedupe view counts
+	preview, err := h.inviteService.GetInvitePreview(c.Request.Context(), token, c.ClientIP(), c.Request.UserAgent())
 	if err != nil {
 		switch {
 		case errors.Is(err, errs.ErrInviteNotFound):
 			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(err), c))
-		case errors.Is(err, errs.ErrInviteExpired), errors.Is(err, errs.ErrInviteMaxUsesReached):
+		case errors.Is(err, errs.ErrInviteExpired), errors.Is(err, errs.ErrInviteMaxUsesReached), errors.Is(err, errs.ErrInvitePaused):
 			c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(err), c))
 		default:
 			log.Error("failed to get invite preview", "error", err)
@@ -120,14 +205,18 @@ func (h *inviteHandler) GetInvitePreview(c *gin.Context) {
 }
 
 // @Summary Redeem an invite link
-// @Description Authenticated user joins a contest via invite token
+// @Description Authenticated user joins a contest via invite token. Any squares the invite reserved are claimed for them in the same step
 // @Tags invites
 // @Produce json
 // @Param token path string true "Invite token"
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the first response for 24h"
 // @Success 201 {object} model.ContestParticipant
 // @Failure 400 {object} model.APIError
+// @Failure 401 {object} model.APIError
+// @Failure 403 {object} model.APIError
 // @Failure 404 {object} model.APIError
 // @Failure 409 {object} model.APIError
+// @Failure 422 {object} model.APIError
 // @Failure 500 {object} model.APIError
 // @Security BearerAuth
 // @Router /invites/{token}/redeem [post]
@@ -146,14 +235,17 @@ func (h *inviteHandler) RedeemInvite(c *gin.Context) {
 		switch {
 		case errors.Is(err, errs.ErrInviteNotFound):
 			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(err), c))
-		case errors.Is(err, errs.ErrInviteExpired), errors.Is(err, errs.ErrInviteMaxUsesReached):
+		case errors.Is(err, errs.ErrInviteExpired), errors.Is(err, errs.ErrInviteMaxUsesReached), errors.Is(err, errs.ErrInvitePaused):
 			c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(err), c))
-		case errors.Is(err, errs.ErrContestFinalized):
+		case errors.Is(err, errs.ErrContestFinalized), errors.Is(err, errs.ErrInviteEmailMismatch), errors.Is(err, errs.ErrInviteEmailNotAllowed),
+			errors.Is(err, errs.ErrBannedFromContest):
 			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
-		case errors.Is(err, errs.ErrAlreadyParticipant):
+		case errors.Is(err, errs.ErrAlreadyParticipant), errors.Is(err, errs.ErrMissingInitials):
 			c.JSON(http.StatusConflict, model.NewAPIError(http.StatusConflict, util.CapitalizeFirstLetter(err), c))
 		case errors.Is(err, errs.ErrNotEnoughSquares):
 			c.JSON(http.StatusUnprocessableEntity, model.NewAPIError(http.StatusUnprocessableEntity, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrClaimsNotFound):
+			c.JSON(http.StatusUnauthorized, model.NewAPIError(http.StatusUnauthorized, util.CapitalizeFirstLetter(err), c))
 		default:
 			log.Error("failed to redeem invite", "error", err)
 			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to redeem invite", c))
@@ -165,7 +257,7 @@ func (h *inviteHandler) RedeemInvite(c *gin.Context) {
 }
 
 // @Summary Get all invites for a contest
-// @Description Owner gets all invite links for a contest
+// @Description Owner gets all invite links for a contest, each with its funnel of preview views, redemptions and timestamps
 // @Tags invites
 // @Produce json
 // @Param id path string true "Contest ID"
@@ -203,6 +295,69 @@ func (h *inviteHandler) GetInvites(c *gin.Context) {
 	c.JSON(http.StatusOK, invites)
 }
 
+// @Summary Update an invite link
+// @Description Owner adjusts an invite's role, square limit, usage limit or expiry, or pauses it without deleting it. Changes only apply to future redemptions
+// @Tags invites
+// @Accept json
+// @Produce json
+// @Param id path string true "Contest ID"
+// @Param inviteId path string true "Invite ID"
+// @Param invite body model.UpdateInviteRequest true "Fields to change"
+// @Success 200 {object} model.ContestInvite
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/invites/{inviteId} [patch]
+func (h *inviteHandler) UpdateInvite(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		log.Warn("invalid contest id", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid contest ID", c))
+		return
+	}
+
+	inviteID, err := uuid.Parse(c.Param("inviteId"))
+	if err != nil {
+		log.Warn("invalid invite id", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid invite ID", c))
+		return
+	}
+
+	var req model.UpdateInviteRequest
+	if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
+		log.Warn("failed to bind update invite json", "error", bindErr)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(errs.ErrInvalidRequestBody), c))
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	invite, err := h.inviteService.UpdateInvite(c.Request.Context(), contestID, inviteID, &req, user)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(errs.ErrContestNotFound), c))
+		case errors.Is(err, errs.ErrInviteNotFound):
+			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrContestFinalized):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrNotParticipant), errors.Is(err, errs.ErrInsufficientRole):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(errs.ErrInsufficientRole), c))
+		case errors.Is(err, errs.ErrInvalidSquareCount), errors.Is(err, errs.ErrViewerCannotHaveSquares), errors.Is(err, errs.ErrInviteMaxUsesTooLow), errors.Is(err, errs.ErrTooManyReservedSquares):
+			c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(err), c))
+		default:
+			log.Error("failed to update invite", "error", err)
+			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to update invite", c))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, invite)
+}
+
 // @Summary Delete an invite link
 // @Description Owner deletes an invite link
 // @Tags invites
@@ -236,6 +391,8 @@ func (h *inviteHandler) DeleteInvite(c *gin.Context) {
 		switch {
 		case errors.Is(err, gorm.ErrRecordNotFound):
 			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(errs.ErrContestNotFound), c))
+		case errors.Is(err, errs.ErrInviteNotFound):
+			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(err), c))
 		case errors.Is(err, errs.ErrContestFinalized):
 			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
 		case errors.Is(err, errs.ErrInsufficientRole):
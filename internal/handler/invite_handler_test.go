@@ -49,6 +49,33 @@ func TestCreateInvite_InvalidContestID(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
+func TestCreateInvite_InvalidAllowlist(t *testing.T) {
+	h := NewInviteHandler(mocks.NewInviteService(t))
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.POST("/contests/:id/invites", h.CreateInvite)
+
+	for _, req := range []model.CreateInviteRequest{
+		{Role: "viewer", AllowedDomains: []string{"@corp.com"}},
+		{Role: "viewer", AllowedDomains: []string{"not a domain"}},
+		{Role: "viewer", AllowedEmails: []string{"nope"}},
+	} {
+		w := doRequest(r, jsonReq(http.MethodPost, fmt.Sprintf("/contests/%s/invites", uuid.New()), req))
+		assert.Equal(t, http.StatusBadRequest, w.Code, req)
+	}
+}
+
+func TestCreateInvite_InvalidReservedSquare(t *testing.T) {
+	h := NewInviteHandler(mocks.NewInviteService(t))
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.POST("/contests/:id/invites", h.CreateInvite)
+
+	req := model.CreateInviteRequest{Role: "participant", MaxSquares: 1, ReservedSquares: []model.SquarePosition{{Row: 10, Col: 0}}}
+	w := doRequest(r, jsonReq(http.MethodPost, fmt.Sprintf("/contests/%s/invites", uuid.New()), req))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func TestCreateInvite_InvalidBody(t *testing.T) {
 	h := NewInviteHandler(mocks.NewInviteService(t))
 	r := gin.New()
@@ -67,6 +94,12 @@ func TestCreateInvite_Forbidden(t *testing.T) {
 func TestCreateInvite_NotFound(t *testing.T) {
 	createInviteErr(t, "owner1", gorm.ErrRecordNotFound, http.StatusNotFound)
 }
+func TestCreateInvite_SquareNotReservable(t *testing.T) {
+	createInviteErr(t, "owner1", errs.ErrSquareNotReservable, http.StatusConflict)
+}
+func TestCreateInvite_TooManyReservedSquares(t *testing.T) {
+	createInviteErr(t, "owner1", errs.ErrTooManyReservedSquares, http.StatusBadRequest)
+}
 func TestCreateInvite_InternalError(t *testing.T) {
 	createInviteErr(t, "owner1", assert.AnError, http.StatusInternalServerError)
 }
@@ -91,7 +124,7 @@ func createInviteErr(t *testing.T, user string, svcErr error, wantCode int) {
 
 func TestGetInvitePreview_Success(t *testing.T) {
 	svc := mocks.NewInviteService(t)
-	svc.EXPECT().GetInvitePreview(mock.Anything, mock.Anything).
+	svc.EXPECT().GetInvitePreview(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 		Return(&model.InvitePreviewResponse{ContestName: "Super Bowl", Owner: "owner1", Role: "participant", MaxSquares: 10}, nil)
 	h := NewInviteHandler(svc)
 
@@ -115,6 +148,9 @@ func TestGetInvitePreview_Expired(t *testing.T) {
 func TestGetInvitePreview_MaxUsesReached(t *testing.T) {
 	getInvitePreviewErr(t, errs.ErrInviteMaxUsesReached, http.StatusBadRequest)
 }
+func TestGetInvitePreview_Paused(t *testing.T) {
+	getInvitePreviewErr(t, errs.ErrInvitePaused, http.StatusBadRequest)
+}
 func TestGetInvitePreview_InternalError(t *testing.T) {
 	getInvitePreviewErr(t, assert.AnError, http.StatusInternalServerError)
 }
@@ -122,7 +158,7 @@ func TestGetInvitePreview_InternalError(t *testing.T) {
 func getInvitePreviewErr(t *testing.T, svcErr error, wantCode int) {
 	t.Helper()
 	svc := mocks.NewInviteService(t)
-	svc.EXPECT().GetInvitePreview(mock.Anything, mock.Anything).Return(nil, svcErr)
+	svc.EXPECT().GetInvitePreview(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, svcErr)
 	h := NewInviteHandler(svc)
 
 	r := gin.New()
@@ -167,6 +203,18 @@ func TestRedeemInvite_MaxUsesReached(t *testing.T) {
 func TestRedeemInvite_NotEnoughSquares(t *testing.T) {
 	redeemInviteErr(t, errs.ErrNotEnoughSquares, http.StatusUnprocessableEntity)
 }
+func TestRedeemInvite_EmailMismatch(t *testing.T) {
+	redeemInviteErr(t, errs.ErrInviteEmailMismatch, http.StatusForbidden)
+}
+
+func TestRedeemInvite_EmailNotAllowed(t *testing.T) {
+	redeemInviteErr(t, errs.ErrInviteEmailNotAllowed, http.StatusForbidden)
+}
+
+func TestRedeemInvite_MissingInitialsForReservedSquares(t *testing.T) {
+	redeemInviteErr(t, errs.ErrMissingInitials, http.StatusConflict)
+}
+
 func TestRedeemInvite_InternalError(t *testing.T) {
 	redeemInviteErr(t, assert.AnError, http.StatusInternalServerError)
 }
@@ -186,6 +234,83 @@ func redeemInviteErr(t *testing.T, svcErr error, wantCode int) {
 	assert.Equal(t, wantCode, w.Code)
 }
 
+// ====================
+// CreateEmailInvites
+// ====================
+
+func emailInviteRouter(svc *mocks.InviteService) *gin.Engine {
+	h := NewInviteHandler(svc)
+	r := gin.New()
+	r.GET("/invites/:token/open.gif", h.TrackInviteOpen)
+	r.POST("/contests/:id/invites/email", authenticatedMiddleware("owner1"), h.CreateEmailInvites)
+	return r
+}
+
+func TestCreateEmailInvites_Success(t *testing.T) {
+	contestID := uuid.New()
+	req := model.CreateEmailInvitesRequest{Emails: []string{"alice@example.com", "bob@example.com"}, Role: "participant", MaxSquares: 5}
+	svc := mocks.NewInviteService(t)
+	svc.EXPECT().CreateEmailInvites(mock.Anything, contestID, &req, "owner1").Return([]model.ContestInvite{
+		{Email: "alice@example.com", EmailStatus: model.InviteEmailStatusPending},
+		{Email: "bob@example.com", EmailStatus: model.InviteEmailStatusPending},
+	}, nil)
+
+	w := doRequest(emailInviteRouter(svc), jsonReq(http.MethodPost, fmt.Sprintf("/contests/%s/invites/email", contestID), req))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp []model.ContestInvite
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp, 2)
+	assert.Equal(t, model.InviteEmailStatusPending, resp[0].EmailStatus)
+}
+
+func TestCreateEmailInvites_InvalidBody(t *testing.T) {
+	for _, body := range []map[string]any{
+		{"emails": []string{}, "role": "viewer"},
+		{"emails": []string{"not-an-email"}, "role": "viewer"},
+		{"emails": []string{"a@example.com"}, "role": "owner"},
+	} {
+		w := doRequest(emailInviteRouter(mocks.NewInviteService(t)), jsonReq(http.MethodPost, fmt.Sprintf("/contests/%s/invites/email", uuid.New()), body))
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+}
+
+func TestCreateEmailInvites_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{gorm.ErrRecordNotFound, http.StatusNotFound},
+		{errs.ErrContestFinalized, http.StatusForbidden},
+		{errs.ErrInsufficientRole, http.StatusForbidden},
+		{errs.ErrNotParticipant, http.StatusForbidden},
+		{errs.ErrInvalidSquareCount, http.StatusBadRequest},
+		{errs.ErrInviteeAlreadyJoined, http.StatusConflict},
+		{errs.ErrDatabaseUnavailable, http.StatusInternalServerError},
+	} {
+		svc := mocks.NewInviteService(t)
+		svc.EXPECT().CreateEmailInvites(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, tc.err)
+
+		req := model.CreateEmailInvitesRequest{Emails: []string{"a@example.com"}, Role: "viewer"}
+		w := doRequest(emailInviteRouter(svc), jsonReq(http.MethodPost, fmt.Sprintf("/contests/%s/invites/email", uuid.New()), req))
+		assert.Equal(t, tc.code, w.Code, tc.err.Error())
+	}
+}
+
+func TestTrackInviteOpen_AlwaysServesPixel(t *testing.T) {
+	for _, svcErr := range []error{nil, errs.ErrInviteNotFound, errs.ErrDatabaseUnavailable} {
+		svc := mocks.NewInviteService(t)
+		svc.EXPECT().MarkInviteOpened(mock.Anything, "tok").Return(svcErr)
+
+		req, _ := http.NewRequest(http.MethodGet, "/invites/tok/open.gif", http.NoBody)
+		w := doRequest(emailInviteRouter(svc), req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "image/gif", w.Header().Get("Content-Type"))
+		assert.Equal(t, "no-store, max-age=0", w.Header().Get("Cache-Control"))
+		assert.True(t, bytes.HasPrefix(w.Body.Bytes(), []byte("GIF89a")))
+	}
+}
+
 // ====================
 // GetInvites
 // ====================
@@ -245,6 +370,66 @@ func getInvitesErr(t *testing.T, user string, svcErr error, wantCode int) {
 	assert.Equal(t, wantCode, w.Code)
 }
 
+// ====================
+// UpdateInvite
+// ====================
+
+func updateInviteRouter(svc *mocks.InviteService) *gin.Engine {
+	h := NewInviteHandler(svc)
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.PATCH("/contests/:id/invites/:inviteId", h.UpdateInvite)
+	return r
+}
+
+func TestUpdateInvite_Success(t *testing.T) {
+	contestID, inviteID := uuid.New(), uuid.New()
+	paused := true
+	svc := mocks.NewInviteService(t)
+	svc.EXPECT().UpdateInvite(mock.Anything, contestID, inviteID, &model.UpdateInviteRequest{Paused: &paused}, "owner1").
+		Return(&model.ContestInvite{ID: inviteID, Paused: true}, nil)
+
+	w := doRequest(updateInviteRouter(svc), jsonReq(http.MethodPatch, fmt.Sprintf("/contests/%s/invites/%s", contestID, inviteID), map[string]any{"paused": true}))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp model.ContestInvite
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.True(t, resp.Paused)
+}
+
+func TestUpdateInvite_InvalidRequest(t *testing.T) {
+	r := updateInviteRouter(mocks.NewInviteService(t))
+
+	w := doRequest(r, jsonReq(http.MethodPatch, fmt.Sprintf("/contests/%s/invites/bad", uuid.New()), map[string]any{}))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	for _, body := range []map[string]any{{"role": "owner"}, {"maxUses": -1}, {"maxSquares": 101}} {
+		w := doRequest(r, jsonReq(http.MethodPatch, fmt.Sprintf("/contests/%s/invites/%s", uuid.New(), uuid.New()), body))
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+}
+
+func TestUpdateInvite_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{gorm.ErrRecordNotFound, http.StatusNotFound},
+		{errs.ErrInviteNotFound, http.StatusNotFound},
+		{errs.ErrContestFinalized, http.StatusForbidden},
+		{errs.ErrInsufficientRole, http.StatusForbidden},
+		{errs.ErrInvalidSquareCount, http.StatusBadRequest},
+		{errs.ErrViewerCannotHaveSquares, http.StatusBadRequest},
+		{errs.ErrInviteMaxUsesTooLow, http.StatusBadRequest},
+		{errs.ErrDatabaseUnavailable, http.StatusInternalServerError},
+	} {
+		svc := mocks.NewInviteService(t)
+		svc.EXPECT().UpdateInvite(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, tc.err)
+
+		w := doRequest(updateInviteRouter(svc), jsonReq(http.MethodPatch, fmt.Sprintf("/contests/%s/invites/%s", uuid.New(), uuid.New()), map[string]any{}))
+		assert.Equal(t, tc.code, w.Code, tc.err.Error())
+	}
+}
+
 // ====================
 // DeleteInvite
 // ====================
@@ -288,6 +473,9 @@ func TestDeleteInvite_Forbidden(t *testing.T) {
 func TestDeleteInvite_NotFound(t *testing.T) {
 	deleteInviteErr(t, "owner1", gorm.ErrRecordNotFound, http.StatusNotFound)
 }
+func TestDeleteInvite_InviteNotFound(t *testing.T) {
+	deleteInviteErr(t, "owner1", errs.ErrInviteNotFound, http.StatusNotFound)
+}
 func TestDeleteInvite_InternalError(t *testing.T) {
 	deleteInviteErr(t, "owner1", assert.AnError, http.StatusInternalServerError)
 }
@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/service"
+	"github.com/maxmorhardt/squares-api/internal/util"
+)
+
+type OrganizationHandler interface {
+	CreateOrganization(c *gin.Context)
+	GetMyOrganizations(c *gin.Context)
+	GetOrgMembers(c *gin.Context)
+	AddOrgMember(c *gin.Context)
+	UpdateOrgMember(c *gin.Context)
+	RemoveOrgMember(c *gin.Context)
+}
+
+type organizationHandler struct {
+	orgService service.OrganizationService
+}
+
+func NewOrganizationHandler(orgService service.OrganizationService) OrganizationHandler {
+	return &organizationHandler{
+		orgService: orgService,
+	}
+}
+
+// @Summary Create an organization
+// @Description Creates an organization with the caller as its first admin. Admins can manage every contest the organization owns
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param organization body model.CreateOrganizationRequest true "Organization details"
+// @Success 200 {object} model.Organization
+// @Failure 400 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /organizations [post]
+func (h *organizationHandler) CreateOrganization(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	var req model.CreateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warn("failed to bind create organization json", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(errs.ErrInvalidRequestBody), c))
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	org, err := h.orgService.CreateOrganization(c.Request.Context(), &req, user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to create organization", c))
+		return
+	}
+
+	c.JSON(http.StatusOK, org)
+}
+
+// @Summary Get the caller's organizations
+// @Description Returns every organization the authenticated user belongs to
+// @Tags organizations
+// @Produce json
+// @Success 200 {array} model.Organization
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /organizations [get]
+func (h *organizationHandler) GetMyOrganizations(c *gin.Context) {
+	user := c.GetString(model.UserKey)
+	orgs, err := h.orgService.GetMyOrganizations(c.Request.Context(), user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to get organizations", c))
+		return
+	}
+
+	c.JSON(http.StatusOK, orgs)
+}
+
+// @Summary Get organization members
+// @Description Returns the members of an organization. Only members can see the list
+// @Tags organizations
+// @Produce json
+// @Param orgId path string true "Organization ID"
+// @Success 200 {array} model.OrganizationMember
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /organizations/{orgId}/members [get]
+func (h *organizationHandler) GetOrgMembers(c *gin.Context) {
+	orgID, ok := parseOrgPath(c)
+	if !ok {
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	members, err := h.orgService.GetMembers(c.Request.Context(), orgID, user)
+	if err != nil {
+		respondOrgError(c, err, "Failed to get organization members")
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+// @Summary Add an organization member
+// @Description Admin adds a user to the organization as a member or admin
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param orgId path string true "Organization ID"
+// @Param member body model.AddOrgMemberRequest true "Member details"
+// @Success 200 {object} model.OrganizationMember
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 409 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /organizations/{orgId}/members [post]
+func (h *organizationHandler) AddOrgMember(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	orgID, ok := parseOrgPath(c)
+	if !ok {
+		return
+	}
+
+	var req model.AddOrgMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warn("failed to bind add organization member json", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(errs.ErrInvalidRequestBody), c))
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	member, err := h.orgService.AddMember(c.Request.Context(), orgID, &req, user)
+	if err != nil {
+		respondOrgError(c, err, "Failed to add organization member")
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+// @Summary Change an organization member's role
+// @Description Admin promotes a member to admin or demotes an admin. The last admin cannot be demoted
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param orgId path string true "Organization ID"
+// @Param userId path string true "Member user ID"
+// @Param member body model.UpdateOrgMemberRequest true "New role"
+// @Success 200 {object} model.OrganizationMember
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 409 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /organizations/{orgId}/members/{userId} [put]
+func (h *organizationHandler) UpdateOrgMember(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	orgID, ok := parseOrgPath(c)
+	if !ok {
+		return
+	}
+
+	var req model.UpdateOrgMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warn("failed to bind update organization member json", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(errs.ErrInvalidRequestBody), c))
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	member, err := h.orgService.UpdateMember(c.Request.Context(), orgID, c.Param("userId"), &req, user)
+	if err != nil {
+		respondOrgError(c, err, "Failed to update organization member")
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+// @Summary Remove an organization member
+// @Description Admin removes a member, or a member leaves the organization. The last admin cannot be removed
+// @Tags organizations
+// @Param orgId path string true "Organization ID"
+// @Param userId path string true "Member user ID"
+// @Success 204
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 409 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /organizations/{orgId}/members/{userId} [delete]
+func (h *organizationHandler) RemoveOrgMember(c *gin.Context) {
+	orgID, ok := parseOrgPath(c)
+	if !ok {
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	if err := h.orgService.RemoveMember(c.Request.Context(), orgID, c.Param("userId"), user); err != nil {
+		respondOrgError(c, err, "Failed to remove organization member")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func parseOrgPath(c *gin.Context) (uuid.UUID, bool) {
+	log := util.LoggerFromGinContext(c)
+
+	orgID, err := uuid.Parse(c.Param("orgId"))
+	if err != nil {
+		log.Warn("invalid organization id", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid organization ID", c))
+		return uuid.Nil, false
+	}
+	return orgID, true
+}
+
+func respondOrgError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, errs.ErrOrganizationNotFound), errors.Is(err, errs.ErrOrgMemberNotFound):
+		c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(err), c))
+	case errors.Is(err, errs.ErrNotOrgMember), errors.Is(err, errs.ErrNotOrgAdmin):
+		c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
+	case errors.Is(err, errs.ErrAlreadyOrgMember), errors.Is(err, errs.ErrLastOrgAdmin):
+		c.JSON(http.StatusConflict, model.NewAPIError(http.StatusConflict, util.CapitalizeFirstLetter(err), c))
+	default:
+		c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, fallback, c))
+	}
+}
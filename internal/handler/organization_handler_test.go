@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/mocks"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func orgRouter(svc *mocks.OrganizationService) *gin.Engine {
+	h := NewOrganizationHandler(svc)
+	r := gin.New()
+	r.Use(authenticatedMiddleware("admin1"))
+	r.POST("/organizations", h.CreateOrganization)
+	r.GET("/organizations", h.GetMyOrganizations)
+	r.GET("/organizations/:orgId/members", h.GetOrgMembers)
+	r.POST("/organizations/:orgId/members", h.AddOrgMember)
+	r.PUT("/organizations/:orgId/members/:userId", h.UpdateOrgMember)
+	r.DELETE("/organizations/:orgId/members/:userId", h.RemoveOrgMember)
+	return r
+}
+
+func TestCreateOrganization_Success(t *testing.T) {
+	svc := mocks.NewOrganizationService(t)
+	svc.EXPECT().CreateOrganization(mock.Anything, &model.CreateOrganizationRequest{Name: "Office"}, "admin1").
+		Return(&model.Organization{ID: uuid.New(), Name: "Office"}, nil)
+
+	w := doRequest(orgRouter(svc), jsonReq(http.MethodPost, "/organizations", model.CreateOrganizationRequest{Name: "Office"}))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp model.Organization
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "Office", resp.Name)
+}
+
+func TestCreateOrganization_InvalidBody(t *testing.T) {
+	w := doRequest(orgRouter(mocks.NewOrganizationService(t)), jsonReq(http.MethodPost, "/organizations", map[string]any{}))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetMyOrganizations_Success(t *testing.T) {
+	svc := mocks.NewOrganizationService(t)
+	svc.EXPECT().GetMyOrganizations(mock.Anything, "admin1").Return([]model.Organization{{Name: "a"}, {Name: "b"}}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/organizations", http.NoBody)
+	w := doRequest(orgRouter(svc), req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp []model.Organization
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp, 2)
+}
+
+func TestGetOrgMembers_InvalidID(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "/organizations/bad/members", http.NoBody)
+	w := doRequest(orgRouter(mocks.NewOrganizationService(t)), req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetOrgMembers_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{errs.ErrOrganizationNotFound, http.StatusNotFound},
+		{errs.ErrNotOrgMember, http.StatusForbidden},
+		{errs.ErrDatabaseUnavailable, http.StatusInternalServerError},
+	} {
+		svc := mocks.NewOrganizationService(t)
+		svc.EXPECT().GetMembers(mock.Anything, mock.Anything, mock.Anything).Return(nil, tc.err)
+
+		req, _ := http.NewRequest(http.MethodGet, "/organizations/"+uuid.New().String()+"/members", http.NoBody)
+		w := doRequest(orgRouter(svc), req)
+		assert.Equal(t, tc.code, w.Code, tc.err.Error())
+	}
+}
+
+func TestAddOrgMember_Success(t *testing.T) {
+	orgID := uuid.New()
+	svc := mocks.NewOrganizationService(t)
+	svc.EXPECT().AddMember(mock.Anything, orgID, &model.AddOrgMemberRequest{UserID: "v@x.com", Role: "admin"}, "admin1").
+		Return(&model.OrganizationMember{UserID: "v@x.com", Role: model.OrgRoleAdmin}, nil)
+
+	w := doRequest(orgRouter(svc), jsonReq(http.MethodPost, "/organizations/"+orgID.String()+"/members", model.AddOrgMemberRequest{UserID: "v@x.com", Role: "admin"}))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAddOrgMember_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{errs.ErrNotOrgAdmin, http.StatusForbidden},
+		{errs.ErrAlreadyOrgMember, http.StatusConflict},
+	} {
+		svc := mocks.NewOrganizationService(t)
+		svc.EXPECT().AddMember(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, tc.err)
+
+		w := doRequest(orgRouter(svc), jsonReq(http.MethodPost, "/organizations/"+uuid.New().String()+"/members", model.AddOrgMemberRequest{UserID: "v@x.com"}))
+		assert.Equal(t, tc.code, w.Code, tc.err.Error())
+	}
+}
+
+func TestAddOrgMember_InvalidEmail(t *testing.T) {
+	w := doRequest(orgRouter(mocks.NewOrganizationService(t)), jsonReq(http.MethodPost, "/organizations/"+uuid.New().String()+"/members", model.AddOrgMemberRequest{UserID: "v"}))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestUpdateOrgMember_InvalidRole(t *testing.T) {
+	w := doRequest(orgRouter(mocks.NewOrganizationService(t)), jsonReq(http.MethodPut, "/organizations/"+uuid.New().String()+"/members/v", model.UpdateOrgMemberRequest{Role: "owner"}))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestUpdateOrgMember_LastAdmin(t *testing.T) {
+	svc := mocks.NewOrganizationService(t)
+	svc.EXPECT().UpdateMember(mock.Anything, mock.Anything, "admin1", &model.UpdateOrgMemberRequest{Role: "member"}, "admin1").Return(nil, errs.ErrLastOrgAdmin)
+
+	w := doRequest(orgRouter(svc), jsonReq(http.MethodPut, "/organizations/"+uuid.New().String()+"/members/admin1", model.UpdateOrgMemberRequest{Role: "member"}))
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestRemoveOrgMember_Success(t *testing.T) {
+	orgID := uuid.New()
+	svc := mocks.NewOrganizationService(t)
+	svc.EXPECT().RemoveMember(mock.Anything, orgID, "v", "admin1").Return(nil)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/organizations/"+orgID.String()+"/members/v", http.NoBody)
+	w := doRequest(orgRouter(svc), req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestRemoveOrgMember_NotFound(t *testing.T) {
+	svc := mocks.NewOrganizationService(t)
+	svc.EXPECT().RemoveMember(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errs.ErrOrgMemberNotFound)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/organizations/"+uuid.New().String()+"/members/v", http.NoBody)
+	w := doRequest(orgRouter(svc), req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
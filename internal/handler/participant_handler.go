@@ -16,9 +16,15 @@ import (
 
 type ParticipantHandler interface {
 	GetParticipants(c *gin.Context)
+	GetPaymentSummary(c *gin.Context)
 	GetMyContests(c *gin.Context)
 	UpdateParticipant(c *gin.Context)
 	RemoveParticipant(c *gin.Context)
+	UpdatePayment(c *gin.Context)
+	UpdateMySquareStyle(c *gin.Context)
+	BanParticipant(c *gin.Context)
+	GetBans(c *gin.Context)
+	UnbanUser(c *gin.Context)
 }
 
 type participantHandler struct {
@@ -32,7 +38,7 @@ func NewParticipantHandler(participantService service.ParticipantService) Partic
 }
 
 // @Summary Get all participants for a contest
-// @Description Returns all participants and their roles. Any participant can view. Public contests allow any authenticated user
+// @Description Returns all participants with their roles. Any participant can view. Public contests allow any authenticated user; payment status is only included for the owner
 // @Tags participants
 // @Produce json
 // @Param id path string true "Contest ID"
@@ -59,7 +65,7 @@ func (h *participantHandler) GetParticipants(c *gin.Context) {
 		switch {
 		case errors.Is(err, gorm.ErrRecordNotFound):
 			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(errs.ErrContestNotFound), c))
-		case errors.Is(err, errs.ErrNotParticipant), errors.Is(err, errs.ErrInsufficientRole):
+		case errors.Is(err, errs.ErrNotParticipant), errors.Is(err, errs.ErrInsufficientRole), errors.Is(err, errs.ErrBannedFromContest):
 			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(errs.ErrInsufficientRole), c))
 		default:
 			log.Error("failed to get participants", "error", err)
@@ -71,12 +77,54 @@ func (h *participantHandler) GetParticipants(c *gin.Context) {
 	c.JSON(http.StatusOK, participants)
 }
 
+// @Summary Get a contest's payment summary
+// @Description Owner only; tallies paid and unpaid square owners and the money collected
+// @Tags participants
+// @Produce json
+// @Param id path string true "Contest ID"
+// @Success 200 {object} model.PaymentSummary
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/participants/payments [get]
+func (h *participantHandler) GetPaymentSummary(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		log.Warn("invalid contest id", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid contest ID", c))
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	summary, err := h.participantService.GetPaymentSummary(c.Request.Context(), contestID, user)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(errs.ErrContestNotFound), c))
+		case errors.Is(err, errs.ErrNotParticipant), errors.Is(err, errs.ErrInsufficientRole), errors.Is(err, errs.ErrBannedFromContest):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(errs.ErrInsufficientRole), c))
+		default:
+			log.Error("failed to get payment summary", "error", err)
+			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to get payment summary", c))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
 // @Summary Get all contests the user participates in
 // @Description Returns all contests where the authenticated user is a participant
 // @Tags participants
 // @Produce json
 // @Param search query string false "Filter contests by name (case-insensitive)"
+// @Param org query string false "Only return contests owned by this organization, including ones the user administers without joining"
 // @Success 200 {array} model.ContestSwagger
+// @Failure 400 {object} model.APIError
 // @Failure 500 {object} model.APIError
 // @Security BearerAuth
 // @Router /contests/me [get]
@@ -85,7 +133,19 @@ func (h *participantHandler) GetMyContests(c *gin.Context) {
 
 	user := c.GetString(model.UserKey)
 	search := strings.TrimSpace(c.Query("search"))
-	contests, err := h.participantService.GetMyContests(c.Request.Context(), user, search)
+
+	var orgID *uuid.UUID
+	if raw := c.Query("org"); raw != "" {
+		parsed, err := uuid.Parse(raw)
+		if err != nil {
+			log.Warn("invalid organization id", "error", err)
+			c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid organization ID", c))
+			return
+		}
+		orgID = &parsed
+	}
+
+	contests, err := h.participantService.GetMyContests(c.Request.Context(), user, search, orgID)
 	if err != nil {
 		log.Error("failed to get user contests", "error", err)
 		c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to get contests", c))
@@ -202,3 +262,251 @@ func (h *participantHandler) RemoveParticipant(c *gin.Context) {
 
 	c.Status(http.StatusNoContent)
 }
+
+// @Summary Record a participant's payment
+// @Description Owner marks a participant paid or unpaid and optionally records the amount. Marking unpaid resets the amount unless one is given. Allowed until the contest is deleted
+// @Tags participants
+// @Accept json
+// @Produce json
+// @Param id path string true "Contest ID"
+// @Param userId path string true "Target user ID"
+// @Param payment body model.UpdatePaymentRequest true "Payment details"
+// @Success 200 {object} model.ContestParticipant
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/participants/{userId}/payment [put]
+func (h *participantHandler) UpdatePayment(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		log.Warn("invalid contest id", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid contest ID", c))
+		return
+	}
+
+	targetUserID := c.Param("userId")
+	if targetUserID == "" {
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "User ID is required", c))
+		return
+	}
+
+	var req model.UpdatePaymentRequest
+	if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
+		log.Warn("failed to bind update payment json", "error", bindErr)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(errs.ErrInvalidRequestBody), c))
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	participant, err := h.participantService.UpdatePayment(c.Request.Context(), contestID, targetUserID, &req, user)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(errs.ErrContestNotFound), c))
+		case errors.Is(err, errs.ErrNotParticipant):
+			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrContestFinalized), errors.Is(err, errs.ErrInsufficientRole):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
+		default:
+			log.Error("failed to update participant payment", "error", err)
+			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to update payment", c))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, participant)
+}
+
+// @Summary Set the caller's square style for a contest
+// @Description Participant sets a display value and a palette colour their squares use in this contest instead of their profile initials. Existing squares are restyled and keep the override when the profile default changes; the clear flags fall back to the defaults. A body that sets and clears nothing is rejected
+// @Tags participants
+// @Accept json
+// @Produce json
+// @Param id path string true "Contest ID"
+// @Param style body model.UpdateSquareStyleRequest true "Square style"
+// @Success 200 {object} model.ContestParticipant
+// @Failure 400 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 409 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/participants/me/style [put]
+func (h *participantHandler) UpdateMySquareStyle(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		log.Warn("invalid contest id", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid contest ID", c))
+		return
+	}
+
+	var req model.UpdateSquareStyleRequest
+	if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
+		log.Warn("failed to bind square style json", "error", bindErr)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(errs.ErrInvalidRequestBody), c))
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	participant, err := h.participantService.UpdateSquareStyle(c.Request.Context(), contestID, &req, user)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(errs.ErrContestNotFound), c))
+		case errors.Is(err, errs.ErrNotParticipant):
+			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrEmptySquareStyleUpdate):
+			c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrContestFinalized):
+			c.JSON(http.StatusConflict, model.NewAPIError(http.StatusConflict, util.CapitalizeFirstLetter(err), c))
+		default:
+			log.Error("failed to update square style", "error", err)
+			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to update square style", c))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, participant)
+}
+
+// @Summary Ban a user from a contest
+// @Description Owner removes a user and blocks them from rejoining through any invite link or watching a public contest. Their squares are cleared before kickoff and ghosted after unless squares is set; their live connections are closed
+// @Tags participants
+// @Accept json
+// @Produce json
+// @Param id path string true "Contest ID"
+// @Param ban body model.BanParticipantRequest true "Ban details"
+// @Success 201 {object} model.ContestBan
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 409 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/bans [post]
+func (h *participantHandler) BanParticipant(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		log.Warn("invalid contest id", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid contest ID", c))
+		return
+	}
+
+	var req model.BanParticipantRequest
+	if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
+		log.Warn("failed to bind ban participant json", "error", bindErr)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(errs.ErrInvalidRequestBody), c))
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	ban, err := h.participantService.BanParticipant(c.Request.Context(), contestID, &req, user)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, "Contest not found", c))
+		case errors.Is(err, errs.ErrNotParticipant), errors.Is(err, errs.ErrInsufficientRole),
+			errors.Is(err, errs.ErrContestFinalized), errors.Is(err, errs.ErrSquareNotEditable):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrCannotBanOwner), errors.Is(err, errs.ErrCannotBanSelf):
+			c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrAlreadyBanned):
+			c.JSON(http.StatusConflict, model.NewAPIError(http.StatusConflict, util.CapitalizeFirstLetter(err), c))
+		default:
+			log.Error("failed to ban participant", "error", err)
+			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to ban participant", c))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, ban)
+}
+
+// @Summary List users banned from a contest
+// @Description Owner only; most recent bans first
+// @Tags participants
+// @Produce json
+// @Param id path string true "Contest ID"
+// @Success 200 {array} model.ContestBan
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/bans [get]
+func (h *participantHandler) GetBans(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		log.Warn("invalid contest id", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid contest ID", c))
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	bans, err := h.participantService.GetBans(c.Request.Context(), contestID, user)
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrNotParticipant), errors.Is(err, errs.ErrInsufficientRole):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
+		default:
+			log.Error("failed to get contest bans", "error", err)
+			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to get bans", c))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, bans)
+}
+
+// @Summary Lift a contest ban
+// @Description Owner only; the user can rejoin through an invite afterwards but gets nothing back automatically
+// @Tags participants
+// @Param id path string true "Contest ID"
+// @Param userId path string true "Banned user ID"
+// @Success 204
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/bans/{userId} [delete]
+func (h *participantHandler) UnbanUser(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		log.Warn("invalid contest id", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid contest ID", c))
+		return
+	}
+
+	targetUserID := c.Param("userId")
+	if targetUserID == "" {
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "User ID is required", c))
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	if err := h.participantService.UnbanUser(c.Request.Context(), contestID, targetUserID, user); err != nil {
+		switch {
+		case errors.Is(err, errs.ErrBanNotFound):
+			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrNotParticipant), errors.Is(err, errs.ErrInsufficientRole):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
+		default:
+			log.Error("failed to lift contest ban", "error", err)
+			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to lift ban", c))
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -82,13 +82,49 @@ func TestGetParticipants_InternalError(t *testing.T) {
 	getParticipantsErr(t, "user1", assert.AnError, http.StatusInternalServerError)
 }
 
+// ====================
+// GetPaymentSummary
+// ====================
+
+func TestGetPaymentSummary_Success(t *testing.T) {
+	svc := mocks.NewParticipantService(t)
+	svc.EXPECT().GetPaymentSummary(mock.Anything, mock.Anything, "owner1").
+		Return(&model.PaymentSummary{SquareOwners: 1, Unpaid: 1, UnpaidUserIDs: []string{"user1"}}, nil)
+	h := NewParticipantHandler(svc)
+
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.GET("/contests/:id/participants/payments", h.GetPaymentSummary)
+
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/contests/%s/participants/payments", uuid.New()), http.NoBody)
+	w := doRequest(r, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp model.PaymentSummary
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, []string{"user1"}, resp.UnpaidUserIDs)
+}
+
+func TestGetPaymentSummary_NotOwner(t *testing.T) {
+	svc := mocks.NewParticipantService(t)
+	svc.EXPECT().GetPaymentSummary(mock.Anything, mock.Anything, mock.Anything).Return(nil, errs.ErrInsufficientRole)
+	h := NewParticipantHandler(svc)
+
+	r := gin.New()
+	r.Use(authenticatedMiddleware("user1"))
+	r.GET("/contests/:id/participants/payments", h.GetPaymentSummary)
+
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/contests/%s/participants/payments", uuid.New()), http.NoBody)
+	w := doRequest(r, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
 // ====================
 // GetMyContests
 // ====================
 
 func TestGetMyContests_Success(t *testing.T) {
 	svc := mocks.NewParticipantService(t)
-	svc.EXPECT().GetMyContests(mock.Anything, mock.Anything, mock.Anything).Return([]model.Contest{{ID: uuid.New(), Name: "MyContest"}}, nil)
+	svc.EXPECT().GetMyContests(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]model.Contest{{ID: uuid.New(), Name: "MyContest"}}, nil)
 	h := NewParticipantHandler(svc)
 
 	r := gin.New()
@@ -105,7 +141,7 @@ func TestGetMyContests_Success(t *testing.T) {
 
 func TestGetMyContests_Error(t *testing.T) {
 	svc := mocks.NewParticipantService(t)
-	svc.EXPECT().GetMyContests(mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError)
+	svc.EXPECT().GetMyContests(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError)
 	h := NewParticipantHandler(svc)
 
 	r := gin.New()
@@ -120,7 +156,7 @@ func TestGetMyContests_Error(t *testing.T) {
 func getMyContestsSearch(t *testing.T, query, wantSearch string) {
 	t.Helper()
 	svc := mocks.NewParticipantService(t)
-	svc.EXPECT().GetMyContests(mock.Anything, mock.Anything, wantSearch).Return([]model.Contest{}, nil)
+	svc.EXPECT().GetMyContests(mock.Anything, mock.Anything, wantSearch, (*uuid.UUID)(nil)).Return([]model.Contest{}, nil)
 	h := NewParticipantHandler(svc)
 
 	r := gin.New()
@@ -137,6 +173,33 @@ func TestGetMyContests_TrimsSearchQuery(t *testing.T) {
 	getMyContestsSearch(t, "search=%20%20bar%20%20", "bar")
 }
 
+func TestGetMyContests_PassesOrgFilter(t *testing.T) {
+	orgID := uuid.New()
+	svc := mocks.NewParticipantService(t)
+	svc.EXPECT().GetMyContests(mock.Anything, "user1", "", &orgID).Return([]model.Contest{}, nil)
+	h := NewParticipantHandler(svc)
+
+	r := gin.New()
+	r.Use(authenticatedMiddleware("user1"))
+	r.GET("/contests/me", h.GetMyContests)
+
+	req, _ := http.NewRequest(http.MethodGet, "/contests/me?org="+orgID.String(), http.NoBody)
+	w := doRequest(r, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestGetMyContests_InvalidOrg(t *testing.T) {
+	h := NewParticipantHandler(mocks.NewParticipantService(t))
+
+	r := gin.New()
+	r.Use(authenticatedMiddleware("user1"))
+	r.GET("/contests/me", h.GetMyContests)
+
+	req, _ := http.NewRequest(http.MethodGet, "/contests/me?org=bad", http.NoBody)
+	w := doRequest(r, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 // ====================
 // UpdateParticipant
 // ====================
@@ -271,3 +334,192 @@ func TestRemoveParticipant_NotFound(t *testing.T) {
 func TestRemoveParticipant_InternalError(t *testing.T) {
 	removeParticipantErr(t, "owner1", "user1", assert.AnError, http.StatusInternalServerError)
 }
+
+// ====================
+// UpdatePayment
+// ====================
+
+func updatePaymentRouter(svc *mocks.ParticipantService) *gin.Engine {
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.PUT("/contests/:id/participants/:userId/payment", NewParticipantHandler(svc).UpdatePayment)
+	return r
+}
+
+func TestUpdatePayment_Success(t *testing.T) {
+	svc := mocks.NewParticipantService(t)
+	svc.EXPECT().UpdatePayment(mock.Anything, mock.Anything, "user1", mock.MatchedBy(func(req *model.UpdatePaymentRequest) bool {
+		return *req.Paid && *req.AmountPaidCents == 2000
+	}), "owner1").Return(&model.ContestParticipant{UserID: "user1", Paid: true, AmountPaidCents: 2000}, nil)
+
+	w := doRequest(updatePaymentRouter(svc), jsonReq(http.MethodPut, fmt.Sprintf("/contests/%s/participants/user1/payment", uuid.New()), map[string]any{"paid": true, "amountPaidCents": 2000}))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp model.ContestParticipant
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.True(t, resp.Paid)
+}
+
+func TestUpdatePayment_InvalidBody(t *testing.T) {
+	for _, body := range []map[string]any{{}, {"paid": true, "amountPaidCents": -1}} {
+		w := doRequest(updatePaymentRouter(mocks.NewParticipantService(t)), jsonReq(http.MethodPut, fmt.Sprintf("/contests/%s/participants/user1/payment", uuid.New()), body))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestUpdatePayment_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{gorm.ErrRecordNotFound, http.StatusNotFound},
+		{errs.ErrNotParticipant, http.StatusNotFound},
+		{errs.ErrInsufficientRole, http.StatusForbidden},
+		{errs.ErrContestFinalized, http.StatusForbidden},
+		{assert.AnError, http.StatusInternalServerError},
+	} {
+		svc := mocks.NewParticipantService(t)
+		svc.EXPECT().UpdatePayment(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, tc.err)
+
+		w := doRequest(updatePaymentRouter(svc), jsonReq(http.MethodPut, fmt.Sprintf("/contests/%s/participants/user1/payment", uuid.New()), map[string]any{"paid": false}))
+		assert.Equal(t, tc.code, w.Code, tc.err.Error())
+	}
+}
+
+// ====================
+// UpdateMySquareStyle
+// ====================
+
+func squareStyleRouter(svc *mocks.ParticipantService) *gin.Engine {
+	r := gin.New()
+	r.Use(authenticatedMiddleware("user1"))
+	r.PUT("/contests/:id/participants/me/style", NewParticipantHandler(svc).UpdateMySquareStyle)
+	return r
+}
+
+func TestUpdateMySquareStyle_Success(t *testing.T) {
+	value, color := "MM2", "teal"
+	svc := mocks.NewParticipantService(t)
+	svc.EXPECT().UpdateSquareStyle(mock.Anything, mock.Anything, &model.UpdateSquareStyleRequest{DisplayValue: &value, Color: &color}, "user1").
+		Return(&model.ContestParticipant{UserID: "user1", DisplayValue: &value, Color: &color}, nil)
+
+	w := doRequest(squareStyleRouter(svc), jsonReq(http.MethodPut, fmt.Sprintf("/contests/%s/participants/me/style", uuid.New()), map[string]any{"displayValue": "MM2", "color": "teal"}))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp model.ContestParticipant
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "MM2", *resp.DisplayValue)
+	assert.Equal(t, "teal", *resp.Color)
+}
+
+func TestUpdateMySquareStyle_InvalidBody(t *testing.T) {
+	// colours outside the palette and lowercase values fail binding
+	for _, body := range []map[string]any{{"color": "#ff0000"}, {"displayValue": "mm"}, {"displayValue": "ABCD"}} {
+		w := doRequest(squareStyleRouter(mocks.NewParticipantService(t)), jsonReq(http.MethodPut, fmt.Sprintf("/contests/%s/participants/me/style", uuid.New()), body))
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+}
+
+func TestUpdateMySquareStyle_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{gorm.ErrRecordNotFound, http.StatusNotFound},
+		{errs.ErrNotParticipant, http.StatusNotFound},
+		{errs.ErrEmptySquareStyleUpdate, http.StatusBadRequest},
+		{errs.ErrContestFinalized, http.StatusConflict},
+		{assert.AnError, http.StatusInternalServerError},
+	} {
+		svc := mocks.NewParticipantService(t)
+		svc.EXPECT().UpdateSquareStyle(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, tc.err)
+
+		w := doRequest(squareStyleRouter(svc), jsonReq(http.MethodPut, fmt.Sprintf("/contests/%s/participants/me/style", uuid.New()), map[string]any{"clearColor": true}))
+		assert.Equal(t, tc.code, w.Code, tc.err.Error())
+	}
+}
+
+// ====================
+// Bans
+// ====================
+
+func banParticipantRouter(svc *mocks.ParticipantService) *gin.Engine {
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.POST("/contests/:id/bans", NewParticipantHandler(svc).BanParticipant)
+	return r
+}
+
+func TestBanParticipant_Success(t *testing.T) {
+	svc := mocks.NewParticipantService(t)
+	svc.EXPECT().BanParticipant(mock.Anything, mock.Anything, &model.BanParticipantRequest{UserID: "user1", Squares: model.BanSquaresGhost}, "owner1").
+		Return(&model.ContestBan{UserID: "user1"}, nil)
+
+	w := doRequest(banParticipantRouter(svc), jsonReq(http.MethodPost, fmt.Sprintf("/contests/%s/bans", uuid.New()), map[string]string{"userId": "user1", "squares": "ghost"}))
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestBanParticipant_InvalidBody(t *testing.T) {
+	for _, body := range []map[string]string{{}, {"userId": "user1", "squares": "keep"}} {
+		w := doRequest(banParticipantRouter(mocks.NewParticipantService(t)), jsonReq(http.MethodPost, fmt.Sprintf("/contests/%s/bans", uuid.New()), body))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestBanParticipant_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{gorm.ErrRecordNotFound, http.StatusNotFound},
+		{errs.ErrInsufficientRole, http.StatusForbidden},
+		{errs.ErrSquareNotEditable, http.StatusForbidden},
+		{errs.ErrCannotBanOwner, http.StatusBadRequest},
+		{errs.ErrCannotBanSelf, http.StatusBadRequest},
+		{errs.ErrAlreadyBanned, http.StatusConflict},
+		{assert.AnError, http.StatusInternalServerError},
+	} {
+		svc := mocks.NewParticipantService(t)
+		svc.EXPECT().BanParticipant(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, tc.err)
+
+		w := doRequest(banParticipantRouter(svc), jsonReq(http.MethodPost, fmt.Sprintf("/contests/%s/bans", uuid.New()), map[string]string{"userId": "user1"}))
+		assert.Equal(t, tc.code, w.Code, tc.err.Error())
+	}
+}
+
+func TestGetBans_Success(t *testing.T) {
+	svc := mocks.NewParticipantService(t)
+	svc.EXPECT().GetBans(mock.Anything, mock.Anything, "owner1").Return([]model.ContestBan{{UserID: "user1"}}, nil)
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.GET("/contests/:id/bans", NewParticipantHandler(svc).GetBans)
+
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/contests/%s/bans", uuid.New()), http.NoBody)
+	w := doRequest(r, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var bans []model.ContestBan
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &bans))
+	assert.Len(t, bans, 1)
+}
+
+func TestUnbanUser(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{nil, http.StatusNoContent},
+		{errs.ErrBanNotFound, http.StatusNotFound},
+		{errs.ErrInsufficientRole, http.StatusForbidden},
+		{assert.AnError, http.StatusInternalServerError},
+	} {
+		svc := mocks.NewParticipantService(t)
+		svc.EXPECT().UnbanUser(mock.Anything, mock.Anything, "user1", "owner1").Return(tc.err)
+		r := gin.New()
+		r.Use(authenticatedMiddleware("owner1"))
+		r.DELETE("/contests/:id/bans/:userId", NewParticipantHandler(svc).UnbanUser)
+
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/contests/%s/bans/user1", uuid.New()), http.NoBody)
+		assert.Equal(t, tc.code, doRequest(r, req).Code)
+	}
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/service"
+	"github.com/maxmorhardt/squares-api/internal/util"
+	"gorm.io/gorm"
+)
+
+// a few hundred rows of emails and numbers is a few kilobytes; this leaves plenty of room
+const maxImportFileSize = 256 << 10
+
+type ParticipantImportHandler interface {
+	PreviewImport(c *gin.Context)
+	ApplyImport(c *gin.Context)
+}
+
+type participantImportHandler struct {
+	importService service.ParticipantImportService
+}
+
+func NewParticipantImportHandler(importService service.ParticipantImportService) ParticipantImportHandler {
+	return &participantImportHandler{
+		importService: importService,
+	}
+}
+
+// @Summary Preview a participant CSV import
+// @Description Owner uploads a CSV with email, role and max_squares columns and optional row and col columns. Returns the participants that would be added or updated, the squares that would be claimed and any row errors, without changing anything
+// @Tags participants
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Contest ID"
+// @Param file formData file true "CSV file"
+// @Success 200 {object} model.ParticipantImportPreview
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 409 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/participants/import/preview [post]
+func (h *participantImportHandler) PreviewImport(c *gin.Context) {
+	h.handleImport(c, false)
+}
+
+// @Summary Apply a participant CSV import
+// @Description Re-validates the uploaded CSV against the contest and, if every row is valid, adds and updates participants and claims their squares in one transaction. A file with row errors is rejected with the preview so the errors can be fixed
+// @Tags participants
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Contest ID"
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the first response for 24h"
+// @Param file formData file true "CSV file"
+// @Success 200 {object} model.ParticipantImportPreview
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 409 {object} model.APIError
+// @Failure 422 {object} model.ParticipantImportPreview
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/participants/import [post]
+func (h *participantImportHandler) ApplyImport(c *gin.Context) {
+	h.handleImport(c, true)
+}
+
+func (h *participantImportHandler) handleImport(c *gin.Context, apply bool) {
+	log := util.LoggerFromGinContext(c)
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		log.Warn("invalid contest id", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid contest ID", c))
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil || header.Size > maxImportFileSize {
+		log.Warn("missing or oversized import file", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(errs.ErrInvalidImportFile), c))
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		log.Error("failed to open import file", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(errs.ErrInvalidImportFile), c))
+		return
+	}
+	defer file.Close()
+
+	user := c.GetString(model.UserKey)
+	var preview *model.ParticipantImportPreview
+	if apply {
+		preview, err = h.importService.ApplyImport(c.Request.Context(), contestID, file, user)
+	} else {
+		preview, err = h.importService.PreviewImport(c.Request.Context(), contestID, file, user)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrImportRejected):
+			c.JSON(http.StatusUnprocessableEntity, preview)
+		case errors.Is(err, errs.ErrInvalidImportFile):
+			c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(errs.ErrContestNotFound), c))
+		case errors.Is(err, errs.ErrNotParticipant), errors.Is(err, errs.ErrInsufficientRole):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(errs.ErrInsufficientRole), c))
+		case errors.Is(err, errs.ErrContestFinalized),
+			errors.Is(err, errs.ErrNotEnoughSquares),
+			errors.Is(err, errs.ErrSquareAlreadyClaimed),
+			errors.Is(err, errs.ErrSquareLimitReached):
+			c.JSON(http.StatusConflict, model.NewAPIError(http.StatusConflict, util.CapitalizeFirstLetter(err), c))
+		default:
+			log.Error("failed to import participants", "error", err)
+			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to import participants", c))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
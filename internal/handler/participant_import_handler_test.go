@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/mocks"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func csvUpload(target string, content []byte) *http.Request {
+	var body bytes.Buffer
+	mpw := multipart.NewWriter(&body)
+	part, _ := mpw.CreateFormFile("file", "pool.csv")
+	_, _ = part.Write(content)
+	_ = mpw.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, target, &body)
+	req.Header.Set("Content-Type", mpw.FormDataContentType())
+	return req
+}
+
+func importRouter(svc *mocks.ParticipantImportService) *gin.Engine {
+	h := NewParticipantImportHandler(svc)
+	r := gin.New()
+	r.Use(authenticatedMiddleware("owner1"))
+	r.POST("/contests/:id/participants/import/preview", h.PreviewImport)
+	r.POST("/contests/:id/participants/import", h.ApplyImport)
+	return r
+}
+
+// ====================
+// PreviewImport
+// ====================
+
+func TestPreviewImport_Success(t *testing.T) {
+	contestID := uuid.New()
+	svc := mocks.NewParticipantImportService(t)
+	svc.EXPECT().PreviewImport(mock.Anything, contestID, mock.Anything, "owner1").
+		Return(&model.ParticipantImportPreview{Participants: []model.ParticipantImportChange{{UserID: "a@b.com", Action: model.ParticipantImportAdd}}}, nil)
+
+	w := doRequest(importRouter(svc), csvUpload("/contests/"+contestID.String()+"/participants/import/preview", []byte("email,role,max_squares\n")))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp model.ParticipantImportPreview
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Participants, 1)
+}
+
+func TestPreviewImport_InvalidID(t *testing.T) {
+	w := doRequest(importRouter(mocks.NewParticipantImportService(t)), csvUpload("/contests/bad/participants/import/preview", []byte("x")))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestPreviewImport_MissingFile(t *testing.T) {
+	req := jsonReq(http.MethodPost, "/contests/"+uuid.New().String()+"/participants/import/preview", map[string]string{})
+	w := doRequest(importRouter(mocks.NewParticipantImportService(t)), req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestPreviewImport_FileTooLarge(t *testing.T) {
+	w := doRequest(importRouter(mocks.NewParticipantImportService(t)),
+		csvUpload("/contests/"+uuid.New().String()+"/participants/import/preview", bytes.Repeat([]byte("a"), maxImportFileSize+1)))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestPreviewImport_InvalidFile(t *testing.T) {
+	previewImportErr(t, errs.ErrInvalidImportFile, http.StatusBadRequest)
+}
+func TestPreviewImport_NotFound(t *testing.T) {
+	previewImportErr(t, gorm.ErrRecordNotFound, http.StatusNotFound)
+}
+func TestPreviewImport_Forbidden(t *testing.T) {
+	previewImportErr(t, errs.ErrInsufficientRole, http.StatusForbidden)
+}
+func TestPreviewImport_Finalized(t *testing.T) {
+	previewImportErr(t, errs.ErrContestFinalized, http.StatusConflict)
+}
+func TestPreviewImport_InternalError(t *testing.T) {
+	previewImportErr(t, errs.ErrDatabaseUnavailable, http.StatusInternalServerError)
+}
+
+func previewImportErr(t *testing.T, svcErr error, wantCode int) {
+	t.Helper()
+	svc := mocks.NewParticipantImportService(t)
+	svc.EXPECT().PreviewImport(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, svcErr)
+
+	w := doRequest(importRouter(svc), csvUpload("/contests/"+uuid.New().String()+"/participants/import/preview", []byte("email\n")))
+	assert.Equal(t, wantCode, w.Code)
+}
+
+// ====================
+// ApplyImport
+// ====================
+
+func TestApplyImport_Success(t *testing.T) {
+	contestID := uuid.New()
+	svc := mocks.NewParticipantImportService(t)
+	svc.EXPECT().ApplyImport(mock.Anything, contestID, mock.Anything, "owner1").
+		Return(&model.ParticipantImportPreview{Applied: true}, nil)
+
+	w := doRequest(importRouter(svc), csvUpload("/contests/"+contestID.String()+"/participants/import", []byte("email,role,max_squares\n")))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp model.ParticipantImportPreview
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.True(t, resp.Applied)
+}
+
+func TestApplyImport_Rejected(t *testing.T) {
+	svc := mocks.NewParticipantImportService(t)
+	svc.EXPECT().ApplyImport(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(&model.ParticipantImportPreview{Errors: []model.ImportRowError{{Line: 2, Message: "Email is required"}}}, errs.ErrImportRejected)
+
+	w := doRequest(importRouter(svc), csvUpload("/contests/"+uuid.New().String()+"/participants/import", []byte("email,role,max_squares\n,,\n")))
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var resp model.ParticipantImportPreview
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, 2, resp.Errors[0].Line)
+}
+
+func TestApplyImport_SquareTaken(t *testing.T) {
+	applyImportErr(t, errs.ErrSquareAlreadyClaimed, http.StatusConflict)
+}
+func TestApplyImport_PoolExhausted(t *testing.T) {
+	applyImportErr(t, errs.ErrNotEnoughSquares, http.StatusConflict)
+}
+func TestApplyImport_InternalError(t *testing.T) {
+	applyImportErr(t, assert.AnError, http.StatusInternalServerError)
+}
+
+func applyImportErr(t *testing.T, svcErr error, wantCode int) {
+	t.Helper()
+	svc := mocks.NewParticipantImportService(t)
+	svc.EXPECT().ApplyImport(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, svcErr)
+
+	w := doRequest(importRouter(svc), csvUpload("/contests/"+uuid.New().String()+"/participants/import", []byte("email\n")))
+	assert.Equal(t, wantCode, w.Code)
+}
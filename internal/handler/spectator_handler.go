@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/service"
+	"github.com/maxmorhardt/squares-api/internal/util"
+	"gorm.io/gorm"
+)
+
+type SpectatorHandler interface {
+	CreateSpectatorToken(c *gin.Context)
+	GetSpectatorTokens(c *gin.Context)
+	RevokeSpectatorToken(c *gin.Context)
+	GetSpectatorSnapshot(c *gin.Context)
+}
+
+type spectatorHandler struct {
+	spectatorService service.SpectatorService
+}
+
+func NewSpectatorHandler(spectatorService service.SpectatorService) SpectatorHandler {
+	return &spectatorHandler{
+		spectatorService: spectatorService,
+	}
+}
+
+// @Summary Create a spectator link for a contest
+// @Description Creates a revocable, optionally expiring read-only link for people without an account. It never makes anyone a participant
+// @Tags spectators
+// @Accept json
+// @Produce json
+// @Param id path string true "Contest ID"
+// @Param spectator body model.CreateSpectatorTokenRequest true "Spectator link details"
+// @Success 200 {object} model.ContestSpectatorToken
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/spectators [post]
+func (h *spectatorHandler) CreateSpectatorToken(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		log.Warn("invalid contest id", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid contest ID", c))
+		return
+	}
+
+	var req model.CreateSpectatorTokenRequest
+	if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
+		log.Warn("failed to bind create spectator token json", "error", bindErr)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(errs.ErrInvalidRequestBody), c))
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	token, err := h.spectatorService.CreateToken(c.Request.Context(), contestID, &req, user)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(errs.ErrContestNotFound), c))
+		case errors.Is(err, errs.ErrNotParticipant), errors.Is(err, errs.ErrInsufficientRole):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(errs.ErrInsufficientRole), c))
+		default:
+			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to create spectator link", c))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, token)
+}
+
+// @Summary Get spectator links for a contest
+// @Description Lists the contest's spectator links, newest first
+// @Tags spectators
+// @Produce json
+// @Param id path string true "Contest ID"
+// @Success 200 {array} model.ContestSpectatorToken
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/spectators [get]
+func (h *spectatorHandler) GetSpectatorTokens(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		log.Warn("invalid contest id", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid contest ID", c))
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	tokens, err := h.spectatorService.GetTokensByContestID(c.Request.Context(), contestID, user)
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrNotParticipant), errors.Is(err, errs.ErrInsufficientRole):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(errs.ErrInsufficientRole), c))
+		default:
+			log.Error("failed to get spectator tokens", "error", err)
+			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to get spectator links", c))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// @Summary Revoke a spectator link
+// @Description Deletes the link and closes any spectator connections using it
+// @Tags spectators
+// @Param id path string true "Contest ID"
+// @Param tokenId path string true "Spectator link ID"
+// @Success 204
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/spectators/{tokenId} [delete]
+func (h *spectatorHandler) RevokeSpectatorToken(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		log.Warn("invalid contest id", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid contest ID", c))
+		return
+	}
+
+	tokenID, err := uuid.Parse(c.Param("tokenId"))
+	if err != nil {
+		log.Warn("invalid spectator token id", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid spectator link ID", c))
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	if err := h.spectatorService.RevokeToken(c.Request.Context(), contestID, tokenID, user); err != nil {
+		switch {
+		case errors.Is(err, errs.ErrSpectatorTokenNotFound):
+			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrNotParticipant), errors.Is(err, errs.ErrInsufficientRole):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(errs.ErrInsufficientRole), c))
+		default:
+			log.Error("failed to revoke spectator token", "error", err)
+			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to revoke spectator link", c))
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary View a contest through a spectator link
+// @Description Returns the contest without authentication. Participant emails are removed; display names and initials remain
+// @Tags spectators
+// @Produce json
+// @Param token path string true "Spectator token"
+// @Success 200 {object} model.ContestSwagger
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Router /spectate/{token} [get]
+func (h *spectatorHandler) GetSpectatorSnapshot(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	contest, err := h.spectatorService.GetSnapshot(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrSpectatorTokenNotFound):
+			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrSpectatorTokenExpired):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(errs.ErrContestNotFound), c))
+		default:
+			log.Error("failed to get spectator snapshot", "error", err)
+			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to get contest", c))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, contest)
+}
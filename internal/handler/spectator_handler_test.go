@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/mocks"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func spectatorRouter(svc *mocks.SpectatorService) *gin.Engine {
+	h := NewSpectatorHandler(svc)
+	r := gin.New()
+	r.GET("/spectate/:token", h.GetSpectatorSnapshot)
+
+	authed := r.Group("", authenticatedMiddleware("owner1"))
+	authed.POST("/contests/:id/spectators", h.CreateSpectatorToken)
+	authed.GET("/contests/:id/spectators", h.GetSpectatorTokens)
+	authed.DELETE("/contests/:id/spectators/:tokenId", h.RevokeSpectatorToken)
+	return r
+}
+
+func TestCreateSpectatorToken_Success(t *testing.T) {
+	contestID := uuid.New()
+	svc := mocks.NewSpectatorService(t)
+	svc.EXPECT().CreateToken(mock.Anything, contestID, &model.CreateSpectatorTokenRequest{Label: "Grandma", ExpiresIn: 60}, "owner1").
+		Return(&model.ContestSpectatorToken{ID: uuid.New(), Token: "abc123", Label: "Grandma"}, nil)
+
+	w := doRequest(spectatorRouter(svc), jsonReq(http.MethodPost, "/contests/"+contestID.String()+"/spectators", model.CreateSpectatorTokenRequest{Label: "Grandma", ExpiresIn: 60}))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp model.ContestSpectatorToken
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "abc123", resp.Token)
+}
+
+func TestCreateSpectatorToken_InvalidBody(t *testing.T) {
+	w := doRequest(spectatorRouter(mocks.NewSpectatorService(t)), jsonReq(http.MethodPost, "/contests/"+uuid.New().String()+"/spectators", map[string]any{"expiresIn": -1}))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCreateSpectatorToken_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{gorm.ErrRecordNotFound, http.StatusNotFound},
+		{errs.ErrInsufficientRole, http.StatusForbidden},
+		{errs.ErrNotParticipant, http.StatusForbidden},
+		{errs.ErrDatabaseUnavailable, http.StatusInternalServerError},
+	} {
+		svc := mocks.NewSpectatorService(t)
+		svc.EXPECT().CreateToken(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, tc.err)
+
+		w := doRequest(spectatorRouter(svc), jsonReq(http.MethodPost, "/contests/"+uuid.New().String()+"/spectators", model.CreateSpectatorTokenRequest{}))
+		assert.Equal(t, tc.code, w.Code, tc.err.Error())
+	}
+}
+
+func TestGetSpectatorTokens(t *testing.T) {
+	svc := mocks.NewSpectatorService(t)
+	svc.EXPECT().GetTokensByContestID(mock.Anything, mock.Anything, "owner1").
+		Return([]model.ContestSpectatorToken{{Token: "a"}, {Token: "b"}}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/contests/"+uuid.New().String()+"/spectators", http.NoBody)
+	w := doRequest(spectatorRouter(svc), req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp []model.ContestSpectatorToken
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp, 2)
+}
+
+func TestGetSpectatorTokens_Forbidden(t *testing.T) {
+	svc := mocks.NewSpectatorService(t)
+	svc.EXPECT().GetTokensByContestID(mock.Anything, mock.Anything, mock.Anything).Return(nil, errs.ErrInsufficientRole)
+
+	req, _ := http.NewRequest(http.MethodGet, "/contests/"+uuid.New().String()+"/spectators", http.NoBody)
+	assert.Equal(t, http.StatusForbidden, doRequest(spectatorRouter(svc), req).Code)
+}
+
+func TestRevokeSpectatorToken(t *testing.T) {
+	contestID, tokenID := uuid.New(), uuid.New()
+	svc := mocks.NewSpectatorService(t)
+	svc.EXPECT().RevokeToken(mock.Anything, contestID, tokenID, "owner1").Return(nil)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/contests/"+contestID.String()+"/spectators/"+tokenID.String(), http.NoBody)
+	assert.Equal(t, http.StatusNoContent, doRequest(spectatorRouter(svc), req).Code)
+}
+
+func TestRevokeSpectatorToken_Errors(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodDelete, "/contests/"+uuid.New().String()+"/spectators/bad", http.NoBody)
+	assert.Equal(t, http.StatusBadRequest, doRequest(spectatorRouter(mocks.NewSpectatorService(t)), req).Code)
+
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{errs.ErrSpectatorTokenNotFound, http.StatusNotFound},
+		{errs.ErrInsufficientRole, http.StatusForbidden},
+		{errs.ErrDatabaseUnavailable, http.StatusInternalServerError},
+	} {
+		svc := mocks.NewSpectatorService(t)
+		svc.EXPECT().RevokeToken(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.err)
+
+		req, _ := http.NewRequest(http.MethodDelete, "/contests/"+uuid.New().String()+"/spectators/"+uuid.New().String(), http.NoBody)
+		assert.Equal(t, tc.code, doRequest(spectatorRouter(svc), req).Code, tc.err.Error())
+	}
+}
+
+func TestGetSpectatorSnapshot(t *testing.T) {
+	svc := mocks.NewSpectatorService(t)
+	svc.EXPECT().GetSnapshot(mock.Anything, "tok").Return(&model.Contest{Name: "Family Pool"}, nil)
+
+	// no auth middleware on this route: anyone holding the link can read it
+	req, _ := http.NewRequest(http.MethodGet, "/spectate/tok", http.NoBody)
+	w := doRequest(spectatorRouter(svc), req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, strings.Contains(w.Body.String(), "Family Pool"))
+}
+
+func TestGetSpectatorSnapshot_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{errs.ErrSpectatorTokenNotFound, http.StatusNotFound},
+		{errs.ErrSpectatorTokenExpired, http.StatusForbidden},
+		{gorm.ErrRecordNotFound, http.StatusNotFound},
+		{errs.ErrDatabaseUnavailable, http.StatusInternalServerError},
+	} {
+		svc := mocks.NewSpectatorService(t)
+		svc.EXPECT().GetSnapshot(mock.Anything, "tok").Return(nil, tc.err)
+
+		req, _ := http.NewRequest(http.MethodGet, "/spectate/tok", http.NoBody)
+		assert.Equal(t, tc.code, doRequest(spectatorRouter(svc), req).Code, tc.err.Error())
+	}
+}
@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/service"
+	"github.com/maxmorhardt/squares-api/internal/util"
+	"gorm.io/gorm"
+)
+
+type SwapHandler interface {
+	GetPendingSwaps(c *gin.Context)
+	RequestSwap(c *gin.Context)
+	AcceptSwap(c *gin.Context)
+	DeclineSwap(c *gin.Context)
+}
+
+type swapHandler struct {
+	swapService service.SwapService
+}
+
+func NewSwapHandler(swapService service.SwapService) SwapHandler {
+	return &swapHandler{
+		swapService: swapService,
+	}
+}
+
+// @Summary List pending square swaps
+// @Description Returns the pending swap requests the authenticated user has sent or received in a contest
+// @Tags swaps
+// @Produce json
+// @Param id path string true "Contest ID"
+// @Success 200 {array} model.SquareSwap
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/swaps [get]
+func (h *swapHandler) GetPendingSwaps(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		log.Warn("invalid contest id", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid contest ID", c))
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	swaps, err := h.swapService.GetPendingSwaps(c.Request.Context(), contestID, user)
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrNotParticipant), errors.Is(err, errs.ErrInsufficientRole):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(errs.ErrInsufficientRole), c))
+		default:
+			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, util.CapitalizeFirstLetter(errs.ErrDatabaseUnavailable), c))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, swaps)
+}
+
+// @Summary Request a square swap
+// @Description Offers one of the caller's squares in exchange for a square held by another participant. The swap only happens once the other participant accepts
+// @Tags swaps
+// @Accept json
+// @Produce json
+// @Param id path string true "Contest ID"
+// @Param request body model.CreateSwapRequest true "Squares to exchange"
+// @Success 201 {object} model.SquareSwap
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/swaps [post]
+func (h *swapHandler) RequestSwap(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		log.Warn("invalid contest id", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid contest ID", c))
+		return
+	}
+
+	var req model.CreateSwapRequest
+	if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
+		log.Warn("invalid request body", "error", bindErr)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(errs.ErrInvalidRequestBody), c))
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	swap, err := h.swapService.RequestSwap(c.Request.Context(), contestID, &req, user)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(errs.ErrSquareNotFound), c))
+		case errors.Is(err, errs.ErrSquareNotEditable), errors.Is(err, errs.ErrUnauthorizedSquareEdit):
+			c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
+		case errors.Is(err, errs.ErrDatabaseUnavailable):
+			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, util.CapitalizeFirstLetter(err), c))
+		default:
+			c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(err), c))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, swap)
+}
+
+// @Summary Accept a square swap
+// @Description Exchanges the two squares in a pending swap request. Only the recipient can accept, and only while the contest is still open
+// @Tags swaps
+// @Produce json
+// @Param id path string true "Contest ID"
+// @Param swapId path string true "Swap ID"
+// @Success 200 {array} model.Square
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 409 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/swaps/{swapId}/accept [post]
+func (h *swapHandler) AcceptSwap(c *gin.Context) {
+	contestID, swapID, ok := parseSwapPath(c)
+	if !ok {
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	squares, err := h.swapService.AcceptSwap(c.Request.Context(), contestID, swapID, user)
+	if err != nil {
+		respondSwapError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, squares)
+}
+
+// @Summary Decline or withdraw a square swap
+// @Description Declines a pending swap request when called by the recipient, or withdraws it when called by the requester
+// @Tags swaps
+// @Produce json
+// @Param id path string true "Contest ID"
+// @Param swapId path string true "Swap ID"
+// @Success 200 {object} model.SquareSwap
+// @Failure 400 {object} model.APIError
+// @Failure 403 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 409 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /contests/{id}/swaps/{swapId}/decline [post]
+func (h *swapHandler) DeclineSwap(c *gin.Context) {
+	contestID, swapID, ok := parseSwapPath(c)
+	if !ok {
+		return
+	}
+
+	user := c.GetString(model.UserKey)
+	swap, err := h.swapService.DeclineSwap(c.Request.Context(), contestID, swapID, user)
+	if err != nil {
+		respondSwapError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, swap)
+}
+
+func parseSwapPath(c *gin.Context) (contestID, swapID uuid.UUID, ok bool) {
+	log := util.LoggerFromGinContext(c)
+
+	contestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		log.Warn("invalid contest id", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid contest ID", c))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	swapID, err = uuid.Parse(c.Param("swapId"))
+	if err != nil {
+		log.Warn("invalid swap id", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid swap ID", c))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return contestID, swapID, true
+}
+
+func respondSwapError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(errs.ErrContestNotFound), c))
+	case errors.Is(err, errs.ErrSwapNotFound):
+		c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(err), c))
+	case errors.Is(err, errs.ErrUnauthorizedSwap), errors.Is(err, errs.ErrSquareNotEditable):
+		c.JSON(http.StatusForbidden, model.NewAPIError(http.StatusForbidden, util.CapitalizeFirstLetter(err), c))
+	case errors.Is(err, errs.ErrSwapNotPending), errors.Is(err, errs.ErrSwapOutdated):
+		c.JSON(http.StatusConflict, model.NewAPIError(http.StatusConflict, util.CapitalizeFirstLetter(err), c))
+	case errors.Is(err, errs.ErrDatabaseUnavailable):
+		c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, util.CapitalizeFirstLetter(err), c))
+	default:
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(err), c))
+	}
+}
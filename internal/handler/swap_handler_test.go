@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/mocks"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func swapRouter(h SwapHandler, user string) *gin.Engine {
+	r := gin.New()
+	r.Use(authenticatedMiddleware(user))
+	r.GET("/contests/:id/swaps", h.GetPendingSwaps)
+	r.POST("/contests/:id/swaps", h.RequestSwap)
+	r.POST("/contests/:id/swaps/:swapId/accept", h.AcceptSwap)
+	r.POST("/contests/:id/swaps/:swapId/decline", h.DeclineSwap)
+	return r
+}
+
+// ====================
+// GetPendingSwaps
+// ====================
+
+func TestGetPendingSwaps_Success(t *testing.T) {
+	svc := mocks.NewSwapService(t)
+	svc.EXPECT().GetPendingSwaps(mock.Anything, mock.Anything, "user1").Return([]model.SquareSwap{{ID: uuid.New()}}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/contests/%s/swaps", uuid.New()), http.NoBody)
+	w := doRequest(swapRouter(NewSwapHandler(svc), "user1"), req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestGetPendingSwaps_NotParticipant(t *testing.T) {
+	svc := mocks.NewSwapService(t)
+	svc.EXPECT().GetPendingSwaps(mock.Anything, mock.Anything, mock.Anything).Return(nil, errs.ErrNotParticipant)
+
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/contests/%s/swaps", uuid.New()), http.NoBody)
+	w := doRequest(swapRouter(NewSwapHandler(svc), "user1"), req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+// ====================
+// RequestSwap
+// ====================
+
+func TestRequestSwap_Success(t *testing.T) {
+	svc := mocks.NewSwapService(t)
+	svc.EXPECT().RequestSwap(mock.Anything, mock.Anything, mock.Anything, "user1").
+		Return(&model.SquareSwap{ID: uuid.New(), Status: model.SwapStatusPending}, nil)
+
+	body, _ := json.Marshal(model.CreateSwapRequest{FromSquareID: uuid.New(), ToSquareID: uuid.New()})
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/contests/%s/swaps", uuid.New()), bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := doRequest(swapRouter(NewSwapHandler(svc), "user1"), req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestRequestSwap_InvalidBody(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/contests/%s/swaps", uuid.New()), bytes.NewReader([]byte(`{}`)))
+	req.Header.Set("Content-Type", "application/json")
+	w := doRequest(swapRouter(NewSwapHandler(mocks.NewSwapService(t)), "user1"), req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRequestSwap_SquareNotFound(t *testing.T) {
+	requestSwapErr(t, gorm.ErrRecordNotFound, http.StatusNotFound)
+}
+func TestRequestSwap_InvalidSwap(t *testing.T) {
+	requestSwapErr(t, errs.ErrInvalidSwap, http.StatusBadRequest)
+}
+func TestRequestSwap_NotEditable(t *testing.T) {
+	requestSwapErr(t, errs.ErrSquareNotEditable, http.StatusForbidden)
+}
+
+func requestSwapErr(t *testing.T, svcErr error, wantCode int) {
+	t.Helper()
+	svc := mocks.NewSwapService(t)
+	svc.EXPECT().RequestSwap(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, svcErr)
+
+	body, _ := json.Marshal(model.CreateSwapRequest{FromSquareID: uuid.New(), ToSquareID: uuid.New()})
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/contests/%s/swaps", uuid.New()), bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := doRequest(swapRouter(NewSwapHandler(svc), "user1"), req)
+	assert.Equal(t, wantCode, w.Code)
+}
+
+// ====================
+// AcceptSwap / DeclineSwap
+// ====================
+
+func TestAcceptSwap_Success(t *testing.T) {
+	svc := mocks.NewSwapService(t)
+	svc.EXPECT().AcceptSwap(mock.Anything, mock.Anything, mock.Anything, "user1").Return([]model.Square{{}, {}}, nil)
+
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/contests/%s/swaps/%s/accept", uuid.New(), uuid.New()), http.NoBody)
+	w := doRequest(swapRouter(NewSwapHandler(svc), "user1"), req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAcceptSwap_InvalidSwapID(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/contests/%s/swaps/bad/accept", uuid.New()), http.NoBody)
+	w := doRequest(swapRouter(NewSwapHandler(mocks.NewSwapService(t)), "user1"), req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAcceptSwap_NotFound(t *testing.T) {
+	acceptSwapErr(t, errs.ErrSwapNotFound, http.StatusNotFound)
+}
+func TestAcceptSwap_NotRecipient(t *testing.T) {
+	acceptSwapErr(t, errs.ErrUnauthorizedSwap, http.StatusForbidden)
+}
+func TestAcceptSwap_Outdated(t *testing.T) {
+	acceptSwapErr(t, errs.ErrSwapOutdated, http.StatusConflict)
+}
+func TestAcceptSwap_DatabaseUnavailable(t *testing.T) {
+	acceptSwapErr(t, errs.ErrDatabaseUnavailable, http.StatusInternalServerError)
+}
+
+func acceptSwapErr(t *testing.T, svcErr error, wantCode int) {
+	t.Helper()
+	svc := mocks.NewSwapService(t)
+	svc.EXPECT().AcceptSwap(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, svcErr)
+
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/contests/%s/swaps/%s/accept", uuid.New(), uuid.New()), http.NoBody)
+	w := doRequest(swapRouter(NewSwapHandler(svc), "user1"), req)
+	assert.Equal(t, wantCode, w.Code)
+}
+
+func TestDeclineSwap_Success(t *testing.T) {
+	svc := mocks.NewSwapService(t)
+	svc.EXPECT().DeclineSwap(mock.Anything, mock.Anything, mock.Anything, "user1").
+		Return(&model.SquareSwap{Status: model.SwapStatusDeclined}, nil)
+
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/contests/%s/swaps/%s/decline", uuid.New(), uuid.New()), http.NoBody)
+	w := doRequest(swapRouter(NewSwapHandler(svc), "user1"), req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestDeclineSwap_NotPending(t *testing.T) {
+	svc := mocks.NewSwapService(t)
+	svc.EXPECT().DeclineSwap(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errs.ErrSwapNotPending)
+
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/contests/%s/swaps/%s/decline", uuid.New(), uuid.New()), http.NoBody)
+	w := doRequest(swapRouter(NewSwapHandler(svc), "user1"), req)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
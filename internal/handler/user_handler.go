@@ -2,10 +2,12 @@ package handler
 
 import (
 	"errors"
+	"io"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/maxmorhardt/squares-api/internal/errs"
 	"github.com/maxmorhardt/squares-api/internal/model"
 	"github.com/maxmorhardt/squares-api/internal/service"
@@ -15,6 +17,10 @@ import (
 type UserHandler interface {
 	GetMe(c *gin.Context)
 	UpdateMe(c *gin.Context)
+	PutMyAvatar(c *gin.Context)
+	DeleteMyAvatar(c *gin.Context)
+	GetUser(c *gin.Context)
+	GetAvatar(c *gin.Context)
 	GetMyStats(c *gin.Context)
 	GetMyActiveContests(c *gin.Context)
 	DeleteMe(c *gin.Context)
@@ -59,16 +65,19 @@ func (h *userHandler) GetMe(c *gin.Context) {
 
 func toProfileResponse(user *model.User) model.UserProfileResponse {
 	return model.UserProfileResponse{
-		Email:           user.Email,
-		DisplayName:     user.DisplayName,
-		DefaultInitials: user.DefaultInitials,
-		CreatedAt:       user.CreatedAt.Format(time.RFC3339),
+		ID:                user.ID.String(),
+		Email:             user.Email,
+		DisplayName:       user.DisplayName,
+		DefaultInitials:   user.DefaultInitials,
+		ProfileVisibility: string(user.ProfileVisibility),
+		AvatarURL:         user.AvatarURL(),
+		CreatedAt:         user.CreatedAt.Format(time.RFC3339),
 	}
 }
 
 // UpdateMe godoc
 // @Summary Update the current user's profile
-// @Description Updates the authenticated user's default initials and applies them to their squares in active contests
+// @Description Updates any of the authenticated user's default initials, display name and profile visibility; initials and name changes apply to their squares in active contests
 // @Tags users
 // @Accept json
 // @Produce json
@@ -88,15 +97,160 @@ func (h *userHandler) UpdateMe(c *gin.Context) {
 		return
 	}
 
-	profile, err := h.userService.UpdateProfile(c.Request.Context(), user, req.DefaultInitials)
+	profile, err := h.userService.UpdateProfile(c.Request.Context(), user, &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, util.CapitalizeFirstLetter(err), c))
+		switch {
+		case errors.Is(err, errs.ErrEmptyProfileUpdate):
+			c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(err), c))
+		default:
+			c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, util.CapitalizeFirstLetter(err), c))
+		}
 		return
 	}
 
 	c.JSON(http.StatusOK, toProfileResponse(profile))
 }
 
+// PutMyAvatar godoc
+// @Summary Upload the current user's avatar
+// @Description Replaces the authenticated user's avatar with a png, jpeg, gif or webp image of at most 512KB
+// @Tags users
+// @Accept multipart/form-data
+// @Produce json
+// @Param avatar formData file true "Avatar image"
+// @Success 200 {object} model.UserProfileResponse
+// @Failure 400 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /users/me/avatar [put]
+func (h *userHandler) PutMyAvatar(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+	user := c.GetString(model.UserKey)
+
+	header, err := c.FormFile("avatar")
+	if err != nil {
+		log.Warn("missing avatar file", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(errs.ErrInvalidRequestBody), c))
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		log.Error("failed to open avatar file", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(errs.ErrInvalidRequestBody), c))
+		return
+	}
+	defer file.Close()
+
+	// the request size limit already bounds this read
+	data, err := io.ReadAll(file)
+	if err != nil {
+		log.Error("failed to read avatar file", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(errs.ErrInvalidRequestBody), c))
+		return
+	}
+
+	profile, err := h.userService.SetAvatar(c.Request.Context(), user, data)
+	if err != nil {
+		respondUserError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toProfileResponse(profile))
+}
+
+// DeleteMyAvatar godoc
+// @Summary Remove the current user's avatar
+// @Description Deletes the authenticated user's avatar
+// @Tags users
+// @Success 204
+// @Failure 404 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /users/me/avatar [delete]
+func (h *userHandler) DeleteMyAvatar(c *gin.Context) {
+	user := c.GetString(model.UserKey)
+
+	if err := h.userService.DeleteAvatar(c.Request.Context(), user); err != nil {
+		respondUserError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetUser godoc
+// @Summary Get a user's public profile
+// @Description Returns another player's display name, avatar, lifetime stats and recent contests. Friends-only and private profiles are reported as not found to everyone they are hidden from
+// @Tags users
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} model.PublicProfileResponse
+// @Failure 400 {object} model.APIError
+// @Failure 404 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Security BearerAuth
+// @Router /users/{id} [get]
+func (h *userHandler) GetUser(c *gin.Context) {
+	userID, ok := parseUserPath(c)
+	if !ok {
+		return
+	}
+
+	profile, err := h.userService.GetPublicProfile(c.Request.Context(), userID, c.GetString(model.UserKey))
+	if err != nil {
+		respondUserError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// GetAvatar godoc
+// @Summary Get an avatar image
+// @Description Returns an avatar without authentication so it can back an image tag. The url comes from a visible profile's avatarUrl and changes on every upload or visibility change
+// @Tags users
+// @Produce image/png,image/jpeg,image/gif,image/webp
+// @Param key path string true "Avatar key"
+// @Success 200 {file} binary
+// @Failure 404 {object} model.APIError
+// @Failure 500 {object} model.APIError
+// @Router /avatars/{key} [get]
+func (h *userHandler) GetAvatar(c *gin.Context) {
+	avatar, err := h.userService.GetAvatar(c.Request.Context(), c.Param("key"))
+	if err != nil {
+		respondUserError(c, err)
+		return
+	}
+
+	// the key changes whenever the image or its visibility does, so the url is safe to cache
+	c.Header("Cache-Control", "public, max-age=86400")
+	c.Data(http.StatusOK, avatar.ContentType, avatar.Data)
+}
+
+func parseUserPath(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		util.LoggerFromGinContext(c).Warn("invalid user id", "error", err)
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, "Invalid user ID", c))
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
+
+func respondUserError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errs.ErrAvatarTooLarge), errors.Is(err, errs.ErrUnsupportedAvatar):
+		c.JSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(err), c))
+	case errors.Is(err, errs.ErrUserNotFound), errors.Is(err, errs.ErrAvatarNotFound):
+		c.JSON(http.StatusNotFound, model.NewAPIError(http.StatusNotFound, util.CapitalizeFirstLetter(err), c))
+	default:
+		c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, util.CapitalizeFirstLetter(err), c))
+	}
+}
+
 // GetMyStats godoc
 // @Summary Get the current user's stats
 // @Description Returns contest and square stats for the authenticated user
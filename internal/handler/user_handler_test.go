@@ -1,11 +1,15 @@
 package handler
 
 import (
+	"bytes"
 	"encoding/json"
+	"mime/multipart"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/maxmorhardt/squares-api/internal/errs"
 	"github.com/maxmorhardt/squares-api/internal/mocks"
 	"github.com/maxmorhardt/squares-api/internal/model"
@@ -24,6 +28,10 @@ func newUserRouter(t *testing.T) (*mocks.UserService, *gin.Engine) {
 	r.GET("/users/me", h.GetMe)
 	r.PATCH("/users/me", h.UpdateMe)
 	r.DELETE("/users/me", h.DeleteMe)
+	r.PUT("/users/me/avatar", h.PutMyAvatar)
+	r.DELETE("/users/me/avatar", h.DeleteMyAvatar)
+	r.GET("/users/:id", h.GetUser)
+	r.GET("/avatars/:key", h.GetAvatar)
 	r.GET("/users/me/stats", h.GetMyStats)
 	r.GET("/users/me/active-contests", h.GetMyActiveContests)
 
@@ -66,36 +74,202 @@ func TestGetMe_ServiceError(t *testing.T) {
 
 func TestUpdateMe_Success(t *testing.T) {
 	svc, r := newUserRouter(t)
-	svc.EXPECT().UpdateProfile(mock.Anything, "a@b.com", "MM").
-		Return(&model.User{Email: "a@b.com", DisplayName: "Max", DefaultInitials: "MM"}, nil)
+	initials, name := "MM", "Maxwell"
+	svc.EXPECT().UpdateProfile(mock.Anything, "a@b.com", &model.UpdateUserProfileRequest{DefaultInitials: &initials, DisplayName: &name}).
+		Return(&model.User{Email: "a@b.com", DisplayName: "Maxwell", DefaultInitials: "MM"}, nil)
 
-	w := doRequest(r, jsonReq(http.MethodPatch, "/users/me", model.UpdateUserProfileRequest{DefaultInitials: "MM"}))
+	w := doRequest(r, jsonReq(http.MethodPatch, "/users/me", model.UpdateUserProfileRequest{DefaultInitials: &initials, DisplayName: &name}))
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	var resp model.UserProfileResponse
 	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
 	assert.Equal(t, "MM", resp.DefaultInitials)
+	assert.Equal(t, "Maxwell", resp.DisplayName)
 }
 
 func TestUpdateMe_InvalidBody(t *testing.T) {
 	_, r := newUserRouter(t)
 
 	// lowercase fails the uppercase/alphanum binding
-	w := doRequest(r, jsonReq(http.MethodPatch, "/users/me", model.UpdateUserProfileRequest{DefaultInitials: "mm"}))
+	initials := "mm"
+	w := doRequest(r, jsonReq(http.MethodPatch, "/users/me", model.UpdateUserProfileRequest{DefaultInitials: &initials}))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestUpdateMe_InvalidVisibility(t *testing.T) {
+	_, r := newUserRouter(t)
+
+	visibility := "everyone"
+	w := doRequest(r, jsonReq(http.MethodPatch, "/users/me", model.UpdateUserProfileRequest{ProfileVisibility: &visibility}))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestUpdateMe_EmptyUpdate(t *testing.T) {
+	svc, r := newUserRouter(t)
+	svc.EXPECT().UpdateProfile(mock.Anything, "a@b.com", &model.UpdateUserProfileRequest{}).
+		Return(nil, errs.ErrEmptyProfileUpdate)
+
+	w := doRequest(r, jsonReq(http.MethodPatch, "/users/me", model.UpdateUserProfileRequest{}))
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
 func TestUpdateMe_ServiceError(t *testing.T) {
 	svc, r := newUserRouter(t)
-	svc.EXPECT().UpdateProfile(mock.Anything, "a@b.com", "MM").
+	initials := "MM"
+	svc.EXPECT().UpdateProfile(mock.Anything, "a@b.com", mock.Anything).
 		Return(nil, errs.ErrDatabaseUnavailable)
 
-	w := doRequest(r, jsonReq(http.MethodPatch, "/users/me", model.UpdateUserProfileRequest{DefaultInitials: "MM"}))
+	w := doRequest(r, jsonReq(http.MethodPatch, "/users/me", model.UpdateUserProfileRequest{DefaultInitials: &initials}))
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
 
+// ====================
+// Avatars
+// ====================
+
+func avatarUpload(t *testing.T, data []byte) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile("avatar", "me.png")
+	require.NoError(t, err)
+	_, err = part.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+
+	req, _ := http.NewRequest(http.MethodPut, "/users/me/avatar", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req
+}
+
+func TestPutMyAvatar_Success(t *testing.T) {
+	svc, r := newUserRouter(t)
+	updated := time.Unix(1783728000, 0)
+	key := "0123456789abcdef0123456789abcdef"
+	svc.EXPECT().SetAvatar(mock.Anything, "a@b.com", []byte("png-bytes")).
+		Return(&model.User{ID: uuid.New(), Email: "a@b.com", AvatarUpdatedAt: &updated, AvatarKey: &key}, nil)
+
+	w := doRequest(r, avatarUpload(t, []byte("png-bytes")))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp model.UserProfileResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "/avatars/"+key, resp.AvatarURL)
+}
+
+func TestPutMyAvatar_MissingFile(t *testing.T) {
+	_, r := newUserRouter(t)
+
+	req, _ := http.NewRequest(http.MethodPut, "/users/me/avatar", http.NoBody)
+	w := doRequest(r, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestPutMyAvatar_Unsupported(t *testing.T) {
+	svc, r := newUserRouter(t)
+	svc.EXPECT().SetAvatar(mock.Anything, "a@b.com", mock.Anything).
+		Return(nil, errs.ErrUnsupportedAvatar)
+
+	w := doRequest(r, avatarUpload(t, []byte("not an image")))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestDeleteMyAvatar(t *testing.T) {
+	t.Run("deleted", func(t *testing.T) {
+		svc, r := newUserRouter(t)
+		svc.EXPECT().DeleteAvatar(mock.Anything, "a@b.com").Return(nil)
+
+		req, _ := http.NewRequest(http.MethodDelete, "/users/me/avatar", http.NoBody)
+		w := doRequest(r, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("no avatar", func(t *testing.T) {
+		svc, r := newUserRouter(t)
+		svc.EXPECT().DeleteAvatar(mock.Anything, "a@b.com").Return(errs.ErrAvatarNotFound)
+
+		req, _ := http.NewRequest(http.MethodDelete, "/users/me/avatar", http.NoBody)
+		w := doRequest(r, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestGetAvatar_Success(t *testing.T) {
+	svc := mocks.NewUserService(t)
+	svc.EXPECT().GetAvatar(mock.Anything, "k1").
+		Return(&model.UserAvatar{UserID: uuid.New(), ContentType: "image/png", Data: []byte("png-bytes")}, nil)
+
+	// no auth middleware: image tags can't send a bearer token
+	r := gin.New()
+	r.GET("/avatars/:key", NewUserHandler(svc).GetAvatar)
+
+	req, _ := http.NewRequest(http.MethodGet, "/avatars/k1", http.NoBody)
+	w := doRequest(r, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+	assert.Equal(t, "png-bytes", w.Body.String())
+}
+
+func TestGetAvatar_NotFound(t *testing.T) {
+	svc, r := newUserRouter(t)
+	svc.EXPECT().GetAvatar(mock.Anything, "old").Return(nil, errs.ErrAvatarNotFound)
+
+	req, _ := http.NewRequest(http.MethodGet, "/avatars/old", http.NoBody)
+	w := doRequest(r, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+// ====================
+// GetUser
+// ====================
+
+func TestGetUser_Success(t *testing.T) {
+	svc, r := newUserRouter(t)
+	id := uuid.New()
+	svc.EXPECT().GetPublicProfile(mock.Anything, id, "a@b.com").
+		Return(&model.PublicProfileResponse{ID: id.String(), DisplayName: "Max", Stats: model.UserStatsResponse{QuarterWins: 3}}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/users/"+id.String(), http.NoBody)
+	w := doRequest(r, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "email")
+	var resp model.PublicProfileResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "Max", resp.DisplayName)
+	assert.Equal(t, int64(3), resp.Stats.QuarterWins)
+}
+
+func TestGetUser_InvalidID(t *testing.T) {
+	_, r := newUserRouter(t)
+
+	req, _ := http.NewRequest(http.MethodGet, "/users/not-a-uuid", http.NoBody)
+	w := doRequest(r, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetUser_Hidden(t *testing.T) {
+	svc, r := newUserRouter(t)
+	id := uuid.New()
+	svc.EXPECT().GetPublicProfile(mock.Anything, id, "a@b.com").Return(nil, errs.ErrUserNotFound)
+
+	req, _ := http.NewRequest(http.MethodGet, "/users/"+id.String(), http.NoBody)
+	w := doRequest(r, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
 // ====================
 // GetMyStats
 // ====================
@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
+	"github.com/maxmorhardt/squares-api/internal/errs"
 	"github.com/maxmorhardt/squares-api/internal/metrics"
 	"github.com/maxmorhardt/squares-api/internal/model"
 	"github.com/maxmorhardt/squares-api/internal/repository"
@@ -31,21 +32,24 @@ func newUpgrader(allowedOrigins []string) websocket.Upgrader {
 
 type WebSocketHandler interface {
 	ContestWSConnection(c *gin.Context)
+	SpectatorWSConnection(c *gin.Context)
 }
 
 type websocketHandler struct {
 	websocketService   service.WebSocketService
 	contestRepo        repository.ContestRepository
 	participantService service.ParticipantService
+	spectatorService   service.SpectatorService
 	upgrader           websocket.Upgrader
 	natsAvailable      func() bool
 }
 
-func NewWebSocketHandler(websocketService service.WebSocketService, contestRepo repository.ContestRepository, participantService service.ParticipantService, allowedOrigins []string, nc *nats.Conn) WebSocketHandler {
+func NewWebSocketHandler(websocketService service.WebSocketService, contestRepo repository.ContestRepository, participantService service.ParticipantService, spectatorService service.SpectatorService, allowedOrigins []string, nc *nats.Conn) WebSocketHandler {
 	return &websocketHandler{
 		websocketService:   websocketService,
 		contestRepo:        contestRepo,
 		participantService: participantService,
+		spectatorService:   spectatorService,
 		upgrader:           newUpgrader(allowedOrigins),
 		natsAvailable: func() bool {
 			return nc != nil && nc.IsConnected()
@@ -114,7 +118,11 @@ func (h *websocketHandler) ContestWSConnection(c *gin.Context) {
 	if authErr := h.participantService.Authorize(c.Request.Context(), contest.ID, user, service.ActionView); authErr != nil {
 		log.Warn("user not authorized for websocket", "user", user, "contest_id", contest.ID)
 		metrics.RecordWSConnectionResult(model.WSResultUnauthorized)
-		_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(4403, "Not authorized"))
+		reason := "Not authorized"
+		if errors.Is(authErr, errs.ErrBannedFromContest) {
+			reason = util.CapitalizeFirstLetter(authErr)
+		}
+		_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(4403, reason))
 		_ = conn.Close()
 		return
 	}
@@ -144,3 +152,71 @@ func (h *websocketHandler) ContestWSConnection(c *gin.Context) {
 	// hand off to service which records the final connection result
 	h.websocketService.HandleWebSocketConnection(c.Request.Context(), contest, participants, conn)
 }
+
+// @Summary Watch a contest through a spectator link
+// @Description Read-only WebSocket stream for people without an account. Chat and participant changes are not sent, participant emails are removed, and incoming messages are ignored. The connection closes when the link is revoked or expires
+// @Tags ws
+// @Param token path string true "Spectator token"
+// @Success 101 {string} string "WebSocket connection upgraded"
+// @Failure 500 {object} model.APIError
+// @Router /ws/spectate/{token} [get]
+func (h *websocketHandler) SpectatorWSConnection(c *gin.Context) {
+	log := util.LoggerFromGinContext(c)
+
+	// upgrade first so every rejection reaches the client as a close code, like the contest socket
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		metrics.RecordWSConnectionResult(model.WSResultUpgradeFailed)
+		c.JSON(http.StatusInternalServerError, model.NewAPIError(http.StatusInternalServerError, "Failed to upgrade connection", c))
+		return
+	}
+
+	spectatorToken, err := h.spectatorService.ValidateToken(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		if errors.Is(err, errs.ErrSpectatorTokenNotFound) || errors.Is(err, errs.ErrSpectatorTokenExpired) {
+			log.Warn("invalid spectator link, closing websocket", "error", err)
+			metrics.RecordWSConnectionResult(model.WSResultUnauthorized)
+			_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(4403, util.CapitalizeFirstLetter(err)))
+			_ = conn.Close()
+			return
+		}
+
+		log.Warn("failed to validate spectator link", "error", err)
+		metrics.RecordWSConnectionResult(model.WSResultInternalError)
+		_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(4500, "Failed to validate spectator link"))
+		_ = conn.Close()
+		return
+	}
+
+	contest, err := h.contestRepo.GetByID(c.Request.Context(), spectatorToken.ContestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("contest not found, closing websocket")
+			metrics.RecordWSConnectionResult(model.WSResultNotFound)
+			_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(4404, "Contest not found"))
+			_ = conn.Close()
+			return
+		}
+
+		log.Warn("failed to get contest for spectator websocket", "error", err)
+		metrics.RecordWSConnectionResult(model.WSResultInternalError)
+		_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(4500, "Failed to get contest"))
+		_ = conn.Close()
+		return
+	}
+
+	util.SynthesizeFromGame(contest)
+
+	log = log.With("contest_id", contest.ID)
+	util.SetGinContextValue(c, model.LoggerKey, log)
+
+	if !h.natsAvailable() {
+		log.Error("NATS connection not available, rejecting websocket")
+		metrics.RecordWSConnectionResult(model.WSResultUnavailable)
+		_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(4503, "Real-time updates unavailable"))
+		_ = conn.Close()
+		return
+	}
+
+	h.websocketService.HandleSpectatorConnection(c.Request.Context(), contest, spectatorToken, conn)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/maxmorhardt/squares-api/internal/config"
+	"github.com/maxmorhardt/squares-api/internal/errs"
 	"github.com/maxmorhardt/squares-api/internal/mocks"
 	"github.com/maxmorhardt/squares-api/internal/model"
 	"github.com/maxmorhardt/squares-api/internal/service"
@@ -51,11 +52,36 @@ func wsTestConfig(t *testing.T) *model.AppConfig {
 func newWSHandler(t *testing.T, repo *mocks.ContestRepository, wsSvc *mocks.WebSocketService, pSvc *mocks.ParticipantService, natsUp bool) WebSocketHandler {
 	t.Helper()
 	cfg := wsTestConfig(t)
-	h := NewWebSocketHandler(wsSvc, repo, pSvc, cfg.Server.AllowedOrigins, nil)
+	h := NewWebSocketHandler(wsSvc, repo, pSvc, &mocks.SpectatorService{}, cfg.Server.AllowedOrigins, nil)
 	h.(*websocketHandler).natsAvailable = func() bool { return natsUp }
 	return h
 }
 
+func serveSpectatorWS(t *testing.T, repo *mocks.ContestRepository, wsSvc *mocks.WebSocketService, sSvc *mocks.SpectatorService, natsUp bool) *httptest.Server {
+	t.Helper()
+	cfg := wsTestConfig(t)
+	h := NewWebSocketHandler(wsSvc, repo, &mocks.ParticipantService{}, sSvc, cfg.Server.AllowedOrigins, nil)
+	h.(*websocketHandler).natsAvailable = func() bool { return natsUp }
+
+	// no auth middleware: spectators never log in
+	r := gin.New()
+	r.GET("/ws/spectate/:token", h.SpectatorWSConnection)
+	return httptest.NewServer(r)
+}
+
+func expectSpectatorCloseCode(t *testing.T, server *httptest.Server, code int) {
+	t.Helper()
+	conn, _, err := dialWS(t, server, "/ws/spectate/tok")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, _, err = conn.ReadMessage()
+	require.Error(t, err)
+	var closeErr *websocket.CloseError
+	require.True(t, errors.As(err, &closeErr))
+	assert.Equal(t, code, closeErr.Code)
+}
+
 func serveWS(t *testing.T, h WebSocketHandler) *httptest.Server {
 	t.Helper()
 	r := gin.New()
@@ -194,3 +220,79 @@ func TestWSHandler_HandoffToService(t *testing.T) {
 		t.Fatal("HandleWebSocketConnection was not called")
 	}
 }
+
+func TestSpectatorWS_InvalidLink(t *testing.T) {
+	for _, linkErr := range []error{errs.ErrSpectatorTokenNotFound, errs.ErrSpectatorTokenExpired} {
+		sSvc := &mocks.SpectatorService{}
+		sSvc.On("ValidateToken", mock.Anything, "tok").Return(nil, linkErr)
+
+		server := serveSpectatorWS(t, &mocks.ContestRepository{}, &mocks.WebSocketService{}, sSvc, true)
+		expectSpectatorCloseCode(t, server, 4403)
+		server.Close()
+	}
+}
+
+func TestSpectatorWS_ValidateError(t *testing.T) {
+	sSvc := &mocks.SpectatorService{}
+	sSvc.On("ValidateToken", mock.Anything, "tok").Return(nil, errs.ErrDatabaseUnavailable)
+
+	server := serveSpectatorWS(t, &mocks.ContestRepository{}, &mocks.WebSocketService{}, sSvc, true)
+	defer server.Close()
+	expectSpectatorCloseCode(t, server, 4500)
+}
+
+func TestSpectatorWS_ContestNotFound(t *testing.T) {
+	sSvc := &mocks.SpectatorService{}
+	sSvc.On("ValidateToken", mock.Anything, "tok").Return(&model.ContestSpectatorToken{ContestID: uuid.New()}, nil)
+	repo := &mocks.ContestRepository{}
+	repo.On("GetByID", mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+	server := serveSpectatorWS(t, repo, &mocks.WebSocketService{}, sSvc, true)
+	defer server.Close()
+	expectSpectatorCloseCode(t, server, 4404)
+}
+
+func TestSpectatorWS_NATSUnavailable(t *testing.T) {
+	sSvc := &mocks.SpectatorService{}
+	sSvc.On("ValidateToken", mock.Anything, "tok").Return(&model.ContestSpectatorToken{ContestID: uuid.New()}, nil)
+	repo := &mocks.ContestRepository{}
+	repo.On("GetByID", mock.Anything, mock.Anything).Return(&model.Contest{ID: uuid.New()}, nil)
+
+	server := serveSpectatorWS(t, repo, &mocks.WebSocketService{}, sSvc, false)
+	defer server.Close()
+	expectSpectatorCloseCode(t, server, 4503)
+}
+
+func TestSpectatorWS_HandoffToService(t *testing.T) {
+	contestID := uuid.New()
+	spectatorToken := &model.ContestSpectatorToken{ID: uuid.New(), ContestID: contestID, Token: "tok"}
+
+	sSvc := &mocks.SpectatorService{}
+	sSvc.On("ValidateToken", mock.Anything, "tok").Return(spectatorToken, nil)
+	repo := &mocks.ContestRepository{}
+	repo.On("GetByID", mock.Anything, contestID).Return(&model.Contest{ID: contestID}, nil)
+
+	called := make(chan struct{}, 1)
+	wsSvc := &mocks.WebSocketService{}
+	wsSvc.On("HandleSpectatorConnection", mock.Anything, mock.Anything, spectatorToken, mock.Anything).
+		Run(func(args mock.Arguments) {
+			conn := args.Get(3).(*websocket.Conn)
+			_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			_ = conn.Close()
+			called <- struct{}{}
+		}).Return()
+
+	server := serveSpectatorWS(t, repo, wsSvc, sSvc, true)
+	defer server.Close()
+
+	conn, _, err := dialWS(t, server, "/ws/spectate/tok")
+	require.NoError(t, err)
+	defer conn.Close()
+	conn.ReadMessage() //nolint:errcheck // draining until server closes
+
+	select {
+	case <-called:
+	case <-time.After(2 * time.Second):
+		t.Fatal("HandleSpectatorConnection was not called")
+	}
+}
@@ -17,6 +17,27 @@ var (
 		},
 	)
 
+	contestsRestoredTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "contests_restored_total",
+			Help: "Total number of soft-deleted contests restored by their owner",
+		},
+	)
+
+	contestsPurgedTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "contests_purged_total",
+			Help: "Total number of deleted contests permanently removed after the restore window",
+		},
+	)
+
+	contestsArchivedTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "contests_archived_total",
+			Help: "Total number of finished contests compacted into an archive snapshot",
+		},
+	)
+
 	contestsStartedTotal = prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Name: "contests_started_total",
@@ -61,6 +82,21 @@ var (
 		},
 	)
 
+	inviteEmailsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "invite_emails_total",
+			Help: "Total number of personal invite email events by outcome (sent, failed, opened)",
+		},
+		[]string{"outcome"},
+	)
+
+	spectatorTokensCreatedTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "spectator_tokens_created_total",
+			Help: "Total number of spectator links created",
+		},
+	)
+
 	participantsJoinedTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "participants_joined_total",
@@ -89,22 +125,53 @@ var (
 			Help: "Total number of squares cleared",
 		},
 	)
+
+	squareClaimConflictsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "square_claim_conflicts_total",
+			Help: "Total number of square claims rejected because another request got there first by reason (claimed or limit)",
+		},
+		[]string{"reason"},
+	)
+
+	contestsLockedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "contests_locked_total",
+			Help: "Total number of scheduled contest locks by outcome (started or not_ready)",
+		},
+		[]string{"outcome"},
+	)
+
+	idempotentReplaysTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "idempotent_replays_total",
+			Help: "Total number of retried requests answered from a stored idempotent response",
+		},
+	)
 )
 
 func init() {
 	prometheus.MustRegister(
 		contestsCreatedTotal,
 		contestsDeletedTotal,
+		contestsRestoredTotal,
+		contestsPurgedTotal,
+		contestsArchivedTotal,
 		contestsStartedTotal,
 		quarterResultsRecordedTotal,
 		quarterResultsRolledBackTotal,
 		chatMessagesTotal,
 		invitesCreatedTotal,
 		invitesRedeemedTotal,
+		inviteEmailsTotal,
+		spectatorTokensCreatedTotal,
 		participantsJoinedTotal,
 		participantsRemovedTotal,
 		squaresClaimedTotal,
 		squaresClearedTotal,
+		squareClaimConflictsTotal,
+		contestsLockedTotal,
+		idempotentReplaysTotal,
 	)
 }
 
@@ -116,6 +183,18 @@ func IncContestDeleted() {
 	contestsDeletedTotal.Inc()
 }
 
+func IncContestRestored() {
+	contestsRestoredTotal.Inc()
+}
+
+func AddContestsPurged(count int64) {
+	contestsPurgedTotal.Add(float64(count))
+}
+
+func IncContestArchived() {
+	contestsArchivedTotal.Inc()
+}
+
 func IncContestStarted() {
 	contestsStartedTotal.Inc()
 }
@@ -140,6 +219,14 @@ func IncInviteRedeemed() {
 	invitesRedeemedTotal.Inc()
 }
 
+func IncInviteEmail(outcome string) {
+	inviteEmailsTotal.WithLabelValues(outcome).Inc()
+}
+
+func IncSpectatorTokenCreated() {
+	spectatorTokensCreatedTotal.Inc()
+}
+
 func IncParticipantJoined(role string) {
 	participantsJoinedTotal.WithLabelValues(role).Inc()
 }
@@ -156,6 +243,22 @@ func IncSquareCleared() {
 	squaresClearedTotal.Inc()
 }
 
+func IncSquareClaimConflict(reason string) {
+	squareClaimConflictsTotal.WithLabelValues(reason).Inc()
+}
+
+func IncContestLocked(started bool) {
+	if started {
+		contestsLockedTotal.WithLabelValues("started").Inc()
+	} else {
+		contestsLockedTotal.WithLabelValues("not_ready").Inc()
+	}
+}
+
+func IncIdempotentReplay() {
+	idempotentReplaysTotal.Inc()
+}
+
 func quarterLabel(q int) string {
 	switch q {
 	case 1:
@@ -28,6 +28,14 @@ var (
 			Help: "Total number of new quarter scores recorded from the scoreboard",
 		},
 	)
+
+	lifecycleWorkerRunsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "lifecycle_worker_runs_total",
+			Help: "Total number of contest lifecycle worker runs by result (success or error)",
+		},
+		[]string{"result"},
+	)
 )
 
 func init() {
@@ -35,6 +43,7 @@ func init() {
 		scoresWorkerRunsTotal,
 		scoresWorkerLastSuccessTimestamp,
 		scoresRecordedTotal,
+		lifecycleWorkerRunsTotal,
 	)
 }
 
@@ -50,3 +59,11 @@ func IncScoresRun(success bool) {
 func AddScoresRecorded(n int) {
 	scoresRecordedTotal.Add(float64(n))
 }
+
+func IncLifecycleRun(success bool) {
+	if success {
+		lifecycleWorkerRunsTotal.WithLabelValues("success").Inc()
+	} else {
+		lifecycleWorkerRunsTotal.WithLabelValues("error").Inc()
+	}
+}
@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/service"
+	"github.com/maxmorhardt/squares-api/internal/util"
+)
+
+const (
+	idempotentReplayHeader = "Idempotent-Replayed"
+	// storing the outcome must not be cut short by the client hanging up once it has its response
+	idempotencyStoreTimeout = 5 * time.Second
+)
+
+// response headers a retry needs back alongside the body
+var replayedHeaders = []string{"Content-Type", "ETag"}
+
+// keeps a copy of everything the handler writes so it can be stored for retries
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// replays the first response for a user's Idempotency-Key instead of running the request again; must run after auth
+func IdempotencyMiddleware(idempotencyService service.IdempotencyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := strings.TrimSpace(c.GetHeader("Idempotency-Key"))
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		log := util.LoggerFromGinContext(c)
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			log.Warn("failed to read request body for idempotency", "error", err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIError(http.StatusBadRequest, util.CapitalizeFirstLetter(errs.ErrInvalidRequestBody), c))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		record, replay, err := idempotencyService.Begin(ctx, c.GetString(model.UserKey), key, c.Request.Method, c.Request.URL.Path, body)
+		if err != nil {
+			status := http.StatusInternalServerError
+			switch {
+			case errors.Is(err, errs.ErrInvalidIdempotencyKey):
+				status = http.StatusBadRequest
+			case errors.Is(err, errs.ErrIdempotencyKeyReused):
+				status = http.StatusUnprocessableEntity
+			case errors.Is(err, errs.ErrIdempotencyKeyInProgress):
+				status = http.StatusConflict
+			}
+			c.AbortWithStatusJSON(status, model.NewAPIError(status, util.CapitalizeFirstLetter(err), c))
+			return
+		}
+
+		if replay {
+			for name, value := range record.ResponseHeaders {
+				c.Header(name, value)
+			}
+			contentType := record.ResponseHeaders["Content-Type"]
+			if contentType == "" {
+				contentType = "application/json; charset=utf-8"
+			}
+
+			c.Header(idempotentReplayHeader, "true")
+			c.Data(record.StatusCode, contentType, record.ResponseBody)
+			c.Abort()
+			return
+		}
+
+		writer := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		storeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), idempotencyStoreTimeout)
+		defer cancel()
+
+		// a panic or server error leaves nothing worth replaying, so let the retry run for real
+		completed := false
+		defer func() {
+			if !completed {
+				_ = idempotencyService.Release(storeCtx, record)
+			}
+		}()
+
+		c.Next()
+
+		if writer.Status() >= http.StatusInternalServerError {
+			return
+		}
+
+		headers := make(map[string]string, len(replayedHeaders))
+		for _, name := range replayedHeaders {
+			if value := writer.Header().Get(name); value != "" {
+				headers[name] = value
+			}
+		}
+
+		if err := idempotencyService.Complete(storeCtx, record, writer.Status(), headers, writer.body.Bytes()); err != nil {
+			log.Warn("idempotent response not stored, retries will run the request again", "error", err)
+			return
+		}
+		completed = true
+	}
+}
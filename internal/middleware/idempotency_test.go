@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/mocks"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func idempotencyRouter(svc *mocks.IdempotencyService, status int, calls *int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(model.UserKey, "u")
+		c.Next()
+	})
+	r.Use(IdempotencyMiddleware(svc))
+	r.PUT("/contests", func(c *gin.Context) {
+		*calls++
+		var body map[string]any
+		_ = c.ShouldBindJSON(&body)
+		c.JSON(status, gin.H{"name": body["name"]})
+	})
+	return r
+}
+
+func idempotencyRequest(r *gin.Engine, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPut, "/contests", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotency_NoHeader(t *testing.T) {
+	calls := 0
+	r := idempotencyRouter(mocks.NewIdempotencyService(t), http.StatusOK, &calls)
+
+	w := idempotencyRequest(r, "", `{"name":"a"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotency_StoresFirstResponse(t *testing.T) {
+	record := &model.IdempotencyKey{UserID: "u", Key: "k"}
+	svc := mocks.NewIdempotencyService(t)
+	svc.EXPECT().Begin(mock.Anything, "u", "k", http.MethodPut, "/contests", []byte(`{"name":"a"}`)).Return(record, false, nil)
+	svc.EXPECT().Complete(mock.Anything, record, http.StatusOK, map[string]string{"Content-Type": "application/json; charset=utf-8"}, []byte(`{"name":"a"}`)).Return(nil)
+
+	calls := 0
+	w := idempotencyRequest(idempotencyRouter(svc, http.StatusOK, &calls), "k", `{"name":"a"}`)
+
+	// the handler still sees the body the middleware read
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"name":"a"}`, w.Body.String())
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotency_Replay(t *testing.T) {
+	svc := mocks.NewIdempotencyService(t)
+	svc.EXPECT().Begin(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(&model.IdempotencyKey{StatusCode: http.StatusCreated, ResponseBody: []byte(`{"name":"first"}`)}, true, nil)
+
+	calls := 0
+	w := idempotencyRequest(idempotencyRouter(svc, http.StatusOK, &calls), "k", `{"name":"a"}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.JSONEq(t, `{"name":"first"}`, w.Body.String())
+	assert.Equal(t, "true", w.Header().Get(idempotentReplayHeader))
+	assert.Equal(t, 0, calls)
+}
+
+func TestIdempotency_ReplaysHeaders(t *testing.T) {
+	svc := mocks.NewIdempotencyService(t)
+	svc.EXPECT().Begin(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(&model.IdempotencyKey{
+			StatusCode:      http.StatusOK,
+			ResponseHeaders: map[string]string{"ETag": `"4"`, "Content-Type": "text/csv"},
+			ResponseBody:    []byte("a,b"),
+		}, true, nil)
+
+	calls := 0
+	w := idempotencyRequest(idempotencyRouter(svc, http.StatusOK, &calls), "k", `{}`)
+
+	assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+	assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	assert.Equal(t, "a,b", w.Body.String())
+}
+
+func TestIdempotency_StoresAfterClientDisconnects(t *testing.T) {
+	record := &model.IdempotencyKey{UserID: "u", Key: "k"}
+	svc := mocks.NewIdempotencyService(t)
+	svc.EXPECT().Begin(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(record, false, nil)
+	svc.EXPECT().Complete(mock.MatchedBy(func(ctx context.Context) bool {
+		return ctx.Err() == nil
+	}), record, http.StatusOK, mock.Anything, mock.Anything).Return(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(model.UserKey, "u")
+		c.Next()
+	})
+	r.Use(IdempotencyMiddleware(svc))
+	r.PUT("/contests", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{})
+		// the client hangs up as soon as it has the response
+		cancel()
+	})
+
+	req := httptest.NewRequest(http.MethodPut, "/contests", bytes.NewBufferString(`{}`)).WithContext(ctx)
+	req.Header.Set("Idempotency-Key", "k")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+}
+
+func TestIdempotency_ReleasesOnServerError(t *testing.T) {
+	record := &model.IdempotencyKey{UserID: "u", Key: "k"}
+	svc := mocks.NewIdempotencyService(t)
+	svc.EXPECT().Begin(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(record, false, nil)
+	svc.EXPECT().Release(mock.Anything, record).Return(nil)
+
+	calls := 0
+	w := idempotencyRequest(idempotencyRouter(svc, http.StatusInternalServerError, &calls), "k", `{}`)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestIdempotency_Errors(t *testing.T) {
+	cases := map[error]int{
+		errs.ErrInvalidIdempotencyKey:    http.StatusBadRequest,
+		errs.ErrIdempotencyKeyReused:     http.StatusUnprocessableEntity,
+		errs.ErrIdempotencyKeyInProgress: http.StatusConflict,
+		errs.ErrDatabaseUnavailable:      http.StatusInternalServerError,
+	}
+
+	for svcErr, want := range cases {
+		svc := mocks.NewIdempotencyService(t)
+		svc.EXPECT().Begin(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, false, svcErr)
+
+		calls := 0
+		w := idempotencyRequest(idempotencyRouter(svc, http.StatusOK, &calls), "k", `{}`)
+		assert.Equal(t, want, w.Code, svcErr.Error())
+		assert.Equal(t, 0, calls)
+	}
+}
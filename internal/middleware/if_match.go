@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/util"
+)
+
+// carries the contest version from If-Match into the request context for the service to check
+func IfMatchMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := strings.TrimSpace(c.GetHeader("If-Match"))
+
+		// no header or a wildcard means the client doesn't care which version it overwrites
+		if header == "" || header == "*" {
+			c.Next()
+			return
+		}
+
+		version, ok := util.ParseETag(header)
+		if !ok {
+			log := util.LoggerFromGinContext(c)
+			log.Warn("invalid if-match header", "if_match", header)
+			c.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIError(
+				http.StatusBadRequest,
+				util.CapitalizeFirstLetter(errs.ErrInvalidIfMatch),
+				c,
+			))
+			return
+		}
+
+		util.SetGinContextValue(c, model.IfMatchKey, version)
+		c.Next()
+	}
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxmorhardt/squares-api/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func ifMatchRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(IfMatchMiddleware())
+	r.POST("/x", func(c *gin.Context) {
+		version, ok := util.ExpectedVersionFromContext(c.Request.Context())
+		if !ok {
+			c.String(http.StatusOK, "none")
+			return
+		}
+		c.String(http.StatusOK, strconv.Itoa(version))
+	})
+	return r
+}
+
+func ifMatchRequest(header string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/x", http.NoBody)
+	if header != "" {
+		req.Header.Set("If-Match", header)
+	}
+
+	w := httptest.NewRecorder()
+	ifMatchRouter().ServeHTTP(w, req)
+	return w
+}
+
+func TestIfMatch_NoHeader(t *testing.T) {
+	w := ifMatchRequest("")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "none", w.Body.String())
+}
+
+func TestIfMatch_Wildcard(t *testing.T) {
+	w := ifMatchRequest("*")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "none", w.Body.String())
+}
+
+func TestIfMatch_Version(t *testing.T) {
+	w := ifMatchRequest(`"4"`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "4", w.Body.String())
+}
+
+func TestIfMatch_Invalid(t *testing.T) {
+	w := ifMatchRequest("not-an-etag")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,145 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	uuid "github.com/google/uuid"
+	model "github.com/maxmorhardt/squares-api/internal/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AnalyticsService is an autogenerated mock type for the AnalyticsService type
+type AnalyticsService struct {
+	mock.Mock
+}
+
+type AnalyticsService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AnalyticsService) EXPECT() *AnalyticsService_Expecter {
+	return &AnalyticsService_Expecter{mock: &_m.Mock}
+}
+
+// AnnotateSquares provides a mock function with given fields: ctx, contest
+func (_m *AnalyticsService) AnnotateSquares(ctx context.Context, contest *model.Contest) error {
+	ret := _m.Called(ctx, contest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AnnotateSquares")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Contest) error); ok {
+		r0 = rf(ctx, contest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AnalyticsService_AnnotateSquares_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AnnotateSquares'
+type AnalyticsService_AnnotateSquares_Call struct {
+	*mock.Call
+}
+
+// AnnotateSquares is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contest *model.Contest
+func (_e *AnalyticsService_Expecter) AnnotateSquares(ctx interface{}, contest interface{}) *AnalyticsService_AnnotateSquares_Call {
+	return &AnalyticsService_AnnotateSquares_Call{Call: _e.mock.On("AnnotateSquares", ctx, contest)}
+}
+
+func (_c *AnalyticsService_AnnotateSquares_Call) Run(run func(ctx context.Context, contest *model.Contest)) *AnalyticsService_AnnotateSquares_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Contest))
+	})
+	return _c
+}
+
+func (_c *AnalyticsService_AnnotateSquares_Call) Return(_a0 error) *AnalyticsService_AnnotateSquares_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AnalyticsService_AnnotateSquares_Call) RunAndReturn(run func(context.Context, *model.Contest) error) *AnalyticsService_AnnotateSquares_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetContestAnalytics provides a mock function with given fields: ctx, contestID, user
+func (_m *AnalyticsService) GetContestAnalytics(ctx context.Context, contestID uuid.UUID, user string) (*model.ContestAnalyticsResponse, error) {
+	ret := _m.Called(ctx, contestID, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContestAnalytics")
+	}
+
+	var r0 *model.ContestAnalyticsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*model.ContestAnalyticsResponse, error)); ok {
+		return rf(ctx, contestID, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *model.ContestAnalyticsResponse); ok {
+		r0 = rf(ctx, contestID, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ContestAnalyticsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, contestID, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AnalyticsService_GetContestAnalytics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetContestAnalytics'
+type AnalyticsService_GetContestAnalytics_Call struct {
+	*mock.Call
+}
+
+// GetContestAnalytics is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - user string
+func (_e *AnalyticsService_Expecter) GetContestAnalytics(ctx interface{}, contestID interface{}, user interface{}) *AnalyticsService_GetContestAnalytics_Call {
+	return &AnalyticsService_GetContestAnalytics_Call{Call: _e.mock.On("GetContestAnalytics", ctx, contestID, user)}
+}
+
+func (_c *AnalyticsService_GetContestAnalytics_Call) Run(run func(ctx context.Context, contestID uuid.UUID, user string)) *AnalyticsService_GetContestAnalytics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *AnalyticsService_GetContestAnalytics_Call) Return(_a0 *model.ContestAnalyticsResponse, _a1 error) *AnalyticsService_GetContestAnalytics_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AnalyticsService_GetContestAnalytics_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) (*model.ContestAnalyticsResponse, error)) *AnalyticsService_GetContestAnalytics_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAnalyticsService creates a new instance of AnalyticsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAnalyticsService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AnalyticsService {
+	mock := &AnalyticsService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,191 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	uuid "github.com/google/uuid"
+	model "github.com/maxmorhardt/squares-api/internal/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AvatarStore is an autogenerated mock type for the AvatarStore type
+type AvatarStore struct {
+	mock.Mock
+}
+
+type AvatarStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AvatarStore) EXPECT() *AvatarStore_Expecter {
+	return &AvatarStore_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, userID
+func (_m *AvatarStore) Delete(ctx context.Context, userID uuid.UUID) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AvatarStore_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type AvatarStore_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *AvatarStore_Expecter) Delete(ctx interface{}, userID interface{}) *AvatarStore_Delete_Call {
+	return &AvatarStore_Delete_Call{Call: _e.mock.On("Delete", ctx, userID)}
+}
+
+func (_c *AvatarStore_Delete_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *AvatarStore_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AvatarStore_Delete_Call) Return(_a0 error) *AvatarStore_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AvatarStore_Delete_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *AvatarStore_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, userID
+func (_m *AvatarStore) Get(ctx context.Context, userID uuid.UUID) (*model.UserAvatar, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.UserAvatar
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.UserAvatar, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.UserAvatar); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserAvatar)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AvatarStore_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type AvatarStore_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *AvatarStore_Expecter) Get(ctx interface{}, userID interface{}) *AvatarStore_Get_Call {
+	return &AvatarStore_Get_Call{Call: _e.mock.On("Get", ctx, userID)}
+}
+
+func (_c *AvatarStore_Get_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *AvatarStore_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AvatarStore_Get_Call) Return(_a0 *model.UserAvatar, _a1 error) *AvatarStore_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AvatarStore_Get_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*model.UserAvatar, error)) *AvatarStore_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Put provides a mock function with given fields: ctx, avatar
+func (_m *AvatarStore) Put(ctx context.Context, avatar *model.UserAvatar) error {
+	ret := _m.Called(ctx, avatar)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Put")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UserAvatar) error); ok {
+		r0 = rf(ctx, avatar)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AvatarStore_Put_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Put'
+type AvatarStore_Put_Call struct {
+	*mock.Call
+}
+
+// Put is a helper method to define mock.On call
+//   - ctx context.Context
+//   - avatar *model.UserAvatar
+func (_e *AvatarStore_Expecter) Put(ctx interface{}, avatar interface{}) *AvatarStore_Put_Call {
+	return &AvatarStore_Put_Call{Call: _e.mock.On("Put", ctx, avatar)}
+}
+
+func (_c *AvatarStore_Put_Call) Run(run func(ctx context.Context, avatar *model.UserAvatar)) *AvatarStore_Put_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.UserAvatar))
+	})
+	return _c
+}
+
+func (_c *AvatarStore_Put_Call) Return(_a0 error) *AvatarStore_Put_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AvatarStore_Put_Call) RunAndReturn(run func(context.Context, *model.UserAvatar) error) *AvatarStore_Put_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAvatarStore creates a new instance of AvatarStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAvatarStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AvatarStore {
+	mock := &AvatarStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
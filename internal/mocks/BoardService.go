@@ -0,0 +1,99 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	uuid "github.com/google/uuid"
+	model "github.com/maxmorhardt/squares-api/internal/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BoardService is an autogenerated mock type for the BoardService type
+type BoardService struct {
+	mock.Mock
+}
+
+type BoardService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BoardService) EXPECT() *BoardService_Expecter {
+	return &BoardService_Expecter{mock: &_m.Mock}
+}
+
+// RenderBoard provides a mock function with given fields: ctx, contestID, user, format
+func (_m *BoardService) RenderBoard(ctx context.Context, contestID uuid.UUID, user string, format model.BoardFormat) (*model.BoardImage, error) {
+	ret := _m.Called(ctx, contestID, user, format)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenderBoard")
+	}
+
+	var r0 *model.BoardImage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, model.BoardFormat) (*model.BoardImage, error)); ok {
+		return rf(ctx, contestID, user, format)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, model.BoardFormat) *model.BoardImage); ok {
+		r0 = rf(ctx, contestID, user, format)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BoardImage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, model.BoardFormat) error); ok {
+		r1 = rf(ctx, contestID, user, format)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BoardService_RenderBoard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenderBoard'
+type BoardService_RenderBoard_Call struct {
+	*mock.Call
+}
+
+// RenderBoard is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - user string
+//   - format model.BoardFormat
+func (_e *BoardService_Expecter) RenderBoard(ctx interface{}, contestID interface{}, user interface{}, format interface{}) *BoardService_RenderBoard_Call {
+	return &BoardService_RenderBoard_Call{Call: _e.mock.On("RenderBoard", ctx, contestID, user, format)}
+}
+
+func (_c *BoardService_RenderBoard_Call) Run(run func(ctx context.Context, contestID uuid.UUID, user string, format model.BoardFormat)) *BoardService_RenderBoard_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(model.BoardFormat))
+	})
+	return _c
+}
+
+func (_c *BoardService_RenderBoard_Call) Return(_a0 *model.BoardImage, _a1 error) *BoardService_RenderBoard_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BoardService_RenderBoard_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, model.BoardFormat) (*model.BoardImage, error)) *BoardService_RenderBoard_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBoardService creates a new instance of BoardService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBoardService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BoardService {
+	mock := &BoardService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -4,11 +4,11 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
+	uuid "github.com/google/uuid"
 	model "github.com/maxmorhardt/squares-api/internal/model"
 	mock "github.com/stretchr/testify/mock"
-
-	uuid "github.com/google/uuid"
 )
 
 // ContestRepository is an autogenerated mock type for the ContestRepository type
@@ -24,6 +24,174 @@ func (_m *ContestRepository) EXPECT() *ContestRepository_Expecter {
 	return &ContestRepository_Expecter{mock: &_m.Mock}
 }
 
+// AcceptSwap provides a mock function with given fields: ctx, swap
+func (_m *ContestRepository) AcceptSwap(ctx context.Context, swap *model.SquareSwap) ([]model.Square, error) {
+	ret := _m.Called(ctx, swap)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptSwap")
+	}
+
+	var r0 []model.Square
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SquareSwap) ([]model.Square, error)); ok {
+		return rf(ctx, swap)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SquareSwap) []model.Square); ok {
+		r0 = rf(ctx, swap)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Square)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.SquareSwap) error); ok {
+		r1 = rf(ctx, swap)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContestRepository_AcceptSwap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcceptSwap'
+type ContestRepository_AcceptSwap_Call struct {
+	*mock.Call
+}
+
+// AcceptSwap is a helper method to define mock.On call
+//   - ctx context.Context
+//   - swap *model.SquareSwap
+func (_e *ContestRepository_Expecter) AcceptSwap(ctx interface{}, swap interface{}) *ContestRepository_AcceptSwap_Call {
+	return &ContestRepository_AcceptSwap_Call{Call: _e.mock.On("AcceptSwap", ctx, swap)}
+}
+
+func (_c *ContestRepository_AcceptSwap_Call) Run(run func(ctx context.Context, swap *model.SquareSwap)) *ContestRepository_AcceptSwap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.SquareSwap))
+	})
+	return _c
+}
+
+func (_c *ContestRepository_AcceptSwap_Call) Return(_a0 []model.Square, _a1 error) *ContestRepository_AcceptSwap_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContestRepository_AcceptSwap_Call) RunAndReturn(run func(context.Context, *model.SquareSwap) ([]model.Square, error)) *ContestRepository_AcceptSwap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Archive provides a mock function with given fields: ctx, id
+func (_m *ContestRepository) Archive(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Archive")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContestRepository_Archive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Archive'
+type ContestRepository_Archive_Call struct {
+	*mock.Call
+}
+
+// Archive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *ContestRepository_Expecter) Archive(ctx interface{}, id interface{}) *ContestRepository_Archive_Call {
+	return &ContestRepository_Archive_Call{Call: _e.mock.On("Archive", ctx, id)}
+}
+
+func (_c *ContestRepository_Archive_Call) Run(run func(ctx context.Context, id uuid.UUID)) *ContestRepository_Archive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *ContestRepository_Archive_Call) Return(_a0 error) *ContestRepository_Archive_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContestRepository_Archive_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *ContestRepository_Archive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AssignSquare provides a mock function with given fields: ctx, square, value, owner, ownerName
+func (_m *ContestRepository) AssignSquare(ctx context.Context, square *model.Square, value string, owner string, ownerName string) (*model.Square, error) {
+	ret := _m.Called(ctx, square, value, owner, ownerName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignSquare")
+	}
+
+	var r0 *model.Square
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Square, string, string, string) (*model.Square, error)); ok {
+		return rf(ctx, square, value, owner, ownerName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Square, string, string, string) *model.Square); ok {
+		r0 = rf(ctx, square, value, owner, ownerName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Square)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Square, string, string, string) error); ok {
+		r1 = rf(ctx, square, value, owner, ownerName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContestRepository_AssignSquare_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignSquare'
+type ContestRepository_AssignSquare_Call struct {
+	*mock.Call
+}
+
+// AssignSquare is a helper method to define mock.On call
+//   - ctx context.Context
+//   - square *model.Square
+//   - value string
+//   - owner string
+//   - ownerName string
+func (_e *ContestRepository_Expecter) AssignSquare(ctx interface{}, square interface{}, value interface{}, owner interface{}, ownerName interface{}) *ContestRepository_AssignSquare_Call {
+	return &ContestRepository_AssignSquare_Call{Call: _e.mock.On("AssignSquare", ctx, square, value, owner, ownerName)}
+}
+
+func (_c *ContestRepository_AssignSquare_Call) Run(run func(ctx context.Context, square *model.Square, value string, owner string, ownerName string)) *ContestRepository_AssignSquare_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Square), args[2].(string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *ContestRepository_AssignSquare_Call) Return(_a0 *model.Square, _a1 error) *ContestRepository_AssignSquare_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContestRepository_AssignSquare_Call) RunAndReturn(run func(context.Context, *model.Square, string, string, string) (*model.Square, error)) *ContestRepository_AssignSquare_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ClaimSquare provides a mock function with given fields: ctx, square, value, owner, ownerName
 func (_m *ContestRepository) ClaimSquare(ctx context.Context, square *model.Square, value string, owner string, ownerName string) (*model.Square, error) {
 	ret := _m.Called(ctx, square, value, owner, ownerName)
@@ -86,6 +254,69 @@ func (_c *ContestRepository_ClaimSquare_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// ClaimSquares provides a mock function with given fields: ctx, contestID, squareIDs, value, owner, ownerName
+func (_m *ContestRepository) ClaimSquares(ctx context.Context, contestID uuid.UUID, squareIDs []uuid.UUID, value string, owner string, ownerName string) ([]model.Square, error) {
+	ret := _m.Called(ctx, contestID, squareIDs, value, owner, ownerName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimSquares")
+	}
+
+	var r0 []model.Square
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []uuid.UUID, string, string, string) ([]model.Square, error)); ok {
+		return rf(ctx, contestID, squareIDs, value, owner, ownerName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []uuid.UUID, string, string, string) []model.Square); ok {
+		r0 = rf(ctx, contestID, squareIDs, value, owner, ownerName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Square)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, []uuid.UUID, string, string, string) error); ok {
+		r1 = rf(ctx, contestID, squareIDs, value, owner, ownerName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContestRepository_ClaimSquares_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimSquares'
+type ContestRepository_ClaimSquares_Call struct {
+	*mock.Call
+}
+
+// ClaimSquares is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - squareIDs []uuid.UUID
+//   - value string
+//   - owner string
+//   - ownerName string
+func (_e *ContestRepository_Expecter) ClaimSquares(ctx interface{}, contestID interface{}, squareIDs interface{}, value interface{}, owner interface{}, ownerName interface{}) *ContestRepository_ClaimSquares_Call {
+	return &ContestRepository_ClaimSquares_Call{Call: _e.mock.On("ClaimSquares", ctx, contestID, squareIDs, value, owner, ownerName)}
+}
+
+func (_c *ContestRepository_ClaimSquares_Call) Run(run func(ctx context.Context, contestID uuid.UUID, squareIDs []uuid.UUID, value string, owner string, ownerName string)) *ContestRepository_ClaimSquares_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].([]uuid.UUID), args[3].(string), args[4].(string), args[5].(string))
+	})
+	return _c
+}
+
+func (_c *ContestRepository_ClaimSquares_Call) Return(_a0 []model.Square, _a1 error) *ContestRepository_ClaimSquares_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContestRepository_ClaimSquares_Call) RunAndReturn(run func(context.Context, uuid.UUID, []uuid.UUID, string, string, string) ([]model.Square, error)) *ContestRepository_ClaimSquares_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ClearSquare provides a mock function with given fields: ctx, square
 func (_m *ContestRepository) ClearSquare(ctx context.Context, square *model.Square) (*model.Square, error) {
 	ret := _m.Called(ctx, square)
@@ -205,6 +436,54 @@ func (_c *ContestRepository_ClearSquaresByOwner_Call) RunAndReturn(run func(cont
 	return _c
 }
 
+// CloseSwap provides a mock function with given fields: ctx, swap, status
+func (_m *ContestRepository) CloseSwap(ctx context.Context, swap *model.SquareSwap, status model.SwapStatus) error {
+	ret := _m.Called(ctx, swap, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseSwap")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SquareSwap, model.SwapStatus) error); ok {
+		r0 = rf(ctx, swap, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContestRepository_CloseSwap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloseSwap'
+type ContestRepository_CloseSwap_Call struct {
+	*mock.Call
+}
+
+// CloseSwap is a helper method to define mock.On call
+//   - ctx context.Context
+//   - swap *model.SquareSwap
+//   - status model.SwapStatus
+func (_e *ContestRepository_Expecter) CloseSwap(ctx interface{}, swap interface{}, status interface{}) *ContestRepository_CloseSwap_Call {
+	return &ContestRepository_CloseSwap_Call{Call: _e.mock.On("CloseSwap", ctx, swap, status)}
+}
+
+func (_c *ContestRepository_CloseSwap_Call) Run(run func(ctx context.Context, swap *model.SquareSwap, status model.SwapStatus)) *ContestRepository_CloseSwap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.SquareSwap), args[2].(model.SwapStatus))
+	})
+	return _c
+}
+
+func (_c *ContestRepository_CloseSwap_Call) Return(_a0 error) *ContestRepository_CloseSwap_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContestRepository_CloseSwap_Call) RunAndReturn(run func(context.Context, *model.SquareSwap, model.SwapStatus) error) *ContestRepository_CloseSwap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function with given fields: ctx, contest, owner
 func (_m *ContestRepository) Create(ctx context.Context, contest *model.Contest, owner *model.ContestParticipant) error {
 	ret := _m.Called(ctx, contest, owner)
@@ -253,17 +532,17 @@ func (_c *ContestRepository_Create_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
-// CreateQuarterResult provides a mock function with given fields: ctx, result
-func (_m *ContestRepository) CreateQuarterResult(ctx context.Context, result *model.QuarterResult) error {
-	ret := _m.Called(ctx, result)
+// CreateSwap provides a mock function with given fields: ctx, swap
+func (_m *ContestRepository) CreateSwap(ctx context.Context, swap *model.SquareSwap) error {
+	ret := _m.Called(ctx, swap)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateQuarterResult")
+		panic("no return value specified for CreateSwap")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *model.QuarterResult) error); ok {
-		r0 = rf(ctx, result)
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SquareSwap) error); ok {
+		r0 = rf(ctx, swap)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -271,31 +550,31 @@ func (_m *ContestRepository) CreateQuarterResult(ctx context.Context, result *mo
 	return r0
 }
 
-// ContestRepository_CreateQuarterResult_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateQuarterResult'
-type ContestRepository_CreateQuarterResult_Call struct {
+// ContestRepository_CreateSwap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSwap'
+type ContestRepository_CreateSwap_Call struct {
 	*mock.Call
 }
 
-// CreateQuarterResult is a helper method to define mock.On call
+// CreateSwap is a helper method to define mock.On call
 //   - ctx context.Context
-//   - result *model.QuarterResult
-func (_e *ContestRepository_Expecter) CreateQuarterResult(ctx interface{}, result interface{}) *ContestRepository_CreateQuarterResult_Call {
-	return &ContestRepository_CreateQuarterResult_Call{Call: _e.mock.On("CreateQuarterResult", ctx, result)}
+//   - swap *model.SquareSwap
+func (_e *ContestRepository_Expecter) CreateSwap(ctx interface{}, swap interface{}) *ContestRepository_CreateSwap_Call {
+	return &ContestRepository_CreateSwap_Call{Call: _e.mock.On("CreateSwap", ctx, swap)}
 }
 
-func (_c *ContestRepository_CreateQuarterResult_Call) Run(run func(ctx context.Context, result *model.QuarterResult)) *ContestRepository_CreateQuarterResult_Call {
+func (_c *ContestRepository_CreateSwap_Call) Run(run func(ctx context.Context, swap *model.SquareSwap)) *ContestRepository_CreateSwap_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*model.QuarterResult))
+		run(args[0].(context.Context), args[1].(*model.SquareSwap))
 	})
 	return _c
 }
 
-func (_c *ContestRepository_CreateQuarterResult_Call) Return(_a0 error) *ContestRepository_CreateQuarterResult_Call {
+func (_c *ContestRepository_CreateSwap_Call) Return(_a0 error) *ContestRepository_CreateSwap_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *ContestRepository_CreateQuarterResult_Call) RunAndReturn(run func(context.Context, *model.QuarterResult) error) *ContestRepository_CreateQuarterResult_Call {
+func (_c *ContestRepository_CreateSwap_Call) RunAndReturn(run func(context.Context, *model.SquareSwap) error) *ContestRepository_CreateSwap_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -474,9 +753,9 @@ func (_c *ContestRepository_GetAllByOwnerPaginated_Call) RunAndReturn(run func(c
 	return _c
 }
 
-// GetAllByParticipantUserID provides a mock function with given fields: ctx, userID, search
-func (_m *ContestRepository) GetAllByParticipantUserID(ctx context.Context, userID string, search string) ([]model.Contest, error) {
-	ret := _m.Called(ctx, userID, search)
+// GetAllByParticipantUserID provides a mock function with given fields: ctx, userID, search, orgID
+func (_m *ContestRepository) GetAllByParticipantUserID(ctx context.Context, userID string, search string, orgID *uuid.UUID) ([]model.Contest, error) {
+	ret := _m.Called(ctx, userID, search, orgID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllByParticipantUserID")
@@ -484,19 +763,19 @@ func (_m *ContestRepository) GetAllByParticipantUserID(ctx context.Context, user
 
 	var r0 []model.Contest
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]model.Contest, error)); ok {
-		return rf(ctx, userID, search)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *uuid.UUID) ([]model.Contest, error)); ok {
+		return rf(ctx, userID, search, orgID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) []model.Contest); ok {
-		r0 = rf(ctx, userID, search)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *uuid.UUID) []model.Contest); ok {
+		r0 = rf(ctx, userID, search, orgID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]model.Contest)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, userID, search)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, search, orgID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -513,13 +792,14 @@ type ContestRepository_GetAllByParticipantUserID_Call struct {
 //   - ctx context.Context
 //   - userID string
 //   - search string
-func (_e *ContestRepository_Expecter) GetAllByParticipantUserID(ctx interface{}, userID interface{}, search interface{}) *ContestRepository_GetAllByParticipantUserID_Call {
-	return &ContestRepository_GetAllByParticipantUserID_Call{Call: _e.mock.On("GetAllByParticipantUserID", ctx, userID, search)}
+//   - orgID *uuid.UUID
+func (_e *ContestRepository_Expecter) GetAllByParticipantUserID(ctx interface{}, userID interface{}, search interface{}, orgID interface{}) *ContestRepository_GetAllByParticipantUserID_Call {
+	return &ContestRepository_GetAllByParticipantUserID_Call{Call: _e.mock.On("GetAllByParticipantUserID", ctx, userID, search, orgID)}
 }
 
-func (_c *ContestRepository_GetAllByParticipantUserID_Call) Run(run func(ctx context.Context, userID string, search string)) *ContestRepository_GetAllByParticipantUserID_Call {
+func (_c *ContestRepository_GetAllByParticipantUserID_Call) Run(run func(ctx context.Context, userID string, search string, orgID *uuid.UUID)) *ContestRepository_GetAllByParticipantUserID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*uuid.UUID))
 	})
 	return _c
 }
@@ -529,7 +809,7 @@ func (_c *ContestRepository_GetAllByParticipantUserID_Call) Return(_a0 []model.C
 	return _c
 }
 
-func (_c *ContestRepository_GetAllByParticipantUserID_Call) RunAndReturn(run func(context.Context, string, string) ([]model.Contest, error)) *ContestRepository_GetAllByParticipantUserID_Call {
+func (_c *ContestRepository_GetAllByParticipantUserID_Call) RunAndReturn(run func(context.Context, string, string, *uuid.UUID) ([]model.Contest, error)) *ContestRepository_GetAllByParticipantUserID_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -652,23 +932,25 @@ func (_c *ContestRepository_GetByID_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
-// GetVisibilityByID provides a mock function with given fields: ctx, id
-func (_m *ContestRepository) GetVisibilityByID(ctx context.Context, id uuid.UUID) (model.ContestVisibility, error) {
+// GetDeletedByID provides a mock function with given fields: ctx, id
+func (_m *ContestRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*model.Contest, error) {
 	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetVisibilityByID")
+		panic("no return value specified for GetDeletedByID")
 	}
 
-	var r0 model.ContestVisibility
+	var r0 *model.Contest
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (model.ContestVisibility, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.Contest, error)); ok {
 		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) model.ContestVisibility); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.Contest); ok {
 		r0 = rf(ctx, id)
 	} else {
-		r0 = ret.Get(0).(model.ContestVisibility)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Contest)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
@@ -680,58 +962,58 @@ func (_m *ContestRepository) GetVisibilityByID(ctx context.Context, id uuid.UUID
 	return r0, r1
 }
 
-// ContestRepository_GetVisibilityByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVisibilityByID'
-type ContestRepository_GetVisibilityByID_Call struct {
+// ContestRepository_GetDeletedByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedByID'
+type ContestRepository_GetDeletedByID_Call struct {
 	*mock.Call
 }
 
-// GetVisibilityByID is a helper method to define mock.On call
+// GetDeletedByID is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id uuid.UUID
-func (_e *ContestRepository_Expecter) GetVisibilityByID(ctx interface{}, id interface{}) *ContestRepository_GetVisibilityByID_Call {
-	return &ContestRepository_GetVisibilityByID_Call{Call: _e.mock.On("GetVisibilityByID", ctx, id)}
+func (_e *ContestRepository_Expecter) GetDeletedByID(ctx interface{}, id interface{}) *ContestRepository_GetDeletedByID_Call {
+	return &ContestRepository_GetDeletedByID_Call{Call: _e.mock.On("GetDeletedByID", ctx, id)}
 }
 
-func (_c *ContestRepository_GetVisibilityByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *ContestRepository_GetVisibilityByID_Call {
+func (_c *ContestRepository_GetDeletedByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *ContestRepository_GetDeletedByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *ContestRepository_GetVisibilityByID_Call) Return(_a0 model.ContestVisibility, _a1 error) *ContestRepository_GetVisibilityByID_Call {
+func (_c *ContestRepository_GetDeletedByID_Call) Return(_a0 *model.Contest, _a1 error) *ContestRepository_GetDeletedByID_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *ContestRepository_GetVisibilityByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (model.ContestVisibility, error)) *ContestRepository_GetVisibilityByID_Call {
+func (_c *ContestRepository_GetDeletedByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*model.Contest, error)) *ContestRepository_GetDeletedByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GhostSquare provides a mock function with given fields: ctx, square
-func (_m *ContestRepository) GhostSquare(ctx context.Context, square *model.Square) (*model.Square, error) {
-	ret := _m.Called(ctx, square)
+// GetDueForArchive provides a mock function with given fields: ctx, before, limit
+func (_m *ContestRepository) GetDueForArchive(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error) {
+	ret := _m.Called(ctx, before, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GhostSquare")
+		panic("no return value specified for GetDueForArchive")
 	}
 
-	var r0 *model.Square
+	var r0 []uuid.UUID
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *model.Square) (*model.Square, error)); ok {
-		return rf(ctx, square)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]uuid.UUID, error)); ok {
+		return rf(ctx, before, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *model.Square) *model.Square); ok {
-		r0 = rf(ctx, square)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []uuid.UUID); ok {
+		r0 = rf(ctx, before, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Square)
+			r0 = ret.Get(0).([]uuid.UUID)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *model.Square) error); ok {
-		r1 = rf(ctx, square)
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, before, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -739,45 +1021,670 @@ func (_m *ContestRepository) GhostSquare(ctx context.Context, square *model.Squa
 	return r0, r1
 }
 
-// ContestRepository_GhostSquare_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GhostSquare'
-type ContestRepository_GhostSquare_Call struct {
+// ContestRepository_GetDueForArchive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDueForArchive'
+type ContestRepository_GetDueForArchive_Call struct {
 	*mock.Call
 }
 
-// GhostSquare is a helper method to define mock.On call
+// GetDueForArchive is a helper method to define mock.On call
 //   - ctx context.Context
-//   - square *model.Square
-func (_e *ContestRepository_Expecter) GhostSquare(ctx interface{}, square interface{}) *ContestRepository_GhostSquare_Call {
-	return &ContestRepository_GhostSquare_Call{Call: _e.mock.On("GhostSquare", ctx, square)}
+//   - before time.Time
+//   - limit int
+func (_e *ContestRepository_Expecter) GetDueForArchive(ctx interface{}, before interface{}, limit interface{}) *ContestRepository_GetDueForArchive_Call {
+	return &ContestRepository_GetDueForArchive_Call{Call: _e.mock.On("GetDueForArchive", ctx, before, limit)}
 }
 
-func (_c *ContestRepository_GhostSquare_Call) Run(run func(ctx context.Context, square *model.Square)) *ContestRepository_GhostSquare_Call {
+func (_c *ContestRepository_GetDueForArchive_Call) Run(run func(ctx context.Context, before time.Time, limit int)) *ContestRepository_GetDueForArchive_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*model.Square))
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
 	})
 	return _c
 }
 
-func (_c *ContestRepository_GhostSquare_Call) Return(_a0 *model.Square, _a1 error) *ContestRepository_GhostSquare_Call {
+func (_c *ContestRepository_GetDueForArchive_Call) Return(_a0 []uuid.UUID, _a1 error) *ContestRepository_GetDueForArchive_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *ContestRepository_GhostSquare_Call) RunAndReturn(run func(context.Context, *model.Square) (*model.Square, error)) *ContestRepository_GhostSquare_Call {
+func (_c *ContestRepository_GetDueForArchive_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]uuid.UUID, error)) *ContestRepository_GetDueForArchive_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RollbackQuarterResult provides a mock function with given fields: ctx, resultID, contest
-func (_m *ContestRepository) RollbackQuarterResult(ctx context.Context, resultID uuid.UUID, contest *model.Contest) error {
-	ret := _m.Called(ctx, resultID, contest)
+// GetDueForLock provides a mock function with given fields: ctx, now
+func (_m *ContestRepository) GetDueForLock(ctx context.Context, now time.Time) ([]model.Contest, error) {
+	ret := _m.Called(ctx, now)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RollbackQuarterResult")
+		panic("no return value specified for GetDueForLock")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.Contest) error); ok {
+	var r0 []model.Contest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]model.Contest, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []model.Contest); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Contest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContestRepository_GetDueForLock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDueForLock'
+type ContestRepository_GetDueForLock_Call struct {
+	*mock.Call
+}
+
+// GetDueForLock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *ContestRepository_Expecter) GetDueForLock(ctx interface{}, now interface{}) *ContestRepository_GetDueForLock_Call {
+	return &ContestRepository_GetDueForLock_Call{Call: _e.mock.On("GetDueForLock", ctx, now)}
+}
+
+func (_c *ContestRepository_GetDueForLock_Call) Run(run func(ctx context.Context, now time.Time)) *ContestRepository_GetDueForLock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ContestRepository_GetDueForLock_Call) Return(_a0 []model.Contest, _a1 error) *ContestRepository_GetDueForLock_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContestRepository_GetDueForLock_Call) RunAndReturn(run func(context.Context, time.Time) ([]model.Contest, error)) *ContestRepository_GetDueForLock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingSwaps provides a mock function with given fields: ctx, contestID, user
+func (_m *ContestRepository) GetPendingSwaps(ctx context.Context, contestID uuid.UUID, user string) ([]model.SquareSwap, error) {
+	ret := _m.Called(ctx, contestID, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingSwaps")
+	}
+
+	var r0 []model.SquareSwap
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) ([]model.SquareSwap, error)); ok {
+		return rf(ctx, contestID, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) []model.SquareSwap); ok {
+		r0 = rf(ctx, contestID, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.SquareSwap)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, contestID, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContestRepository_GetPendingSwaps_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingSwaps'
+type ContestRepository_GetPendingSwaps_Call struct {
+	*mock.Call
+}
+
+// GetPendingSwaps is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - user string
+func (_e *ContestRepository_Expecter) GetPendingSwaps(ctx interface{}, contestID interface{}, user interface{}) *ContestRepository_GetPendingSwaps_Call {
+	return &ContestRepository_GetPendingSwaps_Call{Call: _e.mock.On("GetPendingSwaps", ctx, contestID, user)}
+}
+
+func (_c *ContestRepository_GetPendingSwaps_Call) Run(run func(ctx context.Context, contestID uuid.UUID, user string)) *ContestRepository_GetPendingSwaps_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ContestRepository_GetPendingSwaps_Call) Return(_a0 []model.SquareSwap, _a1 error) *ContestRepository_GetPendingSwaps_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContestRepository_GetPendingSwaps_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) ([]model.SquareSwap, error)) *ContestRepository_GetPendingSwaps_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSwap provides a mock function with given fields: ctx, contestID, swapID
+func (_m *ContestRepository) GetSwap(ctx context.Context, contestID uuid.UUID, swapID uuid.UUID) (*model.SquareSwap, error) {
+	ret := _m.Called(ctx, contestID, swapID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSwap")
+	}
+
+	var r0 *model.SquareSwap
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*model.SquareSwap, error)); ok {
+		return rf(ctx, contestID, swapID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *model.SquareSwap); ok {
+		r0 = rf(ctx, contestID, swapID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SquareSwap)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, contestID, swapID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContestRepository_GetSwap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSwap'
+type ContestRepository_GetSwap_Call struct {
+	*mock.Call
+}
+
+// GetSwap is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - swapID uuid.UUID
+func (_e *ContestRepository_Expecter) GetSwap(ctx interface{}, contestID interface{}, swapID interface{}) *ContestRepository_GetSwap_Call {
+	return &ContestRepository_GetSwap_Call{Call: _e.mock.On("GetSwap", ctx, contestID, swapID)}
+}
+
+func (_c *ContestRepository_GetSwap_Call) Run(run func(ctx context.Context, contestID uuid.UUID, swapID uuid.UUID)) *ContestRepository_GetSwap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *ContestRepository_GetSwap_Call) Return(_a0 *model.SquareSwap, _a1 error) *ContestRepository_GetSwap_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContestRepository_GetSwap_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (*model.SquareSwap, error)) *ContestRepository_GetSwap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetVisibilityByID provides a mock function with given fields: ctx, id
+func (_m *ContestRepository) GetVisibilityByID(ctx context.Context, id uuid.UUID) (model.ContestVisibility, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVisibilityByID")
+	}
+
+	var r0 model.ContestVisibility
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (model.ContestVisibility, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) model.ContestVisibility); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(model.ContestVisibility)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContestRepository_GetVisibilityByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVisibilityByID'
+type ContestRepository_GetVisibilityByID_Call struct {
+	*mock.Call
+}
+
+// GetVisibilityByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *ContestRepository_Expecter) GetVisibilityByID(ctx interface{}, id interface{}) *ContestRepository_GetVisibilityByID_Call {
+	return &ContestRepository_GetVisibilityByID_Call{Call: _e.mock.On("GetVisibilityByID", ctx, id)}
+}
+
+func (_c *ContestRepository_GetVisibilityByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *ContestRepository_GetVisibilityByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *ContestRepository_GetVisibilityByID_Call) Return(_a0 model.ContestVisibility, _a1 error) *ContestRepository_GetVisibilityByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContestRepository_GetVisibilityByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (model.ContestVisibility, error)) *ContestRepository_GetVisibilityByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GhostSquare provides a mock function with given fields: ctx, square
+func (_m *ContestRepository) GhostSquare(ctx context.Context, square *model.Square) (*model.Square, error) {
+	ret := _m.Called(ctx, square)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GhostSquare")
+	}
+
+	var r0 *model.Square
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Square) (*model.Square, error)); ok {
+		return rf(ctx, square)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Square) *model.Square); ok {
+		r0 = rf(ctx, square)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Square)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Square) error); ok {
+		r1 = rf(ctx, square)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContestRepository_GhostSquare_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GhostSquare'
+type ContestRepository_GhostSquare_Call struct {
+	*mock.Call
+}
+
+// GhostSquare is a helper method to define mock.On call
+//   - ctx context.Context
+//   - square *model.Square
+func (_e *ContestRepository_Expecter) GhostSquare(ctx interface{}, square interface{}) *ContestRepository_GhostSquare_Call {
+	return &ContestRepository_GhostSquare_Call{Call: _e.mock.On("GhostSquare", ctx, square)}
+}
+
+func (_c *ContestRepository_GhostSquare_Call) Run(run func(ctx context.Context, square *model.Square)) *ContestRepository_GhostSquare_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Square))
+	})
+	return _c
+}
+
+func (_c *ContestRepository_GhostSquare_Call) Return(_a0 *model.Square, _a1 error) *ContestRepository_GhostSquare_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContestRepository_GhostSquare_Call) RunAndReturn(run func(context.Context, *model.Square) (*model.Square, error)) *ContestRepository_GhostSquare_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Import provides a mock function with given fields: ctx, contest, owner, squares, results, participants
+func (_m *ContestRepository) Import(ctx context.Context, contest *model.Contest, owner *model.ContestParticipant, squares []model.Square, results []model.QuarterResult, participants []model.ContestParticipant) error {
+	ret := _m.Called(ctx, contest, owner, squares, results, participants)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Import")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Contest, *model.ContestParticipant, []model.Square, []model.QuarterResult, []model.ContestParticipant) error); ok {
+		r0 = rf(ctx, contest, owner, squares, results, participants)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContestRepository_Import_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Import'
+type ContestRepository_Import_Call struct {
+	*mock.Call
+}
+
+// Import is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contest *model.Contest
+//   - owner *model.ContestParticipant
+//   - squares []model.Square
+//   - results []model.QuarterResult
+//   - participants []model.ContestParticipant
+func (_e *ContestRepository_Expecter) Import(ctx interface{}, contest interface{}, owner interface{}, squares interface{}, results interface{}, participants interface{}) *ContestRepository_Import_Call {
+	return &ContestRepository_Import_Call{Call: _e.mock.On("Import", ctx, contest, owner, squares, results, participants)}
+}
+
+func (_c *ContestRepository_Import_Call) Run(run func(ctx context.Context, contest *model.Contest, owner *model.ContestParticipant, squares []model.Square, results []model.QuarterResult, participants []model.ContestParticipant)) *ContestRepository_Import_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Contest), args[2].(*model.ContestParticipant), args[3].([]model.Square), args[4].([]model.QuarterResult), args[5].([]model.ContestParticipant))
+	})
+	return _c
+}
+
+func (_c *ContestRepository_Import_Call) Return(_a0 error) *ContestRepository_Import_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContestRepository_Import_Call) RunAndReturn(run func(context.Context, *model.Contest, *model.ContestParticipant, []model.Square, []model.QuarterResult, []model.ContestParticipant) error) *ContestRepository_Import_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsOrgAdmin provides a mock function with given fields: ctx, contestID, userID
+func (_m *ContestRepository) IsOrgAdmin(ctx context.Context, contestID uuid.UUID, userID string) (bool, error) {
+	ret := _m.Called(ctx, contestID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsOrgAdmin")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (bool, error)); ok {
+		return rf(ctx, contestID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) bool); ok {
+		r0 = rf(ctx, contestID, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, contestID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContestRepository_IsOrgAdmin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsOrgAdmin'
+type ContestRepository_IsOrgAdmin_Call struct {
+	*mock.Call
+}
+
+// IsOrgAdmin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - userID string
+func (_e *ContestRepository_Expecter) IsOrgAdmin(ctx interface{}, contestID interface{}, userID interface{}) *ContestRepository_IsOrgAdmin_Call {
+	return &ContestRepository_IsOrgAdmin_Call{Call: _e.mock.On("IsOrgAdmin", ctx, contestID, userID)}
+}
+
+func (_c *ContestRepository_IsOrgAdmin_Call) Run(run func(ctx context.Context, contestID uuid.UUID, userID string)) *ContestRepository_IsOrgAdmin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ContestRepository_IsOrgAdmin_Call) Return(_a0 bool, _a1 error) *ContestRepository_IsOrgAdmin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContestRepository_IsOrgAdmin_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) (bool, error)) *ContestRepository_IsOrgAdmin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeDeleted provides a mock function with given fields: ctx, before
+func (_m *ContestRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeleted")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContestRepository_PurgeDeleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeDeleted'
+type ContestRepository_PurgeDeleted_Call struct {
+	*mock.Call
+}
+
+// PurgeDeleted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *ContestRepository_Expecter) PurgeDeleted(ctx interface{}, before interface{}) *ContestRepository_PurgeDeleted_Call {
+	return &ContestRepository_PurgeDeleted_Call{Call: _e.mock.On("PurgeDeleted", ctx, before)}
+}
+
+func (_c *ContestRepository_PurgeDeleted_Call) Run(run func(ctx context.Context, before time.Time)) *ContestRepository_PurgeDeleted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ContestRepository_PurgeDeleted_Call) Return(_a0 int64, _a1 error) *ContestRepository_PurgeDeleted_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContestRepository_PurgeDeleted_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *ContestRepository_PurgeDeleted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeInviteEvents provides a mock function with given fields: ctx, before
+func (_m *ContestRepository) PurgeInviteEvents(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeInviteEvents")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContestRepository_PurgeInviteEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeInviteEvents'
+type ContestRepository_PurgeInviteEvents_Call struct {
+	*mock.Call
+}
+
+// PurgeInviteEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *ContestRepository_Expecter) PurgeInviteEvents(ctx interface{}, before interface{}) *ContestRepository_PurgeInviteEvents_Call {
+	return &ContestRepository_PurgeInviteEvents_Call{Call: _e.mock.On("PurgeInviteEvents", ctx, before)}
+}
+
+func (_c *ContestRepository_PurgeInviteEvents_Call) Run(run func(ctx context.Context, before time.Time)) *ContestRepository_PurgeInviteEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ContestRepository_PurgeInviteEvents_Call) Return(_a0 int64, _a1 error) *ContestRepository_PurgeInviteEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContestRepository_PurgeInviteEvents_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *ContestRepository_PurgeInviteEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordQuarterResult provides a mock function with given fields: ctx, result, contest
+func (_m *ContestRepository) RecordQuarterResult(ctx context.Context, result *model.QuarterResult, contest *model.Contest) error {
+	ret := _m.Called(ctx, result, contest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordQuarterResult")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.QuarterResult, *model.Contest) error); ok {
+		r0 = rf(ctx, result, contest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContestRepository_RecordQuarterResult_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordQuarterResult'
+type ContestRepository_RecordQuarterResult_Call struct {
+	*mock.Call
+}
+
+// RecordQuarterResult is a helper method to define mock.On call
+//   - ctx context.Context
+//   - result *model.QuarterResult
+//   - contest *model.Contest
+func (_e *ContestRepository_Expecter) RecordQuarterResult(ctx interface{}, result interface{}, contest interface{}) *ContestRepository_RecordQuarterResult_Call {
+	return &ContestRepository_RecordQuarterResult_Call{Call: _e.mock.On("RecordQuarterResult", ctx, result, contest)}
+}
+
+func (_c *ContestRepository_RecordQuarterResult_Call) Run(run func(ctx context.Context, result *model.QuarterResult, contest *model.Contest)) *ContestRepository_RecordQuarterResult_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.QuarterResult), args[2].(*model.Contest))
+	})
+	return _c
+}
+
+func (_c *ContestRepository_RecordQuarterResult_Call) Return(_a0 error) *ContestRepository_RecordQuarterResult_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContestRepository_RecordQuarterResult_Call) RunAndReturn(run func(context.Context, *model.QuarterResult, *model.Contest) error) *ContestRepository_RecordQuarterResult_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseExpiredReservations provides a mock function with given fields: ctx
+func (_m *ContestRepository) ReleaseExpiredReservations(ctx context.Context) ([]model.Square, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseExpiredReservations")
+	}
+
+	var r0 []model.Square
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.Square, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.Square); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Square)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContestRepository_ReleaseExpiredReservations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseExpiredReservations'
+type ContestRepository_ReleaseExpiredReservations_Call struct {
+	*mock.Call
+}
+
+// ReleaseExpiredReservations is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ContestRepository_Expecter) ReleaseExpiredReservations(ctx interface{}) *ContestRepository_ReleaseExpiredReservations_Call {
+	return &ContestRepository_ReleaseExpiredReservations_Call{Call: _e.mock.On("ReleaseExpiredReservations", ctx)}
+}
+
+func (_c *ContestRepository_ReleaseExpiredReservations_Call) Run(run func(ctx context.Context)) *ContestRepository_ReleaseExpiredReservations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ContestRepository_ReleaseExpiredReservations_Call) Return(_a0 []model.Square, _a1 error) *ContestRepository_ReleaseExpiredReservations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContestRepository_ReleaseExpiredReservations_Call) RunAndReturn(run func(context.Context) ([]model.Square, error)) *ContestRepository_ReleaseExpiredReservations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RollbackQuarterResult provides a mock function with given fields: ctx, resultID, contest
+func (_m *ContestRepository) RollbackQuarterResult(ctx context.Context, resultID uuid.UUID, contest *model.Contest) error {
+	ret := _m.Called(ctx, resultID, contest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RollbackQuarterResult")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.Contest) error); ok {
 		r0 = rf(ctx, resultID, contest)
 	} else {
 		r0 = ret.Error(0)
@@ -816,6 +1723,55 @@ func (_c *ContestRepository_RollbackQuarterResult_Call) RunAndReturn(run func(co
 	return _c
 }
 
+// StartWithSquares provides a mock function with given fields: ctx, contest, clearOwners, squares
+func (_m *ContestRepository) StartWithSquares(ctx context.Context, contest *model.Contest, clearOwners []string, squares []model.Square) error {
+	ret := _m.Called(ctx, contest, clearOwners, squares)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartWithSquares")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Contest, []string, []model.Square) error); ok {
+		r0 = rf(ctx, contest, clearOwners, squares)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContestRepository_StartWithSquares_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartWithSquares'
+type ContestRepository_StartWithSquares_Call struct {
+	*mock.Call
+}
+
+// StartWithSquares is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contest *model.Contest
+//   - clearOwners []string
+//   - squares []model.Square
+func (_e *ContestRepository_Expecter) StartWithSquares(ctx interface{}, contest interface{}, clearOwners interface{}, squares interface{}) *ContestRepository_StartWithSquares_Call {
+	return &ContestRepository_StartWithSquares_Call{Call: _e.mock.On("StartWithSquares", ctx, contest, clearOwners, squares)}
+}
+
+func (_c *ContestRepository_StartWithSquares_Call) Run(run func(ctx context.Context, contest *model.Contest, clearOwners []string, squares []model.Square)) *ContestRepository_StartWithSquares_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Contest), args[2].([]string), args[3].([]model.Square))
+	})
+	return _c
+}
+
+func (_c *ContestRepository_StartWithSquares_Call) Return(_a0 error) *ContestRepository_StartWithSquares_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContestRepository_StartWithSquares_Call) RunAndReturn(run func(context.Context, *model.Contest, []string, []model.Square) error) *ContestRepository_StartWithSquares_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: ctx, contest
 func (_m *ContestRepository) Update(ctx context.Context, contest *model.Contest) error {
 	ret := _m.Called(ctx, contest)
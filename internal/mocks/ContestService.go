@@ -5,10 +5,9 @@ package mocks
 import (
 	context "context"
 
+	uuid "github.com/google/uuid"
 	model "github.com/maxmorhardt/squares-api/internal/model"
 	mock "github.com/stretchr/testify/mock"
-
-	uuid "github.com/google/uuid"
 )
 
 // ContestService is an autogenerated mock type for the ContestService type
@@ -24,6 +23,124 @@ func (_m *ContestService) EXPECT() *ContestService_Expecter {
 	return &ContestService_Expecter{mock: &_m.Mock}
 }
 
+// ArchiveFinishedContests provides a mock function with given fields: ctx
+func (_m *ContestService) ArchiveFinishedContests(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArchiveFinishedContests")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContestService_ArchiveFinishedContests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveFinishedContests'
+type ContestService_ArchiveFinishedContests_Call struct {
+	*mock.Call
+}
+
+// ArchiveFinishedContests is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ContestService_Expecter) ArchiveFinishedContests(ctx interface{}) *ContestService_ArchiveFinishedContests_Call {
+	return &ContestService_ArchiveFinishedContests_Call{Call: _e.mock.On("ArchiveFinishedContests", ctx)}
+}
+
+func (_c *ContestService_ArchiveFinishedContests_Call) Run(run func(ctx context.Context)) *ContestService_ArchiveFinishedContests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ContestService_ArchiveFinishedContests_Call) Return(_a0 int, _a1 error) *ContestService_ArchiveFinishedContests_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContestService_ArchiveFinishedContests_Call) RunAndReturn(run func(context.Context) (int, error)) *ContestService_ArchiveFinishedContests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AssignSquare provides a mock function with given fields: ctx, contestID, squareID, req, user
+func (_m *ContestService) AssignSquare(ctx context.Context, contestID uuid.UUID, squareID uuid.UUID, req *model.AssignSquareRequest, user string) (*model.Square, error) {
+	ret := _m.Called(ctx, contestID, squareID, req, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignSquare")
+	}
+
+	var r0 *model.Square
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *model.AssignSquareRequest, string) (*model.Square, error)); ok {
+		return rf(ctx, contestID, squareID, req, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *model.AssignSquareRequest, string) *model.Square); ok {
+		r0 = rf(ctx, contestID, squareID, req, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Square)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, *model.AssignSquareRequest, string) error); ok {
+		r1 = rf(ctx, contestID, squareID, req, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContestService_AssignSquare_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignSquare'
+type ContestService_AssignSquare_Call struct {
+	*mock.Call
+}
+
+// AssignSquare is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - squareID uuid.UUID
+//   - req *model.AssignSquareRequest
+//   - user string
+func (_e *ContestService_Expecter) AssignSquare(ctx interface{}, contestID interface{}, squareID interface{}, req interface{}, user interface{}) *ContestService_AssignSquare_Call {
+	return &ContestService_AssignSquare_Call{Call: _e.mock.On("AssignSquare", ctx, contestID, squareID, req, user)}
+}
+
+func (_c *ContestService_AssignSquare_Call) Run(run func(ctx context.Context, contestID uuid.UUID, squareID uuid.UUID, req *model.AssignSquareRequest, user string)) *ContestService_AssignSquare_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(*model.AssignSquareRequest), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *ContestService_AssignSquare_Call) Return(_a0 *model.Square, _a1 error) *ContestService_AssignSquare_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContestService_AssignSquare_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, *model.AssignSquareRequest, string) (*model.Square, error)) *ContestService_AssignSquare_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ClaimSquare provides a mock function with given fields: ctx, contestID, squareID, user
 func (_m *ContestService) ClaimSquare(ctx context.Context, contestID uuid.UUID, squareID uuid.UUID, user string) (*model.Square, error) {
 	ret := _m.Called(ctx, contestID, squareID, user)
@@ -85,6 +202,67 @@ func (_c *ContestService_ClaimSquare_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// ClaimSquares provides a mock function with given fields: ctx, contestID, squareIDs, user
+func (_m *ContestService) ClaimSquares(ctx context.Context, contestID uuid.UUID, squareIDs []uuid.UUID, user string) ([]model.Square, error) {
+	ret := _m.Called(ctx, contestID, squareIDs, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimSquares")
+	}
+
+	var r0 []model.Square
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []uuid.UUID, string) ([]model.Square, error)); ok {
+		return rf(ctx, contestID, squareIDs, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []uuid.UUID, string) []model.Square); ok {
+		r0 = rf(ctx, contestID, squareIDs, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Square)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, []uuid.UUID, string) error); ok {
+		r1 = rf(ctx, contestID, squareIDs, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContestService_ClaimSquares_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimSquares'
+type ContestService_ClaimSquares_Call struct {
+	*mock.Call
+}
+
+// ClaimSquares is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - squareIDs []uuid.UUID
+//   - user string
+func (_e *ContestService_Expecter) ClaimSquares(ctx interface{}, contestID interface{}, squareIDs interface{}, user interface{}) *ContestService_ClaimSquares_Call {
+	return &ContestService_ClaimSquares_Call{Call: _e.mock.On("ClaimSquares", ctx, contestID, squareIDs, user)}
+}
+
+func (_c *ContestService_ClaimSquares_Call) Run(run func(ctx context.Context, contestID uuid.UUID, squareIDs []uuid.UUID, user string)) *ContestService_ClaimSquares_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].([]uuid.UUID), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *ContestService_ClaimSquares_Call) Return(_a0 []model.Square, _a1 error) *ContestService_ClaimSquares_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContestService_ClaimSquares_Call) RunAndReturn(run func(context.Context, uuid.UUID, []uuid.UUID, string) ([]model.Square, error)) *ContestService_ClaimSquares_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ClearSquare provides a mock function with given fields: ctx, contestID, squareID, user
 func (_m *ContestService) ClearSquare(ctx context.Context, contestID uuid.UUID, squareID uuid.UUID, user string) (*model.Square, error) {
 	ret := _m.Called(ctx, contestID, squareID, user)
@@ -314,6 +492,66 @@ func (_c *ContestService_DeleteContest_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// GetContest provides a mock function with given fields: ctx, contestID, user
+func (_m *ContestService) GetContest(ctx context.Context, contestID uuid.UUID, user string) (*model.Contest, error) {
+	ret := _m.Called(ctx, contestID, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContest")
+	}
+
+	var r0 *model.Contest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*model.Contest, error)); ok {
+		return rf(ctx, contestID, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *model.Contest); ok {
+		r0 = rf(ctx, contestID, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Contest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, contestID, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContestService_GetContest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetContest'
+type ContestService_GetContest_Call struct {
+	*mock.Call
+}
+
+// GetContest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - user string
+func (_e *ContestService_Expecter) GetContest(ctx interface{}, contestID interface{}, user interface{}) *ContestService_GetContest_Call {
+	return &ContestService_GetContest_Call{Call: _e.mock.On("GetContest", ctx, contestID, user)}
+}
+
+func (_c *ContestService_GetContest_Call) Run(run func(ctx context.Context, contestID uuid.UUID, user string)) *ContestService_GetContest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ContestService_GetContest_Call) Return(_a0 *model.Contest, _a1 error) *ContestService_GetContest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContestService_GetContest_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) (*model.Contest, error)) *ContestService_GetContest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetContestsByOwnerPaginated provides a mock function with given fields: ctx, owner, page, limit, search
 func (_m *ContestService) GetContestsByOwnerPaginated(ctx context.Context, owner string, page int, limit int, search string) ([]model.Contest, int64, error) {
 	ret := _m.Called(ctx, owner, page, limit, search)
@@ -383,6 +621,174 @@ func (_c *ContestService_GetContestsByOwnerPaginated_Call) RunAndReturn(run func
 	return _c
 }
 
+// LockDueContests provides a mock function with given fields: ctx
+func (_m *ContestService) LockDueContests(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockDueContests")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContestService_LockDueContests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockDueContests'
+type ContestService_LockDueContests_Call struct {
+	*mock.Call
+}
+
+// LockDueContests is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ContestService_Expecter) LockDueContests(ctx interface{}) *ContestService_LockDueContests_Call {
+	return &ContestService_LockDueContests_Call{Call: _e.mock.On("LockDueContests", ctx)}
+}
+
+func (_c *ContestService_LockDueContests_Call) Run(run func(ctx context.Context)) *ContestService_LockDueContests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ContestService_LockDueContests_Call) Return(_a0 int, _a1 error) *ContestService_LockDueContests_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContestService_LockDueContests_Call) RunAndReturn(run func(context.Context) (int, error)) *ContestService_LockDueContests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeDeletedContests provides a mock function with given fields: ctx
+func (_m *ContestService) PurgeDeletedContests(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeletedContests")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContestService_PurgeDeletedContests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeDeletedContests'
+type ContestService_PurgeDeletedContests_Call struct {
+	*mock.Call
+}
+
+// PurgeDeletedContests is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ContestService_Expecter) PurgeDeletedContests(ctx interface{}) *ContestService_PurgeDeletedContests_Call {
+	return &ContestService_PurgeDeletedContests_Call{Call: _e.mock.On("PurgeDeletedContests", ctx)}
+}
+
+func (_c *ContestService_PurgeDeletedContests_Call) Run(run func(ctx context.Context)) *ContestService_PurgeDeletedContests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ContestService_PurgeDeletedContests_Call) Return(_a0 int64, _a1 error) *ContestService_PurgeDeletedContests_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContestService_PurgeDeletedContests_Call) RunAndReturn(run func(context.Context) (int64, error)) *ContestService_PurgeDeletedContests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeInviteEvents provides a mock function with given fields: ctx
+func (_m *ContestService) PurgeInviteEvents(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeInviteEvents")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContestService_PurgeInviteEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeInviteEvents'
+type ContestService_PurgeInviteEvents_Call struct {
+	*mock.Call
+}
+
+// PurgeInviteEvents is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ContestService_Expecter) PurgeInviteEvents(ctx interface{}) *ContestService_PurgeInviteEvents_Call {
+	return &ContestService_PurgeInviteEvents_Call{Call: _e.mock.On("PurgeInviteEvents", ctx)}
+}
+
+func (_c *ContestService_PurgeInviteEvents_Call) Run(run func(ctx context.Context)) *ContestService_PurgeInviteEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ContestService_PurgeInviteEvents_Call) Return(_a0 int64, _a1 error) *ContestService_PurgeInviteEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContestService_PurgeInviteEvents_Call) RunAndReturn(run func(context.Context) (int64, error)) *ContestService_PurgeInviteEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RecordQuarterResult provides a mock function with given fields: ctx, contestID, homeScore, awayScore, user
 func (_m *ContestService) RecordQuarterResult(ctx context.Context, contestID uuid.UUID, homeScore int, awayScore int, user string) (*model.QuarterResult, error) {
 	ret := _m.Called(ctx, contestID, homeScore, awayScore, user)
@@ -445,6 +851,122 @@ func (_c *ContestService_RecordQuarterResult_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// ReleaseExpiredReservations provides a mock function with given fields: ctx
+func (_m *ContestService) ReleaseExpiredReservations(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseExpiredReservations")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContestService_ReleaseExpiredReservations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseExpiredReservations'
+type ContestService_ReleaseExpiredReservations_Call struct {
+	*mock.Call
+}
+
+// ReleaseExpiredReservations is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ContestService_Expecter) ReleaseExpiredReservations(ctx interface{}) *ContestService_ReleaseExpiredReservations_Call {
+	return &ContestService_ReleaseExpiredReservations_Call{Call: _e.mock.On("ReleaseExpiredReservations", ctx)}
+}
+
+func (_c *ContestService_ReleaseExpiredReservations_Call) Run(run func(ctx context.Context)) *ContestService_ReleaseExpiredReservations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ContestService_ReleaseExpiredReservations_Call) Return(_a0 int, _a1 error) *ContestService_ReleaseExpiredReservations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContestService_ReleaseExpiredReservations_Call) RunAndReturn(run func(context.Context) (int, error)) *ContestService_ReleaseExpiredReservations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreContest provides a mock function with given fields: ctx, contestID, user
+func (_m *ContestService) RestoreContest(ctx context.Context, contestID uuid.UUID, user string) (*model.Contest, error) {
+	ret := _m.Called(ctx, contestID, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreContest")
+	}
+
+	var r0 *model.Contest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*model.Contest, error)); ok {
+		return rf(ctx, contestID, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *model.Contest); ok {
+		r0 = rf(ctx, contestID, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Contest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, contestID, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContestService_RestoreContest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreContest'
+type ContestService_RestoreContest_Call struct {
+	*mock.Call
+}
+
+// RestoreContest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - user string
+func (_e *ContestService_Expecter) RestoreContest(ctx interface{}, contestID interface{}, user interface{}) *ContestService_RestoreContest_Call {
+	return &ContestService_RestoreContest_Call{Call: _e.mock.On("RestoreContest", ctx, contestID, user)}
+}
+
+func (_c *ContestService_RestoreContest_Call) Run(run func(ctx context.Context, contestID uuid.UUID, user string)) *ContestService_RestoreContest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ContestService_RestoreContest_Call) Return(_a0 *model.Contest, _a1 error) *ContestService_RestoreContest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContestService_RestoreContest_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) (*model.Contest, error)) *ContestService_RestoreContest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RollbackLastQuarterResult provides a mock function with given fields: ctx, contestID, user
 func (_m *ContestService) RollbackLastQuarterResult(ctx context.Context, contestID uuid.UUID, user string) (*model.QuarterResult, error) {
 	ret := _m.Called(ctx, contestID, user)
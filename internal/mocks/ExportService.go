@@ -0,0 +1,158 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	uuid "github.com/google/uuid"
+	model "github.com/maxmorhardt/squares-api/internal/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExportService is an autogenerated mock type for the ExportService type
+type ExportService struct {
+	mock.Mock
+}
+
+type ExportService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ExportService) EXPECT() *ExportService_Expecter {
+	return &ExportService_Expecter{mock: &_m.Mock}
+}
+
+// ExportContest provides a mock function with given fields: ctx, contestID, user
+func (_m *ExportService) ExportContest(ctx context.Context, contestID uuid.UUID, user string) (*model.ContestExport, error) {
+	ret := _m.Called(ctx, contestID, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportContest")
+	}
+
+	var r0 *model.ContestExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*model.ContestExport, error)); ok {
+		return rf(ctx, contestID, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *model.ContestExport); ok {
+		r0 = rf(ctx, contestID, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ContestExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, contestID, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportService_ExportContest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportContest'
+type ExportService_ExportContest_Call struct {
+	*mock.Call
+}
+
+// ExportContest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - user string
+func (_e *ExportService_Expecter) ExportContest(ctx interface{}, contestID interface{}, user interface{}) *ExportService_ExportContest_Call {
+	return &ExportService_ExportContest_Call{Call: _e.mock.On("ExportContest", ctx, contestID, user)}
+}
+
+func (_c *ExportService_ExportContest_Call) Run(run func(ctx context.Context, contestID uuid.UUID, user string)) *ExportService_ExportContest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ExportService_ExportContest_Call) Return(_a0 *model.ContestExport, _a1 error) *ExportService_ExportContest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExportService_ExportContest_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) (*model.ContestExport, error)) *ExportService_ExportContest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportContest provides a mock function with given fields: ctx, doc, user
+func (_m *ExportService) ImportContest(ctx context.Context, doc *model.ContestExport, user string) (*model.Contest, error) {
+	ret := _m.Called(ctx, doc, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportContest")
+	}
+
+	var r0 *model.Contest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ContestExport, string) (*model.Contest, error)); ok {
+		return rf(ctx, doc, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ContestExport, string) *model.Contest); ok {
+		r0 = rf(ctx, doc, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Contest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ContestExport, string) error); ok {
+		r1 = rf(ctx, doc, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportService_ImportContest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportContest'
+type ExportService_ImportContest_Call struct {
+	*mock.Call
+}
+
+// ImportContest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - doc *model.ContestExport
+//   - user string
+func (_e *ExportService_Expecter) ImportContest(ctx interface{}, doc interface{}, user interface{}) *ExportService_ImportContest_Call {
+	return &ExportService_ImportContest_Call{Call: _e.mock.On("ImportContest", ctx, doc, user)}
+}
+
+func (_c *ExportService_ImportContest_Call) Run(run func(ctx context.Context, doc *model.ContestExport, user string)) *ExportService_ImportContest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.ContestExport), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ExportService_ImportContest_Call) Return(_a0 *model.Contest, _a1 error) *ExportService_ImportContest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExportService_ImportContest_Call) RunAndReturn(run func(context.Context, *model.ContestExport, string) (*model.Contest, error)) *ExportService_ImportContest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewExportService creates a new instance of ExportService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExportService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExportService {
+	mock := &ExportService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,476 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	model "github.com/maxmorhardt/squares-api/internal/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FriendRepository is an autogenerated mock type for the FriendRepository type
+type FriendRepository struct {
+	mock.Mock
+}
+
+type FriendRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *FriendRepository) EXPECT() *FriendRepository_Expecter {
+	return &FriendRepository_Expecter{mock: &_m.Mock}
+}
+
+// AreFriends provides a mock function with given fields: ctx, a, b
+func (_m *FriendRepository) AreFriends(ctx context.Context, a string, b string) (bool, error) {
+	ret := _m.Called(ctx, a, b)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AreFriends")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, a, b)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, a, b)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, a, b)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FriendRepository_AreFriends_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AreFriends'
+type FriendRepository_AreFriends_Call struct {
+	*mock.Call
+}
+
+// AreFriends is a helper method to define mock.On call
+//   - ctx context.Context
+//   - a string
+//   - b string
+func (_e *FriendRepository_Expecter) AreFriends(ctx interface{}, a interface{}, b interface{}) *FriendRepository_AreFriends_Call {
+	return &FriendRepository_AreFriends_Call{Call: _e.mock.On("AreFriends", ctx, a, b)}
+}
+
+func (_c *FriendRepository_AreFriends_Call) Run(run func(ctx context.Context, a string, b string)) *FriendRepository_AreFriends_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *FriendRepository_AreFriends_Call) Return(_a0 bool, _a1 error) *FriendRepository_AreFriends_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FriendRepository_AreFriends_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *FriendRepository_AreFriends_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, friendship
+func (_m *FriendRepository) Create(ctx context.Context, friendship *model.Friendship) error {
+	ret := _m.Called(ctx, friendship)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Friendship) error); ok {
+		r0 = rf(ctx, friendship)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FriendRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type FriendRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - friendship *model.Friendship
+func (_e *FriendRepository_Expecter) Create(ctx interface{}, friendship interface{}) *FriendRepository_Create_Call {
+	return &FriendRepository_Create_Call{Call: _e.mock.On("Create", ctx, friendship)}
+}
+
+func (_c *FriendRepository_Create_Call) Run(run func(ctx context.Context, friendship *model.Friendship)) *FriendRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Friendship))
+	})
+	return _c
+}
+
+func (_c *FriendRepository_Create_Call) Return(_a0 error) *FriendRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *FriendRepository_Create_Call) RunAndReturn(run func(context.Context, *model.Friendship) error) *FriendRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteBetween provides a mock function with given fields: ctx, a, b
+func (_m *FriendRepository) DeleteBetween(ctx context.Context, a string, b string) error {
+	ret := _m.Called(ctx, a, b)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBetween")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, a, b)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FriendRepository_DeleteBetween_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBetween'
+type FriendRepository_DeleteBetween_Call struct {
+	*mock.Call
+}
+
+// DeleteBetween is a helper method to define mock.On call
+//   - ctx context.Context
+//   - a string
+//   - b string
+func (_e *FriendRepository_Expecter) DeleteBetween(ctx interface{}, a interface{}, b interface{}) *FriendRepository_DeleteBetween_Call {
+	return &FriendRepository_DeleteBetween_Call{Call: _e.mock.On("DeleteBetween", ctx, a, b)}
+}
+
+func (_c *FriendRepository_DeleteBetween_Call) Run(run func(ctx context.Context, a string, b string)) *FriendRepository_DeleteBetween_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *FriendRepository_DeleteBetween_Call) Return(_a0 error) *FriendRepository_DeleteBetween_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *FriendRepository_DeleteBetween_Call) RunAndReturn(run func(context.Context, string, string) error) *FriendRepository_DeleteBetween_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBetween provides a mock function with given fields: ctx, a, b
+func (_m *FriendRepository) GetBetween(ctx context.Context, a string, b string) (*model.Friendship, error) {
+	ret := _m.Called(ctx, a, b)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBetween")
+	}
+
+	var r0 *model.Friendship
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.Friendship, error)); ok {
+		return rf(ctx, a, b)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.Friendship); ok {
+		r0 = rf(ctx, a, b)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Friendship)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, a, b)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FriendRepository_GetBetween_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBetween'
+type FriendRepository_GetBetween_Call struct {
+	*mock.Call
+}
+
+// GetBetween is a helper method to define mock.On call
+//   - ctx context.Context
+//   - a string
+//   - b string
+func (_e *FriendRepository_Expecter) GetBetween(ctx interface{}, a interface{}, b interface{}) *FriendRepository_GetBetween_Call {
+	return &FriendRepository_GetBetween_Call{Call: _e.mock.On("GetBetween", ctx, a, b)}
+}
+
+func (_c *FriendRepository_GetBetween_Call) Run(run func(ctx context.Context, a string, b string)) *FriendRepository_GetBetween_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *FriendRepository_GetBetween_Call) Return(_a0 *model.Friendship, _a1 error) *FriendRepository_GetBetween_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FriendRepository_GetBetween_Call) RunAndReturn(run func(context.Context, string, string) (*model.Friendship, error)) *FriendRepository_GetBetween_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFriends provides a mock function with given fields: ctx, user
+func (_m *FriendRepository) GetFriends(ctx context.Context, user string) ([]model.Friend, error) {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFriends")
+	}
+
+	var r0 []model.Friend
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.Friend, error)); ok {
+		return rf(ctx, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.Friend); ok {
+		r0 = rf(ctx, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Friend)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FriendRepository_GetFriends_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFriends'
+type FriendRepository_GetFriends_Call struct {
+	*mock.Call
+}
+
+// GetFriends is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user string
+func (_e *FriendRepository_Expecter) GetFriends(ctx interface{}, user interface{}) *FriendRepository_GetFriends_Call {
+	return &FriendRepository_GetFriends_Call{Call: _e.mock.On("GetFriends", ctx, user)}
+}
+
+func (_c *FriendRepository_GetFriends_Call) Run(run func(ctx context.Context, user string)) *FriendRepository_GetFriends_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *FriendRepository_GetFriends_Call) Return(_a0 []model.Friend, _a1 error) *FriendRepository_GetFriends_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FriendRepository_GetFriends_Call) RunAndReturn(run func(context.Context, string) ([]model.Friend, error)) *FriendRepository_GetFriends_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPending provides a mock function with given fields: ctx, user
+func (_m *FriendRepository) GetPending(ctx context.Context, user string) ([]model.Friendship, error) {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPending")
+	}
+
+	var r0 []model.Friendship
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.Friendship, error)); ok {
+		return rf(ctx, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.Friendship); ok {
+		r0 = rf(ctx, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Friendship)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FriendRepository_GetPending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPending'
+type FriendRepository_GetPending_Call struct {
+	*mock.Call
+}
+
+// GetPending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user string
+func (_e *FriendRepository_Expecter) GetPending(ctx interface{}, user interface{}) *FriendRepository_GetPending_Call {
+	return &FriendRepository_GetPending_Call{Call: _e.mock.On("GetPending", ctx, user)}
+}
+
+func (_c *FriendRepository_GetPending_Call) Run(run func(ctx context.Context, user string)) *FriendRepository_GetPending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *FriendRepository_GetPending_Call) Return(_a0 []model.Friendship, _a1 error) *FriendRepository_GetPending_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FriendRepository_GetPending_Call) RunAndReturn(run func(context.Context, string) ([]model.Friendship, error)) *FriendRepository_GetPending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchUsers provides a mock function with given fields: ctx, user, query, limit
+func (_m *FriendRepository) SearchUsers(ctx context.Context, user string, query string, limit int) ([]model.UserSearchResult, error) {
+	ret := _m.Called(ctx, user, query, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchUsers")
+	}
+
+	var r0 []model.UserSearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) ([]model.UserSearchResult, error)); ok {
+		return rf(ctx, user, query, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) []model.UserSearchResult); ok {
+		r0 = rf(ctx, user, query, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.UserSearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = rf(ctx, user, query, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FriendRepository_SearchUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchUsers'
+type FriendRepository_SearchUsers_Call struct {
+	*mock.Call
+}
+
+// SearchUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user string
+//   - query string
+//   - limit int
+func (_e *FriendRepository_Expecter) SearchUsers(ctx interface{}, user interface{}, query interface{}, limit interface{}) *FriendRepository_SearchUsers_Call {
+	return &FriendRepository_SearchUsers_Call{Call: _e.mock.On("SearchUsers", ctx, user, query, limit)}
+}
+
+func (_c *FriendRepository_SearchUsers_Call) Run(run func(ctx context.Context, user string, query string, limit int)) *FriendRepository_SearchUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *FriendRepository_SearchUsers_Call) Return(_a0 []model.UserSearchResult, _a1 error) *FriendRepository_SearchUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FriendRepository_SearchUsers_Call) RunAndReturn(run func(context.Context, string, string, int) ([]model.UserSearchResult, error)) *FriendRepository_SearchUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, friendship
+func (_m *FriendRepository) Update(ctx context.Context, friendship *model.Friendship) error {
+	ret := _m.Called(ctx, friendship)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Friendship) error); ok {
+		r0 = rf(ctx, friendship)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FriendRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type FriendRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - friendship *model.Friendship
+func (_e *FriendRepository_Expecter) Update(ctx interface{}, friendship interface{}) *FriendRepository_Update_Call {
+	return &FriendRepository_Update_Call{Call: _e.mock.On("Update", ctx, friendship)}
+}
+
+func (_c *FriendRepository_Update_Call) Run(run func(ctx context.Context, friendship *model.Friendship)) *FriendRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Friendship))
+	})
+	return _c
+}
+
+func (_c *FriendRepository_Update_Call) Return(_a0 error) *FriendRepository_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *FriendRepository_Update_Call) RunAndReturn(run func(context.Context, *model.Friendship) error) *FriendRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewFriendRepository creates a new instance of FriendRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFriendRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FriendRepository {
+	mock := &FriendRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
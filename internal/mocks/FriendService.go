@@ -0,0 +1,445 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	uuid "github.com/google/uuid"
+	model "github.com/maxmorhardt/squares-api/internal/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FriendService is an autogenerated mock type for the FriendService type
+type FriendService struct {
+	mock.Mock
+}
+
+type FriendService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *FriendService) EXPECT() *FriendService_Expecter {
+	return &FriendService_Expecter{mock: &_m.Mock}
+}
+
+// AcceptRequest provides a mock function with given fields: ctx, user, requester
+func (_m *FriendService) AcceptRequest(ctx context.Context, user string, requester string) (*model.Friendship, error) {
+	ret := _m.Called(ctx, user, requester)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptRequest")
+	}
+
+	var r0 *model.Friendship
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.Friendship, error)); ok {
+		return rf(ctx, user, requester)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.Friendship); ok {
+		r0 = rf(ctx, user, requester)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Friendship)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, user, requester)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FriendService_AcceptRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcceptRequest'
+type FriendService_AcceptRequest_Call struct {
+	*mock.Call
+}
+
+// AcceptRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user string
+//   - requester string
+func (_e *FriendService_Expecter) AcceptRequest(ctx interface{}, user interface{}, requester interface{}) *FriendService_AcceptRequest_Call {
+	return &FriendService_AcceptRequest_Call{Call: _e.mock.On("AcceptRequest", ctx, user, requester)}
+}
+
+func (_c *FriendService_AcceptRequest_Call) Run(run func(ctx context.Context, user string, requester string)) *FriendService_AcceptRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *FriendService_AcceptRequest_Call) Return(_a0 *model.Friendship, _a1 error) *FriendService_AcceptRequest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FriendService_AcceptRequest_Call) RunAndReturn(run func(context.Context, string, string) (*model.Friendship, error)) *FriendService_AcceptRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddFriendToContest provides a mock function with given fields: ctx, contestID, req, user
+func (_m *FriendService) AddFriendToContest(ctx context.Context, contestID uuid.UUID, req *model.AddFriendParticipantRequest, user string) (*model.ContestParticipant, error) {
+	ret := _m.Called(ctx, contestID, req, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddFriendToContest")
+	}
+
+	var r0 *model.ContestParticipant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.AddFriendParticipantRequest, string) (*model.ContestParticipant, error)); ok {
+		return rf(ctx, contestID, req, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.AddFriendParticipantRequest, string) *model.ContestParticipant); ok {
+		r0 = rf(ctx, contestID, req, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ContestParticipant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *model.AddFriendParticipantRequest, string) error); ok {
+		r1 = rf(ctx, contestID, req, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FriendService_AddFriendToContest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddFriendToContest'
+type FriendService_AddFriendToContest_Call struct {
+	*mock.Call
+}
+
+// AddFriendToContest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - req *model.AddFriendParticipantRequest
+//   - user string
+func (_e *FriendService_Expecter) AddFriendToContest(ctx interface{}, contestID interface{}, req interface{}, user interface{}) *FriendService_AddFriendToContest_Call {
+	return &FriendService_AddFriendToContest_Call{Call: _e.mock.On("AddFriendToContest", ctx, contestID, req, user)}
+}
+
+func (_c *FriendService_AddFriendToContest_Call) Run(run func(ctx context.Context, contestID uuid.UUID, req *model.AddFriendParticipantRequest, user string)) *FriendService_AddFriendToContest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*model.AddFriendParticipantRequest), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *FriendService_AddFriendToContest_Call) Return(_a0 *model.ContestParticipant, _a1 error) *FriendService_AddFriendToContest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FriendService_AddFriendToContest_Call) RunAndReturn(run func(context.Context, uuid.UUID, *model.AddFriendParticipantRequest, string) (*model.ContestParticipant, error)) *FriendService_AddFriendToContest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFriends provides a mock function with given fields: ctx, user
+func (_m *FriendService) GetFriends(ctx context.Context, user string) ([]model.Friend, error) {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFriends")
+	}
+
+	var r0 []model.Friend
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.Friend, error)); ok {
+		return rf(ctx, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.Friend); ok {
+		r0 = rf(ctx, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Friend)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FriendService_GetFriends_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFriends'
+type FriendService_GetFriends_Call struct {
+	*mock.Call
+}
+
+// GetFriends is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user string
+func (_e *FriendService_Expecter) GetFriends(ctx interface{}, user interface{}) *FriendService_GetFriends_Call {
+	return &FriendService_GetFriends_Call{Call: _e.mock.On("GetFriends", ctx, user)}
+}
+
+func (_c *FriendService_GetFriends_Call) Run(run func(ctx context.Context, user string)) *FriendService_GetFriends_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *FriendService_GetFriends_Call) Return(_a0 []model.Friend, _a1 error) *FriendService_GetFriends_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FriendService_GetFriends_Call) RunAndReturn(run func(context.Context, string) ([]model.Friend, error)) *FriendService_GetFriends_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRequests provides a mock function with given fields: ctx, user
+func (_m *FriendService) GetRequests(ctx context.Context, user string) (*model.FriendRequestsResponse, error) {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRequests")
+	}
+
+	var r0 *model.FriendRequestsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.FriendRequestsResponse, error)); ok {
+		return rf(ctx, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.FriendRequestsResponse); ok {
+		r0 = rf(ctx, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FriendRequestsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FriendService_GetRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRequests'
+type FriendService_GetRequests_Call struct {
+	*mock.Call
+}
+
+// GetRequests is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user string
+func (_e *FriendService_Expecter) GetRequests(ctx interface{}, user interface{}) *FriendService_GetRequests_Call {
+	return &FriendService_GetRequests_Call{Call: _e.mock.On("GetRequests", ctx, user)}
+}
+
+func (_c *FriendService_GetRequests_Call) Run(run func(ctx context.Context, user string)) *FriendService_GetRequests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *FriendService_GetRequests_Call) Return(_a0 *model.FriendRequestsResponse, _a1 error) *FriendService_GetRequests_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FriendService_GetRequests_Call) RunAndReturn(run func(context.Context, string) (*model.FriendRequestsResponse, error)) *FriendService_GetRequests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveFriend provides a mock function with given fields: ctx, user, other
+func (_m *FriendService) RemoveFriend(ctx context.Context, user string, other string) error {
+	ret := _m.Called(ctx, user, other)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveFriend")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, user, other)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FriendService_RemoveFriend_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveFriend'
+type FriendService_RemoveFriend_Call struct {
+	*mock.Call
+}
+
+// RemoveFriend is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user string
+//   - other string
+func (_e *FriendService_Expecter) RemoveFriend(ctx interface{}, user interface{}, other interface{}) *FriendService_RemoveFriend_Call {
+	return &FriendService_RemoveFriend_Call{Call: _e.mock.On("RemoveFriend", ctx, user, other)}
+}
+
+func (_c *FriendService_RemoveFriend_Call) Run(run func(ctx context.Context, user string, other string)) *FriendService_RemoveFriend_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *FriendService_RemoveFriend_Call) Return(_a0 error) *FriendService_RemoveFriend_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *FriendService_RemoveFriend_Call) RunAndReturn(run func(context.Context, string, string) error) *FriendService_RemoveFriend_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchUsers provides a mock function with given fields: ctx, user, query
+func (_m *FriendService) SearchUsers(ctx context.Context, user string, query string) ([]model.UserSearchResult, error) {
+	ret := _m.Called(ctx, user, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchUsers")
+	}
+
+	var r0 []model.UserSearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]model.UserSearchResult, error)); ok {
+		return rf(ctx, user, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []model.UserSearchResult); ok {
+		r0 = rf(ctx, user, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.UserSearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, user, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FriendService_SearchUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchUsers'
+type FriendService_SearchUsers_Call struct {
+	*mock.Call
+}
+
+// SearchUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user string
+//   - query string
+func (_e *FriendService_Expecter) SearchUsers(ctx interface{}, user interface{}, query interface{}) *FriendService_SearchUsers_Call {
+	return &FriendService_SearchUsers_Call{Call: _e.mock.On("SearchUsers", ctx, user, query)}
+}
+
+func (_c *FriendService_SearchUsers_Call) Run(run func(ctx context.Context, user string, query string)) *FriendService_SearchUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *FriendService_SearchUsers_Call) Return(_a0 []model.UserSearchResult, _a1 error) *FriendService_SearchUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FriendService_SearchUsers_Call) RunAndReturn(run func(context.Context, string, string) ([]model.UserSearchResult, error)) *FriendService_SearchUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendRequest provides a mock function with given fields: ctx, user, target
+func (_m *FriendService) SendRequest(ctx context.Context, user string, target string) (*model.Friendship, error) {
+	ret := _m.Called(ctx, user, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendRequest")
+	}
+
+	var r0 *model.Friendship
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.Friendship, error)); ok {
+		return rf(ctx, user, target)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.Friendship); ok {
+		r0 = rf(ctx, user, target)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Friendship)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, user, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FriendService_SendRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendRequest'
+type FriendService_SendRequest_Call struct {
+	*mock.Call
+}
+
+// SendRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user string
+//   - target string
+func (_e *FriendService_Expecter) SendRequest(ctx interface{}, user interface{}, target interface{}) *FriendService_SendRequest_Call {
+	return &FriendService_SendRequest_Call{Call: _e.mock.On("SendRequest", ctx, user, target)}
+}
+
+func (_c *FriendService_SendRequest_Call) Run(run func(ctx context.Context, user string, target string)) *FriendService_SendRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *FriendService_SendRequest_Call) Return(_a0 *model.Friendship, _a1 error) *FriendService_SendRequest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FriendService_SendRequest_Call) RunAndReturn(run func(context.Context, string, string) (*model.Friendship, error)) *FriendService_SendRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewFriendService creates a new instance of FriendService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFriendService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FriendService {
+	mock := &FriendService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -4,13 +4,11 @@ package mocks
 
 import (
 	context "context"
-
-	model "github.com/maxmorhardt/squares-api/internal/model"
-	mock "github.com/stretchr/testify/mock"
-
 	time "time"
 
 	uuid "github.com/google/uuid"
+	model "github.com/maxmorhardt/squares-api/internal/model"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // GameRepository is an autogenerated mock type for the GameRepository type
@@ -85,6 +83,64 @@ func (_c *GameRepository_GetByID_Call) RunAndReturn(run func(context.Context, uu
 	return _c
 }
 
+// GetDigitFrequencies provides a mock function with given fields: ctx
+func (_m *GameRepository) GetDigitFrequencies(ctx context.Context) ([]model.DigitFrequency, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDigitFrequencies")
+	}
+
+	var r0 []model.DigitFrequency
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.DigitFrequency, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.DigitFrequency); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.DigitFrequency)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GameRepository_GetDigitFrequencies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDigitFrequencies'
+type GameRepository_GetDigitFrequencies_Call struct {
+	*mock.Call
+}
+
+// GetDigitFrequencies is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *GameRepository_Expecter) GetDigitFrequencies(ctx interface{}) *GameRepository_GetDigitFrequencies_Call {
+	return &GameRepository_GetDigitFrequencies_Call{Call: _e.mock.On("GetDigitFrequencies", ctx)}
+}
+
+func (_c *GameRepository_GetDigitFrequencies_Call) Run(run func(ctx context.Context)) *GameRepository_GetDigitFrequencies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *GameRepository_GetDigitFrequencies_Call) Return(_a0 []model.DigitFrequency, _a1 error) *GameRepository_GetDigitFrequencies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GameRepository_GetDigitFrequencies_Call) RunAndReturn(run func(context.Context) ([]model.DigitFrequency, error)) *GameRepository_GetDigitFrequencies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetUpcoming provides a mock function with given fields: ctx
 func (_m *GameRepository) GetUpcoming(ctx context.Context) ([]model.Game, error) {
 	ret := _m.Called(ctx)
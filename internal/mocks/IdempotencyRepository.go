@@ -0,0 +1,307 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	model "github.com/maxmorhardt/squares-api/internal/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IdempotencyRepository is an autogenerated mock type for the IdempotencyRepository type
+type IdempotencyRepository struct {
+	mock.Mock
+}
+
+type IdempotencyRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IdempotencyRepository) EXPECT() *IdempotencyRepository_Expecter {
+	return &IdempotencyRepository_Expecter{mock: &_m.Mock}
+}
+
+// Complete provides a mock function with given fields: ctx, record
+func (_m *IdempotencyRepository) Complete(ctx context.Context, record *model.IdempotencyKey) error {
+	ret := _m.Called(ctx, record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.IdempotencyKey) error); ok {
+		r0 = rf(ctx, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IdempotencyRepository_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
+type IdempotencyRepository_Complete_Call struct {
+	*mock.Call
+}
+
+// Complete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - record *model.IdempotencyKey
+func (_e *IdempotencyRepository_Expecter) Complete(ctx interface{}, record interface{}) *IdempotencyRepository_Complete_Call {
+	return &IdempotencyRepository_Complete_Call{Call: _e.mock.On("Complete", ctx, record)}
+}
+
+func (_c *IdempotencyRepository_Complete_Call) Run(run func(ctx context.Context, record *model.IdempotencyKey)) *IdempotencyRepository_Complete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.IdempotencyKey))
+	})
+	return _c
+}
+
+func (_c *IdempotencyRepository_Complete_Call) Return(_a0 error) *IdempotencyRepository_Complete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IdempotencyRepository_Complete_Call) RunAndReturn(run func(context.Context, *model.IdempotencyKey) error) *IdempotencyRepository_Complete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteExpired provides a mock function with given fields: ctx, now
+func (_m *IdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpired")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdempotencyRepository_DeleteExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpired'
+type IdempotencyRepository_DeleteExpired_Call struct {
+	*mock.Call
+}
+
+// DeleteExpired is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *IdempotencyRepository_Expecter) DeleteExpired(ctx interface{}, now interface{}) *IdempotencyRepository_DeleteExpired_Call {
+	return &IdempotencyRepository_DeleteExpired_Call{Call: _e.mock.On("DeleteExpired", ctx, now)}
+}
+
+func (_c *IdempotencyRepository_DeleteExpired_Call) Run(run func(ctx context.Context, now time.Time)) *IdempotencyRepository_DeleteExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IdempotencyRepository_DeleteExpired_Call) Return(_a0 int64, _a1 error) *IdempotencyRepository_DeleteExpired_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IdempotencyRepository_DeleteExpired_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *IdempotencyRepository_DeleteExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, userID, key
+func (_m *IdempotencyRepository) Get(ctx context.Context, userID string, key string) (*model.IdempotencyKey, error) {
+	ret := _m.Called(ctx, userID, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.IdempotencyKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.IdempotencyKey, error)); ok {
+		return rf(ctx, userID, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.IdempotencyKey); ok {
+		r0 = rf(ctx, userID, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.IdempotencyKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdempotencyRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type IdempotencyRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - key string
+func (_e *IdempotencyRepository_Expecter) Get(ctx interface{}, userID interface{}, key interface{}) *IdempotencyRepository_Get_Call {
+	return &IdempotencyRepository_Get_Call{Call: _e.mock.On("Get", ctx, userID, key)}
+}
+
+func (_c *IdempotencyRepository_Get_Call) Run(run func(ctx context.Context, userID string, key string)) *IdempotencyRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *IdempotencyRepository_Get_Call) Return(_a0 *model.IdempotencyKey, _a1 error) *IdempotencyRepository_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IdempotencyRepository_Get_Call) RunAndReturn(run func(context.Context, string, string) (*model.IdempotencyKey, error)) *IdempotencyRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Release provides a mock function with given fields: ctx, record
+func (_m *IdempotencyRepository) Release(ctx context.Context, record *model.IdempotencyKey) error {
+	ret := _m.Called(ctx, record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.IdempotencyKey) error); ok {
+		r0 = rf(ctx, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IdempotencyRepository_Release_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Release'
+type IdempotencyRepository_Release_Call struct {
+	*mock.Call
+}
+
+// Release is a helper method to define mock.On call
+//   - ctx context.Context
+//   - record *model.IdempotencyKey
+func (_e *IdempotencyRepository_Expecter) Release(ctx interface{}, record interface{}) *IdempotencyRepository_Release_Call {
+	return &IdempotencyRepository_Release_Call{Call: _e.mock.On("Release", ctx, record)}
+}
+
+func (_c *IdempotencyRepository_Release_Call) Run(run func(ctx context.Context, record *model.IdempotencyKey)) *IdempotencyRepository_Release_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.IdempotencyKey))
+	})
+	return _c
+}
+
+func (_c *IdempotencyRepository_Release_Call) Return(_a0 error) *IdempotencyRepository_Release_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IdempotencyRepository_Release_Call) RunAndReturn(run func(context.Context, *model.IdempotencyKey) error) *IdempotencyRepository_Release_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reserve provides a mock function with given fields: ctx, record, staleBefore
+func (_m *IdempotencyRepository) Reserve(ctx context.Context, record *model.IdempotencyKey, staleBefore time.Time) (bool, error) {
+	ret := _m.Called(ctx, record, staleBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reserve")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.IdempotencyKey, time.Time) (bool, error)); ok {
+		return rf(ctx, record, staleBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.IdempotencyKey, time.Time) bool); ok {
+		r0 = rf(ctx, record, staleBefore)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.IdempotencyKey, time.Time) error); ok {
+		r1 = rf(ctx, record, staleBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdempotencyRepository_Reserve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reserve'
+type IdempotencyRepository_Reserve_Call struct {
+	*mock.Call
+}
+
+// Reserve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - record *model.IdempotencyKey
+//   - staleBefore time.Time
+func (_e *IdempotencyRepository_Expecter) Reserve(ctx interface{}, record interface{}, staleBefore interface{}) *IdempotencyRepository_Reserve_Call {
+	return &IdempotencyRepository_Reserve_Call{Call: _e.mock.On("Reserve", ctx, record, staleBefore)}
+}
+
+func (_c *IdempotencyRepository_Reserve_Call) Run(run func(ctx context.Context, record *model.IdempotencyKey, staleBefore time.Time)) *IdempotencyRepository_Reserve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.IdempotencyKey), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IdempotencyRepository_Reserve_Call) Return(_a0 bool, _a1 error) *IdempotencyRepository_Reserve_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IdempotencyRepository_Reserve_Call) RunAndReturn(run func(context.Context, *model.IdempotencyKey, time.Time) (bool, error)) *IdempotencyRepository_Reserve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIdempotencyRepository creates a new instance of IdempotencyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIdempotencyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IdempotencyRepository {
+	mock := &IdempotencyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
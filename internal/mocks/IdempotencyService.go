@@ -0,0 +1,260 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	model "github.com/maxmorhardt/squares-api/internal/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IdempotencyService is an autogenerated mock type for the IdempotencyService type
+type IdempotencyService struct {
+	mock.Mock
+}
+
+type IdempotencyService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IdempotencyService) EXPECT() *IdempotencyService_Expecter {
+	return &IdempotencyService_Expecter{mock: &_m.Mock}
+}
+
+// Begin provides a mock function with given fields: ctx, user, key, method, path, body
+func (_m *IdempotencyService) Begin(ctx context.Context, user string, key string, method string, path string, body []byte) (*model.IdempotencyKey, bool, error) {
+	ret := _m.Called(ctx, user, key, method, path, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Begin")
+	}
+
+	var r0 *model.IdempotencyKey
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, []byte) (*model.IdempotencyKey, bool, error)); ok {
+		return rf(ctx, user, key, method, path, body)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, []byte) *model.IdempotencyKey); ok {
+		r0 = rf(ctx, user, key, method, path, body)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.IdempotencyKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, []byte) bool); ok {
+		r1 = rf(ctx, user, key, method, path, body)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, string, string, []byte) error); ok {
+		r2 = rf(ctx, user, key, method, path, body)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// IdempotencyService_Begin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Begin'
+type IdempotencyService_Begin_Call struct {
+	*mock.Call
+}
+
+// Begin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user string
+//   - key string
+//   - method string
+//   - path string
+//   - body []byte
+func (_e *IdempotencyService_Expecter) Begin(ctx interface{}, user interface{}, key interface{}, method interface{}, path interface{}, body interface{}) *IdempotencyService_Begin_Call {
+	return &IdempotencyService_Begin_Call{Call: _e.mock.On("Begin", ctx, user, key, method, path, body)}
+}
+
+func (_c *IdempotencyService_Begin_Call) Run(run func(ctx context.Context, user string, key string, method string, path string, body []byte)) *IdempotencyService_Begin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string), args[5].([]byte))
+	})
+	return _c
+}
+
+func (_c *IdempotencyService_Begin_Call) Return(record *model.IdempotencyKey, replay bool, err error) *IdempotencyService_Begin_Call {
+	_c.Call.Return(record, replay, err)
+	return _c
+}
+
+func (_c *IdempotencyService_Begin_Call) RunAndReturn(run func(context.Context, string, string, string, string, []byte) (*model.IdempotencyKey, bool, error)) *IdempotencyService_Begin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Complete provides a mock function with given fields: ctx, record, statusCode, headers, body
+func (_m *IdempotencyService) Complete(ctx context.Context, record *model.IdempotencyKey, statusCode int, headers map[string]string, body []byte) error {
+	ret := _m.Called(ctx, record, statusCode, headers, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.IdempotencyKey, int, map[string]string, []byte) error); ok {
+		r0 = rf(ctx, record, statusCode, headers, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IdempotencyService_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
+type IdempotencyService_Complete_Call struct {
+	*mock.Call
+}
+
+// Complete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - record *model.IdempotencyKey
+//   - statusCode int
+//   - headers map[string]string
+//   - body []byte
+func (_e *IdempotencyService_Expecter) Complete(ctx interface{}, record interface{}, statusCode interface{}, headers interface{}, body interface{}) *IdempotencyService_Complete_Call {
+	return &IdempotencyService_Complete_Call{Call: _e.mock.On("Complete", ctx, record, statusCode, headers, body)}
+}
+
+func (_c *IdempotencyService_Complete_Call) Run(run func(ctx context.Context, record *model.IdempotencyKey, statusCode int, headers map[string]string, body []byte)) *IdempotencyService_Complete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.IdempotencyKey), args[2].(int), args[3].(map[string]string), args[4].([]byte))
+	})
+	return _c
+}
+
+func (_c *IdempotencyService_Complete_Call) Return(_a0 error) *IdempotencyService_Complete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IdempotencyService_Complete_Call) RunAndReturn(run func(context.Context, *model.IdempotencyKey, int, map[string]string, []byte) error) *IdempotencyService_Complete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeExpired provides a mock function with given fields: ctx
+func (_m *IdempotencyService) PurgeExpired(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeExpired")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdempotencyService_PurgeExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeExpired'
+type IdempotencyService_PurgeExpired_Call struct {
+	*mock.Call
+}
+
+// PurgeExpired is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *IdempotencyService_Expecter) PurgeExpired(ctx interface{}) *IdempotencyService_PurgeExpired_Call {
+	return &IdempotencyService_PurgeExpired_Call{Call: _e.mock.On("PurgeExpired", ctx)}
+}
+
+func (_c *IdempotencyService_PurgeExpired_Call) Run(run func(ctx context.Context)) *IdempotencyService_PurgeExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *IdempotencyService_PurgeExpired_Call) Return(_a0 int64, _a1 error) *IdempotencyService_PurgeExpired_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IdempotencyService_PurgeExpired_Call) RunAndReturn(run func(context.Context) (int64, error)) *IdempotencyService_PurgeExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Release provides a mock function with given fields: ctx, record
+func (_m *IdempotencyService) Release(ctx context.Context, record *model.IdempotencyKey) error {
+	ret := _m.Called(ctx, record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.IdempotencyKey) error); ok {
+		r0 = rf(ctx, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IdempotencyService_Release_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Release'
+type IdempotencyService_Release_Call struct {
+	*mock.Call
+}
+
+// Release is a helper method to define mock.On call
+//   - ctx context.Context
+//   - record *model.IdempotencyKey
+func (_e *IdempotencyService_Expecter) Release(ctx interface{}, record interface{}) *IdempotencyService_Release_Call {
+	return &IdempotencyService_Release_Call{Call: _e.mock.On("Release", ctx, record)}
+}
+
+func (_c *IdempotencyService_Release_Call) Run(run func(ctx context.Context, record *model.IdempotencyKey)) *IdempotencyService_Release_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.IdempotencyKey))
+	})
+	return _c
+}
+
+func (_c *IdempotencyService_Release_Call) Return(_a0 error) *IdempotencyService_Release_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IdempotencyService_Release_Call) RunAndReturn(run func(context.Context, *model.IdempotencyKey) error) *IdempotencyService_Release_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIdempotencyService creates a new instance of IdempotencyService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIdempotencyService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IdempotencyService {
+	mock := &IdempotencyService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -5,10 +5,9 @@ package mocks
 import (
 	context "context"
 
+	uuid "github.com/google/uuid"
 	model "github.com/maxmorhardt/squares-api/internal/model"
 	mock "github.com/stretchr/testify/mock"
-
-	uuid "github.com/google/uuid"
 )
 
 // InviteRepository is an autogenerated mock type for the InviteRepository type
@@ -71,17 +70,17 @@ func (_c *InviteRepository_Create_Call) RunAndReturn(run func(context.Context, *
 	return _c
 }
 
-// Delete provides a mock function with given fields: ctx, id
-func (_m *InviteRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	ret := _m.Called(ctx, id)
+// CreateMany provides a mock function with given fields: ctx, invites
+func (_m *InviteRepository) CreateMany(ctx context.Context, invites []*model.ContestInvite) error {
+	ret := _m.Called(ctx, invites)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Delete")
+		panic("no return value specified for CreateMany")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, []*model.ContestInvite) error); ok {
+		r0 = rf(ctx, invites)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -89,6 +88,125 @@ func (_m *InviteRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return r0
 }
 
+// InviteRepository_CreateMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateMany'
+type InviteRepository_CreateMany_Call struct {
+	*mock.Call
+}
+
+// CreateMany is a helper method to define mock.On call
+//   - ctx context.Context
+//   - invites []*model.ContestInvite
+func (_e *InviteRepository_Expecter) CreateMany(ctx interface{}, invites interface{}) *InviteRepository_CreateMany_Call {
+	return &InviteRepository_CreateMany_Call{Call: _e.mock.On("CreateMany", ctx, invites)}
+}
+
+func (_c *InviteRepository_CreateMany_Call) Run(run func(ctx context.Context, invites []*model.ContestInvite)) *InviteRepository_CreateMany_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*model.ContestInvite))
+	})
+	return _c
+}
+
+func (_c *InviteRepository_CreateMany_Call) Return(_a0 error) *InviteRepository_CreateMany_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *InviteRepository_CreateMany_Call) RunAndReturn(run func(context.Context, []*model.ContestInvite) error) *InviteRepository_CreateMany_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWithReservations provides a mock function with given fields: ctx, invite, positions
+func (_m *InviteRepository) CreateWithReservations(ctx context.Context, invite *model.ContestInvite, positions []model.SquarePosition) ([]model.Square, error) {
+	ret := _m.Called(ctx, invite, positions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWithReservations")
+	}
+
+	var r0 []model.Square
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ContestInvite, []model.SquarePosition) ([]model.Square, error)); ok {
+		return rf(ctx, invite, positions)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ContestInvite, []model.SquarePosition) []model.Square); ok {
+		r0 = rf(ctx, invite, positions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Square)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ContestInvite, []model.SquarePosition) error); ok {
+		r1 = rf(ctx, invite, positions)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InviteRepository_CreateWithReservations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWithReservations'
+type InviteRepository_CreateWithReservations_Call struct {
+	*mock.Call
+}
+
+// CreateWithReservations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - invite *model.ContestInvite
+//   - positions []model.SquarePosition
+func (_e *InviteRepository_Expecter) CreateWithReservations(ctx interface{}, invite interface{}, positions interface{}) *InviteRepository_CreateWithReservations_Call {
+	return &InviteRepository_CreateWithReservations_Call{Call: _e.mock.On("CreateWithReservations", ctx, invite, positions)}
+}
+
+func (_c *InviteRepository_CreateWithReservations_Call) Run(run func(ctx context.Context, invite *model.ContestInvite, positions []model.SquarePosition)) *InviteRepository_CreateWithReservations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.ContestInvite), args[2].([]model.SquarePosition))
+	})
+	return _c
+}
+
+func (_c *InviteRepository_CreateWithReservations_Call) Return(_a0 []model.Square, _a1 error) *InviteRepository_CreateWithReservations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *InviteRepository_CreateWithReservations_Call) RunAndReturn(run func(context.Context, *model.ContestInvite, []model.SquarePosition) ([]model.Square, error)) *InviteRepository_CreateWithReservations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, contestID, id
+func (_m *InviteRepository) Delete(ctx context.Context, contestID uuid.UUID, id uuid.UUID) ([]model.Square, error) {
+	ret := _m.Called(ctx, contestID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 []model.Square
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) ([]model.Square, error)); ok {
+		return rf(ctx, contestID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) []model.Square); ok {
+		r0 = rf(ctx, contestID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Square)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, contestID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // InviteRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
 type InviteRepository_Delete_Call struct {
 	*mock.Call
@@ -96,24 +214,25 @@ type InviteRepository_Delete_Call struct {
 
 // Delete is a helper method to define mock.On call
 //   - ctx context.Context
+//   - contestID uuid.UUID
 //   - id uuid.UUID
-func (_e *InviteRepository_Expecter) Delete(ctx interface{}, id interface{}) *InviteRepository_Delete_Call {
-	return &InviteRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+func (_e *InviteRepository_Expecter) Delete(ctx interface{}, contestID interface{}, id interface{}) *InviteRepository_Delete_Call {
+	return &InviteRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, contestID, id)}
 }
 
-func (_c *InviteRepository_Delete_Call) Run(run func(ctx context.Context, id uuid.UUID)) *InviteRepository_Delete_Call {
+func (_c *InviteRepository_Delete_Call) Run(run func(ctx context.Context, contestID uuid.UUID, id uuid.UUID)) *InviteRepository_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *InviteRepository_Delete_Call) Return(_a0 error) *InviteRepository_Delete_Call {
-	_c.Call.Return(_a0)
+func (_c *InviteRepository_Delete_Call) Return(_a0 []model.Square, _a1 error) *InviteRepository_Delete_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *InviteRepository_Delete_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *InviteRepository_Delete_Call {
+func (_c *InviteRepository_Delete_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) ([]model.Square, error)) *InviteRepository_Delete_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -177,6 +296,66 @@ func (_c *InviteRepository_GetAllByContestID_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// GetByID provides a mock function with given fields: ctx, contestID, id
+func (_m *InviteRepository) GetByID(ctx context.Context, contestID uuid.UUID, id uuid.UUID) (*model.ContestInvite, error) {
+	ret := _m.Called(ctx, contestID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *model.ContestInvite
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*model.ContestInvite, error)); ok {
+		return rf(ctx, contestID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *model.ContestInvite); ok {
+		r0 = rf(ctx, contestID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ContestInvite)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, contestID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InviteRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type InviteRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - id uuid.UUID
+func (_e *InviteRepository_Expecter) GetByID(ctx interface{}, contestID interface{}, id interface{}) *InviteRepository_GetByID_Call {
+	return &InviteRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, contestID, id)}
+}
+
+func (_c *InviteRepository_GetByID_Call) Run(run func(ctx context.Context, contestID uuid.UUID, id uuid.UUID)) *InviteRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *InviteRepository_GetByID_Call) Return(_a0 *model.ContestInvite, _a1 error) *InviteRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *InviteRepository_GetByID_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (*model.ContestInvite, error)) *InviteRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByToken provides a mock function with given fields: ctx, token
 func (_m *InviteRepository) GetByToken(ctx context.Context, token string) (*model.ContestInvite, error) {
 	ret := _m.Called(ctx, token)
@@ -236,17 +415,241 @@ func (_c *InviteRepository_GetByToken_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
-// RedeemInvite provides a mock function with given fields: ctx, inviteID, participant
-func (_m *InviteRepository) RedeemInvite(ctx context.Context, inviteID uuid.UUID, participant *model.ContestParticipant) error {
-	ret := _m.Called(ctx, inviteID, participant)
+// GetFunnelsByContestID provides a mock function with given fields: ctx, contestID
+func (_m *InviteRepository) GetFunnelsByContestID(ctx context.Context, contestID uuid.UUID) (map[uuid.UUID]model.InviteFunnel, error) {
+	ret := _m.Called(ctx, contestID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RedeemInvite")
+		panic("no return value specified for GetFunnelsByContestID")
+	}
+
+	var r0 map[uuid.UUID]model.InviteFunnel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (map[uuid.UUID]model.InviteFunnel, error)); ok {
+		return rf(ctx, contestID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) map[uuid.UUID]model.InviteFunnel); ok {
+		r0 = rf(ctx, contestID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[uuid.UUID]model.InviteFunnel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, contestID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InviteRepository_GetFunnelsByContestID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFunnelsByContestID'
+type InviteRepository_GetFunnelsByContestID_Call struct {
+	*mock.Call
+}
+
+// GetFunnelsByContestID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+func (_e *InviteRepository_Expecter) GetFunnelsByContestID(ctx interface{}, contestID interface{}) *InviteRepository_GetFunnelsByContestID_Call {
+	return &InviteRepository_GetFunnelsByContestID_Call{Call: _e.mock.On("GetFunnelsByContestID", ctx, contestID)}
+}
+
+func (_c *InviteRepository_GetFunnelsByContestID_Call) Run(run func(ctx context.Context, contestID uuid.UUID)) *InviteRepository_GetFunnelsByContestID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *InviteRepository_GetFunnelsByContestID_Call) Return(_a0 map[uuid.UUID]model.InviteFunnel, _a1 error) *InviteRepository_GetFunnelsByContestID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *InviteRepository_GetFunnelsByContestID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (map[uuid.UUID]model.InviteFunnel, error)) *InviteRepository_GetFunnelsByContestID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReservationsByContestID provides a mock function with given fields: ctx, contestID
+func (_m *InviteRepository) GetReservationsByContestID(ctx context.Context, contestID uuid.UUID) (map[uuid.UUID][]model.SquarePosition, error) {
+	ret := _m.Called(ctx, contestID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReservationsByContestID")
+	}
+
+	var r0 map[uuid.UUID][]model.SquarePosition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (map[uuid.UUID][]model.SquarePosition, error)); ok {
+		return rf(ctx, contestID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) map[uuid.UUID][]model.SquarePosition); ok {
+		r0 = rf(ctx, contestID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[uuid.UUID][]model.SquarePosition)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, contestID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InviteRepository_GetReservationsByContestID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReservationsByContestID'
+type InviteRepository_GetReservationsByContestID_Call struct {
+	*mock.Call
+}
+
+// GetReservationsByContestID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+func (_e *InviteRepository_Expecter) GetReservationsByContestID(ctx interface{}, contestID interface{}) *InviteRepository_GetReservationsByContestID_Call {
+	return &InviteRepository_GetReservationsByContestID_Call{Call: _e.mock.On("GetReservationsByContestID", ctx, contestID)}
+}
+
+func (_c *InviteRepository_GetReservationsByContestID_Call) Run(run func(ctx context.Context, contestID uuid.UUID)) *InviteRepository_GetReservationsByContestID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *InviteRepository_GetReservationsByContestID_Call) Return(_a0 map[uuid.UUID][]model.SquarePosition, _a1 error) *InviteRepository_GetReservationsByContestID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *InviteRepository_GetReservationsByContestID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (map[uuid.UUID][]model.SquarePosition, error)) *InviteRepository_GetReservationsByContestID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReservationsByInviteID provides a mock function with given fields: ctx, inviteID
+func (_m *InviteRepository) GetReservationsByInviteID(ctx context.Context, inviteID uuid.UUID) ([]model.SquarePosition, error) {
+	ret := _m.Called(ctx, inviteID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReservationsByInviteID")
+	}
+
+	var r0 []model.SquarePosition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]model.SquarePosition, error)); ok {
+		return rf(ctx, inviteID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []model.SquarePosition); ok {
+		r0 = rf(ctx, inviteID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.SquarePosition)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, inviteID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InviteRepository_GetReservationsByInviteID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReservationsByInviteID'
+type InviteRepository_GetReservationsByInviteID_Call struct {
+	*mock.Call
+}
+
+// GetReservationsByInviteID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - inviteID uuid.UUID
+func (_e *InviteRepository_Expecter) GetReservationsByInviteID(ctx interface{}, inviteID interface{}) *InviteRepository_GetReservationsByInviteID_Call {
+	return &InviteRepository_GetReservationsByInviteID_Call{Call: _e.mock.On("GetReservationsByInviteID", ctx, inviteID)}
+}
+
+func (_c *InviteRepository_GetReservationsByInviteID_Call) Run(run func(ctx context.Context, inviteID uuid.UUID)) *InviteRepository_GetReservationsByInviteID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *InviteRepository_GetReservationsByInviteID_Call) Return(_a0 []model.SquarePosition, _a1 error) *InviteRepository_GetReservationsByInviteID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *InviteRepository_GetReservationsByInviteID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]model.SquarePosition, error)) *InviteRepository_GetReservationsByInviteID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkOpened provides a mock function with given fields: ctx, id
+func (_m *InviteRepository) MarkOpened(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkOpened")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InviteRepository_MarkOpened_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkOpened'
+type InviteRepository_MarkOpened_Call struct {
+	*mock.Call
+}
+
+// MarkOpened is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *InviteRepository_Expecter) MarkOpened(ctx interface{}, id interface{}) *InviteRepository_MarkOpened_Call {
+	return &InviteRepository_MarkOpened_Call{Call: _e.mock.On("MarkOpened", ctx, id)}
+}
+
+func (_c *InviteRepository_MarkOpened_Call) Run(run func(ctx context.Context, id uuid.UUID)) *InviteRepository_MarkOpened_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *InviteRepository_MarkOpened_Call) Return(_a0 error) *InviteRepository_MarkOpened_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *InviteRepository_MarkOpened_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *InviteRepository_MarkOpened_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordView provides a mock function with given fields: ctx, invite, visitor
+func (_m *InviteRepository) RecordView(ctx context.Context, invite *model.ContestInvite, visitor string) error {
+	ret := _m.Called(ctx, invite, visitor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordView")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.ContestParticipant) error); ok {
-		r0 = rf(ctx, inviteID, participant)
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ContestInvite, string) error); ok {
+		r0 = rf(ctx, invite, visitor)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -254,6 +657,66 @@ func (_m *InviteRepository) RedeemInvite(ctx context.Context, inviteID uuid.UUID
 	return r0
 }
 
+// InviteRepository_RecordView_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordView'
+type InviteRepository_RecordView_Call struct {
+	*mock.Call
+}
+
+// RecordView is a helper method to define mock.On call
+//   - ctx context.Context
+//   - invite *model.ContestInvite
+//   - visitor string
+func (_e *InviteRepository_Expecter) RecordView(ctx interface{}, invite interface{}, visitor interface{}) *InviteRepository_RecordView_Call {
+	return &InviteRepository_RecordView_Call{Call: _e.mock.On("RecordView", ctx, invite, visitor)}
+}
+
+func (_c *InviteRepository_RecordView_Call) Run(run func(ctx context.Context, invite *model.ContestInvite, visitor string)) *InviteRepository_RecordView_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.ContestInvite), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *InviteRepository_RecordView_Call) Return(_a0 error) *InviteRepository_RecordView_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *InviteRepository_RecordView_Call) RunAndReturn(run func(context.Context, *model.ContestInvite, string) error) *InviteRepository_RecordView_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RedeemInvite provides a mock function with given fields: ctx, invite, participant, claim
+func (_m *InviteRepository) RedeemInvite(ctx context.Context, invite *model.ContestInvite, participant *model.ContestParticipant, claim *model.Square) ([]model.Square, error) {
+	ret := _m.Called(ctx, invite, participant, claim)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RedeemInvite")
+	}
+
+	var r0 []model.Square
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ContestInvite, *model.ContestParticipant, *model.Square) ([]model.Square, error)); ok {
+		return rf(ctx, invite, participant, claim)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ContestInvite, *model.ContestParticipant, *model.Square) []model.Square); ok {
+		r0 = rf(ctx, invite, participant, claim)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Square)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ContestInvite, *model.ContestParticipant, *model.Square) error); ok {
+		r1 = rf(ctx, invite, participant, claim)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // InviteRepository_RedeemInvite_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RedeemInvite'
 type InviteRepository_RedeemInvite_Call struct {
 	*mock.Call
@@ -261,25 +724,121 @@ type InviteRepository_RedeemInvite_Call struct {
 
 // RedeemInvite is a helper method to define mock.On call
 //   - ctx context.Context
-//   - inviteID uuid.UUID
+//   - invite *model.ContestInvite
 //   - participant *model.ContestParticipant
-func (_e *InviteRepository_Expecter) RedeemInvite(ctx interface{}, inviteID interface{}, participant interface{}) *InviteRepository_RedeemInvite_Call {
-	return &InviteRepository_RedeemInvite_Call{Call: _e.mock.On("RedeemInvite", ctx, inviteID, participant)}
+//   - claim *model.Square
+func (_e *InviteRepository_Expecter) RedeemInvite(ctx interface{}, invite interface{}, participant interface{}, claim interface{}) *InviteRepository_RedeemInvite_Call {
+	return &InviteRepository_RedeemInvite_Call{Call: _e.mock.On("RedeemInvite", ctx, invite, participant, claim)}
+}
+
+func (_c *InviteRepository_RedeemInvite_Call) Run(run func(ctx context.Context, invite *model.ContestInvite, participant *model.ContestParticipant, claim *model.Square)) *InviteRepository_RedeemInvite_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.ContestInvite), args[2].(*model.ContestParticipant), args[3].(*model.Square))
+	})
+	return _c
+}
+
+func (_c *InviteRepository_RedeemInvite_Call) Return(_a0 []model.Square, _a1 error) *InviteRepository_RedeemInvite_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *InviteRepository_RedeemInvite_Call) RunAndReturn(run func(context.Context, *model.ContestInvite, *model.ContestParticipant, *model.Square) ([]model.Square, error)) *InviteRepository_RedeemInvite_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, invite
+func (_m *InviteRepository) Update(ctx context.Context, invite *model.ContestInvite) error {
+	ret := _m.Called(ctx, invite)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ContestInvite) error); ok {
+		r0 = rf(ctx, invite)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InviteRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type InviteRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - invite *model.ContestInvite
+func (_e *InviteRepository_Expecter) Update(ctx interface{}, invite interface{}) *InviteRepository_Update_Call {
+	return &InviteRepository_Update_Call{Call: _e.mock.On("Update", ctx, invite)}
+}
+
+func (_c *InviteRepository_Update_Call) Run(run func(ctx context.Context, invite *model.ContestInvite)) *InviteRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.ContestInvite))
+	})
+	return _c
+}
+
+func (_c *InviteRepository_Update_Call) Return(_a0 error) *InviteRepository_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *InviteRepository_Update_Call) RunAndReturn(run func(context.Context, *model.ContestInvite) error) *InviteRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateEmailStatus provides a mock function with given fields: ctx, id, status
+func (_m *InviteRepository) UpdateEmailStatus(ctx context.Context, id uuid.UUID, status model.InviteEmailStatus) error {
+	ret := _m.Called(ctx, id, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEmailStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, model.InviteEmailStatus) error); ok {
+		r0 = rf(ctx, id, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InviteRepository_UpdateEmailStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEmailStatus'
+type InviteRepository_UpdateEmailStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateEmailStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - status model.InviteEmailStatus
+func (_e *InviteRepository_Expecter) UpdateEmailStatus(ctx interface{}, id interface{}, status interface{}) *InviteRepository_UpdateEmailStatus_Call {
+	return &InviteRepository_UpdateEmailStatus_Call{Call: _e.mock.On("UpdateEmailStatus", ctx, id, status)}
 }
 
-func (_c *InviteRepository_RedeemInvite_Call) Run(run func(ctx context.Context, inviteID uuid.UUID, participant *model.ContestParticipant)) *InviteRepository_RedeemInvite_Call {
+func (_c *InviteRepository_UpdateEmailStatus_Call) Run(run func(ctx context.Context, id uuid.UUID, status model.InviteEmailStatus)) *InviteRepository_UpdateEmailStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*model.ContestParticipant))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(model.InviteEmailStatus))
 	})
 	return _c
 }
 
-func (_c *InviteRepository_RedeemInvite_Call) Return(_a0 error) *InviteRepository_RedeemInvite_Call {
+func (_c *InviteRepository_UpdateEmailStatus_Call) Return(_a0 error) *InviteRepository_UpdateEmailStatus_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *InviteRepository_RedeemInvite_Call) RunAndReturn(run func(context.Context, uuid.UUID, *model.ContestParticipant) error) *InviteRepository_RedeemInvite_Call {
+func (_c *InviteRepository_UpdateEmailStatus_Call) RunAndReturn(run func(context.Context, uuid.UUID, model.InviteEmailStatus) error) *InviteRepository_UpdateEmailStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
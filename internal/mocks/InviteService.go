@@ -5,10 +5,9 @@ package mocks
 import (
 	context "context"
 
+	uuid "github.com/google/uuid"
 	model "github.com/maxmorhardt/squares-api/internal/model"
 	mock "github.com/stretchr/testify/mock"
-
-	uuid "github.com/google/uuid"
 )
 
 // InviteService is an autogenerated mock type for the InviteService type
@@ -24,6 +23,67 @@ func (_m *InviteService) EXPECT() *InviteService_Expecter {
 	return &InviteService_Expecter{mock: &_m.Mock}
 }
 
+// CreateEmailInvites provides a mock function with given fields: ctx, contestID, req, user
+func (_m *InviteService) CreateEmailInvites(ctx context.Context, contestID uuid.UUID, req *model.CreateEmailInvitesRequest, user string) ([]model.ContestInvite, error) {
+	ret := _m.Called(ctx, contestID, req, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEmailInvites")
+	}
+
+	var r0 []model.ContestInvite
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.CreateEmailInvitesRequest, string) ([]model.ContestInvite, error)); ok {
+		return rf(ctx, contestID, req, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.CreateEmailInvitesRequest, string) []model.ContestInvite); ok {
+		r0 = rf(ctx, contestID, req, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ContestInvite)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *model.CreateEmailInvitesRequest, string) error); ok {
+		r1 = rf(ctx, contestID, req, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InviteService_CreateEmailInvites_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEmailInvites'
+type InviteService_CreateEmailInvites_Call struct {
+	*mock.Call
+}
+
+// CreateEmailInvites is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - req *model.CreateEmailInvitesRequest
+//   - user string
+func (_e *InviteService_Expecter) CreateEmailInvites(ctx interface{}, contestID interface{}, req interface{}, user interface{}) *InviteService_CreateEmailInvites_Call {
+	return &InviteService_CreateEmailInvites_Call{Call: _e.mock.On("CreateEmailInvites", ctx, contestID, req, user)}
+}
+
+func (_c *InviteService_CreateEmailInvites_Call) Run(run func(ctx context.Context, contestID uuid.UUID, req *model.CreateEmailInvitesRequest, user string)) *InviteService_CreateEmailInvites_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*model.CreateEmailInvitesRequest), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *InviteService_CreateEmailInvites_Call) Return(_a0 []model.ContestInvite, _a1 error) *InviteService_CreateEmailInvites_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *InviteService_CreateEmailInvites_Call) RunAndReturn(run func(context.Context, uuid.UUID, *model.CreateEmailInvitesRequest, string) ([]model.ContestInvite, error)) *InviteService_CreateEmailInvites_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateInvite provides a mock function with given fields: ctx, contestID, req, user
 func (_m *InviteService) CreateInvite(ctx context.Context, contestID uuid.UUID, req *model.CreateInviteRequest, user string) (*model.ContestInvite, error) {
 	ret := _m.Called(ctx, contestID, req, user)
@@ -134,9 +194,9 @@ func (_c *InviteService_DeleteInvite_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
-// GetInvitePreview provides a mock function with given fields: ctx, token
-func (_m *InviteService) GetInvitePreview(ctx context.Context, token string) (*model.InvitePreviewResponse, error) {
-	ret := _m.Called(ctx, token)
+// GetInvitePreview provides a mock function with given fields: ctx, token, ipAddress, userAgent
+func (_m *InviteService) GetInvitePreview(ctx context.Context, token string, ipAddress string, userAgent string) (*model.InvitePreviewResponse, error) {
+	ret := _m.Called(ctx, token, ipAddress, userAgent)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetInvitePreview")
@@ -144,19 +204,19 @@ func (_m *InviteService) GetInvitePreview(ctx context.Context, token string) (*m
 
 	var r0 *model.InvitePreviewResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.InvitePreviewResponse, error)); ok {
-		return rf(ctx, token)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*model.InvitePreviewResponse, error)); ok {
+		return rf(ctx, token, ipAddress, userAgent)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *model.InvitePreviewResponse); ok {
-		r0 = rf(ctx, token)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *model.InvitePreviewResponse); ok {
+		r0 = rf(ctx, token, ipAddress, userAgent)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.InvitePreviewResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, token)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, token, ipAddress, userAgent)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -172,13 +232,15 @@ type InviteService_GetInvitePreview_Call struct {
 // GetInvitePreview is a helper method to define mock.On call
 //   - ctx context.Context
 //   - token string
-func (_e *InviteService_Expecter) GetInvitePreview(ctx interface{}, token interface{}) *InviteService_GetInvitePreview_Call {
-	return &InviteService_GetInvitePreview_Call{Call: _e.mock.On("GetInvitePreview", ctx, token)}
+//   - ipAddress string
+//   - userAgent string
+func (_e *InviteService_Expecter) GetInvitePreview(ctx interface{}, token interface{}, ipAddress interface{}, userAgent interface{}) *InviteService_GetInvitePreview_Call {
+	return &InviteService_GetInvitePreview_Call{Call: _e.mock.On("GetInvitePreview", ctx, token, ipAddress, userAgent)}
 }
 
-func (_c *InviteService_GetInvitePreview_Call) Run(run func(ctx context.Context, token string)) *InviteService_GetInvitePreview_Call {
+func (_c *InviteService_GetInvitePreview_Call) Run(run func(ctx context.Context, token string, ipAddress string, userAgent string)) *InviteService_GetInvitePreview_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
 	})
 	return _c
 }
@@ -188,7 +250,7 @@ func (_c *InviteService_GetInvitePreview_Call) Return(_a0 *model.InvitePreviewRe
 	return _c
 }
 
-func (_c *InviteService_GetInvitePreview_Call) RunAndReturn(run func(context.Context, string) (*model.InvitePreviewResponse, error)) *InviteService_GetInvitePreview_Call {
+func (_c *InviteService_GetInvitePreview_Call) RunAndReturn(run func(context.Context, string, string, string) (*model.InvitePreviewResponse, error)) *InviteService_GetInvitePreview_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -253,6 +315,53 @@ func (_c *InviteService_GetInvitesByContestID_Call) RunAndReturn(run func(contex
 	return _c
 }
 
+// MarkInviteOpened provides a mock function with given fields: ctx, token
+func (_m *InviteService) MarkInviteOpened(ctx context.Context, token string) error {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkInviteOpened")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InviteService_MarkInviteOpened_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkInviteOpened'
+type InviteService_MarkInviteOpened_Call struct {
+	*mock.Call
+}
+
+// MarkInviteOpened is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *InviteService_Expecter) MarkInviteOpened(ctx interface{}, token interface{}) *InviteService_MarkInviteOpened_Call {
+	return &InviteService_MarkInviteOpened_Call{Call: _e.mock.On("MarkInviteOpened", ctx, token)}
+}
+
+func (_c *InviteService_MarkInviteOpened_Call) Run(run func(ctx context.Context, token string)) *InviteService_MarkInviteOpened_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *InviteService_MarkInviteOpened_Call) Return(_a0 error) *InviteService_MarkInviteOpened_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *InviteService_MarkInviteOpened_Call) RunAndReturn(run func(context.Context, string) error) *InviteService_MarkInviteOpened_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RedeemInvite provides a mock function with given fields: ctx, token, user
 func (_m *InviteService) RedeemInvite(ctx context.Context, token string, user string) (*model.ContestParticipant, error) {
 	ret := _m.Called(ctx, token, user)
@@ -313,6 +422,68 @@ func (_c *InviteService_RedeemInvite_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// UpdateInvite provides a mock function with given fields: ctx, contestID, inviteID, req, user
+func (_m *InviteService) UpdateInvite(ctx context.Context, contestID uuid.UUID, inviteID uuid.UUID, req *model.UpdateInviteRequest, user string) (*model.ContestInvite, error) {
+	ret := _m.Called(ctx, contestID, inviteID, req, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateInvite")
+	}
+
+	var r0 *model.ContestInvite
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *model.UpdateInviteRequest, string) (*model.ContestInvite, error)); ok {
+		return rf(ctx, contestID, inviteID, req, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *model.UpdateInviteRequest, string) *model.ContestInvite); ok {
+		r0 = rf(ctx, contestID, inviteID, req, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ContestInvite)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, *model.UpdateInviteRequest, string) error); ok {
+		r1 = rf(ctx, contestID, inviteID, req, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InviteService_UpdateInvite_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateInvite'
+type InviteService_UpdateInvite_Call struct {
+	*mock.Call
+}
+
+// UpdateInvite is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - inviteID uuid.UUID
+//   - req *model.UpdateInviteRequest
+//   - user string
+func (_e *InviteService_Expecter) UpdateInvite(ctx interface{}, contestID interface{}, inviteID interface{}, req interface{}, user interface{}) *InviteService_UpdateInvite_Call {
+	return &InviteService_UpdateInvite_Call{Call: _e.mock.On("UpdateInvite", ctx, contestID, inviteID, req, user)}
+}
+
+func (_c *InviteService_UpdateInvite_Call) Run(run func(ctx context.Context, contestID uuid.UUID, inviteID uuid.UUID, req *model.UpdateInviteRequest, user string)) *InviteService_UpdateInvite_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(*model.UpdateInviteRequest), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *InviteService_UpdateInvite_Call) Return(_a0 *model.ContestInvite, _a1 error) *InviteService_UpdateInvite_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *InviteService_UpdateInvite_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, *model.UpdateInviteRequest, string) (*model.ContestInvite, error)) *InviteService_UpdateInvite_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewInviteService creates a new instance of InviteService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewInviteService(t interface {
@@ -0,0 +1,124 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// Mailer is an autogenerated mock type for the Mailer type
+type Mailer struct {
+	mock.Mock
+}
+
+type Mailer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Mailer) EXPECT() *Mailer_Expecter {
+	return &Mailer_Expecter{mock: &_m.Mock}
+}
+
+// From provides a mock function with no fields
+func (_m *Mailer) From() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for From")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Mailer_From_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'From'
+type Mailer_From_Call struct {
+	*mock.Call
+}
+
+// From is a helper method to define mock.On call
+func (_e *Mailer_Expecter) From() *Mailer_From_Call {
+	return &Mailer_From_Call{Call: _e.mock.On("From")}
+}
+
+func (_c *Mailer_From_Call) Run(run func()) *Mailer_From_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Mailer_From_Call) Return(_a0 string) *Mailer_From_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Mailer_From_Call) RunAndReturn(run func() string) *Mailer_From_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Send provides a mock function with given fields: to, msg
+func (_m *Mailer) Send(to []string, msg []byte) error {
+	ret := _m.Called(to, msg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]string, []byte) error); ok {
+		r0 = rf(to, msg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Mailer_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type Mailer_Send_Call struct {
+	*mock.Call
+}
+
+// Send is a helper method to define mock.On call
+//   - to []string
+//   - msg []byte
+func (_e *Mailer_Expecter) Send(to interface{}, msg interface{}) *Mailer_Send_Call {
+	return &Mailer_Send_Call{Call: _e.mock.On("Send", to, msg)}
+}
+
+func (_c *Mailer_Send_Call) Run(run func(to []string, msg []byte)) *Mailer_Send_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string), args[1].([]byte))
+	})
+	return _c
+}
+
+func (_c *Mailer_Send_Call) Return(_a0 error) *Mailer_Send_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Mailer_Send_Call) RunAndReturn(run func([]string, []byte) error) *Mailer_Send_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMailer creates a new instance of Mailer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMailer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Mailer {
+	mock := &Mailer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -3,10 +3,9 @@
 package mocks
 
 import (
+	uuid "github.com/google/uuid"
 	model "github.com/maxmorhardt/squares-api/internal/model"
 	mock "github.com/stretchr/testify/mock"
-
-	uuid "github.com/google/uuid"
 )
 
 // NatsService is an autogenerated mock type for the NatsService type
@@ -69,6 +68,102 @@ func (_c *NatsService_PublishContestDeleted_Call) RunAndReturn(run func(uuid.UUI
 	return _c
 }
 
+// PublishContestLocked provides a mock function with given fields: contestID, contest, message
+func (_m *NatsService) PublishContestLocked(contestID uuid.UUID, contest *model.Contest, message string) error {
+	ret := _m.Called(contestID, contest, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishContestLocked")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *model.Contest, string) error); ok {
+		r0 = rf(contestID, contest, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NatsService_PublishContestLocked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishContestLocked'
+type NatsService_PublishContestLocked_Call struct {
+	*mock.Call
+}
+
+// PublishContestLocked is a helper method to define mock.On call
+//   - contestID uuid.UUID
+//   - contest *model.Contest
+//   - message string
+func (_e *NatsService_Expecter) PublishContestLocked(contestID interface{}, contest interface{}, message interface{}) *NatsService_PublishContestLocked_Call {
+	return &NatsService_PublishContestLocked_Call{Call: _e.mock.On("PublishContestLocked", contestID, contest, message)}
+}
+
+func (_c *NatsService_PublishContestLocked_Call) Run(run func(contestID uuid.UUID, contest *model.Contest, message string)) *NatsService_PublishContestLocked_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(*model.Contest), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *NatsService_PublishContestLocked_Call) Return(_a0 error) *NatsService_PublishContestLocked_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NatsService_PublishContestLocked_Call) RunAndReturn(run func(uuid.UUID, *model.Contest, string) error) *NatsService_PublishContestLocked_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PublishContestRestored provides a mock function with given fields: contestID, updatedBy, contest
+func (_m *NatsService) PublishContestRestored(contestID uuid.UUID, updatedBy string, contest *model.Contest) error {
+	ret := _m.Called(contestID, updatedBy, contest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishContestRestored")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string, *model.Contest) error); ok {
+		r0 = rf(contestID, updatedBy, contest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NatsService_PublishContestRestored_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishContestRestored'
+type NatsService_PublishContestRestored_Call struct {
+	*mock.Call
+}
+
+// PublishContestRestored is a helper method to define mock.On call
+//   - contestID uuid.UUID
+//   - updatedBy string
+//   - contest *model.Contest
+func (_e *NatsService_Expecter) PublishContestRestored(contestID interface{}, updatedBy interface{}, contest interface{}) *NatsService_PublishContestRestored_Call {
+	return &NatsService_PublishContestRestored_Call{Call: _e.mock.On("PublishContestRestored", contestID, updatedBy, contest)}
+}
+
+func (_c *NatsService_PublishContestRestored_Call) Run(run func(contestID uuid.UUID, updatedBy string, contest *model.Contest)) *NatsService_PublishContestRestored_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(string), args[2].(*model.Contest))
+	})
+	return _c
+}
+
+func (_c *NatsService_PublishContestRestored_Call) Return(_a0 error) *NatsService_PublishContestRestored_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NatsService_PublishContestRestored_Call) RunAndReturn(run func(uuid.UUID, string, *model.Contest) error) *NatsService_PublishContestRestored_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PublishContestUpdate provides a mock function with given fields: contestID, updatedBy, contest
 func (_m *NatsService) PublishContestUpdate(contestID uuid.UUID, updatedBy string, contest *model.Contest) error {
 	ret := _m.Called(contestID, updatedBy, contest)
@@ -164,6 +259,54 @@ func (_c *NatsService_PublishParticipantAdded_Call) RunAndReturn(run func(uuid.U
 	return _c
 }
 
+// PublishParticipantBanned provides a mock function with given fields: contestID, updatedBy, userID
+func (_m *NatsService) PublishParticipantBanned(contestID uuid.UUID, updatedBy string, userID string) error {
+	ret := _m.Called(contestID, updatedBy, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishParticipantBanned")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string, string) error); ok {
+		r0 = rf(contestID, updatedBy, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NatsService_PublishParticipantBanned_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishParticipantBanned'
+type NatsService_PublishParticipantBanned_Call struct {
+	*mock.Call
+}
+
+// PublishParticipantBanned is a helper method to define mock.On call
+//   - contestID uuid.UUID
+//   - updatedBy string
+//   - userID string
+func (_e *NatsService_Expecter) PublishParticipantBanned(contestID interface{}, updatedBy interface{}, userID interface{}) *NatsService_PublishParticipantBanned_Call {
+	return &NatsService_PublishParticipantBanned_Call{Call: _e.mock.On("PublishParticipantBanned", contestID, updatedBy, userID)}
+}
+
+func (_c *NatsService_PublishParticipantBanned_Call) Run(run func(contestID uuid.UUID, updatedBy string, userID string)) *NatsService_PublishParticipantBanned_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *NatsService_PublishParticipantBanned_Call) Return(_a0 error) *NatsService_PublishParticipantBanned_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NatsService_PublishParticipantBanned_Call) RunAndReturn(run func(uuid.UUID, string, string) error) *NatsService_PublishParticipantBanned_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PublishParticipantRemoved provides a mock function with given fields: contestID, updatedBy, participant
 func (_m *NatsService) PublishParticipantRemoved(contestID uuid.UUID, updatedBy string, participant *model.ContestParticipant) error {
 	ret := _m.Called(contestID, updatedBy, participant)
@@ -309,6 +452,54 @@ func (_c *NatsService_PublishQuarterResultRollback_Call) RunAndReturn(run func(u
 	return _c
 }
 
+// PublishSpectatorRevoked provides a mock function with given fields: contestID, tokenID, updatedBy
+func (_m *NatsService) PublishSpectatorRevoked(contestID uuid.UUID, tokenID uuid.UUID, updatedBy string) error {
+	ret := _m.Called(contestID, tokenID, updatedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishSpectatorRevoked")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, string) error); ok {
+		r0 = rf(contestID, tokenID, updatedBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NatsService_PublishSpectatorRevoked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishSpectatorRevoked'
+type NatsService_PublishSpectatorRevoked_Call struct {
+	*mock.Call
+}
+
+// PublishSpectatorRevoked is a helper method to define mock.On call
+//   - contestID uuid.UUID
+//   - tokenID uuid.UUID
+//   - updatedBy string
+func (_e *NatsService_Expecter) PublishSpectatorRevoked(contestID interface{}, tokenID interface{}, updatedBy interface{}) *NatsService_PublishSpectatorRevoked_Call {
+	return &NatsService_PublishSpectatorRevoked_Call{Call: _e.mock.On("PublishSpectatorRevoked", contestID, tokenID, updatedBy)}
+}
+
+func (_c *NatsService_PublishSpectatorRevoked_Call) Run(run func(contestID uuid.UUID, tokenID uuid.UUID, updatedBy string)) *NatsService_PublishSpectatorRevoked_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *NatsService_PublishSpectatorRevoked_Call) Return(_a0 error) *NatsService_PublishSpectatorRevoked_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NatsService_PublishSpectatorRevoked_Call) RunAndReturn(run func(uuid.UUID, uuid.UUID, string) error) *NatsService_PublishSpectatorRevoked_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PublishSquareUpdate provides a mock function with given fields: contestID, updatedBy, square
 func (_m *NatsService) PublishSquareUpdate(contestID uuid.UUID, updatedBy string, square *model.Square) error {
 	ret := _m.Called(contestID, updatedBy, square)
@@ -357,6 +548,54 @@ func (_c *NatsService_PublishSquareUpdate_Call) RunAndReturn(run func(uuid.UUID,
 	return _c
 }
 
+// PublishSquaresUpdate provides a mock function with given fields: contestID, updatedBy, squares
+func (_m *NatsService) PublishSquaresUpdate(contestID uuid.UUID, updatedBy string, squares []model.Square) error {
+	ret := _m.Called(contestID, updatedBy, squares)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishSquaresUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string, []model.Square) error); ok {
+		r0 = rf(contestID, updatedBy, squares)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NatsService_PublishSquaresUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishSquaresUpdate'
+type NatsService_PublishSquaresUpdate_Call struct {
+	*mock.Call
+}
+
+// PublishSquaresUpdate is a helper method to define mock.On call
+//   - contestID uuid.UUID
+//   - updatedBy string
+//   - squares []model.Square
+func (_e *NatsService_Expecter) PublishSquaresUpdate(contestID interface{}, updatedBy interface{}, squares interface{}) *NatsService_PublishSquaresUpdate_Call {
+	return &NatsService_PublishSquaresUpdate_Call{Call: _e.mock.On("PublishSquaresUpdate", contestID, updatedBy, squares)}
+}
+
+func (_c *NatsService_PublishSquaresUpdate_Call) Run(run func(contestID uuid.UUID, updatedBy string, squares []model.Square)) *NatsService_PublishSquaresUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID), args[1].(string), args[2].([]model.Square))
+	})
+	return _c
+}
+
+func (_c *NatsService_PublishSquaresUpdate_Call) Return(_a0 error) *NatsService_PublishSquaresUpdate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NatsService_PublishSquaresUpdate_Call) RunAndReturn(run func(uuid.UUID, string, []model.Square) error) *NatsService_PublishSquaresUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewNatsService creates a new instance of NatsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewNatsService(t interface {
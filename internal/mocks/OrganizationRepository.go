@@ -0,0 +1,522 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	uuid "github.com/google/uuid"
+	model "github.com/maxmorhardt/squares-api/internal/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OrganizationRepository is an autogenerated mock type for the OrganizationRepository type
+type OrganizationRepository struct {
+	mock.Mock
+}
+
+type OrganizationRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OrganizationRepository) EXPECT() *OrganizationRepository_Expecter {
+	return &OrganizationRepository_Expecter{mock: &_m.Mock}
+}
+
+// AddMember provides a mock function with given fields: ctx, member
+func (_m *OrganizationRepository) AddMember(ctx context.Context, member *model.OrganizationMember) error {
+	ret := _m.Called(ctx, member)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.OrganizationMember) error); ok {
+		r0 = rf(ctx, member)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OrganizationRepository_AddMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddMember'
+type OrganizationRepository_AddMember_Call struct {
+	*mock.Call
+}
+
+// AddMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - member *model.OrganizationMember
+func (_e *OrganizationRepository_Expecter) AddMember(ctx interface{}, member interface{}) *OrganizationRepository_AddMember_Call {
+	return &OrganizationRepository_AddMember_Call{Call: _e.mock.On("AddMember", ctx, member)}
+}
+
+func (_c *OrganizationRepository_AddMember_Call) Run(run func(ctx context.Context, member *model.OrganizationMember)) *OrganizationRepository_AddMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.OrganizationMember))
+	})
+	return _c
+}
+
+func (_c *OrganizationRepository_AddMember_Call) Return(_a0 error) *OrganizationRepository_AddMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OrganizationRepository_AddMember_Call) RunAndReturn(run func(context.Context, *model.OrganizationMember) error) *OrganizationRepository_AddMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountAdmins provides a mock function with given fields: ctx, orgID
+func (_m *OrganizationRepository) CountAdmins(ctx context.Context, orgID uuid.UUID) (int, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountAdmins")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OrganizationRepository_CountAdmins_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountAdmins'
+type OrganizationRepository_CountAdmins_Call struct {
+	*mock.Call
+}
+
+// CountAdmins is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID uuid.UUID
+func (_e *OrganizationRepository_Expecter) CountAdmins(ctx interface{}, orgID interface{}) *OrganizationRepository_CountAdmins_Call {
+	return &OrganizationRepository_CountAdmins_Call{Call: _e.mock.On("CountAdmins", ctx, orgID)}
+}
+
+func (_c *OrganizationRepository_CountAdmins_Call) Run(run func(ctx context.Context, orgID uuid.UUID)) *OrganizationRepository_CountAdmins_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *OrganizationRepository_CountAdmins_Call) Return(_a0 int, _a1 error) *OrganizationRepository_CountAdmins_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OrganizationRepository_CountAdmins_Call) RunAndReturn(run func(context.Context, uuid.UUID) (int, error)) *OrganizationRepository_CountAdmins_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, org, creator
+func (_m *OrganizationRepository) Create(ctx context.Context, org *model.Organization, creator *model.OrganizationMember) error {
+	ret := _m.Called(ctx, org, creator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Organization, *model.OrganizationMember) error); ok {
+		r0 = rf(ctx, org, creator)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OrganizationRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type OrganizationRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - org *model.Organization
+//   - creator *model.OrganizationMember
+func (_e *OrganizationRepository_Expecter) Create(ctx interface{}, org interface{}, creator interface{}) *OrganizationRepository_Create_Call {
+	return &OrganizationRepository_Create_Call{Call: _e.mock.On("Create", ctx, org, creator)}
+}
+
+func (_c *OrganizationRepository_Create_Call) Run(run func(ctx context.Context, org *model.Organization, creator *model.OrganizationMember)) *OrganizationRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Organization), args[2].(*model.OrganizationMember))
+	})
+	return _c
+}
+
+func (_c *OrganizationRepository_Create_Call) Return(_a0 error) *OrganizationRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OrganizationRepository_Create_Call) RunAndReturn(run func(context.Context, *model.Organization, *model.OrganizationMember) error) *OrganizationRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllByUserID provides a mock function with given fields: ctx, userID
+func (_m *OrganizationRepository) GetAllByUserID(ctx context.Context, userID string) ([]model.Organization, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllByUserID")
+	}
+
+	var r0 []model.Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.Organization, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.Organization); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Organization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OrganizationRepository_GetAllByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllByUserID'
+type OrganizationRepository_GetAllByUserID_Call struct {
+	*mock.Call
+}
+
+// GetAllByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *OrganizationRepository_Expecter) GetAllByUserID(ctx interface{}, userID interface{}) *OrganizationRepository_GetAllByUserID_Call {
+	return &OrganizationRepository_GetAllByUserID_Call{Call: _e.mock.On("GetAllByUserID", ctx, userID)}
+}
+
+func (_c *OrganizationRepository_GetAllByUserID_Call) Run(run func(ctx context.Context, userID string)) *OrganizationRepository_GetAllByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *OrganizationRepository_GetAllByUserID_Call) Return(_a0 []model.Organization, _a1 error) *OrganizationRepository_GetAllByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OrganizationRepository_GetAllByUserID_Call) RunAndReturn(run func(context.Context, string) ([]model.Organization, error)) *OrganizationRepository_GetAllByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *OrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.Organization, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *model.Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.Organization, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.Organization); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Organization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OrganizationRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type OrganizationRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *OrganizationRepository_Expecter) GetByID(ctx interface{}, id interface{}) *OrganizationRepository_GetByID_Call {
+	return &OrganizationRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *OrganizationRepository_GetByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *OrganizationRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *OrganizationRepository_GetByID_Call) Return(_a0 *model.Organization, _a1 error) *OrganizationRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OrganizationRepository_GetByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*model.Organization, error)) *OrganizationRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMember provides a mock function with given fields: ctx, orgID, userID
+func (_m *OrganizationRepository) GetMember(ctx context.Context, orgID uuid.UUID, userID string) (*model.OrganizationMember, error) {
+	ret := _m.Called(ctx, orgID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMember")
+	}
+
+	var r0 *model.OrganizationMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*model.OrganizationMember, error)); ok {
+		return rf(ctx, orgID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *model.OrganizationMember); ok {
+		r0 = rf(ctx, orgID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OrganizationMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, orgID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OrganizationRepository_GetMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMember'
+type OrganizationRepository_GetMember_Call struct {
+	*mock.Call
+}
+
+// GetMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID uuid.UUID
+//   - userID string
+func (_e *OrganizationRepository_Expecter) GetMember(ctx interface{}, orgID interface{}, userID interface{}) *OrganizationRepository_GetMember_Call {
+	return &OrganizationRepository_GetMember_Call{Call: _e.mock.On("GetMember", ctx, orgID, userID)}
+}
+
+func (_c *OrganizationRepository_GetMember_Call) Run(run func(ctx context.Context, orgID uuid.UUID, userID string)) *OrganizationRepository_GetMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *OrganizationRepository_GetMember_Call) Return(_a0 *model.OrganizationMember, _a1 error) *OrganizationRepository_GetMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OrganizationRepository_GetMember_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) (*model.OrganizationMember, error)) *OrganizationRepository_GetMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMembers provides a mock function with given fields: ctx, orgID
+func (_m *OrganizationRepository) GetMembers(ctx context.Context, orgID uuid.UUID) ([]model.OrganizationMember, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMembers")
+	}
+
+	var r0 []model.OrganizationMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]model.OrganizationMember, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []model.OrganizationMember); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.OrganizationMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OrganizationRepository_GetMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMembers'
+type OrganizationRepository_GetMembers_Call struct {
+	*mock.Call
+}
+
+// GetMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID uuid.UUID
+func (_e *OrganizationRepository_Expecter) GetMembers(ctx interface{}, orgID interface{}) *OrganizationRepository_GetMembers_Call {
+	return &OrganizationRepository_GetMembers_Call{Call: _e.mock.On("GetMembers", ctx, orgID)}
+}
+
+func (_c *OrganizationRepository_GetMembers_Call) Run(run func(ctx context.Context, orgID uuid.UUID)) *OrganizationRepository_GetMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *OrganizationRepository_GetMembers_Call) Return(_a0 []model.OrganizationMember, _a1 error) *OrganizationRepository_GetMembers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OrganizationRepository_GetMembers_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]model.OrganizationMember, error)) *OrganizationRepository_GetMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveMember provides a mock function with given fields: ctx, orgID, userID
+func (_m *OrganizationRepository) RemoveMember(ctx context.Context, orgID uuid.UUID, userID string) error {
+	ret := _m.Called(ctx, orgID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, orgID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OrganizationRepository_RemoveMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveMember'
+type OrganizationRepository_RemoveMember_Call struct {
+	*mock.Call
+}
+
+// RemoveMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID uuid.UUID
+//   - userID string
+func (_e *OrganizationRepository_Expecter) RemoveMember(ctx interface{}, orgID interface{}, userID interface{}) *OrganizationRepository_RemoveMember_Call {
+	return &OrganizationRepository_RemoveMember_Call{Call: _e.mock.On("RemoveMember", ctx, orgID, userID)}
+}
+
+func (_c *OrganizationRepository_RemoveMember_Call) Run(run func(ctx context.Context, orgID uuid.UUID, userID string)) *OrganizationRepository_RemoveMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *OrganizationRepository_RemoveMember_Call) Return(_a0 error) *OrganizationRepository_RemoveMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OrganizationRepository_RemoveMember_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) error) *OrganizationRepository_RemoveMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateMember provides a mock function with given fields: ctx, member
+func (_m *OrganizationRepository) UpdateMember(ctx context.Context, member *model.OrganizationMember) error {
+	ret := _m.Called(ctx, member)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.OrganizationMember) error); ok {
+		r0 = rf(ctx, member)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OrganizationRepository_UpdateMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMember'
+type OrganizationRepository_UpdateMember_Call struct {
+	*mock.Call
+}
+
+// UpdateMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - member *model.OrganizationMember
+func (_e *OrganizationRepository_Expecter) UpdateMember(ctx interface{}, member interface{}) *OrganizationRepository_UpdateMember_Call {
+	return &OrganizationRepository_UpdateMember_Call{Call: _e.mock.On("UpdateMember", ctx, member)}
+}
+
+func (_c *OrganizationRepository_UpdateMember_Call) Run(run func(ctx context.Context, member *model.OrganizationMember)) *OrganizationRepository_UpdateMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.OrganizationMember))
+	})
+	return _c
+}
+
+func (_c *OrganizationRepository_UpdateMember_Call) Return(_a0 error) *OrganizationRepository_UpdateMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OrganizationRepository_UpdateMember_Call) RunAndReturn(run func(context.Context, *model.OrganizationMember) error) *OrganizationRepository_UpdateMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOrganizationRepository creates a new instance of OrganizationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOrganizationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OrganizationRepository {
+	mock := &OrganizationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,389 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	uuid "github.com/google/uuid"
+	model "github.com/maxmorhardt/squares-api/internal/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OrganizationService is an autogenerated mock type for the OrganizationService type
+type OrganizationService struct {
+	mock.Mock
+}
+
+type OrganizationService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OrganizationService) EXPECT() *OrganizationService_Expecter {
+	return &OrganizationService_Expecter{mock: &_m.Mock}
+}
+
+// AddMember provides a mock function with given fields: ctx, orgID, req, user
+func (_m *OrganizationService) AddMember(ctx context.Context, orgID uuid.UUID, req *model.AddOrgMemberRequest, user string) (*model.OrganizationMember, error) {
+	ret := _m.Called(ctx, orgID, req, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMember")
+	}
+
+	var r0 *model.OrganizationMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.AddOrgMemberRequest, string) (*model.OrganizationMember, error)); ok {
+		return rf(ctx, orgID, req, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.AddOrgMemberRequest, string) *model.OrganizationMember); ok {
+		r0 = rf(ctx, orgID, req, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OrganizationMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *model.AddOrgMemberRequest, string) error); ok {
+		r1 = rf(ctx, orgID, req, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OrganizationService_AddMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddMember'
+type OrganizationService_AddMember_Call struct {
+	*mock.Call
+}
+
+// AddMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID uuid.UUID
+//   - req *model.AddOrgMemberRequest
+//   - user string
+func (_e *OrganizationService_Expecter) AddMember(ctx interface{}, orgID interface{}, req interface{}, user interface{}) *OrganizationService_AddMember_Call {
+	return &OrganizationService_AddMember_Call{Call: _e.mock.On("AddMember", ctx, orgID, req, user)}
+}
+
+func (_c *OrganizationService_AddMember_Call) Run(run func(ctx context.Context, orgID uuid.UUID, req *model.AddOrgMemberRequest, user string)) *OrganizationService_AddMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*model.AddOrgMemberRequest), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *OrganizationService_AddMember_Call) Return(_a0 *model.OrganizationMember, _a1 error) *OrganizationService_AddMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OrganizationService_AddMember_Call) RunAndReturn(run func(context.Context, uuid.UUID, *model.AddOrgMemberRequest, string) (*model.OrganizationMember, error)) *OrganizationService_AddMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrganization provides a mock function with given fields: ctx, req, user
+func (_m *OrganizationService) CreateOrganization(ctx context.Context, req *model.CreateOrganizationRequest, user string) (*model.Organization, error) {
+	ret := _m.Called(ctx, req, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrganization")
+	}
+
+	var r0 *model.Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.CreateOrganizationRequest, string) (*model.Organization, error)); ok {
+		return rf(ctx, req, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.CreateOrganizationRequest, string) *model.Organization); ok {
+		r0 = rf(ctx, req, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Organization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.CreateOrganizationRequest, string) error); ok {
+		r1 = rf(ctx, req, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OrganizationService_CreateOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrganization'
+type OrganizationService_CreateOrganization_Call struct {
+	*mock.Call
+}
+
+// CreateOrganization is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *model.CreateOrganizationRequest
+//   - user string
+func (_e *OrganizationService_Expecter) CreateOrganization(ctx interface{}, req interface{}, user interface{}) *OrganizationService_CreateOrganization_Call {
+	return &OrganizationService_CreateOrganization_Call{Call: _e.mock.On("CreateOrganization", ctx, req, user)}
+}
+
+func (_c *OrganizationService_CreateOrganization_Call) Run(run func(ctx context.Context, req *model.CreateOrganizationRequest, user string)) *OrganizationService_CreateOrganization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.CreateOrganizationRequest), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *OrganizationService_CreateOrganization_Call) Return(_a0 *model.Organization, _a1 error) *OrganizationService_CreateOrganization_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OrganizationService_CreateOrganization_Call) RunAndReturn(run func(context.Context, *model.CreateOrganizationRequest, string) (*model.Organization, error)) *OrganizationService_CreateOrganization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMembers provides a mock function with given fields: ctx, orgID, user
+func (_m *OrganizationService) GetMembers(ctx context.Context, orgID uuid.UUID, user string) ([]model.OrganizationMember, error) {
+	ret := _m.Called(ctx, orgID, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMembers")
+	}
+
+	var r0 []model.OrganizationMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) ([]model.OrganizationMember, error)); ok {
+		return rf(ctx, orgID, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) []model.OrganizationMember); ok {
+		r0 = rf(ctx, orgID, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.OrganizationMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, orgID, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OrganizationService_GetMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMembers'
+type OrganizationService_GetMembers_Call struct {
+	*mock.Call
+}
+
+// GetMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID uuid.UUID
+//   - user string
+func (_e *OrganizationService_Expecter) GetMembers(ctx interface{}, orgID interface{}, user interface{}) *OrganizationService_GetMembers_Call {
+	return &OrganizationService_GetMembers_Call{Call: _e.mock.On("GetMembers", ctx, orgID, user)}
+}
+
+func (_c *OrganizationService_GetMembers_Call) Run(run func(ctx context.Context, orgID uuid.UUID, user string)) *OrganizationService_GetMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *OrganizationService_GetMembers_Call) Return(_a0 []model.OrganizationMember, _a1 error) *OrganizationService_GetMembers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OrganizationService_GetMembers_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) ([]model.OrganizationMember, error)) *OrganizationService_GetMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMyOrganizations provides a mock function with given fields: ctx, user
+func (_m *OrganizationService) GetMyOrganizations(ctx context.Context, user string) ([]model.Organization, error) {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMyOrganizations")
+	}
+
+	var r0 []model.Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.Organization, error)); ok {
+		return rf(ctx, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.Organization); ok {
+		r0 = rf(ctx, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Organization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OrganizationService_GetMyOrganizations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMyOrganizations'
+type OrganizationService_GetMyOrganizations_Call struct {
+	*mock.Call
+}
+
+// GetMyOrganizations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user string
+func (_e *OrganizationService_Expecter) GetMyOrganizations(ctx interface{}, user interface{}) *OrganizationService_GetMyOrganizations_Call {
+	return &OrganizationService_GetMyOrganizations_Call{Call: _e.mock.On("GetMyOrganizations", ctx, user)}
+}
+
+func (_c *OrganizationService_GetMyOrganizations_Call) Run(run func(ctx context.Context, user string)) *OrganizationService_GetMyOrganizations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *OrganizationService_GetMyOrganizations_Call) Return(_a0 []model.Organization, _a1 error) *OrganizationService_GetMyOrganizations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OrganizationService_GetMyOrganizations_Call) RunAndReturn(run func(context.Context, string) ([]model.Organization, error)) *OrganizationService_GetMyOrganizations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveMember provides a mock function with given fields: ctx, orgID, targetUserID, user
+func (_m *OrganizationService) RemoveMember(ctx context.Context, orgID uuid.UUID, targetUserID string, user string) error {
+	ret := _m.Called(ctx, orgID, targetUserID, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) error); ok {
+		r0 = rf(ctx, orgID, targetUserID, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OrganizationService_RemoveMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveMember'
+type OrganizationService_RemoveMember_Call struct {
+	*mock.Call
+}
+
+// RemoveMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID uuid.UUID
+//   - targetUserID string
+//   - user string
+func (_e *OrganizationService_Expecter) RemoveMember(ctx interface{}, orgID interface{}, targetUserID interface{}, user interface{}) *OrganizationService_RemoveMember_Call {
+	return &OrganizationService_RemoveMember_Call{Call: _e.mock.On("RemoveMember", ctx, orgID, targetUserID, user)}
+}
+
+func (_c *OrganizationService_RemoveMember_Call) Run(run func(ctx context.Context, orgID uuid.UUID, targetUserID string, user string)) *OrganizationService_RemoveMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *OrganizationService_RemoveMember_Call) Return(_a0 error) *OrganizationService_RemoveMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OrganizationService_RemoveMember_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, string) error) *OrganizationService_RemoveMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateMember provides a mock function with given fields: ctx, orgID, targetUserID, req, user
+func (_m *OrganizationService) UpdateMember(ctx context.Context, orgID uuid.UUID, targetUserID string, req *model.UpdateOrgMemberRequest, user string) (*model.OrganizationMember, error) {
+	ret := _m.Called(ctx, orgID, targetUserID, req, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMember")
+	}
+
+	var r0 *model.OrganizationMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, *model.UpdateOrgMemberRequest, string) (*model.OrganizationMember, error)); ok {
+		return rf(ctx, orgID, targetUserID, req, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, *model.UpdateOrgMemberRequest, string) *model.OrganizationMember); ok {
+		r0 = rf(ctx, orgID, targetUserID, req, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OrganizationMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, *model.UpdateOrgMemberRequest, string) error); ok {
+		r1 = rf(ctx, orgID, targetUserID, req, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OrganizationService_UpdateMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMember'
+type OrganizationService_UpdateMember_Call struct {
+	*mock.Call
+}
+
+// UpdateMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID uuid.UUID
+//   - targetUserID string
+//   - req *model.UpdateOrgMemberRequest
+//   - user string
+func (_e *OrganizationService_Expecter) UpdateMember(ctx interface{}, orgID interface{}, targetUserID interface{}, req interface{}, user interface{}) *OrganizationService_UpdateMember_Call {
+	return &OrganizationService_UpdateMember_Call{Call: _e.mock.On("UpdateMember", ctx, orgID, targetUserID, req, user)}
+}
+
+func (_c *OrganizationService_UpdateMember_Call) Run(run func(ctx context.Context, orgID uuid.UUID, targetUserID string, req *model.UpdateOrgMemberRequest, user string)) *OrganizationService_UpdateMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(*model.UpdateOrgMemberRequest), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *OrganizationService_UpdateMember_Call) Return(_a0 *model.OrganizationMember, _a1 error) *OrganizationService_UpdateMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OrganizationService_UpdateMember_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, *model.UpdateOrgMemberRequest, string) (*model.OrganizationMember, error)) *OrganizationService_UpdateMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOrganizationService creates a new instance of OrganizationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOrganizationService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OrganizationService {
+	mock := &OrganizationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,161 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	io "io"
+
+	uuid "github.com/google/uuid"
+	model "github.com/maxmorhardt/squares-api/internal/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ParticipantImportService is an autogenerated mock type for the ParticipantImportService type
+type ParticipantImportService struct {
+	mock.Mock
+}
+
+type ParticipantImportService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ParticipantImportService) EXPECT() *ParticipantImportService_Expecter {
+	return &ParticipantImportService_Expecter{mock: &_m.Mock}
+}
+
+// ApplyImport provides a mock function with given fields: ctx, contestID, file, user
+func (_m *ParticipantImportService) ApplyImport(ctx context.Context, contestID uuid.UUID, file io.Reader, user string) (*model.ParticipantImportPreview, error) {
+	ret := _m.Called(ctx, contestID, file, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyImport")
+	}
+
+	var r0 *model.ParticipantImportPreview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, io.Reader, string) (*model.ParticipantImportPreview, error)); ok {
+		return rf(ctx, contestID, file, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, io.Reader, string) *model.ParticipantImportPreview); ok {
+		r0 = rf(ctx, contestID, file, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ParticipantImportPreview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, io.Reader, string) error); ok {
+		r1 = rf(ctx, contestID, file, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParticipantImportService_ApplyImport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyImport'
+type ParticipantImportService_ApplyImport_Call struct {
+	*mock.Call
+}
+
+// ApplyImport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - file io.Reader
+//   - user string
+func (_e *ParticipantImportService_Expecter) ApplyImport(ctx interface{}, contestID interface{}, file interface{}, user interface{}) *ParticipantImportService_ApplyImport_Call {
+	return &ParticipantImportService_ApplyImport_Call{Call: _e.mock.On("ApplyImport", ctx, contestID, file, user)}
+}
+
+func (_c *ParticipantImportService_ApplyImport_Call) Run(run func(ctx context.Context, contestID uuid.UUID, file io.Reader, user string)) *ParticipantImportService_ApplyImport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(io.Reader), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *ParticipantImportService_ApplyImport_Call) Return(_a0 *model.ParticipantImportPreview, _a1 error) *ParticipantImportService_ApplyImport_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParticipantImportService_ApplyImport_Call) RunAndReturn(run func(context.Context, uuid.UUID, io.Reader, string) (*model.ParticipantImportPreview, error)) *ParticipantImportService_ApplyImport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PreviewImport provides a mock function with given fields: ctx, contestID, file, user
+func (_m *ParticipantImportService) PreviewImport(ctx context.Context, contestID uuid.UUID, file io.Reader, user string) (*model.ParticipantImportPreview, error) {
+	ret := _m.Called(ctx, contestID, file, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewImport")
+	}
+
+	var r0 *model.ParticipantImportPreview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, io.Reader, string) (*model.ParticipantImportPreview, error)); ok {
+		return rf(ctx, contestID, file, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, io.Reader, string) *model.ParticipantImportPreview); ok {
+		r0 = rf(ctx, contestID, file, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ParticipantImportPreview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, io.Reader, string) error); ok {
+		r1 = rf(ctx, contestID, file, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParticipantImportService_PreviewImport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PreviewImport'
+type ParticipantImportService_PreviewImport_Call struct {
+	*mock.Call
+}
+
+// PreviewImport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - file io.Reader
+//   - user string
+func (_e *ParticipantImportService_Expecter) PreviewImport(ctx interface{}, contestID interface{}, file interface{}, user interface{}) *ParticipantImportService_PreviewImport_Call {
+	return &ParticipantImportService_PreviewImport_Call{Call: _e.mock.On("PreviewImport", ctx, contestID, file, user)}
+}
+
+func (_c *ParticipantImportService_PreviewImport_Call) Run(run func(ctx context.Context, contestID uuid.UUID, file io.Reader, user string)) *ParticipantImportService_PreviewImport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(io.Reader), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *ParticipantImportService_PreviewImport_Call) Return(_a0 *model.ParticipantImportPreview, _a1 error) *ParticipantImportService_PreviewImport_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParticipantImportService_PreviewImport_Call) RunAndReturn(run func(context.Context, uuid.UUID, io.Reader, string) (*model.ParticipantImportPreview, error)) *ParticipantImportService_PreviewImport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewParticipantImportService creates a new instance of ParticipantImportService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewParticipantImportService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ParticipantImportService {
+	mock := &ParticipantImportService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
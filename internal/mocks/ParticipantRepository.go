@@ -5,10 +5,9 @@ package mocks
 import (
 	context "context"
 
+	uuid "github.com/google/uuid"
 	model "github.com/maxmorhardt/squares-api/internal/model"
 	mock "github.com/stretchr/testify/mock"
-
-	uuid "github.com/google/uuid"
 )
 
 // ParticipantRepository is an autogenerated mock type for the ParticipantRepository type
@@ -24,6 +23,66 @@ func (_m *ParticipantRepository) EXPECT() *ParticipantRepository_Expecter {
 	return &ParticipantRepository_Expecter{mock: &_m.Mock}
 }
 
+// Ban provides a mock function with given fields: ctx, ban, squares
+func (_m *ParticipantRepository) Ban(ctx context.Context, ban *model.ContestBan, squares string) ([]model.Square, error) {
+	ret := _m.Called(ctx, ban, squares)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ban")
+	}
+
+	var r0 []model.Square
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ContestBan, string) ([]model.Square, error)); ok {
+		return rf(ctx, ban, squares)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ContestBan, string) []model.Square); ok {
+		r0 = rf(ctx, ban, squares)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Square)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ContestBan, string) error); ok {
+		r1 = rf(ctx, ban, squares)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParticipantRepository_Ban_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ban'
+type ParticipantRepository_Ban_Call struct {
+	*mock.Call
+}
+
+// Ban is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ban *model.ContestBan
+//   - squares string
+func (_e *ParticipantRepository_Expecter) Ban(ctx interface{}, ban interface{}, squares interface{}) *ParticipantRepository_Ban_Call {
+	return &ParticipantRepository_Ban_Call{Call: _e.mock.On("Ban", ctx, ban, squares)}
+}
+
+func (_c *ParticipantRepository_Ban_Call) Run(run func(ctx context.Context, ban *model.ContestBan, squares string)) *ParticipantRepository_Ban_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.ContestBan), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ParticipantRepository_Ban_Call) Return(_a0 []model.Square, _a1 error) *ParticipantRepository_Ban_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParticipantRepository_Ban_Call) RunAndReturn(run func(context.Context, *model.ContestBan, string) ([]model.Square, error)) *ParticipantRepository_Ban_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CountSquaresByUser provides a mock function with given fields: ctx, contestID, userID
 func (_m *ParticipantRepository) CountSquaresByUser(ctx context.Context, contestID uuid.UUID, userID string) (int, error) {
 	ret := _m.Called(ctx, contestID, userID)
@@ -130,6 +189,54 @@ func (_c *ParticipantRepository_Delete_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// DeleteBan provides a mock function with given fields: ctx, contestID, userID
+func (_m *ParticipantRepository) DeleteBan(ctx context.Context, contestID uuid.UUID, userID string) error {
+	ret := _m.Called(ctx, contestID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, contestID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ParticipantRepository_DeleteBan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBan'
+type ParticipantRepository_DeleteBan_Call struct {
+	*mock.Call
+}
+
+// DeleteBan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - userID string
+func (_e *ParticipantRepository_Expecter) DeleteBan(ctx interface{}, contestID interface{}, userID interface{}) *ParticipantRepository_DeleteBan_Call {
+	return &ParticipantRepository_DeleteBan_Call{Call: _e.mock.On("DeleteBan", ctx, contestID, userID)}
+}
+
+func (_c *ParticipantRepository_DeleteBan_Call) Run(run func(ctx context.Context, contestID uuid.UUID, userID string)) *ParticipantRepository_DeleteBan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ParticipantRepository_DeleteBan_Call) Return(_a0 error) *ParticipantRepository_DeleteBan_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ParticipantRepository_DeleteBan_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) error) *ParticipantRepository_DeleteBan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAllByContestID provides a mock function with given fields: ctx, contestID
 func (_m *ParticipantRepository) GetAllByContestID(ctx context.Context, contestID uuid.UUID) ([]model.ContestParticipant, error) {
 	ret := _m.Called(ctx, contestID)
@@ -189,6 +296,65 @@ func (_c *ParticipantRepository_GetAllByContestID_Call) RunAndReturn(run func(co
 	return _c
 }
 
+// GetBans provides a mock function with given fields: ctx, contestID
+func (_m *ParticipantRepository) GetBans(ctx context.Context, contestID uuid.UUID) ([]model.ContestBan, error) {
+	ret := _m.Called(ctx, contestID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBans")
+	}
+
+	var r0 []model.ContestBan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]model.ContestBan, error)); ok {
+		return rf(ctx, contestID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []model.ContestBan); ok {
+		r0 = rf(ctx, contestID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ContestBan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, contestID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParticipantRepository_GetBans_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBans'
+type ParticipantRepository_GetBans_Call struct {
+	*mock.Call
+}
+
+// GetBans is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+func (_e *ParticipantRepository_Expecter) GetBans(ctx interface{}, contestID interface{}) *ParticipantRepository_GetBans_Call {
+	return &ParticipantRepository_GetBans_Call{Call: _e.mock.On("GetBans", ctx, contestID)}
+}
+
+func (_c *ParticipantRepository_GetBans_Call) Run(run func(ctx context.Context, contestID uuid.UUID)) *ParticipantRepository_GetBans_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *ParticipantRepository_GetBans_Call) Return(_a0 []model.ContestBan, _a1 error) *ParticipantRepository_GetBans_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParticipantRepository_GetBans_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]model.ContestBan, error)) *ParticipantRepository_GetBans_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByContestAndUser provides a mock function with given fields: ctx, contestID, userID
 func (_m *ParticipantRepository) GetByContestAndUser(ctx context.Context, contestID uuid.UUID, userID string) (*model.ContestParticipant, error) {
 	ret := _m.Called(ctx, contestID, userID)
@@ -306,6 +472,126 @@ func (_c *ParticipantRepository_GetTotalAllocatedSquares_Call) RunAndReturn(run
 	return _c
 }
 
+// Import provides a mock function with given fields: ctx, contestID, added, updated, claims
+func (_m *ParticipantRepository) Import(ctx context.Context, contestID uuid.UUID, added []model.ContestParticipant, updated []model.ContestParticipant, claims []model.Square) ([]model.Square, error) {
+	ret := _m.Called(ctx, contestID, added, updated, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Import")
+	}
+
+	var r0 []model.Square
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []model.ContestParticipant, []model.ContestParticipant, []model.Square) ([]model.Square, error)); ok {
+		return rf(ctx, contestID, added, updated, claims)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []model.ContestParticipant, []model.ContestParticipant, []model.Square) []model.Square); ok {
+		r0 = rf(ctx, contestID, added, updated, claims)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Square)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, []model.ContestParticipant, []model.ContestParticipant, []model.Square) error); ok {
+		r1 = rf(ctx, contestID, added, updated, claims)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParticipantRepository_Import_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Import'
+type ParticipantRepository_Import_Call struct {
+	*mock.Call
+}
+
+// Import is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - added []model.ContestParticipant
+//   - updated []model.ContestParticipant
+//   - claims []model.Square
+func (_e *ParticipantRepository_Expecter) Import(ctx interface{}, contestID interface{}, added interface{}, updated interface{}, claims interface{}) *ParticipantRepository_Import_Call {
+	return &ParticipantRepository_Import_Call{Call: _e.mock.On("Import", ctx, contestID, added, updated, claims)}
+}
+
+func (_c *ParticipantRepository_Import_Call) Run(run func(ctx context.Context, contestID uuid.UUID, added []model.ContestParticipant, updated []model.ContestParticipant, claims []model.Square)) *ParticipantRepository_Import_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].([]model.ContestParticipant), args[3].([]model.ContestParticipant), args[4].([]model.Square))
+	})
+	return _c
+}
+
+func (_c *ParticipantRepository_Import_Call) Return(_a0 []model.Square, _a1 error) *ParticipantRepository_Import_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParticipantRepository_Import_Call) RunAndReturn(run func(context.Context, uuid.UUID, []model.ContestParticipant, []model.ContestParticipant, []model.Square) ([]model.Square, error)) *ParticipantRepository_Import_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsBanned provides a mock function with given fields: ctx, contestID, userID
+func (_m *ParticipantRepository) IsBanned(ctx context.Context, contestID uuid.UUID, userID string) (bool, error) {
+	ret := _m.Called(ctx, contestID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsBanned")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (bool, error)); ok {
+		return rf(ctx, contestID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) bool); ok {
+		r0 = rf(ctx, contestID, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, contestID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParticipantRepository_IsBanned_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsBanned'
+type ParticipantRepository_IsBanned_Call struct {
+	*mock.Call
+}
+
+// IsBanned is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - userID string
+func (_e *ParticipantRepository_Expecter) IsBanned(ctx interface{}, contestID interface{}, userID interface{}) *ParticipantRepository_IsBanned_Call {
+	return &ParticipantRepository_IsBanned_Call{Call: _e.mock.On("IsBanned", ctx, contestID, userID)}
+}
+
+func (_c *ParticipantRepository_IsBanned_Call) Run(run func(ctx context.Context, contestID uuid.UUID, userID string)) *ParticipantRepository_IsBanned_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ParticipantRepository_IsBanned_Call) Return(_a0 bool, _a1 error) *ParticipantRepository_IsBanned_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParticipantRepository_IsBanned_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) (bool, error)) *ParticipantRepository_IsBanned_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: ctx, participant
 func (_m *ParticipantRepository) Update(ctx context.Context, participant *model.ContestParticipant) error {
 	ret := _m.Called(ctx, participant)
@@ -353,6 +639,65 @@ func (_c *ParticipantRepository_Update_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// UpdateSquareStyle provides a mock function with given fields: ctx, participant
+func (_m *ParticipantRepository) UpdateSquareStyle(ctx context.Context, participant *model.ContestParticipant) ([]model.Square, error) {
+	ret := _m.Called(ctx, participant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSquareStyle")
+	}
+
+	var r0 []model.Square
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ContestParticipant) ([]model.Square, error)); ok {
+		return rf(ctx, participant)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ContestParticipant) []model.Square); ok {
+		r0 = rf(ctx, participant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Square)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ContestParticipant) error); ok {
+		r1 = rf(ctx, participant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParticipantRepository_UpdateSquareStyle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSquareStyle'
+type ParticipantRepository_UpdateSquareStyle_Call struct {
+	*mock.Call
+}
+
+// UpdateSquareStyle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - participant *model.ContestParticipant
+func (_e *ParticipantRepository_Expecter) UpdateSquareStyle(ctx interface{}, participant interface{}) *ParticipantRepository_UpdateSquareStyle_Call {
+	return &ParticipantRepository_UpdateSquareStyle_Call{Call: _e.mock.On("UpdateSquareStyle", ctx, participant)}
+}
+
+func (_c *ParticipantRepository_UpdateSquareStyle_Call) Run(run func(ctx context.Context, participant *model.ContestParticipant)) *ParticipantRepository_UpdateSquareStyle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.ContestParticipant))
+	})
+	return _c
+}
+
+func (_c *ParticipantRepository_UpdateSquareStyle_Call) Return(_a0 []model.Square, _a1 error) *ParticipantRepository_UpdateSquareStyle_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParticipantRepository_UpdateSquareStyle_Call) RunAndReturn(run func(context.Context, *model.ContestParticipant) ([]model.Square, error)) *ParticipantRepository_UpdateSquareStyle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewParticipantRepository creates a new instance of ParticipantRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewParticipantRepository(t interface {
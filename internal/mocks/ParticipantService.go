@@ -73,9 +73,130 @@ func (_c *ParticipantService_Authorize_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
-// GetMyContests provides a mock function with given fields: ctx, user, search
-func (_m *ParticipantService) GetMyContests(ctx context.Context, user string, search string) ([]model.Contest, error) {
-	ret := _m.Called(ctx, user, search)
+// BanParticipant provides a mock function with given fields: ctx, contestID, req, user
+func (_m *ParticipantService) BanParticipant(ctx context.Context, contestID uuid.UUID, req *model.BanParticipantRequest, user string) (*model.ContestBan, error) {
+	ret := _m.Called(ctx, contestID, req, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BanParticipant")
+	}
+
+	var r0 *model.ContestBan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.BanParticipantRequest, string) (*model.ContestBan, error)); ok {
+		return rf(ctx, contestID, req, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.BanParticipantRequest, string) *model.ContestBan); ok {
+		r0 = rf(ctx, contestID, req, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ContestBan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *model.BanParticipantRequest, string) error); ok {
+		r1 = rf(ctx, contestID, req, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParticipantService_BanParticipant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BanParticipant'
+type ParticipantService_BanParticipant_Call struct {
+	*mock.Call
+}
+
+// BanParticipant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - req *model.BanParticipantRequest
+//   - user string
+func (_e *ParticipantService_Expecter) BanParticipant(ctx interface{}, contestID interface{}, req interface{}, user interface{}) *ParticipantService_BanParticipant_Call {
+	return &ParticipantService_BanParticipant_Call{Call: _e.mock.On("BanParticipant", ctx, contestID, req, user)}
+}
+
+func (_c *ParticipantService_BanParticipant_Call) Run(run func(ctx context.Context, contestID uuid.UUID, req *model.BanParticipantRequest, user string)) *ParticipantService_BanParticipant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*model.BanParticipantRequest), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *ParticipantService_BanParticipant_Call) Return(_a0 *model.ContestBan, _a1 error) *ParticipantService_BanParticipant_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParticipantService_BanParticipant_Call) RunAndReturn(run func(context.Context, uuid.UUID, *model.BanParticipantRequest, string) (*model.ContestBan, error)) *ParticipantService_BanParticipant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBans provides a mock function with given fields: ctx, contestID, user
+func (_m *ParticipantService) GetBans(ctx context.Context, contestID uuid.UUID, user string) ([]model.ContestBan, error) {
+	ret := _m.Called(ctx, contestID, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBans")
+	}
+
+	var r0 []model.ContestBan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) ([]model.ContestBan, error)); ok {
+		return rf(ctx, contestID, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) []model.ContestBan); ok {
+		r0 = rf(ctx, contestID, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ContestBan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, contestID, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParticipantService_GetBans_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBans'
+type ParticipantService_GetBans_Call struct {
+	*mock.Call
+}
+
+// GetBans is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - user string
+func (_e *ParticipantService_Expecter) GetBans(ctx interface{}, contestID interface{}, user interface{}) *ParticipantService_GetBans_Call {
+	return &ParticipantService_GetBans_Call{Call: _e.mock.On("GetBans", ctx, contestID, user)}
+}
+
+func (_c *ParticipantService_GetBans_Call) Run(run func(ctx context.Context, contestID uuid.UUID, user string)) *ParticipantService_GetBans_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ParticipantService_GetBans_Call) Return(_a0 []model.ContestBan, _a1 error) *ParticipantService_GetBans_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParticipantService_GetBans_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) ([]model.ContestBan, error)) *ParticipantService_GetBans_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMyContests provides a mock function with given fields: ctx, user, search, orgID
+func (_m *ParticipantService) GetMyContests(ctx context.Context, user string, search string, orgID *uuid.UUID) ([]model.Contest, error) {
+	ret := _m.Called(ctx, user, search, orgID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetMyContests")
@@ -83,19 +204,19 @@ func (_m *ParticipantService) GetMyContests(ctx context.Context, user string, se
 
 	var r0 []model.Contest
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]model.Contest, error)); ok {
-		return rf(ctx, user, search)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *uuid.UUID) ([]model.Contest, error)); ok {
+		return rf(ctx, user, search, orgID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) []model.Contest); ok {
-		r0 = rf(ctx, user, search)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *uuid.UUID) []model.Contest); ok {
+		r0 = rf(ctx, user, search, orgID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]model.Contest)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, user, search)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *uuid.UUID) error); ok {
+		r1 = rf(ctx, user, search, orgID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -112,13 +233,14 @@ type ParticipantService_GetMyContests_Call struct {
 //   - ctx context.Context
 //   - user string
 //   - search string
-func (_e *ParticipantService_Expecter) GetMyContests(ctx interface{}, user interface{}, search interface{}) *ParticipantService_GetMyContests_Call {
-	return &ParticipantService_GetMyContests_Call{Call: _e.mock.On("GetMyContests", ctx, user, search)}
+//   - orgID *uuid.UUID
+func (_e *ParticipantService_Expecter) GetMyContests(ctx interface{}, user interface{}, search interface{}, orgID interface{}) *ParticipantService_GetMyContests_Call {
+	return &ParticipantService_GetMyContests_Call{Call: _e.mock.On("GetMyContests", ctx, user, search, orgID)}
 }
 
-func (_c *ParticipantService_GetMyContests_Call) Run(run func(ctx context.Context, user string, search string)) *ParticipantService_GetMyContests_Call {
+func (_c *ParticipantService_GetMyContests_Call) Run(run func(ctx context.Context, user string, search string, orgID *uuid.UUID)) *ParticipantService_GetMyContests_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*uuid.UUID))
 	})
 	return _c
 }
@@ -128,7 +250,7 @@ func (_c *ParticipantService_GetMyContests_Call) Return(_a0 []model.Contest, _a1
 	return _c
 }
 
-func (_c *ParticipantService_GetMyContests_Call) RunAndReturn(run func(context.Context, string, string) ([]model.Contest, error)) *ParticipantService_GetMyContests_Call {
+func (_c *ParticipantService_GetMyContests_Call) RunAndReturn(run func(context.Context, string, string, *uuid.UUID) ([]model.Contest, error)) *ParticipantService_GetMyContests_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -252,6 +374,66 @@ func (_c *ParticipantService_GetParticipantsInternal_Call) RunAndReturn(run func
 	return _c
 }
 
+// GetPaymentSummary provides a mock function with given fields: ctx, contestID, user
+func (_m *ParticipantService) GetPaymentSummary(ctx context.Context, contestID uuid.UUID, user string) (*model.PaymentSummary, error) {
+	ret := _m.Called(ctx, contestID, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentSummary")
+	}
+
+	var r0 *model.PaymentSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*model.PaymentSummary, error)); ok {
+		return rf(ctx, contestID, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *model.PaymentSummary); ok {
+		r0 = rf(ctx, contestID, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PaymentSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, contestID, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParticipantService_GetPaymentSummary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPaymentSummary'
+type ParticipantService_GetPaymentSummary_Call struct {
+	*mock.Call
+}
+
+// GetPaymentSummary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - user string
+func (_e *ParticipantService_Expecter) GetPaymentSummary(ctx interface{}, contestID interface{}, user interface{}) *ParticipantService_GetPaymentSummary_Call {
+	return &ParticipantService_GetPaymentSummary_Call{Call: _e.mock.On("GetPaymentSummary", ctx, contestID, user)}
+}
+
+func (_c *ParticipantService_GetPaymentSummary_Call) Run(run func(ctx context.Context, contestID uuid.UUID, user string)) *ParticipantService_GetPaymentSummary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ParticipantService_GetPaymentSummary_Call) Return(_a0 *model.PaymentSummary, _a1 error) *ParticipantService_GetPaymentSummary_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParticipantService_GetPaymentSummary_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) (*model.PaymentSummary, error)) *ParticipantService_GetPaymentSummary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RemoveParticipant provides a mock function with given fields: ctx, contestID, targetUserID, user
 func (_m *ParticipantService) RemoveParticipant(ctx context.Context, contestID uuid.UUID, targetUserID string, user string) error {
 	ret := _m.Called(ctx, contestID, targetUserID, user)
@@ -301,6 +483,55 @@ func (_c *ParticipantService_RemoveParticipant_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// UnbanUser provides a mock function with given fields: ctx, contestID, targetUserID, user
+func (_m *ParticipantService) UnbanUser(ctx context.Context, contestID uuid.UUID, targetUserID string, user string) error {
+	ret := _m.Called(ctx, contestID, targetUserID, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnbanUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) error); ok {
+		r0 = rf(ctx, contestID, targetUserID, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ParticipantService_UnbanUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnbanUser'
+type ParticipantService_UnbanUser_Call struct {
+	*mock.Call
+}
+
+// UnbanUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - targetUserID string
+//   - user string
+func (_e *ParticipantService_Expecter) UnbanUser(ctx interface{}, contestID interface{}, targetUserID interface{}, user interface{}) *ParticipantService_UnbanUser_Call {
+	return &ParticipantService_UnbanUser_Call{Call: _e.mock.On("UnbanUser", ctx, contestID, targetUserID, user)}
+}
+
+func (_c *ParticipantService_UnbanUser_Call) Run(run func(ctx context.Context, contestID uuid.UUID, targetUserID string, user string)) *ParticipantService_UnbanUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *ParticipantService_UnbanUser_Call) Return(_a0 error) *ParticipantService_UnbanUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ParticipantService_UnbanUser_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, string) error) *ParticipantService_UnbanUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateParticipant provides a mock function with given fields: ctx, contestID, targetUserID, req, user
 func (_m *ParticipantService) UpdateParticipant(ctx context.Context, contestID uuid.UUID, targetUserID string, req *model.UpdateParticipantRequest, user string) (*model.ContestParticipant, error) {
 	ret := _m.Called(ctx, contestID, targetUserID, req, user)
@@ -363,6 +594,129 @@ func (_c *ParticipantService_UpdateParticipant_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// UpdatePayment provides a mock function with given fields: ctx, contestID, targetUserID, req, user
+func (_m *ParticipantService) UpdatePayment(ctx context.Context, contestID uuid.UUID, targetUserID string, req *model.UpdatePaymentRequest, user string) (*model.ContestParticipant, error) {
+	ret := _m.Called(ctx, contestID, targetUserID, req, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePayment")
+	}
+
+	var r0 *model.ContestParticipant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, *model.UpdatePaymentRequest, string) (*model.ContestParticipant, error)); ok {
+		return rf(ctx, contestID, targetUserID, req, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, *model.UpdatePaymentRequest, string) *model.ContestParticipant); ok {
+		r0 = rf(ctx, contestID, targetUserID, req, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ContestParticipant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, *model.UpdatePaymentRequest, string) error); ok {
+		r1 = rf(ctx, contestID, targetUserID, req, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParticipantService_UpdatePayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePayment'
+type ParticipantService_UpdatePayment_Call struct {
+	*mock.Call
+}
+
+// UpdatePayment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - targetUserID string
+//   - req *model.UpdatePaymentRequest
+//   - user string
+func (_e *ParticipantService_Expecter) UpdatePayment(ctx interface{}, contestID interface{}, targetUserID interface{}, req interface{}, user interface{}) *ParticipantService_UpdatePayment_Call {
+	return &ParticipantService_UpdatePayment_Call{Call: _e.mock.On("UpdatePayment", ctx, contestID, targetUserID, req, user)}
+}
+
+func (_c *ParticipantService_UpdatePayment_Call) Run(run func(ctx context.Context, contestID uuid.UUID, targetUserID string, req *model.UpdatePaymentRequest, user string)) *ParticipantService_UpdatePayment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(*model.UpdatePaymentRequest), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *ParticipantService_UpdatePayment_Call) Return(_a0 *model.ContestParticipant, _a1 error) *ParticipantService_UpdatePayment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParticipantService_UpdatePayment_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, *model.UpdatePaymentRequest, string) (*model.ContestParticipant, error)) *ParticipantService_UpdatePayment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSquareStyle provides a mock function with given fields: ctx, contestID, req, user
+func (_m *ParticipantService) UpdateSquareStyle(ctx context.Context, contestID uuid.UUID, req *model.UpdateSquareStyleRequest, user string) (*model.ContestParticipant, error) {
+	ret := _m.Called(ctx, contestID, req, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSquareStyle")
+	}
+
+	var r0 *model.ContestParticipant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.UpdateSquareStyleRequest, string) (*model.ContestParticipant, error)); ok {
+		return rf(ctx, contestID, req, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.UpdateSquareStyleRequest, string) *model.ContestParticipant); ok {
+		r0 = rf(ctx, contestID, req, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ContestParticipant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *model.UpdateSquareStyleRequest, string) error); ok {
+		r1 = rf(ctx, contestID, req, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParticipantService_UpdateSquareStyle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSquareStyle'
+type ParticipantService_UpdateSquareStyle_Call struct {
+	*mock.Call
+}
+
+// UpdateSquareStyle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - req *model.UpdateSquareStyleRequest
+//   - user string
+func (_e *ParticipantService_Expecter) UpdateSquareStyle(ctx interface{}, contestID interface{}, req interface{}, user interface{}) *ParticipantService_UpdateSquareStyle_Call {
+	return &ParticipantService_UpdateSquareStyle_Call{Call: _e.mock.On("UpdateSquareStyle", ctx, contestID, req, user)}
+}
+
+func (_c *ParticipantService_UpdateSquareStyle_Call) Run(run func(ctx context.Context, contestID uuid.UUID, req *model.UpdateSquareStyleRequest, user string)) *ParticipantService_UpdateSquareStyle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*model.UpdateSquareStyleRequest), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *ParticipantService_UpdateSquareStyle_Call) Return(_a0 *model.ContestParticipant, _a1 error) *ParticipantService_UpdateSquareStyle_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ParticipantService_UpdateSquareStyle_Call) RunAndReturn(run func(context.Context, uuid.UUID, *model.UpdateSquareStyleRequest, string) (*model.ContestParticipant, error)) *ParticipantService_UpdateSquareStyle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewParticipantService creates a new instance of ParticipantService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewParticipantService(t interface {
@@ -0,0 +1,251 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	uuid "github.com/google/uuid"
+	model "github.com/maxmorhardt/squares-api/internal/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SpectatorRepository is an autogenerated mock type for the SpectatorRepository type
+type SpectatorRepository struct {
+	mock.Mock
+}
+
+type SpectatorRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SpectatorRepository) EXPECT() *SpectatorRepository_Expecter {
+	return &SpectatorRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, token
+func (_m *SpectatorRepository) Create(ctx context.Context, token *model.ContestSpectatorToken) error {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ContestSpectatorToken) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SpectatorRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type SpectatorRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token *model.ContestSpectatorToken
+func (_e *SpectatorRepository_Expecter) Create(ctx interface{}, token interface{}) *SpectatorRepository_Create_Call {
+	return &SpectatorRepository_Create_Call{Call: _e.mock.On("Create", ctx, token)}
+}
+
+func (_c *SpectatorRepository_Create_Call) Run(run func(ctx context.Context, token *model.ContestSpectatorToken)) *SpectatorRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.ContestSpectatorToken))
+	})
+	return _c
+}
+
+func (_c *SpectatorRepository_Create_Call) Return(_a0 error) *SpectatorRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SpectatorRepository_Create_Call) RunAndReturn(run func(context.Context, *model.ContestSpectatorToken) error) *SpectatorRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, contestID, id
+func (_m *SpectatorRepository) Delete(ctx context.Context, contestID uuid.UUID, id uuid.UUID) error {
+	ret := _m.Called(ctx, contestID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, contestID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SpectatorRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type SpectatorRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - id uuid.UUID
+func (_e *SpectatorRepository_Expecter) Delete(ctx interface{}, contestID interface{}, id interface{}) *SpectatorRepository_Delete_Call {
+	return &SpectatorRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, contestID, id)}
+}
+
+func (_c *SpectatorRepository_Delete_Call) Run(run func(ctx context.Context, contestID uuid.UUID, id uuid.UUID)) *SpectatorRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *SpectatorRepository_Delete_Call) Return(_a0 error) *SpectatorRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SpectatorRepository_Delete_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *SpectatorRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllByContestID provides a mock function with given fields: ctx, contestID
+func (_m *SpectatorRepository) GetAllByContestID(ctx context.Context, contestID uuid.UUID) ([]model.ContestSpectatorToken, error) {
+	ret := _m.Called(ctx, contestID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllByContestID")
+	}
+
+	var r0 []model.ContestSpectatorToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]model.ContestSpectatorToken, error)); ok {
+		return rf(ctx, contestID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []model.ContestSpectatorToken); ok {
+		r0 = rf(ctx, contestID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ContestSpectatorToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, contestID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SpectatorRepository_GetAllByContestID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllByContestID'
+type SpectatorRepository_GetAllByContestID_Call struct {
+	*mock.Call
+}
+
+// GetAllByContestID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+func (_e *SpectatorRepository_Expecter) GetAllByContestID(ctx interface{}, contestID interface{}) *SpectatorRepository_GetAllByContestID_Call {
+	return &SpectatorRepository_GetAllByContestID_Call{Call: _e.mock.On("GetAllByContestID", ctx, contestID)}
+}
+
+func (_c *SpectatorRepository_GetAllByContestID_Call) Run(run func(ctx context.Context, contestID uuid.UUID)) *SpectatorRepository_GetAllByContestID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *SpectatorRepository_GetAllByContestID_Call) Return(_a0 []model.ContestSpectatorToken, _a1 error) *SpectatorRepository_GetAllByContestID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SpectatorRepository_GetAllByContestID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]model.ContestSpectatorToken, error)) *SpectatorRepository_GetAllByContestID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByToken provides a mock function with given fields: ctx, token
+func (_m *SpectatorRepository) GetByToken(ctx context.Context, token string) (*model.ContestSpectatorToken, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByToken")
+	}
+
+	var r0 *model.ContestSpectatorToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.ContestSpectatorToken, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.ContestSpectatorToken); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ContestSpectatorToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SpectatorRepository_GetByToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByToken'
+type SpectatorRepository_GetByToken_Call struct {
+	*mock.Call
+}
+
+// GetByToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *SpectatorRepository_Expecter) GetByToken(ctx interface{}, token interface{}) *SpectatorRepository_GetByToken_Call {
+	return &SpectatorRepository_GetByToken_Call{Call: _e.mock.On("GetByToken", ctx, token)}
+}
+
+func (_c *SpectatorRepository_GetByToken_Call) Run(run func(ctx context.Context, token string)) *SpectatorRepository_GetByToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SpectatorRepository_GetByToken_Call) Return(_a0 *model.ContestSpectatorToken, _a1 error) *SpectatorRepository_GetByToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SpectatorRepository_GetByToken_Call) RunAndReturn(run func(context.Context, string) (*model.ContestSpectatorToken, error)) *SpectatorRepository_GetByToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSpectatorRepository creates a new instance of SpectatorRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSpectatorRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SpectatorRepository {
+	mock := &SpectatorRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
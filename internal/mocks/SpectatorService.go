@@ -0,0 +1,326 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	uuid "github.com/google/uuid"
+	model "github.com/maxmorhardt/squares-api/internal/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SpectatorService is an autogenerated mock type for the SpectatorService type
+type SpectatorService struct {
+	mock.Mock
+}
+
+type SpectatorService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SpectatorService) EXPECT() *SpectatorService_Expecter {
+	return &SpectatorService_Expecter{mock: &_m.Mock}
+}
+
+// CreateToken provides a mock function with given fields: ctx, contestID, req, user
+func (_m *SpectatorService) CreateToken(ctx context.Context, contestID uuid.UUID, req *model.CreateSpectatorTokenRequest, user string) (*model.ContestSpectatorToken, error) {
+	ret := _m.Called(ctx, contestID, req, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateToken")
+	}
+
+	var r0 *model.ContestSpectatorToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.CreateSpectatorTokenRequest, string) (*model.ContestSpectatorToken, error)); ok {
+		return rf(ctx, contestID, req, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.CreateSpectatorTokenRequest, string) *model.ContestSpectatorToken); ok {
+		r0 = rf(ctx, contestID, req, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ContestSpectatorToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *model.CreateSpectatorTokenRequest, string) error); ok {
+		r1 = rf(ctx, contestID, req, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SpectatorService_CreateToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateToken'
+type SpectatorService_CreateToken_Call struct {
+	*mock.Call
+}
+
+// CreateToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - req *model.CreateSpectatorTokenRequest
+//   - user string
+func (_e *SpectatorService_Expecter) CreateToken(ctx interface{}, contestID interface{}, req interface{}, user interface{}) *SpectatorService_CreateToken_Call {
+	return &SpectatorService_CreateToken_Call{Call: _e.mock.On("CreateToken", ctx, contestID, req, user)}
+}
+
+func (_c *SpectatorService_CreateToken_Call) Run(run func(ctx context.Context, contestID uuid.UUID, req *model.CreateSpectatorTokenRequest, user string)) *SpectatorService_CreateToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*model.CreateSpectatorTokenRequest), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *SpectatorService_CreateToken_Call) Return(_a0 *model.ContestSpectatorToken, _a1 error) *SpectatorService_CreateToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SpectatorService_CreateToken_Call) RunAndReturn(run func(context.Context, uuid.UUID, *model.CreateSpectatorTokenRequest, string) (*model.ContestSpectatorToken, error)) *SpectatorService_CreateToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSnapshot provides a mock function with given fields: ctx, token
+func (_m *SpectatorService) GetSnapshot(ctx context.Context, token string) (*model.Contest, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSnapshot")
+	}
+
+	var r0 *model.Contest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Contest, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Contest); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Contest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SpectatorService_GetSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSnapshot'
+type SpectatorService_GetSnapshot_Call struct {
+	*mock.Call
+}
+
+// GetSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *SpectatorService_Expecter) GetSnapshot(ctx interface{}, token interface{}) *SpectatorService_GetSnapshot_Call {
+	return &SpectatorService_GetSnapshot_Call{Call: _e.mock.On("GetSnapshot", ctx, token)}
+}
+
+func (_c *SpectatorService_GetSnapshot_Call) Run(run func(ctx context.Context, token string)) *SpectatorService_GetSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SpectatorService_GetSnapshot_Call) Return(_a0 *model.Contest, _a1 error) *SpectatorService_GetSnapshot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SpectatorService_GetSnapshot_Call) RunAndReturn(run func(context.Context, string) (*model.Contest, error)) *SpectatorService_GetSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTokensByContestID provides a mock function with given fields: ctx, contestID, user
+func (_m *SpectatorService) GetTokensByContestID(ctx context.Context, contestID uuid.UUID, user string) ([]model.ContestSpectatorToken, error) {
+	ret := _m.Called(ctx, contestID, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTokensByContestID")
+	}
+
+	var r0 []model.ContestSpectatorToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) ([]model.ContestSpectatorToken, error)); ok {
+		return rf(ctx, contestID, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) []model.ContestSpectatorToken); ok {
+		r0 = rf(ctx, contestID, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ContestSpectatorToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, contestID, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SpectatorService_GetTokensByContestID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTokensByContestID'
+type SpectatorService_GetTokensByContestID_Call struct {
+	*mock.Call
+}
+
+// GetTokensByContestID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - user string
+func (_e *SpectatorService_Expecter) GetTokensByContestID(ctx interface{}, contestID interface{}, user interface{}) *SpectatorService_GetTokensByContestID_Call {
+	return &SpectatorService_GetTokensByContestID_Call{Call: _e.mock.On("GetTokensByContestID", ctx, contestID, user)}
+}
+
+func (_c *SpectatorService_GetTokensByContestID_Call) Run(run func(ctx context.Context, contestID uuid.UUID, user string)) *SpectatorService_GetTokensByContestID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SpectatorService_GetTokensByContestID_Call) Return(_a0 []model.ContestSpectatorToken, _a1 error) *SpectatorService_GetTokensByContestID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SpectatorService_GetTokensByContestID_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) ([]model.ContestSpectatorToken, error)) *SpectatorService_GetTokensByContestID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeToken provides a mock function with given fields: ctx, contestID, tokenID, user
+func (_m *SpectatorService) RevokeToken(ctx context.Context, contestID uuid.UUID, tokenID uuid.UUID, user string) error {
+	ret := _m.Called(ctx, contestID, tokenID, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, contestID, tokenID, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SpectatorService_RevokeToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeToken'
+type SpectatorService_RevokeToken_Call struct {
+	*mock.Call
+}
+
+// RevokeToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - tokenID uuid.UUID
+//   - user string
+func (_e *SpectatorService_Expecter) RevokeToken(ctx interface{}, contestID interface{}, tokenID interface{}, user interface{}) *SpectatorService_RevokeToken_Call {
+	return &SpectatorService_RevokeToken_Call{Call: _e.mock.On("RevokeToken", ctx, contestID, tokenID, user)}
+}
+
+func (_c *SpectatorService_RevokeToken_Call) Run(run func(ctx context.Context, contestID uuid.UUID, tokenID uuid.UUID, user string)) *SpectatorService_RevokeToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *SpectatorService_RevokeToken_Call) Return(_a0 error) *SpectatorService_RevokeToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SpectatorService_RevokeToken_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, string) error) *SpectatorService_RevokeToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateToken provides a mock function with given fields: ctx, token
+func (_m *SpectatorService) ValidateToken(ctx context.Context, token string) (*model.ContestSpectatorToken, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateToken")
+	}
+
+	var r0 *model.ContestSpectatorToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.ContestSpectatorToken, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.ContestSpectatorToken); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ContestSpectatorToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SpectatorService_ValidateToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateToken'
+type SpectatorService_ValidateToken_Call struct {
+	*mock.Call
+}
+
+// ValidateToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *SpectatorService_Expecter) ValidateToken(ctx interface{}, token interface{}) *SpectatorService_ValidateToken_Call {
+	return &SpectatorService_ValidateToken_Call{Call: _e.mock.On("ValidateToken", ctx, token)}
+}
+
+func (_c *SpectatorService_ValidateToken_Call) Run(run func(ctx context.Context, token string)) *SpectatorService_ValidateToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SpectatorService_ValidateToken_Call) Return(_a0 *model.ContestSpectatorToken, _a1 error) *SpectatorService_ValidateToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SpectatorService_ValidateToken_Call) RunAndReturn(run func(context.Context, string) (*model.ContestSpectatorToken, error)) *SpectatorService_ValidateToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSpectatorService creates a new instance of SpectatorService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSpectatorService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SpectatorService {
+	mock := &SpectatorService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,281 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	uuid "github.com/google/uuid"
+	model "github.com/maxmorhardt/squares-api/internal/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SwapService is an autogenerated mock type for the SwapService type
+type SwapService struct {
+	mock.Mock
+}
+
+type SwapService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SwapService) EXPECT() *SwapService_Expecter {
+	return &SwapService_Expecter{mock: &_m.Mock}
+}
+
+// AcceptSwap provides a mock function with given fields: ctx, contestID, swapID, user
+func (_m *SwapService) AcceptSwap(ctx context.Context, contestID uuid.UUID, swapID uuid.UUID, user string) ([]model.Square, error) {
+	ret := _m.Called(ctx, contestID, swapID, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptSwap")
+	}
+
+	var r0 []model.Square
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string) ([]model.Square, error)); ok {
+		return rf(ctx, contestID, swapID, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string) []model.Square); ok {
+		r0 = rf(ctx, contestID, swapID, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Square)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, contestID, swapID, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SwapService_AcceptSwap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcceptSwap'
+type SwapService_AcceptSwap_Call struct {
+	*mock.Call
+}
+
+// AcceptSwap is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - swapID uuid.UUID
+//   - user string
+func (_e *SwapService_Expecter) AcceptSwap(ctx interface{}, contestID interface{}, swapID interface{}, user interface{}) *SwapService_AcceptSwap_Call {
+	return &SwapService_AcceptSwap_Call{Call: _e.mock.On("AcceptSwap", ctx, contestID, swapID, user)}
+}
+
+func (_c *SwapService_AcceptSwap_Call) Run(run func(ctx context.Context, contestID uuid.UUID, swapID uuid.UUID, user string)) *SwapService_AcceptSwap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *SwapService_AcceptSwap_Call) Return(_a0 []model.Square, _a1 error) *SwapService_AcceptSwap_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SwapService_AcceptSwap_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, string) ([]model.Square, error)) *SwapService_AcceptSwap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeclineSwap provides a mock function with given fields: ctx, contestID, swapID, user
+func (_m *SwapService) DeclineSwap(ctx context.Context, contestID uuid.UUID, swapID uuid.UUID, user string) (*model.SquareSwap, error) {
+	ret := _m.Called(ctx, contestID, swapID, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeclineSwap")
+	}
+
+	var r0 *model.SquareSwap
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string) (*model.SquareSwap, error)); ok {
+		return rf(ctx, contestID, swapID, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string) *model.SquareSwap); ok {
+		r0 = rf(ctx, contestID, swapID, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SquareSwap)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, contestID, swapID, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SwapService_DeclineSwap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeclineSwap'
+type SwapService_DeclineSwap_Call struct {
+	*mock.Call
+}
+
+// DeclineSwap is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - swapID uuid.UUID
+//   - user string
+func (_e *SwapService_Expecter) DeclineSwap(ctx interface{}, contestID interface{}, swapID interface{}, user interface{}) *SwapService_DeclineSwap_Call {
+	return &SwapService_DeclineSwap_Call{Call: _e.mock.On("DeclineSwap", ctx, contestID, swapID, user)}
+}
+
+func (_c *SwapService_DeclineSwap_Call) Run(run func(ctx context.Context, contestID uuid.UUID, swapID uuid.UUID, user string)) *SwapService_DeclineSwap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *SwapService_DeclineSwap_Call) Return(_a0 *model.SquareSwap, _a1 error) *SwapService_DeclineSwap_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SwapService_DeclineSwap_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, string) (*model.SquareSwap, error)) *SwapService_DeclineSwap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingSwaps provides a mock function with given fields: ctx, contestID, user
+func (_m *SwapService) GetPendingSwaps(ctx context.Context, contestID uuid.UUID, user string) ([]model.SquareSwap, error) {
+	ret := _m.Called(ctx, contestID, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingSwaps")
+	}
+
+	var r0 []model.SquareSwap
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) ([]model.SquareSwap, error)); ok {
+		return rf(ctx, contestID, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) []model.SquareSwap); ok {
+		r0 = rf(ctx, contestID, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.SquareSwap)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, contestID, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SwapService_GetPendingSwaps_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingSwaps'
+type SwapService_GetPendingSwaps_Call struct {
+	*mock.Call
+}
+
+// GetPendingSwaps is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - user string
+func (_e *SwapService_Expecter) GetPendingSwaps(ctx interface{}, contestID interface{}, user interface{}) *SwapService_GetPendingSwaps_Call {
+	return &SwapService_GetPendingSwaps_Call{Call: _e.mock.On("GetPendingSwaps", ctx, contestID, user)}
+}
+
+func (_c *SwapService_GetPendingSwaps_Call) Run(run func(ctx context.Context, contestID uuid.UUID, user string)) *SwapService_GetPendingSwaps_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SwapService_GetPendingSwaps_Call) Return(_a0 []model.SquareSwap, _a1 error) *SwapService_GetPendingSwaps_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SwapService_GetPendingSwaps_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) ([]model.SquareSwap, error)) *SwapService_GetPendingSwaps_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequestSwap provides a mock function with given fields: ctx, contestID, req, user
+func (_m *SwapService) RequestSwap(ctx context.Context, contestID uuid.UUID, req *model.CreateSwapRequest, user string) (*model.SquareSwap, error) {
+	ret := _m.Called(ctx, contestID, req, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestSwap")
+	}
+
+	var r0 *model.SquareSwap
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.CreateSwapRequest, string) (*model.SquareSwap, error)); ok {
+		return rf(ctx, contestID, req, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.CreateSwapRequest, string) *model.SquareSwap); ok {
+		r0 = rf(ctx, contestID, req, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SquareSwap)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *model.CreateSwapRequest, string) error); ok {
+		r1 = rf(ctx, contestID, req, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SwapService_RequestSwap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestSwap'
+type SwapService_RequestSwap_Call struct {
+	*mock.Call
+}
+
+// RequestSwap is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contestID uuid.UUID
+//   - req *model.CreateSwapRequest
+//   - user string
+func (_e *SwapService_Expecter) RequestSwap(ctx interface{}, contestID interface{}, req interface{}, user interface{}) *SwapService_RequestSwap_Call {
+	return &SwapService_RequestSwap_Call{Call: _e.mock.On("RequestSwap", ctx, contestID, req, user)}
+}
+
+func (_c *SwapService_RequestSwap_Call) Run(run func(ctx context.Context, contestID uuid.UUID, req *model.CreateSwapRequest, user string)) *SwapService_RequestSwap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*model.CreateSwapRequest), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *SwapService_RequestSwap_Call) Return(_a0 *model.SquareSwap, _a1 error) *SwapService_RequestSwap_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SwapService_RequestSwap_Call) RunAndReturn(run func(context.Context, uuid.UUID, *model.CreateSwapRequest, string) (*model.SquareSwap, error)) *SwapService_RequestSwap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSwapService creates a new instance of SwapService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSwapService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SwapService {
+	mock := &SwapService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
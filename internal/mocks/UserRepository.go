@@ -4,7 +4,9 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
+	uuid "github.com/google/uuid"
 	model "github.com/maxmorhardt/squares-api/internal/model"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -81,6 +83,65 @@ func (_c *UserRepository_GetActiveContests_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// GetByAvatarKey provides a mock function with given fields: ctx, key
+func (_m *UserRepository) GetByAvatarKey(ctx context.Context, key string) (*model.User, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByAvatarKey")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_GetByAvatarKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByAvatarKey'
+type UserRepository_GetByAvatarKey_Call struct {
+	*mock.Call
+}
+
+// GetByAvatarKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *UserRepository_Expecter) GetByAvatarKey(ctx interface{}, key interface{}) *UserRepository_GetByAvatarKey_Call {
+	return &UserRepository_GetByAvatarKey_Call{Call: _e.mock.On("GetByAvatarKey", ctx, key)}
+}
+
+func (_c *UserRepository_GetByAvatarKey_Call) Run(run func(ctx context.Context, key string)) *UserRepository_GetByAvatarKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepository_GetByAvatarKey_Call) Return(_a0 *model.User, _a1 error) *UserRepository_GetByAvatarKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_GetByAvatarKey_Call) RunAndReturn(run func(context.Context, string) (*model.User, error)) *UserRepository_GetByAvatarKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByEmail provides a mock function with given fields: ctx, email
 func (_m *UserRepository) GetByEmail(ctx context.Context, email string) (*model.User, error) {
 	ret := _m.Called(ctx, email)
@@ -140,6 +201,65 @@ func (_c *UserRepository_GetByEmail_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type UserRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *UserRepository_Expecter) GetByID(ctx interface{}, id interface{}) *UserRepository_GetByID_Call {
+	return &UserRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *UserRepository_GetByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *UserRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *UserRepository_GetByID_Call) Return(_a0 *model.User, _a1 error) *UserRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_GetByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*model.User, error)) *UserRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetOrCreate provides a mock function with given fields: ctx, email, defaultDisplayName, defaultInitials
 func (_m *UserRepository) GetOrCreate(ctx context.Context, email string, defaultDisplayName string, defaultInitials string) (*model.User, error) {
 	ret := _m.Called(ctx, email, defaultDisplayName, defaultInitials)
@@ -201,6 +321,67 @@ func (_c *UserRepository_GetOrCreate_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// GetRecentContests provides a mock function with given fields: ctx, email, viewer, limit
+func (_m *UserRepository) GetRecentContests(ctx context.Context, email string, viewer string, limit int) ([]model.PublicProfileContest, error) {
+	ret := _m.Called(ctx, email, viewer, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecentContests")
+	}
+
+	var r0 []model.PublicProfileContest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) ([]model.PublicProfileContest, error)); ok {
+		return rf(ctx, email, viewer, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) []model.PublicProfileContest); ok {
+		r0 = rf(ctx, email, viewer, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.PublicProfileContest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = rf(ctx, email, viewer, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_GetRecentContests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRecentContests'
+type UserRepository_GetRecentContests_Call struct {
+	*mock.Call
+}
+
+// GetRecentContests is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - viewer string
+//   - limit int
+func (_e *UserRepository_Expecter) GetRecentContests(ctx interface{}, email interface{}, viewer interface{}, limit interface{}) *UserRepository_GetRecentContests_Call {
+	return &UserRepository_GetRecentContests_Call{Call: _e.mock.On("GetRecentContests", ctx, email, viewer, limit)}
+}
+
+func (_c *UserRepository_GetRecentContests_Call) Run(run func(ctx context.Context, email string, viewer string, limit int)) *UserRepository_GetRecentContests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *UserRepository_GetRecentContests_Call) Return(_a0 []model.PublicProfileContest, _a1 error) *UserRepository_GetRecentContests_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_GetRecentContests_Call) RunAndReturn(run func(context.Context, string, string, int) ([]model.PublicProfileContest, error)) *UserRepository_GetRecentContests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetStats provides a mock function with given fields: ctx, email
 func (_m *UserRepository) GetStats(ctx context.Context, email string) (*model.UserStatsResponse, error) {
 	ret := _m.Called(ctx, email)
@@ -365,9 +546,70 @@ func (_c *UserRepository_ScrubUserData_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
-// UpdateProfile provides a mock function with given fields: ctx, email, initials
-func (_m *UserRepository) UpdateProfile(ctx context.Context, email string, initials string) (*model.User, []model.Square, error) {
-	ret := _m.Called(ctx, email, initials)
+// SetAvatarVersion provides a mock function with given fields: ctx, email, key, updatedAt
+func (_m *UserRepository) SetAvatarVersion(ctx context.Context, email string, key *string, updatedAt *time.Time) (*model.User, error) {
+	ret := _m.Called(ctx, email, key, updatedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAvatarVersion")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *string, *time.Time) (*model.User, error)); ok {
+		return rf(ctx, email, key, updatedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *string, *time.Time) *model.User); ok {
+		r0 = rf(ctx, email, key, updatedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *string, *time.Time) error); ok {
+		r1 = rf(ctx, email, key, updatedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_SetAvatarVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAvatarVersion'
+type UserRepository_SetAvatarVersion_Call struct {
+	*mock.Call
+}
+
+// SetAvatarVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - key *string
+//   - updatedAt *time.Time
+func (_e *UserRepository_Expecter) SetAvatarVersion(ctx interface{}, email interface{}, key interface{}, updatedAt interface{}) *UserRepository_SetAvatarVersion_Call {
+	return &UserRepository_SetAvatarVersion_Call{Call: _e.mock.On("SetAvatarVersion", ctx, email, key, updatedAt)}
+}
+
+func (_c *UserRepository_SetAvatarVersion_Call) Run(run func(ctx context.Context, email string, key *string, updatedAt *time.Time)) *UserRepository_SetAvatarVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*string), args[3].(*time.Time))
+	})
+	return _c
+}
+
+func (_c *UserRepository_SetAvatarVersion_Call) Return(_a0 *model.User, _a1 error) *UserRepository_SetAvatarVersion_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_SetAvatarVersion_Call) RunAndReturn(run func(context.Context, string, *string, *time.Time) (*model.User, error)) *UserRepository_SetAvatarVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateProfile provides a mock function with given fields: ctx, email, req
+func (_m *UserRepository) UpdateProfile(ctx context.Context, email string, req *model.UpdateUserProfileRequest) (*model.User, []model.Square, error) {
+	ret := _m.Called(ctx, email, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateProfile")
@@ -376,27 +618,27 @@ func (_m *UserRepository) UpdateProfile(ctx context.Context, email string, initi
 	var r0 *model.User
 	var r1 []model.Square
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.User, []model.Square, error)); ok {
-		return rf(ctx, email, initials)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.UpdateUserProfileRequest) (*model.User, []model.Square, error)); ok {
+		return rf(ctx, email, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.User); ok {
-		r0 = rf(ctx, email, initials)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.UpdateUserProfileRequest) *model.User); ok {
+		r0 = rf(ctx, email, req)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) []model.Square); ok {
-		r1 = rf(ctx, email, initials)
+	if rf, ok := ret.Get(1).(func(context.Context, string, *model.UpdateUserProfileRequest) []model.Square); ok {
+		r1 = rf(ctx, email, req)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).([]model.Square)
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
-		r2 = rf(ctx, email, initials)
+	if rf, ok := ret.Get(2).(func(context.Context, string, *model.UpdateUserProfileRequest) error); ok {
+		r2 = rf(ctx, email, req)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -412,14 +654,14 @@ type UserRepository_UpdateProfile_Call struct {
 // UpdateProfile is a helper method to define mock.On call
 //   - ctx context.Context
 //   - email string
-//   - initials string
-func (_e *UserRepository_Expecter) UpdateProfile(ctx interface{}, email interface{}, initials interface{}) *UserRepository_UpdateProfile_Call {
-	return &UserRepository_UpdateProfile_Call{Call: _e.mock.On("UpdateProfile", ctx, email, initials)}
+//   - req *model.UpdateUserProfileRequest
+func (_e *UserRepository_Expecter) UpdateProfile(ctx interface{}, email interface{}, req interface{}) *UserRepository_UpdateProfile_Call {
+	return &UserRepository_UpdateProfile_Call{Call: _e.mock.On("UpdateProfile", ctx, email, req)}
 }
 
-func (_c *UserRepository_UpdateProfile_Call) Run(run func(ctx context.Context, email string, initials string)) *UserRepository_UpdateProfile_Call {
+func (_c *UserRepository_UpdateProfile_Call) Run(run func(ctx context.Context, email string, req *model.UpdateUserProfileRequest)) *UserRepository_UpdateProfile_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(*model.UpdateUserProfileRequest))
 	})
 	return _c
 }
@@ -429,7 +671,7 @@ func (_c *UserRepository_UpdateProfile_Call) Return(_a0 *model.User, _a1 []model
 	return _c
 }
 
-func (_c *UserRepository_UpdateProfile_Call) RunAndReturn(run func(context.Context, string, string) (*model.User, []model.Square, error)) *UserRepository_UpdateProfile_Call {
+func (_c *UserRepository_UpdateProfile_Call) RunAndReturn(run func(context.Context, string, *model.UpdateUserProfileRequest) (*model.User, []model.Square, error)) *UserRepository_UpdateProfile_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 
+	uuid "github.com/google/uuid"
 	model "github.com/maxmorhardt/squares-api/internal/model"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -69,6 +70,53 @@ func (_c *UserService_DeleteAccount_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// DeleteAvatar provides a mock function with given fields: ctx, email
+func (_m *UserService) DeleteAvatar(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAvatar")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserService_DeleteAvatar_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAvatar'
+type UserService_DeleteAvatar_Call struct {
+	*mock.Call
+}
+
+// DeleteAvatar is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *UserService_Expecter) DeleteAvatar(ctx interface{}, email interface{}) *UserService_DeleteAvatar_Call {
+	return &UserService_DeleteAvatar_Call{Call: _e.mock.On("DeleteAvatar", ctx, email)}
+}
+
+func (_c *UserService_DeleteAvatar_Call) Run(run func(ctx context.Context, email string)) *UserService_DeleteAvatar_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserService_DeleteAvatar_Call) Return(_a0 error) *UserService_DeleteAvatar_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserService_DeleteAvatar_Call) RunAndReturn(run func(context.Context, string) error) *UserService_DeleteAvatar_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetActiveContests provides a mock function with given fields: ctx, email
 func (_m *UserService) GetActiveContests(ctx context.Context, email string) ([]model.UserActiveContest, error) {
 	ret := _m.Called(ctx, email)
@@ -128,6 +176,65 @@ func (_c *UserService_GetActiveContests_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// GetAvatar provides a mock function with given fields: ctx, key
+func (_m *UserService) GetAvatar(ctx context.Context, key string) (*model.UserAvatar, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAvatar")
+	}
+
+	var r0 *model.UserAvatar
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.UserAvatar, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.UserAvatar); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserAvatar)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_GetAvatar_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAvatar'
+type UserService_GetAvatar_Call struct {
+	*mock.Call
+}
+
+// GetAvatar is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *UserService_Expecter) GetAvatar(ctx interface{}, key interface{}) *UserService_GetAvatar_Call {
+	return &UserService_GetAvatar_Call{Call: _e.mock.On("GetAvatar", ctx, key)}
+}
+
+func (_c *UserService_GetAvatar_Call) Run(run func(ctx context.Context, key string)) *UserService_GetAvatar_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserService_GetAvatar_Call) Return(_a0 *model.UserAvatar, _a1 error) *UserService_GetAvatar_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_GetAvatar_Call) RunAndReturn(run func(context.Context, string) (*model.UserAvatar, error)) *UserService_GetAvatar_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetProfile provides a mock function with given fields: ctx, email, defaultDisplayName
 func (_m *UserService) GetProfile(ctx context.Context, email string, defaultDisplayName string) (*model.User, error) {
 	ret := _m.Called(ctx, email, defaultDisplayName)
@@ -188,6 +295,66 @@ func (_c *UserService_GetProfile_Call) RunAndReturn(run func(context.Context, st
 	return _c
 }
 
+// GetPublicProfile provides a mock function with given fields: ctx, userID, viewer
+func (_m *UserService) GetPublicProfile(ctx context.Context, userID uuid.UUID, viewer string) (*model.PublicProfileResponse, error) {
+	ret := _m.Called(ctx, userID, viewer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPublicProfile")
+	}
+
+	var r0 *model.PublicProfileResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*model.PublicProfileResponse, error)); ok {
+		return rf(ctx, userID, viewer)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *model.PublicProfileResponse); ok {
+		r0 = rf(ctx, userID, viewer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PublicProfileResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, userID, viewer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_GetPublicProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPublicProfile'
+type UserService_GetPublicProfile_Call struct {
+	*mock.Call
+}
+
+// GetPublicProfile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - viewer string
+func (_e *UserService_Expecter) GetPublicProfile(ctx interface{}, userID interface{}, viewer interface{}) *UserService_GetPublicProfile_Call {
+	return &UserService_GetPublicProfile_Call{Call: _e.mock.On("GetPublicProfile", ctx, userID, viewer)}
+}
+
+func (_c *UserService_GetPublicProfile_Call) Run(run func(ctx context.Context, userID uuid.UUID, viewer string)) *UserService_GetPublicProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserService_GetPublicProfile_Call) Return(_a0 *model.PublicProfileResponse, _a1 error) *UserService_GetPublicProfile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_GetPublicProfile_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) (*model.PublicProfileResponse, error)) *UserService_GetPublicProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetStats provides a mock function with given fields: ctx, email
 func (_m *UserService) GetStats(ctx context.Context, email string) (*model.UserStatsResponse, error) {
 	ret := _m.Called(ctx, email)
@@ -304,9 +471,69 @@ func (_c *UserService_IsTokenValid_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
-// UpdateProfile provides a mock function with given fields: ctx, email, initials
-func (_m *UserService) UpdateProfile(ctx context.Context, email string, initials string) (*model.User, error) {
-	ret := _m.Called(ctx, email, initials)
+// SetAvatar provides a mock function with given fields: ctx, email, data
+func (_m *UserService) SetAvatar(ctx context.Context, email string, data []byte) (*model.User, error) {
+	ret := _m.Called(ctx, email, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAvatar")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte) (*model.User, error)); ok {
+		return rf(ctx, email, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte) *model.User); ok {
+		r0 = rf(ctx, email, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []byte) error); ok {
+		r1 = rf(ctx, email, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserService_SetAvatar_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAvatar'
+type UserService_SetAvatar_Call struct {
+	*mock.Call
+}
+
+// SetAvatar is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - data []byte
+func (_e *UserService_Expecter) SetAvatar(ctx interface{}, email interface{}, data interface{}) *UserService_SetAvatar_Call {
+	return &UserService_SetAvatar_Call{Call: _e.mock.On("SetAvatar", ctx, email, data)}
+}
+
+func (_c *UserService_SetAvatar_Call) Run(run func(ctx context.Context, email string, data []byte)) *UserService_SetAvatar_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *UserService_SetAvatar_Call) Return(_a0 *model.User, _a1 error) *UserService_SetAvatar_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserService_SetAvatar_Call) RunAndReturn(run func(context.Context, string, []byte) (*model.User, error)) *UserService_SetAvatar_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateProfile provides a mock function with given fields: ctx, email, req
+func (_m *UserService) UpdateProfile(ctx context.Context, email string, req *model.UpdateUserProfileRequest) (*model.User, error) {
+	ret := _m.Called(ctx, email, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateProfile")
@@ -314,19 +541,19 @@ func (_m *UserService) UpdateProfile(ctx context.Context, email string, initials
 
 	var r0 *model.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.User, error)); ok {
-		return rf(ctx, email, initials)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.UpdateUserProfileRequest) (*model.User, error)); ok {
+		return rf(ctx, email, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.User); ok {
-		r0 = rf(ctx, email, initials)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.UpdateUserProfileRequest) *model.User); ok {
+		r0 = rf(ctx, email, req)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, email, initials)
+	if rf, ok := ret.Get(1).(func(context.Context, string, *model.UpdateUserProfileRequest) error); ok {
+		r1 = rf(ctx, email, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -342,14 +569,14 @@ type UserService_UpdateProfile_Call struct {
 // UpdateProfile is a helper method to define mock.On call
 //   - ctx context.Context
 //   - email string
-//   - initials string
-func (_e *UserService_Expecter) UpdateProfile(ctx interface{}, email interface{}, initials interface{}) *UserService_UpdateProfile_Call {
-	return &UserService_UpdateProfile_Call{Call: _e.mock.On("UpdateProfile", ctx, email, initials)}
+//   - req *model.UpdateUserProfileRequest
+func (_e *UserService_Expecter) UpdateProfile(ctx interface{}, email interface{}, req interface{}) *UserService_UpdateProfile_Call {
+	return &UserService_UpdateProfile_Call{Call: _e.mock.On("UpdateProfile", ctx, email, req)}
 }
 
-func (_c *UserService_UpdateProfile_Call) Run(run func(ctx context.Context, email string, initials string)) *UserService_UpdateProfile_Call {
+func (_c *UserService_UpdateProfile_Call) Run(run func(ctx context.Context, email string, req *model.UpdateUserProfileRequest)) *UserService_UpdateProfile_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(*model.UpdateUserProfileRequest))
 	})
 	return _c
 }
@@ -359,7 +586,7 @@ func (_c *UserService_UpdateProfile_Call) Return(_a0 *model.User, _a1 error) *Us
 	return _c
 }
 
-func (_c *UserService_UpdateProfile_Call) RunAndReturn(run func(context.Context, string, string) (*model.User, error)) *UserService_UpdateProfile_Call {
+func (_c *UserService_UpdateProfile_Call) RunAndReturn(run func(context.Context, string, *model.UpdateUserProfileRequest) (*model.User, error)) *UserService_UpdateProfile_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -5,10 +5,9 @@ package mocks
 import (
 	context "context"
 
+	websocket "github.com/gorilla/websocket"
 	model "github.com/maxmorhardt/squares-api/internal/model"
 	mock "github.com/stretchr/testify/mock"
-
-	websocket "github.com/gorilla/websocket"
 )
 
 // WebSocketService is an autogenerated mock type for the WebSocketService type
@@ -24,6 +23,42 @@ func (_m *WebSocketService) EXPECT() *WebSocketService_Expecter {
 	return &WebSocketService_Expecter{mock: &_m.Mock}
 }
 
+// HandleSpectatorConnection provides a mock function with given fields: ctx, contest, spectatorToken, conn
+func (_m *WebSocketService) HandleSpectatorConnection(ctx context.Context, contest *model.Contest, spectatorToken *model.ContestSpectatorToken, conn *websocket.Conn) {
+	_m.Called(ctx, contest, spectatorToken, conn)
+}
+
+// WebSocketService_HandleSpectatorConnection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleSpectatorConnection'
+type WebSocketService_HandleSpectatorConnection_Call struct {
+	*mock.Call
+}
+
+// HandleSpectatorConnection is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contest *model.Contest
+//   - spectatorToken *model.ContestSpectatorToken
+//   - conn *websocket.Conn
+func (_e *WebSocketService_Expecter) HandleSpectatorConnection(ctx interface{}, contest interface{}, spectatorToken interface{}, conn interface{}) *WebSocketService_HandleSpectatorConnection_Call {
+	return &WebSocketService_HandleSpectatorConnection_Call{Call: _e.mock.On("HandleSpectatorConnection", ctx, contest, spectatorToken, conn)}
+}
+
+func (_c *WebSocketService_HandleSpectatorConnection_Call) Run(run func(ctx context.Context, contest *model.Contest, spectatorToken *model.ContestSpectatorToken, conn *websocket.Conn)) *WebSocketService_HandleSpectatorConnection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Contest), args[2].(*model.ContestSpectatorToken), args[3].(*websocket.Conn))
+	})
+	return _c
+}
+
+func (_c *WebSocketService_HandleSpectatorConnection_Call) Return() *WebSocketService_HandleSpectatorConnection_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *WebSocketService_HandleSpectatorConnection_Call) RunAndReturn(run func(context.Context, *model.Contest, *model.ContestSpectatorToken, *websocket.Conn)) *WebSocketService_HandleSpectatorConnection_Call {
+	_c.Run(run)
+	return _c
+}
+
 // HandleWebSocketConnection provides a mock function with given fields: ctx, contest, participants, conn
 func (_m *WebSocketService) HandleWebSocketConnection(ctx context.Context, contest *model.Contest, participants []model.ContestParticipant, conn *websocket.Conn) {
 	_m.Called(ctx, contest, participants, conn)
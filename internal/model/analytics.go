@@ -0,0 +1,39 @@
+package model
+
+import "github.com/google/uuid"
+
+type DigitFrequency struct {
+	Quarter   int
+	HomeDigit int
+	AwayDigit int
+	Count     int64
+}
+
+// per-quarter probability of each last-digit pair, indexed [quarter-1][homeDigit][awayDigit]
+type DigitProbabilityTable struct {
+	Quarters    [4][10][10]float64
+	SampleGames int64
+}
+
+type SquareAnalytics struct {
+	WinProbabilities []float64 `json:"winProbabilities"`
+	ExpectedWins     float64   `json:"expectedWins"`
+	ExpectedPayout   float64   `json:"expectedPayout"`
+}
+
+type SquareAnalyticsEntry struct {
+	SquareID  uuid.UUID       `json:"squareId"`
+	Row       int             `json:"row"`
+	Col       int             `json:"col"`
+	Owner     string          `json:"owner"`
+	HomeDigit *int            `json:"homeDigit,omitempty"`
+	AwayDigit *int            `json:"awayDigit,omitempty"`
+	Analytics SquareAnalytics `json:"analytics"`
+}
+
+type ContestAnalyticsResponse struct {
+	ContestID      uuid.UUID              `json:"contestId"`
+	LabelsAssigned bool                   `json:"labelsAssigned"`
+	SampleGames    int64                  `json:"sampleGames"`
+	Squares        []SquareAnalyticsEntry `json:"squares"`
+}
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// a finished contest's squares and results, moved out of the normalized tables once it's old enough
+type ContestArchive struct {
+	ContestID  uuid.UUID      `gorm:"type:uuid;primaryKey"`
+	Snapshot   datatypes.JSON `gorm:"type:jsonb;not null"`
+	ArchivedAt time.Time      `gorm:"not null"`
+}
+
+// participants are copied so the snapshot stands on its own, but their rows stay for access checks
+type ContestSnapshot struct {
+	XLabels        datatypes.JSON       `json:"xLabels"`
+	YLabels        datatypes.JSON       `json:"yLabels"`
+	Squares        []Square             `json:"squares"`
+	QuarterResults []QuarterResult      `json:"quarterResults"`
+	Participants   []ContestParticipant `json:"participants"`
+}
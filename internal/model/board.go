@@ -0,0 +1,27 @@
+package model
+
+type BoardFormat string
+
+const (
+	BoardFormatPNG BoardFormat = "png"
+	BoardFormatSVG BoardFormat = "svg"
+	BoardFormatPDF BoardFormat = "pdf"
+)
+
+func (f BoardFormat) ContentType() string {
+	switch f {
+	case BoardFormatSVG:
+		return "image/svg+xml"
+	case BoardFormatPDF:
+		return "application/pdf"
+	default:
+		return "image/png"
+	}
+}
+
+// a rendered board; ETag changes whenever anything drawn on it could have
+type BoardImage struct {
+	Data        []byte
+	ContentType string
+	ETag        string
+}
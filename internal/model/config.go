@@ -10,6 +10,7 @@ type AppConfig struct {
 	Turnstile TurnstileConfig
 	NATS      NATSConfig
 	Worker    WorkerConfig
+	Lifecycle LifecycleConfig
 }
 
 type ServerConfig struct {
@@ -17,6 +18,8 @@ type ServerConfig struct {
 	MetricsEnabled   bool     `env:"METRICS_ENABLED" envDefault:"false"`
 	AllowedOrigins   []string `env:"ALLOWED_ORIGINS" envDefault:"http://localhost:3000" envSeparator:","`
 	ContactRateLimit int      `env:"CONTACT_RATE_LIMIT" envDefault:"10"`
+	AppURL           string   `env:"APP_URL" envDefault:"http://localhost:3000"`
+	PublicAPIURL     string   `env:"PUBLIC_API_URL"`
 }
 
 type DatabaseConfig struct {
@@ -63,3 +66,12 @@ type WorkerConfig struct {
 	IdleInterval   time.Duration `env:"SCORES_IDLE_INTERVAL" envDefault:"6h"`
 	LockKey        int64         `env:"SCORES_LOCK_KEY" envDefault:"910011"`
 }
+
+type LifecycleConfig struct {
+	Enabled        bool          `env:"LIFECYCLE_ENABLED" envDefault:"true"`
+	Interval       time.Duration `env:"LIFECYCLE_INTERVAL" envDefault:"30s"`
+	LockKey        int64         `env:"LIFECYCLE_LOCK_KEY" envDefault:"910012"`
+	RestoreWindow  time.Duration `env:"CONTEST_RESTORE_WINDOW" envDefault:"168h"`
+	ArchiveAfter   time.Duration `env:"CONTEST_ARCHIVE_AFTER" envDefault:"720h"`
+	EventRetention time.Duration `env:"INVITE_EVENT_RETENTION" envDefault:"2160h"`
+}
@@ -15,24 +15,56 @@ const (
 	ContestVisibilityPublic  ContestVisibility = "public"
 )
 
+type FillPolicy string
+
+const (
+	FillPolicyNone     FillPolicy = "none"
+	FillPolicyRandom   FillPolicy = "random"
+	FillPolicyHouse    FillPolicy = "house"
+	FillPolicyRollover FillPolicy = "rollover"
+)
+
+type PaymentPolicy string
+
+const (
+	PaymentPolicyNone        PaymentPolicy = "none"
+	PaymentPolicyRequire     PaymentPolicy = "require"
+	PaymentPolicyClearUnpaid PaymentPolicy = "clear_unpaid"
+)
+
 type Contest struct {
-	ID             uuid.UUID         `json:"id" gorm:"type:uuid;primaryKey"`
-	Name           string            `json:"name"`
-	XLabels        datatypes.JSON    `json:"xLabels"`
-	YLabels        datatypes.JSON    `json:"yLabels"`
-	HomeTeam       string            `json:"homeTeam,omitempty"`
-	AwayTeam       string            `json:"awayTeam,omitempty"`
-	Squares        []Square          `json:"squares" gorm:"foreignKey:ContestID;constraint:OnDelete:CASCADE"`
-	QuarterResults []QuarterResult   `json:"quarterResults,omitempty" gorm:"foreignKey:ContestID;constraint:OnDelete:CASCADE"`
-	Owner          string            `json:"owner"`
-	Visibility     ContestVisibility `json:"visibility" gorm:"not null;default:private"`
-	Status         ContestStatus     `json:"status" gorm:"not null;default:ACTIVE"`
-	GameID         *uuid.UUID        `json:"gameId,omitempty" gorm:"type:uuid;index"`
-	Game           *Game             `json:"game,omitempty" gorm:"foreignKey:GameID;constraint:OnDelete:SET NULL"`
-	CreatedAt      time.Time         `json:"createdAt"`
-	UpdatedAt      time.Time         `json:"updatedAt"`
-	CreatedBy      string            `json:"createdBy"`
-	UpdatedBy      string            `json:"updatedBy"`
+	ID              uuid.UUID         `json:"id" gorm:"type:uuid;primaryKey"`
+	Name            string            `json:"name"`
+	XLabels         datatypes.JSON    `json:"xLabels"`
+	YLabels         datatypes.JSON    `json:"yLabels"`
+	HomeTeam        string            `json:"homeTeam,omitempty"`
+	AwayTeam        string            `json:"awayTeam,omitempty"`
+	Squares         []Square          `json:"squares" gorm:"foreignKey:ContestID;constraint:OnDelete:CASCADE"`
+	QuarterResults  []QuarterResult   `json:"quarterResults,omitempty" gorm:"foreignKey:ContestID;constraint:OnDelete:CASCADE"`
+	Owner           string            `json:"owner"`
+	Visibility      ContestVisibility `json:"visibility" gorm:"not null;default:private"`
+	Status          ContestStatus     `json:"status" gorm:"not null;default:ACTIVE"`
+	GameID          *uuid.UUID        `json:"gameId,omitempty" gorm:"type:uuid;index"`
+	OrgID           *uuid.UUID        `json:"orgId,omitempty" gorm:"type:uuid;index"`
+	Game            *Game             `json:"game,omitempty" gorm:"foreignKey:GameID;constraint:OnDelete:SET NULL"`
+	LockAt          *time.Time        `json:"lockAt,omitempty"`
+	FillPolicy      FillPolicy        `json:"fillPolicy" gorm:"not null;default:none"`
+	PaymentPolicy   PaymentPolicy     `json:"paymentPolicy" gorm:"not null;default:none"`
+	Rollover        bool              `json:"rollover" gorm:"not null;default:false"`
+	Version         int               `json:"version" gorm:"not null;default:1"`
+	DeletedAt       *time.Time        `json:"deletedAt,omitempty"`
+	PreDeleteStatus *ContestStatus    `json:"-"`
+	ArchivedAt      *time.Time        `json:"archivedAt,omitempty"`
+	ImportedAt      *time.Time        `json:"importedAt,omitempty"`
+	CreatedAt       time.Time         `json:"createdAt"`
+	UpdatedAt       time.Time         `json:"updatedAt"`
+	CreatedBy       string            `json:"createdBy"`
+	UpdatedBy       string            `json:"updatedBy"`
+}
+
+// unowned winning squares carry their prize forward; leaving squares empty on purpose implies it
+func (c *Contest) RollsOver() bool {
+	return c.Rollover || c.FillPolicy == FillPolicyRollover
 }
 
 func (c *Contest) BeforeCreate(tx *gorm.DB) (err error) {
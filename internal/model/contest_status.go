@@ -75,7 +75,7 @@ func (cs ContestStatus) CanTransitionTo(target ContestStatus) bool {
 		ContestStatusQ3:       {ContestStatusQ4},
 		ContestStatusQ4:       {ContestStatusFinished},
 		ContestStatusFinished: {},
-		ContestStatusDeleted:  {},
+		ContestStatusDeleted:  {ContestStatusActive, ContestStatusQ1, ContestStatusQ2, ContestStatusQ3, ContestStatusQ4},
 	}
 
 	allowedTargets, exists := validTransitions[cs]
@@ -21,3 +21,9 @@ func NewAPIError(code int, message string, c *gin.Context) APIError {
 		RequestID: c.GetString(RequestIDKey),
 	}
 }
+
+// carries the contest as it stands now so a client that lost a version race can reconcile without refetching
+type ContestConflictError struct {
+	APIError
+	Contest *Contest `json:"contest,omitempty"`
+}
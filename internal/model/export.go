@@ -0,0 +1,82 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// bumped whenever the document shape changes in a way older importers can't read
+const ContestExportSchemaVersion = 1
+
+// a portable copy of one contest; ids are only informational, imports always get fresh ones
+type ContestExport struct {
+	SchemaVersion  int                     `json:"schemaVersion" binding:"required"`
+	ExportedAt     time.Time               `json:"exportedAt"`
+	Contest        ExportedContest         `json:"contest" binding:"required"`
+	Squares        []ExportedSquare        `json:"squares" binding:"max=100,dive"`
+	QuarterResults []ExportedQuarterResult `json:"quarterResults" binding:"max=4,dive"`
+	Participants   []ExportedParticipant   `json:"participants" binding:"max=100,dive"`
+	Invites        []ExportedInvite        `json:"invites,omitempty"`
+}
+
+type ExportedContest struct {
+	ID            uuid.UUID  `json:"id"`
+	Name          string     `json:"name" binding:"required,max=20,min=1,safestring"`
+	XLabels       []int8     `json:"xLabels" binding:"len=10"`
+	YLabels       []int8     `json:"yLabels" binding:"len=10"`
+	HomeTeam      string     `json:"homeTeam,omitempty" binding:"max=20,safestring"`
+	AwayTeam      string     `json:"awayTeam,omitempty" binding:"max=20,safestring"`
+	Owner         string     `json:"owner" binding:"max=255"`
+	Visibility    string     `json:"visibility" binding:"omitempty,oneof=private public"`
+	Status        string     `json:"status" binding:"required,oneof=ACTIVE Q1 Q2 Q3 Q4 FINISHED"`
+	LockAt        *time.Time `json:"lockAt,omitempty"`
+	FillPolicy    string     `json:"fillPolicy" binding:"omitempty,oneof=none random house rollover"`
+	PaymentPolicy string     `json:"paymentPolicy,omitempty" binding:"omitempty,oneof=none require clear_unpaid"`
+	Rollover      bool       `json:"rollover"`
+}
+
+type ExportedSquare struct {
+	Row       int    `json:"row" binding:"min=0,max=9"`
+	Col       int    `json:"col" binding:"min=0,max=9"`
+	Value     string `json:"value" binding:"max=3,safestring"`
+	Owner     string `json:"owner" binding:"max=255,safestring"`
+	OwnerName string `json:"ownerName" binding:"max=255,safestring"`
+}
+
+type ExportedQuarterResult struct {
+	Quarter       int    `json:"quarter" binding:"min=1,max=4"`
+	HomeTeamScore int    `json:"homeTeamScore" binding:"min=0,max=9999"`
+	AwayTeamScore int    `json:"awayTeamScore" binding:"min=0,max=9999"`
+	WinnerRow     int    `json:"winnerRow" binding:"min=-1,max=9"`
+	WinnerCol     int    `json:"winnerCol" binding:"min=-1,max=9"`
+	Winner        string `json:"winner" binding:"max=255,safestring"`
+	WinnerName    string `json:"winnerName" binding:"max=255,safestring"`
+	RolledOver    bool   `json:"rolledOver"`
+	CarriedOver   int    `json:"carriedOver" binding:"min=0"`
+	Fallback      bool   `json:"fallback"`
+}
+
+type ExportedParticipant struct {
+	UserID          string     `json:"userId" binding:"required,email,max=255"`
+	Role            string     `json:"role" binding:"required,oneof=owner participant viewer"`
+	MaxSquares      int        `json:"maxSquares" binding:"min=0,max=100"`
+	Paid            bool       `json:"paid,omitempty"`
+	AmountPaidCents int        `json:"amountPaidCents,omitempty" binding:"min=0,max=100000000"`
+	PaidAt          *time.Time `json:"paidAt,omitempty"`
+	JoinedAt        time.Time  `json:"joinedAt"`
+}
+
+// invite links only work where they were issued, so tokens stay out and imports skip these
+type ExportedInvite struct {
+	Role           string     `json:"role"`
+	MaxSquares     int        `json:"maxSquares"`
+	MaxUses        int        `json:"maxUses"`
+	Uses           int        `json:"uses"`
+	ExpiresAt      *time.Time `json:"expiresAt,omitempty"`
+	Paused         bool       `json:"paused,omitempty"`
+	AllowedDomains []string   `json:"allowedDomains,omitempty"`
+	AllowedEmails  []string   `json:"allowedEmails,omitempty"`
+	CreatedBy      string     `json:"createdBy"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
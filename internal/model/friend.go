@@ -0,0 +1,58 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type FriendshipStatus string
+
+const (
+	FriendshipStatusPending  FriendshipStatus = "pending"
+	FriendshipStatusAccepted FriendshipStatus = "accepted"
+)
+
+// a friend request from requester to addressee; once accepted it links both users symmetrically
+type Friendship struct {
+	ID         uuid.UUID        `json:"id" gorm:"type:uuid;primaryKey"`
+	Requester  string           `json:"requester" gorm:"not null"`
+	Addressee  string           `json:"addressee" gorm:"not null"`
+	Status     FriendshipStatus `json:"status" gorm:"not null;default:pending"`
+	AcceptedAt *time.Time       `json:"acceptedAt,omitempty"`
+	CreatedAt  time.Time        `json:"createdAt"`
+}
+
+func (f *Friendship) BeforeCreate(tx *gorm.DB) error {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return nil
+}
+
+// the other side of the friendship from the given user's point of view
+func (f *Friendship) Other(user string) string {
+	if strings.EqualFold(f.Requester, user) {
+		return f.Addressee
+	}
+	return f.Requester
+}
+
+type Friend struct {
+	UserID      string    `json:"userId" example:"friend@example.com"`
+	DisplayName string    `json:"displayName" example:"Max"`
+	Since       time.Time `json:"since"`
+}
+
+type FriendRequestsResponse struct {
+	Incoming []Friendship `json:"incoming"`
+	Outgoing []Friendship `json:"outgoing"`
+}
+
+type UserSearchResult struct {
+	UserID      string `json:"userId" example:"friend@example.com"`
+	DisplayName string `json:"displayName" example:"Max"`
+	Friend      bool   `json:"friend"`
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// the first response to a request carrying an Idempotency-Key; a zero status code means it's still in flight.
+// only the headers a client acts on, like the ETag it sends back as If-Match, are kept with the body
+type IdempotencyKey struct {
+	UserID          string            `gorm:"primaryKey"`
+	Key             string            `gorm:"primaryKey"`
+	RequestHash     string            `gorm:"not null"`
+	StatusCode      int               `gorm:"not null;default:0"`
+	ResponseBody    []byte            `gorm:"type:bytea"`
+	ResponseHeaders map[string]string `gorm:"type:jsonb;serializer:json"`
+	CreatedAt       time.Time         `gorm:"not null"`
+	ExpiresAt       time.Time         `gorm:"not null;index"`
+}
+
+func (k *IdempotencyKey) Completed() bool {
+	return k.StatusCode != 0
+}
@@ -3,24 +3,81 @@ package model
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
+type InviteEmailStatus string
+
+const (
+	InviteEmailStatusPending  InviteEmailStatus = "pending"
+	InviteEmailStatusSent     InviteEmailStatus = "sent"
+	InviteEmailStatusFailed   InviteEmailStatus = "failed"
+	InviteEmailStatusOpened   InviteEmailStatus = "opened"
+	InviteEmailStatusRedeemed InviteEmailStatus = "redeemed"
+)
+
 type ContestInvite struct {
-	ID         uuid.UUID       `json:"id" gorm:"type:uuid;primaryKey"`
-	ContestID  uuid.UUID       `json:"contestId" gorm:"type:uuid;index;not null"`
-	Token      string          `json:"token" gorm:"uniqueIndex;not null"`
-	MaxSquares int             `json:"maxSquares" gorm:"not null"`
-	Role       ParticipantRole `json:"role" gorm:"not null;default:participant"`
-	CreatedBy  string          `json:"createdBy" gorm:"not null"`
-	ExpiresAt  *time.Time      `json:"expiresAt,omitempty"`
-	MaxUses    int             `json:"maxUses" gorm:"not null;default:0"`
-	Uses       int             `json:"uses" gorm:"not null;default:0"`
-	CreatedAt  time.Time       `json:"createdAt"`
-	UpdatedAt  time.Time       `json:"updatedAt"`
+	ID              uuid.UUID                   `json:"id" gorm:"type:uuid;primaryKey"`
+	ContestID       uuid.UUID                   `json:"contestId" gorm:"type:uuid;index;not null"`
+	Token           string                      `json:"token" gorm:"uniqueIndex;not null"`
+	MaxSquares      int                         `json:"maxSquares" gorm:"not null"`
+	Role            ParticipantRole             `json:"role" gorm:"not null;default:participant"`
+	CreatedBy       string                      `json:"createdBy" gorm:"not null"`
+	ExpiresAt       *time.Time                  `json:"expiresAt,omitempty"`
+	MaxUses         int                         `json:"maxUses" gorm:"not null;default:0"`
+	Uses            int                         `json:"uses" gorm:"not null;default:0"`
+	Paused          bool                        `json:"paused" gorm:"not null;default:false"`
+	AllowedDomains  datatypes.JSONSlice[string] `json:"allowedDomains,omitempty" swaggertype:"array,string"`
+	AllowedEmails   datatypes.JSONSlice[string] `json:"allowedEmails,omitempty" swaggertype:"array,string"`
+	Email           string                      `json:"email,omitempty" gorm:"not null;default:''"`
+	EmailStatus     InviteEmailStatus           `json:"emailStatus,omitempty" gorm:"not null;default:''"`
+	SentAt          *time.Time                  `json:"sentAt,omitempty"`
+	OpenedAt        *time.Time                  `json:"openedAt,omitempty"`
+	RedeemedAt      *time.Time                  `json:"redeemedAt,omitempty"`
+	CreatedAt       time.Time                   `json:"createdAt"`
+	UpdatedAt       time.Time                   `json:"updatedAt"`
+	Funnel          *InviteFunnel               `json:"funnel,omitempty" gorm:"-"`
+	ReservedSquares []SquarePosition            `json:"reservedSquares,omitempty" gorm:"-"`
+}
+
+type InviteEventKind string
+
+const (
+	InviteEventView   InviteEventKind = "view"
+	InviteEventRedeem InviteEventKind = "redeem"
+)
+
+// one row per preview or redemption so owners can see which link actually worked
+type ContestInviteEvent struct {
+	ID        uuid.UUID       `json:"id" gorm:"type:uuid;primaryKey"`
+	InviteID  uuid.UUID       `json:"inviteId" gorm:"type:uuid;index;not null"`
+	ContestID uuid.UUID       `json:"contestId" gorm:"type:uuid;index;not null"`
+	Kind      InviteEventKind `json:"kind" gorm:"not null"`
+	UserID    string          `json:"userId,omitempty"`
+	Visitor   string          `json:"-"`
+	ViewedOn  *time.Time      `json:"-" gorm:"type:date"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+func (e *ContestInviteEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+type InviteFunnel struct {
+	Views          int        `json:"views"`
+	Redemptions    int        `json:"redemptions"`
+	ConversionRate float64    `json:"conversionRate"`
+	FirstViewedAt  *time.Time `json:"firstViewedAt,omitempty"`
+	LastViewedAt   *time.Time `json:"lastViewedAt,omitempty"`
+	LastRedeemedAt *time.Time `json:"lastRedeemedAt,omitempty"`
 }
 
 func (i *ContestInvite) BeforeCreate(tx *gorm.DB) (err error) {
@@ -40,6 +97,41 @@ func (i *ContestInvite) IsExpired() bool {
 	return time.Now().After(*i.ExpiresAt)
 }
 
+// personal invites are bound to one recipient address; bearer invites have no email
+func (i *ContestInvite) IsPersonal() bool {
+	return i.Email != ""
+}
+
+func (i *ContestInvite) IsRestricted() bool {
+	return len(i.AllowedDomains) > 0 || len(i.AllowedEmails) > 0
+}
+
+// an address passes if it is on the allowlist or its domain matches exactly; subdomains must be listed on their own
+func (i *ContestInvite) AllowsEmail(email string) bool {
+	if !i.IsRestricted() {
+		return true
+	}
+
+	for _, allowed := range i.AllowedEmails {
+		if strings.EqualFold(allowed, email) {
+			return true
+		}
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := email[at+1:]
+	for _, allowed := range i.AllowedDomains {
+		if strings.EqualFold(allowed, domain) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (i *ContestInvite) HasUsesRemaining() bool {
 	if i.MaxUses == 0 {
 		return true
@@ -8,4 +8,5 @@ const (
 	LoggerKey       CTXKey = "logger"
 	ClaimsKey       CTXKey = "claims"
 	ConnectionIDKey CTXKey = "connection_id"
+	IfMatchKey      CTXKey = "if_match"
 )
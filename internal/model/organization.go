@@ -0,0 +1,47 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type OrgRole string
+
+const (
+	OrgRoleAdmin  OrgRole = "admin"
+	OrgRoleMember OrgRole = "member"
+)
+
+// a group that runs many pools; its admins manage every contest it owns without joining each one
+type Organization struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	Name      string    `json:"name" gorm:"not null"`
+	CreatedBy string    `json:"createdBy" gorm:"not null"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (o *Organization) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	return nil
+}
+
+type OrganizationMember struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	OrgID     uuid.UUID `json:"orgId" gorm:"type:uuid;index;not null"`
+	UserID    string    `json:"userId" gorm:"not null"`
+	Role      OrgRole   `json:"role" gorm:"not null;default:member"`
+	AddedBy   string    `json:"addedBy" gorm:"not null"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (m *OrganizationMember) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
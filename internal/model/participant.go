@@ -16,15 +16,20 @@ const (
 )
 
 type ContestParticipant struct {
-	ID         uuid.UUID       `json:"id" gorm:"type:uuid;primaryKey"`
-	ContestID  uuid.UUID       `json:"contestId" gorm:"type:uuid;index;not null"`
-	UserID     string          `json:"userId" gorm:"not null;index"`
-	Role       ParticipantRole `json:"role" gorm:"not null"`
-	MaxSquares int             `json:"maxSquares" gorm:"not null;default:0"`
-	InviteID   *uuid.UUID      `json:"inviteId,omitempty" gorm:"type:uuid"`
-	JoinedAt   time.Time       `json:"joinedAt"`
-	CreatedAt  time.Time       `json:"createdAt"`
-	UpdatedAt  time.Time       `json:"updatedAt"`
+	ID              uuid.UUID       `json:"id" gorm:"type:uuid;primaryKey"`
+	ContestID       uuid.UUID       `json:"contestId" gorm:"type:uuid;index;not null"`
+	UserID          string          `json:"userId" gorm:"not null;index"`
+	Role            ParticipantRole `json:"role" gorm:"not null"`
+	MaxSquares      int             `json:"maxSquares" gorm:"not null;default:0"`
+	InviteID        *uuid.UUID      `json:"inviteId,omitempty" gorm:"type:uuid"`
+	Paid            bool            `json:"paid,omitempty" gorm:"not null;default:false"`
+	AmountPaidCents int             `json:"amountPaidCents,omitempty" gorm:"not null;default:0"`
+	PaidAt          *time.Time      `json:"paidAt,omitempty"`
+	DisplayValue    *string         `json:"displayValue,omitempty"`
+	Color           *string         `json:"color,omitempty"`
+	JoinedAt        time.Time       `json:"joinedAt"`
+	CreatedAt       time.Time       `json:"createdAt"`
+	UpdatedAt       time.Time       `json:"updatedAt"`
 }
 
 func (p *ContestParticipant) BeforeCreate(tx *gorm.DB) (err error) {
@@ -36,3 +41,48 @@ func (p *ContestParticipant) BeforeCreate(tx *gorm.DB) (err error) {
 	}
 	return
 }
+
+// what this participant's squares show, preferring their per-contest overrides to the profile initials
+func (p *ContestParticipant) SquareStyle(defaultValue string) (value, color string) {
+	value = defaultValue
+	if p.DisplayValue != nil {
+		value = *p.DisplayValue
+	}
+	if p.Color != nil {
+		color = *p.Color
+	}
+
+	return value, color
+}
+
+// the organiser's payment tally; the owner never owes for their own squares
+type PaymentSummary struct {
+	SquareOwners   int      `json:"squareOwners"`
+	Paid           int      `json:"paid"`
+	Unpaid         int      `json:"unpaid"`
+	CollectedCents int      `json:"collectedCents"`
+	UnpaidSquares  int      `json:"unpaidSquares"`
+	UnpaidUserIDs  []string `json:"unpaidUserIds"`
+}
+
+const (
+	BanSquaresGhost = "ghost"
+	BanSquaresClear = "clear"
+)
+
+// a banned user can't rejoin through an invite or watch a public contest until the ban is lifted
+type ContestBan struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	ContestID uuid.UUID `json:"contestId" gorm:"type:uuid;index;not null"`
+	UserID    string    `json:"userId" gorm:"not null"`
+	Reason    string    `json:"reason,omitempty" gorm:"not null;default:''"`
+	BannedBy  string    `json:"bannedBy" gorm:"not null"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (b *ContestBan) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package model
+
+// what the import would do to one participant already on, or new to, the contest
+type ParticipantImportAction string
+
+const (
+	ParticipantImportAdd       ParticipantImportAction = "add"
+	ParticipantImportUpdate    ParticipantImportAction = "update"
+	ParticipantImportUnchanged ParticipantImportAction = "unchanged"
+)
+
+type ParticipantImportChange struct {
+	UserID             string                  `json:"userId"`
+	Action             ParticipantImportAction `json:"action"`
+	Role               ParticipantRole         `json:"role"`
+	MaxSquares         int                     `json:"maxSquares"`
+	PreviousRole       ParticipantRole         `json:"previousRole,omitempty"`
+	PreviousMaxSquares *int                    `json:"previousMaxSquares,omitempty"`
+}
+
+type SquareImportClaim struct {
+	Row   int    `json:"row"`
+	Col   int    `json:"col"`
+	Owner string `json:"owner"`
+}
+
+// line 0 means the problem is with the file as a whole rather than one row
+type ImportRowError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+type ParticipantImportPreview struct {
+	Participants   []ParticipantImportChange `json:"participants"`
+	Squares        []SquareImportClaim       `json:"squares"`
+	Errors         []ImportRowError          `json:"errors"`
+	TotalAllocated int                       `json:"totalAllocated"`
+	Applied        bool                      `json:"applied"`
+}
+
+func (p *ParticipantImportPreview) Valid() bool {
+	return len(p.Errors) == 0
+}
@@ -17,6 +17,9 @@ type QuarterResult struct {
 	WinnerCol     int       `json:"winnerCol"`
 	Winner        string    `json:"winner"`
 	WinnerName    string    `json:"winnerName"`
+	RolledOver    bool      `json:"rolledOver" gorm:"not null;default:false"`
+	CarriedOver   int       `json:"carriedOver" gorm:"not null;default:0"`
+	Fallback      bool      `json:"fallback" gorm:"not null;default:false"`
 	CreatedAt     time.Time `json:"createdAt"`
 	UpdatedAt     time.Time `json:"updatedAt"`
 	CreatedBy     string    `json:"createdBy"`
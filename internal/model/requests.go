@@ -1,25 +1,59 @@
 package model
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
 type CreateContestRequest struct {
-	Owner      string `json:"owner" binding:"required,max=255,safestring"`
-	Name       string `json:"name" binding:"required,max=20,min=1,safestring"`
-	HomeTeam   string `json:"homeTeam,omitempty" binding:"max=20,safestring"`
-	AwayTeam   string `json:"awayTeam,omitempty" binding:"max=20,safestring"`
-	Visibility string `json:"visibility,omitempty" binding:"omitempty,oneof=private public"`
-	MaxSquares int    `json:"maxSquares" binding:"min=0,max=100"`
-	GameID     string `json:"gameId,omitempty" binding:"omitempty,uuid"`
+	Owner         string     `json:"owner" binding:"required,max=255,safestring"`
+	Name          string     `json:"name" binding:"required,max=20,min=1,safestring"`
+	HomeTeam      string     `json:"homeTeam,omitempty" binding:"max=20,safestring"`
+	AwayTeam      string     `json:"awayTeam,omitempty" binding:"max=20,safestring"`
+	Visibility    string     `json:"visibility,omitempty" binding:"omitempty,oneof=private public"`
+	MaxSquares    int        `json:"maxSquares" binding:"min=0,max=100"`
+	GameID        string     `json:"gameId,omitempty" binding:"omitempty,uuid"`
+	OrgID         string     `json:"orgId,omitempty" binding:"omitempty,uuid"`
+	AddOrgMembers bool       `json:"addOrgMembers,omitempty"`
+	MemberSquares int        `json:"memberSquares,omitempty" binding:"min=0,max=100"` // per member when addOrgMembers is set; 0 adds them as viewers
+	LockAt        *time.Time `json:"lockAt,omitempty"`
+	FillPolicy    string     `json:"fillPolicy,omitempty" binding:"omitempty,oneof=none random house rollover"`
+	PaymentPolicy string     `json:"paymentPolicy,omitempty" binding:"omitempty,oneof=none require clear_unpaid"`
+	Rollover      bool       `json:"rollover,omitempty"`
 }
 
 type UpdateUserProfileRequest struct {
-	DefaultInitials string `json:"defaultInitials" binding:"required,min=1,max=3,uppercase,alphanum,safestring"`
+	DefaultInitials   *string `json:"defaultInitials,omitempty" binding:"omitempty,min=1,max=3,uppercase,alphanum,safestring"`
+	DisplayName       *string `json:"displayName,omitempty" binding:"omitempty,min=1,max=50,safestring"`
+	ProfileVisibility *string `json:"profileVisibility,omitempty" binding:"omitempty,oneof=public friends private"`
 }
 
 type ClearSquareRequest struct{}
 
+type ClaimSquaresRequest struct {
+	SquareIDs []uuid.UUID `json:"squareIds" binding:"required,min=1,max=100"`
+}
+
+type AssignSquareRequest struct {
+	UserID   string `json:"userId" binding:"required,max=255,safestring"`
+	Override bool   `json:"override,omitempty"`
+}
+
+type CreateSwapRequest struct {
+	FromSquareID uuid.UUID `json:"fromSquareId" binding:"required"`
+	ToSquareID   uuid.UUID `json:"toSquareId" binding:"required"`
+}
+
 type UpdateContestRequest struct {
-	HomeTeam   *string `json:"homeTeam,omitempty" binding:"omitempty,max=20,safestring"`
-	AwayTeam   *string `json:"awayTeam,omitempty" binding:"omitempty,max=20,safestring"`
-	Visibility *string `json:"visibility,omitempty" binding:"omitempty,oneof=private public"`
+	HomeTeam      *string    `json:"homeTeam,omitempty" binding:"omitempty,max=20,safestring"`
+	AwayTeam      *string    `json:"awayTeam,omitempty" binding:"omitempty,max=20,safestring"`
+	Visibility    *string    `json:"visibility,omitempty" binding:"omitempty,oneof=private public"`
+	LockAt        *time.Time `json:"lockAt,omitempty"`
+	ClearLockAt   bool       `json:"clearLockAt,omitempty"`
+	FillPolicy    *string    `json:"fillPolicy,omitempty" binding:"omitempty,oneof=none random house rollover"`
+	PaymentPolicy *string    `json:"paymentPolicy,omitempty" binding:"omitempty,oneof=none require clear_unpaid"`
+	Rollover      *bool      `json:"rollover,omitempty"`
 }
 
 type QuarterResultRequest struct {
@@ -28,10 +62,33 @@ type QuarterResultRequest struct {
 }
 
 type CreateInviteRequest struct {
-	MaxSquares int    `json:"maxSquares" binding:"min=0,max=100"`
-	Role       string `json:"role" binding:"required,oneof=participant viewer"`
-	MaxUses    int    `json:"maxUses,omitempty" binding:"min=0"`
-	ExpiresIn  int    `json:"expiresIn,omitempty" binding:"min=0"` // minutes, 0 = no expiry
+	MaxSquares      int              `json:"maxSquares" binding:"min=0,max=100"`
+	Role            string           `json:"role" binding:"required,oneof=participant viewer"`
+	MaxUses         int              `json:"maxUses,omitempty" binding:"min=0"`
+	ExpiresIn       int              `json:"expiresIn,omitempty" binding:"min=0"` // minutes, 0 = no expiry
+	AllowedDomains  []string         `json:"allowedDomains,omitempty" binding:"omitempty,max=20,dive,fqdn,max=253"`
+	AllowedEmails   []string         `json:"allowedEmails,omitempty" binding:"omitempty,max=100,dive,email,max=254"`
+	ReservedSquares []SquarePosition `json:"reservedSquares,omitempty" binding:"omitempty,max=100,dive"`
+}
+
+type UpdateInviteRequest struct {
+	MaxSquares *int    `json:"maxSquares,omitempty" binding:"omitempty,min=0,max=100"`
+	Role       *string `json:"role,omitempty" binding:"omitempty,oneof=participant viewer"`
+	MaxUses    *int    `json:"maxUses,omitempty" binding:"omitempty,min=0"`
+	ExpiresIn  *int    `json:"expiresIn,omitempty" binding:"omitempty,min=0"` // minutes from now, 0 = no expiry
+	Paused     *bool   `json:"paused,omitempty"`
+}
+
+type CreateEmailInvitesRequest struct {
+	Emails     []string `json:"emails" binding:"required,min=1,max=50,dive,required,email,max=254"`
+	MaxSquares int      `json:"maxSquares" binding:"min=0,max=100"`
+	Role       string   `json:"role" binding:"required,oneof=participant viewer"`
+	ExpiresIn  int      `json:"expiresIn,omitempty" binding:"min=0"` // minutes, 0 = no expiry
+}
+
+type CreateSpectatorTokenRequest struct {
+	Label     string `json:"label,omitempty" binding:"max=50,safestring"`
+	ExpiresIn int    `json:"expiresIn,omitempty" binding:"min=0"` // minutes, 0 = no expiry
 }
 
 type UpdateParticipantRequest struct {
@@ -39,6 +96,47 @@ type UpdateParticipantRequest struct {
 	MaxSquares *int    `json:"maxSquares,omitempty" binding:"omitempty,min=0,max=100"`
 }
 
+// colours come from a fixed palette the clients know how to theme
+type UpdateSquareStyleRequest struct {
+	DisplayValue      *string `json:"displayValue,omitempty" binding:"omitempty,min=1,max=3,uppercase,alphanum,safestring"`
+	ClearDisplayValue bool    `json:"clearDisplayValue,omitempty"`
+	Color             *string `json:"color,omitempty" binding:"omitempty,oneof=red orange amber green teal blue indigo purple pink slate"`
+	ClearColor        bool    `json:"clearColor,omitempty"`
+}
+
+type UpdatePaymentRequest struct {
+	Paid            *bool `json:"paid" binding:"required"`
+	AmountPaidCents *int  `json:"amountPaidCents,omitempty" binding:"omitempty,min=0,max=100000000"`
+}
+
+type BanParticipantRequest struct {
+	UserID  string `json:"userId" binding:"required,max=254"`
+	Reason  string `json:"reason,omitempty" binding:"max=255,safestring"`
+	Squares string `json:"squares,omitempty" binding:"omitempty,oneof=ghost clear"` // empty clears before kickoff and ghosts after
+}
+
+type CreateOrganizationRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=50,safestring"`
+}
+
+type AddOrgMemberRequest struct {
+	UserID string `json:"userId" binding:"required,email,max=255,safestring"`
+	Role   string `json:"role,omitempty" binding:"omitempty,oneof=admin member"`
+}
+
+type UpdateOrgMemberRequest struct {
+	Role string `json:"role" binding:"required,oneof=admin member"`
+}
+
+type FriendRequest struct {
+	UserID string `json:"userId" binding:"required,email,max=255,safestring"`
+}
+
+type AddFriendParticipantRequest struct {
+	UserID     string `json:"userId" binding:"required,email,max=255"`
+	MaxSquares int    `json:"maxSquares" binding:"required,min=1,max=100"`
+}
+
 type ContactRequest struct {
 	Name           string `json:"name" binding:"required,min=1,max=100,safestring"`
 	Email          string `json:"email" binding:"required,email,max=255,safestring"`
@@ -53,10 +53,31 @@ type LeaderboardRankResponse struct {
 }
 
 type UserProfileResponse struct {
-	Email           string `json:"email" example:"user@example.com"`
-	DisplayName     string `json:"displayName" example:"Max"`
-	DefaultInitials string `json:"defaultInitials" example:"MM"`
-	CreatedAt       string `json:"createdAt" example:"2026-07-11T00:00:00Z"`
+	ID                string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Email             string `json:"email" example:"user@example.com"`
+	DisplayName       string `json:"displayName" example:"Max"`
+	DefaultInitials   string `json:"defaultInitials" example:"MM"`
+	ProfileVisibility string `json:"profileVisibility" example:"friends"`
+	AvatarURL         string `json:"avatarUrl,omitempty" example:"/users/550e8400-e29b-41d4-a716-446655440000/avatar?v=1783728000"`
+	CreatedAt         string `json:"createdAt" example:"2026-07-11T00:00:00Z"`
+}
+
+// what other players see; never carries the email
+type PublicProfileResponse struct {
+	ID             string                 `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	DisplayName    string                 `json:"displayName" example:"Max"`
+	AvatarURL      string                 `json:"avatarUrl,omitempty" example:"/users/550e8400-e29b-41d4-a716-446655440000/avatar?v=1783728000"`
+	MemberSince    string                 `json:"memberSince" example:"2026-07-11T00:00:00Z"`
+	Stats          UserStatsResponse      `json:"stats"`
+	RecentContests []PublicProfileContest `json:"recentContests"`
+}
+
+type PublicProfileContest struct {
+	ID        string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name      string `json:"name" example:"test"`
+	Status    string `json:"status" example:"FINISHED"`
+	Role      string `json:"role" example:"participant"`
+	CreatedAt string `json:"createdAt" example:"2026-07-11T00:00:00Z"`
 }
 
 type UserActiveContest struct {
@@ -75,9 +96,13 @@ type UserStatsResponse struct {
 }
 
 type InvitePreviewResponse struct {
-	ContestID   uuid.UUID `json:"contestId"`
-	ContestName string    `json:"contestName"`
-	Owner       string    `json:"owner"`
-	Role        string    `json:"role"`
-	MaxSquares  int       `json:"maxSquares"`
+	ContestID       uuid.UUID        `json:"contestId"`
+	ContestName     string           `json:"contestName"`
+	Owner           string           `json:"owner"`
+	Role            string           `json:"role"`
+	MaxSquares      int              `json:"maxSquares"`
+	Email           string           `json:"email,omitempty"`      // set when the invite is bound to one recipient
+	Restricted      bool             `json:"restricted,omitempty"` // the allowed address list itself stays private
+	AllowedDomains  []string         `json:"allowedDomains,omitempty"`
+	ReservedSquares []SquarePosition `json:"reservedSquares,omitempty"`
 }
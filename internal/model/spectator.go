@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// a read-only link for people without an account; unlike invites it never becomes a participant
+type ContestSpectatorToken struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	ContestID uuid.UUID  `json:"contestId" gorm:"type:uuid;index;not null"`
+	Token     string     `json:"token" gorm:"uniqueIndex;not null"`
+	Label     string     `json:"label,omitempty"`
+	CreatedBy string     `json:"createdBy" gorm:"not null"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+func (t *ContestSpectatorToken) BeforeCreate(tx *gorm.DB) (err error) {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	if t.Token == "" {
+		t.Token, err = generateToken()
+	}
+	return
+}
+
+func (t *ContestSpectatorToken) IsExpired() bool {
+	if t.ExpiresAt == nil {
+		return false
+	}
+	return time.Now().After(*t.ExpiresAt)
+}
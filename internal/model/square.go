@@ -8,17 +8,21 @@ import (
 )
 
 type Square struct {
-	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
-	ContestID uuid.UUID `json:"contestId" gorm:"type:uuid;index"`
-	Row       int       `json:"row"`
-	Col       int       `json:"col"`
-	Value     string    `json:"value"`
-	Owner     string    `json:"owner"`
-	OwnerName string    `json:"ownerName"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
-	CreatedBy string    `json:"createdBy"`
-	UpdatedBy string    `json:"updatedBy"`
+	ID               uuid.UUID        `json:"id" gorm:"type:uuid;primaryKey"`
+	ContestID        uuid.UUID        `json:"contestId" gorm:"type:uuid;index"`
+	Row              int              `json:"row"`
+	Col              int              `json:"col"`
+	Value            string           `json:"value"`
+	Owner            string           `json:"owner"`
+	OwnerName        string           `json:"ownerName"`
+	Color            string           `json:"color,omitempty" gorm:"not null;default:''"`
+	ReservedInviteID *uuid.UUID       `json:"reservedInviteId,omitempty" gorm:"type:uuid"`
+	Version          int              `json:"version" gorm:"not null;default:1"`
+	Analytics        *SquareAnalytics `json:"analytics,omitempty" gorm:"-"`
+	CreatedAt        time.Time        `json:"createdAt"`
+	UpdatedAt        time.Time        `json:"updatedAt"`
+	CreatedBy        string           `json:"createdBy"`
+	UpdatedBy        string           `json:"updatedBy"`
 }
 
 func (s *Square) BeforeCreate(tx *gorm.DB) (err error) {
@@ -41,3 +45,8 @@ func (s *Square) BeforeUpdate(tx *gorm.DB) (err error) {
 
 	return
 }
+
+type SquarePosition struct {
+	Row int `json:"row" binding:"min=0,max=9"`
+	Col int `json:"col" binding:"min=0,max=9"`
+}
@@ -17,12 +17,25 @@ type ContestSwagger struct {
 	QuarterResults []QuarterResult `json:"quarterResults,omitempty"`
 	Owner          string          `json:"owner"`
 	Status         string          `json:"status"`
+	LockAt         *time.Time      `json:"lockAt,omitempty"`
+	FillPolicy     string          `json:"fillPolicy"`
+	PaymentPolicy  string          `json:"paymentPolicy"`
+	OrgID          *uuid.UUID      `json:"orgId,omitempty"`
+	Rollover       bool            `json:"rollover"`
+	Version        int             `json:"version"`
+	ArchivedAt     *time.Time      `json:"archivedAt,omitempty"`
+	ImportedAt     *time.Time      `json:"importedAt,omitempty"`
 	CreatedAt      time.Time       `json:"createdAt"`
 	UpdatedAt      time.Time       `json:"updatedAt"`
 	CreatedBy      string          `json:"createdBy"`
 	UpdatedBy      string          `json:"updatedBy"`
 }
 
+type ContestConflictErrorSwagger struct {
+	APIError
+	Contest ContestSwagger `json:"contest"`
+}
+
 type PaginatedContestResponseSwagger struct {
 	Contests    []ContestSwagger `json:"contests"`
 	Page        int              `json:"page"`
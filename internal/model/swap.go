@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SwapStatus string
+
+const (
+	SwapStatusPending   SwapStatus = "pending"
+	SwapStatusAccepted  SwapStatus = "accepted"
+	SwapStatusDeclined  SwapStatus = "declined"
+	SwapStatusCancelled SwapStatus = "cancelled"
+)
+
+type SquareSwap struct {
+	ID           uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	ContestID    uuid.UUID  `json:"contestId" gorm:"type:uuid;index;not null"`
+	FromSquareID uuid.UUID  `json:"fromSquareId" gorm:"type:uuid;not null"`
+	ToSquareID   uuid.UUID  `json:"toSquareId" gorm:"type:uuid;not null"`
+	Requester    string     `json:"requester" gorm:"not null"`
+	Recipient    string     `json:"recipient" gorm:"not null"`
+	Status       SwapStatus `json:"status" gorm:"not null;default:pending"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+}
+
+func (s *SquareSwap) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+
+	return
+}
@@ -9,13 +9,43 @@ import (
 
 const GhostUser = "ghost"
 
+// owns squares filled by the house policy; like the ghost it never ranks
+const HouseUser = "house"
+
+type ProfileVisibility string
+
+const (
+	ProfileVisibilityPublic  ProfileVisibility = "public"
+	ProfileVisibilityFriends ProfileVisibility = "friends"
+	ProfileVisibilityPrivate ProfileVisibility = "private"
+)
+
 type User struct {
-	ID              uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
-	Email           string    `json:"email" gorm:"not null;uniqueIndex:idx_users_email"`
-	DisplayName     string    `json:"displayName" gorm:"not null;default:''"`
-	DefaultInitials string    `json:"defaultInitials" gorm:"not null;default:''"`
-	CreatedAt       time.Time `json:"createdAt"`
-	UpdatedAt       time.Time `json:"updatedAt"`
+	ID                uuid.UUID         `json:"id" gorm:"type:uuid;primaryKey"`
+	Email             string            `json:"email" gorm:"not null;uniqueIndex:idx_users_email"`
+	DisplayName       string            `json:"displayName" gorm:"not null;default:''"`
+	DefaultInitials   string            `json:"defaultInitials" gorm:"not null;default:''"`
+	ProfileVisibility ProfileVisibility `json:"profileVisibility" gorm:"not null;default:friends"`
+	AvatarUpdatedAt   *time.Time        `json:"avatarUpdatedAt,omitempty"`
+	AvatarKey         *string           `json:"-"`
+	CreatedAt         time.Time         `json:"createdAt"`
+	UpdatedAt         time.Time         `json:"updatedAt"`
+}
+
+type UserAvatar struct {
+	UserID      uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ContentType string    `gorm:"not null"`
+	Data        []byte    `gorm:"not null"`
+	UpdatedAt   time.Time `gorm:"not null"`
+}
+
+// the key is random and changes with every upload, so the url needs no auth and busts client caches on its own
+func (u *User) AvatarURL() string {
+	if u.AvatarKey == nil {
+		return ""
+	}
+
+	return "/avatars/" + *u.AvatarKey
 }
 
 func (u *User) BeforeCreate(tx *gorm.DB) (err error) {
@@ -8,13 +8,18 @@ import (
 
 const (
 	SquareUpdateType          string = "square_update"
+	SquaresUpdateType         string = "squares_update"
 	ContestUpdateType         string = "contest_update"
 	QuarterResultUpdateType   string = "quarter_result_update"
 	QuarterResultRollbackType string = "quarter_result_rollback"
 	ContestDeletedType        string = "contest_deleted"
+	ContestRestoredType       string = "contest_restored"
 	ParticipantRemovedType    string = "participant_removed"
 	ParticipantAddedType      string = "participant_added"
+	ParticipantBannedType     string = "participant_banned"
+	ContestLockedType         string = "contest_locked"
 	ChatMessageType           string = "chat_message"
+	SpectatorRevokedType      string = "spectator_revoked"
 	ConnectedType             string = "connected"
 	DisconnectType            string = "disconnected"
 	ContestChannelPrefix      string = "contest"
@@ -42,6 +47,8 @@ const (
 	WSDisconnectNATSChanClose     WSDisconnectReason = "nats_chan_closed"
 	WSDisconnectServerError       WSDisconnectReason = "server_error"
 	WSDisconnectVisibilityRevoked WSDisconnectReason = "visibility_revoked"
+	WSDisconnectSpectatorRevoked  WSDisconnectReason = "spectator_revoked"
+	WSDisconnectBanned            WSDisconnectReason = "banned"
 )
 
 type WSChatMessage struct {
@@ -55,6 +62,7 @@ type WSUpdate struct {
 	UpdatedBy     string               `json:"updatedBy"`
 	Timestamp     time.Time            `json:"timestamp"`
 	Square        *Square              `json:"square,omitempty"`
+	Squares       []Square             `json:"squares,omitempty"`
 	Contest       *Contest             `json:"contest,omitempty"`
 	Participants  []ContestParticipant `json:"participants,omitempty"`
 	QuarterResult *QuarterResult       `json:"quarterResult,omitempty"`
@@ -94,6 +102,16 @@ func NewSquareUpdateMessage(contestID uuid.UUID, updatedBy string, square *Squar
 	}
 }
 
+func NewSquaresUpdateMessage(contestID uuid.UUID, updatedBy string, squares []Square) *WSUpdate {
+	return &WSUpdate{
+		Type:      SquaresUpdateType,
+		ContestID: contestID,
+		UpdatedBy: updatedBy,
+		Timestamp: time.Now(),
+		Squares:   squares,
+	}
+}
+
 func NewQuarterResultUpdateMessage(contestID uuid.UUID, updatedBy string, quarterResult *QuarterResult) *WSUpdate {
 	return &WSUpdate{
 		Type:          QuarterResultUpdateType,
@@ -134,6 +152,16 @@ func NewContestDeletedMessage(contestID uuid.UUID, updatedBy string) *WSUpdate {
 	}
 }
 
+func NewContestRestoredMessage(contestID uuid.UUID, updatedBy string, contest *Contest) *WSUpdate {
+	return &WSUpdate{
+		Type:      ContestRestoredType,
+		ContestID: contestID,
+		UpdatedBy: updatedBy,
+		Timestamp: time.Now(),
+		Contest:   contest,
+	}
+}
+
 func NewChatMessage(contestID uuid.UUID, sender, message string) *WSUpdate {
 	return &WSUpdate{
 		Type:      ChatMessageType,
@@ -154,6 +182,17 @@ func NewParticipantRemovedMessage(contestID uuid.UUID, updatedBy string, partici
 	}
 }
 
+// carries the banned user so their open sockets close; clients can drop them from the roster
+func NewParticipantBannedMessage(contestID uuid.UUID, updatedBy, userID string) *WSUpdate {
+	return &WSUpdate{
+		Type:      ParticipantBannedType,
+		ContestID: contestID,
+		UpdatedBy: updatedBy,
+		Timestamp: time.Now(),
+		Message:   userID,
+	}
+}
+
 func NewParticipantAddedMessage(contestID uuid.UUID, participant *ContestParticipant) *WSUpdate {
 	return &WSUpdate{
 		Type:        ParticipantAddedType,
@@ -163,3 +202,25 @@ func NewParticipantAddedMessage(contestID uuid.UUID, participant *ContestPartici
 		Timestamp:   time.Now(),
 	}
 }
+
+func NewContestLockedMessage(contestID uuid.UUID, contest *Contest, message string) *WSUpdate {
+	return &WSUpdate{
+		Type:      ContestLockedType,
+		ContestID: contestID,
+		UpdatedBy: "system",
+		Timestamp: time.Now(),
+		Contest:   contest,
+		Message:   message,
+	}
+}
+
+// only consumed by the server to close that link's sockets; never forwarded to clients
+func NewSpectatorRevokedMessage(contestID, tokenID uuid.UUID, updatedBy string) *WSUpdate {
+	return &WSUpdate{
+		Type:      SpectatorRevokedType,
+		ContestID: contestID,
+		UpdatedBy: updatedBy,
+		Timestamp: time.Now(),
+		Message:   tokenID.String(),
+	}
+}
@@ -0,0 +1,106 @@
+package render
+
+import (
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/util"
+)
+
+// one layout shared by every output format so a png and an svg of the same board line up
+const (
+	gridSize   = 10
+	cellSize   = 56
+	padding    = 16
+	titleH     = 40
+	homeTeamH  = 28
+	awayTeamW  = 28
+	labelSize  = 36
+	gridLeft   = padding + awayTeamW + labelSize
+	gridTop    = padding + titleH + homeTeamH + labelSize
+	gridExtent = gridSize * cellSize
+
+	Width  = gridLeft + gridExtent + padding
+	Height = gridTop + gridExtent + padding
+)
+
+const (
+	colorBackground = "#ffffff"
+	colorGridLine   = "#d0d5dd"
+	colorLabelFill  = "#1f2937"
+	colorLabelText  = "#ffffff"
+	colorText       = "#111827"
+	colorMuted      = "#6b7280"
+	colorWinnerFill = "#fde68a"
+	colorWinnerTag  = "#92400e"
+)
+
+// everything drawn on a board, already resolved from the contest
+type Board struct {
+	Title    string
+	HomeTeam string
+	AwayTeam string
+	XLabels  [gridSize]string
+	YLabels  [gridSize]string
+	Cells    [gridSize][gridSize]Cell
+}
+
+type Cell struct {
+	Text     string
+	Quarters []int
+}
+
+func NewBoard(contest *model.Contest) *Board {
+	b := &Board{
+		Title:    contest.Name,
+		HomeTeam: contest.HomeTeam,
+		AwayTeam: contest.AwayTeam,
+	}
+	if b.HomeTeam == "" {
+		b.HomeTeam = "Home"
+	}
+	if b.AwayTeam == "" {
+		b.AwayTeam = "Away"
+	}
+
+	// undrawn labels are stored as -1 and shown as a question mark
+	xLabels, yLabels, _ := util.ParseLabels(contest)
+	for i := range gridSize {
+		b.XLabels[i] = labelText(xLabels, i)
+		b.YLabels[i] = labelText(yLabels, i)
+	}
+
+	for _, sq := range contest.Squares {
+		if sq.Row < 0 || sq.Row >= gridSize || sq.Col < 0 || sq.Col >= gridSize {
+			continue
+		}
+		b.Cells[sq.Row][sq.Col].Text = sq.Value
+	}
+
+	// rolled-over quarters have no winning square to mark
+	for _, r := range contest.QuarterResults {
+		if r.WinnerRow < 0 || r.WinnerRow >= gridSize || r.WinnerCol < 0 || r.WinnerCol >= gridSize {
+			continue
+		}
+		cell := &b.Cells[r.WinnerRow][r.WinnerCol]
+		cell.Quarters = append(cell.Quarters, r.Quarter)
+	}
+
+	return b
+}
+
+func labelText(labels []int8, i int) string {
+	if i >= len(labels) || labels[i] < 0 || labels[i] > 9 {
+		return "?"
+	}
+	return string(rune('0' + labels[i]))
+}
+
+func quarterTag(quarters []int) string {
+	tag := ""
+	for i, q := range quarters {
+		if i > 0 {
+			tag += " "
+		}
+		tag += "Q" + string(rune('0'+q))
+	}
+	return tag
+}
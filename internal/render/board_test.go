@@ -0,0 +1,79 @@
+package render
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleContest() *model.Contest {
+	return &model.Contest{
+		Name:     "Big <Game>",
+		HomeTeam: "Chiefs",
+		XLabels:  []byte(`[3,1,4,0,5,9,2,6,8,7]`),
+		YLabels:  []byte(`[-1,-1,-1,-1,-1,-1,-1,-1,-1,-1]`),
+		Squares: []model.Square{
+			{Row: 0, Col: 0, Value: "AB"},
+			{Row: 2, Col: 3, Value: "CD"},
+		},
+		QuarterResults: []model.QuarterResult{
+			{Quarter: 1, WinnerRow: 2, WinnerCol: 3},
+			{Quarter: 2, WinnerRow: -1, WinnerCol: -1, RolledOver: true},
+			{Quarter: 3, WinnerRow: 2, WinnerCol: 3},
+		},
+	}
+}
+
+func TestNewBoard(t *testing.T) {
+	b := NewBoard(sampleContest())
+
+	assert.Equal(t, "Chiefs", b.HomeTeam)
+	assert.Equal(t, "Away", b.AwayTeam, "missing team names fall back to a placeholder")
+	assert.Equal(t, "3", b.XLabels[0])
+	assert.Equal(t, "?", b.YLabels[0], "undrawn labels show as a question mark")
+	assert.Equal(t, "AB", b.Cells[0][0].Text)
+	assert.Equal(t, []int{1, 3}, b.Cells[2][3].Quarters)
+	assert.Empty(t, b.Cells[0][0].Quarters, "rolled-over quarters mark no square")
+}
+
+func TestNewBoard_UnparseableLabels(t *testing.T) {
+	b := NewBoard(&model.Contest{})
+	for i := range gridSize {
+		assert.Equal(t, "?", b.XLabels[i])
+		assert.Equal(t, "?", b.YLabels[i])
+	}
+}
+
+func TestPNG(t *testing.T) {
+	data, err := PNG(NewBoard(sampleContest()))
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, Width, img.Bounds().Dx())
+	assert.Equal(t, Height, img.Bounds().Dy())
+
+	// a winning square is filled with the highlight, an ordinary one isn't
+	winner := img.At(gridLeft+3*cellSize+4, gridTop+2*cellSize+4)
+	plain := img.At(gridLeft+5*cellSize+4, gridTop+5*cellSize+4)
+	assert.Equal(t, parseHex(colorWinnerFill), winner)
+	assert.Equal(t, parseHex(colorBackground), plain)
+}
+
+func TestSVG(t *testing.T) {
+	data := SVG(NewBoard(sampleContest()))
+
+	// well-formed xml, so user-supplied names can't break out of their text nodes
+	require.NoError(t, xml.Unmarshal(data, new(struct{})))
+	svg := string(data)
+	assert.Contains(t, svg, "Big &lt;Game&gt;")
+	assert.Contains(t, svg, ">CD</text>")
+	assert.Contains(t, svg, ">Q1 Q3</text>")
+	assert.Equal(t, 1, strings.Count(svg, `fill="`+colorWinnerFill+`"/>`), "only the winning square is highlighted")
+}
@@ -0,0 +1,195 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/go-pdf/fpdf"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// letter portrait in millimetres; the grid fills the page width so names stay legible
+const (
+	pdfMargin     = 12.0
+	pdfTeamW      = 8.0
+	pdfLabelSize  = 8.0
+	pdfCellSize   = 17.5
+	pdfGridLeft   = pdfMargin + pdfTeamW + pdfLabelSize
+	pdfGridExtent = gridSize * pdfCellSize
+	pdfNameLines  = 3
+	pdfFontFamily = "Go"
+)
+
+func PDF(s *Sheet) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "Letter", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin)
+
+	// fixed metadata keeps the output byte-for-byte stable for the same sheet
+	pdf.SetCreationDate(s.Updated)
+	pdf.SetModificationDate(s.Updated)
+	pdf.SetCatalogSort(true)
+	pdf.SetProducer("squares-api", true)
+	pdf.SetTitle(s.Title, true)
+
+	pdf.AddUTF8FontFromBytes(pdfFontFamily, "", goregular.TTF)
+	pdf.AddUTF8FontFromBytes(pdfFontFamily, "B", gobold.TTF)
+	pdf.AddPage()
+
+	pdf.SetFont(pdfFontFamily, "B", 18)
+	pdfColor(pdf.SetTextColor, colorText)
+	pdf.CellFormat(0, 10, s.Title, "", 1, "C", false, 0, "")
+
+	pdf.SetFont(pdfFontFamily, "B", 12)
+	pdf.SetX(pdfGridLeft)
+	pdf.CellFormat(pdfGridExtent, 7, s.HomeTeam, "", 1, "C", false, 0, "")
+
+	gridTop := pdf.GetY() + pdfLabelSize
+	drawSheetGrid(pdf, s, gridTop)
+
+	pdf.SetY(gridTop + pdfGridExtent + 8)
+	drawSheetResults(pdf, s)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func drawSheetGrid(pdf *fpdf.Fpdf, s *Sheet, top float64) {
+	// the away team runs up the left edge, matching the board images
+	pdf.SetFont(pdfFontFamily, "B", 12)
+	cx, cy := pdfMargin+pdfTeamW/2, top+pdfGridExtent/2
+	pdf.TransformBegin()
+	pdf.TransformRotate(90, cx, cy)
+	pdf.SetXY(cx-pdfGridExtent/2, cy-pdfTeamW/2)
+	pdf.CellFormat(pdfGridExtent, pdfTeamW, s.AwayTeam, "", 0, "C", false, 0, "")
+	pdf.TransformEnd()
+
+	pdfColor(pdf.SetFillColor, colorLabelFill)
+	pdfColor(pdf.SetTextColor, colorLabelText)
+	for i := range gridSize {
+		pdf.SetXY(pdfGridLeft+float64(i)*pdfCellSize, top-pdfLabelSize)
+		pdf.CellFormat(pdfCellSize, pdfLabelSize, s.XLabels[i], "", 0, "C", true, 0, "")
+		pdf.SetXY(pdfGridLeft-pdfLabelSize, top+float64(i)*pdfCellSize)
+		pdf.CellFormat(pdfLabelSize, pdfCellSize, s.YLabels[i], "", 0, "C", true, 0, "")
+	}
+
+	pdfColor(pdf.SetDrawColor, colorGridLine)
+	pdf.SetLineWidth(0.2)
+	for r := range gridSize {
+		for c := range gridSize {
+			cell := s.Cells[r][c]
+			x, y := pdfGridLeft+float64(c)*pdfCellSize, top+float64(r)*pdfCellSize
+
+			style := "D"
+			if len(cell.Quarters) > 0 {
+				pdfColor(pdf.SetFillColor, colorWinnerFill)
+				style = "FD"
+			}
+			pdf.Rect(x, y, pdfCellSize, pdfCellSize, style)
+
+			pdf.SetFont(pdfFontFamily, "", 6.5)
+			pdfColor(pdf.SetTextColor, colorText)
+			lines := fitLines(pdf, cell.Text, pdfCellSize-2, pdfNameLines)
+			lineH := 2.8
+			pdf.SetXY(x+1, y+(pdfCellSize-float64(len(lines))*lineH)/2)
+			for _, line := range lines {
+				pdf.SetX(x + 1)
+				pdf.CellFormat(pdfCellSize-2, lineH, line, "", 2, "C", false, 0, "")
+			}
+
+			if len(cell.Quarters) > 0 {
+				pdf.SetFont(pdfFontFamily, "B", 5.5)
+				pdfColor(pdf.SetTextColor, colorWinnerTag)
+				pdf.SetXY(x, y+pdfCellSize-3.5)
+				pdf.CellFormat(pdfCellSize, 3, quarterTag(cell.Quarters), "", 0, "C", false, 0, "")
+			}
+		}
+	}
+}
+
+func drawSheetResults(pdf *fpdf.Fpdf, s *Sheet) {
+	if len(s.Results) == 0 {
+		return
+	}
+
+	widths := []float64{20, 30, 30, 71.9, 40}
+	header := []string{"Quarter", s.HomeTeam, s.AwayTeam, "Winner", "Share of pot"}
+
+	pdf.SetFont(pdfFontFamily, "B", 12)
+	pdfColor(pdf.SetTextColor, colorText)
+	pdf.CellFormat(0, 8, "Quarter results", "", 1, "L", false, 0, "")
+
+	pdf.SetFont(pdfFontFamily, "B", 9)
+	pdfColor(pdf.SetFillColor, colorLabelFill)
+	pdfColor(pdf.SetTextColor, colorLabelText)
+	for i, h := range header {
+		pdf.CellFormat(widths[i], 7, h, "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont(pdfFontFamily, "", 9)
+	pdfColor(pdf.SetTextColor, colorText)
+	for _, r := range s.Results {
+		winner := r.Winner
+		switch {
+		case r.Winner == "":
+			winner = r.Note
+		case r.Note != "":
+			winner += " (" + r.Note + ")"
+		}
+		share := "-"
+		if r.Share > 0 {
+			share = fmt.Sprintf("%d%%", r.Share)
+		}
+
+		row := []string{fmt.Sprintf("Q%d", r.Quarter), fmt.Sprint(r.HomeScore), fmt.Sprint(r.AwayScore), winner, share}
+		for i, v := range row {
+			pdf.CellFormat(widths[i], 6, v, "1", 0, "C", false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+
+	if len(s.Payouts) == 0 {
+		return
+	}
+
+	pdf.Ln(4)
+	pdf.SetFont(pdfFontFamily, "B", 12)
+	pdf.CellFormat(0, 8, "Payout summary", "", 1, "L", false, 0, "")
+
+	pdf.SetFont(pdfFontFamily, "", 9)
+	for _, p := range s.Payouts {
+		pdf.CellFormat(91.9, 6, p.Name, "B", 0, "L", false, 0, "")
+		pdf.CellFormat(60, 6, quarterTag(p.Quarters), "B", 0, "L", false, 0, "")
+		pdf.CellFormat(40, 6, fmt.Sprintf("%d%%", p.Share), "B", 1, "R", false, 0, "")
+	}
+}
+
+// wraps s to the cell width, marking the last line with an ellipsis when it doesn't fit
+func fitLines(pdf *fpdf.Fpdf, s string, width float64, maxLines int) []string {
+	if s == "" {
+		return nil
+	}
+
+	lines := pdf.SplitText(s, width)
+	if len(lines) <= maxLines {
+		return lines
+	}
+
+	lines = lines[:maxLines]
+	last := []rune(lines[maxLines-1])
+	for len(last) > 0 && pdf.GetStringWidth(string(last)+"…") > width {
+		last = last[:len(last)-1]
+	}
+	lines[maxLines-1] = string(last) + "…"
+	return lines
+}
+
+func pdfColor(set func(r, g, b int), hex string) {
+	c := parseHex(hex)
+	set(int(c.R), int(c.G), int(c.B))
+}
@@ -0,0 +1,144 @@
+package render
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strconv"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+type fonts struct {
+	regular *opentype.Font
+	bold    *opentype.Font
+}
+
+// parsed fonts are safe to share; faces are not, so each render builds its own
+var loadFonts = sync.OnceValues(func() (*fonts, error) {
+	regular, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		return nil, err
+	}
+	bold, err := opentype.Parse(gobold.TTF)
+	if err != nil {
+		return nil, err
+	}
+	return &fonts{regular: regular, bold: bold}, nil
+})
+
+type faces struct {
+	title, label, cell, tag font.Face
+}
+
+func newFaces() (*faces, error) {
+	f, err := loadFonts()
+	if err != nil {
+		return nil, err
+	}
+
+	face := func(ttf *opentype.Font, size float64) (font.Face, error) {
+		return opentype.NewFace(ttf, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+	}
+
+	var fs faces
+	if fs.title, err = face(f.bold, 22); err != nil {
+		return nil, err
+	}
+	if fs.label, err = face(f.bold, 16); err != nil {
+		return nil, err
+	}
+	if fs.cell, err = face(f.regular, 18); err != nil {
+		return nil, err
+	}
+	if fs.tag, err = face(f.bold, 10); err != nil {
+		return nil, err
+	}
+	return &fs, nil
+}
+
+func PNG(b *Board) ([]byte, error) {
+	fs, err := newFaces()
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	fill(img, img.Bounds(), colorBackground)
+
+	textCentered(img, fs.title, colorText, b.Title, Width/2, padding+titleH/2)
+	textCentered(img, fs.label, colorText, b.HomeTeam, gridLeft+gridExtent/2, padding+titleH+homeTeamH/2)
+
+	// the away team reads top to bottom, one letter per line, down the left edge
+	letters := []rune(b.AwayTeam)
+	lineH := fs.label.Metrics().Height.Ceil()
+	top := gridTop + gridExtent/2 - len(letters)*lineH/2 + lineH/2
+	for i, r := range letters {
+		textCentered(img, fs.label, colorText, string(r), padding+awayTeamW/2, top+i*lineH)
+	}
+
+	for i := range gridSize {
+		col := image.Rect(gridLeft+i*cellSize, gridTop-labelSize, gridLeft+(i+1)*cellSize, gridTop)
+		fill(img, col, colorLabelFill)
+		textCentered(img, fs.label, colorLabelText, b.XLabels[i], col.Min.X+cellSize/2, col.Min.Y+labelSize/2)
+
+		row := image.Rect(gridLeft-labelSize, gridTop+i*cellSize, gridLeft, gridTop+(i+1)*cellSize)
+		fill(img, row, colorLabelFill)
+		textCentered(img, fs.label, colorLabelText, b.YLabels[i], row.Min.X+labelSize/2, row.Min.Y+cellSize/2)
+	}
+
+	for r := range gridSize {
+		for c := range gridSize {
+			cell := b.Cells[r][c]
+			x, y := gridLeft+c*cellSize, gridTop+r*cellSize
+			if len(cell.Quarters) > 0 {
+				fill(img, image.Rect(x, y, x+cellSize, y+cellSize), colorWinnerFill)
+				textCentered(img, fs.tag, colorWinnerTag, quarterTag(cell.Quarters), x+cellSize/2, y+cellSize-8)
+			}
+			textCentered(img, fs.cell, colorText, cell.Text, x+cellSize/2, y+cellSize/2)
+		}
+	}
+
+	for i := 0; i <= gridSize; i++ {
+		fill(img, image.Rect(gridLeft+i*cellSize, gridTop, gridLeft+i*cellSize+1, gridTop+gridExtent), colorGridLine)
+		fill(img, image.Rect(gridLeft, gridTop+i*cellSize, gridLeft+gridExtent, gridTop+i*cellSize+1), colorGridLine)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func fill(img draw.Image, r image.Rectangle, hex string) {
+	draw.Draw(img, r, image.NewUniform(parseHex(hex)), image.Point{}, draw.Src)
+}
+
+// centers s on (cx, cy) using the face's own ascent and descent
+func textCentered(img draw.Image, face font.Face, hex, s string, cx, cy int) {
+	if s == "" {
+		return
+	}
+
+	d := &font.Drawer{Dst: img, Src: image.NewUniform(parseHex(hex)), Face: face}
+	m := face.Metrics()
+	width := d.MeasureString(s)
+	d.Dot = fixed.Point26_6{
+		X: fixed.I(cx) - width/2,
+		Y: fixed.I(cy) + (m.Ascent-m.Descent)/2,
+	}
+	d.DrawString(s)
+}
+
+func parseHex(hex string) color.RGBA {
+	v, _ := strconv.ParseUint(hex[1:], 16, 32)
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}
+}
@@ -0,0 +1,102 @@
+package render
+
+import (
+	"strings"
+	"time"
+
+	"github.com/maxmorhardt/squares-api/internal/model"
+)
+
+// each quarter pays an equal share of the pot, plus any shares rolled into it
+const quarterSharePercent = 25
+
+// a board resolved for printing: full owner names, blank labels to write in, and results
+type Sheet struct {
+	*Board
+	Results []SheetResult
+	Payouts []SheetPayout
+	Updated time.Time
+}
+
+type SheetResult struct {
+	Quarter   int
+	HomeScore int
+	AwayScore int
+	Winner    string
+	Share     int
+	Note      string
+}
+
+type SheetPayout struct {
+	Name     string
+	Quarters []int
+	Share    int
+}
+
+func NewSheet(contest *model.Contest) *Sheet {
+	s := &Sheet{Board: NewBoard(contest), Updated: contest.UpdatedAt}
+	if contest.Game != nil && contest.Game.UpdatedAt.After(s.Updated) {
+		s.Updated = contest.Game.UpdatedAt
+	}
+
+	// a printed sheet leaves undrawn numbers blank so they can be filled in by hand
+	for i := range gridSize {
+		if s.XLabels[i] == "?" {
+			s.XLabels[i] = ""
+		}
+		if s.YLabels[i] == "?" {
+			s.YLabels[i] = ""
+		}
+	}
+
+	for _, sq := range contest.Squares {
+		if sq.Row < 0 || sq.Row >= gridSize || sq.Col < 0 || sq.Col >= gridSize {
+			continue
+		}
+		s.Cells[sq.Row][sq.Col].Text = ownerName(sq)
+	}
+
+	// payouts keep the order winners first appeared in
+	payouts := make(map[string]int)
+	for _, r := range contest.QuarterResults {
+		result := SheetResult{Quarter: r.Quarter, HomeScore: r.HomeTeamScore, AwayScore: r.AwayTeamScore}
+		switch {
+		case r.RolledOver:
+			result.Note = "Rolled over"
+		case r.Winner == "":
+			result.Note = "Unclaimed"
+		default:
+			result.Winner = r.WinnerName
+			if result.Winner == "" {
+				result.Winner = r.Winner
+			}
+			result.Share = quarterSharePercent * (1 + r.CarriedOver)
+			if r.Fallback {
+				result.Note = "Next owned square"
+			}
+
+			i, ok := payouts[r.Winner]
+			if !ok {
+				i = len(s.Payouts)
+				payouts[r.Winner] = i
+				s.Payouts = append(s.Payouts, SheetPayout{Name: result.Winner})
+			}
+			s.Payouts[i].Quarters = append(s.Payouts[i].Quarters, r.Quarter)
+			s.Payouts[i].Share += result.Share
+		}
+		s.Results = append(s.Results, result)
+	}
+
+	return s
+}
+
+// squares claimed before profiles existed may lack a name, so fall back to the email's local part
+func ownerName(sq model.Square) string {
+	if sq.OwnerName != "" {
+		return sq.OwnerName
+	}
+	if local, _, ok := strings.Cut(sq.Owner, "@"); ok && local != "" {
+		return local
+	}
+	return sq.Value
+}
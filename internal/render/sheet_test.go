@@ -0,0 +1,93 @@
+package render
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+func sampleSheetContest() *model.Contest {
+	c := sampleContest()
+	c.AwayTeam = "Eagles"
+	c.UpdatedAt = time.Date(2026, 2, 8, 23, 30, 0, 0, time.UTC)
+	c.YLabels = []byte(`[8,2,0,6,1,7,3,9,5,4]`)
+	c.Squares = []model.Square{
+		{Row: 0, Col: 0, Value: "AB", Owner: "alice@example.com", OwnerName: "Alice Bennett"},
+		{Row: 2, Col: 3, Value: "CD", Owner: "carol@example.com", OwnerName: "Carol Dominguez-Fitzwilliam the Third of Westminster"},
+		{Row: 4, Col: 4, Value: "EF", Owner: "erin@example.com"},
+		{Row: 9, Col: 9, Value: "GH", Owner: "gus@example.com", OwnerName: "Gus Hå"},
+	}
+	c.QuarterResults = []model.QuarterResult{
+		{Quarter: 1, HomeTeamScore: 7, AwayTeamScore: 3, WinnerRow: 2, WinnerCol: 3, Winner: "carol@example.com", WinnerName: "Carol Dominguez-Fitzwilliam the Third of Westminster"},
+		{Quarter: 2, HomeTeamScore: 10, AwayTeamScore: 6, WinnerRow: 5, WinnerCol: 0, RolledOver: true},
+		{Quarter: 3, HomeTeamScore: 17, AwayTeamScore: 13, WinnerRow: 2, WinnerCol: 3, Winner: "carol@example.com", WinnerName: "Carol Dominguez-Fitzwilliam the Third of Westminster", CarriedOver: 1},
+		{Quarter: 4, HomeTeamScore: 24, AwayTeamScore: 20, WinnerRow: 8, WinnerCol: 9, Winner: "gus@example.com", WinnerName: "Gus Hå", Fallback: true},
+	}
+	return c
+}
+
+func TestNewSheet(t *testing.T) {
+	s := NewSheet(sampleSheetContest())
+
+	assert.Equal(t, "Alice Bennett", s.Cells[0][0].Text, "squares show full owner names")
+	assert.Equal(t, "erin", s.Cells[4][4].Text, "unnamed owners fall back to their email")
+	assert.Equal(t, []int{1, 3}, s.Cells[2][3].Quarters)
+	assert.Equal(t, "8", s.YLabels[0])
+
+	require.Len(t, s.Results, 4)
+	assert.Equal(t, 25, s.Results[0].Share)
+	assert.Equal(t, "Rolled over", s.Results[1].Note)
+	assert.Zero(t, s.Results[1].Share)
+	assert.Equal(t, 50, s.Results[2].Share, "a quarter pays out the shares rolled into it")
+	assert.Equal(t, "Next owned square", s.Results[3].Note)
+
+	assert.Equal(t, []SheetPayout{
+		{Name: "Carol Dominguez-Fitzwilliam the Third of Westminster", Quarters: []int{1, 3}, Share: 75},
+		{Name: "Gus Hå", Quarters: []int{4}, Share: 25},
+	}, s.Payouts)
+}
+
+func TestNewSheet_BlankUntilDrawn(t *testing.T) {
+	s := NewSheet(&model.Contest{Name: "Empty"})
+
+	for i := range gridSize {
+		assert.Empty(t, s.XLabels[i], "undrawn numbers are left blank to write in")
+		assert.Empty(t, s.YLabels[i])
+	}
+	assert.Empty(t, s.Results)
+	assert.Empty(t, s.Payouts, "no payout summary before any quarter is scored")
+}
+
+func TestPDF_Golden(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		contest *model.Contest
+	}{
+		{name: "sheet_results", contest: sampleSheetContest()},
+		{name: "sheet_empty", contest: &model.Contest{Name: "Office Pool", UpdatedAt: time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := PDF(NewSheet(tc.contest))
+			require.NoError(t, err)
+			require.True(t, bytes.HasPrefix(data, []byte("%PDF-")))
+
+			golden := filepath.Join("testdata", tc.name+".pdf")
+			if *update {
+				require.NoError(t, os.WriteFile(golden, data, 0o644))
+			}
+
+			want, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.True(t, bytes.Equal(want, data), "pdf differs from %s; rerun with -update if the change is intended", golden)
+		})
+	}
+}
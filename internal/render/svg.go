@@ -0,0 +1,62 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+)
+
+const svgFont = `font-family="Go, Helvetica, Arial, sans-serif"`
+
+func SVG(b *Board) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" %s>`, Width, Height, Width, Height, svgFont)
+	buf.WriteByte('\n')
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="%s"/>`+"\n", Width, Height, colorBackground)
+
+	svgText(&buf, b.Title, Width/2, padding+titleH/2, 22, "bold", colorText, "")
+	svgText(&buf, b.HomeTeam, gridLeft+gridExtent/2, padding+titleH+homeTeamH/2, 16, "bold", colorText, "")
+
+	// the away team runs up the left edge, matching the png
+	cx, cy := padding+awayTeamW/2, gridTop+gridExtent/2
+	svgText(&buf, b.AwayTeam, cx, cy, 16, "bold", colorText, fmt.Sprintf(`transform="rotate(-90 %d %d)"`, cx, cy))
+
+	for i := range gridSize {
+		x := gridLeft + i*cellSize
+		fmt.Fprintf(&buf, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n", x, gridTop-labelSize, cellSize, labelSize, colorLabelFill)
+		svgText(&buf, b.XLabels[i], x+cellSize/2, gridTop-labelSize/2, 16, "bold", colorLabelText, "")
+
+		y := gridTop + i*cellSize
+		fmt.Fprintf(&buf, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n", gridLeft-labelSize, y, labelSize, cellSize, colorLabelFill)
+		svgText(&buf, b.YLabels[i], gridLeft-labelSize/2, y+cellSize/2, 16, "bold", colorLabelText, "")
+	}
+
+	for r := range gridSize {
+		for c := range gridSize {
+			cell := b.Cells[r][c]
+			x, y := gridLeft+c*cellSize, gridTop+r*cellSize
+			if len(cell.Quarters) > 0 {
+				fmt.Fprintf(&buf, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n", x, y, cellSize, cellSize, colorWinnerFill)
+				svgText(&buf, quarterTag(cell.Quarters), x+cellSize/2, y+cellSize-8, 10, "bold", colorWinnerTag, "")
+			}
+			svgText(&buf, cell.Text, x+cellSize/2, y+cellSize/2, 18, "normal", colorText, "")
+		}
+	}
+
+	fmt.Fprintf(&buf, `<g stroke="%s" stroke-width="1">`+"\n", colorGridLine)
+	for i := 0; i <= gridSize; i++ {
+		fmt.Fprintf(&buf, `<line x1="%d" y1="%d" x2="%d" y2="%d"/>`+"\n", gridLeft+i*cellSize, gridTop, gridLeft+i*cellSize, gridTop+gridExtent)
+		fmt.Fprintf(&buf, `<line x1="%d" y1="%d" x2="%d" y2="%d"/>`+"\n", gridLeft, gridTop+i*cellSize, gridLeft+gridExtent, gridTop+i*cellSize)
+	}
+	buf.WriteString("</g>\n</svg>\n")
+
+	return buf.Bytes()
+}
+
+func svgText(buf *bytes.Buffer, s string, x, y, size int, weight, fill, extra string) {
+	if s == "" {
+		return
+	}
+	fmt.Fprintf(buf, `<text x="%d" y="%d" font-size="%d" font-weight="%s" fill="%s" text-anchor="middle" dominant-baseline="central" %s>%s</text>`+"\n",
+		x, y, size, weight, fill, extra, html.EscapeString(s))
+}
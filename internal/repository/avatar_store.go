@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AvatarStore holds avatar image bytes keyed by user id, so they can move to object storage without touching the service
+type AvatarStore interface {
+	Put(ctx context.Context, avatar *model.UserAvatar) error
+	Get(ctx context.Context, userID uuid.UUID) (*model.UserAvatar, error)
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
+
+type postgresAvatarStore struct {
+	db *gorm.DB
+}
+
+func NewPostgresAvatarStore(db *gorm.DB) AvatarStore {
+	return &postgresAvatarStore{
+		db: db,
+	}
+}
+
+func (s *postgresAvatarStore) Put(ctx context.Context, avatar *model.UserAvatar) error {
+	return s.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"content_type", "data", "updated_at"}),
+		}).
+		Create(avatar).Error
+}
+
+func (s *postgresAvatarStore) Get(ctx context.Context, userID uuid.UUID) (*model.UserAvatar, error) {
+	avatar := &model.UserAvatar{}
+	if err := s.db.WithContext(ctx).Where("user_id = ?", userID).First(avatar).Error; err != nil {
+		return nil, err
+	}
+
+	return avatar, nil
+}
+
+func (s *postgresAvatarStore) Delete(ctx context.Context, userID uuid.UUID) error {
+	return s.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&model.UserAvatar{}).Error
+}
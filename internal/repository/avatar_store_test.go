@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestAvatarStore_Put(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	store := NewPostgresAvatarStore(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "user_avatars" .* ON CONFLICT \("user_id"\) DO UPDATE`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := store.Put(context.Background(), &model.UserAvatar{UserID: uuid.New(), ContentType: "image/png", Data: []byte("png"), UpdatedAt: time.Now()})
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAvatarStore_Get(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	store := NewPostgresAvatarStore(gdb)
+
+	userID := uuid.New()
+	mock.ExpectQuery(`SELECT .* FROM "user_avatars" WHERE user_id = \$1`).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "content_type", "data", "updated_at"}).
+			AddRow(userID, "image/png", []byte("png"), time.Now()))
+
+	avatar, err := store.Get(context.Background(), userID)
+
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", avatar.ContentType)
+	assert.Equal(t, []byte("png"), avatar.Data)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAvatarStore_Get_NotFound(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	store := NewPostgresAvatarStore(gdb)
+
+	mock.ExpectQuery(`SELECT .* FROM "user_avatars"`).WillReturnError(gorm.ErrRecordNotFound)
+
+	avatar, err := store.Get(context.Background(), uuid.New())
+
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Nil(t, avatar)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAvatarStore_Delete(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	store := NewPostgresAvatarStore(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "user_avatars" WHERE user_id = \$1`).WillReturnError(errors.New("delete failed"))
+	mock.ExpectRollback()
+
+	err := store.Delete(context.Background(), uuid.New())
+
+	require.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,8 +2,12 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
 	"github.com/maxmorhardt/squares-api/internal/model"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -14,19 +18,37 @@ type ContestRepository interface {
 	GetVisibilityByID(ctx context.Context, id uuid.UUID) (model.ContestVisibility, error)
 	ExistsByOwnerAndName(ctx context.Context, owner, name string) (bool, error)
 	GetAllByOwnerPaginated(ctx context.Context, owner string, page, limit int, search string) ([]model.Contest, int64, error)
-	GetAllByParticipantUserID(ctx context.Context, userID, search string) ([]model.Contest, error)
+	GetAllByParticipantUserID(ctx context.Context, userID, search string, orgID *uuid.UUID) ([]model.Contest, error)
 	GetByGameID(ctx context.Context, gameID uuid.UUID) ([]model.Contest, error)
+	GetDueForLock(ctx context.Context, now time.Time) ([]model.Contest, error)
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*model.Contest, error)
+	GetDueForArchive(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error)
+	IsOrgAdmin(ctx context.Context, contestID uuid.UUID, userID string) (bool, error)
 
 	Create(ctx context.Context, contest *model.Contest, owner *model.ContestParticipant) error
+	Import(ctx context.Context, contest *model.Contest, owner *model.ContestParticipant, squares []model.Square, results []model.QuarterResult, participants []model.ContestParticipant) error
 	Update(ctx context.Context, contest *model.Contest) error
+	StartWithSquares(ctx context.Context, contest *model.Contest, clearOwners []string, squares []model.Square) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	CreateQuarterResult(ctx context.Context, result *model.QuarterResult) error
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
+	Archive(ctx context.Context, id uuid.UUID) error
+	RecordQuarterResult(ctx context.Context, result *model.QuarterResult, contest *model.Contest) error
 	RollbackQuarterResult(ctx context.Context, resultID uuid.UUID, contest *model.Contest) error
 
 	ClaimSquare(ctx context.Context, square *model.Square, value, owner, ownerName string) (*model.Square, error)
 	ClearSquare(ctx context.Context, square *model.Square) (*model.Square, error)
 	GhostSquare(ctx context.Context, square *model.Square) (*model.Square, error)
 	ClearSquaresByOwner(ctx context.Context, contestID uuid.UUID, owner string) ([]model.Square, error)
+	ClaimSquares(ctx context.Context, contestID uuid.UUID, squareIDs []uuid.UUID, value, owner, ownerName string) ([]model.Square, error)
+	AssignSquare(ctx context.Context, square *model.Square, value, owner, ownerName string) (*model.Square, error)
+	ReleaseExpiredReservations(ctx context.Context) ([]model.Square, error)
+	PurgeInviteEvents(ctx context.Context, before time.Time) (int64, error)
+
+	CreateSwap(ctx context.Context, swap *model.SquareSwap) error
+	GetSwap(ctx context.Context, contestID, swapID uuid.UUID) (*model.SquareSwap, error)
+	GetPendingSwaps(ctx context.Context, contestID uuid.UUID, user string) ([]model.SquareSwap, error)
+	AcceptSwap(ctx context.Context, swap *model.SquareSwap) ([]model.Square, error)
+	CloseSwap(ctx context.Context, swap *model.SquareSwap, status model.SwapStatus) error
 }
 
 type contestRepository struct {
@@ -54,8 +76,11 @@ func (r *contestRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.C
 			return db.Order("quarter ASC")
 		}).
 		First(&contest, "id = ? AND status != ?", id, model.ContestStatusDeleted).Error
+	if err != nil {
+		return &contest, err
+	}
 
-	return &contest, err
+	return &contest, hydrateArchived(r.db.WithContext(ctx), &contest)
 }
 
 func (r *contestRepository) GetVisibilityByID(ctx context.Context, id uuid.UUID) (model.ContestVisibility, error) {
@@ -66,6 +91,22 @@ func (r *contestRepository) GetVisibilityByID(ctx context.Context, id uuid.UUID)
 	return contest.Visibility, err
 }
 
+// soft-deleted contests are hidden from every other getter; this is only for restoring them
+func (r *contestRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*model.Contest, error) {
+	var contest model.Contest
+	err := r.db.WithContext(ctx).
+		Preload("Squares").
+		Preload("QuarterResults", func(db *gorm.DB) *gorm.DB {
+			return db.Order("quarter ASC")
+		}).
+		Preload("Game.Scores", func(db *gorm.DB) *gorm.DB {
+			return db.Order("quarter ASC")
+		}).
+		First(&contest, "id = ? AND status = ?", id, model.ContestStatusDeleted).Error
+
+	return &contest, err
+}
+
 func (r *contestRepository) ExistsByOwnerAndName(ctx context.Context, owner, name string) (bool, error) {
 	var count int64
 	err := r.db.WithContext(ctx).
@@ -101,7 +142,7 @@ func (r *contestRepository) GetAllByOwnerPaginated(ctx context.Context, owner st
 	return contests, total, err
 }
 
-func (r *contestRepository) GetAllByParticipantUserID(ctx context.Context, userID, search string) ([]model.Contest, error) {
+func (r *contestRepository) GetAllByParticipantUserID(ctx context.Context, userID, search string, orgID *uuid.UUID) ([]model.Contest, error) {
 	var contests []model.Contest
 
 	q := r.db.WithContext(ctx).
@@ -114,15 +155,47 @@ func (r *contestRepository) GetAllByParticipantUserID(ctx context.Context, userI
 		Preload("Game.Scores", func(db *gorm.DB) *gorm.DB {
 			return db.Order("quarter ASC")
 		}).
-		Joins("JOIN contest_participants cp ON cp.contest_id = contests.id").
-		Where("cp.user_id = ? AND cp.role != ? AND contests.status != ?", userID, model.ParticipantRoleOwner, model.ContestStatusDeleted)
+		Where("contests.status != ?", model.ContestStatusDeleted)
+
+	order := "cp.joined_at DESC"
+	if orgID != nil {
+		// org admins manage every contest in the org without joining it, so those show up too
+		q = q.Joins("LEFT JOIN contest_participants cp ON cp.contest_id = contests.id AND cp.user_id = ?", userID).
+			Where("contests.org_id = ?", *orgID).
+			Where(`(cp.role IS NOT NULL AND cp.role != ?) OR (cp.role IS NULL AND EXISTS (
+				SELECT 1 FROM organization_members om
+				WHERE om.org_id = contests.org_id AND lower(om.user_id) = lower(?) AND om.role = ?
+			))`, model.ParticipantRoleOwner, userID, model.OrgRoleAdmin)
+		order = "cp.joined_at DESC NULLS LAST, contests.created_at DESC"
+	} else {
+		q = q.Joins("JOIN contest_participants cp ON cp.contest_id = contests.id").
+			Where("cp.user_id = ? AND cp.role != ?", userID, model.ParticipantRoleOwner)
+	}
 
 	if search != "" {
 		q = q.Where("contests.name ILIKE ?", "%"+search+"%")
 	}
 
-	err := q.Order("cp.joined_at DESC").Find(&contests).Error
-	return contests, err
+	if err := q.Order(order).Find(&contests).Error; err != nil {
+		return nil, err
+	}
+
+	refs := make([]*model.Contest, len(contests))
+	for i := range contests {
+		refs[i] = &contests[i]
+	}
+	return contests, hydrateArchived(r.db.WithContext(ctx), refs...)
+}
+
+// true when the contest belongs to an organization the user administers
+func (r *contestRepository) IsOrgAdmin(ctx context.Context, contestID uuid.UUID, userID string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&model.Contest{}).
+		Joins("JOIN organization_members om ON om.org_id = contests.org_id").
+		Where("contests.id = ? AND lower(om.user_id) = lower(?) AND om.role = ?", contestID, userID, model.OrgRoleAdmin).
+		Count(&count).Error
+	return count > 0, err
 }
 
 func (r *contestRepository) GetByGameID(ctx context.Context, gameID uuid.UUID) ([]model.Contest, error) {
@@ -134,54 +207,184 @@ func (r *contestRepository) GetByGameID(ctx context.Context, gameID uuid.UUID) (
 	return contests, err
 }
 
+func (r *contestRepository) GetDueForLock(ctx context.Context, now time.Time) ([]model.Contest, error) {
+	var contests []model.Contest
+	err := r.db.WithContext(ctx).
+		Preload("Squares").
+		// a linked game starts the contest at kickoff, so its lock time no longer applies
+		Where("status = ? AND lock_at IS NOT NULL AND lock_at <= ? AND game_id IS NULL", model.ContestStatusActive, now).
+		Order("lock_at ASC").
+		Find(&contests).Error
+	return contests, err
+}
+
+func (r *contestRepository) GetDueForArchive(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).
+		Model(&model.Contest{}).
+		Where("status = ? AND archived_at IS NULL AND updated_at < ?", model.ContestStatusFinished, before).
+		Order("updated_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
 // ====================
 // Contest Lifecycle Actions
 // ====================
 
 func (r *contestRepository) Create(ctx context.Context, contest *model.Contest, owner *model.ContestParticipant) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// create contest record
-		if err := tx.Create(contest).Error; err != nil {
+		return createContest(tx, contest, owner)
+	})
+}
+
+// creates the contest exactly like Create, then fills in the imported squares, results and participants
+func (r *contestRepository) Import(ctx context.Context, contest *model.Contest, owner *model.ContestParticipant, squares []model.Square, results []model.QuarterResult, participants []model.ContestParticipant) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := createContest(tx, contest, owner); err != nil {
 			return err
 		}
 
-		// initialize 10x10 grid of squares
-		var squares []model.Square
-		for row := range 10 {
-			for col := range 10 {
-				squares = append(squares, model.Square{
-					ContestID: contest.ID,
-					Row:       row,
-					Col:       col,
-					Value:     "",
-				})
+		for _, sq := range squares {
+			if err := tx.Model(&model.Square{}).
+				Where(`contest_id = ? AND "row" = ? AND col = ?`, contest.ID, sq.Row, sq.Col).
+				Updates(map[string]any{"value": sq.Value, "owner": sq.Owner, "owner_name": sq.OwnerName, "color": sq.Color}).Error; err != nil {
+				return err
 			}
 		}
 
-		if err := tx.Create(&squares).Error; err != nil {
-			return err
+		for i := range results {
+			results[i].ContestID = contest.ID
+		}
+		if len(results) > 0 {
+			if err := tx.Create(&results).Error; err != nil {
+				return err
+			}
+		}
+
+		for i := range participants {
+			participants[i].ContestID = contest.ID
+		}
+		if len(participants) > 0 {
+			return tx.Create(&participants).Error
 		}
 
-		// create owner participant within the same transaction
-		owner.ContestID = contest.ID
-		return tx.Create(owner).Error
+		return nil
 	})
 }
 
+func createContest(tx *gorm.DB, contest *model.Contest, owner *model.ContestParticipant) error {
+	// create contest record
+	if err := tx.Create(contest).Error; err != nil {
+		return err
+	}
+
+	// initialize 10x10 grid of squares
+	var squares []model.Square
+	for row := range 10 {
+		for col := range 10 {
+			squares = append(squares, model.Square{
+				ContestID: contest.ID,
+				Row:       row,
+				Col:       col,
+				Value:     "",
+			})
+		}
+	}
+
+	if err := tx.Create(&squares).Error; err != nil {
+		return err
+	}
+
+	// create owner participant within the same transaction
+	owner.ContestID = contest.ID
+	return tx.Create(owner).Error
+}
+
 func (r *contestRepository) Update(ctx context.Context, contest *model.Contest) error {
-	// only persist the contest row itself; never write preloaded associations
-	return r.db.WithContext(ctx).Omit(clause.Associations).Save(contest).Error
+	return saveVersioned(r.db.WithContext(ctx), contest)
+}
+
+func (r *contestRepository) StartWithSquares(ctx context.Context, contest *model.Contest, clearOwners []string, squares []model.Square) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// a payment policy's clears only land if the contest actually starts
+		if len(clearOwners) > 0 {
+			if err := tx.Model(&model.Square{}).
+				Where("contest_id = ? AND owner IN ?", contest.ID, clearOwners).
+				Updates(map[string]any{"value": "", "owner": "", "owner_name": "", "color": "", "version": gorm.Expr("version + 1")}).Error; err != nil {
+				return err
+			}
+		}
+
+		for i := range squares {
+			// only fill squares that are still empty; a late claim aborts the whole start, and filling ends any reservation
+			res := tx.Model(&model.Square{}).
+				Where("id = ? AND owner = ''", squares[i].ID).
+				Updates(map[string]any{"value": squares[i].Value, "owner": squares[i].Owner, "owner_name": squares[i].OwnerName, "color": squares[i].Color, "reserved_invite_id": nil, "version": gorm.Expr("version + 1")})
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return errs.ErrSquareAlreadyClaimed
+			}
+		}
+
+		return saveVersioned(tx, contest)
+	})
 }
 
 func (r *contestRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.db.WithContext(ctx).
 		Model(&model.Contest{}).
 		Where("id = ?", id).
-		Update("status", model.ContestStatusDeleted).Error
+		Updates(map[string]any{
+			"status":            model.ContestStatusDeleted,
+			"pre_delete_status": gorm.Expr("status"),
+			"deleted_at":        time.Now(),
+			"version":           gorm.Expr("version + 1"),
+		}).Error
+}
+
+// hard-deletes contests soft-deleted before the cutoff; squares, results and swaps cascade, participants and invites don't
+func (r *contestRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []uuid.UUID
+		if err := tx.Model(&model.Contest{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND deleted_at < ?", model.ContestStatusDeleted, before).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if err := tx.Where("contest_id IN ?", ids).Delete(&model.ContestInvite{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("contest_id IN ?", ids).Delete(&model.ContestParticipant{}).Error; err != nil {
+			return err
+		}
+
+		res := tx.Where("id IN ?", ids).Delete(&model.Contest{})
+		purged = res.RowsAffected
+		return res.Error
+	})
+
+	return purged, err
 }
 
-func (r *contestRepository) CreateQuarterResult(ctx context.Context, result *model.QuarterResult) error {
-	return r.db.WithContext(ctx).Create(result).Error
+func (r *contestRepository) RecordQuarterResult(ctx context.Context, result *model.QuarterResult, contest *model.Contest) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(result).Error; err != nil {
+			return err
+		}
+
+		// the result only sticks if the contest hasn't moved on underneath us
+		return saveVersioned(tx, contest)
+	})
 }
 
 func (r *contestRepository) RollbackQuarterResult(ctx context.Context, resultID uuid.UUID, contest *model.Contest) error {
@@ -190,11 +393,114 @@ func (r *contestRepository) RollbackQuarterResult(ctx context.Context, resultID
 			return err
 		}
 
-		// only persist the contest row itself; don't write preloaded associations
-		return tx.Omit(clause.Associations).Save(contest).Error
+		return saveVersioned(tx, contest)
 	})
 }
 
+// writes the contest row only if it's still at the version the caller loaded, never touching preloaded associations
+func saveVersioned(tx *gorm.DB, contest *model.Contest) error {
+	loaded := contest.Version
+	contest.Version = loaded + 1
+
+	res := tx.Model(contest).
+		Omit(clause.Associations).
+		Select("*").
+		Where("version = ?", loaded).
+		Updates(contest)
+	if res.Error == nil && res.RowsAffected == 0 {
+		res.Error = errs.ErrContestVersionConflict
+	}
+	if res.Error != nil {
+		contest.Version = loaded
+		return res.Error
+	}
+
+	return nil
+}
+
+// ====================
+// Archival
+// ====================
+
+// moves a finished contest's squares and results into a single snapshot row; participants are copied but kept
+func (r *contestRepository) Archive(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var contest model.Contest
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&contest, "id = ? AND status = ? AND archived_at IS NULL", id, model.ContestStatusFinished).Error; err != nil {
+			return err
+		}
+
+		snapshot := model.ContestSnapshot{XLabels: contest.XLabels, YLabels: contest.YLabels}
+		if err := tx.Where("contest_id = ?", id).Order(`"row", col`).Find(&snapshot.Squares).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("contest_id = ?", id).Order("quarter ASC").Find(&snapshot.QuarterResults).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("contest_id = ?", id).Order("joined_at ASC").Find(&snapshot.Participants).Error; err != nil {
+			return err
+		}
+
+		raw, err := json.Marshal(snapshot)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err := tx.Create(&model.ContestArchive{ContestID: id, Snapshot: raw, ArchivedAt: now}).Error; err != nil {
+			return err
+		}
+
+		// swaps reference squares and cascade with them
+		if err := tx.Where("contest_id = ?", id).Delete(&model.QuarterResult{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("contest_id = ?", id).Delete(&model.Square{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&contest).
+			Updates(map[string]any{"archived_at": now, "version": gorm.Expr("version + 1")}).Error
+	})
+}
+
+// fills squares and results of archived contests from their snapshot so callers can't tell the difference
+func hydrateArchived(db *gorm.DB, contests ...*model.Contest) error {
+	byID := make(map[uuid.UUID]*model.Contest)
+	for _, c := range contests {
+		if c.ArchivedAt != nil {
+			byID[c.ID] = c
+		}
+	}
+	if len(byID) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(byID))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+
+	var archives []model.ContestArchive
+	if err := db.Where("contest_id IN ?", ids).Find(&archives).Error; err != nil {
+		return err
+	}
+
+	for _, a := range archives {
+		var snapshot model.ContestSnapshot
+		if err := json.Unmarshal(a.Snapshot, &snapshot); err != nil {
+			return err
+		}
+
+		contest := byID[a.ContestID]
+		contest.Squares = snapshot.Squares
+		contest.QuarterResults = snapshot.QuarterResults
+	}
+
+	return nil
+}
+
 // ====================
 // Square Actions
 // ====================
@@ -202,16 +508,39 @@ func (r *contestRepository) RollbackQuarterResult(ctx context.Context, resultID
 func (r *contestRepository) ClaimSquare(ctx context.Context, square *model.Square, value, owner, ownerName string) (*model.Square, error) {
 	var claimedSquare *model.Square
 	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// update square value and owner information
-		square.Value = value
-		square.Owner = owner
-		square.OwnerName = ownerName
+		if err := lockActiveContest(tx, square.ContestID); err != nil {
+			return err
+		}
 
-		// save updated square
-		if err := tx.Save(square).Error; err != nil {
+		participant, err := lockClaimant(tx, square.ContestID, owner)
+		if err != nil {
 			return err
 		}
+		value, color := participant.SquareStyle(value)
+
+		// only take the square if it's still empty or already the caller's, and no live invite holds it
+		res := tx.Model(&model.Square{}).
+			Where("id = ? AND (owner = '' OR owner = ?) AND "+unreservedSquare, square.ID, owner).
+			Updates(map[string]any{"value": value, "owner": owner, "owner_name": ownerName, "color": color, "version": gorm.Expr("version + 1")})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errs.ErrSquareAlreadyClaimed
+		}
 
+		if err := checkSquareLimit(tx, square.ContestID, owner, participant.MaxSquares); err != nil {
+			return err
+		}
+		if err := bumpContestVersions(tx, square.ContestID); err != nil {
+			return err
+		}
+
+		square.Value = value
+		square.Owner = owner
+		square.OwnerName = ownerName
+		square.Color = color
+		square.Version++
 		claimedSquare = square
 		return nil
 	})
@@ -222,16 +551,18 @@ func (r *contestRepository) ClaimSquare(ctx context.Context, square *model.Squar
 func (r *contestRepository) ClearSquare(ctx context.Context, square *model.Square) (*model.Square, error) {
 	var clearedSquare *model.Square
 	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// clear all square data
-		square.Value = ""
-		square.Owner = ""
-		square.OwnerName = ""
-
-		// save cleared square
-		if err := tx.Save(square).Error; err != nil {
+		// clear all square data, as long as nobody touched the square since it was loaded
+		if err := saveSquareVersioned(tx, square, map[string]any{"value": "", "owner": "", "owner_name": "", "color": ""}); err != nil {
+			return err
+		}
+		if err := bumpContestVersions(tx, square.ContestID); err != nil {
 			return err
 		}
 
+		square.Value = ""
+		square.Owner = ""
+		square.OwnerName = ""
+		square.Color = ""
 		clearedSquare = square
 		return nil
 	})
@@ -243,13 +574,16 @@ func (r *contestRepository) GhostSquare(ctx context.Context, square *model.Squar
 	var ghostedSquare *model.Square
 	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// keep the value so the started grid stays filled and scoring is unaffected
-		square.Owner = model.GhostUser
-		square.OwnerName = ""
-
-		if err := tx.Save(square).Error; err != nil {
+		if err := saveSquareVersioned(tx, square, map[string]any{"owner": model.GhostUser, "owner_name": "", "color": ""}); err != nil {
+			return err
+		}
+		if err := bumpContestVersions(tx, square.ContestID); err != nil {
 			return err
 		}
 
+		square.Owner = model.GhostUser
+		square.OwnerName = ""
+		square.Color = ""
 		ghostedSquare = square
 		return nil
 	})
@@ -272,7 +606,10 @@ func (r *contestRepository) ClearSquaresByOwner(ctx context.Context, contestID u
 		// clear value and owner for every square the caller owns in one update
 		if err := tx.Model(&model.Square{}).
 			Where("contest_id = ? AND owner = ?", contestID, owner).
-			Updates(map[string]any{"value": "", "owner": "", "owner_name": ""}).Error; err != nil {
+			Updates(map[string]any{"value": "", "owner": "", "owner_name": "", "color": "", "version": gorm.Expr("version + 1")}).Error; err != nil {
+			return err
+		}
+		if err := bumpContestVersions(tx, contestID); err != nil {
 			return err
 		}
 
@@ -281,6 +618,8 @@ func (r *contestRepository) ClearSquaresByOwner(ctx context.Context, contestID u
 			clearedSquares[i].Value = ""
 			clearedSquares[i].Owner = ""
 			clearedSquares[i].OwnerName = ""
+			clearedSquares[i].Color = ""
+			clearedSquares[i].Version++
 		}
 
 		return nil
@@ -288,3 +627,339 @@ func (r *contestRepository) ClearSquaresByOwner(ctx context.Context, contestID u
 
 	return clearedSquares, err
 }
+
+func (r *contestRepository) ClaimSquares(ctx context.Context, contestID uuid.UUID, squareIDs []uuid.UUID, value, owner, ownerName string) ([]model.Square, error) {
+	var claimedSquares []model.Square
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockActiveContest(tx, contestID); err != nil {
+			return err
+		}
+
+		participant, err := lockClaimant(tx, contestID, owner)
+		if err != nil {
+			return err
+		}
+		value, color := participant.SquareStyle(value)
+
+		// only take squares that are empty or already the caller's and not held by a live invite; anything else aborts the batch
+		res := tx.Model(&model.Square{}).
+			Where("contest_id = ? AND id IN ? AND (owner = '' OR owner = ?) AND "+unreservedSquare, contestID, squareIDs, owner).
+			Updates(map[string]any{"value": value, "owner": owner, "owner_name": ownerName, "color": color, "version": gorm.Expr("version + 1")})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != int64(len(squareIDs)) {
+			return errs.ErrSquareAlreadyClaimed
+		}
+
+		if err := checkSquareLimit(tx, contestID, owner, participant.MaxSquares); err != nil {
+			return err
+		}
+		if err := bumpContestVersions(tx, contestID); err != nil {
+			return err
+		}
+
+		return tx.Where("contest_id = ? AND id IN ?", contestID, squareIDs).
+			Order(`"row", col`).
+			Find(&claimedSquares).Error
+	})
+
+	return claimedSquares, err
+}
+
+func (r *contestRepository) AssignSquare(ctx context.Context, square *model.Square, value, owner, ownerName string) (*model.Square, error) {
+	var assignedSquare *model.Square
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockActiveContest(tx, square.ContestID); err != nil {
+			return err
+		}
+
+		participant, err := lockClaimant(tx, square.ContestID, owner)
+		if err != nil {
+			return err
+		}
+		value, color := participant.SquareStyle(value)
+
+		// the square must still belong to whoever held it when it was loaded, and organisers can't hand out a reserved one
+		res := tx.Model(&model.Square{}).
+			Where("id = ? AND owner = ? AND "+unreservedSquare, square.ID, square.Owner).
+			Updates(map[string]any{"value": value, "owner": owner, "owner_name": ownerName, "color": color, "version": gorm.Expr("version + 1")})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errs.ErrSquareAlreadyClaimed
+		}
+
+		if err := checkSquareLimit(tx, square.ContestID, owner, participant.MaxSquares); err != nil {
+			return err
+		}
+		if err := bumpContestVersions(tx, square.ContestID); err != nil {
+			return err
+		}
+
+		square.Value = value
+		square.Owner = owner
+		square.OwnerName = ownerName
+		square.Color = color
+		square.Version++
+		assignedSquare = square
+		return nil
+	})
+
+	return assignedSquare, err
+}
+
+func (r *contestRepository) ReleaseExpiredReservations(ctx context.Context) ([]model.Square, error) {
+	var releasedSquares []model.Square
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		expired := tx.Model(&model.ContestInvite{}).Select("id").Where("expires_at <= NOW()")
+
+		// load the held squares so their released state can be broadcast
+		if err := tx.Where("reserved_invite_id IN (?)", expired).Find(&releasedSquares).Error; err != nil {
+			return err
+		}
+
+		if len(releasedSquares) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(releasedSquares))
+		for i := range releasedSquares {
+			ids[i] = releasedSquares[i].ID
+		}
+
+		if err := tx.Model(&model.Square{}).
+			Where("id IN ?", ids).
+			Updates(map[string]any{"reserved_invite_id": nil, "version": gorm.Expr("version + 1")}).Error; err != nil {
+			return err
+		}
+		if err := bumpContestVersions(tx, squareContestIDs(releasedSquares)...); err != nil {
+			return err
+		}
+
+		for i := range releasedSquares {
+			releasedSquares[i].ReservedInviteID = nil
+			releasedSquares[i].Version++
+		}
+
+		return nil
+	})
+
+	return releasedSquares, err
+}
+
+// invite funnels only look back so far, so older view and redeem events are dropped
+func (r *contestRepository) PurgeInviteEvents(ctx context.Context, before time.Time) (int64, error) {
+	res := r.db.WithContext(ctx).
+		Where("created_at < ?", before).
+		Delete(&model.ContestInviteEvent{})
+	return res.RowsAffected, res.Error
+}
+
+// applies the update only if the square is still at the version the caller loaded
+func saveSquareVersioned(tx *gorm.DB, square *model.Square, fields map[string]any) error {
+	fields["version"] = gorm.Expr("version + 1")
+
+	res := tx.Model(&model.Square{}).
+		Where("id = ? AND version = ?", square.ID, square.Version).
+		Updates(fields)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errs.ErrSquareVersionConflict
+	}
+
+	square.Version++
+	return nil
+}
+
+// a reservation only holds while its invite is live, so an expired invite frees the square before the lifecycle sweep clears it
+const unreservedSquare = `(squares.reserved_invite_id IS NULL OR NOT EXISTS (
+	SELECT 1 FROM contest_invites ci
+	WHERE ci.id = squares.reserved_invite_id AND (ci.expires_at IS NULL OR ci.expires_at > NOW())))`
+
+// claims queue behind a start or lock on the contest row, so none lands on a board whose labels are already drawn
+func lockActiveContest(tx *gorm.DB, contestID uuid.UUID) error {
+	contest, err := lockContest(tx, contestID)
+	if err != nil {
+		return err
+	}
+	if contest.Status != model.ContestStatusActive {
+		return errs.ErrContestNotEditable
+	}
+
+	return nil
+}
+
+func lockContest(tx *gorm.DB, contestID uuid.UUID) (*model.Contest, error) {
+	var contest model.Contest
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "status").
+		First(&contest, "id = ?", contestID).Error; err != nil {
+		return nil, err
+	}
+
+	return &contest, nil
+}
+
+// locks the owner's participant row so their concurrent claims queue up behind each other
+// the locked row also carries the claimant's square style, so claims can't race a style change
+func lockClaimant(tx *gorm.DB, contestID uuid.UUID, owner string) (*model.ContestParticipant, error) {
+	var participant model.ContestParticipant
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("contest_id = ? AND user_id = ?", contestID, owner).
+		First(&participant).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errs.ErrNotParticipant
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &participant, nil
+}
+
+// counted after the write so the total includes the squares just taken
+func checkSquareLimit(tx *gorm.DB, contestID uuid.UUID, owner string, limit int) error {
+	var claimed int64
+	if err := tx.Model(&model.Square{}).
+		Where("contest_id = ? AND owner = ?", contestID, owner).
+		Count(&claimed).Error; err != nil {
+		return err
+	}
+	if claimed > int64(limit) {
+		return errs.ErrSquareLimitReached
+	}
+
+	return nil
+}
+
+// the contest version is the board's etag, so every square write bumps it in the same transaction
+func bumpContestVersions(tx *gorm.DB, contestIDs ...uuid.UUID) error {
+	if len(contestIDs) == 0 {
+		return nil
+	}
+
+	return tx.Model(&model.Contest{}).
+		Where("id IN ?", contestIDs).
+		UpdateColumn("version", gorm.Expr("version + 1")).Error
+}
+
+// distinct contests touched by a multi-square write
+func squareContestIDs(squares []model.Square) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(squares))
+	ids := make([]uuid.UUID, 0, len(squares))
+	for i := range squares {
+		if !seen[squares[i].ContestID] {
+			seen[squares[i].ContestID] = true
+			ids = append(ids, squares[i].ContestID)
+		}
+	}
+
+	return ids
+}
+
+// ====================
+// Swap Actions
+// ====================
+
+func (r *contestRepository) CreateSwap(ctx context.Context, swap *model.SquareSwap) error {
+	return r.db.WithContext(ctx).Create(swap).Error
+}
+
+func (r *contestRepository) GetSwap(ctx context.Context, contestID, swapID uuid.UUID) (*model.SquareSwap, error) {
+	var swap model.SquareSwap
+	err := r.db.WithContext(ctx).First(&swap, "id = ? AND contest_id = ?", swapID, contestID).Error
+	return &swap, err
+}
+
+func (r *contestRepository) GetPendingSwaps(ctx context.Context, contestID uuid.UUID, user string) ([]model.SquareSwap, error) {
+	var swaps []model.SquareSwap
+	err := r.db.WithContext(ctx).
+		Where("contest_id = ? AND status = ? AND (requester = ? OR recipient = ?)", contestID, model.SwapStatusPending, user, user).
+		Order("created_at DESC").
+		Find(&swaps).Error
+	return swaps, err
+}
+
+func (r *contestRepository) AcceptSwap(ctx context.Context, swap *model.SquareSwap) ([]model.Square, error) {
+	var swapped []model.Square
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// claim the request first so a concurrent accept or decline loses cleanly
+		res := tx.Model(&model.SquareSwap{}).
+			Where("id = ? AND status = ?", swap.ID, model.SwapStatusPending).
+			Update("status", model.SwapStatusAccepted)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errs.ErrSwapNotPending
+		}
+
+		var squares []model.Square
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("contest_id = ? AND id IN ?", swap.ContestID, []uuid.UUID{swap.FromSquareID, swap.ToSquareID}).
+			Find(&squares).Error; err != nil {
+			return err
+		}
+		if len(squares) != 2 {
+			return errs.ErrSwapOutdated
+		}
+
+		from, to := &squares[0], &squares[1]
+		if from.ID != swap.FromSquareID {
+			from, to = to, from
+		}
+		if from.Owner != swap.Requester || to.Owner != swap.Recipient {
+			return errs.ErrSwapOutdated
+		}
+
+		from.Value, to.Value = to.Value, from.Value
+		from.Owner, to.Owner = to.Owner, from.Owner
+		from.OwnerName, to.OwnerName = to.OwnerName, from.OwnerName
+		from.Color, to.Color = to.Color, from.Color
+		for _, sq := range []*model.Square{from, to} {
+			if err := tx.Model(&model.Square{}).
+				Where("id = ?", sq.ID).
+				Updates(map[string]any{"value": sq.Value, "owner": sq.Owner, "owner_name": sq.OwnerName, "color": sq.Color, "version": gorm.Expr("version + 1")}).Error; err != nil {
+				return err
+			}
+			sq.Version++
+		}
+		if err := bumpContestVersions(tx, swap.ContestID); err != nil {
+			return err
+		}
+
+		// other pending requests on either square can no longer be honoured
+		if err := tx.Model(&model.SquareSwap{}).
+			Where("id <> ? AND status = ? AND (from_square_id IN ? OR to_square_id IN ?)",
+				swap.ID, model.SwapStatusPending, []uuid.UUID{from.ID, to.ID}, []uuid.UUID{from.ID, to.ID}).
+			Update("status", model.SwapStatusCancelled).Error; err != nil {
+			return err
+		}
+
+		swap.Status = model.SwapStatusAccepted
+		swapped = []model.Square{*from, *to}
+		return nil
+	})
+
+	return swapped, err
+}
+
+func (r *contestRepository) CloseSwap(ctx context.Context, swap *model.SquareSwap, status model.SwapStatus) error {
+	res := r.db.WithContext(ctx).
+		Model(&model.SquareSwap{}).
+		Where("id = ? AND status = ?", swap.ID, model.SwapStatusPending).
+		Update("status", status)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errs.ErrSwapNotPending
+	}
+
+	swap.Status = status
+	return nil
+}
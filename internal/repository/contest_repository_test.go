@@ -2,14 +2,18 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
 	"github.com/maxmorhardt/squares-api/internal/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 func TestContestRepository_GetVisibilityByID(t *testing.T) {
@@ -62,13 +66,82 @@ func TestContestRepository_Delete(t *testing.T) {
 	repo := NewContestRepository(gdb)
 
 	mock.ExpectBegin()
-	mock.ExpectExec(`UPDATE "contests" SET "status"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	// the current status is kept so a restore knows where to go back to
+	mock.ExpectExec(`UPDATE "contests" SET "deleted_at"=\$1,"pre_delete_status"=status,"status"=\$2,"version"=version \+ 1`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
 	require.NoError(t, repo.Delete(context.Background(), uuid.New()))
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestContestRepository_GetDeletedByID(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	id := uuid.New()
+	mock.ExpectQuery(`SELECT \* FROM "contests" WHERE id = \$1 AND status = \$2`).
+		WithArgs(id, model.ContestStatusDeleted, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "pre_delete_status"}).AddRow(id, "DELETED", "Q2"))
+	mock.ExpectQuery(`SELECT \* FROM "squares"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "contest_id"}))
+	mock.ExpectQuery(`SELECT \* FROM "quarter_results"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "contest_id"}))
+
+	contest, err := repo.GetDeletedByID(context.Background(), id)
+	require.NoError(t, err)
+	require.NotNil(t, contest.PreDeleteStatus)
+	assert.Equal(t, model.ContestStatusQ2, *contest.PreDeleteStatus)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_PurgeDeleted(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT "id" FROM "contests" WHERE status = \$1 AND deleted_at < \$2 FOR UPDATE SKIP LOCKED`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(ids[0]).AddRow(ids[1]))
+	mock.ExpectExec(`DELETE FROM "contest_invites" WHERE contest_id IN \(\$1,\$2\)`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM "contest_participants" WHERE contest_id IN \(\$1,\$2\)`).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(`DELETE FROM "contests" WHERE id IN \(\$1,\$2\)`).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	purged, err := repo.PurgeDeleted(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_PurgeDeleted_None(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT "id" FROM "contests"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectCommit()
+
+	purged, err := repo.PurgeDeleted(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_PurgeInviteEvents(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "contest_invite_events" WHERE created_at < \$1`).WillReturnResult(sqlmock.NewResult(0, 5))
+	mock.ExpectCommit()
+
+	purged, err := repo.PurgeInviteEvents(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), purged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestContestRepository_Update(t *testing.T) {
 	gdb, mock := newMockDB(t)
 	repo := NewContestRepository(gdb)
@@ -81,6 +154,20 @@ func TestContestRepository_Update(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestContestRepository_Update_VersionConflict(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "contests" SET .* WHERE version = \$\d+`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	contest := &model.Contest{ID: uuid.New(), Name: "x", Version: 2}
+	assert.ErrorIs(t, repo.Update(context.Background(), contest), errs.ErrContestVersionConflict)
+	assert.Equal(t, 2, contest.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestContestRepository_GetByGameID(t *testing.T) {
 	gdb, mock := newMockDB(t)
 	repo := NewContestRepository(gdb)
@@ -98,12 +185,23 @@ func TestContestRepository_GetByGameID(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+// the row lock every claim takes before touching squares
+func expectContestLock(mock sqlmock.Sqlmock, status model.ContestStatus) {
+	mock.ExpectQuery(`SELECT "id","status" FROM "contests" WHERE id = \$1 .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(uuid.New(), status))
+}
+
 func TestContestRepository_ClaimSquare(t *testing.T) {
 	gdb, mock := newMockDB(t)
 	repo := NewContestRepository(gdb)
 
 	mock.ExpectBegin()
-	mock.ExpectExec(`UPDATE "squares"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectContestLock(mock, model.ContestStatusActive)
+	mock.ExpectQuery(`SELECT \* FROM "contest_participants" .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "max_squares"}).AddRow(uuid.New(), 5))
+	mock.ExpectExec(`UPDATE "squares" SET .* WHERE id = .* AND \(owner = '' OR owner = .*\) AND \(squares.reserved_invite_id IS NULL OR NOT EXISTS`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "squares"`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectExec(`UPDATE "contests" SET "version"=version \+ 1`).WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
 	sq, err := repo.ClaimSquare(context.Background(), &model.Square{ID: uuid.New()}, "AB", "owner", "Owner Name")
@@ -114,12 +212,147 @@ func TestContestRepository_ClaimSquare(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestContestRepository_ClaimSquare_UsesSquareStyle(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	// the participant's per-contest value beats the profile initials passed in
+	mock.ExpectBegin()
+	expectContestLock(mock, model.ContestStatusActive)
+	mock.ExpectQuery(`SELECT \* FROM "contest_participants" .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "max_squares", "display_value", "color"}).AddRow(uuid.New(), 5, "MM2", "teal"))
+	mock.ExpectExec(`UPDATE "squares" SET "color"=\$1,"owner"=\$2,"owner_name"=\$3,"value"=\$4`).
+		WithArgs("teal", "owner", "Owner Name", "MM2", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "squares"`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectExec(`UPDATE "contests" SET "version"=version \+ 1`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	sq, err := repo.ClaimSquare(context.Background(), &model.Square{ID: uuid.New()}, "MM", "owner", "Owner Name")
+
+	require.NoError(t, err)
+	assert.Equal(t, "MM2", sq.Value)
+	assert.Equal(t, "teal", sq.Color)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_ReleaseExpiredReservations(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "squares" WHERE reserved_invite_id IN \(SELECT "id" FROM "contest_invites" WHERE expires_at <= NOW\(\)\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "version", "reserved_invite_id"}).AddRow(uuid.New(), 2, uuid.New()))
+	mock.ExpectExec(`UPDATE "squares" SET "reserved_invite_id"=\$1,"version"=version \+ 1`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "contests" SET "version"=version \+ 1`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	released, err := repo.ReleaseExpiredReservations(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, released, 1)
+	assert.Nil(t, released[0].ReservedInviteID)
+	assert.Equal(t, 3, released[0].Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_ReleaseExpiredReservations_None(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "squares"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectCommit()
+
+	released, err := repo.ReleaseExpiredReservations(context.Background())
+
+	require.NoError(t, err)
+	assert.Empty(t, released)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_ClaimSquare_AlreadyClaimed(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	// the conditional update matches nothing once someone else holds the square
+	mock.ExpectBegin()
+	expectContestLock(mock, model.ContestStatusActive)
+	mock.ExpectQuery(`SELECT \* FROM "contest_participants" .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "max_squares"}).AddRow(uuid.New(), 5))
+	mock.ExpectExec(`UPDATE "squares"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err := repo.ClaimSquare(context.Background(), &model.Square{ID: uuid.New()}, "AB", "owner", "Owner Name")
+	assert.ErrorIs(t, err, errs.ErrSquareAlreadyClaimed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_ClaimSquare_LimitReached(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	mock.ExpectBegin()
+	expectContestLock(mock, model.ContestStatusActive)
+	mock.ExpectQuery(`SELECT \* FROM "contest_participants" .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "max_squares"}).AddRow(uuid.New(), 2))
+	mock.ExpectExec(`UPDATE "squares"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "squares"`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectRollback()
+
+	_, err := repo.ClaimSquare(context.Background(), &model.Square{ID: uuid.New()}, "AB", "owner", "Owner Name")
+	assert.ErrorIs(t, err, errs.ErrSquareLimitReached)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_ClaimSquare_ContestStarted(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	// the contest was ACTIVE when the service loaded it, but the start committed before the claim got the lock
+	mock.ExpectBegin()
+	expectContestLock(mock, model.ContestStatusQ1)
+	mock.ExpectRollback()
+
+	_, err := repo.ClaimSquare(context.Background(), &model.Square{ID: uuid.New(), ContestID: uuid.New()}, "AB", "owner", "Owner Name")
+	assert.ErrorIs(t, err, errs.ErrContestNotEditable)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_ClaimSquares_ContestStarted(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	mock.ExpectBegin()
+	expectContestLock(mock, model.ContestStatusQ1)
+	mock.ExpectRollback()
+
+	_, err := repo.ClaimSquares(context.Background(), uuid.New(), []uuid.UUID{uuid.New()}, "AB", "owner", "Owner Name")
+	assert.ErrorIs(t, err, errs.ErrContestNotEditable)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_ClaimSquare_NotParticipant(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	mock.ExpectBegin()
+	expectContestLock(mock, model.ContestStatusActive)
+	mock.ExpectQuery(`SELECT \* FROM "contest_participants"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+
+	_, err := repo.ClaimSquare(context.Background(), &model.Square{ID: uuid.New()}, "AB", "owner", "Owner Name")
+	assert.ErrorIs(t, err, errs.ErrNotParticipant)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestContestRepository_ClearSquare(t *testing.T) {
 	gdb, mock := newMockDB(t)
 	repo := NewContestRepository(gdb)
 
 	mock.ExpectBegin()
 	mock.ExpectExec(`UPDATE "squares"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "contests" SET "version"=version \+ 1`).WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
 	sq, err := repo.ClearSquare(context.Background(), &model.Square{ID: uuid.New(), Value: "AB", Owner: "o"})
@@ -130,6 +363,23 @@ func TestContestRepository_ClearSquare(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestContestRepository_ClearSquare_VersionConflict(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "squares" SET .* WHERE id = \$\d+ AND version = \$\d+`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	sq := &model.Square{ID: uuid.New(), Value: "AB", Owner: "o", Version: 5}
+	_, err := repo.ClearSquare(context.Background(), sq)
+
+	assert.ErrorIs(t, err, errs.ErrSquareVersionConflict)
+	assert.Equal(t, "o", sq.Owner)
+	assert.Equal(t, 5, sq.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestContestRepository_ClearSquaresByOwner(t *testing.T) {
 	gdb, mock := newMockDB(t)
 	repo := NewContestRepository(gdb)
@@ -141,6 +391,7 @@ func TestContestRepository_ClearSquaresByOwner(t *testing.T) {
 			AddRow(uuid.New(), contestID, "o", "AB").
 			AddRow(uuid.New(), contestID, "o", "CD"))
 	mock.ExpectExec(`UPDATE "squares"`).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`UPDATE "contests" SET "version"=version \+ 1`).WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
 	squares, err := repo.ClearSquaresByOwner(context.Background(), contestID, "o")
@@ -189,6 +440,90 @@ func TestContestRepository_GetByID(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestContestRepository_GetByID_Archived(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	id := uuid.New()
+	snapshot, err := json.Marshal(model.ContestSnapshot{
+		Squares:        []model.Square{{ContestID: id, Row: 0, Col: 0, Owner: "a@b.com"}},
+		QuarterResults: []model.QuarterResult{{ContestID: id, Quarter: 1, Winner: "a@b.com"}},
+	})
+	require.NoError(t, err)
+
+	mock.ExpectQuery(`SELECT \* FROM "contests"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "archived_at"}).AddRow(id, "FINISHED", time.Now()))
+	mock.ExpectQuery(`SELECT \* FROM "squares"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "contest_id"}))
+	mock.ExpectQuery(`SELECT \* FROM "quarter_results"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "contest_id"}))
+	mock.ExpectQuery(`SELECT \* FROM "contest_archives" WHERE contest_id IN \(\$1\)`).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"contest_id", "snapshot"}).AddRow(id, snapshot))
+
+	contest, err := repo.GetByID(context.Background(), id)
+	require.NoError(t, err)
+	require.Len(t, contest.Squares, 1)
+	assert.Equal(t, "a@b.com", contest.Squares[0].Owner)
+	require.Len(t, contest.QuarterResults, 1)
+	assert.Equal(t, 1, contest.QuarterResults[0].Quarter)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_GetDueForArchive(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	id := uuid.New()
+	mock.ExpectQuery(`SELECT "id" FROM "contests" WHERE status = \$1 AND archived_at IS NULL AND updated_at < \$2 ORDER BY updated_at ASC LIMIT \$3`).
+		WithArgs(model.ContestStatusFinished, sqlmock.AnyArg(), 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+
+	ids, err := repo.GetDueForArchive(context.Background(), time.Now(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{id}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_Archive(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	id := uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "contests" WHERE id = \$1 AND status = \$2 AND archived_at IS NULL .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "x_labels", "y_labels", "version"}).AddRow(id, "FINISHED", []byte(`[1]`), []byte(`[2]`), 7))
+	mock.ExpectQuery(`SELECT \* FROM "squares" WHERE contest_id = \$1 ORDER BY "row", col`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "contest_id", "owner"}).AddRow(uuid.New(), id, "a@b.com"))
+	mock.ExpectQuery(`SELECT \* FROM "quarter_results" WHERE contest_id = \$1 ORDER BY quarter ASC`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "contest_id", "winner"}).AddRow(uuid.New(), id, "a@b.com"))
+	mock.ExpectQuery(`SELECT \* FROM "contest_participants" WHERE contest_id = \$1 ORDER BY joined_at ASC`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "contest_id", "user_id"}).AddRow(uuid.New(), id, "a@b.com"))
+	mock.ExpectExec(`INSERT INTO "contest_archives"`).
+		WithArgs(id, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM "quarter_results" WHERE contest_id = \$1`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM "squares" WHERE contest_id = \$1`).WillReturnResult(sqlmock.NewResult(0, 100))
+	mock.ExpectExec(`UPDATE "contests" SET "archived_at"=\$1,"version"=version \+ 1`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.Archive(context.Background(), id))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_Archive_NotFinished(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "contests"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+
+	err := repo.Archive(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestContestRepository_Create(t *testing.T) {
 	gdb, mock := newMockDB(t)
 	repo := NewContestRepository(gdb)
@@ -217,6 +552,47 @@ func TestContestRepository_Create_Error(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestContestRepository_Import(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "contests"`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO "squares"`).WillReturnResult(sqlmock.NewResult(1, 100))
+	mock.ExpectExec(`INSERT INTO "contest_participants"`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`UPDATE "squares" SET .* WHERE contest_id = \$\d+ AND "row" = \$\d+ AND col = \$\d+`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO "quarter_results"`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO "contest_participants"`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	contest := &model.Contest{ID: uuid.New(), Name: "C1", Status: model.ContestStatusQ2}
+	results := []model.QuarterResult{{Quarter: 1}}
+	participants := []model.ContestParticipant{{UserID: "p1", Role: model.ParticipantRoleParticipant}}
+	err := repo.Import(context.Background(), contest, &model.ContestParticipant{UserID: "owner"},
+		[]model.Square{{Row: 1, Col: 2, Value: "AB", Owner: "p1"}}, results, participants)
+	require.NoError(t, err)
+	assert.Equal(t, contest.ID, results[0].ContestID)
+	assert.Equal(t, contest.ID, participants[0].ContestID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_Import_RollsBack(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "contests"`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO "squares"`).WillReturnResult(sqlmock.NewResult(1, 100))
+	mock.ExpectExec(`INSERT INTO "contest_participants"`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO "quarter_results"`).WillReturnError(errors.New("insert failed"))
+	mock.ExpectRollback()
+
+	err := repo.Import(context.Background(), &model.Contest{ID: uuid.New(), Name: "C1"}, &model.ContestParticipant{UserID: "owner"},
+		nil, []model.QuarterResult{{Quarter: 1}}, nil)
+	require.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestContestRepository_GetAllByParticipantUserID(t *testing.T) {
 	gdb, mock := newMockDB(t)
 	repo := NewContestRepository(gdb)
@@ -229,23 +605,71 @@ func TestContestRepository_GetAllByParticipantUserID(t *testing.T) {
 	mock.ExpectQuery(`SELECT \* FROM "quarter_results"`).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "contest_id"}))
 
-	contests, err := repo.GetAllByParticipantUserID(context.Background(), "user1", "foo")
+	contests, err := repo.GetAllByParticipantUserID(context.Background(), "user1", "foo", nil)
 
 	require.NoError(t, err)
 	assert.Len(t, contests, 1)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestContestRepository_CreateQuarterResult(t *testing.T) {
+func TestContestRepository_GetAllByParticipantUserID_OrgFilter(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	orgID := uuid.New()
+	mock.ExpectQuery(`SELECT contests\.\* FROM "contests" LEFT JOIN contest_participants cp .* contests\.org_id = \$\d+ .* organization_members om .* NULLS LAST`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+	contests, err := repo.GetAllByParticipantUserID(context.Background(), "user1", "", &orgID)
+
+	require.NoError(t, err)
+	assert.Empty(t, contests)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_IsOrgAdmin(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "contests" JOIN organization_members om`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	isAdmin, err := repo.IsOrgAdmin(context.Background(), uuid.New(), "Admin@Example.com")
+
+	require.NoError(t, err)
+	assert.True(t, isAdmin)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_RecordQuarterResult(t *testing.T) {
 	gdb, mock := newMockDB(t)
 	repo := NewContestRepository(gdb)
 
 	mock.ExpectBegin()
 	mock.ExpectExec(`INSERT INTO "quarter_results"`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`UPDATE "contests" SET .* WHERE version = \$\d+ AND "id" = \$\d+`).WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
-	err := repo.CreateQuarterResult(context.Background(), &model.QuarterResult{ContestID: uuid.New(), Quarter: 1})
+	contest := &model.Contest{ID: uuid.New(), Status: model.ContestStatusQ2, Version: 3}
+	err := repo.RecordQuarterResult(context.Background(), &model.QuarterResult{ContestID: contest.ID, Quarter: 1}, contest)
 	require.NoError(t, err)
+	assert.Equal(t, 4, contest.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_RecordQuarterResult_VersionConflict(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "quarter_results"`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`UPDATE "contests"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	contest := &model.Contest{ID: uuid.New(), Status: model.ContestStatusQ2, Version: 3}
+	err := repo.RecordQuarterResult(context.Background(), &model.QuarterResult{ContestID: contest.ID, Quarter: 1}, contest)
+	assert.ErrorIs(t, err, errs.ErrContestVersionConflict)
+	assert.Equal(t, 3, contest.Version)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -275,3 +699,212 @@ func TestContestRepository_RollbackQuarterResult_DeleteError(t *testing.T) {
 	require.Error(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestContestRepository_GetDueForLock(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	id := uuid.New()
+	mock.ExpectQuery(`SELECT \* FROM "contests" WHERE status = .* AND lock_at IS NOT NULL AND lock_at <= .* AND game_id IS NULL`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(id, "c"))
+	mock.ExpectQuery(`SELECT \* FROM "squares"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "contest_id"}).AddRow(uuid.New(), id))
+
+	contests, err := repo.GetDueForLock(context.Background(), time.Now())
+	require.NoError(t, err)
+	require.Len(t, contests, 1)
+	assert.Len(t, contests[0].Squares, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_StartWithSquares(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "squares" SET .* WHERE id = .* AND owner = ''`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "contests"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.StartWithSquares(context.Background(), &model.Contest{ID: uuid.New(), Name: "x"}, nil,
+		[]model.Square{{ID: uuid.New(), Owner: "alice", Value: "AL"}})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_StartWithSquares_ClearsUnpaid(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "squares" SET .* WHERE contest_id = .* AND owner IN \(\$\d+\)`).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`UPDATE "squares" SET .* WHERE id = .* AND owner = ''`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "contests"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.StartWithSquares(context.Background(), &model.Contest{ID: uuid.New(), Name: "x"}, []string{"bob"},
+		[]model.Square{{ID: uuid.New(), Owner: model.HouseUser, Value: "HSE"}})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_StartWithSquares_AlreadyClaimed(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "squares"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err := repo.StartWithSquares(context.Background(), &model.Contest{ID: uuid.New(), Name: "x"}, nil,
+		[]model.Square{{ID: uuid.New(), Owner: "alice", Value: "AL"}})
+	assert.ErrorIs(t, err, errs.ErrSquareAlreadyClaimed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_ClaimSquares(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+	contestID := uuid.New()
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+
+	mock.ExpectBegin()
+	expectContestLock(mock, model.ContestStatusActive)
+	mock.ExpectQuery(`SELECT \* FROM "contest_participants" .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "max_squares"}).AddRow(uuid.New(), 2))
+	mock.ExpectExec(`UPDATE "squares" SET .* WHERE contest_id = .* AND id IN .* AND \(owner = '' OR owner = .*\)`).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "squares"`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(`SELECT \* FROM "squares"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "contest_id", "owner", "value"}).
+			AddRow(ids[0], contestID, "owner", "AB").
+			AddRow(ids[1], contestID, "owner", "AB"))
+	mock.ExpectExec(`UPDATE "contests" SET "version"=version \+ 1`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	squares, err := repo.ClaimSquares(context.Background(), contestID, ids, "AB", "owner", "Owner Name")
+	require.NoError(t, err)
+	assert.Len(t, squares, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_ClaimSquares_AlreadyClaimed(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	mock.ExpectBegin()
+	expectContestLock(mock, model.ContestStatusActive)
+	mock.ExpectQuery(`SELECT \* FROM "contest_participants" .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "max_squares"}).AddRow(uuid.New(), 5))
+	mock.ExpectExec(`UPDATE "squares"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+
+	_, err := repo.ClaimSquares(context.Background(), uuid.New(), []uuid.UUID{uuid.New(), uuid.New()}, "AB", "owner", "")
+	assert.ErrorIs(t, err, errs.ErrSquareAlreadyClaimed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_ClaimSquares_LimitReached(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	mock.ExpectBegin()
+	expectContestLock(mock, model.ContestStatusActive)
+	mock.ExpectQuery(`SELECT \* FROM "contest_participants" .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "max_squares"}).AddRow(uuid.New(), 2))
+	mock.ExpectExec(`UPDATE "squares"`).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "squares"`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectRollback()
+
+	_, err := repo.ClaimSquares(context.Background(), uuid.New(), []uuid.UUID{uuid.New(), uuid.New()}, "AB", "owner", "")
+	assert.ErrorIs(t, err, errs.ErrSquareLimitReached)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_AssignSquare_ChangedHands(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	mock.ExpectBegin()
+	expectContestLock(mock, model.ContestStatusActive)
+	mock.ExpectQuery(`SELECT \* FROM "contest_participants" .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "max_squares"}).AddRow(uuid.New(), 5))
+	mock.ExpectExec(`UPDATE "squares" SET .* WHERE id = .* AND owner = `).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err := repo.AssignSquare(context.Background(), &model.Square{ID: uuid.New(), Owner: "old"}, "AB", "new", "")
+	assert.ErrorIs(t, err, errs.ErrSquareAlreadyClaimed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_AcceptSwap(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+	swap := &model.SquareSwap{ID: uuid.New(), FromSquareID: uuid.New(), ToSquareID: uuid.New(), Requester: "u", Recipient: "v"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "square_swaps" SET "status"=.* WHERE id = .* AND status = `).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT \* FROM "squares" WHERE contest_id = .* AND id IN .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "owner", "value"}).
+			AddRow(swap.ToSquareID, "v", "VV").
+			AddRow(swap.FromSquareID, "u", "UU"))
+	mock.ExpectExec(`UPDATE "squares"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "squares"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "square_swaps" SET "status"=.* WHERE id <> `).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE "contests" SET "version"=version \+ 1`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	squares, err := repo.AcceptSwap(context.Background(), swap)
+	require.NoError(t, err)
+	require.Len(t, squares, 2)
+	assert.Equal(t, swap.FromSquareID, squares[0].ID)
+	assert.Equal(t, "v", squares[0].Owner)
+	assert.Equal(t, "VV", squares[0].Value)
+	assert.Equal(t, "u", squares[1].Owner)
+	assert.Equal(t, model.SwapStatusAccepted, swap.Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_AcceptSwap_NotPending(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "square_swaps"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err := repo.AcceptSwap(context.Background(), &model.SquareSwap{ID: uuid.New()})
+	assert.ErrorIs(t, err, errs.ErrSwapNotPending)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_AcceptSwap_Outdated(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+	swap := &model.SquareSwap{ID: uuid.New(), FromSquareID: uuid.New(), ToSquareID: uuid.New(), Requester: "u", Recipient: "v"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "square_swaps"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT \* FROM "squares"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "owner"}).
+			AddRow(swap.FromSquareID, "u").
+			AddRow(swap.ToSquareID, "someone-else"))
+	mock.ExpectRollback()
+
+	_, err := repo.AcceptSwap(context.Background(), swap)
+	assert.ErrorIs(t, err, errs.ErrSwapOutdated)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContestRepository_CloseSwap_NotPending(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewContestRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "square_swaps"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.CloseSwap(context.Background(), &model.SquareSwap{ID: uuid.New()}, model.SwapStatusDeclined)
+	assert.ErrorIs(t, err, errs.ErrSwapNotPending)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FriendRepository interface {
+	Create(ctx context.Context, friendship *model.Friendship) error
+	GetBetween(ctx context.Context, a, b string) (*model.Friendship, error)
+	Update(ctx context.Context, friendship *model.Friendship) error
+	DeleteBetween(ctx context.Context, a, b string) error
+	AreFriends(ctx context.Context, a, b string) (bool, error)
+
+	GetFriends(ctx context.Context, user string) ([]model.Friend, error)
+	GetPending(ctx context.Context, user string) ([]model.Friendship, error)
+	SearchUsers(ctx context.Context, user, query string, limit int) ([]model.UserSearchResult, error)
+}
+
+type friendRepository struct {
+	db *gorm.DB
+}
+
+func NewFriendRepository(db *gorm.DB) FriendRepository {
+	return &friendRepository{
+		db: db,
+	}
+}
+
+// a pair can only hold one row in either direction, so a second request for the same pair inserts nothing
+func (r *friendRepository) Create(ctx context.Context, friendship *model.Friendship) error {
+	res := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(friendship)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errs.ErrFriendRequestExists
+	}
+	return nil
+}
+
+func (r *friendRepository) GetBetween(ctx context.Context, a, b string) (*model.Friendship, error) {
+	var friendship model.Friendship
+	err := r.db.WithContext(ctx).
+		Where(pairClause, a, b, b, a).
+		First(&friendship).Error
+	return &friendship, err
+}
+
+func (r *friendRepository) Update(ctx context.Context, friendship *model.Friendship) error {
+	return r.db.WithContext(ctx).Save(friendship).Error
+}
+
+func (r *friendRepository) DeleteBetween(ctx context.Context, a, b string) error {
+	result := r.db.WithContext(ctx).
+		Where(pairClause, a, b, b, a).
+		Delete(&model.Friendship{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *friendRepository) AreFriends(ctx context.Context, a, b string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&model.Friendship{}).
+		Where(pairClause, a, b, b, a).
+		Where("status = ?", model.FriendshipStatusAccepted).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// joining users drops scrubbed accounts and supplies the current display name
+func (r *friendRepository) GetFriends(ctx context.Context, user string) ([]model.Friend, error) {
+	friends := make([]model.Friend, 0)
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT u.email AS user_id, u.display_name AS display_name, f.accepted_at AS since
+		FROM friendships f
+		JOIN users u ON lower(u.email) = lower(CASE WHEN lower(f.requester) = lower(?) THEN f.addressee ELSE f.requester END)
+		WHERE f.status = ? AND (lower(f.requester) = lower(?) OR lower(f.addressee) = lower(?))
+		ORDER BY u.display_name ASC`,
+		user, model.FriendshipStatusAccepted, user, user).
+		Scan(&friends).Error
+	return friends, err
+}
+
+func (r *friendRepository) GetPending(ctx context.Context, user string) ([]model.Friendship, error) {
+	var pending []model.Friendship
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND (lower(requester) = lower(?) OR lower(addressee) = lower(?))", model.FriendshipStatusPending, user, user).
+		Order("created_at DESC").
+		Find(&pending).Error
+	return pending, err
+}
+
+// only people the user already knows are searchable: accepted friends and anyone they share a contest with
+func (r *friendRepository) SearchUsers(ctx context.Context, user, query string, limit int) ([]model.UserSearchResult, error) {
+	results := make([]model.UserSearchResult, 0, limit)
+	err := r.db.WithContext(ctx).Raw(`
+		WITH friends AS (
+			SELECT lower(CASE WHEN lower(requester) = lower(?) THEN addressee ELSE requester END) AS email
+			FROM friendships
+			WHERE status = ? AND (lower(requester) = lower(?) OR lower(addressee) = lower(?))
+		), mates AS (
+			SELECT DISTINCT lower(other.user_id) AS email
+			FROM contest_participants me
+			JOIN contest_participants other ON other.contest_id = me.contest_id
+			WHERE lower(me.user_id) = lower(?)
+		)
+		SELECT u.email AS user_id, u.display_name AS display_name,
+			lower(u.email) IN (SELECT email FROM friends) AS friend
+		FROM users u
+		WHERE lower(u.email) <> lower(?)
+			AND (lower(u.email) IN (SELECT email FROM friends) OR lower(u.email) IN (SELECT email FROM mates))
+			AND u.display_name ILIKE ?
+		ORDER BY friend DESC, u.display_name ASC
+		LIMIT ?`,
+		user, model.FriendshipStatusAccepted, user, user, user, user, "%"+query+"%", limit).
+		Scan(&results).Error
+	return results, err
+}
+
+// matches the pair in either direction, case-insensitively like the unique index
+const pairClause = "((lower(requester) = lower(?) AND lower(addressee) = lower(?)) OR (lower(requester) = lower(?) AND lower(addressee) = lower(?)))"
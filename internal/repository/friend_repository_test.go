@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestFriendRepository_Create_Duplicate(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewFriendRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "friendships" .* ON CONFLICT DO NOTHING`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.Create(context.Background(), &model.Friendship{Requester: "a", Addressee: "b"})
+
+	assert.ErrorIs(t, err, errs.ErrFriendRequestExists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFriendRepository_GetBetween_EitherDirection(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewFriendRepository(gdb)
+
+	mock.ExpectQuery(`SELECT \* FROM "friendships" WHERE \(\(lower\(requester\) = lower\(\$1\) AND lower\(addressee\) = lower\(\$2\)\) OR \(lower\(requester\) = lower\(\$3\) AND lower\(addressee\) = lower\(\$4\)\)\)`).
+		WithArgs("a", "b", "b", "a", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"requester", "addressee", "status"}).AddRow("b", "a", "pending"))
+
+	f, err := repo.GetBetween(context.Background(), "a", "b")
+
+	require.NoError(t, err)
+	assert.Equal(t, "b", f.Requester)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFriendRepository_DeleteBetween_NotFound(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewFriendRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "friendships"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.DeleteBetween(context.Background(), "a", "b")
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFriendRepository_AreFriends(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewFriendRepository(gdb)
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "friendships" WHERE .* AND status = \$5`).
+		WithArgs("a", "b", "b", "a", model.FriendshipStatusAccepted).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	ok, err := repo.AreFriends(context.Background(), "a", "b")
+
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFriendRepository_GetFriends(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewFriendRepository(gdb)
+
+	mock.ExpectQuery(`SELECT u\.email AS user_id, u\.display_name AS display_name, f\.accepted_at AS since\s+FROM friendships f\s+JOIN users u`).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "display_name", "since"}).AddRow("b@x.com", "Bea", time.Now()))
+
+	friends, err := repo.GetFriends(context.Background(), "a@x.com")
+
+	require.NoError(t, err)
+	require.Len(t, friends, 1)
+	assert.Equal(t, "Bea", friends[0].DisplayName)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFriendRepository_SearchUsers(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewFriendRepository(gdb)
+
+	mock.ExpectQuery(`WITH friends AS .* mates AS .* u\.display_name ILIKE \$7\s+ORDER BY friend DESC, u\.display_name ASC\s+LIMIT \$8`).
+		WithArgs("a", model.FriendshipStatusAccepted, "a", "a", "a", "a", "%max%", 20).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "display_name", "friend"}).AddRow("m@x.com", "Max", true).AddRow("n@x.com", "Maxine", false))
+
+	results, err := repo.SearchUsers(context.Background(), "a", "max", 20)
+
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.True(t, results[0].Friend)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -20,6 +20,7 @@ type GameRepository interface {
 	GetUpcoming(ctx context.Context) ([]model.Game, error)
 
 	UpsertScore(ctx context.Context, score *model.GameScore) (created bool, err error)
+	GetDigitFrequencies(ctx context.Context) ([]model.DigitFrequency, error)
 
 	HasLiveGame(ctx context.Context) (bool, error)
 	NextKickoff(ctx context.Context) (time.Time, error)
@@ -120,3 +121,15 @@ func (r *gameRepository) UpsertScore(ctx context.Context, score *model.GameScore
 
 	return res.RowsAffected > 0, nil
 }
+
+func (r *gameRepository) GetDigitFrequencies(ctx context.Context) ([]model.DigitFrequency, error) {
+	// only finished games count toward the last-digit pair distribution; live scores are still moving
+	var freqs []model.DigitFrequency
+	err := r.db.WithContext(ctx).
+		Model(&model.GameScore{}).
+		Select("game_scores.quarter, game_scores.home_score % 10 AS home_digit, game_scores.away_score % 10 AS away_digit, COUNT(*) AS count").
+		Joins("JOIN games g ON g.id = game_scores.game_id AND g.status = ?", model.GameStatusFinal).
+		Group("game_scores.quarter, game_scores.home_score % 10, game_scores.away_score % 10").
+		Scan(&freqs).Error
+	return freqs, err
+}
@@ -174,3 +174,20 @@ func TestGameRepository_UpsertScore_Duplicate(t *testing.T) {
 	assert.False(t, created)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestGameRepository_GetDigitFrequencies(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewGameRepository(gdb)
+
+	mock.ExpectQuery(`SELECT game_scores.quarter, game_scores.home_score % 10 AS home_digit.* FROM "game_scores" JOIN games g ON g.id = game_scores.game_id AND g.status = \$1 GROUP BY`).
+		WithArgs(model.GameStatusFinal).
+		WillReturnRows(sqlmock.NewRows([]string{"quarter", "home_digit", "away_digit", "count"}).
+			AddRow(1, 7, 0, 12).
+			AddRow(4, 4, 7, 3))
+
+	freqs, err := repo.GetDigitFrequencies(context.Background())
+	require.NoError(t, err)
+	require.Len(t, freqs, 2)
+	assert.Equal(t, model.DigitFrequency{Quarter: 1, HomeDigit: 7, AwayDigit: 0, Count: 12}, freqs[0])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyRepository interface {
+	Reserve(ctx context.Context, record *model.IdempotencyKey, staleBefore time.Time) (bool, error)
+	Get(ctx context.Context, userID, key string) (*model.IdempotencyKey, error)
+	Complete(ctx context.Context, record *model.IdempotencyKey) error
+	Release(ctx context.Context, record *model.IdempotencyKey) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+type idempotencyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(db *gorm.DB) IdempotencyRepository {
+	return &idempotencyRepository{
+		db: db,
+	}
+}
+
+func (r *idempotencyRepository) Reserve(ctx context.Context, record *model.IdempotencyKey, staleBefore time.Time) (bool, error) {
+	// claim the key, or take over one that expired but hasn't been purged yet, or whose first attempt
+	// never finished (the process died before it could complete or release it)
+	res := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "key"}},
+			DoUpdates: clause.AssignmentColumns([]string{"request_hash", "status_code", "response_body", "response_headers", "created_at", "expires_at"}),
+			Where: clause.Where{Exprs: []clause.Expression{clause.Or(
+				clause.Lt{Column: clause.Column{Table: "idempotency_keys", Name: "expires_at"}, Value: record.CreatedAt},
+				clause.And(
+					clause.Eq{Column: clause.Column{Table: "idempotency_keys", Name: "status_code"}, Value: 0},
+					clause.Lt{Column: clause.Column{Table: "idempotency_keys", Name: "created_at"}, Value: staleBefore},
+				),
+			)}},
+		}).
+		Create(record)
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected == 1, nil
+}
+
+func (r *idempotencyRepository) Get(ctx context.Context, userID, key string) (*model.IdempotencyKey, error) {
+	var record model.IdempotencyKey
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND key = ?", userID, key).
+		First(&record).Error; err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// scoped to this attempt's reservation so an attempt whose key was reclaimed as stale can't overwrite the new one
+func (r *idempotencyRepository) Complete(ctx context.Context, record *model.IdempotencyKey) error {
+	// a struct update so the headers go through their json serializer
+	return r.db.WithContext(ctx).
+		Model(record).
+		Select("status_code", "response_body", "response_headers").
+		Where("created_at = ?", record.CreatedAt).
+		Updates(record).Error
+}
+
+func (r *idempotencyRepository) Release(ctx context.Context, record *model.IdempotencyKey) error {
+	return r.db.WithContext(ctx).
+		Where("user_id = ? AND key = ? AND created_at = ? AND status_code = 0", record.UserID, record.Key, record.CreatedAt).
+		Delete(&model.IdempotencyKey{}).Error
+}
+
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	res := r.db.WithContext(ctx).
+		Where("expires_at < ?", now).
+		Delete(&model.IdempotencyKey{})
+	return res.RowsAffected, res.Error
+}
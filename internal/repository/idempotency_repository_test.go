@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func idempotencyRecord() *model.IdempotencyKey {
+	now := time.Now()
+	return &model.IdempotencyKey{UserID: "u", Key: "k", RequestHash: "h", CreatedAt: now, ExpiresAt: now.Add(time.Hour)}
+}
+
+func TestIdempotencyRepository_Reserve(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewIdempotencyRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "idempotency_keys" .* ON CONFLICT \("user_id","key"\) DO UPDATE SET .* WHERE \("idempotency_keys"\."expires_at" < \$\d+ OR \("idempotency_keys"\."status_code" = \$\d+ AND "idempotency_keys"\."created_at" < \$\d+\)\)`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	reserved, err := repo.Reserve(context.Background(), idempotencyRecord(), time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	assert.True(t, reserved)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdempotencyRepository_Reserve_Taken(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewIdempotencyRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "idempotency_keys"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	reserved, err := repo.Reserve(context.Background(), idempotencyRecord(), time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdempotencyRepository_Get(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewIdempotencyRepository(gdb)
+
+	mock.ExpectQuery(`SELECT \* FROM "idempotency_keys" WHERE user_id = \$1 AND key = \$2`).
+		WithArgs("u", "k", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "key", "status_code", "response_body"}).AddRow("u", "k", 201, []byte(`{}`)))
+
+	record, err := repo.Get(context.Background(), "u", "k")
+	require.NoError(t, err)
+	assert.Equal(t, 201, record.StatusCode)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdempotencyRepository_Complete(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewIdempotencyRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "idempotency_keys" SET "status_code"=\$1,"response_body"=\$2,"response_headers"=\$3 WHERE created_at = \$4 AND "user_id" = \$5 AND "key" = \$6`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	record := idempotencyRecord()
+	record.StatusCode = 200
+	record.ResponseBody = []byte(`{}`)
+	record.ResponseHeaders = map[string]string{"ETag": `"1"`}
+	require.NoError(t, repo.Complete(context.Background(), record))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdempotencyRepository_Release(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewIdempotencyRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "idempotency_keys" WHERE user_id = \$1 AND key = \$2 AND created_at = \$3 AND status_code = 0`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.Release(context.Background(), idempotencyRecord()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdempotencyRepository_DeleteExpired(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewIdempotencyRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "idempotency_keys" WHERE expires_at < \$1`).
+		WillReturnResult(sqlmock.NewResult(0, 6))
+	mock.ExpectCommit()
+
+	purged, err := repo.DeleteExpired(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), purged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,18 +2,31 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
 	"github.com/maxmorhardt/squares-api/internal/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type InviteRepository interface {
 	GetByToken(ctx context.Context, token string) (*model.ContestInvite, error)
+	GetByID(ctx context.Context, contestID, id uuid.UUID) (*model.ContestInvite, error)
 	GetAllByContestID(ctx context.Context, contestID uuid.UUID) ([]model.ContestInvite, error)
+	GetFunnelsByContestID(ctx context.Context, contestID uuid.UUID) (map[uuid.UUID]model.InviteFunnel, error)
+	GetReservationsByContestID(ctx context.Context, contestID uuid.UUID) (map[uuid.UUID][]model.SquarePosition, error)
+	GetReservationsByInviteID(ctx context.Context, inviteID uuid.UUID) ([]model.SquarePosition, error)
 	Create(ctx context.Context, invite *model.ContestInvite) error
-	RedeemInvite(ctx context.Context, inviteID uuid.UUID, participant *model.ContestParticipant) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	CreateWithReservations(ctx context.Context, invite *model.ContestInvite, positions []model.SquarePosition) ([]model.Square, error)
+	Update(ctx context.Context, invite *model.ContestInvite) error
+	RecordView(ctx context.Context, invite *model.ContestInvite, visitor string) error
+	CreateMany(ctx context.Context, invites []*model.ContestInvite) error
+	UpdateEmailStatus(ctx context.Context, id uuid.UUID, status model.InviteEmailStatus) error
+	MarkOpened(ctx context.Context, id uuid.UUID) error
+	RedeemInvite(ctx context.Context, invite *model.ContestInvite, participant *model.ContestParticipant, claim *model.Square) ([]model.Square, error)
+	Delete(ctx context.Context, contestID, id uuid.UUID) ([]model.Square, error)
 }
 
 type inviteRepository struct {
@@ -32,33 +45,277 @@ func (r *inviteRepository) GetByToken(ctx context.Context, token string) (*model
 	return &invite, err
 }
 
+// scoped to the contest so an invite id from another contest can't be edited through this one
+func (r *inviteRepository) GetByID(ctx context.Context, contestID, id uuid.UUID) (*model.ContestInvite, error) {
+	var invite model.ContestInvite
+	err := r.db.WithContext(ctx).Where("id = ? AND contest_id = ?", id, contestID).First(&invite).Error
+	return &invite, err
+}
+
 func (r *inviteRepository) GetAllByContestID(ctx context.Context, contestID uuid.UUID) ([]model.ContestInvite, error) {
 	var invites []model.ContestInvite
 	err := r.db.WithContext(ctx).Where("contest_id = ?", contestID).Order("created_at DESC").Find(&invites).Error
 	return invites, err
 }
 
+// redemption counts come from the invite's uses; events only supply views and timestamps
+func (r *inviteRepository) GetFunnelsByContestID(ctx context.Context, contestID uuid.UUID) (map[uuid.UUID]model.InviteFunnel, error) {
+	var rows []struct {
+		InviteID       uuid.UUID
+		Views          int
+		FirstViewedAt  *time.Time
+		LastViewedAt   *time.Time
+		LastRedeemedAt *time.Time
+	}
+
+	err := r.db.WithContext(ctx).
+		Model(&model.ContestInviteEvent{}).
+		Select(`invite_id,
+			COUNT(*) FILTER (WHERE kind = ?) AS views,
+			MIN(created_at) FILTER (WHERE kind = ?) AS first_viewed_at,
+			MAX(created_at) FILTER (WHERE kind = ?) AS last_viewed_at,
+			MAX(created_at) FILTER (WHERE kind = ?) AS last_redeemed_at`,
+			model.InviteEventView, model.InviteEventView, model.InviteEventView, model.InviteEventRedeem).
+		Where("contest_id = ?", contestID).
+		Group("invite_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	funnels := make(map[uuid.UUID]model.InviteFunnel, len(rows))
+	for _, row := range rows {
+		funnels[row.InviteID] = model.InviteFunnel{
+			Views:          row.Views,
+			FirstViewedAt:  row.FirstViewedAt,
+			LastViewedAt:   row.LastViewedAt,
+			LastRedeemedAt: row.LastRedeemedAt,
+		}
+	}
+
+	return funnels, nil
+}
+
+func (r *inviteRepository) GetReservationsByContestID(ctx context.Context, contestID uuid.UUID) (map[uuid.UUID][]model.SquarePosition, error) {
+	var squares []model.Square
+	err := r.db.WithContext(ctx).
+		Select("reserved_invite_id", "row", "col").
+		Where("contest_id = ? AND reserved_invite_id IS NOT NULL", contestID).
+		Order(`"row", col`).
+		Find(&squares).Error
+	if err != nil {
+		return nil, err
+	}
+
+	reservations := make(map[uuid.UUID][]model.SquarePosition)
+	for _, sq := range squares {
+		reservations[*sq.ReservedInviteID] = append(reservations[*sq.ReservedInviteID], model.SquarePosition{Row: sq.Row, Col: sq.Col})
+	}
+
+	return reservations, nil
+}
+
+func (r *inviteRepository) GetReservationsByInviteID(ctx context.Context, inviteID uuid.UUID) ([]model.SquarePosition, error) {
+	var positions []model.SquarePosition
+	err := r.db.WithContext(ctx).
+		Model(&model.Square{}).
+		Select("row", "col").
+		Where("reserved_invite_id = ?", inviteID).
+		Order(`"row", col`).
+		Scan(&positions).Error
+	return positions, err
+}
+
 func (r *inviteRepository) Create(ctx context.Context, invite *model.ContestInvite) error {
 	return r.db.WithContext(ctx).Create(invite).Error
 }
 
-func (r *inviteRepository) RedeemInvite(ctx context.Context, inviteID uuid.UUID, participant *model.ContestParticipant) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+// the invite and its held squares are written together so a taken square leaves no half-made invite behind
+func (r *inviteRepository) CreateWithReservations(ctx context.Context, invite *model.ContestInvite, positions []model.SquarePosition) ([]model.Square, error) {
+	var reservedSquares []model.Square
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(invite).Error; err != nil {
+			return err
+		}
+
+		// a square can be held only while it's empty and no other live invite holds it
+		for _, pos := range positions {
+			res := tx.Model(&model.Square{}).
+				Where(`contest_id = ? AND "row" = ? AND col = ? AND owner = '' AND `+unreservedSquare, invite.ContestID, pos.Row, pos.Col).
+				Updates(map[string]any{"reserved_invite_id": invite.ID, "version": gorm.Expr("version + 1")})
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return errs.ErrSquareNotReservable
+			}
+		}
+		if len(positions) > 0 {
+			if err := bumpContestVersions(tx, invite.ContestID); err != nil {
+				return err
+			}
+		}
+
+		return tx.Where("reserved_invite_id = ?", invite.ID).
+			Order(`"row", col`).
+			Find(&reservedSquares).Error
+	})
+
+	return reservedSquares, err
+}
+
+func (r *inviteRepository) Update(ctx context.Context, invite *model.ContestInvite) error {
+	return r.db.WithContext(ctx).
+		Model(invite).
+		Select("max_squares", "role", "max_uses", "expires_at", "paused", "updated_at").
+		Updates(invite).Error
+}
+
+// a repeat view from the same visitor on the same day hits the unique index and is dropped
+func (r *inviteRepository) RecordView(ctx context.Context, invite *model.ContestInvite, visitor string) error {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.ContestInviteEvent{
+			InviteID:  invite.ID,
+			ContestID: invite.ContestID,
+			Kind:      model.InviteEventView,
+			Visitor:   visitor,
+			ViewedOn:  &today,
+		}).Error
+}
+
+func (r *inviteRepository) CreateMany(ctx context.Context, invites []*model.ContestInvite) error {
+	return r.db.WithContext(ctx).Create(invites).Error
+}
+
+// only moves a pending send forward so a late delivery result never overwrites opened or redeemed
+func (r *inviteRepository) UpdateEmailStatus(ctx context.Context, id uuid.UUID, status model.InviteEmailStatus) error {
+	updates := map[string]any{"email_status": status}
+	if status == model.InviteEmailStatusSent {
+		updates["sent_at"] = time.Now()
+	}
+
+	return r.db.WithContext(ctx).Model(&model.ContestInvite{}).
+		Where("id = ? AND email_status = ?", id, model.InviteEmailStatusPending).
+		UpdateColumns(updates).Error
+}
+
+func (r *inviteRepository) MarkOpened(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&model.ContestInvite{}).
+		Where("id = ? AND opened_at IS NULL AND email_status IN ?", id, []model.InviteEmailStatus{
+			model.InviteEmailStatusPending, model.InviteEmailStatusSent, model.InviteEmailStatusFailed,
+		}).
+		UpdateColumns(map[string]any{
+			"email_status": model.InviteEmailStatusOpened,
+			"opened_at":    time.Now(),
+		}).Error
+}
+
+// claim carries the value and display name for the squares the invite reserved; nil when it reserved none
+func (r *inviteRepository) RedeemInvite(ctx context.Context, invite *model.ContestInvite, participant *model.ContestParticipant, claim *model.Square) ([]model.Square, error) {
+	var claimedSquares []model.Square
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(participant).Error; err != nil {
 			return err
 		}
 
+		if claim != nil {
+			// a contest that started since the caller checked keeps its board as locked
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("reserved_invite_id = ? AND owner = '' AND EXISTS (SELECT 1 FROM contests c WHERE c.id = squares.contest_id AND c.status = ?)", invite.ID, model.ContestStatusActive).
+				Order(`"row", col`).
+				Find(&claimedSquares).Error; err != nil {
+				return err
+			}
+
+			for i := range claimedSquares {
+				if err := tx.Model(&model.Square{}).
+					Where("id = ?", claimedSquares[i].ID).
+					Updates(map[string]any{"value": claim.Value, "owner": participant.UserID, "owner_name": claim.OwnerName, "reserved_invite_id": nil, "version": gorm.Expr("version + 1")}).Error; err != nil {
+					return err
+				}
+
+				claimedSquares[i].Value = claim.Value
+				claimedSquares[i].Owner = participant.UserID
+				claimedSquares[i].OwnerName = claim.OwnerName
+				claimedSquares[i].ReservedInviteID = nil
+				claimedSquares[i].Version++
+			}
+
+			// the reservation is used up by the first redemption, even for squares a contest start already filled
+			if err := tx.Model(&model.Square{}).
+				Where("reserved_invite_id = ?", invite.ID).
+				UpdateColumn("reserved_invite_id", nil).Error; err != nil {
+				return err
+			}
+			if err := bumpContestVersions(tx, invite.ContestID); err != nil {
+				return err
+			}
+		}
+
+		updates := map[string]any{"uses": gorm.Expr("uses + 1")}
+		if invite.IsPersonal() {
+			updates["email_status"] = model.InviteEmailStatusRedeemed
+			updates["redeemed_at"] = time.Now()
+		}
+
 		result := tx.Model(&model.ContestInvite{}).
-			Where("id = ?", inviteID).
-			UpdateColumn("uses", gorm.Expr("uses + 1"))
+			Where("id = ?", invite.ID).
+			UpdateColumns(updates)
 		if result.Error != nil {
 			return result.Error
 		}
 
-		return nil
+		return tx.Create(&model.ContestInviteEvent{
+			InviteID:  invite.ID,
+			ContestID: invite.ContestID,
+			Kind:      model.InviteEventRedeem,
+			UserID:    participant.UserID,
+		}).Error
 	})
+
+	return claimedSquares, err
 }
 
-func (r *inviteRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&model.ContestInvite{}).Error
+// returns the squares the invite was holding so their release can be broadcast
+func (r *inviteRepository) Delete(ctx context.Context, contestID, id uuid.UUID) ([]model.Square, error) {
+	var releasedSquares []model.Square
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("reserved_invite_id = ? AND contest_id = ?", id, contestID).Find(&releasedSquares).Error; err != nil {
+			return err
+		}
+
+		if len(releasedSquares) > 0 {
+			if err := tx.Model(&model.Square{}).
+				Where("reserved_invite_id = ? AND contest_id = ?", id, contestID).
+				Updates(map[string]any{"reserved_invite_id": nil, "version": gorm.Expr("version + 1")}).Error; err != nil {
+				return err
+			}
+
+			for i := range releasedSquares {
+				releasedSquares[i].ReservedInviteID = nil
+				releasedSquares[i].Version++
+			}
+			if err := bumpContestVersions(tx, contestID); err != nil {
+				return err
+			}
+		}
+
+		// an invite from another contest is rolled back along with any release above
+		result := tx.Where("id = ? AND contest_id = ?", id, contestID).Delete(&model.ContestInvite{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return releasedSquares, nil
 }
@@ -3,9 +3,11 @@ package repository
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
 	"github.com/maxmorhardt/squares-api/internal/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -72,10 +74,234 @@ func TestInviteRepository_RedeemInvite(t *testing.T) {
 	mock.ExpectBegin()
 	mock.ExpectExec(`INSERT INTO "contest_participants"`).WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectExec(`UPDATE "contest_invites"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO "contest_invite_events"`).WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	err := repo.RedeemInvite(context.Background(), uuid.New(), &model.ContestParticipant{UserID: "u"})
+	claimed, err := repo.RedeemInvite(context.Background(), &model.ContestInvite{ID: uuid.New()}, &model.ContestParticipant{UserID: "u"}, nil)
 	require.NoError(t, err)
+	assert.Empty(t, claimed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInviteRepository_RedeemInvite_ClaimsReservedSquares(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewInviteRepository(gdb)
+	invite := &model.ContestInvite{ID: uuid.New(), ContestID: uuid.New()}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "contest_participants"`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(`SELECT \* FROM "squares" WHERE reserved_invite_id = \$1 AND owner = '' AND EXISTS \(SELECT 1 FROM contests c WHERE c.id = squares.contest_id AND c.status = \$2\) ORDER BY "row", col FOR UPDATE`).
+		WithArgs(invite.ID, model.ContestStatusActive).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "row", "col", "version", "reserved_invite_id"}).
+			AddRow(uuid.New(), 2, 3, 1, invite.ID).
+			AddRow(uuid.New(), 2, 4, 1, invite.ID))
+	mock.ExpectExec(`UPDATE "squares" SET "owner"=.*"reserved_invite_id"=.*WHERE id = `).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "squares" SET "owner"=.*"reserved_invite_id"=.*WHERE id = `).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "squares" SET "reserved_invite_id"=\$1 WHERE reserved_invite_id = \$2`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE "contest_invites"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO "contest_invite_events"`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`UPDATE "contests" SET "version"=version \+ 1`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	claimed, err := repo.RedeemInvite(context.Background(), invite, &model.ContestParticipant{UserID: "alice@example.com"}, &model.Square{Value: "AL", OwnerName: "Alice"})
+	require.NoError(t, err)
+	require.Len(t, claimed, 2)
+	for _, sq := range claimed {
+		assert.Equal(t, "alice@example.com", sq.Owner)
+		assert.Equal(t, "AL", sq.Value)
+		assert.Nil(t, sq.ReservedInviteID)
+		assert.Equal(t, 2, sq.Version)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInviteRepository_CreateWithReservations(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewInviteRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "contest_invites"`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`UPDATE "squares" SET .*"reserved_invite_id"=.* WHERE contest_id = \$\d+ AND "row" = \$\d+ AND col = \$\d+ AND owner = '' AND \(squares.reserved_invite_id IS NULL OR NOT EXISTS`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT \* FROM "squares" WHERE reserved_invite_id = `).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "row", "col"}).AddRow(uuid.New(), 1, 2))
+	mock.ExpectExec(`UPDATE "contests" SET "version"=version \+ 1`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	reserved, err := repo.CreateWithReservations(context.Background(), &model.ContestInvite{ContestID: uuid.New()}, []model.SquarePosition{{Row: 1, Col: 2}})
+	require.NoError(t, err)
+	assert.Len(t, reserved, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInviteRepository_CreateWithReservations_SquareUnavailable(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewInviteRepository(gdb)
+
+	// the invite insert is rolled back along with the failed reservation
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "contest_invites"`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`UPDATE "squares"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err := repo.CreateWithReservations(context.Background(), &model.ContestInvite{ContestID: uuid.New()}, []model.SquarePosition{{Row: 1, Col: 2}})
+	assert.ErrorIs(t, err, errs.ErrSquareNotReservable)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInviteRepository_GetReservationsByContestID(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewInviteRepository(gdb)
+	first, second := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(`SELECT "reserved_invite_id","row","col" FROM "squares" WHERE contest_id = \$1 AND reserved_invite_id IS NOT NULL`).
+		WillReturnRows(sqlmock.NewRows([]string{"reserved_invite_id", "row", "col"}).
+			AddRow(first, 0, 1).
+			AddRow(second, 3, 3).
+			AddRow(first, 0, 2))
+
+	got, err := repo.GetReservationsByContestID(context.Background(), uuid.New())
+	require.NoError(t, err)
+	assert.Equal(t, []model.SquarePosition{{Row: 0, Col: 1}, {Row: 0, Col: 2}}, got[first])
+	assert.Equal(t, []model.SquarePosition{{Row: 3, Col: 3}}, got[second])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInviteRepository_GetReservationsByInviteID(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewInviteRepository(gdb)
+	inviteID := uuid.New()
+
+	mock.ExpectQuery(`SELECT "row","col" FROM "squares" WHERE reserved_invite_id = \$1 ORDER BY "row", col`).
+		WithArgs(inviteID).
+		WillReturnRows(sqlmock.NewRows([]string{"row", "col"}).AddRow(0, 1).AddRow(0, 2))
+
+	got, err := repo.GetReservationsByInviteID(context.Background(), inviteID)
+	require.NoError(t, err)
+	assert.Equal(t, []model.SquarePosition{{Row: 0, Col: 1}, {Row: 0, Col: 2}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInviteRepository_RedeemInvite_PersonalRecordsRedemption(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewInviteRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "contest_participants"`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`UPDATE "contest_invites" SET .*"email_status"=.*"redeemed_at"=.*"uses"=uses \+ 1`).
+		WithArgs(model.InviteEmailStatusRedeemed, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO "contest_invite_events"`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	invite := &model.ContestInvite{ID: uuid.New(), Email: "alice@example.com"}
+	_, err := repo.RedeemInvite(context.Background(), invite, &model.ContestParticipant{UserID: "alice@example.com"}, nil)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInviteRepository_CreateMany(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewInviteRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "contest_invites"`).WillReturnResult(sqlmock.NewResult(2, 2))
+	mock.ExpectCommit()
+
+	invites := []*model.ContestInvite{{Email: "a@example.com"}, {Email: "b@example.com"}}
+	require.NoError(t, repo.CreateMany(context.Background(), invites))
+	assert.NotEqual(t, invites[0].Token, invites[1].Token)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInviteRepository_UpdateEmailStatus(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewInviteRepository(gdb)
+
+	// only pending sends move forward, and a successful send stamps sent_at
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "contest_invites" SET "email_status"=\$1,"sent_at"=\$2 WHERE id = \$3 AND email_status = \$4`).
+		WithArgs(model.InviteEmailStatusSent, sqlmock.AnyArg(), sqlmock.AnyArg(), model.InviteEmailStatusPending).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.UpdateEmailStatus(context.Background(), uuid.New(), model.InviteEmailStatusSent))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInviteRepository_MarkOpened(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewInviteRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "contest_invites" SET "email_status"=\$1,"opened_at"=\$2 WHERE id = \$3 AND opened_at IS NULL AND email_status IN`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.MarkOpened(context.Background(), uuid.New()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInviteRepository_GetByID_ScopedToContest(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewInviteRepository(gdb)
+
+	contestID, inviteID := uuid.New(), uuid.New()
+	mock.ExpectQuery(`SELECT .* FROM "contest_invites" WHERE id = \$1 AND contest_id = \$2`).
+		WithArgs(inviteID, contestID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err := repo.GetByID(context.Background(), contestID, inviteID)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInviteRepository_GetFunnelsByContestID(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewInviteRepository(gdb)
+
+	inviteID := uuid.New()
+	first := time.Now().Add(-time.Hour)
+	last := time.Now()
+	mock.ExpectQuery(`SELECT invite_id,\s+COUNT\(\*\) FILTER .* FROM "contest_invite_events" WHERE contest_id = .* GROUP BY "invite_id"`).
+		WillReturnRows(sqlmock.NewRows([]string{"invite_id", "views", "first_viewed_at", "last_viewed_at", "last_redeemed_at"}).
+			AddRow(inviteID, 12, first, last, nil))
+
+	funnels, err := repo.GetFunnelsByContestID(context.Background(), uuid.New())
+
+	require.NoError(t, err)
+	require.Contains(t, funnels, inviteID)
+	assert.Equal(t, 12, funnels[inviteID].Views)
+	assert.Nil(t, funnels[inviteID].LastRedeemedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInviteRepository_Update(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewInviteRepository(gdb)
+
+	// zero values like paused=false and a cleared expiry must still be written
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "contest_invites" SET "max_squares"=\$1,"role"=\$2,"expires_at"=\$3,"max_uses"=\$4,"paused"=\$5,"updated_at"=\$6 WHERE "id" = \$7`).
+		WithArgs(0, model.ParticipantRoleViewer, nil, 0, false, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.Update(context.Background(), &model.ContestInvite{ID: uuid.New(), Role: model.ParticipantRoleViewer}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInviteRepository_RecordView(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewInviteRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "contest_invite_events" .* ON CONFLICT DO NOTHING`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), model.InviteEventView, "", "visitor-hash", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.RecordView(context.Background(), &model.ContestInvite{ID: uuid.New(), ContestID: uuid.New()}, "visitor-hash"))
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -84,11 +310,59 @@ func TestInviteRepository_Delete(t *testing.T) {
 	repo := NewInviteRepository(gdb)
 
 	mock.ExpectBegin()
-	mock.ExpectExec(`DELETE FROM "contest_invites"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT \* FROM "squares" WHERE reserved_invite_id = \$1 AND contest_id = \$2`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectExec(`DELETE FROM "contest_invites" WHERE id = \$1 AND contest_id = \$2`).WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
-	err := repo.Delete(context.Background(), uuid.New())
+	released, err := repo.Delete(context.Background(), uuid.New(), uuid.New())
 
 	require.NoError(t, err)
+	assert.Empty(t, released)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInviteRepository_Delete_ReleasesReservedSquares(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewInviteRepository(gdb)
+	contestID, inviteID := uuid.New(), uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "squares" WHERE reserved_invite_id = \$1 AND contest_id = \$2`).
+		WithArgs(inviteID, contestID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "version", "reserved_invite_id"}).AddRow(uuid.New(), 3, inviteID))
+	mock.ExpectExec(`UPDATE "squares" SET "reserved_invite_id"=\$1,"version"=version \+ 1.* WHERE reserved_invite_id = \$3 AND contest_id = \$4`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM "contest_invites"`).WithArgs(inviteID, contestID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "contests" SET "version"=version \+ 1`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	released, err := repo.Delete(context.Background(), contestID, inviteID)
+
+	require.NoError(t, err)
+	require.Len(t, released, 1)
+	assert.Nil(t, released[0].ReservedInviteID)
+	assert.Equal(t, 4, released[0].Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInviteRepository_Delete_OtherContestInvite(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewInviteRepository(gdb)
+	contestID, inviteID := uuid.New(), uuid.New()
+
+	// the invite belongs to another contest, so neither its squares nor the row match
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "squares" WHERE reserved_invite_id = \$1 AND contest_id = \$2`).
+		WithArgs(inviteID, contestID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectExec(`DELETE FROM "contest_invites" WHERE id = \$1 AND contest_id = \$2`).
+		WithArgs(inviteID, contestID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	released, err := repo.Delete(context.Background(), contestID, inviteID)
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Nil(t, released)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
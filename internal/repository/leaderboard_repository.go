@@ -7,12 +7,14 @@ import (
 	"gorm.io/gorm"
 )
 
+// the contest_* views include archived contests alongside the live squares and results; imported contests
+// were played somewhere else, so their wins don't rank
 const winsCTE = `WITH wins AS (
 	SELECT q.winner AS email, COUNT(*) AS quarter_wins
-	FROM quarter_results q
-	JOIN contests c ON c.id = q.contest_id AND c.status <> ?
+	FROM contest_quarter_winners q
+	JOIN contests c ON c.id = q.contest_id AND c.status <> ? AND c.imported_at IS NULL
 	JOIN users u ON u.email = q.winner
-	WHERE q.winner <> '' AND q.winner <> ?
+	WHERE q.winner <> '' AND q.winner NOT IN (?, ?)
 	GROUP BY q.winner
 )`
 
@@ -44,21 +46,21 @@ func (r *leaderboardRepository) GetTopWinners(ctx context.Context, limit int) ([
 		JOIN users u ON u.email = w.email
 		LEFT JOIN (
 			SELECT s.owner, COUNT(*) AS squares_claimed
-			FROM squares s
-			JOIN contests c ON c.id = s.contest_id AND c.status <> ?
+			FROM contest_square_owners s
+			JOIN contests c ON c.id = s.contest_id AND c.status <> ? AND c.imported_at IS NULL
 			WHERE s.owner <> '' AND s.owner IN (SELECT email FROM wins)
 			GROUP BY s.owner
 		) sq ON sq.owner = w.email
 		LEFT JOIN (
 			SELECT s.owner, COUNT(*) AS quarters_played
-			FROM (SELECT DISTINCT owner, contest_id FROM squares WHERE owner <> '' AND owner IN (SELECT email FROM wins)) s
-			JOIN quarter_results q ON q.contest_id = s.contest_id
-			JOIN contests c ON c.id = s.contest_id AND c.status <> ?
+			FROM (SELECT DISTINCT owner, contest_id FROM contest_square_owners WHERE owner <> '' AND owner IN (SELECT email FROM wins)) s
+			JOIN contest_quarter_winners q ON q.contest_id = s.contest_id
+			JOIN contests c ON c.id = s.contest_id AND c.status <> ? AND c.imported_at IS NULL
 			GROUP BY s.owner
 		) qp ON qp.owner = w.email
 		ORDER BY w.quarter_wins DESC, squares_claimed ASC, u.display_name ASC
 		LIMIT ?`,
-		model.ContestStatusDeleted, model.GhostUser, model.ContestStatusDeleted, model.ContestStatusDeleted, limit).
+		model.ContestStatusDeleted, model.GhostUser, model.HouseUser, model.ContestStatusDeleted, model.ContestStatusDeleted, limit).
 		Scan(&entries).Error; err != nil {
 		return nil, err
 	}
@@ -80,7 +82,7 @@ func (r *leaderboardRepository) GetUserRank(ctx context.Context, email string) (
 				THEN (SELECT COUNT(*) + 1 FROM wins w WHERE w.quarter_wins > (SELECT quarter_wins FROM me))
 				ELSE 0
 			END AS rank`,
-		model.ContestStatusDeleted, model.GhostUser, email).
+		model.ContestStatusDeleted, model.GhostUser, model.HouseUser, email).
 		Scan(&rank).Error; err != nil {
 		return nil, err
 	}
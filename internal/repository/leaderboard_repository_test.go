@@ -14,7 +14,7 @@ func TestLeaderboardRepository_GetTopWinners_Success(t *testing.T) {
 	gdb, mock := newMockDB(t)
 	repo := NewLeaderboardRepository(gdb)
 
-	mock.ExpectQuery(`WITH wins AS`).
+	mock.ExpectQuery(`WITH wins AS .* JOIN contests c ON c\.id = q\.contest_id AND c\.status <> \$1 AND c\.imported_at IS NULL`).
 		WillReturnRows(
 			sqlmock.NewRows([]string{"display_name", "quarter_wins", "squares_claimed", "quarters_played"}).
 				AddRow("Max", 12, 48, 40).
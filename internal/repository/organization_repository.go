@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OrganizationRepository interface {
+	Create(ctx context.Context, org *model.Organization, creator *model.OrganizationMember) error
+	GetByID(ctx context.Context, id uuid.UUID) (*model.Organization, error)
+	GetAllByUserID(ctx context.Context, userID string) ([]model.Organization, error)
+
+	GetMember(ctx context.Context, orgID uuid.UUID, userID string) (*model.OrganizationMember, error)
+	GetMembers(ctx context.Context, orgID uuid.UUID) ([]model.OrganizationMember, error)
+	AddMember(ctx context.Context, member *model.OrganizationMember) error
+	UpdateMember(ctx context.Context, member *model.OrganizationMember) error
+	RemoveMember(ctx context.Context, orgID uuid.UUID, userID string) error
+	CountAdmins(ctx context.Context, orgID uuid.UUID) (int, error)
+}
+
+type organizationRepository struct {
+	db *gorm.DB
+}
+
+func NewOrganizationRepository(db *gorm.DB) OrganizationRepository {
+	return &organizationRepository{
+		db: db,
+	}
+}
+
+// the creator becomes the first admin in the same transaction so an organization never exists without one
+func (r *organizationRepository) Create(ctx context.Context, org *model.Organization, creator *model.OrganizationMember) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(org).Error; err != nil {
+			return err
+		}
+
+		creator.OrgID = org.ID
+		return tx.Create(creator).Error
+	})
+}
+
+func (r *organizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.Organization, error) {
+	var org model.Organization
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&org).Error
+	return &org, err
+}
+
+func (r *organizationRepository) GetAllByUserID(ctx context.Context, userID string) ([]model.Organization, error) {
+	var orgs []model.Organization
+	err := r.db.WithContext(ctx).
+		Joins("JOIN organization_members om ON om.org_id = organizations.id").
+		Where("lower(om.user_id) = lower(?)", userID).
+		Order("organizations.name ASC").
+		Find(&orgs).Error
+	return orgs, err
+}
+
+// emails are matched case-insensitively, the same way the unique index compares them
+func (r *organizationRepository) GetMember(ctx context.Context, orgID uuid.UUID, userID string) (*model.OrganizationMember, error) {
+	var member model.OrganizationMember
+	err := r.db.WithContext(ctx).
+		Where("org_id = ? AND lower(user_id) = lower(?)", orgID, userID).
+		First(&member).Error
+	return &member, err
+}
+
+func (r *organizationRepository) GetMembers(ctx context.Context, orgID uuid.UUID) ([]model.OrganizationMember, error) {
+	var members []model.OrganizationMember
+	err := r.db.WithContext(ctx).
+		Where("org_id = ?", orgID).
+		Order("created_at ASC").
+		Find(&members).Error
+	return members, err
+}
+
+func (r *organizationRepository) AddMember(ctx context.Context, member *model.OrganizationMember) error {
+	res := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(member)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errs.ErrAlreadyOrgMember
+	}
+	return nil
+}
+
+func (r *organizationRepository) UpdateMember(ctx context.Context, member *model.OrganizationMember) error {
+	return r.db.WithContext(ctx).Save(member).Error
+}
+
+func (r *organizationRepository) RemoveMember(ctx context.Context, orgID uuid.UUID, userID string) error {
+	result := r.db.WithContext(ctx).
+		Where("org_id = ? AND lower(user_id) = lower(?)", orgID, userID).
+		Delete(&model.OrganizationMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *organizationRepository) CountAdmins(ctx context.Context, orgID uuid.UUID) (int, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&model.OrganizationMember{}).
+		Where("org_id = ? AND role = ?", orgID, model.OrgRoleAdmin).
+		Count(&count).Error
+	return int(count), err
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestOrganizationRepository_Create(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewOrganizationRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "organizations"`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO "organization_members"`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	org := &model.Organization{Name: "Office", CreatedBy: "u"}
+	creator := &model.OrganizationMember{UserID: "u", Role: model.OrgRoleAdmin, AddedBy: "u"}
+	err := repo.Create(context.Background(), org, creator)
+
+	require.NoError(t, err)
+	assert.Equal(t, org.ID, creator.OrgID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOrganizationRepository_GetAllByUserID(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewOrganizationRepository(gdb)
+
+	mock.ExpectQuery(`SELECT "organizations"\.".*" FROM "organizations" JOIN organization_members om .* lower\(om\.user_id\) = lower\(\$1\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(uuid.New(), "Office"))
+
+	orgs, err := repo.GetAllByUserID(context.Background(), "U@x.com")
+
+	require.NoError(t, err)
+	assert.Len(t, orgs, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOrganizationRepository_AddMember_AlreadyMember(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewOrganizationRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "organization_members" .* ON CONFLICT DO NOTHING`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.AddMember(context.Background(), &model.OrganizationMember{OrgID: uuid.New(), UserID: "v"})
+
+	assert.ErrorIs(t, err, errs.ErrAlreadyOrgMember)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOrganizationRepository_RemoveMember_NotFound(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewOrganizationRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "organization_members" WHERE org_id = \$1 AND lower\(user_id\) = lower\(\$2\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.RemoveMember(context.Background(), uuid.New(), "v")
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOrganizationRepository_CountAdmins(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewOrganizationRepository(gdb)
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "organization_members" WHERE org_id = \$1 AND role = \$2`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	count, err := repo.CountAdmins(context.Background(), uuid.New())
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
 	"github.com/maxmorhardt/squares-api/internal/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ParticipantRepository interface {
@@ -14,7 +16,14 @@ type ParticipantRepository interface {
 	GetTotalAllocatedSquares(ctx context.Context, contestID uuid.UUID) (int, error)
 	CountSquaresByUser(ctx context.Context, contestID uuid.UUID, userID string) (int, error)
 	Update(ctx context.Context, participant *model.ContestParticipant) error
+	UpdateSquareStyle(ctx context.Context, participant *model.ContestParticipant) ([]model.Square, error)
 	Delete(ctx context.Context, contestID uuid.UUID, userID string) error
+	Import(ctx context.Context, contestID uuid.UUID, added, updated []model.ContestParticipant, claims []model.Square) ([]model.Square, error)
+
+	Ban(ctx context.Context, ban *model.ContestBan, squares string) ([]model.Square, error)
+	IsBanned(ctx context.Context, contestID uuid.UUID, userID string) (bool, error)
+	GetBans(ctx context.Context, contestID uuid.UUID) ([]model.ContestBan, error)
+	DeleteBan(ctx context.Context, contestID uuid.UUID, userID string) error
 }
 
 type participantRepository struct {
@@ -68,8 +77,246 @@ func (r *participantRepository) Update(ctx context.Context, participant *model.C
 	return r.db.WithContext(ctx).Save(participant).Error
 }
 
+func (r *participantRepository) UpdateSquareStyle(ctx context.Context, participant *model.ContestParticipant) ([]model.Square, error) {
+	var squares []model.Square
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.ContestParticipant{}).
+			Where("id = ?", participant.ID).
+			Updates(map[string]any{"display_value": participant.DisplayValue, "color": participant.Color}).Error; err != nil {
+			return err
+		}
+
+		// clearing the override puts the squares back on the profile default
+		var initials string
+		if err := tx.Model(&model.User{}).
+			Select("default_initials").
+			Where("email = ?", participant.UserID).
+			Scan(&initials).Error; err != nil {
+			return err
+		}
+
+		value, color := participant.SquareStyle(initials)
+		updates := map[string]any{"color": color, "version": gorm.Expr("version + 1")}
+		if value != "" {
+			updates["value"] = value
+		}
+
+		if err := tx.Model(&model.Square{}).
+			Where("contest_id = ? AND owner = ?", participant.ContestID, participant.UserID).
+			Updates(updates).Error; err != nil {
+			return err
+		}
+		if err := bumpContestVersions(tx, participant.ContestID); err != nil {
+			return err
+		}
+
+		// re-select the restyled squares so the caller can broadcast the change
+		return tx.Where("contest_id = ? AND owner = ?", participant.ContestID, participant.UserID).
+			Order(`"row", col`).
+			Find(&squares).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return squares, nil
+}
+
 func (r *participantRepository) Delete(ctx context.Context, contestID uuid.UUID, userID string) error {
 	return r.db.WithContext(ctx).
 		Where("contest_id = ? AND user_id = ?", contestID, userID).
 		Delete(&model.ContestParticipant{}).Error
 }
+
+// records the ban and drops any membership together so the user can't slip back in between the two
+// the ban, the banned user's squares and their membership change together so a failure part-way
+// never leaves a banned user holding squares or a board freed without the ban in place
+func (r *participantRepository) Ban(ctx context.Context, ban *model.ContestBan, squares string) ([]model.Square, error) {
+	var changed []model.Square
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(ban)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errs.ErrAlreadyBanned
+		}
+
+		if squares != "" {
+			if err := tx.Where("contest_id = ? AND owner = ?", ban.ContestID, ban.UserID).Find(&changed).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(changed) > 0 {
+			// ghosting keeps the value so a started grid stays filled and scoring is unaffected
+			updates := map[string]any{"owner": model.GhostUser, "owner_name": "", "color": "", "version": gorm.Expr("version + 1")}
+			if squares == model.BanSquaresClear {
+				updates["owner"] = ""
+				updates["value"] = ""
+			}
+			if err := tx.Model(&model.Square{}).
+				Where("contest_id = ? AND owner = ?", ban.ContestID, ban.UserID).
+				Updates(updates).Error; err != nil {
+				return err
+			}
+			if err := bumpContestVersions(tx, ban.ContestID); err != nil {
+				return err
+			}
+
+			for i := range changed {
+				changed[i].Owner = model.GhostUser
+				if squares == model.BanSquaresClear {
+					changed[i].Owner = ""
+					changed[i].Value = ""
+				}
+				changed[i].OwnerName = ""
+				changed[i].Color = ""
+				changed[i].Version++
+			}
+		}
+
+		return tx.Where("contest_id = ? AND user_id = ?", ban.ContestID, ban.UserID).
+			Delete(&model.ContestParticipant{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return changed, nil
+}
+
+// emails are matched case-insensitively, the same way the unique index compares them
+func (r *participantRepository) IsBanned(ctx context.Context, contestID uuid.UUID, userID string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&model.ContestBan{}).
+		Where("contest_id = ? AND lower(user_id) = lower(?)", contestID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *participantRepository) GetBans(ctx context.Context, contestID uuid.UUID) ([]model.ContestBan, error) {
+	var bans []model.ContestBan
+	err := r.db.WithContext(ctx).
+		Where("contest_id = ?", contestID).
+		Order("created_at DESC").
+		Find(&bans).Error
+	return bans, err
+}
+
+func (r *participantRepository) DeleteBan(ctx context.Context, contestID uuid.UUID, userID string) error {
+	res := r.db.WithContext(ctx).
+		Where("contest_id = ? AND lower(user_id) = lower(?)", contestID, userID).
+		Delete(&model.ContestBan{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *participantRepository) Import(ctx context.Context, contestID uuid.UUID, added, updated []model.ContestParticipant, claims []model.Square) ([]model.Square, error) {
+	var claimedSquares []model.Square
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// imports and friend adds serialize on the contest row, so the limit sum below sees every one that committed first
+		if _, err := lockContest(tx, contestID); err != nil {
+			return err
+		}
+
+		if len(added) > 0 {
+			if err := tx.Create(&added).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, p := range updated {
+			if err := tx.Model(&model.ContestParticipant{}).
+				Where("id = ?", p.ID).
+				Updates(map[string]any{"role": p.Role, "max_squares": p.MaxSquares}).Error; err != nil {
+				return err
+			}
+		}
+
+		// the preview checked the pool against a snapshot; re-check now that the rows are written
+		var total int
+		if err := tx.Model(&model.ContestParticipant{}).
+			Where("contest_id = ?", contestID).
+			Select("COALESCE(SUM(max_squares), 0)").
+			Row().
+			Scan(&total); err != nil {
+			return err
+		}
+		if total > 100 {
+			return errs.ErrNotEnoughSquares
+		}
+
+		claimants := make(map[string]*model.ContestParticipant)
+		for _, sq := range claims {
+			if _, ok := claimants[sq.Owner]; ok {
+				continue
+			}
+			participant, err := lockClaimant(tx, contestID, sq.Owner)
+			if err != nil {
+				return err
+			}
+			claimants[sq.Owner] = participant
+		}
+
+		// same rule as a claim: the square must still be empty or already theirs, and not held by a live invite
+		for _, sq := range claims {
+			value, color := claimants[sq.Owner].SquareStyle(sq.Value)
+			res := tx.Model(&model.Square{}).
+				Where(`contest_id = ? AND "row" = ? AND col = ? AND (owner = '' OR owner = ?) AND `+unreservedSquare, contestID, sq.Row, sq.Col, sq.Owner).
+				Updates(map[string]any{"value": value, "owner": sq.Owner, "owner_name": sq.OwnerName, "color": color, "version": gorm.Expr("version + 1")})
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return errs.ErrSquareAlreadyClaimed
+			}
+		}
+
+		for owner, participant := range claimants {
+			if err := checkSquareLimit(tx, contestID, owner, participant.MaxSquares); err != nil {
+				return err
+			}
+		}
+
+		if len(claims) == 0 {
+			return nil
+		}
+		if err := bumpContestVersions(tx, contestID); err != nil {
+			return err
+		}
+
+		owners := make([]string, 0, len(claimants))
+		for owner := range claimants {
+			owners = append(owners, owner)
+		}
+
+		var squares []model.Square
+		if err := tx.Where("contest_id = ? AND owner IN ?", contestID, owners).
+			Order(`"row", col`).
+			Find(&squares).Error; err != nil {
+			return err
+		}
+
+		// only hand back the squares this import touched so the broadcast stays small
+		claimed := make(map[[2]int]bool, len(claims))
+		for _, sq := range claims {
+			claimed[[2]int{sq.Row, sq.Col}] = true
+		}
+		for _, sq := range squares {
+			if claimed[[2]int{sq.Row, sq.Col}] {
+				claimedSquares = append(claimedSquares, sq)
+			}
+		}
+		return nil
+	})
+
+	return claimedSquares, err
+}
@@ -2,12 +2,16 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 func TestParticipantRepository_GetByContestAndUser(t *testing.T) {
@@ -82,3 +86,203 @@ func TestParticipantRepository_Delete(t *testing.T) {
 	require.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestParticipantRepository_UpdateSquareStyle(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewParticipantRepository(gdb)
+
+	color := "teal"
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "contest_participants" SET "color"=\$1,"display_value"=\$2`).
+		WithArgs("teal", nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT "default_initials" FROM "users"`).
+		WillReturnRows(sqlmock.NewRows([]string{"default_initials"}).AddRow("MM"))
+	// a cleared display value puts the squares back on the profile initials
+	mock.ExpectExec(`UPDATE "squares" SET "color"=\$1,"value"=\$2,"version"=version \+ 1`).
+		WithArgs("teal", "MM", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(`SELECT \* FROM "squares" WHERE contest_id = \$1 AND owner = \$2`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "value", "color"}).
+			AddRow(uuid.New(), "MM", "teal").
+			AddRow(uuid.New(), "MM", "teal"))
+	mock.ExpectExec(`UPDATE "contests" SET "version"=version \+ 1`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	squares, err := repo.UpdateSquareStyle(context.Background(), &model.ContestParticipant{ID: uuid.New(), ContestID: uuid.New(), UserID: "mom@b.com", Color: &color})
+
+	require.NoError(t, err)
+	require.Len(t, squares, 2)
+	assert.Equal(t, "teal", squares[0].Color)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestParticipantRepository_Ban(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewParticipantRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "contest_bans" .* ON CONFLICT DO NOTHING`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM "contest_participants"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	squares, err := repo.Ban(context.Background(), &model.ContestBan{ContestID: uuid.New(), UserID: "u1", BannedBy: "owner"}, "")
+
+	require.NoError(t, err)
+	assert.Empty(t, squares)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestParticipantRepository_Ban_ClearsSquares(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewParticipantRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "contest_bans"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT \* FROM "squares" WHERE contest_id = \$1 AND owner = \$2`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "value", "owner", "version"}).AddRow(uuid.New(), "AB", "u1", 2))
+	mock.ExpectExec(`UPDATE "squares" SET "color"=\$1,"owner"=\$2,"owner_name"=\$3,"value"=\$4,"version"=version \+ 1`).
+		WithArgs("", "", "", "", sqlmock.AnyArg(), sqlmock.AnyArg(), "u1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "contests" SET "version"=version \+ 1`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM "contest_participants"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	squares, err := repo.Ban(context.Background(), &model.ContestBan{ContestID: uuid.New(), UserID: "u1", BannedBy: "owner"}, model.BanSquaresClear)
+
+	require.NoError(t, err)
+	require.Len(t, squares, 1)
+	assert.Empty(t, squares[0].Owner)
+	assert.Empty(t, squares[0].Value)
+	assert.Equal(t, 3, squares[0].Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestParticipantRepository_Ban_GhostFailureRollsBack(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewParticipantRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "contest_bans"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT \* FROM "squares"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "owner"}).AddRow(uuid.New(), "u1"))
+	mock.ExpectExec(`UPDATE "squares"`).WillReturnError(errors.New("update failed"))
+	mock.ExpectRollback()
+
+	_, err := repo.Ban(context.Background(), &model.ContestBan{ContestID: uuid.New(), UserID: "u1", BannedBy: "owner"}, model.BanSquaresGhost)
+
+	require.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestParticipantRepository_Ban_AlreadyBanned(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewParticipantRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "contest_bans"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err := repo.Ban(context.Background(), &model.ContestBan{ContestID: uuid.New(), UserID: "u1", BannedBy: "owner"}, model.BanSquaresClear)
+
+	assert.ErrorIs(t, err, errs.ErrAlreadyBanned)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestParticipantRepository_IsBanned(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewParticipantRepository(gdb)
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "contest_bans" WHERE contest_id = \$1 AND lower\(user_id\) = lower\(\$2\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	banned, err := repo.IsBanned(context.Background(), uuid.New(), "U1")
+
+	require.NoError(t, err)
+	assert.True(t, banned)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestParticipantRepository_DeleteBan_NotFound(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewParticipantRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "contest_bans"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.DeleteBan(context.Background(), uuid.New(), "u1")
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestParticipantRepository_Import(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewParticipantRepository(gdb)
+
+	contestID := uuid.New()
+	mock.ExpectBegin()
+	expectContestLock(mock, model.ContestStatusActive)
+	mock.ExpectExec(`INSERT INTO "contest_participants"`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`UPDATE "contest_participants" SET .* WHERE id = \$\d+`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT COALESCE\(SUM\(max_squares\), 0\) FROM "contest_participants"`).
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(12))
+	mock.ExpectQuery(`SELECT \* FROM "contest_participants" .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "max_squares"}).AddRow("new", 2))
+	mock.ExpectExec(`UPDATE "squares" SET .* WHERE contest_id = \$\d+ AND "row" = \$\d+ AND col = \$\d+ AND \(owner = '' OR owner = \$\d+\)`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "squares"`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT \* FROM "squares" WHERE contest_id = \$1 AND owner IN \(\$2\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"row", "col", "owner"}).AddRow(9, 9, "new").AddRow(0, 0, "new"))
+	mock.ExpectExec(`UPDATE "contests" SET "version"=version \+ 1`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	squares, err := repo.Import(context.Background(), contestID,
+		[]model.ContestParticipant{{ContestID: contestID, UserID: "new", Role: model.ParticipantRoleParticipant, MaxSquares: 2}},
+		[]model.ContestParticipant{{ID: uuid.New(), UserID: "old", Role: model.ParticipantRoleParticipant, MaxSquares: 4}},
+		[]model.Square{{Row: 9, Col: 9, Owner: "new", Value: "N"}})
+
+	require.NoError(t, err)
+	// squares the owner already held aren't part of the import
+	require.Len(t, squares, 1)
+	assert.Equal(t, 9, squares[0].Row)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestParticipantRepository_Import_PoolExceeded(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewParticipantRepository(gdb)
+
+	mock.ExpectBegin()
+	expectContestLock(mock, model.ContestStatusActive)
+	mock.ExpectExec(`INSERT INTO "contest_participants"`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(`SELECT COALESCE\(SUM\(max_squares\), 0\) FROM "contest_participants"`).
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(101))
+	mock.ExpectRollback()
+
+	_, err := repo.Import(context.Background(), uuid.New(),
+		[]model.ContestParticipant{{UserID: "new", Role: model.ParticipantRoleParticipant, MaxSquares: 2}}, nil, nil)
+
+	assert.ErrorIs(t, err, errs.ErrNotEnoughSquares)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestParticipantRepository_Import_SquareTaken(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewParticipantRepository(gdb)
+
+	mock.ExpectBegin()
+	expectContestLock(mock, model.ContestStatusActive)
+	mock.ExpectQuery(`SELECT COALESCE\(SUM\(max_squares\), 0\) FROM "contest_participants"`).
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(10))
+	mock.ExpectQuery(`SELECT \* FROM "contest_participants" .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "max_squares"}).AddRow("old", 2))
+	mock.ExpectExec(`UPDATE "squares" SET`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err := repo.Import(context.Background(), uuid.New(), nil, nil, []model.Square{{Row: 1, Col: 1, Owner: "old"}})
+
+	assert.ErrorIs(t, err, errs.ErrSquareAlreadyClaimed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"gorm.io/gorm"
+)
+
+type SpectatorRepository interface {
+	GetByToken(ctx context.Context, token string) (*model.ContestSpectatorToken, error)
+	GetAllByContestID(ctx context.Context, contestID uuid.UUID) ([]model.ContestSpectatorToken, error)
+	Create(ctx context.Context, token *model.ContestSpectatorToken) error
+	Delete(ctx context.Context, contestID, id uuid.UUID) error
+}
+
+type spectatorRepository struct {
+	db *gorm.DB
+}
+
+func NewSpectatorRepository(db *gorm.DB) SpectatorRepository {
+	return &spectatorRepository{
+		db: db,
+	}
+}
+
+func (r *spectatorRepository) GetByToken(ctx context.Context, token string) (*model.ContestSpectatorToken, error) {
+	var spectatorToken model.ContestSpectatorToken
+	err := r.db.WithContext(ctx).Where("token = ?", token).First(&spectatorToken).Error
+	return &spectatorToken, err
+}
+
+func (r *spectatorRepository) GetAllByContestID(ctx context.Context, contestID uuid.UUID) ([]model.ContestSpectatorToken, error) {
+	var tokens []model.ContestSpectatorToken
+	err := r.db.WithContext(ctx).Where("contest_id = ?", contestID).Order("created_at DESC").Find(&tokens).Error
+	return tokens, err
+}
+
+func (r *spectatorRepository) Create(ctx context.Context, token *model.ContestSpectatorToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+// scoped to the contest so a token id from another contest can't be revoked through this one
+func (r *spectatorRepository) Delete(ctx context.Context, contestID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND contest_id = ?", id, contestID).Delete(&model.ContestSpectatorToken{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
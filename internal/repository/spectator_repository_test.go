@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestSpectatorRepository_GetByToken(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewSpectatorRepository(gdb)
+
+	mock.ExpectQuery(`SELECT .* FROM "contest_spectator_tokens"`).
+		WillReturnRows(sqlmock.NewRows([]string{"token", "label"}).AddRow("tok123", "Grandma"))
+
+	token, err := repo.GetByToken(context.Background(), "tok123")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Grandma", token.Label)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSpectatorRepository_GetAllByContestID(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewSpectatorRepository(gdb)
+
+	mock.ExpectQuery(`SELECT .* FROM "contest_spectator_tokens" WHERE contest_id = .* ORDER BY created_at DESC`).
+		WillReturnRows(sqlmock.NewRows([]string{"token"}).AddRow("t1").AddRow("t2"))
+
+	tokens, err := repo.GetAllByContestID(context.Background(), uuid.New())
+
+	require.NoError(t, err)
+	assert.Len(t, tokens, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSpectatorRepository_Create(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewSpectatorRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "contest_spectator_tokens"`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	token := &model.ContestSpectatorToken{ContestID: uuid.New(), CreatedBy: "owner"}
+	require.NoError(t, repo.Create(context.Background(), token))
+	assert.Len(t, token.Token, 64, "a token is generated on create")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSpectatorRepository_Delete(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewSpectatorRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "contest_spectator_tokens" WHERE id = .* AND contest_id = `).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.Delete(context.Background(), uuid.New(), uuid.New()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSpectatorRepository_Delete_NotFound(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewSpectatorRepository(gdb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "contest_spectator_tokens"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.Delete(context.Background(), uuid.New(), uuid.New())
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
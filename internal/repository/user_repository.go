@@ -6,6 +6,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/maxmorhardt/squares-api/internal/model"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -14,9 +15,13 @@ import (
 type UserRepository interface {
 	GetOrCreate(ctx context.Context, email, defaultDisplayName, defaultInitials string) (*model.User, error)
 	GetByEmail(ctx context.Context, email string) (*model.User, error)
-	UpdateProfile(ctx context.Context, email, initials string) (*model.User, []model.Square, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*model.User, error)
+	UpdateProfile(ctx context.Context, email string, req *model.UpdateUserProfileRequest) (*model.User, []model.Square, error)
+	GetByAvatarKey(ctx context.Context, key string) (*model.User, error)
+	SetAvatarVersion(ctx context.Context, email string, key *string, updatedAt *time.Time) (*model.User, error)
 	GetStats(ctx context.Context, email string) (*model.UserStatsResponse, error)
 	GetActiveContests(ctx context.Context, email string) ([]model.UserActiveContest, error)
+	GetRecentContests(ctx context.Context, email, viewer string, limit int) ([]model.PublicProfileContest, error)
 	ScrubUserData(ctx context.Context, email string) error
 	IsTokenRevoked(ctx context.Context, email string, issuedAtUnix int64) (bool, error)
 }
@@ -92,14 +97,46 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*model.U
 	return user, nil
 }
 
-func (r *userRepository) UpdateProfile(ctx context.Context, email, initials string) (*model.User, []model.Square, error) {
+func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	user := &model.User{}
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(user).Error; err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (r *userRepository) UpdateProfile(ctx context.Context, email string, req *model.UpdateUserProfileRequest) (*model.User, []model.Square, error) {
 	user := &model.User{}
 	var squares []model.Square
 
+	updates := map[string]any{}
+	squareUpdates := map[string]any{}
+	if req.DefaultInitials != nil {
+		updates["default_initials"] = *req.DefaultInitials
+		// squares with a per-contest display value keep it when the profile default changes
+		squareUpdates["value"] = gorm.Expr(
+			`CASE WHEN EXISTS (
+				SELECT 1 FROM contest_participants p
+				WHERE p.contest_id = squares.contest_id AND p.user_id = squares.owner AND p.display_value IS NOT NULL
+			) THEN value ELSE ? END`, *req.DefaultInitials)
+	}
+	if req.DisplayName != nil {
+		updates["display_name"] = *req.DisplayName
+		squareUpdates["owner_name"] = *req.DisplayName
+	}
+	if req.ProfileVisibility != nil {
+		updates["profile_visibility"] = *req.ProfileVisibility
+		// avatar urls need no auth, so links handed out under the old visibility stop working
+		updates["avatar_key"] = gorm.Expr(
+			`CASE WHEN avatar_key IS NULL OR profile_visibility = ? THEN avatar_key
+			ELSE replace(gen_random_uuid()::text, '-', '') END`, *req.ProfileVisibility)
+	}
+
 	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Model(&model.User{}).
 			Where("email = ?", email).
-			Update("default_initials", initials).Error; err != nil {
+			Updates(updates).Error; err != nil {
 			return err
 		}
 
@@ -107,13 +144,19 @@ func (r *userRepository) UpdateProfile(ctx context.Context, email, initials stri
 			return err
 		}
 
-		// cascade the new initials to the user's squares in contests still in play
+		// visibility alone never shows up on a board
+		if len(squareUpdates) == 0 {
+			return nil
+		}
+
+		// cascade the new initials and name to the user's squares in contests still in play
 		liveContests := tx.Model(&model.Contest{}).Select("id").
 			Where("status NOT IN ?", []model.ContestStatus{model.ContestStatusFinished, model.ContestStatusDeleted})
 
+		squareUpdates["version"] = gorm.Expr("version + 1")
 		if err := tx.Model(&model.Square{}).
 			Where("owner = ? AND contest_id IN (?)", email, liveContests).
-			Update("value", initials).Error; err != nil {
+			Updates(squareUpdates).Error; err != nil {
 			return err
 		}
 
@@ -123,7 +166,7 @@ func (r *userRepository) UpdateProfile(ctx context.Context, email, initials stri
 			return err
 		}
 
-		return nil
+		return bumpContestVersions(tx, squareContestIDs(squares)...)
 	})
 	if err != nil {
 		return nil, nil, err
@@ -132,6 +175,25 @@ func (r *userRepository) UpdateProfile(ctx context.Context, email, initials stri
 	return user, squares, nil
 }
 
+func (r *userRepository) GetByAvatarKey(ctx context.Context, key string) (*model.User, error) {
+	user := &model.User{}
+	if err := r.db.WithContext(ctx).Where("avatar_key = ?", key).First(user).Error; err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (r *userRepository) SetAvatarVersion(ctx context.Context, email string, key *string, updatedAt *time.Time) (*model.User, error) {
+	if err := r.db.WithContext(ctx).Model(&model.User{}).
+		Where("email = ?", email).
+		Updates(map[string]any{"avatar_key": key, "avatar_updated_at": updatedAt}).Error; err != nil {
+		return nil, err
+	}
+
+	return r.GetByEmail(ctx, email)
+}
+
 func (r *userRepository) GetStats(ctx context.Context, email string) (*model.UserStatsResponse, error) {
 	var stats model.UserStatsResponse
 
@@ -144,24 +206,25 @@ func (r *userRepository) GetStats(ctx context.Context, email string) (*model.Use
 
 	if err := r.db.WithContext(ctx).
 		Model(&model.ContestParticipant{}).
-		Joins("JOIN contests c ON c.id = contest_participants.contest_id AND c.status <> ?", model.ContestStatusDeleted).
+		Joins("JOIN contests c ON c.id = contest_participants.contest_id AND c.status <> ? AND c.imported_at IS NULL", model.ContestStatusDeleted).
 		Where("contest_participants.user_id = ?", email).
 		Count(&stats.ContestsJoined).Error; err != nil {
 		return nil, err
 	}
 
+	// squares and results go through the views so archived contests still count; imported ones never do
 	if err := r.db.WithContext(ctx).
-		Model(&model.Square{}).
-		Joins("JOIN contests c ON c.id = squares.contest_id AND c.status <> ?", model.ContestStatusDeleted).
-		Where("squares.owner = ?", email).
+		Table("contest_square_owners s").
+		Joins("JOIN contests c ON c.id = s.contest_id AND c.status <> ? AND c.imported_at IS NULL", model.ContestStatusDeleted).
+		Where("s.owner = ?", email).
 		Count(&stats.SquaresClaimed).Error; err != nil {
 		return nil, err
 	}
 
 	if err := r.db.WithContext(ctx).
-		Model(&model.QuarterResult{}).
-		Joins("JOIN contests c ON c.id = quarter_results.contest_id AND c.status <> ?", model.ContestStatusDeleted).
-		Where("quarter_results.winner = ?", email).
+		Table("contest_quarter_winners q").
+		Joins("JOIN contests c ON c.id = q.contest_id AND c.status <> ? AND c.imported_at IS NULL", model.ContestStatusDeleted).
+		Where("q.winner = ?", email).
 		Count(&stats.QuarterWins).Error; err != nil {
 		return nil, err
 	}
@@ -169,10 +232,10 @@ func (r *userRepository) GetStats(ctx context.Context, email string) (*model.Use
 	// every quarter the user had a stake in, so the win rate is wins per opportunity
 	if err := r.db.WithContext(ctx).Raw(
 		`SELECT COUNT(*)
-		FROM quarter_results q
-		JOIN contests c ON c.id = q.contest_id AND c.status <> ?
+		FROM contest_quarter_winners q
+		JOIN contests c ON c.id = q.contest_id AND c.status <> ? AND c.imported_at IS NULL
 		WHERE EXISTS (
-			SELECT 1 FROM squares s WHERE s.contest_id = q.contest_id AND s.owner = ?
+			SELECT 1 FROM contest_square_owners s WHERE s.contest_id = q.contest_id AND s.owner = ?
 		)`, model.ContestStatusDeleted, email).
 		Scan(&stats.QuartersPlayed).Error; err != nil {
 		return nil, err
@@ -199,17 +262,68 @@ func (r *userRepository) GetActiveContests(ctx context.Context, email string) ([
 	return contests, nil
 }
 
+func (r *userRepository) GetRecentContests(ctx context.Context, email, viewer string, limit int) ([]model.PublicProfileContest, error) {
+	var rows []struct {
+		ID        uuid.UUID
+		Name      string
+		Status    string
+		Role      string
+		CreatedAt time.Time
+	}
+
+	// the membership row carries the role, so viewers show up as viewers; private contests only show up
+	// for viewers who can already see them
+	if err := r.db.WithContext(ctx).Raw(
+		`SELECT c.id, c.name, c.status, cp.role, c.created_at
+		FROM contests c
+		JOIN contest_participants cp ON cp.contest_id = c.id AND cp.user_id = ?
+		WHERE c.status <> ?
+		AND (c.visibility = ? OR c.owner = ? OR c.id IN (SELECT contest_id FROM contest_participants WHERE user_id = ?))
+		ORDER BY c.created_at DESC
+		LIMIT ?`,
+		email, model.ContestStatusDeleted, model.ContestVisibilityPublic, viewer, viewer, limit).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	contests := make([]model.PublicProfileContest, 0, len(rows))
+	for _, row := range rows {
+		contests = append(contests, model.PublicProfileContest{
+			ID:        row.ID.String(),
+			Name:      row.Name,
+			Status:    row.Status,
+			Role:      row.Role,
+			CreatedAt: row.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return contests, nil
+}
+
 func (r *userRepository) ScrubUserData(ctx context.Context, email string) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// free the user's squares in contests that are still being played
+		var liveContestIDs []uuid.UUID
 		if err := tx.Model(&model.Square{}).
+			Distinct("contest_id").
 			Where("owner = ? AND contest_id IN (?)", email,
 				tx.Model(&model.Contest{}).Select("id").
 					Where("status NOT IN ?", []model.ContestStatus{model.ContestStatusFinished, model.ContestStatusDeleted})).
-			Updates(map[string]any{"value": "", "owner": "", "owner_name": ""}).Error; err != nil {
+			Pluck("contest_id", &liveContestIDs).Error; err != nil {
 			return err
 		}
 
+		if len(liveContestIDs) > 0 {
+			if err := tx.Model(&model.Square{}).
+				Where("owner = ? AND contest_id IN ?", email, liveContestIDs).
+				Updates(map[string]any{"value": "", "owner": "", "owner_name": "", "color": "", "version": gorm.Expr("version + 1")}).Error; err != nil {
+				return err
+			}
+			if err := bumpContestVersions(tx, liveContestIDs...); err != nil {
+				return err
+			}
+		}
+
 		// finished/deleted contests keep their history under the ghost identity
 		anonymize := []struct {
 			tableModel any
@@ -225,6 +339,11 @@ func (r *userRepository) ScrubUserData(ctx context.Context, email string) error
 			{&model.Contest{}, "created_by"},
 			{&model.Contest{}, "updated_by"},
 			{&model.ContestInvite{}, "created_by"},
+			{&model.ContestInviteEvent{}, "user_id"},
+			{&model.ContestSpectatorToken{}, "created_by"},
+			{&model.ContestBan{}, "banned_by"},
+			{&model.Organization{}, "created_by"},
+			{&model.OrganizationMember{}, "added_by"},
 		}
 		for _, a := range anonymize {
 			if err := tx.Model(a.tableModel).
@@ -234,10 +353,62 @@ func (r *userRepository) ScrubUserData(ctx context.Context, email string) error
 			}
 		}
 
+		// personal invites stay bound to the ghost rather than falling back to bearer links
+		if err := tx.Model(&model.ContestInvite{}).
+			Where("lower(email) = lower(?)", email).
+			Update("email", model.GhostUser).Error; err != nil {
+			return err
+		}
+
+		// the ghost takes the address's place on allowlists so a restricted invite never opens up
+		if err := tx.Exec(
+			`UPDATE contest_invites
+			SET allowed_emails = (
+				SELECT jsonb_agg(CASE WHEN lower(e.value) = lower(?) THEN ? ELSE e.value END ORDER BY e.ord)
+				FROM jsonb_array_elements_text(allowed_emails) WITH ORDINALITY AS e(value, ord)
+			)
+			WHERE EXISTS (SELECT 1 FROM jsonb_array_elements_text(allowed_emails) a WHERE lower(a) = lower(?))`,
+			email, model.GhostUser, email).Error; err != nil {
+			return err
+		}
+
+		// archived snapshots hold the same identities as json strings
+		if err := tx.Exec(
+			`UPDATE contest_archives
+			SET snapshot = replace(snapshot::text, to_jsonb(?::text)::text, to_jsonb(?::text)::text)::jsonb
+			WHERE strpos(snapshot::text, to_jsonb(?::text)::text) > 0`,
+			email, model.GhostUser, email).Error; err != nil {
+			return err
+		}
+
+		// swaps can't be honoured once one side is gone
+		if err := tx.Where("requester = ? OR recipient = ?", email, email).Delete(&model.SquareSwap{}).Error; err != nil {
+			return err
+		}
+
+		// friends-only search and direct adds would otherwise keep pointing at the deleted account
+		if err := tx.Where("lower(requester) = lower(?) OR lower(addressee) = lower(?)", email, email).Delete(&model.Friendship{}).Error; err != nil {
+			return err
+		}
+
+		if err := scrubOrgMemberships(tx, email); err != nil {
+			return err
+		}
+
 		if err := tx.Where("user_id = ?", email).Delete(&model.ContestParticipant{}).Error; err != nil {
 			return err
 		}
 
+		// bans hold the address itself, so they go with the account
+		if err := tx.Where("lower(user_id) = lower(?)", email).Delete(&model.ContestBan{}).Error; err != nil {
+			return err
+		}
+
+		// stored responses can echo the user's own data back
+		if err := tx.Where("user_id = ?", email).Delete(&model.IdempotencyKey{}).Error; err != nil {
+			return err
+		}
+
 		if err := tx.Where("email = ?", email).Delete(&model.User{}).Error; err != nil {
 			return err
 		}
@@ -253,3 +424,40 @@ func (r *userRepository) ScrubUserData(ctx context.Context, email string) error
 		return nil
 	})
 }
+
+// drops the user from every organization without leaving one adminless: where they were the only
+// admin the longest-standing remaining member is promoted, and an organization with nobody left is removed
+func scrubOrgMemberships(tx *gorm.DB, email string) error {
+	var orgIDs []uuid.UUID
+	if err := tx.Model(&model.OrganizationMember{}).
+		Where("lower(user_id) = lower(?)", email).
+		Pluck("org_id", &orgIDs).Error; err != nil {
+		return err
+	}
+	if len(orgIDs) == 0 {
+		return nil
+	}
+
+	if err := tx.Exec(
+		`UPDATE organization_members SET role = ?
+		WHERE id IN (
+			SELECT DISTINCT ON (om.org_id) om.id FROM organization_members om
+			WHERE om.org_id IN ? AND lower(om.user_id) != lower(?)
+			AND NOT EXISTS (
+				SELECT 1 FROM organization_members a
+				WHERE a.org_id = om.org_id AND a.role = ? AND lower(a.user_id) != lower(?)
+			)
+			ORDER BY om.org_id, om.created_at ASC
+		)`,
+		model.OrgRoleAdmin, orgIDs, email, model.OrgRoleAdmin, email).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("lower(user_id) = lower(?)", email).Delete(&model.OrganizationMember{}).Error; err != nil {
+		return err
+	}
+
+	// contests of a removed organization keep running on their own; org_id is set null by the foreign key
+	return tx.Where("id IN ? AND NOT EXISTS (SELECT 1 FROM organization_members om WHERE om.org_id = organizations.id)", orgIDs).
+		Delete(&model.Organization{}).Error
+}
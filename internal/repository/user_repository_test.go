@@ -8,6 +8,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -131,14 +132,17 @@ func TestUserRepository_UpdateProfile_Success(t *testing.T) {
 	mock.ExpectBegin()
 	mock.ExpectExec(`UPDATE "users" SET`).WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectQuery(`SELECT .* FROM "users"`).WillReturnRows(userRows())
-	mock.ExpectExec(`UPDATE "squares" SET`).WillReturnResult(sqlmock.NewResult(0, 2))
+	// squares with a per-contest display value keep it
+	mock.ExpectExec(`UPDATE "squares" SET .*"value"=CASE WHEN EXISTS \(\s*SELECT 1 FROM contest_participants p`).WillReturnResult(sqlmock.NewResult(0, 2))
 	mock.ExpectQuery(`SELECT .* FROM "squares"`).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "contest_id", "owner", "value"}).
 			AddRow(uuid.New(), uuid.New(), "a@b.com", "MM").
 			AddRow(uuid.New(), uuid.New(), "a@b.com", "MM"))
+	mock.ExpectExec(`UPDATE "contests" SET "version"=version \+ 1`).WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
-	user, squares, err := repo.UpdateProfile(context.Background(), "a@b.com", "MM")
+	initials, name := "MM", "Maxwell"
+	user, squares, err := repo.UpdateProfile(context.Background(), "a@b.com", &model.UpdateUserProfileRequest{DefaultInitials: &initials, DisplayName: &name})
 
 	require.NoError(t, err)
 	assert.Equal(t, "a@b.com", user.Email)
@@ -146,6 +150,27 @@ func TestUserRepository_UpdateProfile_Success(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestUserRepository_UpdateProfile_VisibilityOnly(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewUserRepository(gdb)
+
+	// visibility never touches squares, but a change rotates the avatar key
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "users" SET "avatar_key"=CASE WHEN avatar_key IS NULL OR profile_visibility = \$1 THEN avatar_key .*gen_random_uuid\(\).*"profile_visibility"=\$2`).
+		WithArgs("public", "public", sqlmock.AnyArg(), "a@b.com").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT .* FROM "users"`).WillReturnRows(userRows())
+	mock.ExpectCommit()
+
+	visibility := "public"
+	user, squares, err := repo.UpdateProfile(context.Background(), "a@b.com", &model.UpdateUserProfileRequest{ProfileVisibility: &visibility})
+
+	require.NoError(t, err)
+	assert.Equal(t, "a@b.com", user.Email)
+	assert.Empty(t, squares)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestUserRepository_UpdateProfile_Error(t *testing.T) {
 	gdb, mock := newMockDB(t)
 	repo := NewUserRepository(gdb)
@@ -154,7 +179,8 @@ func TestUserRepository_UpdateProfile_Error(t *testing.T) {
 	mock.ExpectExec(`UPDATE "users" SET`).WillReturnError(errors.New("update failed"))
 	mock.ExpectRollback()
 
-	user, squares, err := repo.UpdateProfile(context.Background(), "a@b.com", "MM")
+	initials := "MM"
+	user, squares, err := repo.UpdateProfile(context.Background(), "a@b.com", &model.UpdateUserProfileRequest{DefaultInitials: &initials})
 
 	require.Error(t, err)
 	assert.Nil(t, user)
@@ -162,19 +188,88 @@ func TestUserRepository_UpdateProfile_Error(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestUserRepository_GetByID(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewUserRepository(gdb)
+
+	mock.ExpectQuery(`SELECT .* FROM "users" WHERE id = \$1`).WillReturnRows(userRows())
+
+	user, err := repo.GetByID(context.Background(), uuid.New())
+
+	require.NoError(t, err)
+	assert.Equal(t, "a@b.com", user.Email)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_SetAvatarVersion(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewUserRepository(gdb)
+
+	key := "0123456789abcdef0123456789abcdef"
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "users" SET "avatar_key"=\$1,"avatar_updated_at"=\$2`).
+		WithArgs(key, sqlmock.AnyArg(), sqlmock.AnyArg(), "a@b.com").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(`SELECT .* FROM "users"`).WillReturnRows(userRows())
+
+	now := time.Now()
+	user, err := repo.SetAvatarVersion(context.Background(), "a@b.com", &key, &now)
+
+	require.NoError(t, err)
+	assert.Equal(t, "a@b.com", user.Email)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_GetByAvatarKey(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewUserRepository(gdb)
+
+	mock.ExpectQuery(`SELECT \* FROM "users" WHERE avatar_key = \$1`).
+		WithArgs("0123456789abcdef0123456789abcdef", 1).
+		WillReturnRows(userRows())
+
+	user, err := repo.GetByAvatarKey(context.Background(), "0123456789abcdef0123456789abcdef")
+
+	require.NoError(t, err)
+	assert.Equal(t, "a@b.com", user.Email)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_GetRecentContests(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewUserRepository(gdb)
+
+	id := uuid.New()
+	created := time.Date(2026, 7, 11, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT c\.id, c\.name, c\.status, cp\.role, c\.created_at FROM contests c JOIN contest_participants cp ON cp\.contest_id = c\.id AND cp\.user_id = \$1`).
+		WithArgs("a@b.com", model.ContestStatusDeleted, model.ContestVisibilityPublic, "viewer@b.com", "viewer@b.com", 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status", "role", "created_at"}).
+			AddRow(id, "Super Bowl", "FINISHED", "viewer", created))
+
+	contests, err := repo.GetRecentContests(context.Background(), "a@b.com", "viewer@b.com", 5)
+
+	require.NoError(t, err)
+	require.Len(t, contests, 1)
+	assert.Equal(t, id.String(), contests[0].ID)
+	assert.Equal(t, "viewer", contests[0].Role)
+	assert.Equal(t, "2026-07-11T00:00:00Z", contests[0].CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestUserRepository_GetStats_Success(t *testing.T) {
 	gdb, mock := newMockDB(t)
 	repo := NewUserRepository(gdb)
 
 	mock.ExpectQuery(`SELECT count\(\*\) FROM "contests"`).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
-	mock.ExpectQuery(`SELECT count\(\*\) FROM "contest_participants" JOIN contests c ON c\.id = contest_participants\.contest_id AND c\.status <> \$1`).
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "contest_participants" JOIN contests c ON c\.id = contest_participants\.contest_id AND c\.status <> \$1 AND c\.imported_at IS NULL`).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
-	mock.ExpectQuery(`SELECT count\(\*\) FROM "squares"`).
+	mock.ExpectQuery(`SELECT count\(\*\) FROM contest_square_owners s`).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
-	mock.ExpectQuery(`SELECT count\(\*\) FROM "quarter_results"`).
+	mock.ExpectQuery(`SELECT count\(\*\) FROM contest_quarter_winners q`).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
-	mock.ExpectQuery(`FROM quarter_results q`).
+	mock.ExpectQuery(`FROM contest_quarter_winners q`).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(20))
 
 	stats, err := repo.GetStats(context.Background(), "a@b.com")
@@ -194,13 +289,13 @@ func TestUserRepository_GetStats_QuartersPlayedError(t *testing.T) {
 
 	mock.ExpectQuery(`SELECT count\(\*\) FROM "contests"`).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
-	mock.ExpectQuery(`SELECT count\(\*\) FROM "contest_participants" JOIN contests c ON c\.id = contest_participants\.contest_id AND c\.status <> \$1`).
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "contest_participants" JOIN contests c ON c\.id = contest_participants\.contest_id AND c\.status <> \$1 AND c\.imported_at IS NULL`).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
-	mock.ExpectQuery(`SELECT count\(\*\) FROM "squares"`).
+	mock.ExpectQuery(`SELECT count\(\*\) FROM contest_square_owners s`).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
-	mock.ExpectQuery(`SELECT count\(\*\) FROM "quarter_results"`).
+	mock.ExpectQuery(`SELECT count\(\*\) FROM contest_quarter_winners q`).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
-	mock.ExpectQuery(`FROM quarter_results q`).
+	mock.ExpectQuery(`FROM contest_quarter_winners q`).
 		WillReturnError(errors.New("quarters played query failed"))
 
 	stats, err := repo.GetStats(context.Background(), "a@b.com")
@@ -261,18 +356,82 @@ func TestUserRepository_ScrubUserData_Success(t *testing.T) {
 	repo := NewUserRepository(gdb)
 
 	mock.ExpectBegin()
-	// free squares in live contests, then anonymize owner/created_by/updated_by
+	// free squares in live contests and bump those boards, then anonymize owner/created_by/updated_by
+	mock.ExpectQuery(`SELECT DISTINCT "contest_id" FROM "squares"`).
+		WillReturnRows(sqlmock.NewRows([]string{"contest_id"}).AddRow(uuid.New()))
 	for i := 0; i < 4; i++ {
 		mock.ExpectExec(`UPDATE "squares"`).WillReturnResult(sqlmock.NewResult(0, 1))
 	}
 	for i := 0; i < 3; i++ {
 		mock.ExpectExec(`UPDATE "quarter_results"`).WillReturnResult(sqlmock.NewResult(0, 1))
 	}
+	mock.ExpectExec(`UPDATE "contests" SET "version"=version \+ 1`).WillReturnResult(sqlmock.NewResult(0, 1))
 	for i := 0; i < 3; i++ {
 		mock.ExpectExec(`UPDATE "contests"`).WillReturnResult(sqlmock.NewResult(0, 1))
 	}
-	mock.ExpectExec(`UPDATE "contest_invites"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "contest_invites" SET "created_by"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "contest_invites" SET "email"`).WithArgs(model.GhostUser, sqlmock.AnyArg(), "a@b.com").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE contest_invites SET allowed_emails`).WithArgs("a@b.com", model.GhostUser, "a@b.com").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "contest_invite_events" SET "user_id"`).WithArgs(model.GhostUser, "a@b.com").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "contest_spectator_tokens" SET "created_by"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "contest_bans" SET "banned_by"`).WithArgs(model.GhostUser, "a@b.com").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "organizations" SET "created_by"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "organization_members" SET "added_by"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE contest_archives`).WithArgs("a@b.com", model.GhostUser, "a@b.com").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM "square_swaps"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM "friendships"`).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(`SELECT "org_id" FROM "organization_members"`).
+		WillReturnRows(sqlmock.NewRows([]string{"org_id"}))
 	mock.ExpectExec(`DELETE FROM "contest_participants"`).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(`DELETE FROM "contest_bans" WHERE lower\(user_id\) = lower\(\$1\)`).WithArgs("a@b.com").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM "idempotency_keys"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM "users"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO deleted_accounts`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.ScrubUserData(context.Background(), "a@b.com")
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_ScrubUserData_HandsOffOrgs(t *testing.T) {
+	gdb, mock := newMockDB(t)
+	repo := NewUserRepository(gdb)
+
+	orgID := uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT DISTINCT "contest_id" FROM "squares"`).
+		WillReturnRows(sqlmock.NewRows([]string{"contest_id"}))
+	mock.ExpectExec(`UPDATE "squares"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE "squares"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE "squares"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	for i := 0; i < 3; i++ {
+		mock.ExpectExec(`UPDATE "quarter_results"`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`UPDATE "contests"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	mock.ExpectExec(`UPDATE "contest_invites" SET "created_by"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE "contest_invites" SET "email"`).WithArgs(model.GhostUser, sqlmock.AnyArg(), "a@b.com").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE contest_invites SET allowed_emails`).WithArgs("a@b.com", model.GhostUser, "a@b.com").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE "contest_invite_events" SET "user_id"`).WithArgs(model.GhostUser, "a@b.com").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE "contest_spectator_tokens" SET "created_by"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE "contest_bans" SET "banned_by"`).WithArgs(model.GhostUser, "a@b.com").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE "organizations" SET "created_by"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "organization_members" SET "added_by"`).WithArgs(model.GhostUser, "a@b.com").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`UPDATE contest_archives`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM "square_swaps"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM "friendships"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT "org_id" FROM "organization_members" WHERE lower\(user_id\) = lower\(\$1\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"org_id"}).AddRow(orgID))
+	// a sole admin's longest-standing member takes over before the membership goes
+	mock.ExpectExec(`UPDATE organization_members SET role = \$1 WHERE id IN \( SELECT DISTINCT ON \(om\.org_id\)`).
+		WithArgs(model.OrgRoleAdmin, orgID, "a@b.com", model.OrgRoleAdmin, "a@b.com").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM "organization_members" WHERE lower\(user_id\) = lower\(\$1\)`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM "organizations" WHERE id IN \(\$1\) AND NOT EXISTS`).WithArgs(orgID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM "contest_participants"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM "contest_bans" WHERE lower\(user_id\) = lower\(\$1\)`).WithArgs("a@b.com").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM "idempotency_keys"`).WillReturnResult(sqlmock.NewResult(0, 0))
 	mock.ExpectExec(`DELETE FROM "users"`).WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectExec(`INSERT INTO deleted_accounts`).WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
@@ -329,6 +488,8 @@ func TestUserRepository_ScrubUserData_Error(t *testing.T) {
 	repo := NewUserRepository(gdb)
 
 	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT DISTINCT "contest_id" FROM "squares"`).
+		WillReturnRows(sqlmock.NewRows([]string{"contest_id"}).AddRow(uuid.New()))
 	mock.ExpectExec(`UPDATE "squares"`).WillReturnError(errors.New("update failed"))
 	mock.ExpectRollback()
 
@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/maxmorhardt/squares-api/internal/handler"
+	"github.com/maxmorhardt/squares-api/internal/middleware"
+	"github.com/maxmorhardt/squares-api/internal/service"
+)
+
+func RegisterAnalyticsRoutes(rg *gin.RouterGroup, h handler.AnalyticsHandler, userService service.UserService) {
+	rg.GET("", middleware.AuthMiddleware(userService), h.GetContestAnalytics)
+}
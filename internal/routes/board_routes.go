@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/maxmorhardt/squares-api/internal/handler"
+	"github.com/maxmorhardt/squares-api/internal/middleware"
+	"github.com/maxmorhardt/squares-api/internal/service"
+)
+
+func RegisterBoardRoutes(rg *gin.RouterGroup, h handler.BoardHandler, userService service.UserService) {
+	rg.GET("/:id/board.png", middleware.AuthMiddleware(userService), h.GetBoardPNG)
+	rg.GET("/:id/board.svg", middleware.AuthMiddleware(userService), h.GetBoardSVG)
+	rg.GET("/:id/sheet.pdf", middleware.AuthMiddleware(userService), h.GetSheetPDF)
+}
@@ -7,17 +7,21 @@ import (
 	"github.com/maxmorhardt/squares-api/internal/service"
 )
 
-func RegisterContestRoutes(rg *gin.RouterGroup, h handler.ContestHandler, userService service.UserService) {
+func RegisterContestRoutes(rg *gin.RouterGroup, h handler.ContestHandler, userService service.UserService, idempotencyService service.IdempotencyService) {
 	rg.GET("/owner/:owner", middleware.AuthMiddleware(userService), h.GetContestsByOwner)
+	rg.GET("/:id", middleware.AuthMiddleware(userService), h.GetContest)
 
-	rg.PUT("", middleware.AuthMiddleware(userService), h.CreateContest)
-	rg.PATCH("/:id", middleware.AuthMiddleware(userService), h.UpdateContest)
-	rg.POST("/:id/start", middleware.AuthMiddleware(userService), h.StartContest)
-	rg.POST("/:id/quarter-result", middleware.AuthMiddleware(userService), h.RecordQuarterResult)
-	rg.POST("/:id/quarter-result/rollback", middleware.AuthMiddleware(userService), h.RollbackLastQuarterResult)
-	rg.DELETE("/:id", middleware.AuthMiddleware(userService), h.DeleteContest)
+	rg.PUT("", middleware.AuthMiddleware(userService), middleware.IdempotencyMiddleware(idempotencyService), h.CreateContest)
+	rg.PATCH("/:id", middleware.AuthMiddleware(userService), middleware.IfMatchMiddleware(), h.UpdateContest)
+	rg.POST("/:id/start", middleware.AuthMiddleware(userService), middleware.IfMatchMiddleware(), h.StartContest)
+	rg.POST("/:id/quarter-result", middleware.AuthMiddleware(userService), middleware.IdempotencyMiddleware(idempotencyService), middleware.IfMatchMiddleware(), h.RecordQuarterResult)
+	rg.POST("/:id/quarter-result/rollback", middleware.AuthMiddleware(userService), middleware.IfMatchMiddleware(), h.RollbackLastQuarterResult)
+	rg.DELETE("/:id", middleware.AuthMiddleware(userService), middleware.IfMatchMiddleware(), h.DeleteContest)
+	rg.POST("/:id/restore", middleware.AuthMiddleware(userService), middleware.IfMatchMiddleware(), h.RestoreContest)
 
-	rg.POST("/:id/squares/:squareId/claim", middleware.AuthMiddleware(userService), h.ClaimSquare)
-	rg.POST("/:id/squares/clear-mine", middleware.AuthMiddleware(userService), h.ClearMySquares)
-	rg.POST("/:id/squares/:squareId/clear", middleware.AuthMiddleware(userService), h.ClearSquare)
+	rg.POST("/:id/squares/:squareId/claim", middleware.AuthMiddleware(userService), middleware.IfMatchMiddleware(), h.ClaimSquare)
+	rg.POST("/:id/squares/clear-mine", middleware.AuthMiddleware(userService), middleware.IfMatchMiddleware(), h.ClearMySquares)
+	rg.POST("/:id/squares/:squareId/clear", middleware.AuthMiddleware(userService), middleware.IfMatchMiddleware(), h.ClearSquare)
+	rg.POST("/:id/squares/claim", middleware.AuthMiddleware(userService), middleware.IfMatchMiddleware(), h.ClaimSquares)
+	rg.POST("/:id/squares/:squareId/assign", middleware.AuthMiddleware(userService), middleware.IfMatchMiddleware(), h.AssignSquare)
 }
@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/maxmorhardt/squares-api/internal/handler"
+	"github.com/maxmorhardt/squares-api/internal/middleware"
+	"github.com/maxmorhardt/squares-api/internal/service"
+)
+
+func RegisterExportRoutes(rg *gin.RouterGroup, h handler.ExportHandler, userService service.UserService, idempotencyService service.IdempotencyService) {
+	rg.GET("/:id/export", middleware.AuthMiddleware(userService), h.ExportContest)
+	rg.POST("/import", middleware.AuthMiddleware(userService), middleware.IdempotencyMiddleware(idempotencyService), h.ImportContest)
+}
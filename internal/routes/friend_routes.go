@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/maxmorhardt/squares-api/internal/handler"
+	"github.com/maxmorhardt/squares-api/internal/middleware"
+	"github.com/maxmorhardt/squares-api/internal/service"
+)
+
+func RegisterFriendRoutes(rg *gin.RouterGroup, h handler.FriendHandler, userService service.UserService) {
+	auth := middleware.AuthMiddleware(userService)
+
+	rg.GET("", auth, h.GetFriends)
+	rg.GET("/requests", auth, h.GetFriendRequests)
+	rg.POST("/requests", auth, h.SendFriendRequest)
+	rg.POST("/requests/:userId/accept", auth, h.AcceptFriendRequest)
+	rg.DELETE("/:userId", auth, h.RemoveFriend)
+}
+
+func RegisterUserSearchRoute(rg *gin.RouterGroup, h handler.FriendHandler, userService service.UserService) {
+	rg.GET("", middleware.AuthMiddleware(userService), h.SearchUsers)
+}
+
+func RegisterContestFriendRoutes(rg *gin.RouterGroup, h handler.FriendHandler, userService service.UserService) {
+	rg.POST("", middleware.AuthMiddleware(userService), h.AddFriendToContest)
+}
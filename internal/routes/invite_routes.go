@@ -7,13 +7,16 @@ import (
 	"github.com/maxmorhardt/squares-api/internal/service"
 )
 
-func RegisterInviteRoutes(rg *gin.RouterGroup, h handler.InviteHandler, userService service.UserService) {
+func RegisterInviteRoutes(rg *gin.RouterGroup, h handler.InviteHandler, userService service.UserService, idempotencyService service.IdempotencyService) {
 	rg.GET("/:token", h.GetInvitePreview)
-	rg.POST("/:token/redeem", middleware.AuthMiddleware(userService), h.RedeemInvite)
+	rg.GET("/:token/open.gif", h.TrackInviteOpen)
+	rg.POST("/:token/redeem", middleware.AuthMiddleware(userService), middleware.IdempotencyMiddleware(idempotencyService), h.RedeemInvite)
 }
 
 func RegisterContestInviteRoutes(rg *gin.RouterGroup, h handler.InviteHandler, userService service.UserService) {
 	rg.POST("", middleware.AuthMiddleware(userService), h.CreateInvite)
+	rg.POST("/email", middleware.AuthMiddleware(userService), h.CreateEmailInvites)
 	rg.GET("", middleware.AuthMiddleware(userService), h.GetInvites)
+	rg.PATCH("/:inviteId", middleware.AuthMiddleware(userService), h.UpdateInvite)
 	rg.DELETE("/:inviteId", middleware.AuthMiddleware(userService), h.DeleteInvite)
 }
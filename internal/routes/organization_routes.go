@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/maxmorhardt/squares-api/internal/handler"
+	"github.com/maxmorhardt/squares-api/internal/middleware"
+	"github.com/maxmorhardt/squares-api/internal/service"
+)
+
+func RegisterOrganizationRoutes(rg *gin.RouterGroup, h handler.OrganizationHandler, userService service.UserService) {
+	rg.POST("", middleware.AuthMiddleware(userService), h.CreateOrganization)
+	rg.GET("", middleware.AuthMiddleware(userService), h.GetMyOrganizations)
+	rg.GET("/:orgId/members", middleware.AuthMiddleware(userService), h.GetOrgMembers)
+	rg.POST("/:orgId/members", middleware.AuthMiddleware(userService), h.AddOrgMember)
+	rg.PUT("/:orgId/members/:userId", middleware.AuthMiddleware(userService), h.UpdateOrgMember)
+	rg.DELETE("/:orgId/members/:userId", middleware.AuthMiddleware(userService), h.RemoveOrgMember)
+}
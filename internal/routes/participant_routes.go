@@ -9,10 +9,24 @@ import (
 
 func RegisterParticipantRoutes(rg *gin.RouterGroup, h handler.ParticipantHandler, userService service.UserService) {
 	rg.GET("", middleware.AuthMiddleware(userService), h.GetParticipants)
+	rg.GET("/payments", middleware.AuthMiddleware(userService), h.GetPaymentSummary)
 	rg.PATCH("/:userId", middleware.AuthMiddleware(userService), h.UpdateParticipant)
 	rg.DELETE("/:userId", middleware.AuthMiddleware(userService), h.RemoveParticipant)
+	rg.PUT("/:userId/payment", middleware.AuthMiddleware(userService), h.UpdatePayment)
+	rg.PUT("/me/style", middleware.AuthMiddleware(userService), h.UpdateMySquareStyle)
+}
+
+func RegisterBanRoutes(rg *gin.RouterGroup, h handler.ParticipantHandler, userService service.UserService) {
+	rg.POST("", middleware.AuthMiddleware(userService), h.BanParticipant)
+	rg.GET("", middleware.AuthMiddleware(userService), h.GetBans)
+	rg.DELETE("/:userId", middleware.AuthMiddleware(userService), h.UnbanUser)
 }
 
 func RegisterMyContestsRoute(rg *gin.RouterGroup, h handler.ParticipantHandler, userService service.UserService) {
 	rg.GET("", middleware.AuthMiddleware(userService), h.GetMyContests)
 }
+
+func RegisterParticipantImportRoutes(rg *gin.RouterGroup, h handler.ParticipantImportHandler, userService service.UserService, idempotencyService service.IdempotencyService) {
+	rg.POST("/import/preview", middleware.AuthMiddleware(userService), h.PreviewImport)
+	rg.POST("/import", middleware.AuthMiddleware(userService), middleware.IdempotencyMiddleware(idempotencyService), h.ApplyImport)
+}
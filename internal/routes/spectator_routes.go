@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/maxmorhardt/squares-api/internal/handler"
+	"github.com/maxmorhardt/squares-api/internal/middleware"
+	"github.com/maxmorhardt/squares-api/internal/service"
+)
+
+func RegisterSpectatorRoutes(rg *gin.RouterGroup, h handler.SpectatorHandler) {
+	rg.GET("/:token", h.GetSpectatorSnapshot)
+}
+
+func RegisterContestSpectatorRoutes(rg *gin.RouterGroup, h handler.SpectatorHandler, userService service.UserService) {
+	rg.POST("", middleware.AuthMiddleware(userService), h.CreateSpectatorToken)
+	rg.GET("", middleware.AuthMiddleware(userService), h.GetSpectatorTokens)
+	rg.DELETE("/:tokenId", middleware.AuthMiddleware(userService), h.RevokeSpectatorToken)
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/maxmorhardt/squares-api/internal/handler"
+	"github.com/maxmorhardt/squares-api/internal/middleware"
+	"github.com/maxmorhardt/squares-api/internal/service"
+)
+
+func RegisterSwapRoutes(rg *gin.RouterGroup, h handler.SwapHandler, userService service.UserService) {
+	rg.GET("", middleware.AuthMiddleware(userService), h.GetPendingSwaps)
+	rg.POST("", middleware.AuthMiddleware(userService), h.RequestSwap)
+	rg.POST("/:swapId/accept", middleware.AuthMiddleware(userService), h.AcceptSwap)
+	rg.POST("/:swapId/decline", middleware.AuthMiddleware(userService), h.DeclineSwap)
+}
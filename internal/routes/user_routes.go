@@ -15,4 +15,17 @@ func RegisterUserRoutes(rg *gin.RouterGroup, h handler.UserHandler, userService
 	rg.DELETE("", auth, h.DeleteMe)
 	rg.GET("/stats", auth, h.GetMyStats)
 	rg.GET("/active-contests", auth, h.GetMyActiveContests)
+	rg.PUT("/avatar", auth, h.PutMyAvatar)
+	rg.DELETE("/avatar", auth, h.DeleteMyAvatar)
+}
+
+func RegisterPublicUserRoutes(rg *gin.RouterGroup, h handler.UserHandler, userService service.UserService) {
+	auth := middleware.AuthMiddleware(userService)
+
+	rg.GET("/:id", auth, h.GetUser)
+}
+
+// avatar keys are unguessable, so image tags can load them without a bearer token
+func RegisterAvatarRoutes(rg *gin.RouterGroup, h handler.UserHandler) {
+	rg.GET("/:key", h.GetAvatar)
 }
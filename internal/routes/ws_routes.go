@@ -10,4 +10,5 @@ import (
 
 func RegisterWebSocketRoutes(rg *gin.RouterGroup, h handler.WebSocketHandler, userService service.UserService) {
 	rg.GET("/contests/:id", middleware.AuthMiddlewareWS(userService), h.ContestWSConnection)
+	rg.GET("/spectate/:token", h.SpectatorWSConnection)
 }
@@ -0,0 +1,8 @@
+package seed
+
+import _ "embed"
+
+// approximate historical last-digit pair counts used to smooth our own game history
+//
+//go:embed nfl_digit_frequencies.json
+var NFLDigitFrequenciesJSON []byte
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/repository"
+	"github.com/maxmorhardt/squares-api/internal/seed"
+	"github.com/maxmorhardt/squares-api/internal/util"
+	"gorm.io/gorm"
+)
+
+// game history only changes as quarters complete, so the table can be reused for a while
+const digitProbabilityCacheTTL = time.Hour
+
+type AnalyticsService interface {
+	GetContestAnalytics(ctx context.Context, contestID uuid.UUID, user string) (*model.ContestAnalyticsResponse, error)
+	AnnotateSquares(ctx context.Context, contest *model.Contest) error
+}
+
+type analyticsService struct {
+	contestRepo        repository.ContestRepository
+	gameRepo           repository.GameRepository
+	participantService ParticipantService
+	cache              *util.TTLCache[struct{}, *model.DigitProbabilityTable]
+}
+
+func NewAnalyticsService(
+	contestRepo repository.ContestRepository,
+	gameRepo repository.GameRepository,
+	participantService ParticipantService,
+) AnalyticsService {
+	return &analyticsService{
+		contestRepo:        contestRepo,
+		gameRepo:           gameRepo,
+		participantService: participantService,
+		cache:              util.NewTTLCache[struct{}, *model.DigitProbabilityTable](1, digitProbabilityCacheTTL),
+	}
+}
+
+func (s *analyticsService) GetContestAnalytics(ctx context.Context, contestID uuid.UUID, user string) (*model.ContestAnalyticsResponse, error) {
+	log := util.LoggerFromContext(ctx)
+
+	// a missing contest is a 404 before it is anyone's permission problem
+	contest, err := s.contestRepo.GetByID(ctx, contestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		log.Error("failed to get contest for analytics", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	// anyone who can view the contest can view its odds
+	if err := s.participantService.Authorize(ctx, contestID, user, ActionView); err != nil {
+		log.Warn("user not authorized to view contest analytics", "contest_id", contestID, "user", user)
+		return nil, err
+	}
+
+	table, err := s.cache.GetOrLoad(ctx, struct{}{}, s.loadProbabilities)
+	if err != nil {
+		log.Error("failed to load digit probabilities", "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	resp, err := util.ContestAnalytics(contest, table)
+	if err != nil {
+		log.Error("failed to compute contest analytics", "contest_id", contestID, "error", err)
+		return nil, err
+	}
+
+	log.Info("computed contest analytics", "contest_id", contestID, "labels_assigned", resp.LabelsAssigned)
+	return resp, nil
+}
+
+func (s *analyticsService) AnnotateSquares(ctx context.Context, contest *model.Contest) error {
+	table, err := s.cache.GetOrLoad(ctx, struct{}{}, s.loadProbabilities)
+	if err != nil {
+		return err
+	}
+
+	_, err = util.AnnotateSquareAnalytics(contest, table)
+	return err
+}
+
+func (s *analyticsService) loadProbabilities(ctx context.Context) (*model.DigitProbabilityTable, error) {
+	seedFreqs, seedGames, err := util.ParseDigitFrequencies(seed.NFLDigitFrequenciesJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	observed, err := s.gameRepo.GetDigitFrequencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// every finished game records exactly one final-quarter score
+	observedGames := int64(0)
+	for _, f := range observed {
+		if f.Quarter == 4 {
+			observedGames += f.Count
+		}
+	}
+
+	return util.DigitProbabilities(append(seedFreqs, observed...), seedGames+observedGames), nil
+}
@@ -0,0 +1,136 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/mocks"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/service"
+	"github.com/maxmorhardt/squares-api/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// analytics service that leaves squares untouched
+func anyAnalytics() *mocks.AnalyticsService {
+	m := &mocks.AnalyticsService{}
+	m.On("AnnotateSquares", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return m
+}
+
+func analyticsContest(t *testing.T, drawn bool) *model.Contest {
+	xLabels, yLabels := util.InitialLabels()
+	if drawn {
+		var err error
+		xLabels, yLabels, err = util.RandomizedLabels()
+		require.NoError(t, err)
+	}
+
+	c := &model.Contest{ID: uuid.New(), XLabels: xLabels, YLabels: yLabels}
+	for row := range 10 {
+		for col := range 10 {
+			c.Squares = append(c.Squares, model.Square{ID: uuid.New(), Row: row, Col: col})
+		}
+	}
+	return c
+}
+
+func TestAnalyticsService_GetContestAnalytics_Success(t *testing.T) {
+	contest := analyticsContest(t, true)
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, contest.ID).Return(contest, nil)
+	gameRepo := mocks.NewGameRepository(t)
+	gameRepo.EXPECT().GetDigitFrequencies(mock.Anything).Return([]model.DigitFrequency{{Quarter: 4, HomeDigit: 7, AwayDigit: 0, Count: 5}}, nil)
+
+	got, err := service.NewAnalyticsService(repo, gameRepo, okAuth(t)).GetContestAnalytics(context.Background(), contest.ID, "u")
+	require.NoError(t, err)
+	assert.True(t, got.LabelsAssigned)
+	assert.Len(t, got.Squares, 100)
+	assert.Greater(t, got.SampleGames, int64(5))
+
+	// each quarter's probabilities across the grid form a full distribution
+	var q1 float64
+	for _, sq := range got.Squares {
+		require.NotNil(t, sq.HomeDigit)
+		q1 += sq.Analytics.WinProbabilities[0]
+	}
+	assert.InDelta(t, 1.0, q1, 1e-9)
+}
+
+func TestAnalyticsService_GetContestAnalytics_CachesTable(t *testing.T) {
+	contest := analyticsContest(t, false)
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, contest.ID).Return(contest, nil)
+	gameRepo := mocks.NewGameRepository(t)
+	// mockery fails on cleanup if the repo is hit more than once
+	gameRepo.EXPECT().GetDigitFrequencies(mock.Anything).Return(nil, nil).Once()
+
+	svc := service.NewAnalyticsService(repo, gameRepo, okAuth(t))
+	_, err := svc.GetContestAnalytics(context.Background(), contest.ID, "u")
+	require.NoError(t, err)
+	got, err := svc.GetContestAnalytics(context.Background(), contest.ID, "u")
+	require.NoError(t, err)
+	assert.False(t, got.LabelsAssigned)
+}
+
+func TestAnalyticsService_GetContestAnalytics_Unauthorized(t *testing.T) {
+	contest := analyticsContest(t, true)
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, contest.ID).Return(contest, nil)
+	pSvc := mocks.NewParticipantService(t)
+	pSvc.EXPECT().Authorize(mock.Anything, contest.ID, "u", service.ActionView).Return(errs.ErrNotParticipant)
+
+	_, err := service.NewAnalyticsService(repo, mocks.NewGameRepository(t), pSvc).
+		GetContestAnalytics(context.Background(), contest.ID, "u")
+	assert.ErrorIs(t, err, errs.ErrNotParticipant)
+}
+
+func TestAnalyticsService_GetContestAnalytics_NotFound(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+	// no Authorize expectation: a missing contest is reported before any permission check
+	_, err := service.NewAnalyticsService(repo, mocks.NewGameRepository(t), mocks.NewParticipantService(t)).
+		GetContestAnalytics(context.Background(), uuid.New(), "u")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestAnalyticsService_GetContestAnalytics_ContestLoadError(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+
+	_, err := service.NewAnalyticsService(repo, mocks.NewGameRepository(t), mocks.NewParticipantService(t)).
+		GetContestAnalytics(context.Background(), uuid.New(), "u")
+	assert.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
+}
+
+func TestAnalyticsService_GetContestAnalytics_FrequencyError(t *testing.T) {
+	contest := analyticsContest(t, true)
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, contest.ID).Return(contest, nil)
+	gameRepo := mocks.NewGameRepository(t)
+	gameRepo.EXPECT().GetDigitFrequencies(mock.Anything).Return(nil, errors.New("db down"))
+
+	_, err := service.NewAnalyticsService(repo, gameRepo, okAuth(t)).GetContestAnalytics(context.Background(), contest.ID, "u")
+	assert.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
+}
+
+func TestAnalyticsService_AnnotateSquares(t *testing.T) {
+	contest := analyticsContest(t, true)
+	gameRepo := mocks.NewGameRepository(t)
+	gameRepo.EXPECT().GetDigitFrequencies(mock.Anything).Return(nil, nil)
+
+	err := service.NewAnalyticsService(mocks.NewContestRepository(t), gameRepo, mocks.NewParticipantService(t)).
+		AnnotateSquares(context.Background(), contest)
+	require.NoError(t, err)
+	for _, sq := range contest.Squares {
+		require.NotNil(t, sq.Analytics)
+		assert.Len(t, sq.Analytics.WinProbabilities, 4)
+	}
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/render"
+	"github.com/maxmorhardt/squares-api/internal/repository"
+	"github.com/maxmorhardt/squares-api/internal/util"
+	"gorm.io/gorm"
+)
+
+const (
+	// every contest and square write bumps the contest version, so a key names exactly one board; the ttl only bounds memory
+	boardCacheTTL  = time.Hour
+	boardCacheSize = 256
+)
+
+type BoardService interface {
+	RenderBoard(ctx context.Context, contestID uuid.UUID, user string, format model.BoardFormat) (*model.BoardImage, error)
+}
+
+// a game-linked contest's winners move with the game record, not the contest version
+type boardKey struct {
+	contestID   uuid.UUID
+	version     int
+	gameUpdated int64
+	format      model.BoardFormat
+}
+
+type boardService struct {
+	contestRepo        repository.ContestRepository
+	participantService ParticipantService
+	cache              *util.TTLCache[boardKey, []byte]
+}
+
+func NewBoardService(contestRepo repository.ContestRepository, participantService ParticipantService) BoardService {
+	return &boardService{
+		contestRepo:        contestRepo,
+		participantService: participantService,
+		cache:              util.NewTTLCache[boardKey, []byte](boardCacheSize, boardCacheTTL),
+	}
+}
+
+func (s *boardService) RenderBoard(ctx context.Context, contestID uuid.UUID, user string, format model.BoardFormat) (*model.BoardImage, error) {
+	log := util.LoggerFromContext(ctx)
+
+	contest, err := s.contestRepo.GetByID(ctx, contestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		log.Error("failed to get contest for board", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	if err := s.participantService.Authorize(ctx, contestID, user, ActionView); err != nil {
+		log.Warn("user not authorized to view board", "contest_id", contestID, "user", user)
+		return nil, err
+	}
+
+	key := boardKey{contestID: contestID, version: contest.Version, format: format}
+	if contest.Game != nil {
+		key.gameUpdated = contest.Game.UpdatedAt.UnixNano()
+	}
+
+	data, err := s.cache.GetOrLoad(ctx, key, func(context.Context) ([]byte, error) {
+		util.SynthesizeFromGame(contest)
+		switch format {
+		case model.BoardFormatSVG:
+			return render.SVG(render.NewBoard(contest)), nil
+		case model.BoardFormatPDF:
+			return render.PDF(render.NewSheet(contest))
+		default:
+			return render.PNG(render.NewBoard(contest))
+		}
+	})
+	if err != nil {
+		log.Error("failed to render board", "contest_id", contestID, "format", format, "error", err)
+		return nil, err
+	}
+
+	log.Info("rendered board", "contest_id", contestID, "version", contest.Version, "format", format)
+	return &model.BoardImage{
+		Data:        data,
+		ContentType: format.ContentType(),
+		ETag:        fmt.Sprintf(`"%d-%d-%s"`, key.version, key.gameUpdated, format),
+	}, nil
+}
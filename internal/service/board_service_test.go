@@ -0,0 +1,125 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/mocks"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func boardContest(version int) *model.Contest {
+	return &model.Contest{
+		ID: uuid.New(), Name: "c", Version: version,
+		XLabels: []byte(`[0,1,2,3,4,5,6,7,8,9]`), YLabels: []byte(`[0,1,2,3,4,5,6,7,8,9]`),
+		Squares: []model.Square{{Row: 0, Col: 0, Value: "AB"}},
+	}
+}
+
+func TestRenderBoard_PNG(t *testing.T) {
+	contest := boardContest(3)
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, contest.ID).Return(contest, nil)
+
+	board, err := service.NewBoardService(repo, okAuth(t)).RenderBoard(context.Background(), contest.ID, "u", model.BoardFormatPNG)
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", board.ContentType)
+	assert.Equal(t, `"3-0-png"`, board.ETag)
+	_, err = png.Decode(bytes.NewReader(board.Data))
+	assert.NoError(t, err)
+}
+
+func TestRenderBoard_PDF(t *testing.T) {
+	contest := boardContest(2)
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, contest.ID).Return(contest, nil)
+
+	sheet, err := service.NewBoardService(repo, okAuth(t)).RenderBoard(context.Background(), contest.ID, "u", model.BoardFormatPDF)
+	require.NoError(t, err)
+	assert.Equal(t, "application/pdf", sheet.ContentType)
+	assert.Equal(t, `"2-0-pdf"`, sheet.ETag)
+	assert.True(t, bytes.HasPrefix(sheet.Data, []byte("%PDF-")))
+}
+
+func TestRenderBoard_CachedByVersion(t *testing.T) {
+	contest := boardContest(1)
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, contest.ID).Return(contest, nil)
+	svc := service.NewBoardService(repo, okAuth(t))
+
+	first, err := svc.RenderBoard(context.Background(), contest.ID, "u", model.BoardFormatSVG)
+	require.NoError(t, err)
+
+	// a change that didn't bump the version isn't drawn: the cached board is served
+	contest.Squares[0].Value = "ZZ"
+	second, err := svc.RenderBoard(context.Background(), contest.ID, "u", model.BoardFormatSVG)
+	require.NoError(t, err)
+	assert.Equal(t, first.Data, second.Data)
+
+	contest.Version = 2
+	third, err := svc.RenderBoard(context.Background(), contest.ID, "u", model.BoardFormatSVG)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.Data, third.Data)
+	assert.Contains(t, string(third.Data), ">ZZ</text>")
+	assert.NotEqual(t, first.ETag, third.ETag)
+}
+
+func TestRenderBoard_ETagPerFormat(t *testing.T) {
+	contest := boardContest(4)
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, contest.ID).Return(contest, nil)
+	svc := service.NewBoardService(repo, okAuth(t))
+
+	// a png etag must never revalidate a cached svg of the same version
+	board, err := svc.RenderBoard(context.Background(), contest.ID, "u", model.BoardFormatPNG)
+	require.NoError(t, err)
+	svg, err := svc.RenderBoard(context.Background(), contest.ID, "u", model.BoardFormatSVG)
+	require.NoError(t, err)
+	assert.NotEqual(t, board.ETag, svg.ETag)
+}
+
+func TestRenderBoard_GameUpdateInvalidates(t *testing.T) {
+	contest := boardContest(1)
+	contest.Game = &model.Game{UpdatedAt: time.Unix(100, 0)}
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, contest.ID).Return(contest, nil)
+	svc := service.NewBoardService(repo, okAuth(t))
+
+	first, err := svc.RenderBoard(context.Background(), contest.ID, "u", model.BoardFormatSVG)
+	require.NoError(t, err)
+
+	contest.Game = &model.Game{UpdatedAt: time.Unix(200, 0), Scores: []model.GameScore{{Quarter: 1, HomeScore: 3, AwayScore: 0}}}
+	second, err := svc.RenderBoard(context.Background(), contest.ID, "u", model.BoardFormatSVG)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ETag, second.ETag)
+	assert.Contains(t, string(second.Data), ">Q1</text>")
+}
+
+func TestRenderBoard_NotFound(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := service.NewBoardService(repo, mocks.NewParticipantService(t)).RenderBoard(context.Background(), uuid.New(), "u", model.BoardFormatPNG)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestRenderBoard_NotAuthorized(t *testing.T) {
+	contest := boardContest(1)
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, contest.ID).Return(contest, nil)
+	pSvc := mocks.NewParticipantService(t)
+	pSvc.EXPECT().Authorize(mock.Anything, contest.ID, "u", service.ActionView).Return(errs.ErrNotParticipant)
+
+	_, err := service.NewBoardService(repo, pSvc).RenderBoard(context.Background(), contest.ID, "u", model.BoardFormatPNG)
+	assert.ErrorIs(t, err, errs.ErrNotParticipant)
+}
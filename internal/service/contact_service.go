@@ -7,7 +7,6 @@ import (
 	"html/template"
 	"mime/multipart"
 	"net/http"
-	"net/smtp"
 	"net/textproto"
 	"strings"
 	"time"
@@ -89,11 +88,8 @@ func (s *contactService) sendEmailNotification(req *model.ContactRequest) error
 		return fmt.Errorf("%w: %w", errs.ErrEmailNotification, err)
 	}
 
-	auth := smtp.PlainAuth("", s.cfg.SMTP.User, s.cfg.SMTP.Password, s.cfg.SMTP.Host)
-	addr := fmt.Sprintf("%s:%d", s.cfg.SMTP.Host, s.cfg.SMTP.Port)
 	to := []string{s.cfg.SMTP.SupportEmail}
-
-	if err := smtp.SendMail(addr, auth, s.cfg.SMTP.User, to, msg); err != nil {
+	if err := NewSMTPMailer(s.cfg.SMTP).Send(to, msg); err != nil {
 		return fmt.Errorf("%w: %w", errs.ErrEmailNotification, err)
 	}
 
@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/maxmorhardt/squares-api/internal/errs"
@@ -13,8 +15,12 @@ import (
 	"gorm.io/gorm"
 )
 
+// keeps a single lifecycle pass short even after a long outage
+const archiveBatchSize = 50
+
 type ContestService interface {
 	GetContestsByOwnerPaginated(ctx context.Context, owner string, page, limit int, search string) ([]model.Contest, int64, error)
+	GetContest(ctx context.Context, contestID uuid.UUID, user string) (*model.Contest, error)
 
 	CreateContest(ctx context.Context, req *model.CreateContestRequest, user string) (*model.Contest, error)
 	UpdateContest(ctx context.Context, contestID uuid.UUID, req *model.UpdateContestRequest, user string) (*model.Contest, error)
@@ -22,10 +28,18 @@ type ContestService interface {
 	RecordQuarterResult(ctx context.Context, contestID uuid.UUID, homeScore, awayScore int, user string) (*model.QuarterResult, error)
 	RollbackLastQuarterResult(ctx context.Context, contestID uuid.UUID, user string) (*model.QuarterResult, error)
 	DeleteContest(ctx context.Context, contestID uuid.UUID, user string) error
+	RestoreContest(ctx context.Context, contestID uuid.UUID, user string) (*model.Contest, error)
+	LockDueContests(ctx context.Context) (int, error)
+	PurgeDeletedContests(ctx context.Context) (int64, error)
+	ArchiveFinishedContests(ctx context.Context) (int, error)
+	ReleaseExpiredReservations(ctx context.Context) (int, error)
+	PurgeInviteEvents(ctx context.Context) (int64, error)
 
 	ClaimSquare(ctx context.Context, contestID, squareID uuid.UUID, user string) (*model.Square, error)
 	ClearSquare(ctx context.Context, contestID, squareID uuid.UUID, user string) (*model.Square, error)
 	ClearUserSquares(ctx context.Context, contestID uuid.UUID, user string) ([]model.Square, error)
+	ClaimSquares(ctx context.Context, contestID uuid.UUID, squareIDs []uuid.UUID, user string) ([]model.Square, error)
+	AssignSquare(ctx context.Context, contestID, squareID uuid.UUID, req *model.AssignSquareRequest, user string) (*model.Square, error)
 }
 
 type contestService struct {
@@ -33,8 +47,13 @@ type contestService struct {
 	participantRepo    repository.ParticipantRepository
 	gameRepo           repository.GameRepository
 	userRepo           repository.UserRepository
+	orgRepo            repository.OrganizationRepository
 	natsService        NatsService
 	participantService ParticipantService
+	analyticsService   AnalyticsService
+	restoreWindow      time.Duration
+	archiveAfter       time.Duration
+	eventRetention     time.Duration
 }
 
 func NewContestService(
@@ -42,16 +61,24 @@ func NewContestService(
 	participantRepo repository.ParticipantRepository,
 	gameRepo repository.GameRepository,
 	userRepo repository.UserRepository,
+	orgRepo repository.OrganizationRepository,
 	natsService NatsService,
 	participantService ParticipantService,
+	analyticsService AnalyticsService,
+	lifecycleCfg model.LifecycleConfig,
 ) ContestService {
 	return &contestService{
 		repo:               repo,
 		participantRepo:    participantRepo,
 		gameRepo:           gameRepo,
 		userRepo:           userRepo,
+		orgRepo:            orgRepo,
 		natsService:        natsService,
 		participantService: participantService,
+		analyticsService:   analyticsService,
+		restoreWindow:      lifecycleCfg.RestoreWindow,
+		archiveAfter:       lifecycleCfg.ArchiveAfter,
+		eventRetention:     lifecycleCfg.EventRetention,
 	}
 }
 
@@ -72,6 +99,35 @@ func (s *contestService) GetContestsByOwnerPaginated(ctx context.Context, owner
 	return contests, total, nil
 }
 
+func (s *contestService) GetContest(ctx context.Context, contestID uuid.UUID, user string) (*model.Contest, error) {
+	log := util.LoggerFromContext(ctx)
+
+	contest, err := s.repo.GetByID(ctx, contestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		log.Error("failed to get contest", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	if err := s.participantService.Authorize(ctx, contestID, user, ActionView); err != nil {
+		log.Warn("user not authorized to view contest", "contest_id", contestID, "user", user)
+		return nil, err
+	}
+
+	// game-linked contests read their quarter results from the shared game record
+	util.SynthesizeFromGame(contest)
+
+	// odds aren't stored, so every read recomputes them; the board still loads without them
+	if err := s.analyticsService.AnnotateSquares(ctx, contest); err != nil {
+		log.Warn("failed to annotate squares with analytics", "contest_id", contestID, "error", err)
+	}
+
+	log.Info("retrieved contest", "contest_id", contestID, "version", contest.Version)
+	return contest, nil
+}
+
 // ====================
 // Contest Lifecycle Actions
 // ====================
@@ -107,6 +163,26 @@ func (s *contestService) CreateContest(ctx context.Context, req *model.CreateCon
 		Owner:      req.Owner,
 		Visibility: visibility,
 		Status:     model.ContestStatusActive,
+		FillPolicy: model.FillPolicyNone,
+		Rollover:   req.Rollover,
+	}
+
+	if req.FillPolicy != "" {
+		contest.FillPolicy = model.FillPolicy(req.FillPolicy)
+	}
+
+	contest.PaymentPolicy = model.PaymentPolicyNone
+	if req.PaymentPolicy != "" {
+		contest.PaymentPolicy = model.PaymentPolicy(req.PaymentPolicy)
+	}
+
+	// an optional scheduled lock starts the contest automatically
+	if req.LockAt != nil {
+		if !req.LockAt.After(time.Now()) {
+			log.Warn("lock time is not in the future", "lock_at", req.LockAt)
+			return nil, errs.ErrInvalidLockTime
+		}
+		contest.LockAt = req.LockAt
 	}
 
 	// game-linked contest scores automatically and takes its teams from the game
@@ -131,23 +207,105 @@ func (s *contestService) CreateContest(ctx context.Context, req *model.CreateCon
 		contest.AwayTeam = game.AwayTeam
 	}
 
+	// an organization-owned contest can be managed by any of the organization's admins
+	var members []model.ContestParticipant
+	if req.OrgID != "" {
+		orgID, seeded, orgErr := s.orgParticipants(ctx, req, user)
+		if orgErr != nil {
+			return nil, orgErr
+		}
+		contest.OrgID = &orgID
+		members = seeded
+	}
+
 	// atomically create contest, squares, and owner participant
 	ownerParticipant := &model.ContestParticipant{
 		UserID:     user,
 		Role:       model.ParticipantRoleOwner,
 		MaxSquares: req.MaxSquares,
 	}
-	if err := s.repo.Create(ctx, &contest, ownerParticipant); err != nil {
+	if len(members) > 0 {
+		// seeded members are written in the same transaction as the contest
+		if err := s.repo.Import(ctx, &contest, ownerParticipant, nil, nil, members); err != nil {
+			log.Error("failed to create contest with organization members", "org_id", contest.OrgID, "error", err)
+			return nil, err
+		}
+	} else if err := s.repo.Create(ctx, &contest, ownerParticipant); err != nil {
 		log.Error("failed to create contest with owner participant", "error", err)
 		return nil, err
 	}
 
 	metrics.IncContestCreated()
 	metrics.IncParticipantJoined(string(model.ParticipantRoleOwner))
+	for _, m := range members {
+		metrics.IncParticipantJoined(string(m.Role))
+	}
 	log.Info("created contest", "name", req.Name, "contest_id", contest.ID, "owner", req.Owner)
 	return &contest, nil
 }
 
+// checks the creator belongs to the organization and, when asked, builds a participant row for every
+// other member so the whole group starts in the pool
+func (s *contestService) orgParticipants(ctx context.Context, req *model.CreateContestRequest, user string) (uuid.UUID, []model.ContestParticipant, error) {
+	log := util.LoggerFromContext(ctx)
+
+	orgID, err := uuid.Parse(req.OrgID)
+	if err != nil {
+		return uuid.Nil, nil, errs.ErrOrganizationNotFound
+	}
+
+	if _, err := s.orgRepo.GetByID(ctx, orgID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return uuid.Nil, nil, errs.ErrOrganizationNotFound
+		}
+		log.Error("failed to get organization for contest", "org_id", orgID, "error", err)
+		return uuid.Nil, nil, errs.ErrDatabaseUnavailable
+	}
+
+	if _, err := s.orgRepo.GetMember(ctx, orgID, user); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return uuid.Nil, nil, errs.ErrNotOrgMember
+		}
+		log.Error("failed to get organization membership for contest", "org_id", orgID, "user_id", user, "error", err)
+		return uuid.Nil, nil, errs.ErrDatabaseUnavailable
+	}
+
+	if !req.AddOrgMembers {
+		return orgID, nil, nil
+	}
+
+	orgMembers, err := s.orgRepo.GetMembers(ctx, orgID)
+	if err != nil {
+		log.Error("failed to get organization members for contest", "org_id", orgID, "error", err)
+		return uuid.Nil, nil, errs.ErrDatabaseUnavailable
+	}
+
+	// members without a square allotment join as viewers and can be promoted later
+	role := model.ParticipantRoleViewer
+	if req.MemberSquares > 0 {
+		role = model.ParticipantRoleParticipant
+	}
+
+	participants := make([]model.ContestParticipant, 0, len(orgMembers))
+	for _, m := range orgMembers {
+		if strings.EqualFold(m.UserID, user) {
+			continue
+		}
+		participants = append(participants, model.ContestParticipant{
+			UserID:     m.UserID,
+			Role:       role,
+			MaxSquares: req.MemberSquares,
+		})
+	}
+
+	if req.MaxSquares+len(participants)*req.MemberSquares > 100 {
+		log.Warn("organization members exceed square pool", "org_id", orgID, "members", len(participants), "member_squares", req.MemberSquares)
+		return uuid.Nil, nil, errs.ErrNotEnoughSquares
+	}
+
+	return orgID, participants, nil
+}
+
 func (s *contestService) UpdateContest(ctx context.Context, contestID uuid.UUID, req *model.UpdateContestRequest, user string) (*model.Contest, error) {
 	log := util.LoggerFromContext(ctx)
 
@@ -176,6 +334,10 @@ func (s *contestService) UpdateContest(ctx context.Context, contestID uuid.UUID,
 		return nil, errs.ErrUnauthorizedContestEdit
 	}
 
+	if err := checkIfMatch(ctx, contest); err != nil {
+		return nil, err
+	}
+
 	// game-linked contests take their team names from the game record
 	canEditTeams := contest.GameID == nil
 
@@ -197,6 +359,44 @@ func (s *contestService) UpdateContest(ctx context.Context, contestID uuid.UUID,
 		needsUpdate = true
 	}
 
+	// the lock schedule, fill and payment policies, and rollover rule only change while squares are still being claimed
+	if req.LockAt != nil || req.ClearLockAt || req.FillPolicy != nil || req.PaymentPolicy != nil || req.Rollover != nil {
+		if contest.Status != model.ContestStatusActive {
+			log.Warn("cannot change lock schedule once contest has started", "contest_id", contestID, "status", contest.Status)
+			return nil, errs.ErrContestNotEditable
+		}
+
+		switch {
+		case req.ClearLockAt:
+			if contest.LockAt != nil {
+				contest.LockAt = nil
+				needsUpdate = true
+			}
+		case req.LockAt != nil:
+			if !req.LockAt.After(time.Now()) {
+				log.Warn("lock time is not in the future", "contest_id", contestID, "lock_at", req.LockAt)
+				return nil, errs.ErrInvalidLockTime
+			}
+			contest.LockAt = req.LockAt
+			needsUpdate = true
+		}
+
+		if req.FillPolicy != nil && model.FillPolicy(*req.FillPolicy) != contest.FillPolicy {
+			contest.FillPolicy = model.FillPolicy(*req.FillPolicy)
+			needsUpdate = true
+		}
+
+		if req.PaymentPolicy != nil && model.PaymentPolicy(*req.PaymentPolicy) != contest.PaymentPolicy {
+			contest.PaymentPolicy = model.PaymentPolicy(*req.PaymentPolicy)
+			needsUpdate = true
+		}
+
+		if req.Rollover != nil && *req.Rollover != contest.Rollover {
+			contest.Rollover = *req.Rollover
+			needsUpdate = true
+		}
+	}
+
 	if !needsUpdate {
 		log.Info("no changes detected for contest update", "contest_id", contest.ID)
 		return contest, nil
@@ -246,23 +446,48 @@ func (s *contestService) StartContest(ctx context.Context, contestID uuid.UUID,
 		return nil, errors.New("contest must be in ACTIVE status to start")
 	}
 
-	if !util.AllSquaresClaimed(contest) {
-		log.Warn("cannot start contest - unclaimed squares remain", "contest_id", contestID)
+	if err := checkIfMatch(ctx, contest); err != nil {
+		return nil, err
+	}
+
+	// unpaid squares are settled first so anything cleared is open to the fill policy
+	unpaid, cleared, paid, err := settlePayments(ctx, contest, s.participantRepo)
+	if err != nil {
+		log.Error("failed to apply payment policy", "contest_id", contestID, "payment_policy", contest.PaymentPolicy, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	if !paid {
+		log.Warn("cannot start contest - square owners unpaid", "contest_id", contestID)
+		return nil, errs.ErrUnpaidSquareOwners
+	}
+
+	// the fill policy decides what happens to squares nobody claimed
+	assigned, ready, err := planFill(ctx, contest, s.participantRepo, s.userRepo)
+	if err != nil {
+		log.Error("failed to fill unclaimed squares", "contest_id", contestID, "fill_policy", contest.FillPolicy, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	if !ready {
+		log.Warn("cannot start contest - unclaimed squares remain", "contest_id", contestID, "fill_policy", contest.FillPolicy)
 		return nil, errs.ErrContestNotReady
 	}
+	mergeAssignedSquares(contest, assigned)
 
-	// transition to q1 and randomize labels
-	if err := s.transitionToQ1(ctx, contest, user); err != nil {
+	// transition to q1 and randomize labels; unpaid squares are only cleared if the start goes through
+	if err := s.transitionToQ1(ctx, contest, unpaid, assigned, user); err != nil {
 		log.Error("failed to transition to Q1", "contest_id", contestID, "error", err)
 		return nil, err
 	}
+	s.publishClearedSquares(ctx, contest.ID, user, settledSquares(contest, cleared))
 
 	metrics.IncContestStarted()
 	log.Info("contest started successfully", "contest_id", contestID)
 	return contest, nil
 }
 
-func (s *contestService) transitionToQ1(ctx context.Context, contest *model.Contest, user string) error {
+func (s *contestService) transitionToQ1(ctx context.Context, contest *model.Contest, unpaid []string, assigned []model.Square, user string) error {
 	log := util.LoggerFromContext(ctx)
 
 	// randomize the x and y labels
@@ -275,14 +500,25 @@ func (s *contestService) transitionToQ1(ctx context.Context, contest *model.Cont
 	contest.XLabels = xLabels
 	contest.YLabels = yLabels
 	contest.Status = model.ContestStatusQ1
+	contest.LockAt = nil
 	contest.UpdatedBy = user
 
-	// save contest with randomized labels and updated status
-	if err := s.repo.Update(ctx, contest); err != nil {
+	// save contest with randomized labels and updated status, clearing unpaid owners and filling any assigned squares in the same transaction
+	if len(unpaid) > 0 || len(assigned) > 0 {
+		err = s.repo.StartWithSquares(ctx, contest, unpaid, assigned)
+	} else {
+		err = s.repo.Update(ctx, contest)
+	}
+	if err != nil {
 		log.Error("failed to save contest with randomized labels", "contest_id", contest.ID, "error", err)
 		return err
 	}
 
+	// attach win odds now that each square maps to a digit pair; the start still succeeds without them
+	if err := s.analyticsService.AnnotateSquares(ctx, contest); err != nil {
+		log.Warn("failed to annotate squares with analytics", "contest_id", contest.ID, "error", err)
+	}
+
 	// publish status change to websocket clients
 	go func() {
 		if err := s.natsService.PublishContestUpdate(contest.ID, user, contest); err != nil {
@@ -316,6 +552,10 @@ func (s *contestService) RecordQuarterResult(ctx context.Context, contestID uuid
 		return nil, errs.ErrUnauthorizedContestEdit
 	}
 
+	if err := checkIfMatch(ctx, contest); err != nil {
+		return nil, err
+	}
+
 	// determine quarter and next status from current contest status
 	quarter, ok := contest.Status.Quarter()
 	if !ok {
@@ -338,32 +578,15 @@ func (s *contestService) RecordQuarterResult(ctx context.Context, contestID uuid
 		log.Error("failed to compute quarter result", "contest_id", contestID, "error", err)
 		return nil, err
 	}
+	util.ApplyRollover(contest, result, contest.QuarterResults)
 
-	if err := s.repo.CreateQuarterResult(ctx, result); err != nil {
-		log.Error("failed to create quarter result", "contest_id", contestID, "quarter", quarter, "error", err)
+	// the result and the status change land together, and only if nobody else moved the contest on first
+	contest.Status = nextStatus
+	if err := s.repo.RecordQuarterResult(ctx, result, contest); err != nil {
+		log.Error("failed to record quarter result", "contest_id", contestID, "quarter", quarter, "new_status", nextStatus, "error", err)
 		return nil, err
 	}
 
-	// transition contest status and publish update
-	if err := s.transitionContestAfterQuarter(ctx, contest, nextStatus, result, user); err != nil {
-		log.Error("failed to transition contest after quarter", "contest_id", contestID, "quarter", quarter, "error", err)
-		return nil, err
-	}
-
-	metrics.IncQuarterResult(quarter)
-	log.Info("quarter result recorded and status transitioned", "contest_id", contestID, "quarter", quarter, "winner", result.Winner, "new_status", nextStatus)
-	return result, nil
-}
-
-func (s *contestService) transitionContestAfterQuarter(ctx context.Context, contest *model.Contest, newStatus model.ContestStatus, result *model.QuarterResult, user string) error {
-	log := util.LoggerFromContext(ctx)
-
-	contest.Status = newStatus
-	if err := s.repo.Update(ctx, contest); err != nil {
-		log.Error("failed to update contest status", "contest_id", contest.ID, "new_status", newStatus, "error", err)
-		return err
-	}
-
 	// publish quarter result to websocket clients
 	go func() {
 		if err := s.natsService.PublishQuarterResult(contest.ID, user, result); err != nil {
@@ -371,8 +594,9 @@ func (s *contestService) transitionContestAfterQuarter(ctx context.Context, cont
 		}
 	}()
 
-	log.Info("contest transitioned after quarter", "contest_id", contest.ID, "quarter", result.Quarter, "new_status", newStatus)
-	return nil
+	metrics.IncQuarterResult(quarter)
+	log.Info("quarter result recorded and status transitioned", "contest_id", contestID, "quarter", quarter, "winner", result.Winner, "rolled_over", result.RolledOver, "new_status", nextStatus)
+	return result, nil
 }
 
 func (s *contestService) RollbackLastQuarterResult(ctx context.Context, contestID uuid.UUID, user string) (*model.QuarterResult, error) {
@@ -397,6 +621,10 @@ func (s *contestService) RollbackLastQuarterResult(ctx context.Context, contestI
 		return nil, errs.ErrUnauthorizedContestEdit
 	}
 
+	if err := checkIfMatch(ctx, contest); err != nil {
+		return nil, err
+	}
+
 	// determine the most recently recorded quarter and the status to revert to
 	revertStatus, quarter, ok := model.PreviousQuarterStatus(contest.Status)
 	if !ok {
@@ -466,6 +694,10 @@ func (s *contestService) DeleteContest(ctx context.Context, contestID uuid.UUID,
 		return errs.ErrUnauthorizedContestDelete
 	}
 
+	if err := checkIfMatch(ctx, contest); err != nil {
+		return err
+	}
+
 	if err := s.repo.Delete(ctx, contestID); err != nil {
 		log.Error("failed to delete contest from repository", "contest_id", contestID, "error", err)
 		return err
@@ -482,6 +714,289 @@ func (s *contestService) DeleteContest(ctx context.Context, contestID uuid.UUID,
 	return nil
 }
 
+func (s *contestService) RestoreContest(ctx context.Context, contestID uuid.UUID, user string) (*model.Contest, error) {
+	log := util.LoggerFromContext(ctx)
+
+	contest, err := s.repo.GetDeletedByID(ctx, contestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		log.Error("failed to get deleted contest for restore", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	// the owner's participant row outlives the soft delete, so the usual check still applies
+	if err := s.participantService.Authorize(ctx, contestID, user, ActionDeleteContest); err != nil {
+		log.Warn("unauthorized restore attempt", "contest_id", contestID, "user", user)
+		return nil, errs.ErrUnauthorizedContestRestore
+	}
+
+	// contests deleted before restores existed never recorded where they came from
+	if contest.PreDeleteStatus == nil || contest.DeletedAt == nil || !contest.Status.CanTransitionTo(*contest.PreDeleteStatus) {
+		log.Warn("contest has no status to restore to", "contest_id", contestID)
+		return nil, errs.ErrContestNotRestorable
+	}
+
+	if time.Since(*contest.DeletedAt) > s.restoreWindow {
+		log.Warn("restore window has passed", "contest_id", contestID, "deleted_at", contest.DeletedAt)
+		return nil, errs.ErrContestRestoreExpired
+	}
+
+	if err := checkIfMatch(ctx, contest); err != nil {
+		return nil, err
+	}
+
+	// the owner may have reused the name while this one was deleted
+	exists, err := s.repo.ExistsByOwnerAndName(ctx, contest.Owner, contest.Name)
+	if err != nil {
+		log.Error("failed to check for existing contest name", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+	if exists {
+		log.Warn("contest name taken since delete", "contest_id", contestID, "name", contest.Name)
+		return nil, errs.ErrContestAlreadyExists
+	}
+
+	contest.Status = *contest.PreDeleteStatus
+	contest.PreDeleteStatus = nil
+	contest.DeletedAt = nil
+	contest.UpdatedBy = user
+	if err := s.repo.Update(ctx, contest); err != nil {
+		log.Error("failed to save restored contest", "contest_id", contestID, "error", err)
+		return nil, err
+	}
+
+	go func() {
+		wsContest := *contest
+		wsContest.Squares = nil
+		wsContest.QuarterResults = nil
+
+		if err := s.natsService.PublishContestRestored(contestID, user, &wsContest); err != nil {
+			log.Error("failed to publish contest restored", "contest_id", contestID, "error", err)
+		}
+	}()
+
+	metrics.IncContestRestored()
+	log.Info("restored contest successfully", "contest_id", contestID, "status", contest.Status)
+	return contest, nil
+}
+
+func (s *contestService) LockDueContests(ctx context.Context) (int, error) {
+	log := util.LoggerFromContext(ctx)
+
+	contests, err := s.repo.GetDueForLock(ctx, time.Now())
+	if err != nil {
+		log.Error("failed to get contests due for lock", "error", err)
+		return 0, err
+	}
+
+	// one failing contest shouldn't hold up the rest
+	started := 0
+	for i := range contests {
+		ok, lockErr := s.lockContest(ctx, &contests[i])
+		if lockErr != nil {
+			log.Error("failed to lock contest", "contest_id", contests[i].ID, "error", lockErr)
+			continue
+		}
+		if ok {
+			started++
+		}
+	}
+
+	return started, nil
+}
+
+func (s *contestService) lockContest(ctx context.Context, contest *model.Contest) (bool, error) {
+	log := util.LoggerFromContext(ctx)
+
+	unpaid, cleared, paid, err := settlePayments(ctx, contest, s.participantRepo)
+	if err != nil {
+		return false, err
+	}
+
+	if !paid {
+		return false, s.skipLock(ctx, contest, "Scheduled lock passed but some square owners haven't paid")
+	}
+
+	// the fill policy decides whether a grid with holes can still lock
+	assigned, ready, err := planFill(ctx, contest, s.participantRepo, s.userRepo)
+	if err != nil {
+		return false, err
+	}
+
+	if !ready {
+		return false, s.skipLock(ctx, contest, "Scheduled lock passed but unclaimed squares remain")
+	}
+
+	mergeAssignedSquares(contest, assigned)
+
+	if err := s.transitionToQ1(ctx, contest, unpaid, assigned, systemUser); err != nil {
+		return false, err
+	}
+	s.publishClearedSquares(ctx, contest.ID, systemUser, settledSquares(contest, cleared))
+
+	s.publishContestLocked(ctx, contest, "Contest locked and started at its scheduled time")
+	metrics.IncContestStarted()
+	metrics.IncContestLocked(true)
+	log.Info("contest started at scheduled lock", "contest_id", contest.ID, "assigned_squares", len(assigned))
+	return true, nil
+}
+
+// the schedule fires once; the owner can reschedule or start manually
+func (s *contestService) skipLock(ctx context.Context, contest *model.Contest, reason string) error {
+	log := util.LoggerFromContext(ctx)
+
+	contest.LockAt = nil
+	contest.UpdatedBy = systemUser
+	if err := s.repo.Update(ctx, contest); err != nil {
+		return err
+	}
+
+	s.publishContestLocked(ctx, contest, reason)
+	metrics.IncContestLocked(false)
+	log.Info("scheduled lock skipped", "contest_id", contest.ID, "reason", reason)
+	return nil
+}
+
+func (s *contestService) publishClearedSquares(ctx context.Context, contestID uuid.UUID, user string, cleared []model.Square) {
+	log := util.LoggerFromContext(ctx)
+
+	if len(cleared) == 0 {
+		return
+	}
+
+	go func() {
+		if err := s.natsService.PublishSquaresUpdate(contestID, user, cleared); err != nil {
+			log.Error("failed to publish squares cleared for unpaid owners", "contest_id", contestID, "error", err)
+		}
+	}()
+}
+
+// removes contests for good once their restore window has passed
+func (s *contestService) PurgeDeletedContests(ctx context.Context) (int64, error) {
+	log := util.LoggerFromContext(ctx)
+
+	purged, err := s.repo.PurgeDeleted(ctx, time.Now().Add(-s.restoreWindow))
+	if err != nil {
+		log.Error("failed to purge deleted contests", "error", err)
+		return 0, errs.ErrDatabaseUnavailable
+	}
+
+	if purged > 0 {
+		metrics.AddContestsPurged(purged)
+		log.Info("purged deleted contests", "count", purged)
+	}
+	return purged, nil
+}
+
+// compacts finished contests past retention, a batch per run; one bad contest doesn't hold up the rest
+func (s *contestService) ArchiveFinishedContests(ctx context.Context) (int, error) {
+	log := util.LoggerFromContext(ctx)
+
+	ids, err := s.repo.GetDueForArchive(ctx, time.Now().Add(-s.archiveAfter), archiveBatchSize)
+	if err != nil {
+		log.Error("failed to get contests due for archive", "error", err)
+		return 0, errs.ErrDatabaseUnavailable
+	}
+
+	archived := 0
+	for _, id := range ids {
+		if err := s.repo.Archive(ctx, id); err != nil {
+			log.Error("failed to archive contest", "contest_id", id, "error", err)
+			continue
+		}
+
+		archived++
+		metrics.IncContestArchived()
+	}
+
+	if archived > 0 {
+		log.Info("archived finished contests", "count", archived)
+	}
+	return archived, nil
+}
+
+// claims already ignore reservations of expired invites; this clears them so boards stop showing the square as held
+func (s *contestService) ReleaseExpiredReservations(ctx context.Context) (int, error) {
+	log := util.LoggerFromContext(ctx)
+
+	released, err := s.repo.ReleaseExpiredReservations(ctx)
+	if err != nil {
+		log.Error("failed to release expired reservations", "error", err)
+		return 0, errs.ErrDatabaseUnavailable
+	}
+
+	byContest := make(map[uuid.UUID][]model.Square)
+	for _, sq := range released {
+		byContest[sq.ContestID] = append(byContest[sq.ContestID], sq)
+	}
+
+	for contestID, squares := range byContest {
+		if err := s.natsService.PublishSquaresUpdate(contestID, systemUser, squares); err != nil {
+			log.Error("failed to publish released squares", "contest_id", contestID, "error", err)
+		}
+	}
+
+	if len(released) > 0 {
+		log.Info("released expired square reservations", "count", len(released))
+	}
+	return len(released), nil
+}
+
+func (s *contestService) PurgeInviteEvents(ctx context.Context) (int64, error) {
+	log := util.LoggerFromContext(ctx)
+
+	purged, err := s.repo.PurgeInviteEvents(ctx, time.Now().Add(-s.eventRetention))
+	if err != nil {
+		log.Error("failed to purge invite events", "error", err)
+		return 0, errs.ErrDatabaseUnavailable
+	}
+
+	if purged > 0 {
+		log.Info("purged old invite events", "count", purged)
+	}
+	return purged, nil
+}
+
+// rejects the write when the client's If-Match names an older version than the one just loaded
+func checkIfMatch(ctx context.Context, contest *model.Contest) error {
+	expected, ok := util.ExpectedVersionFromContext(ctx)
+	if !ok || expected == contest.Version {
+		return nil
+	}
+
+	util.LoggerFromContext(ctx).Warn("if-match version mismatch", "contest_id", contest.ID, "expected_version", expected, "current_version", contest.Version)
+	return errs.ErrContestVersionMismatch
+}
+
+// single-square writes compare If-Match against the square's etag, which is what their responses hand back
+func checkSquareIfMatch(ctx context.Context, square *model.Square) error {
+	expected, ok := util.ExpectedVersionFromContext(ctx)
+	if !ok || expected == square.Version {
+		return nil
+	}
+
+	util.LoggerFromContext(ctx).Warn("if-match square version mismatch", "square_id", square.ID, "expected_version", expected, "current_version", square.Version)
+	return errs.ErrSquareVersionMismatch
+}
+
+func (s *contestService) publishContestLocked(ctx context.Context, contest *model.Contest, message string) {
+	log := util.LoggerFromContext(ctx)
+
+	// send a lightweight contest copy without large preloaded relations
+	wsContest := *contest
+	wsContest.Squares = nil
+	wsContest.QuarterResults = nil
+	wsContest.Game = nil
+
+	go func() {
+		if err := s.natsService.PublishContestLocked(contest.ID, &wsContest, message); err != nil {
+			log.Error("failed to publish contest locked", "contest_id", contest.ID, "error", err)
+		}
+	}()
+}
+
 // ====================
 // Square Actions
 // ====================
@@ -525,63 +1040,34 @@ func (s *contestService) ClaimSquare(ctx context.Context, contestID, squareID uu
 		return nil, gorm.ErrRecordNotFound
 	}
 
-	// a claimed square can only be re-claimed by its owner
-	if square.Owner != "" && square.Owner != user {
-		log.Warn("user not authorized to update square", "square_id", squareID, "owner", square.Owner, "user", user)
-		return nil, errs.ErrUnauthorizedSquareEdit
+	if err := checkSquareIfMatch(ctx, square); err != nil {
+		return nil, err
 	}
 
-	// enforce square limit for the participant
-	participant, err := s.participantRepo.GetByContestAndUser(ctx, contestID, user)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			log.Warn("user is not a participant", "contest_id", contestID, "user", user)
-			return nil, errs.ErrNotParticipant
-		}
-		log.Error("failed to get participant for square limit check", "error", err)
-		return nil, errs.ErrDatabaseUnavailable
+	// a claimed square can only be re-claimed by its owner; the repository re-checks this under lock
+	if square.Owner != "" && square.Owner != user {
+		log.Warn("square already claimed by another user", "square_id", squareID, "owner", square.Owner, "user", user)
+		metrics.IncSquareClaimConflict("claimed")
+		return nil, errs.ErrSquareAlreadyClaimed
 	}
 
 	// capture whether the square was unclaimed before the update mutates square.Owner
 	wasUnclaimed := square.Owner == ""
 
-	// only check limit if claiming a new square (not re-editing own square)
-	if wasUnclaimed {
-		claimed, countErr := s.participantRepo.CountSquaresByUser(ctx, contestID, user)
-		if countErr != nil {
-			log.Error("failed to count user squares", "contest_id", contestID, "user", user, "error", countErr)
-			return nil, errs.ErrDatabaseUnavailable
-		}
-
-		if claimed >= participant.MaxSquares {
-			log.Warn("user has reached square limit", "contest_id", contestID, "user", user, "claimed", claimed, "limit", participant.MaxSquares)
-			return nil, errs.ErrSquareLimitReached
-		}
-	}
-
-	// get claims so we can capture the owner's display name
-	claims := util.ClaimsFromContext(ctx)
-	if claims == nil {
-		log.Error("claims not found in context")
-		return nil, errs.ErrClaimsNotFound
-	}
-
-	// the square value is the claimant's profile default initials, seeded from their name on first visit
-	profile, err := s.userRepo.GetOrCreate(ctx, user, claims.Name, util.InitialsFromName(claims.Name))
+	profile, ownerName, err := claimantProfile(ctx, s.userRepo, user)
 	if err != nil {
-		log.Error("failed to load profile for square claim", "user", user, "error", err)
-		return nil, errs.ErrDatabaseUnavailable
-	}
-
-	if profile.DefaultInitials == "" {
-		log.Warn("user has no default initials set", "user", user)
-		return nil, errs.ErrMissingInitials
+		return nil, err
 	}
 
-	claimedSquare, err := s.repo.ClaimSquare(ctx, square, profile.DefaultInitials, user, claims.Name)
+	// ownership and the participant's square limit are enforced inside the claim transaction
+	claimedSquare, err := s.repo.ClaimSquare(ctx, square, profile.DefaultInitials, user, ownerName)
 	if err != nil {
+		if rejected := claimRejection(err); rejected != nil {
+			log.Warn("square claim rejected", "square_id", squareID, "user", user, "error", err)
+			return nil, rejected
+		}
 		log.Error("failed to claim square", "square_id", square.ID, "value", profile.DefaultInitials, "owner", user, "error", err)
-		return nil, err
+		return nil, errs.ErrDatabaseUnavailable
 	}
 
 	if wasUnclaimed {
@@ -640,6 +1126,10 @@ func (s *contestService) ClearSquare(ctx context.Context, contestID, squareID uu
 		return nil, errs.ErrUnauthorizedSquareEdit
 	}
 
+	if err := checkSquareIfMatch(ctx, square); err != nil {
+		return nil, err
+	}
+
 	clearedSquare, err := s.repo.ClearSquare(ctx, square)
 	if err != nil {
 		log.Error("failed to clear square", "square_id", square.ID, "error", err)
@@ -678,6 +1168,10 @@ func (s *contestService) ClearUserSquares(ctx context.Context, contestID uuid.UU
 		return nil, errs.ErrSquareNotEditable
 	}
 
+	if err := checkIfMatch(ctx, contest); err != nil {
+		return nil, err
+	}
+
 	clearedSquares, err := s.repo.ClearSquaresByOwner(ctx, contestID, user)
 	if err != nil {
 		log.Error("failed to clear user squares", "contest_id", contestID, "user", user, "error", err)
@@ -698,3 +1192,240 @@ func (s *contestService) ClearUserSquares(ctx context.Context, contestID uuid.UU
 	log.Info("user squares cleared successfully", "contest_id", contestID, "user", user, "count", len(clearedSquares))
 	return clearedSquares, nil
 }
+
+func (s *contestService) ClaimSquares(ctx context.Context, contestID uuid.UUID, squareIDs []uuid.UUID, user string) ([]model.Square, error) {
+	log := util.LoggerFromContext(ctx)
+
+	// get contest to check status and find squares
+	contest, err := s.repo.GetByID(ctx, contestID)
+	if err != nil {
+		log.Error("failed to get contest for bulk square claim", "contest_id", contestID, "error", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	if contest.Status != model.ContestStatusActive {
+		log.Warn("cannot claim squares when contest is not active", "contest_id", contestID, "contest_status", contest.Status)
+		return nil, errs.ErrSquareNotEditable
+	}
+
+	if err = s.participantService.Authorize(ctx, contestID, user, ActionClaimSquare); err != nil {
+		log.Warn("user not authorized to claim squares", "contest_id", contestID, "user", user)
+		return nil, errs.ErrUnauthorizedSquareEdit
+	}
+
+	if err := checkIfMatch(ctx, contest); err != nil {
+		return nil, err
+	}
+
+	// every requested square must be in this contest and free for the caller
+	squares := make(map[uuid.UUID]*model.Square, len(contest.Squares))
+	for i := range contest.Squares {
+		squares[contest.Squares[i].ID] = &contest.Squares[i]
+	}
+
+	ids := make([]uuid.UUID, 0, len(squareIDs))
+	seen := make(map[uuid.UUID]bool, len(squareIDs))
+	newlyClaimed := 0
+	for _, id := range squareIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		square, ok := squares[id]
+		if !ok {
+			log.Warn("square not found in contest", "square_id", id, "contest_id", contestID)
+			return nil, gorm.ErrRecordNotFound
+		}
+		if square.Owner != "" && square.Owner != user {
+			log.Warn("square already claimed by another user", "square_id", id, "owner", square.Owner, "user", user)
+			metrics.IncSquareClaimConflict("claimed")
+			return nil, errs.ErrSquareAlreadyClaimed
+		}
+		if square.Owner == "" {
+			newlyClaimed++
+		}
+		ids = append(ids, id)
+	}
+
+	profile, ownerName, err := claimantProfile(ctx, s.userRepo, user)
+	if err != nil {
+		return nil, err
+	}
+
+	// the repository re-checks ownership and the limit inside the transaction
+	claimedSquares, err := s.repo.ClaimSquares(ctx, contestID, ids, profile.DefaultInitials, user, ownerName)
+	if err != nil {
+		if rejected := claimRejection(err); rejected != nil {
+			log.Warn("bulk square claim rejected", "contest_id", contestID, "user", user, "count", len(ids), "error", err)
+			return nil, rejected
+		}
+		log.Error("failed to claim squares", "contest_id", contestID, "user", user, "count", len(ids), "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	for range newlyClaimed {
+		metrics.IncSquareClaimed()
+	}
+
+	go func() {
+		if err := s.natsService.PublishSquaresUpdate(contest.ID, user, claimedSquares); err != nil {
+			log.Error("failed to publish squares update", "contest_id", contest.ID, "count", len(claimedSquares), "error", err)
+		}
+	}()
+
+	log.Info("squares claimed successfully", "contest_id", contestID, "owner", user, "count", len(claimedSquares))
+	return claimedSquares, nil
+}
+
+func (s *contestService) AssignSquare(ctx context.Context, contestID, squareID uuid.UUID, req *model.AssignSquareRequest, user string) (*model.Square, error) {
+	log := util.LoggerFromContext(ctx)
+	assignee := req.UserID
+
+	contest, err := s.repo.GetByID(ctx, contestID)
+	if err != nil {
+		log.Error("failed to get contest for square assignment", "contest_id", contestID, "error", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	if contest.Status != model.ContestStatusActive {
+		log.Warn("cannot assign square when contest is not active", "square_id", squareID, "contest_status", contest.Status)
+		return nil, errs.ErrSquareNotEditable
+	}
+
+	// only the contest owner hands out squares
+	if err = s.participantService.Authorize(ctx, contestID, user, ActionEditContest); err != nil {
+		log.Warn("user not authorized to assign squares", "contest_id", contestID, "user", user)
+		return nil, errs.ErrUnauthorizedContestEdit
+	}
+
+	var square *model.Square
+	for i := range contest.Squares {
+		if contest.Squares[i].ID == squareID {
+			square = &contest.Squares[i]
+			break
+		}
+	}
+
+	if square == nil {
+		log.Warn("square not found in contest", "square_id", squareID, "contest_id", contestID)
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	if err := checkSquareIfMatch(ctx, square); err != nil {
+		return nil, err
+	}
+
+	participant, err := s.participantRepo.GetByContestAndUser(ctx, contestID, assignee)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("assignee is not a participant", "contest_id", contestID, "assignee", assignee)
+			return nil, errs.ErrNotParticipant
+		}
+		log.Error("failed to get assignee participant", "contest_id", contestID, "assignee", assignee, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	if participant.Role == model.ParticipantRoleViewer {
+		log.Warn("cannot assign square to a viewer", "contest_id", contestID, "assignee", assignee)
+		return nil, errs.ErrViewerCannotHaveSquares
+	}
+
+	if square.Owner == assignee {
+		return square, nil
+	}
+
+	// taking a square away from another participant has to be asked for explicitly
+	if square.Owner != "" && !req.Override {
+		log.Warn("square already held, assignment needs override", "square_id", squareID, "owner", square.Owner, "assignee", assignee)
+		metrics.IncSquareClaimConflict("claimed")
+		return nil, errs.ErrSquareAlreadyClaimed
+	}
+
+	// the square shows the assignee's initials, as if they'd claimed it themselves
+	labelled := []model.Square{{Owner: assignee}}
+	if err := labelAssignedSquares(ctx, s.userRepo, labelled); err != nil {
+		log.Error("failed to load assignee profile", "assignee", assignee, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	wasUnclaimed := square.Owner == ""
+	assignedSquare, err := s.repo.AssignSquare(ctx, square, labelled[0].Value, assignee, labelled[0].OwnerName)
+	if err != nil {
+		if rejected := claimRejection(err); rejected != nil {
+			log.Warn("square assignment rejected", "square_id", squareID, "assignee", assignee, "error", err)
+			return nil, rejected
+		}
+		log.Error("failed to assign square", "square_id", squareID, "assignee", assignee, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	if wasUnclaimed {
+		metrics.IncSquareClaimed()
+	}
+
+	go func() {
+		if err := s.natsService.PublishSquaresUpdate(contest.ID, user, []model.Square{*assignedSquare}); err != nil {
+			log.Error("failed to publish square assignment", "contest_id", contest.ID, "square_id", assignedSquare.ID, "error", err)
+		}
+	}()
+
+	log.Info("square assigned successfully", "square_id", squareID, "assignee", assignee, "user", user)
+	return assignedSquare, nil
+}
+
+func claimantProfile(ctx context.Context, userRepo repository.UserRepository, user string) (*model.User, string, error) {
+	log := util.LoggerFromContext(ctx)
+
+	// get claims so we can capture the owner's display name
+	claims := util.ClaimsFromContext(ctx)
+	if claims == nil {
+		log.Error("claims not found in context")
+		return nil, "", errs.ErrClaimsNotFound
+	}
+
+	// the square value is the claimant's profile default initials, seeded from their name on first visit
+	profile, err := userRepo.GetOrCreate(ctx, user, claims.Name, util.InitialsFromName(claims.Name))
+	if err != nil {
+		log.Error("failed to load profile for square claim", "user", user, "error", err)
+		return nil, "", errs.ErrDatabaseUnavailable
+	}
+
+	if profile.DefaultInitials == "" {
+		log.Warn("user has no default initials set", "user", user)
+		return nil, "", errs.ErrMissingInitials
+	}
+
+	// a name the user chose wins over whatever the token carries
+	ownerName := claims.Name
+	if profile.DisplayName != "" {
+		ownerName = profile.DisplayName
+	}
+
+	return profile, ownerName, nil
+}
+
+// picks out the repository errors that mean another request won the race, so callers get a conflict instead of a 500
+func claimRejection(err error) error {
+	switch {
+	case errors.Is(err, errs.ErrSquareAlreadyClaimed):
+		metrics.IncSquareClaimConflict("claimed")
+		return errs.ErrSquareAlreadyClaimed
+	case errors.Is(err, errs.ErrSquareLimitReached):
+		metrics.IncSquareClaimConflict("limit")
+		return errs.ErrSquareLimitReached
+	case errors.Is(err, errs.ErrNotParticipant):
+		return errs.ErrNotParticipant
+	case errors.Is(err, errs.ErrContestNotEditable):
+		// the contest started between loading it and writing the claim
+		return errs.ErrContestNotEditable
+	default:
+		return nil
+	}
+}
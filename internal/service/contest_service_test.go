@@ -5,12 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/maxmorhardt/squares-api/internal/errs"
 	"github.com/maxmorhardt/squares-api/internal/mocks"
 	"github.com/maxmorhardt/squares-api/internal/model"
 	"github.com/maxmorhardt/squares-api/internal/service"
+	"github.com/maxmorhardt/squares-api/internal/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -29,8 +31,16 @@ func TestGetContestsByOwnerPaginated(t *testing.T) {
 	assert.Equal(t, int64(1), total)
 }
 
+const (
+	restoreWindow  = 7 * 24 * time.Hour
+	archiveAfter   = 30 * 24 * time.Hour
+	eventRetention = 90 * 24 * time.Hour
+)
+
+var lifecycleCfg = model.LifecycleConfig{RestoreWindow: restoreWindow, ArchiveAfter: archiveAfter, EventRetention: eventRetention}
+
 func contestSvc(repo *mocks.ContestRepository, pRepo *mocks.ParticipantRepository, pSvc *mocks.ParticipantService) service.ContestService {
-	return service.NewContestService(repo, pRepo, &mocks.GameRepository{}, anyUser(), anyNats(), pSvc)
+	return service.NewContestService(repo, pRepo, &mocks.GameRepository{}, anyUser(), &mocks.OrganizationRepository{}, anyNats(), pSvc, anyAnalytics(), lifecycleCfg)
 }
 
 // yields non-empty default initials so square claims proceed
@@ -44,7 +54,7 @@ func anyUser() *mocks.UserRepository {
 }
 
 func contestSvcWithGame(repo *mocks.ContestRepository, pRepo *mocks.ParticipantRepository, gameRepo *mocks.GameRepository, pSvc *mocks.ParticipantService) service.ContestService {
-	return service.NewContestService(repo, pRepo, gameRepo, anyUser(), anyNats(), pSvc)
+	return service.NewContestService(repo, pRepo, gameRepo, anyUser(), &mocks.OrganizationRepository{}, anyNats(), pSvc, anyAnalytics(), lifecycleCfg)
 }
 
 // participant service that authorizes every action it's asked about
@@ -54,6 +64,59 @@ func okAuth(t *testing.T) *mocks.ParticipantService {
 	return m
 }
 
+func TestGetContest_NotFound(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), mocks.NewParticipantService(t)).
+		GetContest(context.Background(), uuid.New(), "u")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestGetContest_DBError(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(nil, errors.New("boom"))
+
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), mocks.NewParticipantService(t)).
+		GetContest(context.Background(), uuid.New(), "u")
+	assert.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
+}
+
+func TestGetContest_Unauthorized(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
+	pSvc := mocks.NewParticipantService(t)
+	pSvc.EXPECT().Authorize(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errs.ErrNotParticipant)
+
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), pSvc).
+		GetContest(context.Background(), uuid.New(), "u")
+	assert.ErrorIs(t, err, errs.ErrNotParticipant)
+}
+
+func TestGetContest_Success(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive, Version: 7}, nil)
+
+	got, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
+		GetContest(context.Background(), uuid.New(), "u")
+	require.NoError(t, err)
+	assert.Equal(t, 7, got.Version)
+}
+
+func TestGetContest_AnnotatesSquares(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusQ2, Squares: []model.Square{{Owner: "alice"}}}, nil)
+
+	// odds aren't persisted, so a read has to attach them; the contest still loads when they fail
+	analytics := mocks.NewAnalyticsService(t)
+	analytics.EXPECT().AnnotateSquares(mock.Anything, mock.Anything).Return(errors.New("boom")).Once()
+
+	got, err := service.NewContestService(repo, mocks.NewParticipantRepository(t), &mocks.GameRepository{}, anyUser(), &mocks.OrganizationRepository{}, anyNats(), okAuth(t), analytics, lifecycleCfg).
+		GetContest(context.Background(), uuid.New(), "u")
+	require.NoError(t, err)
+	assert.Len(t, got.Squares, 1)
+}
+
 func TestCreateContest_AlreadyExists(t *testing.T) {
 	repo := mocks.NewContestRepository(t)
 	repo.EXPECT().ExistsByOwnerAndName(mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
@@ -84,6 +147,106 @@ func TestCreateContest_Success(t *testing.T) {
 	assert.Equal(t, model.ContestStatusActive, got.Status)
 }
 
+func orgContestSvc(repo *mocks.ContestRepository, orgRepo *mocks.OrganizationRepository) service.ContestService {
+	return service.NewContestService(repo, &mocks.ParticipantRepository{}, &mocks.GameRepository{}, anyUser(), orgRepo, anyNats(), &mocks.ParticipantService{}, anyAnalytics(), lifecycleCfg)
+}
+
+func TestCreateContest_InOrganization(t *testing.T) {
+	orgID := uuid.New()
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().ExistsByOwnerAndName(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	repo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(c *model.Contest) bool {
+		return c.OrgID != nil && *c.OrgID == orgID
+	}), mock.Anything).Return(nil)
+	orgRepo := mocks.NewOrganizationRepository(t)
+	orgRepo.EXPECT().GetByID(mock.Anything, orgID).Return(&model.Organization{ID: orgID}, nil)
+	orgRepo.EXPECT().GetMember(mock.Anything, orgID, "o").Return(&model.OrganizationMember{Role: model.OrgRoleMember}, nil)
+
+	got, err := orgContestSvc(repo, orgRepo).
+		CreateContest(context.Background(), &model.CreateContestRequest{Owner: "o", Name: "n", OrgID: orgID.String()}, "o")
+	require.NoError(t, err)
+	assert.Equal(t, orgID, *got.OrgID)
+}
+
+func TestCreateContest_OrgNotMember(t *testing.T) {
+	orgID := uuid.New()
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().ExistsByOwnerAndName(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	orgRepo := mocks.NewOrganizationRepository(t)
+	orgRepo.EXPECT().GetByID(mock.Anything, orgID).Return(&model.Organization{ID: orgID}, nil)
+	orgRepo.EXPECT().GetMember(mock.Anything, orgID, "o").Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := orgContestSvc(repo, orgRepo).
+		CreateContest(context.Background(), &model.CreateContestRequest{Owner: "o", Name: "n", OrgID: orgID.String()}, "o")
+	assert.ErrorIs(t, err, errs.ErrNotOrgMember)
+}
+
+func TestCreateContest_OrgNotFound(t *testing.T) {
+	orgID := uuid.New()
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().ExistsByOwnerAndName(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	orgRepo := mocks.NewOrganizationRepository(t)
+	orgRepo.EXPECT().GetByID(mock.Anything, orgID).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := orgContestSvc(repo, orgRepo).
+		CreateContest(context.Background(), &model.CreateContestRequest{Owner: "o", Name: "n", OrgID: orgID.String()}, "o")
+	assert.ErrorIs(t, err, errs.ErrOrganizationNotFound)
+}
+
+func TestCreateContest_AddsOrgMembers(t *testing.T) {
+	orgID := uuid.New()
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().ExistsByOwnerAndName(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	// the creator is already the owner, so only the other members are seeded
+	repo.EXPECT().Import(mock.Anything, mock.Anything, mock.Anything, []model.Square(nil), []model.QuarterResult(nil),
+		mock.MatchedBy(func(ps []model.ContestParticipant) bool {
+			return len(ps) == 2 && ps[0].UserID == "a" && ps[0].Role == model.ParticipantRoleParticipant && ps[1].MaxSquares == 5
+		})).Return(nil)
+	orgRepo := mocks.NewOrganizationRepository(t)
+	orgRepo.EXPECT().GetByID(mock.Anything, orgID).Return(&model.Organization{ID: orgID}, nil)
+	orgRepo.EXPECT().GetMember(mock.Anything, orgID, "o").Return(&model.OrganizationMember{Role: model.OrgRoleAdmin}, nil)
+	orgRepo.EXPECT().GetMembers(mock.Anything, orgID).Return([]model.OrganizationMember{{UserID: "O"}, {UserID: "a"}, {UserID: "b"}}, nil)
+
+	_, err := orgContestSvc(repo, orgRepo).CreateContest(context.Background(), &model.CreateContestRequest{
+		Owner: "o", Name: "n", MaxSquares: 10, OrgID: orgID.String(), AddOrgMembers: true, MemberSquares: 5,
+	}, "o")
+	require.NoError(t, err)
+}
+
+func TestCreateContest_OrgMembersAsViewers(t *testing.T) {
+	orgID := uuid.New()
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().ExistsByOwnerAndName(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	repo.EXPECT().Import(mock.Anything, mock.Anything, mock.Anything, []model.Square(nil), []model.QuarterResult(nil),
+		mock.MatchedBy(func(ps []model.ContestParticipant) bool {
+			return len(ps) == 1 && ps[0].Role == model.ParticipantRoleViewer && ps[0].MaxSquares == 0
+		})).Return(nil)
+	orgRepo := mocks.NewOrganizationRepository(t)
+	orgRepo.EXPECT().GetByID(mock.Anything, orgID).Return(&model.Organization{ID: orgID}, nil)
+	orgRepo.EXPECT().GetMember(mock.Anything, orgID, "o").Return(&model.OrganizationMember{}, nil)
+	orgRepo.EXPECT().GetMembers(mock.Anything, orgID).Return([]model.OrganizationMember{{UserID: "a"}}, nil)
+
+	_, err := orgContestSvc(repo, orgRepo).CreateContest(context.Background(), &model.CreateContestRequest{
+		Owner: "o", Name: "n", OrgID: orgID.String(), AddOrgMembers: true,
+	}, "o")
+	require.NoError(t, err)
+}
+
+func TestCreateContest_OrgMembersExceedPool(t *testing.T) {
+	orgID := uuid.New()
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().ExistsByOwnerAndName(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	orgRepo := mocks.NewOrganizationRepository(t)
+	orgRepo.EXPECT().GetByID(mock.Anything, orgID).Return(&model.Organization{ID: orgID}, nil)
+	orgRepo.EXPECT().GetMember(mock.Anything, orgID, "o").Return(&model.OrganizationMember{}, nil)
+	orgRepo.EXPECT().GetMembers(mock.Anything, orgID).Return([]model.OrganizationMember{{UserID: "a"}, {UserID: "b"}}, nil)
+
+	_, err := orgContestSvc(repo, orgRepo).CreateContest(context.Background(), &model.CreateContestRequest{
+		Owner: "o", Name: "n", MaxSquares: 10, OrgID: orgID.String(), AddOrgMembers: true, MemberSquares: 50,
+	}, "o")
+	assert.ErrorIs(t, err, errs.ErrNotEnoughSquares)
+}
+
 func TestCreateContest_WithGame(t *testing.T) {
 	gameID := uuid.New()
 	repo := mocks.NewContestRepository(t)
@@ -181,6 +344,41 @@ func TestUpdateContest_Success(t *testing.T) {
 	assert.Equal(t, "B", got.HomeTeam)
 }
 
+func TestUpdateContest_IfMatchMismatch(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive, Version: 3}, nil)
+
+	ctx := context.WithValue(context.Background(), model.IfMatchKey, 2)
+	homeTeam := "B"
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
+		UpdateContest(ctx, uuid.New(), &model.UpdateContestRequest{HomeTeam: &homeTeam}, "u")
+	assert.ErrorIs(t, err, errs.ErrContestVersionMismatch)
+}
+
+func TestUpdateContest_IfMatchCurrent(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive, Version: 3}, nil)
+	repo.EXPECT().Update(mock.Anything, mock.Anything).Return(nil)
+
+	ctx := context.WithValue(context.Background(), model.IfMatchKey, 3)
+	homeTeam := "B"
+	got, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
+		UpdateContest(ctx, uuid.New(), &model.UpdateContestRequest{HomeTeam: &homeTeam}, "u")
+	require.NoError(t, err)
+	assert.Equal(t, "B", got.HomeTeam)
+}
+
+func TestUpdateContest_VersionConflict(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
+	repo.EXPECT().Update(mock.Anything, mock.Anything).Return(errs.ErrContestVersionConflict)
+
+	homeTeam := "B"
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
+		UpdateContest(context.Background(), uuid.New(), &model.UpdateContestRequest{HomeTeam: &homeTeam}, "u")
+	assert.ErrorIs(t, err, errs.ErrContestVersionConflict)
+}
+
 func TestUpdateContest_VisibilityChange(t *testing.T) {
 	repo := mocks.NewContestRepository(t)
 	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive, Visibility: model.ContestVisibilityPublic}, nil)
@@ -261,6 +459,93 @@ func TestStartContest_Success(t *testing.T) {
 	assert.Equal(t, model.ContestStatusQ1, got.Status)
 }
 
+func TestStartContest_AnnotatesSquares(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{
+		Status:  model.ContestStatusActive,
+		Squares: []model.Square{{Owner: "alice"}},
+	}, nil)
+	repo.EXPECT().Update(mock.Anything, mock.Anything).Return(nil)
+
+	// analytics run once the labels are drawn; a failure doesn't block the start
+	analytics := mocks.NewAnalyticsService(t)
+	analytics.EXPECT().AnnotateSquares(mock.Anything, mock.MatchedBy(func(c *model.Contest) bool {
+		return c.Status == model.ContestStatusQ1
+	})).Return(errors.New("boom"))
+
+	got, err := service.NewContestService(repo, mocks.NewParticipantRepository(t), &mocks.GameRepository{}, anyUser(), &mocks.OrganizationRepository{}, anyNats(), mocks.NewParticipantService(t), analytics, lifecycleCfg).
+		StartContest(context.Background(), uuid.New(), "u")
+	require.NoError(t, err)
+	assert.Equal(t, model.ContestStatusQ1, got.Status)
+}
+
+func TestStartContest_HouseFill(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{
+		Status:     model.ContestStatusActive,
+		FillPolicy: model.FillPolicyHouse,
+		Squares:    []model.Square{{ID: uuid.New(), Owner: "alice"}, {ID: uuid.New()}},
+	}, nil)
+	repo.EXPECT().StartWithSquares(mock.Anything, mock.Anything, mock.Anything, mock.MatchedBy(func(sqs []model.Square) bool {
+		return len(sqs) == 1 && sqs[0].Owner == model.HouseUser && sqs[0].Value == "HSE"
+	})).Return(nil)
+
+	got, err := contestSvc(repo, mocks.NewParticipantRepository(t), mocks.NewParticipantService(t)).
+		StartContest(context.Background(), uuid.New(), "u")
+	require.NoError(t, err)
+	assert.Equal(t, model.ContestStatusQ1, got.Status)
+	assert.Equal(t, model.HouseUser, got.Squares[1].Owner)
+}
+
+func TestStartContest_RolloverFill(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{
+		Status:     model.ContestStatusActive,
+		FillPolicy: model.FillPolicyRollover,
+		Squares:    []model.Square{{Owner: "alice"}, {}},
+	}, nil)
+	// empty squares stay empty, so only the contest row is saved
+	repo.EXPECT().Update(mock.Anything, mock.Anything).Return(nil)
+
+	got, err := contestSvc(repo, mocks.NewParticipantRepository(t), mocks.NewParticipantService(t)).
+		StartContest(context.Background(), uuid.New(), "u")
+	require.NoError(t, err)
+	assert.Equal(t, model.ContestStatusQ1, got.Status)
+	assert.Empty(t, got.Squares[1].Owner)
+}
+
+func TestStartContest_RandomFillShortOfCapacity(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{
+		Status:     model.ContestStatusActive,
+		FillPolicy: model.FillPolicyRandom,
+		Squares:    []model.Square{{Owner: "alice"}, {}, {}},
+	}, nil)
+	pRepo := mocks.NewParticipantRepository(t)
+	pRepo.EXPECT().GetAllByContestID(mock.Anything, mock.Anything).Return([]model.ContestParticipant{
+		{UserID: "alice", Role: model.ParticipantRoleOwner, MaxSquares: 2},
+	}, nil)
+
+	_, err := contestSvc(repo, pRepo, mocks.NewParticipantService(t)).
+		StartContest(context.Background(), uuid.New(), "u")
+	assert.ErrorIs(t, err, errs.ErrContestNotReady)
+}
+
+func TestStartContest_FillError(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{
+		Status:     model.ContestStatusActive,
+		FillPolicy: model.FillPolicyRandom,
+		Squares:    []model.Square{{}},
+	}, nil)
+	pRepo := mocks.NewParticipantRepository(t)
+	pRepo.EXPECT().GetAllByContestID(mock.Anything, mock.Anything).Return(nil, errors.New("db"))
+
+	_, err := contestSvc(repo, pRepo, mocks.NewParticipantService(t)).
+		StartContest(context.Background(), uuid.New(), "u")
+	assert.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
+}
+
 func TestStartContest_GameLinked(t *testing.T) {
 	gameID := uuid.New()
 	repo := mocks.NewContestRepository(t)
@@ -303,8 +588,9 @@ func TestRecordQuarterResult_Success(t *testing.T) {
 		YLabels: orderedLabels(t),
 		Squares: []model.Square{{Row: 3, Col: 7, Owner: "winner", OwnerName: "Win Ner"}},
 	}, nil)
-	repo.EXPECT().CreateQuarterResult(mock.Anything, mock.Anything).Return(nil)
-	repo.EXPECT().Update(mock.Anything, mock.Anything).Return(nil)
+	repo.EXPECT().RecordQuarterResult(mock.Anything, mock.Anything, mock.MatchedBy(func(c *model.Contest) bool {
+		return c.Status == model.ContestStatusQ2
+	})).Return(nil)
 
 	got, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
 		RecordQuarterResult(context.Background(), uuid.New(), 17, 23, "u")
@@ -313,6 +599,26 @@ func TestRecordQuarterResult_Success(t *testing.T) {
 	assert.Equal(t, "winner", got.Winner)
 }
 
+func TestRecordQuarterResult_RollsOverUnownedWinner(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{
+		Status:         model.ContestStatusQ2,
+		Rollover:       true,
+		XLabels:        orderedLabels(t),
+		YLabels:        orderedLabels(t),
+		Squares:        []model.Square{{Row: 3, Col: 7}},
+		QuarterResults: []model.QuarterResult{{Quarter: 1, RolledOver: true}},
+	}, nil)
+	repo.EXPECT().RecordQuarterResult(mock.Anything, mock.MatchedBy(func(r *model.QuarterResult) bool {
+		return r.RolledOver && r.CarriedOver == 1
+	}), mock.Anything).Return(nil)
+
+	got, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
+		RecordQuarterResult(context.Background(), uuid.New(), 17, 23, "u")
+	require.NoError(t, err)
+	assert.Empty(t, got.Winner)
+}
+
 func orderedLabels(t *testing.T) []byte {
 	t.Helper()
 	b, err := json.Marshal([]int8{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
@@ -361,6 +667,154 @@ func TestDeleteContest_Success(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestDeleteContest_IfMatchMismatch(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive, Version: 3}, nil)
+
+	ctx := context.WithValue(context.Background(), model.IfMatchKey, 2)
+	err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
+		DeleteContest(ctx, uuid.New(), "u")
+	assert.ErrorIs(t, err, errs.ErrContestVersionMismatch)
+}
+
+func deletedContest(from model.ContestStatus, deletedAgo time.Duration) *model.Contest {
+	deletedAt := time.Now().Add(-deletedAgo)
+	return &model.Contest{Name: "c", Owner: "u", Status: model.ContestStatusDeleted, PreDeleteStatus: &from, DeletedAt: &deletedAt, Version: 4}
+}
+
+func TestRestoreContest_Success(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetDeletedByID(mock.Anything, mock.Anything).Return(deletedContest(model.ContestStatusQ2, time.Hour), nil)
+	repo.EXPECT().ExistsByOwnerAndName(mock.Anything, "u", "c").Return(false, nil)
+	repo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(c *model.Contest) bool {
+		return c.Status == model.ContestStatusQ2 && c.PreDeleteStatus == nil && c.DeletedAt == nil && c.UpdatedBy == "u"
+	})).Return(nil)
+
+	contest, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
+		RestoreContest(context.Background(), uuid.New(), "u")
+	require.NoError(t, err)
+	assert.Equal(t, model.ContestStatusQ2, contest.Status)
+}
+
+func TestRestoreContest_NotFound(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetDeletedByID(mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), mocks.NewParticipantService(t)).
+		RestoreContest(context.Background(), uuid.New(), "u")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestRestoreContest_Unauthorized(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetDeletedByID(mock.Anything, mock.Anything).Return(deletedContest(model.ContestStatusActive, time.Hour), nil)
+	pSvc := mocks.NewParticipantService(t)
+	pSvc.EXPECT().Authorize(mock.Anything, mock.Anything, mock.Anything, service.ActionDeleteContest).Return(errs.ErrInsufficientRole)
+
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), pSvc).
+		RestoreContest(context.Background(), uuid.New(), "u")
+	assert.ErrorIs(t, err, errs.ErrUnauthorizedContestRestore)
+}
+
+func TestRestoreContest_WindowPassed(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetDeletedByID(mock.Anything, mock.Anything).Return(deletedContest(model.ContestStatusActive, restoreWindow+time.Hour), nil)
+
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
+		RestoreContest(context.Background(), uuid.New(), "u")
+	assert.ErrorIs(t, err, errs.ErrContestRestoreExpired)
+}
+
+func TestRestoreContest_DeletedBeforeRestoresExisted(t *testing.T) {
+	contest := deletedContest(model.ContestStatusActive, time.Hour)
+	contest.PreDeleteStatus = nil
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetDeletedByID(mock.Anything, mock.Anything).Return(contest, nil)
+
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
+		RestoreContest(context.Background(), uuid.New(), "u")
+	assert.ErrorIs(t, err, errs.ErrContestNotRestorable)
+}
+
+func TestRestoreContest_NameTaken(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetDeletedByID(mock.Anything, mock.Anything).Return(deletedContest(model.ContestStatusActive, time.Hour), nil)
+	repo.EXPECT().ExistsByOwnerAndName(mock.Anything, "u", "c").Return(true, nil)
+
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
+		RestoreContest(context.Background(), uuid.New(), "u")
+	assert.ErrorIs(t, err, errs.ErrContestAlreadyExists)
+}
+
+func TestRestoreContest_IfMatchMismatch(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetDeletedByID(mock.Anything, mock.Anything).Return(deletedContest(model.ContestStatusActive, time.Hour), nil)
+
+	ctx := context.WithValue(context.Background(), model.IfMatchKey, 3)
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
+		RestoreContest(ctx, uuid.New(), "u")
+	assert.ErrorIs(t, err, errs.ErrContestVersionMismatch)
+}
+
+func TestPurgeDeletedContests(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().PurgeDeleted(mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		return time.Until(before) < -restoreWindow+time.Minute
+	})).Return(int64(2), nil)
+
+	purged, err := contestSvc(repo, mocks.NewParticipantRepository(t), mocks.NewParticipantService(t)).
+		PurgeDeletedContests(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+}
+
+func TestPurgeInviteEvents(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().PurgeInviteEvents(mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		return time.Until(before) < -eventRetention+time.Minute
+	})).Return(int64(4), nil)
+
+	purged, err := contestSvc(repo, mocks.NewParticipantRepository(t), mocks.NewParticipantService(t)).
+		PurgeInviteEvents(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), purged)
+}
+
+func TestPurgeDeletedContests_RepoError(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().PurgeDeleted(mock.Anything, mock.Anything).Return(0, errors.New("db"))
+
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), mocks.NewParticipantService(t)).
+		PurgeDeletedContests(context.Background())
+	assert.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
+}
+
+func TestArchiveFinishedContests(t *testing.T) {
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetDueForArchive(mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		return time.Until(before) < -archiveAfter+time.Minute
+	}), mock.Anything).Return(ids, nil)
+	repo.EXPECT().Archive(mock.Anything, ids[0]).Return(nil)
+	repo.EXPECT().Archive(mock.Anything, ids[1]).Return(errors.New("db"))
+	repo.EXPECT().Archive(mock.Anything, ids[2]).Return(nil)
+
+	// a failed contest is skipped, not fatal to the batch
+	archived, err := contestSvc(repo, mocks.NewParticipantRepository(t), mocks.NewParticipantService(t)).
+		ArchiveFinishedContests(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, archived)
+}
+
+func TestArchiveFinishedContests_RepoError(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetDueForArchive(mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db"))
+
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), mocks.NewParticipantService(t)).
+		ArchiveFinishedContests(context.Background())
+	assert.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
+}
+
 func TestClaimSquare_NotActive(t *testing.T) {
 	repo := mocks.NewContestRepository(t)
 	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusQ1}, nil)
@@ -385,13 +839,13 @@ func TestClaimSquare_NotParticipant(t *testing.T) {
 	squareID := uuid.New()
 	repo := mocks.NewContestRepository(t)
 	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive, Squares: []model.Square{{ID: squareID}}}, nil)
+	repo.EXPECT().ClaimSquare(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errs.ErrNotParticipant)
 	pSvc := mocks.NewParticipantService(t)
 	pSvc.EXPECT().Authorize(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
-	pRepo := mocks.NewParticipantRepository(t)
-	pRepo.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
 
-	_, err := contestSvc(repo, pRepo, pSvc).
-		ClaimSquare(context.Background(), uuid.New(), squareID, "u")
+	ctx := context.WithValue(context.Background(), model.ClaimsKey, &model.Claims{Name: "N"})
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), pSvc).
+		ClaimSquare(ctx, uuid.New(), squareID, "u")
 	assert.ErrorIs(t, err, errs.ErrNotParticipant)
 }
 
@@ -399,28 +853,39 @@ func TestClaimSquare_LimitReached(t *testing.T) {
 	squareID := uuid.New()
 	repo := mocks.NewContestRepository(t)
 	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive, Squares: []model.Square{{ID: squareID}}}, nil)
+	repo.EXPECT().ClaimSquare(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errs.ErrSquareLimitReached)
 	pSvc := mocks.NewParticipantService(t)
 	pSvc.EXPECT().Authorize(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
-	pRepo := mocks.NewParticipantRepository(t)
-	pRepo.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, mock.Anything).Return(&model.ContestParticipant{MaxSquares: 2}, nil)
-	pRepo.EXPECT().CountSquaresByUser(mock.Anything, mock.Anything, mock.Anything).Return(2, nil)
 
-	_, err := contestSvc(repo, pRepo, pSvc).
-		ClaimSquare(context.Background(), uuid.New(), squareID, "u")
+	ctx := context.WithValue(context.Background(), model.ClaimsKey, &model.Claims{Name: "N"})
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), pSvc).
+		ClaimSquare(ctx, uuid.New(), squareID, "u")
 	assert.ErrorIs(t, err, errs.ErrSquareLimitReached)
 }
 
+func TestClaimSquare_LostRace(t *testing.T) {
+	squareID := uuid.New()
+	repo := mocks.NewContestRepository(t)
+	// the square looked free when loaded, but another claim committed first
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive, Squares: []model.Square{{ID: squareID}}}, nil)
+	repo.EXPECT().ClaimSquare(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errs.ErrSquareAlreadyClaimed)
+	pSvc := mocks.NewParticipantService(t)
+	pSvc.EXPECT().Authorize(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ctx := context.WithValue(context.Background(), model.ClaimsKey, &model.Claims{Name: "N"})
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), pSvc).
+		ClaimSquare(ctx, uuid.New(), squareID, "u")
+	assert.ErrorIs(t, err, errs.ErrSquareAlreadyClaimed)
+}
+
 func TestClaimSquare_ClaimsNotFound(t *testing.T) {
 	squareID := uuid.New()
 	repo := mocks.NewContestRepository(t)
 	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive, Squares: []model.Square{{ID: squareID}}}, nil)
 	pSvc := mocks.NewParticipantService(t)
 	pSvc.EXPECT().Authorize(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
-	pRepo := mocks.NewParticipantRepository(t)
-	pRepo.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, mock.Anything).Return(&model.ContestParticipant{MaxSquares: 5}, nil)
-	pRepo.EXPECT().CountSquaresByUser(mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
 
-	_, err := contestSvc(repo, pRepo, pSvc).
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), pSvc).
 		ClaimSquare(context.Background(), uuid.New(), squareID, "u")
 	assert.ErrorIs(t, err, errs.ErrClaimsNotFound)
 }
@@ -433,33 +898,40 @@ func TestClaimSquare_Success(t *testing.T) {
 		Return(&model.Square{ID: squareID, Value: "AB", Owner: "u", OwnerName: "Display Name"}, nil)
 	pSvc := mocks.NewParticipantService(t)
 	pSvc.EXPECT().Authorize(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
-	pRepo := mocks.NewParticipantRepository(t)
-	pRepo.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, mock.Anything).Return(&model.ContestParticipant{MaxSquares: 5}, nil)
-	pRepo.EXPECT().CountSquaresByUser(mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
 
 	ctx := context.WithValue(context.Background(), model.ClaimsKey, &model.Claims{Name: "Display Name"})
-	got, err := contestSvc(repo, pRepo, pSvc).
+	got, err := contestSvc(repo, mocks.NewParticipantRepository(t), pSvc).
 		ClaimSquare(ctx, uuid.New(), squareID, "u")
 	require.NoError(t, err)
 	assert.Equal(t, "AB", got.Value)
 	assert.Equal(t, "Display Name", got.OwnerName)
 }
 
+func TestClaimSquare_IfMatchMismatch(t *testing.T) {
+	squareID := uuid.New()
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).
+		Return(&model.Contest{Status: model.ContestStatusActive, Version: 9, Squares: []model.Square{{ID: squareID, Version: 2}}}, nil)
+
+	// single-square writes compare against the square's version, not the contest's
+	ctx := context.WithValue(context.Background(), model.IfMatchKey, 9)
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
+		ClaimSquare(ctx, uuid.New(), squareID, "u")
+	assert.ErrorIs(t, err, errs.ErrSquareVersionMismatch)
+}
+
 func TestClaimSquare_MissingInitials(t *testing.T) {
 	squareID := uuid.New()
 	repo := mocks.NewContestRepository(t)
 	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive, Squares: []model.Square{{ID: squareID}}}, nil)
 	pSvc := mocks.NewParticipantService(t)
 	pSvc.EXPECT().Authorize(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
-	pRepo := mocks.NewParticipantRepository(t)
-	pRepo.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, mock.Anything).Return(&model.ContestParticipant{MaxSquares: 5}, nil)
-	pRepo.EXPECT().CountSquaresByUser(mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
 
 	// user profile exists but has no default initials set
 	userRepo := &mocks.UserRepository{}
 	userRepo.On("GetOrCreate", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 		Return(&model.User{Email: "u", DefaultInitials: ""}, nil).Maybe()
-	svc := service.NewContestService(repo, pRepo, &mocks.GameRepository{}, userRepo, anyNats(), pSvc)
+	svc := service.NewContestService(repo, mocks.NewParticipantRepository(t), &mocks.GameRepository{}, userRepo, &mocks.OrganizationRepository{}, anyNats(), pSvc, anyAnalytics(), lifecycleCfg)
 
 	ctx := context.WithValue(context.Background(), model.ClaimsKey, &model.Claims{Name: "Display Name"})
 	_, err := svc.ClaimSquare(ctx, uuid.New(), squareID, "u")
@@ -599,18 +1071,29 @@ func TestRecordQuarterResult_BadYLabels(t *testing.T) {
 	assert.Error(t, err)
 }
 
-func TestRecordQuarterResult_TransitionError(t *testing.T) {
+func TestRecordQuarterResult_VersionConflict(t *testing.T) {
 	repo := mocks.NewContestRepository(t)
 	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{
 		Status: model.ContestStatusQ1, XLabels: orderedLabels(t), YLabels: orderedLabels(t),
 		Squares: []model.Square{{Row: 3, Col: 7, Owner: "w"}},
 	}, nil)
-	repo.EXPECT().CreateQuarterResult(mock.Anything, mock.Anything).Return(nil)
-	repo.EXPECT().Update(mock.Anything, mock.Anything).Return(errors.New("db"))
+	repo.EXPECT().RecordQuarterResult(mock.Anything, mock.Anything, mock.Anything).Return(errs.ErrContestVersionConflict)
 
 	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
 		RecordQuarterResult(context.Background(), uuid.New(), 17, 23, "u")
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, errs.ErrContestVersionConflict)
+}
+
+func TestRecordQuarterResult_IfMatchMismatch(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{
+		Status: model.ContestStatusQ1, Version: 4, XLabels: orderedLabels(t), YLabels: orderedLabels(t),
+	}, nil)
+
+	ctx := context.WithValue(context.Background(), model.IfMatchKey, 3)
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
+		RecordQuarterResult(ctx, uuid.New(), 17, 23, "u")
+	assert.ErrorIs(t, err, errs.ErrContestVersionMismatch)
 }
 
 func TestRecordQuarterResult_CreateError(t *testing.T) {
@@ -619,7 +1102,7 @@ func TestRecordQuarterResult_CreateError(t *testing.T) {
 		Status: model.ContestStatusQ1, XLabels: orderedLabels(t), YLabels: orderedLabels(t),
 		Squares: []model.Square{{Row: 3, Col: 7, Owner: "w"}},
 	}, nil)
-	repo.EXPECT().CreateQuarterResult(mock.Anything, mock.Anything).Return(errors.New("db"))
+	repo.EXPECT().RecordQuarterResult(mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db"))
 
 	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
 		RecordQuarterResult(context.Background(), uuid.New(), 17, 23, "u")
@@ -644,13 +1127,10 @@ func TestClaimSquare_RepoError(t *testing.T) {
 	repo.EXPECT().ClaimSquare(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db"))
 	pSvc := mocks.NewParticipantService(t)
 	pSvc.EXPECT().Authorize(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
-	pRepo := mocks.NewParticipantRepository(t)
-	pRepo.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, mock.Anything).Return(&model.ContestParticipant{MaxSquares: 5}, nil)
-	pRepo.EXPECT().CountSquaresByUser(mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
 
 	ctx := context.WithValue(context.Background(), model.ClaimsKey, &model.Claims{Name: "N"})
-	_, err := contestSvc(repo, pRepo, pSvc).ClaimSquare(ctx, uuid.New(), squareID, "u")
-	assert.Error(t, err)
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), pSvc).ClaimSquare(ctx, uuid.New(), squareID, "u")
+	assert.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
 }
 
 func TestClaimSquare_ReEditOwnSquare(t *testing.T) {
@@ -660,13 +1140,9 @@ func TestClaimSquare_ReEditOwnSquare(t *testing.T) {
 	repo.EXPECT().ClaimSquare(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&model.Square{ID: squareID, Value: "XY", Owner: "u"}, nil)
 	pSvc := mocks.NewParticipantService(t)
 	pSvc.EXPECT().Authorize(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
-	pRepo := mocks.NewParticipantRepository(t)
-
-	// already owns the square -> no square-limit count check
-	pRepo.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, mock.Anything).Return(&model.ContestParticipant{MaxSquares: 5}, nil)
 
 	ctx := context.WithValue(context.Background(), model.ClaimsKey, &model.Claims{Name: "N"})
-	got, err := contestSvc(repo, pRepo, pSvc).ClaimSquare(ctx, uuid.New(), squareID, "u")
+	got, err := contestSvc(repo, mocks.NewParticipantRepository(t), pSvc).ClaimSquare(ctx, uuid.New(), squareID, "u")
 	require.NoError(t, err)
 	assert.Equal(t, "XY", got.Value)
 }
@@ -701,7 +1177,7 @@ func TestClaimSquare_OwnedByAnotherUser(t *testing.T) {
 
 	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), pSvc).
 		ClaimSquare(context.Background(), uuid.New(), squareID, "u")
-	assert.ErrorIs(t, err, errs.ErrUnauthorizedSquareEdit)
+	assert.ErrorIs(t, err, errs.ErrSquareAlreadyClaimed)
 }
 
 func TestClearSquare_OwnerDespiteAuthFail(t *testing.T) {
@@ -851,3 +1327,344 @@ func TestRollbackLastQuarterResult_Unauthorized(t *testing.T) {
 		RollbackLastQuarterResult(context.Background(), uuid.New(), "u")
 	assert.ErrorIs(t, err, errs.ErrUnauthorizedContestEdit)
 }
+
+// ====================
+// Scheduled Lock
+// ====================
+
+func TestCreateContest_LockInPast(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().ExistsByOwnerAndName(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+
+	past := time.Now().Add(-time.Minute)
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), mocks.NewParticipantService(t)).
+		CreateContest(context.Background(), &model.CreateContestRequest{Owner: "o", Name: "n", LockAt: &past}, "o")
+	assert.ErrorIs(t, err, errs.ErrInvalidLockTime)
+}
+
+func TestCreateContest_WithLock(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().ExistsByOwnerAndName(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	repo.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	lockAt := time.Now().Add(time.Hour)
+	got, err := contestSvc(repo, mocks.NewParticipantRepository(t), mocks.NewParticipantService(t)).
+		CreateContest(context.Background(), &model.CreateContestRequest{Owner: "o", Name: "n", LockAt: &lockAt, FillPolicy: "random"}, "o")
+	require.NoError(t, err)
+	assert.Equal(t, &lockAt, got.LockAt)
+	assert.Equal(t, model.FillPolicyRandom, got.FillPolicy)
+	assert.Equal(t, model.PaymentPolicyNone, got.PaymentPolicy)
+}
+
+func TestUpdateContest_ScheduleLock(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
+	repo.EXPECT().Update(mock.Anything, mock.Anything).Return(nil)
+
+	lockAt := time.Now().Add(time.Hour)
+	policy := "house"
+	got, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
+		UpdateContest(context.Background(), uuid.New(), &model.UpdateContestRequest{LockAt: &lockAt, FillPolicy: &policy}, "u")
+	require.NoError(t, err)
+	assert.Equal(t, &lockAt, got.LockAt)
+	assert.Equal(t, model.FillPolicyHouse, got.FillPolicy)
+}
+
+func TestUpdateContest_Rollover(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
+	repo.EXPECT().Update(mock.Anything, mock.Anything).Return(nil)
+
+	rollover := true
+	got, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
+		UpdateContest(context.Background(), uuid.New(), &model.UpdateContestRequest{Rollover: &rollover}, "u")
+	require.NoError(t, err)
+	assert.True(t, got.Rollover)
+}
+
+func TestUpdateContest_PaymentPolicy(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive, PaymentPolicy: model.PaymentPolicyNone}, nil)
+	repo.EXPECT().Update(mock.Anything, mock.Anything).Return(nil)
+
+	policy := "clear_unpaid"
+	got, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
+		UpdateContest(context.Background(), uuid.New(), &model.UpdateContestRequest{PaymentPolicy: &policy}, "u")
+	require.NoError(t, err)
+	assert.Equal(t, model.PaymentPolicyClearUnpaid, got.PaymentPolicy)
+}
+
+func TestUpdateContest_RolloverAfterStart(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusQ1}, nil)
+
+	rollover := true
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
+		UpdateContest(context.Background(), uuid.New(), &model.UpdateContestRequest{Rollover: &rollover}, "u")
+	assert.ErrorIs(t, err, errs.ErrContestNotEditable)
+}
+
+func TestUpdateContest_ClearLock(t *testing.T) {
+	lockAt := time.Now().Add(time.Hour)
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive, LockAt: &lockAt}, nil)
+	repo.EXPECT().Update(mock.Anything, mock.Anything).Return(nil)
+
+	got, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
+		UpdateContest(context.Background(), uuid.New(), &model.UpdateContestRequest{ClearLockAt: true, LockAt: &lockAt}, "u")
+	require.NoError(t, err)
+	assert.Nil(t, got.LockAt)
+}
+
+func TestUpdateContest_LockInPast(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
+
+	past := time.Now().Add(-time.Minute)
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
+		UpdateContest(context.Background(), uuid.New(), &model.UpdateContestRequest{LockAt: &past}, "u")
+	assert.ErrorIs(t, err, errs.ErrInvalidLockTime)
+}
+
+func TestUpdateContest_LockAfterStart(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusQ2}, nil)
+
+	lockAt := time.Now().Add(time.Hour)
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
+		UpdateContest(context.Background(), uuid.New(), &model.UpdateContestRequest{LockAt: &lockAt}, "u")
+	assert.ErrorIs(t, err, errs.ErrContestNotEditable)
+}
+
+func lockableContest(owners ...string) model.Contest {
+	xLabels, yLabels := util.InitialLabels()
+	lockAt := time.Now().Add(-time.Minute)
+	c := model.Contest{ID: uuid.New(), Status: model.ContestStatusActive, XLabels: xLabels, YLabels: yLabels, LockAt: &lockAt}
+	for i, owner := range owners {
+		c.Squares = append(c.Squares, model.Square{ID: uuid.New(), Row: 0, Col: i, Owner: owner})
+	}
+	return c
+}
+
+func TestLockDueContests_StartsFullContest(t *testing.T) {
+	contest := lockableContest("alice", "bob")
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetDueForLock(mock.Anything, mock.Anything).Return([]model.Contest{contest}, nil)
+	repo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(c *model.Contest) bool {
+		return c.Status == model.ContestStatusQ1 && c.LockAt == nil
+	})).Return(nil)
+
+	started, err := contestSvc(repo, mocks.NewParticipantRepository(t), mocks.NewParticipantService(t)).
+		LockDueContests(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, started)
+}
+
+func TestLockDueContests_NotReadyClearsSchedule(t *testing.T) {
+	contest := lockableContest("alice", "")
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetDueForLock(mock.Anything, mock.Anything).Return([]model.Contest{contest}, nil)
+	repo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(c *model.Contest) bool {
+		return c.Status == model.ContestStatusActive && c.LockAt == nil
+	})).Return(nil)
+
+	started, err := contestSvc(repo, mocks.NewParticipantRepository(t), mocks.NewParticipantService(t)).
+		LockDueContests(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, started)
+}
+
+func TestLockDueContests_RandomFill(t *testing.T) {
+	contest := lockableContest("alice", "", "")
+	contest.FillPolicy = model.FillPolicyRandom
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetDueForLock(mock.Anything, mock.Anything).Return([]model.Contest{contest}, nil)
+	repo.EXPECT().StartWithSquares(mock.Anything, mock.Anything, mock.Anything, mock.MatchedBy(func(sqs []model.Square) bool {
+		return len(sqs) == 2 && sqs[0].Owner == "bob@x.com" && sqs[0].Value == "BO"
+	})).Return(nil)
+	pRepo := mocks.NewParticipantRepository(t)
+	pRepo.EXPECT().GetAllByContestID(mock.Anything, contest.ID).Return([]model.ContestParticipant{
+		{UserID: "alice", Role: model.ParticipantRoleOwner, MaxSquares: 1},
+		{UserID: "bob@x.com", Role: model.ParticipantRoleParticipant, MaxSquares: 2},
+	}, nil)
+	userRepo := mocks.NewUserRepository(t)
+	userRepo.EXPECT().GetByEmail(mock.Anything, "bob@x.com").Return(&model.User{Email: "bob@x.com", DefaultInitials: "BO", DisplayName: "Bob"}, nil).Once()
+
+	started, err := service.NewContestService(repo, pRepo, &mocks.GameRepository{}, userRepo, &mocks.OrganizationRepository{}, anyNats(), mocks.NewParticipantService(t), anyAnalytics(), lifecycleCfg).
+		LockDueContests(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, started)
+}
+
+func TestLockDueContests_RandomFillMissingProfile(t *testing.T) {
+	contest := lockableContest("")
+	contest.FillPolicy = model.FillPolicyRandom
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetDueForLock(mock.Anything, mock.Anything).Return([]model.Contest{contest}, nil)
+	repo.EXPECT().StartWithSquares(mock.Anything, mock.Anything, mock.Anything, mock.MatchedBy(func(sqs []model.Square) bool {
+		return len(sqs) == 1 && sqs[0].Value == "C"
+	})).Return(nil)
+	pRepo := mocks.NewParticipantRepository(t)
+	pRepo.EXPECT().GetAllByContestID(mock.Anything, contest.ID).Return([]model.ContestParticipant{
+		{UserID: "carol@x.com", Role: model.ParticipantRoleParticipant, MaxSquares: 1},
+	}, nil)
+	userRepo := mocks.NewUserRepository(t)
+	userRepo.EXPECT().GetByEmail(mock.Anything, "carol@x.com").Return(nil, gorm.ErrRecordNotFound)
+
+	started, err := service.NewContestService(repo, pRepo, &mocks.GameRepository{}, userRepo, &mocks.OrganizationRepository{}, anyNats(), mocks.NewParticipantService(t), anyAnalytics(), lifecycleCfg).
+		LockDueContests(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, started)
+}
+
+func TestLockDueContests_ContinuesPastFailures(t *testing.T) {
+	failing := lockableContest("alice")
+	ok := lockableContest("bob")
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetDueForLock(mock.Anything, mock.Anything).Return([]model.Contest{failing, ok}, nil)
+	repo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(c *model.Contest) bool { return c.ID == failing.ID })).Return(errors.New("db"))
+	repo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(c *model.Contest) bool { return c.ID == ok.ID })).Return(nil)
+
+	started, err := contestSvc(repo, mocks.NewParticipantRepository(t), mocks.NewParticipantService(t)).
+		LockDueContests(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, started)
+}
+
+func TestLockDueContests_QueryError(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetDueForLock(mock.Anything, mock.Anything).Return(nil, errors.New("db"))
+
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), mocks.NewParticipantService(t)).
+		LockDueContests(context.Background())
+	assert.Error(t, err)
+}
+
+func TestClaimSquares_NotActive(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusQ1}, nil)
+
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), mocks.NewParticipantService(t)).
+		ClaimSquares(context.Background(), uuid.New(), []uuid.UUID{uuid.New()}, "u")
+	assert.ErrorIs(t, err, errs.ErrSquareNotEditable)
+}
+
+func TestClaimSquares_SquareNotFound(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).
+		Return(&model.Contest{Status: model.ContestStatusActive, Squares: []model.Square{{ID: uuid.New()}}}, nil)
+
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
+		ClaimSquares(context.Background(), uuid.New(), []uuid.UUID{uuid.New()}, "u")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestClaimSquares_OwnedByAnotherUser(t *testing.T) {
+	free, taken := uuid.New(), uuid.New()
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{
+		Status:  model.ContestStatusActive,
+		Squares: []model.Square{{ID: free}, {ID: taken, Owner: "other"}},
+	}, nil)
+
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
+		ClaimSquares(context.Background(), uuid.New(), []uuid.UUID{free, taken}, "u")
+	assert.ErrorIs(t, err, errs.ErrSquareAlreadyClaimed)
+}
+
+func TestClaimSquares_LimitReached(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).
+		Return(&model.Contest{Status: model.ContestStatusActive, Squares: []model.Square{{ID: a}, {ID: b}}}, nil)
+	repo.EXPECT().ClaimSquares(mock.Anything, mock.Anything, mock.Anything, "AB", "u", mock.Anything).
+		Return(nil, errs.ErrSquareLimitReached)
+
+	ctx := context.WithValue(context.Background(), model.ClaimsKey, &model.Claims{Name: "Display Name"})
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
+		ClaimSquares(ctx, uuid.New(), []uuid.UUID{a, b}, "u")
+	assert.ErrorIs(t, err, errs.ErrSquareLimitReached)
+}
+
+func TestClaimSquares_Success(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).
+		Return(&model.Contest{Status: model.ContestStatusActive, Squares: []model.Square{{ID: a}, {ID: b, Owner: "u"}}}, nil)
+	// duplicates collapse into a single claim per square
+	repo.EXPECT().ClaimSquares(mock.Anything, mock.Anything, []uuid.UUID{a, b}, "AB", "u", "Display Name").
+		Return([]model.Square{{ID: a, Owner: "u", Value: "AB"}, {ID: b, Owner: "u", Value: "AB"}}, nil)
+
+	ctx := context.WithValue(context.Background(), model.ClaimsKey, &model.Claims{Name: "Display Name"})
+	got, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
+		ClaimSquares(ctx, uuid.New(), []uuid.UUID{a, b, a}, "u")
+	require.NoError(t, err)
+	assert.Len(t, got, 2)
+}
+
+func TestClaimSquares_IfMatchMismatch(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).
+		Return(&model.Contest{Status: model.ContestStatusActive, Version: 5, Squares: []model.Square{{ID: uuid.New()}}}, nil)
+
+	ctx := context.WithValue(context.Background(), model.IfMatchKey, 4)
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), okAuth(t)).
+		ClaimSquares(ctx, uuid.New(), []uuid.UUID{uuid.New()}, "u")
+	assert.ErrorIs(t, err, errs.ErrContestVersionMismatch)
+}
+
+func TestAssignSquare_Unauthorized(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
+	pSvc := mocks.NewParticipantService(t)
+	pSvc.EXPECT().Authorize(mock.Anything, mock.Anything, "u", service.ActionEditContest).Return(errs.ErrInsufficientRole)
+
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), pSvc).
+		AssignSquare(context.Background(), uuid.New(), uuid.New(), &model.AssignSquareRequest{UserID: "p"}, "u")
+	assert.ErrorIs(t, err, errs.ErrUnauthorizedContestEdit)
+}
+
+func TestAssignSquare_Viewer(t *testing.T) {
+	squareID := uuid.New()
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).
+		Return(&model.Contest{Status: model.ContestStatusActive, Squares: []model.Square{{ID: squareID}}}, nil)
+	pRepo := mocks.NewParticipantRepository(t)
+	pRepo.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, "p").
+		Return(&model.ContestParticipant{Role: model.ParticipantRoleViewer}, nil)
+
+	_, err := contestSvc(repo, pRepo, okAuth(t)).
+		AssignSquare(context.Background(), uuid.New(), squareID, &model.AssignSquareRequest{UserID: "p"}, "u")
+	assert.ErrorIs(t, err, errs.ErrViewerCannotHaveSquares)
+}
+
+func TestAssignSquare_Success(t *testing.T) {
+	squareID := uuid.New()
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).
+		Return(&model.Contest{Status: model.ContestStatusActive, Squares: []model.Square{{ID: squareID, Owner: "old"}}}, nil)
+	repo.EXPECT().AssignSquare(mock.Anything, mock.Anything, "AB", "p", mock.Anything).
+		Return(&model.Square{ID: squareID, Owner: "p", Value: "AB"}, nil)
+	pRepo := mocks.NewParticipantRepository(t)
+	pRepo.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, "p").
+		Return(&model.ContestParticipant{Role: model.ParticipantRoleParticipant, MaxSquares: 10}, nil)
+
+	got, err := contestSvc(repo, pRepo, okAuth(t)).
+		AssignSquare(context.Background(), uuid.New(), squareID, &model.AssignSquareRequest{UserID: "p", Override: true}, "u")
+	require.NoError(t, err)
+	assert.Equal(t, "p", got.Owner)
+}
+
+func TestAssignSquare_HeldWithoutOverride(t *testing.T) {
+	squareID := uuid.New()
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).
+		Return(&model.Contest{Status: model.ContestStatusActive, Squares: []model.Square{{ID: squareID, Owner: "old"}}}, nil)
+	pRepo := mocks.NewParticipantRepository(t)
+	pRepo.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, "p").
+		Return(&model.ContestParticipant{Role: model.ParticipantRoleParticipant, MaxSquares: 10}, nil)
+
+	_, err := contestSvc(repo, pRepo, okAuth(t)).
+		AssignSquare(context.Background(), uuid.New(), squareID, &model.AssignSquareRequest{UserID: "p"}, "u")
+	assert.ErrorIs(t, err, errs.ErrSquareAlreadyClaimed)
+}
@@ -0,0 +1,375 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/metrics"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/repository"
+	"github.com/maxmorhardt/squares-api/internal/util"
+	"gorm.io/gorm"
+)
+
+type ExportService interface {
+	ExportContest(ctx context.Context, contestID uuid.UUID, user string) (*model.ContestExport, error)
+	ImportContest(ctx context.Context, doc *model.ContestExport, user string) (*model.Contest, error)
+}
+
+type exportService struct {
+	contestRepo        repository.ContestRepository
+	participantRepo    repository.ParticipantRepository
+	inviteRepo         repository.InviteRepository
+	participantService ParticipantService
+}
+
+func NewExportService(
+	contestRepo repository.ContestRepository,
+	participantRepo repository.ParticipantRepository,
+	inviteRepo repository.InviteRepository,
+	participantService ParticipantService,
+) ExportService {
+	return &exportService{
+		contestRepo:        contestRepo,
+		participantRepo:    participantRepo,
+		inviteRepo:         inviteRepo,
+		participantService: participantService,
+	}
+}
+
+func (s *exportService) ExportContest(ctx context.Context, contestID uuid.UUID, user string) (*model.ContestExport, error) {
+	log := util.LoggerFromContext(ctx)
+
+	contest, err := s.contestRepo.GetByID(ctx, contestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		log.Error("failed to get contest for export", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	// the document lists every participant's email and the invite settings, so it's owner-only
+	if err := s.participantService.Authorize(ctx, contestID, user, ActionEditContest); err != nil {
+		if errors.Is(err, errs.ErrDatabaseUnavailable) {
+			return nil, err
+		}
+		log.Warn("unauthorized export attempt", "contest_id", contestID, "user", user)
+		return nil, errs.ErrUnauthorizedContestExport
+	}
+
+	// game-linked contests only have results through the game, and the import won't carry the link
+	util.SynthesizeFromGame(contest)
+
+	participants, err := s.participantRepo.GetAllByContestID(ctx, contestID)
+	if err != nil {
+		log.Error("failed to get participants for export", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	invites, err := s.inviteRepo.GetAllByContestID(ctx, contestID)
+	if err != nil {
+		log.Error("failed to get invites for export", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	doc, err := buildExport(contest, participants, invites)
+	if err != nil {
+		log.Error("failed to build contest export", "contest_id", contestID, "error", err)
+		return nil, err
+	}
+
+	log.Info("exported contest", "contest_id", contestID, "squares", len(doc.Squares), "participants", len(doc.Participants))
+	return doc, nil
+}
+
+func (s *exportService) ImportContest(ctx context.Context, doc *model.ContestExport, user string) (*model.Contest, error) {
+	log := util.LoggerFromContext(ctx)
+
+	if doc.SchemaVersion != model.ContestExportSchemaVersion {
+		log.Warn("unsupported export schema version", "schema_version", doc.SchemaVersion)
+		return nil, errs.ErrUnsupportedExportVersion
+	}
+
+	if err := validateExport(doc, user); err != nil {
+		log.Warn("inconsistent export document", "error", err)
+		return nil, err
+	}
+
+	exists, err := s.contestRepo.ExistsByOwnerAndName(ctx, user, doc.Contest.Name)
+	if err != nil {
+		log.Error("failed to check if contest exists", "owner", user, "name", doc.Contest.Name, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+	if exists {
+		log.Warn("contest already exists", "owner", user, "name", doc.Contest.Name)
+		return nil, errs.ErrContestAlreadyExists
+	}
+
+	contest, owner, squares, participants := contestFromExport(doc, user)
+
+	results, err := rescoreResults(contest, squares, doc.QuarterResults)
+	if err != nil {
+		log.Warn("export results can't be rescored", "error", err)
+		return nil, err
+	}
+
+	if err := s.contestRepo.Import(ctx, contest, owner, squares, results, participants); err != nil {
+		log.Error("failed to import contest", "name", contest.Name, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	metrics.IncContestCreated()
+	metrics.IncParticipantJoined(string(model.ParticipantRoleOwner))
+	log.Info("imported contest", "contest_id", contest.ID, "source_contest_id", doc.Contest.ID, "owner", user)
+	return contest, nil
+}
+
+func buildExport(contest *model.Contest, participants []model.ContestParticipant, invites []model.ContestInvite) (*model.ContestExport, error) {
+	xLabels, yLabels, err := util.ParseLabels(contest)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &model.ContestExport{
+		SchemaVersion: model.ContestExportSchemaVersion,
+		ExportedAt:    time.Now(),
+		Contest: model.ExportedContest{
+			ID:            contest.ID,
+			Name:          contest.Name,
+			XLabels:       xLabels,
+			YLabels:       yLabels,
+			HomeTeam:      contest.HomeTeam,
+			AwayTeam:      contest.AwayTeam,
+			Owner:         contest.Owner,
+			Visibility:    string(contest.Visibility),
+			Status:        string(contest.Status),
+			LockAt:        contest.LockAt,
+			FillPolicy:    string(contest.FillPolicy),
+			PaymentPolicy: string(contest.PaymentPolicy),
+			Rollover:      contest.Rollover,
+		},
+		Squares:        make([]model.ExportedSquare, 0, len(contest.Squares)),
+		QuarterResults: make([]model.ExportedQuarterResult, 0, len(contest.QuarterResults)),
+		Participants:   make([]model.ExportedParticipant, 0, len(participants)),
+		Invites:        make([]model.ExportedInvite, 0, len(invites)),
+	}
+
+	for _, sq := range contest.Squares {
+		doc.Squares = append(doc.Squares, model.ExportedSquare{
+			Row:       sq.Row,
+			Col:       sq.Col,
+			Value:     sq.Value,
+			Owner:     sq.Owner,
+			OwnerName: sq.OwnerName,
+		})
+	}
+
+	for _, r := range contest.QuarterResults {
+		doc.QuarterResults = append(doc.QuarterResults, model.ExportedQuarterResult{
+			Quarter:       r.Quarter,
+			HomeTeamScore: r.HomeTeamScore,
+			AwayTeamScore: r.AwayTeamScore,
+			WinnerRow:     r.WinnerRow,
+			WinnerCol:     r.WinnerCol,
+			Winner:        r.Winner,
+			WinnerName:    r.WinnerName,
+			RolledOver:    r.RolledOver,
+			CarriedOver:   r.CarriedOver,
+			Fallback:      r.Fallback,
+		})
+	}
+
+	for _, p := range participants {
+		doc.Participants = append(doc.Participants, model.ExportedParticipant{
+			UserID:          p.UserID,
+			Role:            string(p.Role),
+			MaxSquares:      p.MaxSquares,
+			Paid:            p.Paid,
+			AmountPaidCents: p.AmountPaidCents,
+			PaidAt:          p.PaidAt,
+			JoinedAt:        p.JoinedAt,
+		})
+	}
+
+	for _, i := range invites {
+		doc.Invites = append(doc.Invites, model.ExportedInvite{
+			Role:           string(i.Role),
+			MaxSquares:     i.MaxSquares,
+			MaxUses:        i.MaxUses,
+			Uses:           i.Uses,
+			ExpiresAt:      i.ExpiresAt,
+			Paused:         i.Paused,
+			AllowedDomains: i.AllowedDomains,
+			AllowedEmails:  i.AllowedEmails,
+			CreatedBy:      i.CreatedBy,
+			CreatedAt:      i.CreatedAt,
+		})
+	}
+
+	return doc, nil
+}
+
+// binding covers field ranges; this catches documents whose parts don't agree with each other
+func validateExport(doc *model.ContestExport, user string) error {
+	// squares can only belong to someone the document brings along, and the limits must fit the grid
+	members := map[string]bool{"": true, user: true, model.GhostUser: true, model.HouseUser: true}
+	limits := make(map[string]int, len(doc.Participants))
+	for _, p := range doc.Participants {
+		members[p.UserID] = true
+		if _, ok := limits[p.UserID]; !ok || p.UserID == user {
+			limits[p.UserID] = p.MaxSquares
+		}
+	}
+
+	// same as the import: a repeated participant keeps their first limit, the importer their last
+	total := 0
+	for _, limit := range limits {
+		total += limit
+	}
+	if total > 100 {
+		return errs.ErrInvalidContestExport
+	}
+
+	seen := make(map[[2]int]bool, len(doc.Squares))
+	for _, sq := range doc.Squares {
+		pos := [2]int{sq.Row, sq.Col}
+		if seen[pos] || !members[sq.Owner] {
+			return errs.ErrInvalidContestExport
+		}
+		seen[pos] = true
+	}
+
+	quarters := make(map[int]bool, len(doc.QuarterResults))
+	for _, r := range doc.QuarterResults {
+		if quarters[r.Quarter] {
+			return errs.ErrInvalidContestExport
+		}
+		quarters[r.Quarter] = true
+	}
+
+	// a started contest has its labels drawn; one that hasn't started can't have results
+	status := model.ContestStatus(doc.Contest.Status)
+	if status != model.ContestStatusActive {
+		if !drawnLabels(doc.Contest.XLabels) || !drawnLabels(doc.Contest.YLabels) {
+			return errs.ErrInvalidContestExport
+		}
+	} else if len(doc.QuarterResults) > 0 {
+		return errs.ErrInvalidContestExport
+	}
+
+	return nil
+}
+
+func drawnLabels(labels []int8) bool {
+	var seen [10]bool
+	for _, l := range labels {
+		if l < 0 || l > 9 || seen[l] {
+			return false
+		}
+		seen[l] = true
+	}
+	return len(labels) == 10
+}
+
+func contestFromExport(doc *model.ContestExport, user string) (*model.Contest, *model.ContestParticipant, []model.Square, []model.ContestParticipant) {
+	// binding limited both to ten int8s, so these can't fail
+	xLabels, _ := json.Marshal(doc.Contest.XLabels)
+	yLabels, _ := json.Marshal(doc.Contest.YLabels)
+
+	now := time.Now()
+	contest := &model.Contest{
+		Name:          doc.Contest.Name,
+		XLabels:       xLabels,
+		YLabels:       yLabels,
+		HomeTeam:      doc.Contest.HomeTeam,
+		AwayTeam:      doc.Contest.AwayTeam,
+		Owner:         user,
+		Visibility:    model.ContestVisibilityPrivate,
+		Status:        model.ContestStatus(doc.Contest.Status),
+		FillPolicy:    model.FillPolicyNone,
+		PaymentPolicy: model.PaymentPolicyNone,
+		Rollover:      doc.Contest.Rollover,
+		ImportedAt:    &now,
+	}
+	if doc.Contest.Visibility != "" {
+		contest.Visibility = model.ContestVisibility(doc.Contest.Visibility)
+	}
+	if doc.Contest.FillPolicy != "" {
+		contest.FillPolicy = model.FillPolicy(doc.Contest.FillPolicy)
+	}
+	if doc.Contest.PaymentPolicy != "" {
+		contest.PaymentPolicy = model.PaymentPolicy(doc.Contest.PaymentPolicy)
+	}
+
+	// a lock that already passed would start the contest the moment it lands
+	if contest.Status == model.ContestStatusActive && doc.Contest.LockAt != nil && doc.Contest.LockAt.After(time.Now()) {
+		contest.LockAt = doc.Contest.LockAt
+	}
+
+	squares := make([]model.Square, 0, len(doc.Squares))
+	for _, sq := range doc.Squares {
+		if sq.Owner == "" && sq.Value == "" {
+			continue
+		}
+		squares = append(squares, model.Square{Row: sq.Row, Col: sq.Col, Value: sq.Value, Owner: sq.Owner, OwnerName: sq.OwnerName})
+	}
+
+	// the importer owns the copy; the original owner stays on as a regular participant
+	owner := &model.ContestParticipant{UserID: user, Role: model.ParticipantRoleOwner}
+	participants := make([]model.ContestParticipant, 0, len(doc.Participants))
+	seen := map[string]bool{user: true}
+	for _, p := range doc.Participants {
+		if p.UserID == user {
+			owner.MaxSquares = p.MaxSquares
+			continue
+		}
+		if seen[p.UserID] {
+			continue
+		}
+		seen[p.UserID] = true
+
+		role := model.ParticipantRole(p.Role)
+		if role == model.ParticipantRoleOwner {
+			role = model.ParticipantRoleParticipant
+		}
+		participants = append(participants, model.ContestParticipant{
+			UserID:          p.UserID,
+			Role:            role,
+			MaxSquares:      p.MaxSquares,
+			Paid:            p.Paid,
+			AmountPaidCents: p.AmountPaidCents,
+			PaidAt:          p.PaidAt,
+			JoinedAt:        p.JoinedAt,
+		})
+	}
+
+	return contest, owner, squares, participants
+}
+
+// the document's winners are only a claim; each quarter is scored again from its labels, scores and grid
+func rescoreResults(contest *model.Contest, squares []model.Square, exported []model.ExportedQuarterResult) ([]model.QuarterResult, error) {
+	scoring := *contest
+	scoring.Squares = squares
+
+	ordered := slices.SortedFunc(slices.Values(exported), func(a, b model.ExportedQuarterResult) int {
+		return a.Quarter - b.Quarter
+	})
+
+	results := make([]model.QuarterResult, 0, len(ordered))
+	for _, r := range ordered {
+		result, err := util.QuarterResultFor(&scoring, r.Quarter, r.HomeTeamScore, r.AwayTeamScore)
+		if err != nil {
+			return nil, errs.ErrInvalidContestExport
+		}
+		util.ApplyRollover(&scoring, result, results)
+		results = append(results, *result)
+	}
+
+	return results, nil
+}
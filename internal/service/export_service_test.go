@@ -0,0 +1,186 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/mocks"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func drawnLabels() []int8 {
+	return []int8{3, 1, 4, 0, 5, 9, 2, 6, 8, 7}
+}
+
+func exportDoc() *model.ContestExport {
+	return &model.ContestExport{
+		SchemaVersion: model.ContestExportSchemaVersion,
+		Contest: model.ExportedContest{
+			ID: uuid.New(), Name: "c", Owner: "old@b.com", Status: "Q2",
+			XLabels: drawnLabels(), YLabels: drawnLabels(),
+		},
+		Squares: []model.ExportedSquare{
+			{Row: 0, Col: 0, Value: "AB", Owner: "a@b.com", OwnerName: "A"},
+			{Row: 0, Col: 1},
+		},
+		QuarterResults: []model.ExportedQuarterResult{{Quarter: 1, HomeTeamScore: 7, Winner: "a@b.com"}},
+		Participants: []model.ExportedParticipant{
+			{UserID: "old@b.com", Role: "owner", MaxSquares: 10},
+			{UserID: "me@b.com", Role: "participant", MaxSquares: 5},
+			{UserID: "a@b.com", Role: "viewer"},
+		},
+	}
+}
+
+func TestExportContest_Success(t *testing.T) {
+	id := uuid.New()
+	contest := &model.Contest{ID: id, Name: "c", Status: model.ContestStatusQ1, XLabels: []byte(`[0,1,2,3,4,5,6,7,8,9]`), YLabels: []byte(`[0,1,2,3,4,5,6,7,8,9]`),
+		Squares: []model.Square{{Row: 1, Col: 2, Owner: "a@b.com"}}}
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, id).Return(contest, nil)
+	pRepo := mocks.NewParticipantRepository(t)
+	pRepo.EXPECT().GetAllByContestID(mock.Anything, id).Return([]model.ContestParticipant{{UserID: "u", Role: model.ParticipantRoleOwner}}, nil)
+	iRepo := mocks.NewInviteRepository(t)
+	iRepo.EXPECT().GetAllByContestID(mock.Anything, id).Return([]model.ContestInvite{{Token: "secret", Role: model.ParticipantRoleViewer, MaxUses: 3}}, nil)
+
+	doc, err := service.NewExportService(repo, pRepo, iRepo, okAuth(t)).ExportContest(context.Background(), id, "u")
+	require.NoError(t, err)
+	assert.Equal(t, model.ContestExportSchemaVersion, doc.SchemaVersion)
+	assert.Equal(t, []int8{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, doc.Contest.XLabels)
+	assert.Equal(t, []model.ExportedSquare{{Row: 1, Col: 2, Owner: "a@b.com"}}, doc.Squares)
+	require.Len(t, doc.Invites, 1)
+	assert.Equal(t, 3, doc.Invites[0].MaxUses)
+}
+
+func TestExportContest_NotOwner(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{}, nil)
+	pSvc := mocks.NewParticipantService(t)
+	pSvc.EXPECT().Authorize(mock.Anything, mock.Anything, "u", service.ActionEditContest).Return(errs.ErrInsufficientRole)
+
+	_, err := service.NewExportService(repo, mocks.NewParticipantRepository(t), mocks.NewInviteRepository(t), pSvc).
+		ExportContest(context.Background(), uuid.New(), "u")
+	assert.ErrorIs(t, err, errs.ErrUnauthorizedContestExport)
+}
+
+func TestExportContest_NotFound(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := service.NewExportService(repo, mocks.NewParticipantRepository(t), mocks.NewInviteRepository(t), mocks.NewParticipantService(t)).
+		ExportContest(context.Background(), uuid.New(), "u")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestImportContest_Success(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().ExistsByOwnerAndName(mock.Anything, "me@b.com", "c").Return(false, nil)
+	repo.EXPECT().Import(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, c *model.Contest, owner *model.ContestParticipant, squares []model.Square, results []model.QuarterResult, participants []model.ContestParticipant) error {
+			assert.Equal(t, "me@b.com", c.Owner)
+			assert.Equal(t, model.ContestStatusQ2, c.Status)
+			assert.Equal(t, uuid.Nil, c.ID)
+			assert.JSONEq(t, `[3,1,4,0,5,9,2,6,8,7]`, string(c.XLabels))
+
+			// the importer owns the copy and keeps their own square limit
+			assert.Equal(t, model.ParticipantRoleOwner, owner.Role)
+			assert.Equal(t, 5, owner.MaxSquares)
+
+			// empty squares are already created empty
+			assert.Len(t, squares, 1)
+			assert.NotNil(t, c.ImportedAt, "imported contests stay out of stats and the leaderboard")
+
+			// the document credits a@b.com, but 7-0 lands on an empty square under these labels
+			require.Len(t, results, 1)
+			assert.Empty(t, results[0].Winner)
+			assert.NotEqual(t, [2]int{0, 0}, [2]int{results[0].WinnerRow, results[0].WinnerCol})
+
+			require.Len(t, participants, 2)
+			assert.Equal(t, model.ParticipantRoleParticipant, participants[0].Role, "the old owner stays as a participant")
+			assert.Equal(t, model.ParticipantRoleViewer, participants[1].Role)
+			return nil
+		})
+
+	contest, err := service.NewExportService(repo, mocks.NewParticipantRepository(t), mocks.NewInviteRepository(t), mocks.NewParticipantService(t)).
+		ImportContest(context.Background(), exportDoc(), "me@b.com")
+	require.NoError(t, err)
+	assert.Equal(t, "c", contest.Name)
+}
+
+func TestImportContest_UnsupportedVersion(t *testing.T) {
+	doc := exportDoc()
+	doc.SchemaVersion = model.ContestExportSchemaVersion + 1
+
+	_, err := service.NewExportService(mocks.NewContestRepository(t), mocks.NewParticipantRepository(t), mocks.NewInviteRepository(t), mocks.NewParticipantService(t)).
+		ImportContest(context.Background(), doc, "me@b.com")
+	assert.ErrorIs(t, err, errs.ErrUnsupportedExportVersion)
+}
+
+func TestImportContest_Inconsistent(t *testing.T) {
+	cases := map[string]func(*model.ContestExport){
+		"duplicate square": func(d *model.ContestExport) { d.Squares[1].Col = 0 },
+		"duplicate quarter": func(d *model.ContestExport) {
+			d.QuarterResults = append(d.QuarterResults, d.QuarterResults[0])
+		},
+		"started without drawn labels":   func(d *model.ContestExport) { d.Contest.XLabels = []int8{-1, -1, -1, -1, -1, -1, -1, -1, -1, -1} },
+		"results before start":           func(d *model.ContestExport) { d.Contest.Status = "ACTIVE" },
+		"square owner not brought along": func(d *model.ContestExport) { d.Squares[0].Owner = "stranger@b.com" },
+		"limits exceed the grid":         func(d *model.ContestExport) { d.Participants[0].MaxSquares = 96 },
+	}
+
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			doc := exportDoc()
+			mutate(doc)
+
+			_, err := service.NewExportService(mocks.NewContestRepository(t), mocks.NewParticipantRepository(t), mocks.NewInviteRepository(t), mocks.NewParticipantService(t)).
+				ImportContest(context.Background(), doc, "me@b.com")
+			assert.ErrorIs(t, err, errs.ErrInvalidContestExport)
+		})
+	}
+}
+
+func TestImportContest_NameTaken(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().ExistsByOwnerAndName(mock.Anything, "me@b.com", "c").Return(true, nil)
+
+	_, err := service.NewExportService(repo, mocks.NewParticipantRepository(t), mocks.NewInviteRepository(t), mocks.NewParticipantService(t)).
+		ImportContest(context.Background(), exportDoc(), "me@b.com")
+	assert.ErrorIs(t, err, errs.ErrContestAlreadyExists)
+}
+
+func TestImportContest_PastLockDropped(t *testing.T) {
+	doc := exportDoc()
+	doc.Contest.Status = "ACTIVE"
+	doc.QuarterResults = nil
+	past := time.Now().Add(-time.Hour)
+	doc.Contest.LockAt = &past
+
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().ExistsByOwnerAndName(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	repo.EXPECT().Import(mock.Anything, mock.MatchedBy(func(c *model.Contest) bool { return c.LockAt == nil }),
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	_, err := service.NewExportService(repo, mocks.NewParticipantRepository(t), mocks.NewInviteRepository(t), mocks.NewParticipantService(t)).
+		ImportContest(context.Background(), doc, "me@b.com")
+	require.NoError(t, err)
+}
+
+func TestImportContest_RepoError(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().ExistsByOwnerAndName(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	repo.EXPECT().Import(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db"))
+
+	_, err := service.NewExportService(repo, mocks.NewParticipantRepository(t), mocks.NewInviteRepository(t), mocks.NewParticipantService(t)).
+		ImportContest(context.Background(), exportDoc(), "me@b.com")
+	assert.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
+}
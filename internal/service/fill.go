@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/repository"
+	"github.com/maxmorhardt/squares-api/internal/util"
+	"gorm.io/gorm"
+)
+
+const (
+	houseInitials = "HSE"
+	houseName     = "House"
+)
+
+// works out which empty squares the contest's fill policy hands out and whether the grid can lock
+func planFill(
+	ctx context.Context,
+	contest *model.Contest,
+	participantRepo repository.ParticipantRepository,
+	userRepo repository.UserRepository,
+) (assigned []model.Square, ready bool, err error) {
+	if util.AllSquaresClaimed(contest) {
+		return nil, true, nil
+	}
+
+	switch contest.FillPolicy {
+	case model.FillPolicyRollover:
+		// empty squares stay empty; a quarter they win has no winner
+		return nil, len(contest.Squares) > 0, nil
+	case model.FillPolicyHouse:
+		for _, sq := range contest.Squares {
+			if sq.Owner != "" {
+				continue
+			}
+			sq.Owner = model.HouseUser
+			sq.Value = houseInitials
+			sq.OwnerName = houseName
+			assigned = append(assigned, sq)
+		}
+		return assigned, true, nil
+	case model.FillPolicyRandom:
+		participants, err := participantRepo.GetAllByContestID(ctx, contest.ID)
+		if err != nil {
+			return nil, false, err
+		}
+
+		filled, ok, err := util.AssignRemainingSquares(contest, payingParticipants(contest, participants))
+		if err != nil || !ok {
+			return nil, false, err
+		}
+
+		if err := labelAssignedSquares(ctx, userRepo, filled); err != nil {
+			return nil, false, err
+		}
+		styleAssignedSquares(filled, participants)
+		return filled, true, nil
+	default:
+		return nil, false, nil
+	}
+}
+
+// reflects assigned squares on the contest so responses and broadcasts show the full grid
+func mergeAssignedSquares(contest *model.Contest, assigned []model.Square) {
+	for _, sq := range assigned {
+		for i := range contest.Squares {
+			if contest.Squares[i].ID == sq.ID {
+				contest.Squares[i] = sq
+				break
+			}
+		}
+	}
+}
+
+func labelAssignedSquares(ctx context.Context, userRepo repository.UserRepository, squares []model.Square) error {
+	// each owner's squares show their profile initials, as if they'd claimed them
+	profiles := make(map[string]*model.User)
+	for i := range squares {
+		owner := squares[i].Owner
+		profile, ok := profiles[owner]
+		if !ok {
+			p, err := userRepo.GetByEmail(ctx, owner)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if err != nil {
+				p = &model.User{Email: owner}
+			}
+			if p.DefaultInitials == "" {
+				// participants who never opened their profile fall back to their email's initial
+				local, _, _ := strings.Cut(owner, "@")
+				p.DefaultInitials = util.InitialsFromName(local)
+			}
+			profile = p
+			profiles[owner] = profile
+		}
+
+		squares[i].Value = profile.DefaultInitials
+		squares[i].OwnerName = profile.DisplayName
+	}
+
+	return nil
+}
+
+// per-contest display values and colours win over the profile initials, as they would on a claim
+func styleAssignedSquares(squares []model.Square, participants []model.ContestParticipant) {
+	byUser := make(map[string]*model.ContestParticipant, len(participants))
+	for i := range participants {
+		byUser[participants[i].UserID] = &participants[i]
+	}
+
+	for i := range squares {
+		if p, ok := byUser[squares[i].Owner]; ok {
+			squares[i].Value, squares[i].Color = p.SquareStyle(squares[i].Value)
+		}
+	}
+}
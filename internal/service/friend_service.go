@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/metrics"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/repository"
+	"github.com/maxmorhardt/squares-api/internal/util"
+	"gorm.io/gorm"
+)
+
+const userSearchLimit = 20
+
+type FriendService interface {
+	SendRequest(ctx context.Context, user, target string) (*model.Friendship, error)
+	AcceptRequest(ctx context.Context, user, requester string) (*model.Friendship, error)
+	RemoveFriend(ctx context.Context, user, other string) error
+	GetFriends(ctx context.Context, user string) ([]model.Friend, error)
+	GetRequests(ctx context.Context, user string) (*model.FriendRequestsResponse, error)
+	SearchUsers(ctx context.Context, user, query string) ([]model.UserSearchResult, error)
+	AddFriendToContest(ctx context.Context, contestID uuid.UUID, req *model.AddFriendParticipantRequest, user string) (*model.ContestParticipant, error)
+}
+
+type friendService struct {
+	friendRepo         repository.FriendRepository
+	userRepo           repository.UserRepository
+	contestRepo        repository.ContestRepository
+	participantRepo    repository.ParticipantRepository
+	participantService ParticipantService
+	natsService        NatsService
+}
+
+func NewFriendService(
+	friendRepo repository.FriendRepository,
+	userRepo repository.UserRepository,
+	contestRepo repository.ContestRepository,
+	participantRepo repository.ParticipantRepository,
+	participantService ParticipantService,
+	natsService NatsService,
+) FriendService {
+	return &friendService{
+		friendRepo:         friendRepo,
+		userRepo:           userRepo,
+		contestRepo:        contestRepo,
+		participantRepo:    participantRepo,
+		participantService: participantService,
+		natsService:        natsService,
+	}
+}
+
+// ====================
+// Friend Graph
+// ====================
+
+// a request to someone who already asked us is treated as accepting theirs
+func (s *friendService) SendRequest(ctx context.Context, user, target string) (*model.Friendship, error) {
+	log := util.LoggerFromContext(ctx)
+
+	if strings.EqualFold(user, target) {
+		return nil, errs.ErrCannotFriendSelf
+	}
+
+	profile, err := s.userRepo.GetByEmail(ctx, target)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrUserNotFound
+		}
+		log.Error("failed to get friend request target", "target", target, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	existing, err := s.friendRepo.GetBetween(ctx, user, target)
+	switch {
+	case err == nil:
+		if existing.Status == model.FriendshipStatusAccepted {
+			return nil, errs.ErrAlreadyFriends
+		}
+		if strings.EqualFold(existing.Requester, target) {
+			return s.accept(ctx, existing)
+		}
+		return nil, errs.ErrFriendRequestExists
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		log.Error("failed to check existing friendship", "target", target, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	friendship := &model.Friendship{
+		Requester: user,
+		Addressee: profile.Email,
+		Status:    model.FriendshipStatusPending,
+	}
+	if err := s.friendRepo.Create(ctx, friendship); err != nil {
+		if errors.Is(err, errs.ErrFriendRequestExists) {
+			return nil, err
+		}
+		log.Error("failed to create friend request", "target", target, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	log.Info("friend request sent", "friendship_id", friendship.ID, "target", target)
+	return friendship, nil
+}
+
+func (s *friendService) AcceptRequest(ctx context.Context, user, requester string) (*model.Friendship, error) {
+	log := util.LoggerFromContext(ctx)
+
+	friendship, err := s.friendRepo.GetBetween(ctx, user, requester)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrFriendRequestNotFound
+		}
+		log.Error("failed to get friend request", "requester", requester, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	// only the addressee can accept, and only while the request is still pending
+	if friendship.Status != model.FriendshipStatusPending || !strings.EqualFold(friendship.Addressee, user) {
+		return nil, errs.ErrFriendRequestNotFound
+	}
+
+	return s.accept(ctx, friendship)
+}
+
+// unfriends, declines an incoming request or cancels an outgoing one; all three just drop the pair's row
+func (s *friendService) RemoveFriend(ctx context.Context, user, other string) error {
+	log := util.LoggerFromContext(ctx)
+
+	if err := s.friendRepo.DeleteBetween(ctx, user, other); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errs.ErrFriendNotFound
+		}
+		log.Error("failed to remove friendship", "other", other, "error", err)
+		return errs.ErrDatabaseUnavailable
+	}
+
+	log.Info("friendship removed", "other", other)
+	return nil
+}
+
+func (s *friendService) GetFriends(ctx context.Context, user string) ([]model.Friend, error) {
+	log := util.LoggerFromContext(ctx)
+
+	friends, err := s.friendRepo.GetFriends(ctx, user)
+	if err != nil {
+		log.Error("failed to get friends", "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	log.Info("retrieved friends", "count", len(friends))
+	return friends, nil
+}
+
+func (s *friendService) GetRequests(ctx context.Context, user string) (*model.FriendRequestsResponse, error) {
+	log := util.LoggerFromContext(ctx)
+
+	pending, err := s.friendRepo.GetPending(ctx, user)
+	if err != nil {
+		log.Error("failed to get friend requests", "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	resp := &model.FriendRequestsResponse{
+		Incoming: make([]model.Friendship, 0),
+		Outgoing: make([]model.Friendship, 0),
+	}
+	for _, f := range pending {
+		if strings.EqualFold(f.Addressee, user) {
+			resp.Incoming = append(resp.Incoming, f)
+		} else {
+			resp.Outgoing = append(resp.Outgoing, f)
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *friendService) SearchUsers(ctx context.Context, user, query string) ([]model.UserSearchResult, error) {
+	log := util.LoggerFromContext(ctx)
+
+	query = strings.TrimSpace(query)
+	if n := len([]rune(query)); n < 2 || n > 50 {
+		return nil, errs.ErrInvalidSearchQuery
+	}
+
+	results, err := s.friendRepo.SearchUsers(ctx, user, query, userSearchLimit)
+	if err != nil {
+		log.Error("failed to search users", "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	log.Info("searched users", "count", len(results))
+	return results, nil
+}
+
+// ====================
+// Direct Add
+// ====================
+
+// skips the invite link entirely: an owner puts a friend straight into the pool with their own square limit
+func (s *friendService) AddFriendToContest(ctx context.Context, contestID uuid.UUID, req *model.AddFriendParticipantRequest, user string) (*model.ContestParticipant, error) {
+	log := util.LoggerFromContext(ctx)
+
+	contest, err := s.contestRepo.GetByID(ctx, contestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		log.Error("failed to get contest for direct add", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	if contest.Status.IsTerminal() {
+		return nil, errs.ErrContestFinalized
+	}
+
+	if err := s.participantService.Authorize(ctx, contestID, user, ActionManageInvites); err != nil {
+		return nil, err
+	}
+
+	friendship, err := s.friendRepo.GetBetween(ctx, user, req.UserID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error("failed to check friendship", "contest_id", contestID, "target", req.UserID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+	if err != nil || friendship.Status != model.FriendshipStatusAccepted {
+		return nil, errs.ErrNotFriend
+	}
+
+	// the friendship holds the account's own email, so the participant row matches what their token carries
+	target := friendship.Other(user)
+
+	_, err = s.participantRepo.GetByContestAndUser(ctx, contestID, target)
+	if err == nil {
+		return nil, errs.ErrAlreadyParticipant
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error("failed to check existing participant", "contest_id", contestID, "target", target, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	banned, err := s.participantRepo.IsBanned(ctx, contestID, target)
+	if err != nil {
+		log.Error("failed to check contest ban", "contest_id", contestID, "target", target, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+	if banned {
+		return nil, errs.ErrBannedFromContest
+	}
+
+	totalAllocated, err := s.participantRepo.GetTotalAllocatedSquares(ctx, contestID)
+	if err != nil {
+		log.Error("failed to get total allocated squares", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+	if totalAllocated+req.MaxSquares > 100 {
+		log.Warn("not enough squares remaining", "contest_id", contestID, "allocated", totalAllocated, "requested", req.MaxSquares)
+		return nil, errs.ErrNotEnoughSquares
+	}
+
+	added := []model.ContestParticipant{{
+		ContestID:  contestID,
+		UserID:     target,
+		Role:       model.ParticipantRoleParticipant,
+		MaxSquares: req.MaxSquares,
+	}}
+
+	// the import path locks the contest and re-checks the pool, so two concurrent adds can't overshoot it
+	if _, err := s.participantRepo.Import(ctx, contestID, added, nil, nil); err != nil {
+		if errors.Is(err, errs.ErrNotEnoughSquares) {
+			return nil, err
+		}
+		log.Error("failed to add friend to contest", "contest_id", contestID, "target", target, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	participant := &added[0]
+	metrics.IncParticipantJoined(string(participant.Role))
+
+	go func() {
+		if err := s.natsService.PublishParticipantAdded(contestID, participant); err != nil {
+			log.Error("failed to publish participant added", "contest_id", contestID, "target", target, "error", err)
+		}
+	}()
+
+	log.Info("friend added to contest", "contest_id", contestID, "target", target, "max_squares", req.MaxSquares)
+	return participant, nil
+}
+
+// ====================
+// Helpers
+// ====================
+
+func (s *friendService) accept(ctx context.Context, friendship *model.Friendship) (*model.Friendship, error) {
+	log := util.LoggerFromContext(ctx)
+
+	now := time.Now()
+	friendship.Status = model.FriendshipStatusAccepted
+	friendship.AcceptedAt = &now
+
+	if err := s.friendRepo.Update(ctx, friendship); err != nil {
+		log.Error("failed to accept friend request", "friendship_id", friendship.ID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	log.Info("friend request accepted", "friendship_id", friendship.ID)
+	return friendship, nil
+}
@@ -0,0 +1,278 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/mocks"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func friendSvc(f *mocks.FriendRepository, u *mocks.UserRepository) service.FriendService {
+	return service.NewFriendService(f, u, &mocks.ContestRepository{}, &mocks.ParticipantRepository{}, &mocks.ParticipantService{}, anyNats())
+}
+
+func existingUser(t *testing.T, email string) *mocks.UserRepository {
+	u := mocks.NewUserRepository(t)
+	u.EXPECT().GetByEmail(mock.Anything, email).Return(&model.User{Email: email}, nil)
+	return u
+}
+
+// ====================
+// SendRequest
+// ====================
+
+func TestSendFriendRequest_Success(t *testing.T) {
+	f := mocks.NewFriendRepository(t)
+	f.EXPECT().GetBetween(mock.Anything, "a@x.com", "b@x.com").Return(nil, gorm.ErrRecordNotFound)
+	f.EXPECT().Create(mock.Anything, mock.MatchedBy(func(fr *model.Friendship) bool {
+		return fr.Requester == "a@x.com" && fr.Addressee == "b@x.com" && fr.Status == model.FriendshipStatusPending
+	})).Return(nil)
+
+	got, err := friendSvc(f, existingUser(t, "b@x.com")).SendRequest(context.Background(), "a@x.com", "b@x.com")
+	require.NoError(t, err)
+	assert.Equal(t, model.FriendshipStatusPending, got.Status)
+}
+
+func TestSendFriendRequest_Self(t *testing.T) {
+	_, err := friendSvc(mocks.NewFriendRepository(t), mocks.NewUserRepository(t)).SendRequest(context.Background(), "a@x.com", "A@x.com")
+	assert.ErrorIs(t, err, errs.ErrCannotFriendSelf)
+}
+
+func TestSendFriendRequest_UnknownUser(t *testing.T) {
+	u := mocks.NewUserRepository(t)
+	u.EXPECT().GetByEmail(mock.Anything, "b@x.com").Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := friendSvc(mocks.NewFriendRepository(t), u).SendRequest(context.Background(), "a@x.com", "b@x.com")
+	assert.ErrorIs(t, err, errs.ErrUserNotFound)
+}
+
+func TestSendFriendRequest_AlreadyFriends(t *testing.T) {
+	f := mocks.NewFriendRepository(t)
+	f.EXPECT().GetBetween(mock.Anything, mock.Anything, mock.Anything).Return(&model.Friendship{Status: model.FriendshipStatusAccepted}, nil)
+
+	_, err := friendSvc(f, existingUser(t, "b@x.com")).SendRequest(context.Background(), "a@x.com", "b@x.com")
+	assert.ErrorIs(t, err, errs.ErrAlreadyFriends)
+}
+
+func TestSendFriendRequest_AlreadyPending(t *testing.T) {
+	f := mocks.NewFriendRepository(t)
+	f.EXPECT().GetBetween(mock.Anything, mock.Anything, mock.Anything).
+		Return(&model.Friendship{Requester: "a@x.com", Addressee: "b@x.com", Status: model.FriendshipStatusPending}, nil)
+
+	_, err := friendSvc(f, existingUser(t, "b@x.com")).SendRequest(context.Background(), "a@x.com", "b@x.com")
+	assert.ErrorIs(t, err, errs.ErrFriendRequestExists)
+}
+
+func TestSendFriendRequest_AcceptsReverseRequest(t *testing.T) {
+	f := mocks.NewFriendRepository(t)
+	f.EXPECT().GetBetween(mock.Anything, mock.Anything, mock.Anything).
+		Return(&model.Friendship{Requester: "b@x.com", Addressee: "a@x.com", Status: model.FriendshipStatusPending}, nil)
+	f.EXPECT().Update(mock.Anything, mock.MatchedBy(func(fr *model.Friendship) bool {
+		return fr.Status == model.FriendshipStatusAccepted && fr.AcceptedAt != nil
+	})).Return(nil)
+
+	got, err := friendSvc(f, existingUser(t, "b@x.com")).SendRequest(context.Background(), "a@x.com", "b@x.com")
+	require.NoError(t, err)
+	assert.Equal(t, model.FriendshipStatusAccepted, got.Status)
+}
+
+// ====================
+// AcceptRequest / RemoveFriend
+// ====================
+
+func TestAcceptFriendRequest_OnlyAddresseeCanAccept(t *testing.T) {
+	f := mocks.NewFriendRepository(t)
+	f.EXPECT().GetBetween(mock.Anything, "a@x.com", "b@x.com").
+		Return(&model.Friendship{Requester: "a@x.com", Addressee: "b@x.com", Status: model.FriendshipStatusPending}, nil)
+
+	_, err := friendSvc(f, mocks.NewUserRepository(t)).AcceptRequest(context.Background(), "a@x.com", "b@x.com")
+	assert.ErrorIs(t, err, errs.ErrFriendRequestNotFound)
+}
+
+func TestAcceptFriendRequest_Success(t *testing.T) {
+	f := mocks.NewFriendRepository(t)
+	f.EXPECT().GetBetween(mock.Anything, "b@x.com", "a@x.com").
+		Return(&model.Friendship{Requester: "a@x.com", Addressee: "b@x.com", Status: model.FriendshipStatusPending}, nil)
+	f.EXPECT().Update(mock.Anything, mock.Anything).Return(nil)
+
+	got, err := friendSvc(f, mocks.NewUserRepository(t)).AcceptRequest(context.Background(), "b@x.com", "a@x.com")
+	require.NoError(t, err)
+	assert.Equal(t, model.FriendshipStatusAccepted, got.Status)
+}
+
+func TestAcceptFriendRequest_NotFound(t *testing.T) {
+	f := mocks.NewFriendRepository(t)
+	f.EXPECT().GetBetween(mock.Anything, mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := friendSvc(f, mocks.NewUserRepository(t)).AcceptRequest(context.Background(), "b@x.com", "a@x.com")
+	assert.ErrorIs(t, err, errs.ErrFriendRequestNotFound)
+}
+
+func TestRemoveFriend_NotFound(t *testing.T) {
+	f := mocks.NewFriendRepository(t)
+	f.EXPECT().DeleteBetween(mock.Anything, "a@x.com", "b@x.com").Return(gorm.ErrRecordNotFound)
+
+	err := friendSvc(f, mocks.NewUserRepository(t)).RemoveFriend(context.Background(), "a@x.com", "b@x.com")
+	assert.ErrorIs(t, err, errs.ErrFriendNotFound)
+}
+
+func TestGetFriendRequests_SplitsByDirection(t *testing.T) {
+	f := mocks.NewFriendRepository(t)
+	f.EXPECT().GetPending(mock.Anything, "a@x.com").Return([]model.Friendship{
+		{Requester: "b@x.com", Addressee: "A@x.com"},
+		{Requester: "a@x.com", Addressee: "c@x.com"},
+	}, nil)
+
+	got, err := friendSvc(f, mocks.NewUserRepository(t)).GetRequests(context.Background(), "a@x.com")
+	require.NoError(t, err)
+	require.Len(t, got.Incoming, 1)
+	require.Len(t, got.Outgoing, 1)
+	assert.Equal(t, "b@x.com", got.Incoming[0].Requester)
+}
+
+// ====================
+// SearchUsers
+// ====================
+
+func TestSearchUsers_RejectsShortQuery(t *testing.T) {
+	_, err := friendSvc(mocks.NewFriendRepository(t), mocks.NewUserRepository(t)).SearchUsers(context.Background(), "a@x.com", " m ")
+	assert.ErrorIs(t, err, errs.ErrInvalidSearchQuery)
+}
+
+func TestSearchUsers_TrimsQuery(t *testing.T) {
+	f := mocks.NewFriendRepository(t)
+	f.EXPECT().SearchUsers(mock.Anything, "a@x.com", "max", 20).Return([]model.UserSearchResult{{UserID: "m@x.com", Friend: true}}, nil)
+
+	got, err := friendSvc(f, mocks.NewUserRepository(t)).SearchUsers(context.Background(), "a@x.com", "  max ")
+	require.NoError(t, err)
+	assert.Len(t, got, 1)
+}
+
+func TestSearchUsers_DBError(t *testing.T) {
+	f := mocks.NewFriendRepository(t)
+	f.EXPECT().SearchUsers(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db"))
+
+	_, err := friendSvc(f, mocks.NewUserRepository(t)).SearchUsers(context.Background(), "a@x.com", "max")
+	assert.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
+}
+
+// ====================
+// AddFriendToContest
+// ====================
+
+type directAddMocks struct {
+	contest     *mocks.ContestRepository
+	friend      *mocks.FriendRepository
+	participant *mocks.ParticipantRepository
+	svc         service.FriendService
+}
+
+// an active contest the caller is allowed to manage
+func directAdd(t *testing.T) directAddMocks {
+	m := directAddMocks{
+		contest:     mocks.NewContestRepository(t),
+		friend:      mocks.NewFriendRepository(t),
+		participant: mocks.NewParticipantRepository(t),
+	}
+	m.contest.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
+	m.svc = service.NewFriendService(m.friend, mocks.NewUserRepository(t), m.contest, m.participant, okAuth(t), anyNats())
+	return m
+}
+
+func acceptedWith(friend string) *model.Friendship {
+	return &model.Friendship{Requester: "owner", Addressee: friend, Status: model.FriendshipStatusAccepted}
+}
+
+func TestAddFriendToContest_Success(t *testing.T) {
+	contestID := uuid.New()
+	m := directAdd(t)
+	// the request spells the email differently; the participant row uses the account's own
+	m.friend.EXPECT().GetBetween(mock.Anything, "owner", "F@X.com").Return(acceptedWith("f@x.com"), nil)
+	m.participant.EXPECT().GetByContestAndUser(mock.Anything, contestID, "f@x.com").Return(nil, gorm.ErrRecordNotFound)
+	m.participant.EXPECT().IsBanned(mock.Anything, contestID, "f@x.com").Return(false, nil)
+	m.participant.EXPECT().GetTotalAllocatedSquares(mock.Anything, contestID).Return(90, nil)
+	m.participant.EXPECT().Import(mock.Anything, contestID, mock.MatchedBy(func(ps []model.ContestParticipant) bool {
+		return len(ps) == 1 && ps[0].UserID == "f@x.com" && ps[0].Role == model.ParticipantRoleParticipant && ps[0].MaxSquares == 10
+	}), []model.ContestParticipant(nil), []model.Square(nil)).Return(nil, nil)
+
+	got, err := m.svc.AddFriendToContest(context.Background(), contestID, &model.AddFriendParticipantRequest{UserID: "F@X.com", MaxSquares: 10}, "owner")
+	require.NoError(t, err)
+	assert.Equal(t, 10, got.MaxSquares)
+}
+
+func TestAddFriendToContest_NotFriend(t *testing.T) {
+	m := directAdd(t)
+	m.friend.EXPECT().GetBetween(mock.Anything, mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := m.svc.AddFriendToContest(context.Background(), uuid.New(), &model.AddFriendParticipantRequest{UserID: "f@x.com", MaxSquares: 1}, "owner")
+	assert.ErrorIs(t, err, errs.ErrNotFriend)
+}
+
+func TestAddFriendToContest_RequestStillPending(t *testing.T) {
+	m := directAdd(t)
+	pending := acceptedWith("f@x.com")
+	pending.Status = model.FriendshipStatusPending
+	m.friend.EXPECT().GetBetween(mock.Anything, mock.Anything, mock.Anything).Return(pending, nil)
+
+	_, err := m.svc.AddFriendToContest(context.Background(), uuid.New(), &model.AddFriendParticipantRequest{UserID: "f@x.com", MaxSquares: 1}, "owner")
+	assert.ErrorIs(t, err, errs.ErrNotFriend)
+}
+
+func TestAddFriendToContest_ExceedsPool(t *testing.T) {
+	m := directAdd(t)
+	m.friend.EXPECT().GetBetween(mock.Anything, mock.Anything, mock.Anything).Return(acceptedWith("f@x.com"), nil)
+	m.participant.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+	m.participant.EXPECT().IsBanned(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	m.participant.EXPECT().GetTotalAllocatedSquares(mock.Anything, mock.Anything).Return(95, nil)
+
+	_, err := m.svc.AddFriendToContest(context.Background(), uuid.New(), &model.AddFriendParticipantRequest{UserID: "f@x.com", MaxSquares: 10}, "owner")
+	assert.ErrorIs(t, err, errs.ErrNotEnoughSquares)
+}
+
+func TestAddFriendToContest_AlreadyParticipant(t *testing.T) {
+	m := directAdd(t)
+	m.friend.EXPECT().GetBetween(mock.Anything, mock.Anything, mock.Anything).Return(acceptedWith("f@x.com"), nil)
+	m.participant.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, mock.Anything).Return(&model.ContestParticipant{}, nil)
+
+	_, err := m.svc.AddFriendToContest(context.Background(), uuid.New(), &model.AddFriendParticipantRequest{UserID: "f@x.com", MaxSquares: 1}, "owner")
+	assert.ErrorIs(t, err, errs.ErrAlreadyParticipant)
+}
+
+func TestAddFriendToContest_Banned(t *testing.T) {
+	m := directAdd(t)
+	m.friend.EXPECT().GetBetween(mock.Anything, mock.Anything, mock.Anything).Return(acceptedWith("f@x.com"), nil)
+	m.participant.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+	m.participant.EXPECT().IsBanned(mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+
+	_, err := m.svc.AddFriendToContest(context.Background(), uuid.New(), &model.AddFriendParticipantRequest{UserID: "f@x.com", MaxSquares: 1}, "owner")
+	assert.ErrorIs(t, err, errs.ErrBannedFromContest)
+}
+
+func TestAddFriendToContest_Finalized(t *testing.T) {
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusFinished}, nil)
+	svc := service.NewFriendService(mocks.NewFriendRepository(t), mocks.NewUserRepository(t), c, mocks.NewParticipantRepository(t), mocks.NewParticipantService(t), anyNats())
+
+	_, err := svc.AddFriendToContest(context.Background(), uuid.New(), &model.AddFriendParticipantRequest{UserID: "f@x.com", MaxSquares: 1}, "owner")
+	assert.ErrorIs(t, err, errs.ErrContestFinalized)
+}
+
+func TestAddFriendToContest_RequiresOwner(t *testing.T) {
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
+	pSvc := mocks.NewParticipantService(t)
+	pSvc.EXPECT().Authorize(mock.Anything, mock.Anything, "viewer", service.ActionManageInvites).Return(errs.ErrInsufficientRole)
+	svc := service.NewFriendService(mocks.NewFriendRepository(t), mocks.NewUserRepository(t), c, mocks.NewParticipantRepository(t), pSvc, anyNats())
+
+	_, err := svc.AddFriendToContest(context.Background(), uuid.New(), &model.AddFriendParticipantRequest{UserID: "f@x.com", MaxSquares: 1}, "viewer")
+	assert.ErrorIs(t, err, errs.ErrInsufficientRole)
+}
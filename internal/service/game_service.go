@@ -14,7 +14,13 @@ import (
 
 const systemUser = "system"
 
-const upcomingCacheTTL = 60 * time.Second
+const (
+	upcomingCacheTTL = 60 * time.Second
+
+	// long enough to cover a game, so a contest held open by unpaid owners is announced once
+	unpaidNoticeTTL       = 6 * time.Hour
+	unpaidNoticeCacheSize = 1024
+)
 
 type GameService interface {
 	GetUpcoming(ctx context.Context) ([]model.Game, error)
@@ -24,22 +30,33 @@ type GameService interface {
 }
 
 type gameService struct {
-	gameRepo    repository.GameRepository
-	contestRepo repository.ContestRepository
-	natsService NatsService
-	upcoming    *util.TTLCache[struct{}, []model.Game]
+	gameRepo         repository.GameRepository
+	contestRepo      repository.ContestRepository
+	participantRepo  repository.ParticipantRepository
+	userRepo         repository.UserRepository
+	natsService      NatsService
+	analyticsService AnalyticsService
+	upcoming         *util.TTLCache[struct{}, []model.Game]
+	unpaidNotices    *util.TTLCache[uuid.UUID, struct{}]
 }
 
 func NewGameService(
 	gameRepo repository.GameRepository,
 	contestRepo repository.ContestRepository,
+	participantRepo repository.ParticipantRepository,
+	userRepo repository.UserRepository,
 	natsService NatsService,
+	analyticsService AnalyticsService,
 ) GameService {
 	return &gameService{
-		gameRepo:    gameRepo,
-		contestRepo: contestRepo,
-		natsService: natsService,
-		upcoming:    util.NewTTLCache[struct{}, []model.Game](1, upcomingCacheTTL),
+		gameRepo:         gameRepo,
+		contestRepo:      contestRepo,
+		participantRepo:  participantRepo,
+		userRepo:         userRepo,
+		natsService:      natsService,
+		analyticsService: analyticsService,
+		upcoming:         util.NewTTLCache[struct{}, []model.Game](1, upcomingCacheTTL),
+		unpaidNotices:    util.NewTTLCache[uuid.UUID, struct{}](unpaidNoticeCacheSize, unpaidNoticeTTL),
 	}
 }
 
@@ -147,17 +164,43 @@ func (s *gameService) reconcile(ctx context.Context, contest *model.Contest, gam
 
 	// an ACTIVE contest hasn't locked its grid yet
 	if contest.Status == model.ContestStatusActive {
-		switch {
-		case game.Status == model.GameStatusFinal:
+		switch game.Status {
+		case model.GameStatusFinal:
 			// the game ended before the grid ever locked; finalize straight from the final scores
 			return s.finalize(ctx, contest, game)
-		case game.Status == model.GameStatusInProgress && util.AllSquaresClaimed(contest):
-			// full grid at kickoff; lock, randomize, and score live
-			if err := s.autoStart(ctx, contest); err != nil {
+		case model.GameStatusInProgress:
+			// unpaid owners hold the lock or lose their squares, depending on the payment policy
+			unpaid, cleared, paid, err := settlePayments(ctx, contest, s.participantRepo)
+			if err != nil {
 				return err
 			}
+			if !paid {
+				// the grid stays open until the owner marks everyone paid, so they need to hear about it
+				s.notifyUnpaidAtKickoff(ctx, contest)
+				return errs.ErrUnpaidSquareOwners
+			}
+
+			// the fill policy decides whether a grid with holes can lock at kickoff
+			assigned, ready, err := planFill(ctx, contest, s.participantRepo, s.userRepo)
+			if err != nil {
+				return err
+			}
+			if !ready {
+				// grid stays fillable until it fills up or the game ends
+				return nil
+			}
+
+			// lock, randomize, and score live
+			if err := s.autoStart(ctx, contest, unpaid, assigned); err != nil {
+				return err
+			}
+
+			if settled := settledSquares(contest, cleared); len(settled) > 0 {
+				if err := s.natsService.PublishSquaresUpdate(contest.ID, systemUser, settled); err != nil {
+					log.Error("failed to publish squares cleared for unpaid owners", "contest_id", contest.ID, "error", err)
+				}
+			}
 		default:
-			// grid stays fillable until it fills up or the game ends
 			return nil
 		}
 	}
@@ -167,15 +210,21 @@ func (s *gameService) reconcile(ctx context.Context, contest *model.Contest, gam
 		return nil
 	}
 
+	// earlier quarters are replayed so a rollover run carries into the quarters being applied
+	var previous []model.QuarterResult
 	for i := range game.Scores {
 		score := game.Scores[i]
-		if score.Quarter < currentQuarter {
+		result, err := util.QuarterResultFor(contest, score.Quarter, score.HomeScore, score.AwayScore)
+		if err != nil {
+			if score.Quarter >= currentQuarter {
+				log.Warn("skipping quarter, winner not determinable", "contest_id", contest.ID, "quarter", score.Quarter, "error", err)
+			}
 			continue
 		}
+		util.ApplyRollover(contest, result, previous)
+		previous = append(previous, *result)
 
-		result, err := util.QuarterResultFor(contest, score.Quarter, score.HomeScore, score.AwayScore)
-		if err != nil {
-			log.Warn("skipping quarter, winner not determinable", "contest_id", contest.ID, "quarter", score.Quarter, "error", err)
+		if score.Quarter < currentQuarter {
 			continue
 		}
 
@@ -198,13 +247,13 @@ func (s *gameService) reconcile(ctx context.Context, contest *model.Contest, gam
 		}
 
 		currentQuarter = score.Quarter + 1
-		log.Info("applied quarter result", "contest_id", contest.ID, "game_id", game.ID, "quarter", score.Quarter, "winner", result.Winner)
+		log.Info("applied quarter result", "contest_id", contest.ID, "game_id", game.ID, "quarter", score.Quarter, "winner", result.Winner, "rolled_over", result.RolledOver)
 	}
 
 	return nil
 }
 
-func (s *gameService) autoStart(ctx context.Context, contest *model.Contest) error {
+func (s *gameService) autoStart(ctx context.Context, contest *model.Contest, unpaid []string, assigned []model.Square) error {
 	log := util.LoggerFromContext(ctx)
 
 	xLabels, yLabels, err := util.RandomizedLabels()
@@ -217,25 +266,55 @@ func (s *gameService) autoStart(ctx context.Context, contest *model.Contest) err
 	contest.Status = model.ContestStatusQ1
 	contest.UpdatedBy = systemUser
 
-	if err := s.contestRepo.Update(ctx, contest); err != nil {
+	// cleared and filled squares land in the same transaction as the lock
+	if len(unpaid) > 0 || len(assigned) > 0 {
+		err = s.contestRepo.StartWithSquares(ctx, contest, unpaid, assigned)
+	} else {
+		err = s.contestRepo.Update(ctx, contest)
+	}
+	if err != nil {
 		return err
 	}
+	mergeAssignedSquares(contest, assigned)
 
 	metrics.IncContestStarted()
 
-	// notify clients the grid is locked and randomized; strip heavy relations
+	// same as a manual start: odds attach once the labels are drawn, and the start stands without them
+	if err := s.analyticsService.AnnotateSquares(ctx, contest); err != nil {
+		log.Warn("failed to annotate squares with analytics", "contest_id", contest.ID, "error", err)
+	}
+
+	// notify clients the grid is locked and randomized; squares stay so each carries its new odds
 	wsContest := *contest
-	wsContest.Squares = nil
 	wsContest.QuarterResults = nil
 	wsContest.Game = nil
 	if err := s.natsService.PublishContestUpdate(contest.ID, systemUser, &wsContest); err != nil {
 		log.Error("failed to publish auto-start update", "contest_id", contest.ID, "error", err)
 	}
 
-	log.Info("auto-started game-linked contest", "contest_id", contest.ID)
+	log.Info("auto-started game-linked contest", "contest_id", contest.ID, "assigned_squares", len(assigned))
 	return nil
 }
 
+// every sync retries the lock, but the owner only hears once per contest that unpaid squares are holding it
+func (s *gameService) notifyUnpaidAtKickoff(ctx context.Context, contest *model.Contest) {
+	log := util.LoggerFromContext(ctx)
+
+	_, _ = s.unpaidNotices.GetOrLoad(ctx, contest.ID, func(context.Context) (struct{}, error) {
+		wsContest := *contest
+		wsContest.Squares = nil
+		wsContest.QuarterResults = nil
+		wsContest.Game = nil
+		if err := s.natsService.PublishContestLocked(contest.ID, &wsContest, "Game started but some square owners haven't paid; the grid locks once everyone is marked paid"); err != nil {
+			log.Error("failed to publish unpaid owners at kickoff", "contest_id", contest.ID, "error", err)
+		}
+
+		metrics.IncContestLocked(false)
+		log.Warn("game started with unpaid square owners, grid left open", "contest_id", contest.ID)
+		return struct{}{}, nil
+	})
+}
+
 func (s *gameService) finalize(ctx context.Context, contest *model.Contest, game *model.Game) error {
 	log := util.LoggerFromContext(ctx)
 
@@ -255,6 +334,7 @@ func (s *gameService) finalize(ctx context.Context, contest *model.Contest, game
 	}
 
 	// publish every quarter's outcome so connected clients render the final board
+	var previous []model.QuarterResult
 	for i := range game.Scores {
 		score := game.Scores[i]
 		result, resultErr := util.QuarterResultFor(contest, score.Quarter, score.HomeScore, score.AwayScore)
@@ -262,6 +342,8 @@ func (s *gameService) finalize(ctx context.Context, contest *model.Contest, game
 			log.Warn("skipping quarter on finalize, winner not determinable", "contest_id", contest.ID, "quarter", score.Quarter, "error", resultErr)
 			continue
 		}
+		util.ApplyRollover(contest, result, previous)
+		previous = append(previous, *result)
 
 		metrics.IncQuarterResult(score.Quarter)
 		if err := s.natsService.PublishQuarterResult(contest.ID, systemUser, result); err != nil {
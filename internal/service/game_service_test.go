@@ -21,7 +21,7 @@ func TestGameService_GetUpcoming(t *testing.T) {
 	g := mocks.NewGameRepository(t)
 	g.EXPECT().GetUpcoming(mock.Anything).Return([]model.Game{{ESPNID: "1"}, {ESPNID: "2"}}, nil)
 
-	got, err := gameSvc(g, mocks.NewContestRepository(t)).GetUpcoming(context.Background())
+	got, err := gameSvc(t, g, mocks.NewContestRepository(t)).GetUpcoming(context.Background())
 	require.NoError(t, err)
 	assert.Len(t, got, 2)
 }
@@ -31,7 +31,7 @@ func TestGameService_GetUpcoming_CachesResult(t *testing.T) {
 	// mockery fails on cleanup if the repo is hit more than once
 	g.EXPECT().GetUpcoming(mock.Anything).Return([]model.Game{{ESPNID: "1"}}, nil).Once()
 
-	svc := gameSvc(g, mocks.NewContestRepository(t))
+	svc := gameSvc(t, g, mocks.NewContestRepository(t))
 	first, err := svc.GetUpcoming(context.Background())
 	require.NoError(t, err)
 	second, err := svc.GetUpcoming(context.Background())
@@ -41,15 +41,15 @@ func TestGameService_GetUpcoming_CachesResult(t *testing.T) {
 	assert.Len(t, second, 1)
 }
 
-func gameSvc(gameRepo *mocks.GameRepository, contestRepo *mocks.ContestRepository) service.GameService {
-	return service.NewGameService(gameRepo, contestRepo, anyNats())
+func gameSvc(t *testing.T, gameRepo *mocks.GameRepository, contestRepo *mocks.ContestRepository) service.GameService {
+	return service.NewGameService(gameRepo, contestRepo, mocks.NewParticipantRepository(t), mocks.NewUserRepository(t), anyNats(), anyAnalytics())
 }
 
 func TestGameService_GetUpcoming_DBError(t *testing.T) {
 	g := mocks.NewGameRepository(t)
 	g.EXPECT().GetUpcoming(mock.Anything).Return(nil, errors.New("boom"))
 
-	_, err := gameSvc(g, mocks.NewContestRepository(t)).GetUpcoming(context.Background())
+	_, err := gameSvc(t, g, mocks.NewContestRepository(t)).GetUpcoming(context.Background())
 	assert.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
 }
 
@@ -59,7 +59,7 @@ func TestGameService_Ingest_UpsertsAndSkipsScheduled(t *testing.T) {
 	g.EXPECT().Upsert(mock.Anything, mock.Anything).Return(nil).Once()
 
 	games := []model.ESPNGame{{ESPNID: "1", State: "pre"}}
-	newScores, err := gameSvc(g, mocks.NewContestRepository(t)).Ingest(context.Background(), games)
+	newScores, err := gameSvc(t, g, mocks.NewContestRepository(t)).Ingest(context.Background(), games)
 	require.NoError(t, err)
 	assert.Zero(t, newScores)
 }
@@ -76,7 +76,7 @@ func TestGameService_Ingest_RecordsScoresAndSyncs(t *testing.T) {
 	c.EXPECT().GetByGameID(mock.Anything, mock.Anything).Return([]model.Contest{}, nil).Once()
 
 	games := []model.ESPNGame{{ESPNID: "1", State: "in", Period: 2, HomeLine: []int{7}, AwayLine: []int{3}}}
-	newScores, err := gameSvc(g, c).Ingest(context.Background(), games)
+	newScores, err := gameSvc(t, g, c).Ingest(context.Background(), games)
 	require.NoError(t, err)
 	assert.Equal(t, 1, newScores)
 }
@@ -87,7 +87,7 @@ func TestGameService_Ingest_UpsertErrorSkipsGame(t *testing.T) {
 	// UpsertScore / SyncGame must not run when the game upsert fails
 
 	games := []model.ESPNGame{{ESPNID: "1", State: "in"}}
-	newScores, err := gameSvc(g, mocks.NewContestRepository(t)).Ingest(context.Background(), games)
+	newScores, err := gameSvc(t, g, mocks.NewContestRepository(t)).Ingest(context.Background(), games)
 	require.NoError(t, err)
 	assert.Zero(t, newScores)
 }
@@ -98,7 +98,7 @@ func TestGameService_Activity(t *testing.T) {
 	g.EXPECT().HasLiveGame(mock.Anything).Return(true, nil)
 	g.EXPECT().NextKickoff(mock.Anything).Return(kickoff, nil)
 
-	act, err := gameSvc(g, mocks.NewContestRepository(t)).Activity(context.Background())
+	act, err := gameSvc(t, g, mocks.NewContestRepository(t)).Activity(context.Background())
 	require.NoError(t, err)
 	assert.True(t, act.Live)
 	assert.Equal(t, kickoff, act.NextKickoff)
@@ -108,7 +108,7 @@ func TestGameService_Activity_LiveError(t *testing.T) {
 	g := mocks.NewGameRepository(t)
 	g.EXPECT().HasLiveGame(mock.Anything).Return(false, errors.New("db"))
 
-	_, err := gameSvc(g, mocks.NewContestRepository(t)).Activity(context.Background())
+	_, err := gameSvc(t, g, mocks.NewContestRepository(t)).Activity(context.Background())
 	assert.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
 }
 
@@ -117,7 +117,7 @@ func TestGameService_Activity_KickoffError(t *testing.T) {
 	g.EXPECT().HasLiveGame(mock.Anything).Return(false, nil)
 	g.EXPECT().NextKickoff(mock.Anything).Return(time.Time{}, errors.New("db"))
 
-	_, err := gameSvc(g, mocks.NewContestRepository(t)).Activity(context.Background())
+	_, err := gameSvc(t, g, mocks.NewContestRepository(t)).Activity(context.Background())
 	assert.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
 }
 
@@ -133,7 +133,7 @@ func TestGameService_SyncGame_AdvancesStartedContest(t *testing.T) {
 		return ct.Status == model.ContestStatusQ2
 	})).Return(nil).Once()
 
-	require.NoError(t, gameSvc(g, c).SyncGame(context.Background(), gameID))
+	require.NoError(t, gameSvc(t, g, c).SyncGame(context.Background(), gameID))
 }
 
 func liveGame(gameID uuid.UUID, scores ...model.GameScore) *model.Game {
@@ -169,10 +169,34 @@ func TestGameService_SyncGame_AutoStartsAndBackfills(t *testing.T) {
 		lastStatus = ct.Status
 	}).Return(nil)
 
-	require.NoError(t, gameSvc(g, c).SyncGame(context.Background(), gameID))
+	require.NoError(t, gameSvc(t, g, c).SyncGame(context.Background(), gameID))
 	assert.Equal(t, model.ContestStatusQ3, lastStatus)
 }
 
+func TestGameService_SyncGame_AutoStartAnnotatesSquares(t *testing.T) {
+	gameID := uuid.New()
+	g := mocks.NewGameRepository(t)
+	g.EXPECT().GetByID(mock.Anything, gameID).Return(liveGame(gameID), nil)
+
+	contest := startedContest(model.ContestStatusActive, &model.Game{ID: gameID})
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByGameID(mock.Anything, gameID).Return([]model.Contest{contest}, nil)
+	c.EXPECT().Update(mock.Anything, mock.Anything).Return(nil).Once()
+
+	// the odds go out with the squares; a failure doesn't undo the start
+	analytics := mocks.NewAnalyticsService(t)
+	analytics.EXPECT().AnnotateSquares(mock.Anything, mock.MatchedBy(func(ct *model.Contest) bool {
+		return ct.Status == model.ContestStatusQ1
+	})).Return(errors.New("boom")).Once()
+	nats := mocks.NewNatsService(t)
+	nats.EXPECT().PublishContestUpdate(contest.ID, mock.Anything, mock.MatchedBy(func(ct *model.Contest) bool {
+		return len(ct.Squares) == len(contest.Squares) && ct.Game == nil
+	})).Return(nil).Once()
+
+	svc := service.NewGameService(g, c, mocks.NewParticipantRepository(t), mocks.NewUserRepository(t), nats, analytics)
+	require.NoError(t, svc.SyncGame(context.Background(), gameID))
+}
+
 func TestGameService_SyncGame_SkipsWhenGameNotLive(t *testing.T) {
 	gameID := uuid.New()
 	g := mocks.NewGameRepository(t)
@@ -183,7 +207,7 @@ func TestGameService_SyncGame_SkipsWhenGameNotLive(t *testing.T) {
 	c.EXPECT().GetByGameID(mock.Anything, gameID).Return([]model.Contest{contest}, nil)
 	// no Update expected: game hasn't started
 
-	require.NoError(t, gameSvc(g, c).SyncGame(context.Background(), gameID))
+	require.NoError(t, gameSvc(t, g, c).SyncGame(context.Background(), gameID))
 }
 
 func TestGameService_SyncGame_SkipsWhenGridNotFull(t *testing.T) {
@@ -197,7 +221,44 @@ func TestGameService_SyncGame_SkipsWhenGridNotFull(t *testing.T) {
 	c.EXPECT().GetByGameID(mock.Anything, gameID).Return([]model.Contest{contest}, nil)
 	// no Update expected: grid not ready to start
 
-	require.NoError(t, gameSvc(g, c).SyncGame(context.Background(), gameID))
+	require.NoError(t, gameSvc(t, g, c).SyncGame(context.Background(), gameID))
+}
+
+func TestGameService_SyncGame_HouseFillAutoStarts(t *testing.T) {
+	gameID := uuid.New()
+	g := mocks.NewGameRepository(t)
+	g.EXPECT().GetByID(mock.Anything, gameID).Return(liveGame(gameID), nil)
+
+	contest := startedContest(model.ContestStatusActive, &model.Game{ID: gameID})
+	contest.FillPolicy = model.FillPolicyHouse
+	contest.Squares[0].Owner = ""
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByGameID(mock.Anything, gameID).Return([]model.Contest{contest}, nil)
+	// the house square is written in the same transaction as the lock
+	c.EXPECT().StartWithSquares(mock.Anything, mock.MatchedBy(func(ct *model.Contest) bool {
+		return ct.Status == model.ContestStatusQ1
+	}), mock.Anything, mock.MatchedBy(func(sqs []model.Square) bool {
+		return len(sqs) == 1 && sqs[0].Owner == model.HouseUser
+	})).Return(nil).Once()
+
+	require.NoError(t, gameSvc(t, g, c).SyncGame(context.Background(), gameID))
+}
+
+func TestGameService_SyncGame_RolloverFillAutoStarts(t *testing.T) {
+	gameID := uuid.New()
+	g := mocks.NewGameRepository(t)
+	g.EXPECT().GetByID(mock.Anything, gameID).Return(liveGame(gameID), nil)
+
+	contest := startedContest(model.ContestStatusActive, &model.Game{ID: gameID})
+	contest.FillPolicy = model.FillPolicyRollover
+	contest.Squares[0].Owner = ""
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByGameID(mock.Anything, gameID).Return([]model.Contest{contest}, nil)
+	c.EXPECT().Update(mock.Anything, mock.MatchedBy(func(ct *model.Contest) bool {
+		return ct.Status == model.ContestStatusQ1
+	})).Return(nil).Once()
+
+	require.NoError(t, gameSvc(t, g, c).SyncGame(context.Background(), gameID))
 }
 
 func TestGameService_SyncGame_SkipsAlreadyAppliedQuarter(t *testing.T) {
@@ -211,7 +272,32 @@ func TestGameService_SyncGame_SkipsAlreadyAppliedQuarter(t *testing.T) {
 	c.EXPECT().GetByGameID(mock.Anything, gameID).Return([]model.Contest{contest}, nil)
 	// no Update expected
 
-	require.NoError(t, gameSvc(g, c).SyncGame(context.Background(), gameID))
+	require.NoError(t, gameSvc(t, g, c).SyncGame(context.Background(), gameID))
+}
+
+func TestGameService_SyncGame_CarriesRolloverIntoAppliedQuarter(t *testing.T) {
+	gameID := uuid.New()
+	g := mocks.NewGameRepository(t)
+	g.EXPECT().GetByID(mock.Anything, gameID).Return(liveGame(gameID,
+		model.GameScore{Quarter: 1, HomeScore: 7, AwayScore: 3},
+		model.GameScore{Quarter: 2, HomeScore: 14, AwayScore: 10},
+	), nil)
+
+	// Q1 already applied with an unowned winner; Q2 picks up its prize
+	contest := startedContest(model.ContestStatusQ2, &model.Game{ID: gameID})
+	contest.Rollover = true
+	contest.Squares[37].Owner = ""
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByGameID(mock.Anything, gameID).Return([]model.Contest{contest}, nil)
+	c.EXPECT().Update(mock.Anything, mock.Anything).Return(nil).Once()
+
+	nats := mocks.NewNatsService(t)
+	nats.EXPECT().PublishQuarterResult(contest.ID, mock.Anything, mock.MatchedBy(func(r *model.QuarterResult) bool {
+		return r.Quarter == 2 && r.CarriedOver == 1 && r.Winner == "u"
+	})).Return(nil).Once()
+
+	svc := service.NewGameService(g, c, mocks.NewParticipantRepository(t), mocks.NewUserRepository(t), nats, anyAnalytics())
+	require.NoError(t, svc.SyncGame(context.Background(), gameID))
 }
 
 func finalGame(gameID uuid.UUID, scores ...model.GameScore) *model.Game {
@@ -237,7 +323,7 @@ func TestGameService_SyncGame_FinalizesWhenGameEnds(t *testing.T) {
 		finalStatus = ct.Status
 	}).Return(nil).Once()
 
-	require.NoError(t, gameSvc(g, c).SyncGame(context.Background(), gameID))
+	require.NoError(t, gameSvc(t, g, c).SyncGame(context.Background(), gameID))
 	assert.Equal(t, model.ContestStatusFinished, finalStatus)
 }
 
@@ -252,7 +338,7 @@ func TestGameService_SyncGame_FinalizeUpdateError(t *testing.T) {
 	c.EXPECT().Update(mock.Anything, mock.Anything).Return(errors.New("db")).Once()
 
 	// SyncGame logs and swallows the reconcile error, so it still returns nil
-	require.NoError(t, gameSvc(g, c).SyncGame(context.Background(), gameID))
+	require.NoError(t, gameSvc(t, g, c).SyncGame(context.Background(), gameID))
 }
 
 func TestGameService_SyncGame_FinalizesUnfilledGrid(t *testing.T) {
@@ -273,6 +359,6 @@ func TestGameService_SyncGame_FinalizesUnfilledGrid(t *testing.T) {
 		finalStatus = ct.Status
 	}).Return(nil).Once()
 
-	require.NoError(t, gameSvc(g, c).SyncGame(context.Background(), gameID))
+	require.NoError(t, gameSvc(t, g, c).SyncGame(context.Background(), gameID))
 	assert.Equal(t, model.ContestStatusFinished, finalStatus)
 }
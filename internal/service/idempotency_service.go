@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/metrics"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/repository"
+	"github.com/maxmorhardt/squares-api/internal/util"
+	"gorm.io/gorm"
+)
+
+const (
+	idempotencyKeyTTL       = 24 * time.Hour
+	idempotencyKeyMaxLength = 255
+	// longer than any request should run; an in-flight key this old belongs to an attempt that died
+	idempotencyInFlightTimeout = 60 * time.Second
+)
+
+type IdempotencyService interface {
+	Begin(ctx context.Context, user, key, method, path string, body []byte) (record *model.IdempotencyKey, replay bool, err error)
+	Complete(ctx context.Context, record *model.IdempotencyKey, statusCode int, headers map[string]string, body []byte) error
+	Release(ctx context.Context, record *model.IdempotencyKey) error
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+type idempotencyService struct {
+	repo repository.IdempotencyRepository
+}
+
+func NewIdempotencyService(repo repository.IdempotencyRepository) IdempotencyService {
+	return &idempotencyService{
+		repo: repo,
+	}
+}
+
+// reserves the key for this request, or hands back the stored response when it's a retry
+func (s *idempotencyService) Begin(ctx context.Context, user, key, method, path string, body []byte) (*model.IdempotencyKey, bool, error) {
+	log := util.LoggerFromContext(ctx)
+
+	if key == "" || len(key) > idempotencyKeyMaxLength {
+		return nil, false, errs.ErrInvalidIdempotencyKey
+	}
+
+	// postgres keeps microseconds; completing and releasing match on this exact reservation time
+	now := time.Now().Truncate(time.Microsecond)
+	record := &model.IdempotencyKey{
+		UserID:      user,
+		Key:         key,
+		RequestHash: requestHash(method, path, body),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(idempotencyKeyTTL),
+	}
+
+	reserved, err := s.repo.Reserve(ctx, record, now.Add(-idempotencyInFlightTimeout))
+	if err != nil {
+		log.Error("failed to reserve idempotency key", "user", user, "error", err)
+		return nil, false, errs.ErrDatabaseUnavailable
+	}
+	if reserved {
+		return record, false, nil
+	}
+
+	existing, err := s.repo.Get(ctx, user, key)
+	if err != nil {
+		// released between our reserve and this read; the client can simply retry
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, errs.ErrIdempotencyKeyInProgress
+		}
+		log.Error("failed to get idempotency key", "user", user, "error", err)
+		return nil, false, errs.ErrDatabaseUnavailable
+	}
+
+	// the same key on a different request is a client bug, not a retry
+	if existing.RequestHash != record.RequestHash {
+		log.Warn("idempotency key reused for a different request", "user", user, "method", method, "path", path)
+		return nil, false, errs.ErrIdempotencyKeyReused
+	}
+
+	if !existing.Completed() {
+		log.Warn("idempotency key still in progress", "user", user, "method", method, "path", path)
+		return nil, false, errs.ErrIdempotencyKeyInProgress
+	}
+
+	metrics.IncIdempotentReplay()
+	log.Info("replaying idempotent response", "user", user, "method", method, "path", path, "status", existing.StatusCode)
+	return existing, true, nil
+}
+
+func (s *idempotencyService) Complete(ctx context.Context, record *model.IdempotencyKey, statusCode int, headers map[string]string, body []byte) error {
+	log := util.LoggerFromContext(ctx)
+
+	record.StatusCode = statusCode
+	record.ResponseHeaders = headers
+	record.ResponseBody = body
+	if err := s.repo.Complete(ctx, record); err != nil {
+		log.Error("failed to store idempotent response", "user", record.UserID, "error", err)
+		return errs.ErrDatabaseUnavailable
+	}
+
+	return nil
+}
+
+// frees the key so a retry runs the request again, used when the first attempt didn't produce a response worth keeping
+func (s *idempotencyService) Release(ctx context.Context, record *model.IdempotencyKey) error {
+	log := util.LoggerFromContext(ctx)
+
+	if err := s.repo.Release(ctx, record); err != nil {
+		log.Error("failed to release idempotency key", "user", record.UserID, "error", err)
+		return errs.ErrDatabaseUnavailable
+	}
+
+	return nil
+}
+
+func (s *idempotencyService) PurgeExpired(ctx context.Context) (int64, error) {
+	log := util.LoggerFromContext(ctx)
+
+	purged, err := s.repo.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		log.Error("failed to purge expired idempotency keys", "error", err)
+		return 0, errs.ErrDatabaseUnavailable
+	}
+
+	return purged, nil
+}
+
+func requestHash(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
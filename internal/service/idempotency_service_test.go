@@ -0,0 +1,138 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/mocks"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// stores whatever Reserve was handed so a later Get can return it
+func reservedBy(repo *mocks.IdempotencyRepository, hash *string) {
+	repo.EXPECT().Reserve(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, r *model.IdempotencyKey, _ time.Time) { *hash = r.RequestHash }).
+		Return(false, nil).Once()
+}
+
+func TestIdempotencyBegin_InvalidKey(t *testing.T) {
+	svc := service.NewIdempotencyService(mocks.NewIdempotencyRepository(t))
+
+	_, _, err := svc.Begin(context.Background(), "u", strings.Repeat("k", 256), "PUT", "/contests", nil)
+	assert.ErrorIs(t, err, errs.ErrInvalidIdempotencyKey)
+}
+
+func TestIdempotencyBegin_Reserved(t *testing.T) {
+	repo := mocks.NewIdempotencyRepository(t)
+	repo.EXPECT().Reserve(mock.Anything, mock.MatchedBy(func(r *model.IdempotencyKey) bool {
+		return r.UserID == "u" && r.Key == "k" && r.RequestHash != "" && r.ExpiresAt.After(r.CreatedAt)
+	}), mock.MatchedBy(func(staleBefore time.Time) bool {
+		// only attempts that have been in flight for a while can be taken over
+		return time.Since(staleBefore) >= time.Minute
+	})).Return(true, nil)
+
+	record, replay, err := service.NewIdempotencyService(repo).
+		Begin(context.Background(), "u", "k", "PUT", "/contests", []byte(`{}`))
+	require.NoError(t, err)
+	assert.False(t, replay)
+	assert.Equal(t, "k", record.Key)
+}
+
+func TestIdempotencyBegin_ReserveError(t *testing.T) {
+	repo := mocks.NewIdempotencyRepository(t)
+	repo.EXPECT().Reserve(mock.Anything, mock.Anything, mock.Anything).Return(false, errors.New("db"))
+
+	_, _, err := service.NewIdempotencyService(repo).
+		Begin(context.Background(), "u", "k", "PUT", "/contests", nil)
+	assert.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
+}
+
+func TestIdempotencyBegin_Replay(t *testing.T) {
+	var hash string
+	repo := mocks.NewIdempotencyRepository(t)
+	reservedBy(repo, &hash)
+	repo.EXPECT().Get(mock.Anything, "u", "k").RunAndReturn(func(context.Context, string, string) (*model.IdempotencyKey, error) {
+		return &model.IdempotencyKey{UserID: "u", Key: "k", RequestHash: hash, StatusCode: 200, ResponseBody: []byte(`{"id":"1"}`)}, nil
+	})
+
+	record, replay, err := service.NewIdempotencyService(repo).
+		Begin(context.Background(), "u", "k", "PUT", "/contests", []byte(`{"name":"a"}`))
+	require.NoError(t, err)
+	assert.True(t, replay)
+	assert.Equal(t, 200, record.StatusCode)
+	assert.JSONEq(t, `{"id":"1"}`, string(record.ResponseBody))
+}
+
+func TestIdempotencyBegin_DifferentBody(t *testing.T) {
+	var hash string
+	repo := mocks.NewIdempotencyRepository(t)
+	reservedBy(repo, &hash)
+	repo.EXPECT().Get(mock.Anything, "u", "k").
+		Return(&model.IdempotencyKey{RequestHash: "something-else", StatusCode: 200}, nil)
+
+	_, _, err := service.NewIdempotencyService(repo).
+		Begin(context.Background(), "u", "k", "PUT", "/contests", []byte(`{"name":"b"}`))
+	assert.ErrorIs(t, err, errs.ErrIdempotencyKeyReused)
+}
+
+func TestIdempotencyBegin_InProgress(t *testing.T) {
+	var hash string
+	repo := mocks.NewIdempotencyRepository(t)
+	reservedBy(repo, &hash)
+	repo.EXPECT().Get(mock.Anything, "u", "k").RunAndReturn(func(context.Context, string, string) (*model.IdempotencyKey, error) {
+		return &model.IdempotencyKey{RequestHash: hash}, nil
+	})
+
+	_, _, err := service.NewIdempotencyService(repo).
+		Begin(context.Background(), "u", "k", "PUT", "/contests", nil)
+	assert.ErrorIs(t, err, errs.ErrIdempotencyKeyInProgress)
+}
+
+func TestIdempotencyBegin_ReleasedMeanwhile(t *testing.T) {
+	var hash string
+	repo := mocks.NewIdempotencyRepository(t)
+	reservedBy(repo, &hash)
+	repo.EXPECT().Get(mock.Anything, "u", "k").Return(nil, gorm.ErrRecordNotFound)
+
+	_, _, err := service.NewIdempotencyService(repo).
+		Begin(context.Background(), "u", "k", "PUT", "/contests", nil)
+	assert.ErrorIs(t, err, errs.ErrIdempotencyKeyInProgress)
+}
+
+func TestIdempotencyComplete(t *testing.T) {
+	repo := mocks.NewIdempotencyRepository(t)
+	repo.EXPECT().Complete(mock.Anything, mock.MatchedBy(func(r *model.IdempotencyKey) bool {
+		return r.StatusCode == 201 && string(r.ResponseBody) == "ok" && r.ResponseHeaders["ETag"] == `"3"`
+	})).Return(nil)
+
+	err := service.NewIdempotencyService(repo).
+		Complete(context.Background(), &model.IdempotencyKey{UserID: "u", Key: "k"}, 201, map[string]string{"ETag": `"3"`}, []byte("ok"))
+	require.NoError(t, err)
+}
+
+func TestIdempotencyRelease_Error(t *testing.T) {
+	repo := mocks.NewIdempotencyRepository(t)
+	record := &model.IdempotencyKey{UserID: "u", Key: "k"}
+	repo.EXPECT().Release(mock.Anything, record).Return(errors.New("db"))
+
+	err := service.NewIdempotencyService(repo).Release(context.Background(), record)
+	assert.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
+}
+
+func TestIdempotencyPurgeExpired(t *testing.T) {
+	repo := mocks.NewIdempotencyRepository(t)
+	repo.EXPECT().DeleteExpired(mock.Anything, mock.Anything).Return(int64(4), nil)
+
+	purged, err := service.NewIdempotencyService(repo).PurgeExpired(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), purged)
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"mime/multipart"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/metrics"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/templates"
+	"github.com/maxmorhardt/squares-api/internal/util"
+)
+
+var inviteEmailTmpl = template.Must(template.New("invite_email").Parse(templates.InviteEmailHTML))
+
+type inviteEmailData struct {
+	ContestName string
+	Inviter     string
+	Email       string
+	Role        string
+	MaxSquares  int
+	ExpiresAt   string
+	AcceptURL   string
+	PixelURL    string
+}
+
+// sends each invite on its own so one bad address doesn't hold up the rest of the batch
+func (s *inviteService) sendInviteEmails(ctx context.Context, contest *model.Contest, invites []model.ContestInvite, inviter string) {
+	log := util.LoggerFromContext(ctx)
+
+	for i := range invites {
+		invite := &invites[i]
+
+		status := model.InviteEmailStatusSent
+		if err := s.sendInviteEmail(contest, invite, inviter); err != nil {
+			log.Error("failed to send invite email", "invite_id", invite.ID, "contest_id", contest.ID, "error", err)
+			status = model.InviteEmailStatusFailed
+		}
+		metrics.IncInviteEmail(string(status))
+
+		if err := s.inviteRepo.UpdateEmailStatus(ctx, invite.ID, status); err != nil {
+			log.Error("failed to update invite email status", "invite_id", invite.ID, "status", status, "error", err)
+			continue
+		}
+
+		log.Info("invite email processed", "invite_id", invite.ID, "contest_id", contest.ID, "status", status)
+	}
+}
+
+func (s *inviteService) sendInviteEmail(contest *model.Contest, invite *model.ContestInvite, inviter string) error {
+	msg, err := s.buildInviteEmail(contest, invite, inviter)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errs.ErrInviteEmail, err)
+	}
+
+	if err := s.mailer.Send([]string{invite.Email}, msg); err != nil {
+		return fmt.Errorf("%w: %w", errs.ErrInviteEmail, err)
+	}
+
+	return nil
+}
+
+func (s *inviteService) buildInviteEmail(contest *model.Contest, invite *model.ContestInvite, inviter string) ([]byte, error) {
+	data := inviteEmailData{
+		ContestName: contest.Name,
+		Inviter:     inviter,
+		Email:       invite.Email,
+		Role:        string(invite.Role),
+		MaxSquares:  invite.MaxSquares,
+		AcceptURL:   strings.TrimRight(s.serverCfg.AppURL, "/") + "/invites/" + url.PathEscape(invite.Token),
+	}
+	if invite.ExpiresAt != nil {
+		data.ExpiresAt = invite.ExpiresAt.UTC().Format(time.RFC1123)
+	}
+
+	// open tracking needs a URL the recipient's mail client can reach; without one, opens are still recorded on preview
+	if s.serverCfg.PublicAPIURL != "" {
+		data.PixelURL = strings.TrimRight(s.serverCfg.PublicAPIURL, "/") + "/invites/" + url.PathEscape(invite.Token) + "/open.gif"
+	}
+
+	var htmlBody bytes.Buffer
+	if err := inviteEmailTmpl.Execute(&htmlBody, data); err != nil {
+		return nil, fmt.Errorf("failed to render invite email template: %w", err)
+	}
+
+	plainBody := fmt.Sprintf(
+		"%s invited you to join %s on Squares.\n\n"+
+			"This invite is for %s. Sign in with that address to accept it.\n\n"+
+			"Accept the invite: %s\n",
+		data.Inviter,
+		data.ContestName,
+		data.Email,
+		data.AcceptURL,
+	)
+
+	var msg bytes.Buffer
+
+	// top-level headers
+	fmt.Fprintf(&msg, "From: %s\r\n", sanitizeHeader(s.mailer.From()))
+	fmt.Fprintf(&msg, "To: %s\r\n", sanitizeHeader(invite.Email))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", sanitizeHeader(fmt.Sprintf("You're invited to %s", contest.Name)))
+	fmt.Fprintf(&msg, "Reply-To: %s\r\n", sanitizeHeader(inviter))
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+
+	mpw := multipart.NewWriter(&msg)
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%q\r\n", mpw.Boundary())
+	fmt.Fprintf(&msg, "\r\n")
+
+	if err := writeMIMEPart(mpw, `text/plain; charset="UTF-8"`, []byte(plainBody)); err != nil {
+		return nil, err
+	}
+	if err := writeMIMEPart(mpw, `text/html; charset="UTF-8"`, htmlBody.Bytes()); err != nil {
+		return nil, err
+	}
+
+	if err := mpw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+
+	return msg.Bytes(), nil
+}
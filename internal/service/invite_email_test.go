@@ -0,0 +1,186 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/mocks"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func emailInviteSvc(t *testing.T, inv *mocks.InviteRepository, p *mocks.ParticipantRepository, c *mocks.ContestRepository, mailer *mocks.Mailer) service.InviteService {
+	cfg := model.ServerConfig{AppURL: "https://squares.example.com/", PublicAPIURL: "https://api.example.com"}
+	return service.NewInviteService(inv, p, c, nil, okAuth(t), anyNats(), mailer, cfg)
+}
+
+func TestCreateEmailInvites_SendsOnePerRecipient(t *testing.T) {
+	contestID := uuid.New()
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, contestID).Return(&model.Contest{ID: contestID, Name: "Family Pool", Status: model.ContestStatusActive}, nil)
+	p := mocks.NewParticipantRepository(t)
+	p.EXPECT().GetByContestAndUser(mock.Anything, contestID, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+	inv := mocks.NewInviteRepository(t)
+	inv.EXPECT().CreateMany(mock.Anything, mock.MatchedBy(func(invites []*model.ContestInvite) bool {
+		for _, i := range invites {
+			if i.MaxUses != 1 || i.EmailStatus != model.InviteEmailStatusPending || i.ExpiresAt == nil {
+				return false
+			}
+		}
+		return len(invites) == 2 && invites[0].Email == "alice@example.com" && invites[1].Email == "bob@example.com"
+	})).Run(func(_ context.Context, invites []*model.ContestInvite) {
+		for _, i := range invites {
+			i.ID = uuid.New()
+			i.Token = "tok-" + i.Email
+		}
+	}).Return(nil)
+
+	var mu sync.Mutex
+	statuses := map[uuid.UUID]model.InviteEmailStatus{}
+	done := make(chan struct{}, 2)
+	inv.EXPECT().UpdateEmailStatus(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, id uuid.UUID, status model.InviteEmailStatus) {
+			mu.Lock()
+			statuses[id] = status
+			mu.Unlock()
+			done <- struct{}{}
+		}).Return(nil)
+
+	mailer := mocks.NewMailer(t)
+	mailer.EXPECT().From().Return("noreply@example.com")
+	mailer.EXPECT().Send([]string{"alice@example.com"}, mock.MatchedBy(func(msg []byte) bool {
+		s := string(msg)
+		return strings.Contains(s, "To: alice@example.com") &&
+			strings.Contains(s, "https://squares.example.com/invites/tok-alice@example.com") &&
+			strings.Contains(s, "https://api.example.com/invites/tok-alice@example.com/open.gif")
+	})).Return(nil)
+	mailer.EXPECT().Send([]string{"bob@example.com"}, mock.Anything).Return(errors.New("mailbox unavailable"))
+
+	req := &model.CreateEmailInvitesRequest{
+		Emails:     []string{"Alice@Example.com", "bob@example.com", " alice@example.com "},
+		Role:       "participant",
+		MaxSquares: 5,
+		ExpiresIn:  60,
+	}
+	invites, err := emailInviteSvc(t, inv, p, c, mailer).CreateEmailInvites(context.Background(), contestID, req, "owner@example.com")
+	require.NoError(t, err)
+	require.Len(t, invites, 2)
+
+	for range 2 {
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("invite emails were not processed")
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, model.InviteEmailStatusSent, statuses[invites[0].ID])
+	assert.Equal(t, model.InviteEmailStatusFailed, statuses[invites[1].ID])
+}
+
+func TestCreateEmailInvites_RecipientAlreadyJoined(t *testing.T) {
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
+	p := mocks.NewParticipantRepository(t)
+	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, "alice@example.com").Return(&model.ContestParticipant{}, nil)
+
+	_, err := emailInviteSvc(t, mocks.NewInviteRepository(t), p, c, mocks.NewMailer(t)).CreateEmailInvites(context.Background(), uuid.New(),
+		&model.CreateEmailInvitesRequest{Emails: []string{"alice@example.com"}, Role: "viewer"}, "owner")
+	assert.ErrorIs(t, err, errs.ErrInviteeAlreadyJoined)
+}
+
+func TestCreateEmailInvites_InvalidSquareCount(t *testing.T) {
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
+
+	_, err := emailInviteSvc(t, mocks.NewInviteRepository(t), mocks.NewParticipantRepository(t), c, mocks.NewMailer(t)).CreateEmailInvites(context.Background(), uuid.New(),
+		&model.CreateEmailInvitesRequest{Emails: []string{"alice@example.com"}, Role: "participant"}, "owner")
+	assert.ErrorIs(t, err, errs.ErrInvalidSquareCount)
+}
+
+func TestRedeemInvite_PersonalInviteWrongEmail(t *testing.T) {
+	inv := mocks.NewInviteRepository(t)
+	inv.EXPECT().GetByToken(mock.Anything, mock.Anything).Return(&model.ContestInvite{Email: "alice@example.com", MaxUses: 1}, nil)
+
+	_, err := inviteSvc(inv, mocks.NewParticipantRepository(t), mocks.NewContestRepository(t), mocks.NewParticipantService(t)).
+		RedeemInvite(context.Background(), "tok", "mallory@example.com")
+	assert.ErrorIs(t, err, errs.ErrInviteEmailMismatch)
+}
+
+func TestRedeemInvite_PersonalInviteMatchesCaseInsensitively(t *testing.T) {
+	invite := &model.ContestInvite{ID: uuid.New(), ContestID: uuid.New(), Email: "alice@example.com", MaxUses: 1, Role: model.ParticipantRoleViewer}
+	inv := mocks.NewInviteRepository(t)
+	inv.EXPECT().GetByToken(mock.Anything, mock.Anything).Return(invite, nil)
+	inv.EXPECT().RedeemInvite(mock.Anything, invite, mock.Anything, mock.Anything).Return(nil, nil)
+	inv.EXPECT().GetReservationsByInviteID(mock.Anything, mock.Anything).Return(nil, nil)
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
+	p := mocks.NewParticipantRepository(t)
+	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+	p.EXPECT().IsBanned(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	p.EXPECT().GetTotalAllocatedSquares(mock.Anything, mock.Anything).Return(0, nil)
+
+	_, err := inviteSvc(inv, p, c, mocks.NewParticipantService(t)).RedeemInvite(context.Background(), "tok", "Alice@Example.com")
+	require.NoError(t, err)
+}
+
+func TestGetInvitePreview_PersonalInviteMarksOpened(t *testing.T) {
+	invite := &model.ContestInvite{ID: uuid.New(), ContestID: uuid.New(), Email: "alice@example.com", EmailStatus: model.InviteEmailStatusSent}
+	inv := mocks.NewInviteRepository(t)
+	inv.EXPECT().GetByToken(mock.Anything, "tok").Return(invite, nil)
+	inv.EXPECT().MarkOpened(mock.Anything, invite.ID).Return(nil)
+	inv.EXPECT().GetReservationsByInviteID(mock.Anything, mock.Anything).Return(nil, nil)
+	inv.EXPECT().RecordView(mock.Anything, invite, mock.Anything).Return(nil)
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Name: "Pool"}, nil)
+
+	got, err := inviteSvc(inv, mocks.NewParticipantRepository(t), c, mocks.NewParticipantService(t)).GetInvitePreview(context.Background(), "tok", "203.0.113.7", "ua")
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", got.Email)
+}
+
+func TestMarkInviteOpened(t *testing.T) {
+	opened := time.Now()
+	for _, tc := range []struct {
+		name   string
+		invite *model.ContestInvite
+		err    error
+		mark   bool
+		want   error
+	}{
+		{name: "personal", invite: &model.ContestInvite{Email: "a@example.com"}, mark: true},
+		{name: "already opened", invite: &model.ContestInvite{Email: "a@example.com", OpenedAt: &opened}},
+		{name: "bearer invite", invite: &model.ContestInvite{}},
+		{name: "not found", err: gorm.ErrRecordNotFound, want: errs.ErrInviteNotFound},
+		{name: "db error", err: errors.New("boom"), want: errs.ErrDatabaseUnavailable},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			inv := mocks.NewInviteRepository(t)
+			inv.EXPECT().GetByToken(mock.Anything, "tok").Return(tc.invite, tc.err)
+			if tc.mark {
+				inv.EXPECT().MarkOpened(mock.Anything, mock.Anything).Return(nil)
+			}
+
+			err := inviteSvc(inv, mocks.NewParticipantRepository(t), mocks.NewContestRepository(t), mocks.NewParticipantService(t)).
+				MarkInviteOpened(context.Background(), "tok")
+			if tc.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.want)
+			}
+		})
+	}
+}
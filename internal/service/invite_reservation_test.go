@@ -0,0 +1,172 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/mocks"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func contestInStatus(t *testing.T, status model.ContestStatus) *mocks.ContestRepository {
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: status}, nil)
+	return c
+}
+
+func TestCreateInvite_ReservesSquares(t *testing.T) {
+	inv := mocks.NewInviteRepository(t)
+	inv.EXPECT().CreateWithReservations(mock.Anything, mock.Anything, []model.SquarePosition{{Row: 1, Col: 2}, {Row: 4, Col: 4}}).
+		Return([]model.Square{{Row: 1, Col: 2}, {Row: 4, Col: 4}}, nil)
+
+	// repeated positions collapse to one reservation
+	req := &model.CreateInviteRequest{
+		Role:            "participant",
+		MaxSquares:      2,
+		ReservedSquares: []model.SquarePosition{{Row: 1, Col: 2}, {Row: 4, Col: 4}, {Row: 1, Col: 2}},
+	}
+	got, err := inviteSvc(inv, mocks.NewParticipantRepository(t), contestInStatus(t, model.ContestStatusActive), okAuth(t)).
+		CreateInvite(context.Background(), uuid.New(), req, "owner")
+	require.NoError(t, err)
+	assert.Equal(t, []model.SquarePosition{{Row: 1, Col: 2}, {Row: 4, Col: 4}}, got.ReservedSquares)
+}
+
+func TestCreateInvite_ReservationRejected(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		status model.ContestStatus
+		req    model.CreateInviteRequest
+		want   error
+	}{
+		{
+			name:   "more squares than allotted",
+			status: model.ContestStatusActive,
+			req:    model.CreateInviteRequest{Role: "participant", MaxSquares: 1, ReservedSquares: []model.SquarePosition{{Row: 0, Col: 0}, {Row: 0, Col: 1}}},
+			want:   errs.ErrTooManyReservedSquares,
+		},
+		{
+			name:   "viewer",
+			status: model.ContestStatusActive,
+			req:    model.CreateInviteRequest{Role: "viewer", ReservedSquares: []model.SquarePosition{{Row: 0, Col: 0}}},
+			want:   errs.ErrViewerCannotHaveSquares,
+		},
+		{
+			name:   "contest already started",
+			status: model.ContestStatusQ1,
+			req:    model.CreateInviteRequest{Role: "participant", MaxSquares: 1, ReservedSquares: []model.SquarePosition{{Row: 0, Col: 0}}},
+			want:   errs.ErrSquareNotEditable,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := inviteSvc(mocks.NewInviteRepository(t), mocks.NewParticipantRepository(t), contestInStatus(t, tc.status), okAuth(t)).
+				CreateInvite(context.Background(), uuid.New(), &tc.req, "owner")
+			assert.ErrorIs(t, err, tc.want)
+		})
+	}
+}
+
+func TestCreateInvite_SquareTaken(t *testing.T) {
+	inv := mocks.NewInviteRepository(t)
+	inv.EXPECT().CreateWithReservations(mock.Anything, mock.Anything, mock.Anything).Return(nil, errs.ErrSquareNotReservable)
+
+	req := &model.CreateInviteRequest{Role: "participant", MaxSquares: 1, ReservedSquares: []model.SquarePosition{{Row: 5, Col: 5}}}
+	_, err := inviteSvc(inv, mocks.NewParticipantRepository(t), contestInStatus(t, model.ContestStatusActive), okAuth(t)).
+		CreateInvite(context.Background(), uuid.New(), req, "owner")
+	assert.ErrorIs(t, err, errs.ErrSquareNotReservable)
+}
+
+func reservedRedeemMocks(t *testing.T, invite *model.ContestInvite) (*mocks.InviteRepository, *mocks.ParticipantRepository) {
+	inv := mocks.NewInviteRepository(t)
+	inv.EXPECT().GetByToken(mock.Anything, mock.Anything).Return(invite, nil)
+	inv.EXPECT().GetReservationsByInviteID(mock.Anything, invite.ID).Return([]model.SquarePosition{{Row: 2, Col: 3}}, nil)
+	p := mocks.NewParticipantRepository(t)
+	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+	p.EXPECT().IsBanned(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	p.EXPECT().GetTotalAllocatedSquares(mock.Anything, mock.Anything).Return(0, nil)
+	return inv, p
+}
+
+func TestRedeemInvite_ClaimsReservedSquares(t *testing.T) {
+	invite := &model.ContestInvite{ID: uuid.New(), ContestID: uuid.New(), Role: model.ParticipantRoleParticipant, MaxSquares: 3}
+	inv, p := reservedRedeemMocks(t, invite)
+	inv.EXPECT().RedeemInvite(mock.Anything, invite, mock.Anything, &model.Square{Value: "AB", OwnerName: "Alice"}).
+		Return([]model.Square{{Row: 2, Col: 3, Value: "AB", Owner: "alice@example.com"}}, nil)
+
+	svc := service.NewInviteService(inv, p, contestInStatus(t, model.ContestStatusActive), anyUser(), okAuth(t), anyNats(), nil, model.ServerConfig{})
+	ctx := context.WithValue(context.Background(), model.ClaimsKey, &model.Claims{Name: "Alice"})
+	participant, err := svc.RedeemInvite(ctx, "tok", "alice@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, 3, participant.MaxSquares)
+}
+
+func TestRedeemInvite_StartedContestSkipsReservedSquares(t *testing.T) {
+	invite := &model.ContestInvite{ID: uuid.New(), ContestID: uuid.New(), Role: model.ParticipantRoleParticipant, MaxSquares: 3}
+	inv, p := reservedRedeemMocks(t, invite)
+	// the board is locked, so the invitee joins without the held squares
+	inv.EXPECT().RedeemInvite(mock.Anything, invite, mock.Anything, (*model.Square)(nil)).Return(nil, nil)
+
+	svc := service.NewInviteService(inv, p, contestInStatus(t, model.ContestStatusQ1), mocks.NewUserRepository(t), okAuth(t), anyNats(), nil, model.ServerConfig{})
+	_, err := svc.RedeemInvite(context.Background(), "tok", "alice@example.com")
+	require.NoError(t, err)
+}
+
+func TestRedeemInvite_ReservedSquaresNeedInitials(t *testing.T) {
+	invite := &model.ContestInvite{ID: uuid.New(), ContestID: uuid.New(), Role: model.ParticipantRoleParticipant, MaxSquares: 3}
+	inv, p := reservedRedeemMocks(t, invite)
+	users := mocks.NewUserRepository(t)
+	users.EXPECT().GetOrCreate(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&model.User{Email: "u"}, nil)
+
+	svc := service.NewInviteService(inv, p, contestInStatus(t, model.ContestStatusActive), users, okAuth(t), anyNats(), nil, model.ServerConfig{})
+	ctx := context.WithValue(context.Background(), model.ClaimsKey, &model.Claims{})
+	_, err := svc.RedeemInvite(ctx, "tok", "u")
+	assert.ErrorIs(t, err, errs.ErrMissingInitials)
+}
+
+func TestRedeemInvite_ReservationLookupFails(t *testing.T) {
+	inv := mocks.NewInviteRepository(t)
+	inv.EXPECT().GetByToken(mock.Anything, mock.Anything).Return(&model.ContestInvite{MaxSquares: 1}, nil)
+	inv.EXPECT().GetReservationsByInviteID(mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+	p := mocks.NewParticipantRepository(t)
+	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+	p.EXPECT().IsBanned(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	p.EXPECT().GetTotalAllocatedSquares(mock.Anything, mock.Anything).Return(0, nil)
+
+	_, err := inviteSvc(inv, p, contestInStatus(t, model.ContestStatusActive), mocks.NewParticipantService(t)).
+		RedeemInvite(context.Background(), "tok", "u")
+	assert.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
+}
+
+func TestReleaseExpiredReservations_PublishesPerContest(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().ReleaseExpiredReservations(mock.Anything).Return([]model.Square{
+		{ContestID: first, Row: 0, Col: 0},
+		{ContestID: second, Row: 1, Col: 1},
+		{ContestID: first, Row: 0, Col: 1},
+	}, nil)
+	nats := mocks.NewNatsService(t)
+	nats.EXPECT().PublishSquaresUpdate(first, "system", mock.MatchedBy(func(sq []model.Square) bool { return len(sq) == 2 })).Return(nil)
+	nats.EXPECT().PublishSquaresUpdate(second, "system", mock.MatchedBy(func(sq []model.Square) bool { return len(sq) == 1 })).Return(nil)
+
+	svc := service.NewContestService(repo, mocks.NewParticipantRepository(t), &mocks.GameRepository{}, anyUser(), &mocks.OrganizationRepository{}, nats, mocks.NewParticipantService(t), anyAnalytics(), lifecycleCfg)
+	released, err := svc.ReleaseExpiredReservations(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, released)
+}
+
+func TestReleaseExpiredReservations_RepoError(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().ReleaseExpiredReservations(mock.Anything).Return(nil, errors.New("db"))
+
+	_, err := contestSvc(repo, mocks.NewParticipantRepository(t), mocks.NewParticipantService(t)).
+		ReleaseExpiredReservations(context.Background())
+	assert.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
+}
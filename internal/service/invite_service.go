@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,9 +19,12 @@ import (
 
 type InviteService interface {
 	CreateInvite(ctx context.Context, contestID uuid.UUID, req *model.CreateInviteRequest, user string) (*model.ContestInvite, error)
-	GetInvitePreview(ctx context.Context, token string) (*model.InvitePreviewResponse, error)
+	CreateEmailInvites(ctx context.Context, contestID uuid.UUID, req *model.CreateEmailInvitesRequest, user string) ([]model.ContestInvite, error)
+	GetInvitePreview(ctx context.Context, token, ipAddress, userAgent string) (*model.InvitePreviewResponse, error)
+	MarkInviteOpened(ctx context.Context, token string) error
 	RedeemInvite(ctx context.Context, token, user string) (*model.ContestParticipant, error)
 	GetInvitesByContestID(ctx context.Context, contestID uuid.UUID, user string) ([]model.ContestInvite, error)
+	UpdateInvite(ctx context.Context, contestID, inviteID uuid.UUID, req *model.UpdateInviteRequest, user string) (*model.ContestInvite, error)
 	DeleteInvite(ctx context.Context, contestID, inviteID uuid.UUID, user string) error
 }
 
@@ -26,30 +32,182 @@ type inviteService struct {
 	inviteRepo         repository.InviteRepository
 	participantRepo    repository.ParticipantRepository
 	contestRepo        repository.ContestRepository
+	userRepo           repository.UserRepository
 	participantService ParticipantService
 	natsService        NatsService
+	mailer             Mailer
+	serverCfg          model.ServerConfig
 }
 
 func NewInviteService(
 	inviteRepo repository.InviteRepository,
 	participantRepo repository.ParticipantRepository,
 	contestRepo repository.ContestRepository,
+	userRepo repository.UserRepository,
 	participantService ParticipantService,
 	natsService NatsService,
+	mailer Mailer,
+	serverCfg model.ServerConfig,
 ) InviteService {
 	return &inviteService{
 		inviteRepo:         inviteRepo,
 		participantRepo:    participantRepo,
 		contestRepo:        contestRepo,
+		userRepo:           userRepo,
 		participantService: participantService,
 		natsService:        natsService,
+		mailer:             mailer,
+		serverCfg:          serverCfg,
 	}
 }
 
 func (s *inviteService) CreateInvite(ctx context.Context, contestID uuid.UUID, req *model.CreateInviteRequest, user string) (*model.ContestInvite, error) {
 	log := util.LoggerFromContext(ctx)
 
-	// reject invites once the contest is in a terminal state
+	contest, err := s.getInvitableContest(ctx, contestID, user)
+	if err != nil {
+		return nil, err
+	}
+
+	role, maxSquares, err := inviteGrant(req.Role, req.MaxSquares)
+	if err != nil {
+		return nil, err
+	}
+
+	reserved := uniquePositions(req.ReservedSquares)
+	if len(reserved) > 0 {
+		if role == model.ParticipantRoleViewer {
+			return nil, errs.ErrViewerCannotHaveSquares
+		}
+		if len(reserved) > maxSquares {
+			return nil, errs.ErrTooManyReservedSquares
+		}
+		// holding squares only means something while they can still be claimed
+		if contest.Status != model.ContestStatusActive {
+			log.Warn("cannot reserve squares when contest is not active", "contest_id", contestID, "contest_status", contest.Status)
+			return nil, errs.ErrSquareNotEditable
+		}
+	}
+
+	// build invite
+	invite := &model.ContestInvite{
+		ContestID:  contestID,
+		MaxSquares: maxSquares,
+		Role:       role,
+		CreatedBy:  user,
+		MaxUses:    req.MaxUses,
+		ExpiresAt:  inviteExpiry(req.ExpiresIn),
+	}
+
+	// stored lowercase so redemption compares against the verified claim without surprises
+	if domains := normalizeAllowlist(req.AllowedDomains); len(domains) > 0 {
+		invite.AllowedDomains = domains
+	}
+	if emails := normalizeAllowlist(req.AllowedEmails); len(emails) > 0 {
+		invite.AllowedEmails = emails
+	}
+
+	if len(reserved) == 0 {
+		if err := s.inviteRepo.Create(ctx, invite); err != nil {
+			log.Error("failed to create invite", "contest_id", contestID, "error", err)
+			return nil, err
+		}
+	} else {
+		reservedSquares, err := s.inviteRepo.CreateWithReservations(ctx, invite, reserved)
+		if err != nil {
+			if errors.Is(err, errs.ErrSquareNotReservable) {
+				log.Warn("requested square cannot be reserved", "contest_id", contestID, "error", err)
+				return nil, err
+			}
+			log.Error("failed to create invite with reservations", "contest_id", contestID, "error", err)
+			return nil, err
+		}
+
+		invite.ReservedSquares = reserved
+		s.publishSquares(ctx, contestID, user, reservedSquares)
+	}
+
+	metrics.IncInviteCreated()
+	log.Info("invite created", "invite_id", invite.ID, "contest_id", contestID, "token", invite.Token, "reserved_squares", len(reserved))
+	return invite, nil
+}
+
+func (s *inviteService) CreateEmailInvites(ctx context.Context, contestID uuid.UUID, req *model.CreateEmailInvitesRequest, user string) ([]model.ContestInvite, error) {
+	log := util.LoggerFromContext(ctx)
+
+	contest, err := s.getInvitableContest(ctx, contestID, user)
+	if err != nil {
+		return nil, err
+	}
+
+	role, maxSquares, err := inviteGrant(req.Role, req.MaxSquares)
+	if err != nil {
+		return nil, err
+	}
+
+	// participants are keyed by their verified email, so addresses compare case-insensitively
+	emails := make([]string, 0, len(req.Emails))
+	seen := make(map[string]bool, len(req.Emails))
+	for _, email := range req.Emails {
+		email = strings.ToLower(strings.TrimSpace(email))
+		if seen[email] {
+			continue
+		}
+		seen[email] = true
+		emails = append(emails, email)
+	}
+
+	for _, email := range emails {
+		_, err := s.participantRepo.GetByContestAndUser(ctx, contestID, email)
+		if err == nil {
+			log.Warn("invitee already a participant", "contest_id", contestID, "email", email)
+			return nil, errs.ErrInviteeAlreadyJoined
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Error("failed to check existing participant", "contest_id", contestID, "error", err)
+			return nil, errs.ErrDatabaseUnavailable
+		}
+	}
+
+	// each recipient gets a single-use invite of their own so status is tracked per address
+	expiresAt := inviteExpiry(req.ExpiresIn)
+	invites := make([]*model.ContestInvite, len(emails))
+	for i, email := range emails {
+		invites[i] = &model.ContestInvite{
+			ContestID:   contestID,
+			MaxSquares:  maxSquares,
+			Role:        role,
+			CreatedBy:   user,
+			MaxUses:     1,
+			ExpiresAt:   expiresAt,
+			Email:       email,
+			EmailStatus: model.InviteEmailStatusPending,
+		}
+	}
+
+	if err := s.inviteRepo.CreateMany(ctx, invites); err != nil {
+		log.Error("failed to create email invites", "contest_id", contestID, "error", err)
+		return nil, err
+	}
+
+	created := make([]model.ContestInvite, len(invites))
+	for i, invite := range invites {
+		created[i] = *invite
+		metrics.IncInviteCreated()
+	}
+
+	// delivery happens after the response; the sender works on its own copy and outlives the request
+	pending := append([]model.ContestInvite(nil), created...)
+	go s.sendInviteEmails(context.WithoutCancel(ctx), contest, pending, user)
+
+	log.Info("email invites created", "contest_id", contestID, "count", len(created))
+	return created, nil
+}
+
+// rejects invites once the contest is in a terminal state or the user can't manage them
+func (s *inviteService) getInvitableContest(ctx context.Context, contestID uuid.UUID, user string) (*model.Contest, error) {
+	log := util.LoggerFromContext(ctx)
+
 	contest, err := s.contestRepo.GetByID(ctx, contestID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -69,39 +227,71 @@ func (s *inviteService) CreateInvite(ctx context.Context, contestID uuid.UUID, r
 		return nil, err
 	}
 
-	// viewers never consume squares; participants must be granted at least one
-	role := model.ParticipantRole(req.Role)
+	return contest, nil
+}
+
+// viewers never consume squares; participants must be granted at least one
+func inviteGrant(roleName string, maxSquares int) (model.ParticipantRole, int, error) {
+	role := model.ParticipantRole(roleName)
 	if role == model.ParticipantRoleViewer {
-		req.MaxSquares = 0
-	} else if req.MaxSquares < 1 {
-		return nil, errs.ErrInvalidSquareCount
+		return role, 0, nil
+	}
+	if maxSquares < 1 {
+		return role, 0, errs.ErrInvalidSquareCount
 	}
+	return role, maxSquares, nil
+}
 
-	// build invite
-	invite := &model.ContestInvite{
-		ContestID:  contestID,
-		MaxSquares: req.MaxSquares,
-		Role:       role,
-		CreatedBy:  user,
-		MaxUses:    req.MaxUses,
+func normalizeAllowlist(entries []string) []string {
+	var out []string
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" || seen[entry] {
+			continue
+		}
+		seen[entry] = true
+		out = append(out, entry)
 	}
+	return out
+}
 
-	if req.ExpiresIn > 0 {
-		expiresAt := time.Now().Add(time.Duration(req.ExpiresIn) * time.Minute)
-		invite.ExpiresAt = &expiresAt
+func uniquePositions(positions []model.SquarePosition) []model.SquarePosition {
+	var out []model.SquarePosition
+	seen := make(map[model.SquarePosition]bool, len(positions))
+	for _, pos := range positions {
+		if seen[pos] {
+			continue
+		}
+		seen[pos] = true
+		out = append(out, pos)
 	}
+	return out
+}
 
-	if err := s.inviteRepo.Create(ctx, invite); err != nil {
-		log.Error("failed to create invite", "contest_id", contestID, "error", err)
-		return nil, err
+func (s *inviteService) publishSquares(ctx context.Context, contestID uuid.UUID, user string, squares []model.Square) {
+	log := util.LoggerFromContext(ctx)
+
+	if len(squares) == 0 {
+		return
 	}
 
-	metrics.IncInviteCreated()
-	log.Info("invite created", "invite_id", invite.ID, "contest_id", contestID, "token", invite.Token)
-	return invite, nil
+	go func() {
+		if err := s.natsService.PublishSquaresUpdate(contestID, user, squares); err != nil {
+			log.Error("failed to publish squares update", "contest_id", contestID, "count", len(squares), "error", err)
+		}
+	}()
 }
 
-func (s *inviteService) GetInvitePreview(ctx context.Context, token string) (*model.InvitePreviewResponse, error) {
+func inviteExpiry(expiresIn int) *time.Time {
+	if expiresIn <= 0 {
+		return nil
+	}
+	expiresAt := time.Now().Add(time.Duration(expiresIn) * time.Minute)
+	return &expiresAt
+}
+
+func (s *inviteService) GetInvitePreview(ctx context.Context, token, ipAddress, userAgent string) (*model.InvitePreviewResponse, error) {
 	log := util.LoggerFromContext(ctx)
 
 	invite, err := s.inviteRepo.GetByToken(ctx, token)
@@ -121,22 +311,83 @@ func (s *inviteService) GetInvitePreview(ctx context.Context, token string) (*mo
 		return nil, errs.ErrInviteMaxUsesReached
 	}
 
+	if invite.Paused {
+		return nil, errs.ErrInvitePaused
+	}
+
 	contest, err := s.contestRepo.GetByID(ctx, invite.ContestID)
 	if err != nil {
 		log.Error("failed to get contest for invite preview", "contest_id", invite.ContestID, "error", err)
 		return nil, errs.ErrDatabaseUnavailable
 	}
 
+	// analytics never block the preview
+	if err := s.inviteRepo.RecordView(ctx, invite, visitorFingerprint(ipAddress, userAgent)); err != nil {
+		log.Error("failed to record invite view", "invite_id", invite.ID, "error", err)
+	}
+
+	// following the emailed link counts as an open even when the client blocked the tracking pixel
+	if invite.IsPersonal() {
+		s.markOpened(ctx, invite)
+	}
+
+	// the invitee sees which squares are waiting for them; a failed lookup just leaves them out
+	reserved, err := s.inviteRepo.GetReservationsByInviteID(ctx, invite.ID)
+	if err != nil {
+		log.Error("failed to get invite reservations", "invite_id", invite.ID, "error", err)
+	}
+
 	log.Info("retrieved invite preview", "contest_id", contest.ID)
 	return &model.InvitePreviewResponse{
-		ContestID:   contest.ID,
-		ContestName: contest.Name,
-		Owner:       contest.Owner,
-		Role:        string(invite.Role),
-		MaxSquares:  invite.MaxSquares,
+		ContestID:       contest.ID,
+		ContestName:     contest.Name,
+		Owner:           contest.Owner,
+		Role:            string(invite.Role),
+		MaxSquares:      invite.MaxSquares,
+		Email:           invite.Email,
+		Restricted:      invite.IsRestricted(),
+		AllowedDomains:  invite.AllowedDomains,
+		ReservedSquares: reserved,
 	}, nil
 }
 
+func (s *inviteService) MarkInviteOpened(ctx context.Context, token string) error {
+	log := util.LoggerFromContext(ctx)
+
+	invite, err := s.inviteRepo.GetByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errs.ErrInviteNotFound
+		}
+		log.Error("failed to get invite by token", "error", err)
+		return errs.ErrDatabaseUnavailable
+	}
+
+	if !invite.IsPersonal() {
+		return nil
+	}
+
+	s.markOpened(ctx, invite)
+	return nil
+}
+
+// only the first open is recorded; tracking failures never block the invitee
+func (s *inviteService) markOpened(ctx context.Context, invite *model.ContestInvite) {
+	log := util.LoggerFromContext(ctx)
+
+	if invite.OpenedAt != nil {
+		return
+	}
+
+	if err := s.inviteRepo.MarkOpened(ctx, invite.ID); err != nil {
+		log.Error("failed to mark invite opened", "invite_id", invite.ID, "error", err)
+		return
+	}
+
+	metrics.IncInviteEmail(string(model.InviteEmailStatusOpened))
+	log.Info("invite opened", "invite_id", invite.ID, "contest_id", invite.ContestID)
+}
+
 func (s *inviteService) RedeemInvite(ctx context.Context, token, user string) (*model.ContestParticipant, error) {
 	log := util.LoggerFromContext(ctx)
 
@@ -160,6 +411,22 @@ func (s *inviteService) RedeemInvite(ctx context.Context, token, user string) (*
 		return nil, errs.ErrInviteMaxUsesReached
 	}
 
+	if invite.Paused {
+		log.Warn("attempted to redeem paused invite", "invite_id", invite.ID)
+		return nil, errs.ErrInvitePaused
+	}
+
+	// personal invites only work for the address they were sent to; the auth middleware already requires it verified
+	if invite.IsPersonal() && !strings.EqualFold(invite.Email, user) {
+		log.Warn("attempted to redeem invite sent to another address", "invite_id", invite.ID, "user", user)
+		return nil, errs.ErrInviteEmailMismatch
+	}
+
+	if !invite.AllowsEmail(user) {
+		log.Warn("attempted to redeem restricted invite from address not allowed", "invite_id", invite.ID, "user", user)
+		return nil, errs.ErrInviteEmailNotAllowed
+	}
+
 	// check contest is not in a terminal state
 	contest, err := s.contestRepo.GetByID(ctx, invite.ContestID)
 	if err != nil {
@@ -183,6 +450,17 @@ func (s *inviteService) RedeemInvite(ctx context.Context, token, user string) (*
 		return nil, errs.ErrDatabaseUnavailable
 	}
 
+	// a ban outlives any invite the user still holds
+	banned, err := s.participantRepo.IsBanned(ctx, invite.ContestID, user)
+	if err != nil {
+		log.Error("failed to check contest ban", "contest_id", invite.ContestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+	if banned {
+		log.Warn("banned user attempted to redeem invite", "contest_id", invite.ContestID, "user", user)
+		return nil, errs.ErrBannedFromContest
+	}
+
 	// reject if granting this invite would exceed the 100-square pool
 	totalAllocated, err := s.participantRepo.GetTotalAllocatedSquares(ctx, invite.ContestID)
 	if err != nil {
@@ -195,6 +473,25 @@ func (s *inviteService) RedeemInvite(ctx context.Context, token, user string) (*
 		return nil, errs.ErrNotEnoughSquares
 	}
 
+	// reserved squares are claimed in the redemption transaction, so they need the same value a normal claim would write
+	reserved, err := s.inviteRepo.GetReservationsByInviteID(ctx, invite.ID)
+	if err != nil {
+		log.Error("failed to get invite reservations", "invite_id", invite.ID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	// held squares can only be taken while the board is still open; once it locks the invite just joins
+	var claim *model.Square
+	if len(reserved) > 0 && contest.Status != model.ContestStatusActive {
+		log.Warn("not claiming reserved squares for contest that is not active", "invite_id", invite.ID, "contest_id", invite.ContestID, "status", contest.Status)
+	} else if len(reserved) > 0 {
+		profile, ownerName, err := claimantProfile(ctx, s.userRepo, user)
+		if err != nil {
+			return nil, err
+		}
+		claim = &model.Square{Value: profile.DefaultInitials, OwnerName: ownerName}
+	}
+
 	// create participant and increment invite usage atomically
 	participant := &model.ContestParticipant{
 		ContestID:  invite.ContestID,
@@ -204,13 +501,18 @@ func (s *inviteService) RedeemInvite(ctx context.Context, token, user string) (*
 		InviteID:   &invite.ID,
 	}
 
-	if err := s.inviteRepo.RedeemInvite(ctx, invite.ID, participant); err != nil {
+	claimedSquares, err := s.inviteRepo.RedeemInvite(ctx, invite, participant, claim)
+	if err != nil {
 		log.Error("failed to redeem invite", "invite_id", invite.ID, "contest_id", invite.ContestID, "user", user, "error", err)
 		return nil, err
 	}
 
 	metrics.IncInviteRedeemed()
 	metrics.IncParticipantJoined(string(invite.Role))
+	for range claimedSquares {
+		metrics.IncSquareClaimed()
+	}
+	s.publishSquares(ctx, invite.ContestID, user, claimedSquares)
 
 	go func() {
 		if err := s.natsService.PublishParticipantAdded(invite.ContestID, participant); err != nil {
@@ -236,10 +538,102 @@ func (s *inviteService) GetInvitesByContestID(ctx context.Context, contestID uui
 		return nil, errs.ErrDatabaseUnavailable
 	}
 
+	funnels, err := s.inviteRepo.GetFunnelsByContestID(ctx, contestID)
+	if err != nil {
+		log.Error("failed to get invite funnels", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	reservations, err := s.inviteRepo.GetReservationsByContestID(ctx, contestID)
+	if err != nil {
+		log.Error("failed to get invite reservations", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	for i := range invites {
+		funnel := funnels[invites[i].ID]
+		funnel.Redemptions = invites[i].Uses
+		if funnel.Views > 0 {
+			funnel.ConversionRate = float64(funnel.Redemptions) / float64(funnel.Views)
+		}
+		invites[i].Funnel = &funnel
+		invites[i].ReservedSquares = reservations[invites[i].ID]
+	}
+
 	log.Info("retrieved invites by contest", "contest_id", contestID, "count", len(invites))
 	return invites, nil
 }
 
+func (s *inviteService) UpdateInvite(ctx context.Context, contestID, inviteID uuid.UUID, req *model.UpdateInviteRequest, user string) (*model.ContestInvite, error) {
+	log := util.LoggerFromContext(ctx)
+
+	if _, err := s.getInvitableContest(ctx, contestID, user); err != nil {
+		return nil, err
+	}
+
+	invite, err := s.inviteRepo.GetByID(ctx, contestID, inviteID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrInviteNotFound
+		}
+		log.Error("failed to get invite", "invite_id", inviteID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	if req.Role != nil {
+		invite.Role = model.ParticipantRole(*req.Role)
+	}
+
+	// changes only apply to future redemptions; people who already joined keep what they got
+	switch invite.Role {
+	case model.ParticipantRoleViewer:
+		if req.MaxSquares != nil && *req.MaxSquares > 0 {
+			return nil, errs.ErrViewerCannotHaveSquares
+		}
+		invite.MaxSquares = 0
+	default:
+		if req.MaxSquares != nil {
+			invite.MaxSquares = *req.MaxSquares
+		}
+		if invite.MaxSquares < 1 {
+			return nil, errs.ErrInvalidSquareCount
+		}
+	}
+
+	// squares the invite already holds must still fit in what it hands out
+	invite.ReservedSquares, err = s.inviteRepo.GetReservationsByInviteID(ctx, invite.ID)
+	if err != nil {
+		log.Error("failed to get invite reservations", "invite_id", inviteID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+	if len(invite.ReservedSquares) > invite.MaxSquares {
+		return nil, errs.ErrTooManyReservedSquares
+	}
+
+	if req.MaxUses != nil {
+		if *req.MaxUses > 0 && *req.MaxUses < invite.Uses {
+			return nil, errs.ErrInviteMaxUsesTooLow
+		}
+		invite.MaxUses = *req.MaxUses
+	}
+
+	if req.ExpiresIn != nil {
+		invite.ExpiresAt = inviteExpiry(*req.ExpiresIn)
+	}
+
+	if req.Paused != nil {
+		invite.Paused = *req.Paused
+	}
+
+	if err := s.inviteRepo.Update(ctx, invite); err != nil {
+		log.Error("failed to update invite", "invite_id", inviteID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	log.Info("invite updated", "invite_id", inviteID, "contest_id", contestID, "paused", invite.Paused)
+	return invite, nil
+}
+
 func (s *inviteService) DeleteInvite(ctx context.Context, contestID, inviteID uuid.UUID, user string) error {
 	log := util.LoggerFromContext(ctx)
 
@@ -263,11 +657,23 @@ func (s *inviteService) DeleteInvite(ctx context.Context, contestID, inviteID uu
 		return err
 	}
 
-	if err := s.inviteRepo.Delete(ctx, inviteID); err != nil {
+	releasedSquares, err := s.inviteRepo.Delete(ctx, contestID, inviteID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errs.ErrInviteNotFound
+		}
 		log.Error("failed to delete invite", "invite_id", inviteID, "error", err)
-		return err
+		return errs.ErrDatabaseUnavailable
 	}
 
+	s.publishSquares(ctx, contestID, user, releasedSquares)
+
 	log.Info("invite deleted", "invite_id", inviteID, "contest_id", contestID)
 	return nil
 }
+
+// views are deduped per visitor without keeping the raw address
+func visitorFingerprint(ipAddress, userAgent string) string {
+	sum := sha256.Sum256([]byte(ipAddress + "|" + userAgent))
+	return hex.EncodeToString(sum[:])
+}
@@ -27,7 +27,7 @@ func TestCreateInvite_ContestNotFound(t *testing.T) {
 }
 
 func inviteSvc(inv *mocks.InviteRepository, p *mocks.ParticipantRepository, c *mocks.ContestRepository, pSvc *mocks.ParticipantService) service.InviteService {
-	return service.NewInviteService(inv, p, c, pSvc, anyNats())
+	return service.NewInviteService(inv, p, c, nil, pSvc, anyNats(), nil, model.ServerConfig{})
 }
 
 func TestCreateInvite_DBError(t *testing.T) {
@@ -118,7 +118,7 @@ func TestGetInvitePreview_NotFound(t *testing.T) {
 	inv.EXPECT().GetByToken(mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
 
 	_, err := inviteSvc(inv, mocks.NewParticipantRepository(t), mocks.NewContestRepository(t), mocks.NewParticipantService(t)).
-		GetInvitePreview(context.Background(), "tok")
+		GetInvitePreview(context.Background(), "tok", "203.0.113.7", "ua")
 	assert.ErrorIs(t, err, errs.ErrInviteNotFound)
 }
 
@@ -127,7 +127,7 @@ func TestGetInvitePreview_DBError(t *testing.T) {
 	inv.EXPECT().GetByToken(mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
 
 	_, err := inviteSvc(inv, mocks.NewParticipantRepository(t), mocks.NewContestRepository(t), mocks.NewParticipantService(t)).
-		GetInvitePreview(context.Background(), "tok")
+		GetInvitePreview(context.Background(), "tok", "203.0.113.7", "ua")
 	assert.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
 }
 
@@ -137,7 +137,7 @@ func TestGetInvitePreview_Expired(t *testing.T) {
 	inv.EXPECT().GetByToken(mock.Anything, mock.Anything).Return(&model.ContestInvite{ExpiresAt: &past}, nil)
 
 	_, err := inviteSvc(inv, mocks.NewParticipantRepository(t), mocks.NewContestRepository(t), mocks.NewParticipantService(t)).
-		GetInvitePreview(context.Background(), "tok")
+		GetInvitePreview(context.Background(), "tok", "203.0.113.7", "ua")
 	assert.ErrorIs(t, err, errs.ErrInviteExpired)
 }
 
@@ -146,7 +146,7 @@ func TestGetInvitePreview_MaxUsesReached(t *testing.T) {
 	inv.EXPECT().GetByToken(mock.Anything, mock.Anything).Return(&model.ContestInvite{MaxUses: 1, Uses: 1}, nil)
 
 	_, err := inviteSvc(inv, mocks.NewParticipantRepository(t), mocks.NewContestRepository(t), mocks.NewParticipantService(t)).
-		GetInvitePreview(context.Background(), "tok")
+		GetInvitePreview(context.Background(), "tok", "203.0.113.7", "ua")
 	assert.ErrorIs(t, err, errs.ErrInviteMaxUsesReached)
 }
 
@@ -157,19 +157,44 @@ func TestGetInvitePreview_ContestFetchFails(t *testing.T) {
 	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
 
 	_, err := inviteSvc(inv, mocks.NewParticipantRepository(t), c, mocks.NewParticipantService(t)).
-		GetInvitePreview(context.Background(), "tok")
+		GetInvitePreview(context.Background(), "tok", "203.0.113.7", "ua")
 	assert.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
 }
 
+func TestGetInvitePreview_VisitorFingerprint(t *testing.T) {
+	var visitors []string
+	inv := mocks.NewInviteRepository(t)
+	inv.EXPECT().GetByToken(mock.Anything, mock.Anything).Return(&model.ContestInvite{ContestID: uuid.New(), MaxSquares: 5}, nil)
+	inv.EXPECT().RecordView(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, _ *model.ContestInvite, visitor string) { visitors = append(visitors, visitor) }).
+		Return(nil)
+	inv.EXPECT().GetReservationsByInviteID(mock.Anything, mock.Anything).Return(nil, nil)
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{}, nil)
+	svc := inviteSvc(inv, mocks.NewParticipantRepository(t), c, mocks.NewParticipantService(t))
+
+	for _, ua := range []string{"ua", "ua", "other"} {
+		_, err := svc.GetInvitePreview(context.Background(), "tok", "203.0.113.7", ua)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, visitors, 3)
+	assert.NotContains(t, visitors[0], "203.0.113.7")
+	assert.Equal(t, visitors[0], visitors[1])
+	assert.NotEqual(t, visitors[0], visitors[2])
+}
+
 func TestGetInvitePreview_Success(t *testing.T) {
 	contestID := uuid.New()
 	inv := mocks.NewInviteRepository(t)
 	inv.EXPECT().GetByToken(mock.Anything, mock.Anything).Return(&model.ContestInvite{ContestID: contestID, Role: model.ParticipantRoleParticipant, MaxSquares: 5}, nil)
+	inv.EXPECT().RecordView(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	inv.EXPECT().GetReservationsByInviteID(mock.Anything, mock.Anything).Return(nil, nil)
 	c := mocks.NewContestRepository(t)
 	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{ID: contestID, Name: "Pool", Owner: "owner"}, nil)
 
 	got, err := inviteSvc(inv, mocks.NewParticipantRepository(t), c, mocks.NewParticipantService(t)).
-		GetInvitePreview(context.Background(), "tok")
+		GetInvitePreview(context.Background(), "tok", "203.0.113.7", "ua")
 	require.NoError(t, err)
 	assert.Equal(t, contestID, got.ContestID)
 	assert.Equal(t, "Pool", got.ContestName)
@@ -251,6 +276,7 @@ func TestRedeemInvite_GetTotalAllocatedSquaresError(t *testing.T) {
 	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
 	p := mocks.NewParticipantRepository(t)
 	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+	p.EXPECT().IsBanned(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
 	p.EXPECT().GetTotalAllocatedSquares(mock.Anything, mock.Anything).Return(0, errors.New("db error"))
 
 	_, err := inviteSvc(inv, p, c, mocks.NewParticipantService(t)).
@@ -258,6 +284,19 @@ func TestRedeemInvite_GetTotalAllocatedSquaresError(t *testing.T) {
 	assert.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
 }
 
+func TestRedeemInvite_BannedUser(t *testing.T) {
+	inv := mocks.NewInviteRepository(t)
+	inv.EXPECT().GetByToken(mock.Anything, mock.Anything).Return(&model.ContestInvite{ContestID: uuid.New(), MaxSquares: 10}, nil)
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
+	p := mocks.NewParticipantRepository(t)
+	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+	p.EXPECT().IsBanned(mock.Anything, mock.Anything, "u").Return(true, nil)
+
+	_, err := inviteSvc(inv, p, c, mocks.NewParticipantService(t)).RedeemInvite(context.Background(), "tok", "u")
+	assert.ErrorIs(t, err, errs.ErrBannedFromContest)
+}
+
 func TestRedeemInvite_NotEnoughSquares(t *testing.T) {
 	contestID := uuid.New()
 	inv := mocks.NewInviteRepository(t)
@@ -266,6 +305,7 @@ func TestRedeemInvite_NotEnoughSquares(t *testing.T) {
 	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
 	p := mocks.NewParticipantRepository(t)
 	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+	p.EXPECT().IsBanned(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
 	p.EXPECT().GetTotalAllocatedSquares(mock.Anything, mock.Anything).Return(60, nil)
 
 	_, err := inviteSvc(inv, p, c, mocks.NewParticipantService(t)).RedeemInvite(context.Background(), "tok", "u")
@@ -276,11 +316,13 @@ func TestRedeemInvite_RepoFails(t *testing.T) {
 	contestID := uuid.New()
 	inv := mocks.NewInviteRepository(t)
 	inv.EXPECT().GetByToken(mock.Anything, mock.Anything).Return(&model.ContestInvite{ContestID: contestID, MaxSquares: 10}, nil)
-	inv.EXPECT().RedeemInvite(mock.Anything, mock.Anything, mock.Anything).Return(errors.New("tx failed"))
+	inv.EXPECT().RedeemInvite(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("tx failed"))
+	inv.EXPECT().GetReservationsByInviteID(mock.Anything, mock.Anything).Return(nil, nil)
 	c := mocks.NewContestRepository(t)
 	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
 	p := mocks.NewParticipantRepository(t)
 	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+	p.EXPECT().IsBanned(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
 	p.EXPECT().GetTotalAllocatedSquares(mock.Anything, mock.Anything).Return(0, nil)
 
 	_, err := inviteSvc(inv, p, c, mocks.NewParticipantService(t)).
@@ -292,11 +334,13 @@ func TestRedeemInvite_Success(t *testing.T) {
 	contestID := uuid.New()
 	inv := mocks.NewInviteRepository(t)
 	inv.EXPECT().GetByToken(mock.Anything, mock.Anything).Return(&model.ContestInvite{ContestID: contestID, Role: model.ParticipantRoleParticipant, MaxSquares: 10}, nil)
-	inv.EXPECT().RedeemInvite(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	inv.EXPECT().RedeemInvite(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	inv.EXPECT().GetReservationsByInviteID(mock.Anything, mock.Anything).Return(nil, nil)
 	c := mocks.NewContestRepository(t)
 	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
 	p := mocks.NewParticipantRepository(t)
 	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+	p.EXPECT().IsBanned(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
 	p.EXPECT().GetTotalAllocatedSquares(mock.Anything, mock.Anything).Return(0, nil)
 
 	got, err := inviteSvc(inv, p, c, mocks.NewParticipantService(t)).RedeemInvite(context.Background(), "tok", "u")
@@ -306,16 +350,195 @@ func TestRedeemInvite_Success(t *testing.T) {
 }
 
 func TestGetInvitesByContestID_Success(t *testing.T) {
-	want := []model.ContestInvite{{Token: "t1"}}
+	groupChat, office := uuid.New(), uuid.New()
+	lastViewed := time.Now()
 	inv := mocks.NewInviteRepository(t)
-	inv.EXPECT().GetAllByContestID(mock.Anything, mock.Anything).Return(want, nil)
+	inv.EXPECT().GetAllByContestID(mock.Anything, mock.Anything).Return([]model.ContestInvite{
+		{ID: groupChat, Token: "t1", Uses: 3},
+		{ID: office, Token: "t2"},
+	}, nil)
+	inv.EXPECT().GetFunnelsByContestID(mock.Anything, mock.Anything).Return(map[uuid.UUID]model.InviteFunnel{
+		groupChat: {Views: 12, LastViewedAt: &lastViewed},
+	}, nil)
+	inv.EXPECT().GetReservationsByContestID(mock.Anything, mock.Anything).Return(map[uuid.UUID][]model.SquarePosition{
+		office: {{Row: 3, Col: 7}},
+	}, nil)
 	pSvc := mocks.NewParticipantService(t)
 	pSvc.EXPECT().Authorize(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	got, err := inviteSvc(inv, mocks.NewParticipantRepository(t), mocks.NewContestRepository(t), pSvc).
 		GetInvitesByContestID(context.Background(), uuid.New(), "owner")
 	require.NoError(t, err)
-	assert.Equal(t, want, got)
+	require.Len(t, got, 2)
+	assert.Equal(t, model.InviteFunnel{Views: 12, Redemptions: 3, ConversionRate: 0.25, LastViewedAt: &lastViewed}, *got[0].Funnel)
+
+	// an invite nobody has opened yet still reports an empty funnel rather than none
+	assert.Equal(t, model.InviteFunnel{}, *got[1].Funnel)
+
+	assert.Empty(t, got[0].ReservedSquares)
+	assert.Equal(t, []model.SquarePosition{{Row: 3, Col: 7}}, got[1].ReservedSquares)
+}
+
+func TestGetInvitesByContestID_FunnelError(t *testing.T) {
+	inv := mocks.NewInviteRepository(t)
+	inv.EXPECT().GetAllByContestID(mock.Anything, mock.Anything).Return([]model.ContestInvite{{Token: "t1"}}, nil)
+	inv.EXPECT().GetFunnelsByContestID(mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+
+	_, err := inviteSvc(inv, mocks.NewParticipantRepository(t), mocks.NewContestRepository(t), okAuth(t)).
+		GetInvitesByContestID(context.Background(), uuid.New(), "owner")
+	assert.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
+}
+
+func TestGetInvitePreview_Paused(t *testing.T) {
+	inv := mocks.NewInviteRepository(t)
+	inv.EXPECT().GetByToken(mock.Anything, mock.Anything).Return(&model.ContestInvite{Paused: true}, nil)
+
+	_, err := inviteSvc(inv, mocks.NewParticipantRepository(t), mocks.NewContestRepository(t), mocks.NewParticipantService(t)).
+		GetInvitePreview(context.Background(), "tok", "203.0.113.7", "ua")
+	assert.ErrorIs(t, err, errs.ErrInvitePaused)
+}
+
+func TestGetInvitePreview_RecordViewFailureIgnored(t *testing.T) {
+	inv := mocks.NewInviteRepository(t)
+	inv.EXPECT().GetByToken(mock.Anything, mock.Anything).Return(&model.ContestInvite{}, nil)
+	inv.EXPECT().RecordView(mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db error"))
+	inv.EXPECT().GetReservationsByInviteID(mock.Anything, mock.Anything).Return(nil, nil)
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Name: "Pool"}, nil)
+
+	_, err := inviteSvc(inv, mocks.NewParticipantRepository(t), c, mocks.NewParticipantService(t)).GetInvitePreview(context.Background(), "tok", "203.0.113.7", "ua")
+	assert.NoError(t, err)
+}
+
+func TestRedeemInvite_Paused(t *testing.T) {
+	inv := mocks.NewInviteRepository(t)
+	inv.EXPECT().GetByToken(mock.Anything, mock.Anything).Return(&model.ContestInvite{Paused: true}, nil)
+
+	_, err := inviteSvc(inv, mocks.NewParticipantRepository(t), mocks.NewContestRepository(t), mocks.NewParticipantService(t)).
+		RedeemInvite(context.Background(), "tok", "u")
+	assert.ErrorIs(t, err, errs.ErrInvitePaused)
+}
+
+func TestUpdateInvite(t *testing.T) {
+	intp := func(v int) *int { return &v }
+	strp := func(v string) *string { return &v }
+	boolp := func(v bool) *bool { return &v }
+
+	for _, tc := range []struct {
+		name     string
+		invite   model.ContestInvite
+		reserved []model.SquarePosition
+		req      model.UpdateInviteRequest
+		want     error
+		check    func(t *testing.T, got *model.ContestInvite)
+	}{
+		{
+			name:   "pause",
+			invite: model.ContestInvite{Role: model.ParticipantRoleParticipant, MaxSquares: 5},
+			req:    model.UpdateInviteRequest{Paused: boolp(true)},
+			check:  func(t *testing.T, got *model.ContestInvite) { assert.True(t, got.Paused) },
+		},
+		{
+			name:   "adjust limits and clear expiry",
+			invite: model.ContestInvite{Role: model.ParticipantRoleParticipant, MaxSquares: 5, Uses: 2, ExpiresAt: &time.Time{}},
+			req:    model.UpdateInviteRequest{MaxSquares: intp(10), MaxUses: intp(20), ExpiresIn: intp(0)},
+			check: func(t *testing.T, got *model.ContestInvite) {
+				assert.Equal(t, 10, got.MaxSquares)
+				assert.Equal(t, 20, got.MaxUses)
+				assert.Nil(t, got.ExpiresAt)
+			},
+		},
+		{
+			name:   "extend expiry",
+			invite: model.ContestInvite{Role: model.ParticipantRoleViewer},
+			req:    model.UpdateInviteRequest{ExpiresIn: intp(60)},
+			check: func(t *testing.T, got *model.ContestInvite) {
+				require.NotNil(t, got.ExpiresAt)
+				assert.Greater(t, time.Until(*got.ExpiresAt), 59*time.Minute)
+			},
+		},
+		{
+			name:   "demote to viewer drops squares",
+			invite: model.ContestInvite{Role: model.ParticipantRoleParticipant, MaxSquares: 5},
+			req:    model.UpdateInviteRequest{Role: strp("viewer")},
+			check:  func(t *testing.T, got *model.ContestInvite) { assert.Equal(t, 0, got.MaxSquares) },
+		},
+		{
+			name:   "promote viewer needs squares",
+			invite: model.ContestInvite{Role: model.ParticipantRoleViewer},
+			req:    model.UpdateInviteRequest{Role: strp("participant")},
+			want:   errs.ErrInvalidSquareCount,
+		},
+		{
+			name:   "viewer with squares",
+			invite: model.ContestInvite{Role: model.ParticipantRoleViewer},
+			req:    model.UpdateInviteRequest{MaxSquares: intp(3)},
+			want:   errs.ErrViewerCannotHaveSquares,
+		},
+		{
+			name:   "max uses below uses",
+			invite: model.ContestInvite{Role: model.ParticipantRoleViewer, Uses: 4},
+			req:    model.UpdateInviteRequest{MaxUses: intp(3)},
+			want:   errs.ErrInviteMaxUsesTooLow,
+		},
+		{
+			name:     "max squares below reserved squares",
+			invite:   model.ContestInvite{Role: model.ParticipantRoleParticipant, MaxSquares: 3},
+			reserved: []model.SquarePosition{{Row: 0, Col: 0}, {Row: 0, Col: 1}},
+			req:      model.UpdateInviteRequest{MaxSquares: intp(1)},
+			want:     errs.ErrTooManyReservedSquares,
+		},
+		{
+			name:     "demote to viewer with reserved squares",
+			invite:   model.ContestInvite{Role: model.ParticipantRoleParticipant, MaxSquares: 3},
+			reserved: []model.SquarePosition{{Row: 0, Col: 0}},
+			req:      model.UpdateInviteRequest{Role: strp("viewer")},
+			want:     errs.ErrTooManyReservedSquares,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			contestID, inviteID := uuid.New(), uuid.New()
+			c := mocks.NewContestRepository(t)
+			c.EXPECT().GetByID(mock.Anything, contestID).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
+			invite := tc.invite
+			inv := mocks.NewInviteRepository(t)
+			invite.ID = inviteID
+			inv.EXPECT().GetByID(mock.Anything, contestID, inviteID).Return(&invite, nil)
+			inv.EXPECT().GetReservationsByInviteID(mock.Anything, inviteID).Return(tc.reserved, nil).Maybe()
+			if tc.want == nil {
+				inv.EXPECT().Update(mock.Anything, &invite).Return(nil)
+			}
+
+			got, err := inviteSvc(inv, mocks.NewParticipantRepository(t), c, okAuth(t)).
+				UpdateInvite(context.Background(), contestID, inviteID, &tc.req, "owner")
+			if tc.want != nil {
+				assert.ErrorIs(t, err, tc.want)
+				return
+			}
+			require.NoError(t, err)
+			tc.check(t, got)
+		})
+	}
+}
+
+func TestUpdateInvite_NotFound(t *testing.T) {
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
+	inv := mocks.NewInviteRepository(t)
+	inv.EXPECT().GetByID(mock.Anything, mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := inviteSvc(inv, mocks.NewParticipantRepository(t), c, okAuth(t)).
+		UpdateInvite(context.Background(), uuid.New(), uuid.New(), &model.UpdateInviteRequest{}, "owner")
+	assert.ErrorIs(t, err, errs.ErrInviteNotFound)
+}
+
+func TestUpdateInvite_Terminal(t *testing.T) {
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusFinished}, nil)
+
+	_, err := inviteSvc(mocks.NewInviteRepository(t), mocks.NewParticipantRepository(t), c, mocks.NewParticipantService(t)).
+		UpdateInvite(context.Background(), uuid.New(), uuid.New(), &model.UpdateInviteRequest{}, "owner")
+	assert.ErrorIs(t, err, errs.ErrContestFinalized)
 }
 
 func TestGetInvitesByContestID_RepoError(t *testing.T) {
@@ -364,11 +587,25 @@ func TestDeleteInvite_RepoDeleteFails(t *testing.T) {
 	pSvc := mocks.NewParticipantService(t)
 	pSvc.EXPECT().Authorize(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	inv := mocks.NewInviteRepository(t)
-	inv.EXPECT().Delete(mock.Anything, mock.Anything).Return(errors.New("db error"))
+	inv.EXPECT().Delete(mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
 
 	err := inviteSvc(inv, mocks.NewParticipantRepository(t), c, pSvc).
 		DeleteInvite(context.Background(), uuid.New(), uuid.New(), "u")
-	require.Error(t, err)
+	assert.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
+}
+
+func TestDeleteInvite_OtherContestInvite(t *testing.T) {
+	contestID, inviteID := uuid.New(), uuid.New()
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, contestID).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
+	pSvc := mocks.NewParticipantService(t)
+	pSvc.EXPECT().Authorize(mock.Anything, contestID, mock.Anything, mock.Anything).Return(nil)
+	inv := mocks.NewInviteRepository(t)
+	inv.EXPECT().Delete(mock.Anything, contestID, inviteID).Return(nil, gorm.ErrRecordNotFound)
+
+	err := inviteSvc(inv, mocks.NewParticipantRepository(t), c, pSvc).
+		DeleteInvite(context.Background(), contestID, inviteID, "u")
+	assert.ErrorIs(t, err, errs.ErrInviteNotFound)
 }
 
 func TestDeleteInvite_Success(t *testing.T) {
@@ -377,9 +614,85 @@ func TestDeleteInvite_Success(t *testing.T) {
 	pSvc := mocks.NewParticipantService(t)
 	pSvc.EXPECT().Authorize(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	inv := mocks.NewInviteRepository(t)
-	inv.EXPECT().Delete(mock.Anything, mock.Anything).Return(nil)
+	inv.EXPECT().Delete(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 
 	err := inviteSvc(inv, mocks.NewParticipantRepository(t), c, pSvc).
 		DeleteInvite(context.Background(), uuid.New(), uuid.New(), "u")
 	require.NoError(t, err)
 }
+
+func TestCreateInvite_NormalizesAllowlists(t *testing.T) {
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
+	inv := mocks.NewInviteRepository(t)
+	inv.EXPECT().Create(mock.Anything, mock.Anything).Return(nil)
+
+	req := &model.CreateInviteRequest{
+		Role:           "viewer",
+		AllowedDomains: []string{"Corp.com", " corp.com", "eng.corp.com"},
+		AllowedEmails:  []string{"Contractor@Gmail.com"},
+	}
+	got, err := inviteSvc(inv, mocks.NewParticipantRepository(t), c, okAuth(t)).CreateInvite(context.Background(), uuid.New(), req, "owner")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"corp.com", "eng.corp.com"}, []string(got.AllowedDomains))
+	assert.Equal(t, []string{"contractor@gmail.com"}, []string(got.AllowedEmails))
+}
+
+func TestRedeemInvite_Restricted(t *testing.T) {
+	for _, tc := range []struct {
+		user    string
+		allowed bool
+	}{
+		{"alice@corp.com", true},
+		{"Alice@CORP.com", true},
+		{"contractor@gmail.com", true},
+		{"bob@eng.corp.com", false},
+		{"mallory@corp.com.evil.io", false},
+		{"mallory@gmail.com", false},
+	} {
+		t.Run(tc.user, func(t *testing.T) {
+			invite := &model.ContestInvite{
+				ContestID:      uuid.New(),
+				Role:           model.ParticipantRoleViewer,
+				AllowedDomains: []string{"corp.com"},
+				AllowedEmails:  []string{"contractor@gmail.com"},
+			}
+			inv := mocks.NewInviteRepository(t)
+			inv.EXPECT().GetByToken(mock.Anything, mock.Anything).Return(invite, nil)
+			c := mocks.NewContestRepository(t)
+			p := mocks.NewParticipantRepository(t)
+			if tc.allowed {
+				c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
+				p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+				p.EXPECT().IsBanned(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+				p.EXPECT().GetTotalAllocatedSquares(mock.Anything, mock.Anything).Return(0, nil)
+				inv.EXPECT().RedeemInvite(mock.Anything, invite, mock.Anything, mock.Anything).Return(nil, nil)
+				inv.EXPECT().GetReservationsByInviteID(mock.Anything, mock.Anything).Return(nil, nil)
+			}
+
+			_, err := inviteSvc(inv, p, c, mocks.NewParticipantService(t)).RedeemInvite(context.Background(), "tok", tc.user)
+			if tc.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, errs.ErrInviteEmailNotAllowed)
+			}
+		})
+	}
+}
+
+func TestGetInvitePreview_SurfacesRestriction(t *testing.T) {
+	inv := mocks.NewInviteRepository(t)
+	inv.EXPECT().GetByToken(mock.Anything, mock.Anything).Return(&model.ContestInvite{
+		AllowedDomains: []string{"corp.com"},
+		AllowedEmails:  []string{"contractor@gmail.com"},
+	}, nil)
+	inv.EXPECT().RecordView(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	inv.EXPECT().GetReservationsByInviteID(mock.Anything, mock.Anything).Return(nil, nil)
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Name: "Office Pool"}, nil)
+
+	got, err := inviteSvc(inv, mocks.NewParticipantRepository(t), c, mocks.NewParticipantService(t)).GetInvitePreview(context.Background(), "tok", "203.0.113.7", "ua")
+	require.NoError(t, err)
+	assert.True(t, got.Restricted)
+	assert.Equal(t, []string{"corp.com"}, got.AllowedDomains)
+}
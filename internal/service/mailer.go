@@ -0,0 +1,33 @@
+package service
+
+import (
+	"fmt"
+	"net/smtp"
+
+	"github.com/maxmorhardt/squares-api/internal/model"
+)
+
+type Mailer interface {
+	From() string
+	Send(to []string, msg []byte) error
+}
+
+type smtpMailer struct {
+	cfg model.SMTPConfig
+}
+
+func NewSMTPMailer(cfg model.SMTPConfig) Mailer {
+	return &smtpMailer{
+		cfg: cfg,
+	}
+}
+
+func (m *smtpMailer) From() string {
+	return m.cfg.User
+}
+
+func (m *smtpMailer) Send(to []string, msg []byte) error {
+	auth := smtp.PlainAuth("", m.cfg.User, m.cfg.Password, m.cfg.Host)
+	addr := fmt.Sprintf("%s:%d", m.cfg.Host, m.cfg.Port)
+	return smtp.SendMail(addr, auth, m.cfg.User, to, msg)
+}
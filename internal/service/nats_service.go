@@ -12,12 +12,17 @@ import (
 
 type NatsService interface {
 	PublishSquareUpdate(contestID uuid.UUID, updatedBy string, square *model.Square) error
+	PublishSquaresUpdate(contestID uuid.UUID, updatedBy string, squares []model.Square) error
 	PublishContestUpdate(contestID uuid.UUID, updatedBy string, contest *model.Contest) error
 	PublishQuarterResult(contestID uuid.UUID, updatedBy string, quarterResult *model.QuarterResult) error
 	PublishQuarterResultRollback(contestID uuid.UUID, updatedBy string, quarterResult *model.QuarterResult, contest *model.Contest) error
 	PublishContestDeleted(contestID uuid.UUID, updatedBy string) error
+	PublishContestRestored(contestID uuid.UUID, updatedBy string, contest *model.Contest) error
 	PublishParticipantRemoved(contestID uuid.UUID, updatedBy string, participant *model.ContestParticipant) error
 	PublishParticipantAdded(contestID uuid.UUID, participant *model.ContestParticipant) error
+	PublishParticipantBanned(contestID uuid.UUID, updatedBy, userID string) error
+	PublishContestLocked(contestID uuid.UUID, contest *model.Contest, message string) error
+	PublishSpectatorRevoked(contestID, tokenID uuid.UUID, updatedBy string) error
 }
 
 type natsService struct {
@@ -33,6 +38,11 @@ func (s *natsService) PublishSquareUpdate(contestID uuid.UUID, updatedBy string,
 	return s.publishToContestSubject(contestID, updateMessage)
 }
 
+func (s *natsService) PublishSquaresUpdate(contestID uuid.UUID, updatedBy string, squares []model.Square) error {
+	updateMessage := model.NewSquaresUpdateMessage(contestID, updatedBy, squares)
+	return s.publishToContestSubject(contestID, updateMessage)
+}
+
 func (s *natsService) PublishContestUpdate(contestID uuid.UUID, updatedBy string, contest *model.Contest) error {
 	updateMessage := model.NewContestUpdateMessage(contestID, updatedBy, contest)
 	return s.publishToContestSubject(contestID, updateMessage)
@@ -53,6 +63,11 @@ func (s *natsService) PublishContestDeleted(contestID uuid.UUID, updatedBy strin
 	return s.publishToContestSubject(contestID, updateMessage)
 }
 
+func (s *natsService) PublishContestRestored(contestID uuid.UUID, updatedBy string, contest *model.Contest) error {
+	updateMessage := model.NewContestRestoredMessage(contestID, updatedBy, contest)
+	return s.publishToContestSubject(contestID, updateMessage)
+}
+
 func (s *natsService) PublishParticipantRemoved(contestID uuid.UUID, updatedBy string, participant *model.ContestParticipant) error {
 	updateMessage := model.NewParticipantRemovedMessage(contestID, updatedBy, participant)
 	return s.publishToContestSubject(contestID, updateMessage)
@@ -63,6 +78,21 @@ func (s *natsService) PublishParticipantAdded(contestID uuid.UUID, participant *
 	return s.publishToContestSubject(contestID, updateMessage)
 }
 
+func (s *natsService) PublishParticipantBanned(contestID uuid.UUID, updatedBy, userID string) error {
+	updateMessage := model.NewParticipantBannedMessage(contestID, updatedBy, userID)
+	return s.publishToContestSubject(contestID, updateMessage)
+}
+
+func (s *natsService) PublishContestLocked(contestID uuid.UUID, contest *model.Contest, message string) error {
+	updateMessage := model.NewContestLockedMessage(contestID, contest, message)
+	return s.publishToContestSubject(contestID, updateMessage)
+}
+
+func (s *natsService) PublishSpectatorRevoked(contestID, tokenID uuid.UUID, updatedBy string) error {
+	updateMessage := model.NewSpectatorRevokedMessage(contestID, tokenID, updatedBy)
+	return s.publishToContestSubject(contestID, updateMessage)
+}
+
 func (s *natsService) publishToContestSubject(contestID uuid.UUID, message any) error {
 	subject := fmt.Sprintf("%s.%s", model.ContestChannelPrefix, contestID.String())
 	jsonData, err := json.Marshal(message)
@@ -21,6 +21,7 @@ func TestNatsService_PublishWithoutConnection(t *testing.T) {
 		fn   func() error
 	}{
 		{"square update", func() error { return svc.PublishSquareUpdate(contestID, "user", &model.Square{}) }},
+		{"squares update", func() error { return svc.PublishSquaresUpdate(contestID, "user", []model.Square{{}}) }},
 		{"contest update", func() error { return svc.PublishContestUpdate(contestID, "user", &model.Contest{}) }},
 		{"quarter result", func() error { return svc.PublishQuarterResult(contestID, "user", &model.QuarterResult{}) }},
 		{"contest deleted", func() error { return svc.PublishContestDeleted(contestID, "user") }},
@@ -28,6 +29,8 @@ func TestNatsService_PublishWithoutConnection(t *testing.T) {
 			return svc.PublishParticipantRemoved(contestID, "user", &model.ContestParticipant{})
 		}},
 		{"participant added", func() error { return svc.PublishParticipantAdded(contestID, &model.ContestParticipant{}) }},
+		{"contest locked", func() error { return svc.PublishContestLocked(contestID, &model.Contest{}, "locked") }},
+		{"spectator revoked", func() error { return svc.PublishSpectatorRevoked(contestID, uuid.New(), "u") }},
 	}
 
 	for _, tt := range tests {
@@ -42,11 +45,16 @@ func TestNatsService_PublishWithoutConnection(t *testing.T) {
 func anyNats() *mocks.NatsService {
 	m := &mocks.NatsService{}
 	m.On("PublishSquareUpdate", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.On("PublishSquaresUpdate", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 	m.On("PublishContestUpdate", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 	m.On("PublishQuarterResult", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 	m.On("PublishQuarterResultRollback", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 	m.On("PublishContestDeleted", mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.On("PublishContestRestored", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 	m.On("PublishParticipantRemoved", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 	m.On("PublishParticipantAdded", mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.On("PublishContestLocked", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.On("PublishSpectatorRevoked", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.On("PublishParticipantBanned", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 	return m
 }
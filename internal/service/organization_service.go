@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/repository"
+	"github.com/maxmorhardt/squares-api/internal/util"
+	"gorm.io/gorm"
+)
+
+type OrganizationService interface {
+	CreateOrganization(ctx context.Context, req *model.CreateOrganizationRequest, user string) (*model.Organization, error)
+	GetMyOrganizations(ctx context.Context, user string) ([]model.Organization, error)
+	GetMembers(ctx context.Context, orgID uuid.UUID, user string) ([]model.OrganizationMember, error)
+	AddMember(ctx context.Context, orgID uuid.UUID, req *model.AddOrgMemberRequest, user string) (*model.OrganizationMember, error)
+	UpdateMember(ctx context.Context, orgID uuid.UUID, targetUserID string, req *model.UpdateOrgMemberRequest, user string) (*model.OrganizationMember, error)
+	RemoveMember(ctx context.Context, orgID uuid.UUID, targetUserID, user string) error
+}
+
+type organizationService struct {
+	orgRepo repository.OrganizationRepository
+}
+
+func NewOrganizationService(orgRepo repository.OrganizationRepository) OrganizationService {
+	return &organizationService{
+		orgRepo: orgRepo,
+	}
+}
+
+func (s *organizationService) CreateOrganization(ctx context.Context, req *model.CreateOrganizationRequest, user string) (*model.Organization, error) {
+	log := util.LoggerFromContext(ctx)
+
+	org := &model.Organization{
+		Name:      req.Name,
+		CreatedBy: user,
+	}
+	creator := &model.OrganizationMember{
+		UserID:  user,
+		Role:    model.OrgRoleAdmin,
+		AddedBy: user,
+	}
+
+	if err := s.orgRepo.Create(ctx, org, creator); err != nil {
+		log.Error("failed to create organization", "name", req.Name, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	log.Info("organization created", "org_id", org.ID, "created_by", user)
+	return org, nil
+}
+
+func (s *organizationService) GetMyOrganizations(ctx context.Context, user string) ([]model.Organization, error) {
+	log := util.LoggerFromContext(ctx)
+
+	orgs, err := s.orgRepo.GetAllByUserID(ctx, user)
+	if err != nil {
+		log.Error("failed to get user organizations", "user", user, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	log.Info("retrieved organizations", "count", len(orgs))
+	return orgs, nil
+}
+
+func (s *organizationService) GetMembers(ctx context.Context, orgID uuid.UUID, user string) ([]model.OrganizationMember, error) {
+	log := util.LoggerFromContext(ctx)
+
+	if _, err := s.requireMember(ctx, orgID, user, false); err != nil {
+		return nil, err
+	}
+
+	members, err := s.orgRepo.GetMembers(ctx, orgID)
+	if err != nil {
+		log.Error("failed to get organization members", "org_id", orgID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	log.Info("retrieved organization members", "org_id", orgID, "count", len(members))
+	return members, nil
+}
+
+func (s *organizationService) AddMember(ctx context.Context, orgID uuid.UUID, req *model.AddOrgMemberRequest, user string) (*model.OrganizationMember, error) {
+	log := util.LoggerFromContext(ctx)
+
+	if _, err := s.requireMember(ctx, orgID, user, true); err != nil {
+		return nil, err
+	}
+
+	member := &model.OrganizationMember{
+		OrgID:   orgID,
+		UserID:  req.UserID,
+		Role:    model.OrgRoleMember,
+		AddedBy: user,
+	}
+	if req.Role != "" {
+		member.Role = model.OrgRole(req.Role)
+	}
+
+	if err := s.orgRepo.AddMember(ctx, member); err != nil {
+		if errors.Is(err, errs.ErrAlreadyOrgMember) {
+			return nil, err
+		}
+		log.Error("failed to add organization member", "org_id", orgID, "user_id", req.UserID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	log.Info("organization member added", "org_id", orgID, "user_id", req.UserID, "role", member.Role)
+	return member, nil
+}
+
+func (s *organizationService) UpdateMember(ctx context.Context, orgID uuid.UUID, targetUserID string, req *model.UpdateOrgMemberRequest, user string) (*model.OrganizationMember, error) {
+	log := util.LoggerFromContext(ctx)
+
+	if _, err := s.requireMember(ctx, orgID, user, true); err != nil {
+		return nil, err
+	}
+
+	target, err := s.getMember(ctx, orgID, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	role := model.OrgRole(req.Role)
+	if target.Role == model.OrgRoleAdmin && role != model.OrgRoleAdmin {
+		if err := s.ensureAnotherAdmin(ctx, orgID); err != nil {
+			return nil, err
+		}
+	}
+
+	target.Role = role
+	if err := s.orgRepo.UpdateMember(ctx, target); err != nil {
+		log.Error("failed to update organization member", "org_id", orgID, "user_id", targetUserID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	log.Info("organization member updated", "org_id", orgID, "user_id", targetUserID, "role", role)
+	return target, nil
+}
+
+// admins remove anyone; members may only remove themselves to leave the organization
+func (s *organizationService) RemoveMember(ctx context.Context, orgID uuid.UUID, targetUserID, user string) error {
+	log := util.LoggerFromContext(ctx)
+
+	leaving := strings.EqualFold(targetUserID, user)
+	caller, err := s.requireMember(ctx, orgID, user, !leaving)
+	if err != nil {
+		return err
+	}
+
+	target := caller
+	if !leaving {
+		if target, err = s.getMember(ctx, orgID, targetUserID); err != nil {
+			return err
+		}
+	}
+
+	if target.Role == model.OrgRoleAdmin {
+		if err := s.ensureAnotherAdmin(ctx, orgID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.orgRepo.RemoveMember(ctx, orgID, targetUserID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errs.ErrOrgMemberNotFound
+		}
+		log.Error("failed to remove organization member", "org_id", orgID, "user_id", targetUserID, "error", err)
+		return errs.ErrDatabaseUnavailable
+	}
+
+	log.Info("organization member removed", "org_id", orgID, "user_id", targetUserID, "removed_by", user)
+	return nil
+}
+
+// ====================
+// Helpers
+// ====================
+
+// loads the caller's membership, telling a missing organization apart from one the caller isn't in
+func (s *organizationService) requireMember(ctx context.Context, orgID uuid.UUID, user string, admin bool) (*model.OrganizationMember, error) {
+	log := util.LoggerFromContext(ctx)
+
+	if _, err := s.orgRepo.GetByID(ctx, orgID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrOrganizationNotFound
+		}
+		log.Error("failed to get organization", "org_id", orgID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	member, err := s.orgRepo.GetMember(ctx, orgID, user)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotOrgMember
+		}
+		log.Error("failed to get organization membership", "org_id", orgID, "user_id", user, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	if admin && member.Role != model.OrgRoleAdmin {
+		return nil, errs.ErrNotOrgAdmin
+	}
+
+	return member, nil
+}
+
+func (s *organizationService) getMember(ctx context.Context, orgID uuid.UUID, userID string) (*model.OrganizationMember, error) {
+	log := util.LoggerFromContext(ctx)
+
+	member, err := s.orgRepo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrOrgMemberNotFound
+		}
+		log.Error("failed to get organization member", "org_id", orgID, "user_id", userID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+	return member, nil
+}
+
+func (s *organizationService) ensureAnotherAdmin(ctx context.Context, orgID uuid.UUID) error {
+	log := util.LoggerFromContext(ctx)
+
+	admins, err := s.orgRepo.CountAdmins(ctx, orgID)
+	if err != nil {
+		log.Error("failed to count organization admins", "org_id", orgID, "error", err)
+		return errs.ErrDatabaseUnavailable
+	}
+	if admins <= 1 {
+		return errs.ErrLastOrgAdmin
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/mocks"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// org repo where the caller holds the given role in an existing organization
+func orgWithCaller(t *testing.T, orgID uuid.UUID, caller string, role model.OrgRole) *mocks.OrganizationRepository {
+	r := mocks.NewOrganizationRepository(t)
+	r.EXPECT().GetByID(mock.Anything, orgID).Return(&model.Organization{ID: orgID}, nil)
+	r.EXPECT().GetMember(mock.Anything, orgID, caller).Return(&model.OrganizationMember{OrgID: orgID, UserID: caller, Role: role}, nil)
+	return r
+}
+
+func TestCreateOrganization_CreatorIsAdmin(t *testing.T) {
+	r := mocks.NewOrganizationRepository(t)
+	r.EXPECT().Create(mock.Anything, mock.MatchedBy(func(o *model.Organization) bool {
+		return o.Name == "Office" && o.CreatedBy == "u"
+	}), mock.MatchedBy(func(m *model.OrganizationMember) bool {
+		return m.UserID == "u" && m.Role == model.OrgRoleAdmin
+	})).Return(nil)
+
+	org, err := service.NewOrganizationService(r).CreateOrganization(context.Background(), &model.CreateOrganizationRequest{Name: "Office"}, "u")
+	require.NoError(t, err)
+	assert.Equal(t, "Office", org.Name)
+}
+
+func TestCreateOrganization_DBError(t *testing.T) {
+	r := mocks.NewOrganizationRepository(t)
+	r.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db"))
+
+	_, err := service.NewOrganizationService(r).CreateOrganization(context.Background(), &model.CreateOrganizationRequest{Name: "Office"}, "u")
+	assert.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
+}
+
+func TestGetMyOrganizations(t *testing.T) {
+	r := mocks.NewOrganizationRepository(t)
+	r.EXPECT().GetAllByUserID(mock.Anything, "u").Return([]model.Organization{{Name: "Office"}}, nil)
+
+	orgs, err := service.NewOrganizationService(r).GetMyOrganizations(context.Background(), "u")
+	require.NoError(t, err)
+	assert.Len(t, orgs, 1)
+}
+
+func TestGetOrgMembers_NotFound(t *testing.T) {
+	orgID := uuid.New()
+	r := mocks.NewOrganizationRepository(t)
+	r.EXPECT().GetByID(mock.Anything, orgID).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := service.NewOrganizationService(r).GetMembers(context.Background(), orgID, "u")
+	assert.ErrorIs(t, err, errs.ErrOrganizationNotFound)
+}
+
+func TestGetOrgMembers_NotMember(t *testing.T) {
+	orgID := uuid.New()
+	r := mocks.NewOrganizationRepository(t)
+	r.EXPECT().GetByID(mock.Anything, orgID).Return(&model.Organization{ID: orgID}, nil)
+	r.EXPECT().GetMember(mock.Anything, orgID, "stranger").Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := service.NewOrganizationService(r).GetMembers(context.Background(), orgID, "stranger")
+	assert.ErrorIs(t, err, errs.ErrNotOrgMember)
+}
+
+func TestGetOrgMembers_Success(t *testing.T) {
+	orgID := uuid.New()
+	r := orgWithCaller(t, orgID, "u", model.OrgRoleMember)
+	r.EXPECT().GetMembers(mock.Anything, orgID).Return([]model.OrganizationMember{{UserID: "u"}, {UserID: "v"}}, nil)
+
+	members, err := service.NewOrganizationService(r).GetMembers(context.Background(), orgID, "u")
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+}
+
+func TestAddOrgMember_RequiresAdmin(t *testing.T) {
+	orgID := uuid.New()
+	r := orgWithCaller(t, orgID, "u", model.OrgRoleMember)
+
+	_, err := service.NewOrganizationService(r).AddMember(context.Background(), orgID, &model.AddOrgMemberRequest{UserID: "v"}, "u")
+	assert.ErrorIs(t, err, errs.ErrNotOrgAdmin)
+}
+
+func TestAddOrgMember_DefaultsToMember(t *testing.T) {
+	orgID := uuid.New()
+	r := orgWithCaller(t, orgID, "admin", model.OrgRoleAdmin)
+	r.EXPECT().AddMember(mock.Anything, mock.MatchedBy(func(m *model.OrganizationMember) bool {
+		return m.OrgID == orgID && m.UserID == "v" && m.Role == model.OrgRoleMember && m.AddedBy == "admin"
+	})).Return(nil)
+
+	member, err := service.NewOrganizationService(r).AddMember(context.Background(), orgID, &model.AddOrgMemberRequest{UserID: "v"}, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, model.OrgRoleMember, member.Role)
+}
+
+func TestAddOrgMember_AlreadyMember(t *testing.T) {
+	orgID := uuid.New()
+	r := orgWithCaller(t, orgID, "admin", model.OrgRoleAdmin)
+	r.EXPECT().AddMember(mock.Anything, mock.Anything).Return(errs.ErrAlreadyOrgMember)
+
+	_, err := service.NewOrganizationService(r).AddMember(context.Background(), orgID, &model.AddOrgMemberRequest{UserID: "v", Role: "admin"}, "admin")
+	assert.ErrorIs(t, err, errs.ErrAlreadyOrgMember)
+}
+
+func TestUpdateOrgMember_Promotes(t *testing.T) {
+	orgID := uuid.New()
+	r := orgWithCaller(t, orgID, "admin", model.OrgRoleAdmin)
+	r.EXPECT().GetMember(mock.Anything, orgID, "v").Return(&model.OrganizationMember{UserID: "v", Role: model.OrgRoleMember}, nil)
+	r.EXPECT().UpdateMember(mock.Anything, mock.MatchedBy(func(m *model.OrganizationMember) bool {
+		return m.Role == model.OrgRoleAdmin
+	})).Return(nil)
+
+	member, err := service.NewOrganizationService(r).UpdateMember(context.Background(), orgID, "v", &model.UpdateOrgMemberRequest{Role: "admin"}, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, model.OrgRoleAdmin, member.Role)
+}
+
+func TestUpdateOrgMember_CannotDemoteLastAdmin(t *testing.T) {
+	orgID := uuid.New()
+	r := orgWithCaller(t, orgID, "admin", model.OrgRoleAdmin)
+	r.EXPECT().CountAdmins(mock.Anything, orgID).Return(1, nil)
+
+	_, err := service.NewOrganizationService(r).UpdateMember(context.Background(), orgID, "admin", &model.UpdateOrgMemberRequest{Role: "member"}, "admin")
+	assert.ErrorIs(t, err, errs.ErrLastOrgAdmin)
+}
+
+func TestUpdateOrgMember_TargetNotFound(t *testing.T) {
+	orgID := uuid.New()
+	r := orgWithCaller(t, orgID, "admin", model.OrgRoleAdmin)
+	r.EXPECT().GetMember(mock.Anything, orgID, "ghost").Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := service.NewOrganizationService(r).UpdateMember(context.Background(), orgID, "ghost", &model.UpdateOrgMemberRequest{Role: "admin"}, "admin")
+	assert.ErrorIs(t, err, errs.ErrOrgMemberNotFound)
+}
+
+func TestRemoveOrgMember_MemberCanLeave(t *testing.T) {
+	orgID := uuid.New()
+	r := orgWithCaller(t, orgID, "u", model.OrgRoleMember)
+	r.EXPECT().RemoveMember(mock.Anything, orgID, "U").Return(nil)
+
+	assert.NoError(t, service.NewOrganizationService(r).RemoveMember(context.Background(), orgID, "U", "u"))
+}
+
+func TestRemoveOrgMember_MemberCannotRemoveOthers(t *testing.T) {
+	orgID := uuid.New()
+	r := orgWithCaller(t, orgID, "u", model.OrgRoleMember)
+
+	err := service.NewOrganizationService(r).RemoveMember(context.Background(), orgID, "v", "u")
+	assert.ErrorIs(t, err, errs.ErrNotOrgAdmin)
+}
+
+func TestRemoveOrgMember_LastAdminCannotLeave(t *testing.T) {
+	orgID := uuid.New()
+	r := orgWithCaller(t, orgID, "admin", model.OrgRoleAdmin)
+	r.EXPECT().CountAdmins(mock.Anything, orgID).Return(1, nil)
+
+	err := service.NewOrganizationService(r).RemoveMember(context.Background(), orgID, "admin", "admin")
+	assert.ErrorIs(t, err, errs.ErrLastOrgAdmin)
+}
+
+func TestRemoveOrgMember_AdminRemovesAdmin(t *testing.T) {
+	orgID := uuid.New()
+	r := orgWithCaller(t, orgID, "admin", model.OrgRoleAdmin)
+	r.EXPECT().GetMember(mock.Anything, orgID, "other").Return(&model.OrganizationMember{UserID: "other", Role: model.OrgRoleAdmin}, nil)
+	r.EXPECT().CountAdmins(mock.Anything, orgID).Return(2, nil)
+	r.EXPECT().RemoveMember(mock.Anything, orgID, "other").Return(nil)
+
+	assert.NoError(t, service.NewOrganizationService(r).RemoveMember(context.Background(), orgID, "other", "admin"))
+}
@@ -0,0 +1,489 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/metrics"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/repository"
+	"github.com/maxmorhardt/squares-api/internal/util"
+	"gorm.io/gorm"
+)
+
+// a pool tops out at 100 squares, so anything much past that is a wrong file
+const maxImportRows = 500
+
+type ParticipantImportService interface {
+	PreviewImport(ctx context.Context, contestID uuid.UUID, file io.Reader, user string) (*model.ParticipantImportPreview, error)
+	ApplyImport(ctx context.Context, contestID uuid.UUID, file io.Reader, user string) (*model.ParticipantImportPreview, error)
+}
+
+type participantImportService struct {
+	contestRepo        repository.ContestRepository
+	participantRepo    repository.ParticipantRepository
+	userRepo           repository.UserRepository
+	participantService ParticipantService
+	natsService        NatsService
+}
+
+func NewParticipantImportService(
+	contestRepo repository.ContestRepository,
+	participantRepo repository.ParticipantRepository,
+	userRepo repository.UserRepository,
+	participantService ParticipantService,
+	natsService NatsService,
+) ParticipantImportService {
+	return &participantImportService{
+		contestRepo:        contestRepo,
+		participantRepo:    participantRepo,
+		userRepo:           userRepo,
+		participantService: participantService,
+		natsService:        natsService,
+	}
+}
+
+// one csv line; blank role and limit cells mean "same as this email's first line"
+type importRow struct {
+	line       int
+	email      string
+	role       model.ParticipantRole
+	maxSquares *int
+	row, col   *int
+}
+
+// everything the apply step needs, worked out against the contest as it is now
+type importPlan struct {
+	preview *model.ParticipantImportPreview
+	added   []model.ContestParticipant
+	updated []model.ContestParticipant
+	claims  []model.Square
+}
+
+func (s *participantImportService) PreviewImport(ctx context.Context, contestID uuid.UUID, file io.Reader, user string) (*model.ParticipantImportPreview, error) {
+	log := util.LoggerFromContext(ctx)
+
+	plan, err := s.plan(ctx, contestID, file, user)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("previewed participant import", "contest_id", contestID, "participants", len(plan.preview.Participants), "squares", len(plan.preview.Squares), "errors", len(plan.preview.Errors))
+	return plan.preview, nil
+}
+
+func (s *participantImportService) ApplyImport(ctx context.Context, contestID uuid.UUID, file io.Reader, user string) (*model.ParticipantImportPreview, error) {
+	log := util.LoggerFromContext(ctx)
+
+	// the contest may have moved on since the preview, so the file is checked again from scratch
+	plan, err := s.plan(ctx, contestID, file, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if !plan.preview.Valid() {
+		log.Warn("participant import rejected", "contest_id", contestID, "errors", len(plan.preview.Errors))
+		return plan.preview, errs.ErrImportRejected
+	}
+
+	// imported squares show each owner's initials, as if they'd claimed them
+	if err := labelAssignedSquares(ctx, s.userRepo, plan.claims); err != nil {
+		log.Error("failed to load profiles for imported squares", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	claimedSquares, err := s.participantRepo.Import(ctx, contestID, plan.added, plan.updated, plan.claims)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotEnoughSquares) {
+			log.Warn("participant import exceeds square pool", "contest_id", contestID)
+			return nil, errs.ErrNotEnoughSquares
+		}
+		if rejected := claimRejection(err); rejected != nil {
+			log.Warn("participant import claim rejected", "contest_id", contestID, "error", err)
+			return nil, rejected
+		}
+		log.Error("failed to apply participant import", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	for _, p := range plan.added {
+		metrics.IncParticipantJoined(string(p.Role))
+	}
+	for range plan.claims {
+		metrics.IncSquareClaimed()
+	}
+
+	for i := range plan.added {
+		participant := plan.added[i]
+		go func() {
+			if err := s.natsService.PublishParticipantAdded(contestID, &participant); err != nil {
+				log.Error("failed to publish imported participant", "contest_id", contestID, "user", participant.UserID, "error", err)
+			}
+		}()
+	}
+
+	if len(claimedSquares) > 0 {
+		go func() {
+			if err := s.natsService.PublishSquaresUpdate(contestID, user, claimedSquares); err != nil {
+				log.Error("failed to publish imported squares", "contest_id", contestID, "count", len(claimedSquares), "error", err)
+			}
+		}()
+	}
+
+	plan.preview.Applied = true
+	log.Info("applied participant import", "contest_id", contestID, "added", len(plan.added), "updated", len(plan.updated), "squares", len(claimedSquares))
+	return plan.preview, nil
+}
+
+func (s *participantImportService) plan(ctx context.Context, contestID uuid.UUID, file io.Reader, user string) (*importPlan, error) {
+	log := util.LoggerFromContext(ctx)
+
+	contest, err := s.contestRepo.GetByID(ctx, contestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		log.Error("failed to get contest for participant import", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	if contest.Status.IsTerminal() {
+		log.Warn("cannot import participants into a finalized contest", "contest_id", contestID, "status", contest.Status)
+		return nil, errs.ErrContestFinalized
+	}
+
+	if err := s.participantService.Authorize(ctx, contestID, user, ActionManageInvites); err != nil {
+		return nil, err
+	}
+
+	rows, rowErrs, err := parseImportCSV(file)
+	if err != nil {
+		log.Warn("unreadable participant import file", "contest_id", contestID, "error", err)
+		return nil, errs.ErrInvalidImportFile
+	}
+
+	participants, err := s.participantRepo.GetAllByContestID(ctx, contestID)
+	if err != nil {
+		log.Error("failed to get participants for import", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	// banned users stay out however they arrive, the same as an invite or a public join
+	bans, err := s.participantRepo.GetBans(ctx, contestID)
+	if err != nil {
+		log.Error("failed to get bans for import", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+	banned := make(map[string]bool, len(bans))
+	for _, ban := range bans {
+		banned[strings.ToLower(ban.UserID)] = true
+	}
+
+	plan := planImport(contest, participants, banned, rows)
+	if len(rowErrs) > 0 {
+		plan.preview.Errors = append(rowErrs, plan.preview.Errors...)
+	}
+	return plan, nil
+}
+
+func parseImportCSV(file io.Reader) ([]importRow, []model.ImportRowError, error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// spreadsheet exports sometimes lead with a byte order mark
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[strings.ReplaceAll(name, " ", "_")] = i
+	}
+	for _, required := range []string{"email", "role", "max_squares"} {
+		if _, ok := columns[required]; !ok {
+			return nil, nil, fmt.Errorf("missing %s column", required)
+		}
+	}
+	_, hasRow := columns["row"]
+	_, hasCol := columns["col"]
+	if hasRow != hasCol {
+		return nil, nil, errors.New("row and col columns must be given together")
+	}
+
+	cell := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var rows []importRow
+	var rowErrs []model.ImportRowError
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		if len(rows)+len(rowErrs) >= maxImportRows {
+			return nil, nil, fmt.Errorf("more than %d rows", maxImportRows)
+		}
+
+		row, msg := parseImportRow(line, cell(record, "email"), cell(record, "role"), cell(record, "max_squares"), cell(record, "row"), cell(record, "col"))
+		if msg != "" {
+			rowErrs = append(rowErrs, model.ImportRowError{Line: line, Message: msg})
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	if len(rows)+len(rowErrs) == 0 {
+		return nil, nil, errors.New("no rows")
+	}
+
+	return rows, rowErrs, nil
+}
+
+func parseImportRow(line int, email, role, maxSquares, row, col string) (importRow, string) {
+	r := importRow{line: line, email: email}
+
+	if email == "" {
+		return r, "Email is required"
+	}
+	if len(email) > 255 || !util.IsSafeString(email) {
+		return r, "Email contains characters that are not allowed"
+	}
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		return r, fmt.Sprintf("%q is not a valid email address", email)
+	}
+
+	switch model.ParticipantRole(strings.ToLower(role)) {
+	case "":
+	case model.ParticipantRoleOwner, model.ParticipantRoleParticipant, model.ParticipantRoleViewer:
+		r.role = model.ParticipantRole(strings.ToLower(role))
+	default:
+		return r, "Role must be participant, viewer or owner"
+	}
+
+	if maxSquares != "" {
+		n, err := strconv.Atoi(maxSquares)
+		if err != nil || n < 0 || n > 100 {
+			return r, "Max squares must be a whole number from 0 to 100"
+		}
+		r.maxSquares = &n
+	}
+
+	if (row == "") != (col == "") {
+		return r, "Row and col must both be set or both be empty"
+	}
+	if row != "" {
+		rn, rowErr := strconv.Atoi(row)
+		cn, colErr := strconv.Atoi(col)
+		if rowErr != nil || colErr != nil || rn < 0 || rn > 9 || cn < 0 || cn > 9 {
+			return r, "Row and col must be whole numbers from 0 to 9"
+		}
+		r.row, r.col = &rn, &cn
+	}
+
+	return r, ""
+}
+
+// same rules as RedeemInvite, UpdateParticipant and claiming, applied to the whole file at once
+func planImport(contest *model.Contest, participants []model.ContestParticipant, banned map[string]bool, rows []importRow) *importPlan {
+	plan := &importPlan{preview: &model.ParticipantImportPreview{
+		Participants: []model.ParticipantImportChange{},
+		Squares:      []model.SquareImportClaim{},
+		Errors:       []model.ImportRowError{},
+	}}
+	rowError := func(line int, msg string) {
+		plan.preview.Errors = append(plan.preview.Errors, model.ImportRowError{Line: line, Message: msg})
+	}
+
+	existing := make(map[string]*model.ContestParticipant, len(participants))
+	for i := range participants {
+		existing[participants[i].UserID] = &participants[i]
+	}
+
+	squares := make(map[[2]int]*model.Square, len(contest.Squares))
+	heldSquares := make(map[string]int)
+	for i := range contest.Squares {
+		sq := &contest.Squares[i]
+		squares[[2]int{sq.Row, sq.Col}] = sq
+		if sq.Owner != "" {
+			heldSquares[sq.Owner]++
+		}
+	}
+
+	// fold repeated emails into one entry; later lines only add squares
+	type entry struct {
+		first      importRow
+		role       model.ParticipantRole
+		maxSquares *int
+		claims     []importRow
+	}
+	var order []string
+	entries := make(map[string]*entry)
+	for _, row := range rows {
+		if banned[strings.ToLower(row.email)] {
+			rowError(row.line, fmt.Sprintf("%s is banned from this contest", row.email))
+			continue
+		}
+		e, ok := entries[row.email]
+		if !ok {
+			e = &entry{first: row, role: row.role, maxSquares: row.maxSquares}
+			entries[row.email] = e
+			order = append(order, row.email)
+		} else {
+			if row.role != "" && e.role != "" && row.role != e.role {
+				rowError(row.line, fmt.Sprintf("Role conflicts with line %d for %s", e.first.line, row.email))
+				continue
+			}
+			if row.maxSquares != nil && e.maxSquares != nil && *row.maxSquares != *e.maxSquares {
+				rowError(row.line, fmt.Sprintf("Max squares conflicts with line %d for %s", e.first.line, row.email))
+				continue
+			}
+			if e.role == "" {
+				e.role = row.role
+			}
+			if e.maxSquares == nil {
+				e.maxSquares = row.maxSquares
+			}
+		}
+		if row.row != nil {
+			e.claims = append(e.claims, row)
+		}
+	}
+
+	// allocation outside the file stays as it is
+	total := 0
+	for _, p := range participants {
+		if _, inFile := entries[p.UserID]; !inFile {
+			total += p.MaxSquares
+		}
+	}
+
+	taken := make(map[[2]int]int)
+	for _, email := range order {
+		e := entries[email]
+		current := existing[email]
+
+		role, maxSquares := e.role, 0
+		if e.maxSquares != nil {
+			maxSquares = *e.maxSquares
+		}
+		if current != nil {
+			if role == "" {
+				role = current.Role
+			}
+			if e.maxSquares == nil {
+				maxSquares = current.MaxSquares
+			}
+		}
+		if role == "" {
+			role = model.ParticipantRoleParticipant
+		}
+		total += maxSquares
+
+		switch {
+		case current != nil && current.Role == model.ParticipantRoleOwner && role != model.ParticipantRoleOwner:
+			rowError(e.first.line, util.CapitalizeFirstLetter(errs.ErrCannotChangeOwner))
+			continue
+		case role == model.ParticipantRoleOwner && (current == nil || current.Role != model.ParticipantRoleOwner):
+			rowError(e.first.line, fmt.Sprintf("%s cannot be made an owner; a contest has one owner", email))
+			continue
+		case role == model.ParticipantRoleViewer && (maxSquares > 0 || len(e.claims) > 0):
+			rowError(e.first.line, util.CapitalizeFirstLetter(errs.ErrViewerCannotHaveSquares))
+			continue
+		case role == model.ParticipantRoleParticipant && maxSquares < 1:
+			rowError(e.first.line, util.CapitalizeFirstLetter(errs.ErrInvalidSquareCount))
+			continue
+		case maxSquares < heldSquares[email]:
+			rowError(e.first.line, fmt.Sprintf("%s already holds %d squares, more than a limit of %d", email, heldSquares[email], maxSquares))
+			continue
+		}
+
+		newClaims := 0
+		var claims []model.Square
+		for _, c := range e.claims {
+			pos := [2]int{*c.row, *c.col}
+			if line, dup := taken[pos]; dup {
+				rowError(c.line, fmt.Sprintf("Square at row %d, col %d is already assigned on line %d", pos[0], pos[1], line))
+				continue
+			}
+			taken[pos] = c.line
+
+			if contest.Status != model.ContestStatusActive {
+				rowError(c.line, util.CapitalizeFirstLetter(errs.ErrSquareNotEditable))
+				continue
+			}
+			sq, ok := squares[pos]
+			if !ok {
+				rowError(c.line, fmt.Sprintf("Square at row %d, col %d does not exist", pos[0], pos[1]))
+				continue
+			}
+			if sq.Owner == email {
+				continue
+			}
+			if sq.Owner != "" {
+				rowError(c.line, fmt.Sprintf("Square at row %d, col %d is already claimed", pos[0], pos[1]))
+				continue
+			}
+			newClaims++
+			claims = append(claims, model.Square{Row: pos[0], Col: pos[1], Owner: email})
+		}
+
+		if heldSquares[email]+newClaims > maxSquares {
+			rowError(e.first.line, fmt.Sprintf("%s would hold %d squares, more than a limit of %d", email, heldSquares[email]+newClaims, maxSquares))
+			continue
+		}
+
+		change := model.ParticipantImportChange{UserID: email, Role: role, MaxSquares: maxSquares}
+		switch {
+		case current == nil:
+			change.Action = model.ParticipantImportAdd
+			plan.added = append(plan.added, model.ContestParticipant{ContestID: contest.ID, UserID: email, Role: role, MaxSquares: maxSquares})
+		case current.Role != role || current.MaxSquares != maxSquares:
+			change.Action = model.ParticipantImportUpdate
+			change.PreviousRole = current.Role
+			previous := current.MaxSquares
+			change.PreviousMaxSquares = &previous
+			updated := *current
+			updated.Role, updated.MaxSquares = role, maxSquares
+			plan.updated = append(plan.updated, updated)
+		default:
+			change.Action = model.ParticipantImportUnchanged
+		}
+		plan.preview.Participants = append(plan.preview.Participants, change)
+
+		for _, sq := range claims {
+			plan.claims = append(plan.claims, sq)
+			plan.preview.Squares = append(plan.preview.Squares, model.SquareImportClaim{Row: sq.Row, Col: sq.Col, Owner: sq.Owner})
+		}
+	}
+
+	if total > 100 {
+		rowError(0, fmt.Sprintf("Limits add up to %d squares but a contest only has 100", total))
+	}
+	plan.preview.TotalAllocated = total
+
+	return plan
+}
@@ -0,0 +1,268 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/mocks"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func importContest(status model.ContestStatus) *model.Contest {
+	contest := &model.Contest{ID: uuid.New(), Owner: "owner@b.com", Status: status}
+	for row := range 10 {
+		for col := range 10 {
+			contest.Squares = append(contest.Squares, model.Square{ID: uuid.New(), ContestID: contest.ID, Row: row, Col: col})
+		}
+	}
+	return contest
+}
+
+func importSetup(t *testing.T, contest *model.Contest, participants []model.ContestParticipant) (*mocks.ContestRepository, *mocks.ParticipantRepository) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, contest.ID).Return(contest, nil)
+	pRepo := mocks.NewParticipantRepository(t)
+	pRepo.EXPECT().GetAllByContestID(mock.Anything, contest.ID).Return(participants, nil).Maybe()
+	pRepo.EXPECT().GetBans(mock.Anything, contest.ID).Return(nil, nil).Maybe()
+	return repo, pRepo
+}
+
+func importSvc(t *testing.T, repo *mocks.ContestRepository, pRepo *mocks.ParticipantRepository) service.ParticipantImportService {
+	uRepo := mocks.NewUserRepository(t)
+	uRepo.EXPECT().GetByEmail(mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound).Maybe()
+	return service.NewParticipantImportService(repo, pRepo, uRepo, okAuth(t), anyNats())
+}
+
+func TestPreviewImport_Diff(t *testing.T) {
+	contest := importContest(model.ContestStatusActive)
+	contest.Squares[0].Owner = "a@b.com"
+	participants := []model.ContestParticipant{
+		{UserID: "owner@b.com", Role: model.ParticipantRoleOwner, MaxSquares: 10},
+		{UserID: "a@b.com", Role: model.ParticipantRoleParticipant, MaxSquares: 5},
+		{UserID: "v@b.com", Role: model.ParticipantRoleViewer},
+	}
+	repo, pRepo := importSetup(t, contest, participants)
+
+	csv := "Email,Role,Max Squares,Row,Col\n" +
+		"new@b.com,participant,3,1,1\n" +
+		"new@b.com,,,1,2\n" +
+		"a@b.com,participant,8,0,0\n" +
+		"v@b.com,viewer,0,,\n"
+
+	preview, err := importSvc(t, repo, pRepo).PreviewImport(context.Background(), contest.ID, strings.NewReader(csv), "owner@b.com")
+	require.NoError(t, err)
+	assert.Empty(t, preview.Errors)
+	assert.False(t, preview.Applied)
+	assert.Equal(t, 21, preview.TotalAllocated)
+
+	require.Len(t, preview.Participants, 3)
+	assert.Equal(t, model.ParticipantImportAdd, preview.Participants[0].Action)
+	assert.Equal(t, model.ParticipantImportUpdate, preview.Participants[1].Action)
+	assert.Equal(t, 5, *preview.Participants[1].PreviousMaxSquares)
+	assert.Equal(t, model.ParticipantImportUnchanged, preview.Participants[2].Action)
+
+	// a@b.com already holds 0,0 so only the new participant's squares are claims
+	assert.Equal(t, []model.SquareImportClaim{{Row: 1, Col: 1, Owner: "new@b.com"}, {Row: 1, Col: 2, Owner: "new@b.com"}}, preview.Squares)
+}
+
+func TestPreviewImport_RowErrors(t *testing.T) {
+	contest := importContest(model.ContestStatusActive)
+	contest.Squares[5].Owner = "someone@b.com"
+	participants := []model.ContestParticipant{
+		{UserID: "owner@b.com", Role: model.ParticipantRoleOwner, MaxSquares: 0},
+		{UserID: "someone@b.com", Role: model.ParticipantRoleParticipant, MaxSquares: 1},
+	}
+	repo, pRepo := importSetup(t, contest, participants)
+
+	csv := "email,role,max_squares,row,col\n" +
+		"not-an-email,participant,1,,\n" + // line 2
+		"x@b.com,admin,1,,\n" + // line 3
+		"y@b.com,participant,101,,\n" + // line 4
+		"z@b.com,participant,1,0,5\n" + // line 5: taken by someone
+		"w@b.com,viewer,2,,\n" + // line 6: viewer with squares
+		"owner@b.com,participant,1,,\n" + // line 7: owner demoted
+		"q@b.com,participant,1,3,3\n" + // line 8
+		"r@b.com,participant,1,3,3\n" + // line 9: same square as line 8
+		"s@b.com,participant,1,4,4\n" + // line 10: two squares over a limit of one
+		"s@b.com,participant,1,4,5\n" +
+		"t@b.com,<b>,1,,\n" // line 12
+
+	preview, err := importSvc(t, repo, pRepo).PreviewImport(context.Background(), contest.ID, strings.NewReader(csv), "owner@b.com")
+	require.NoError(t, err)
+
+	lines := make(map[int]bool)
+	for _, e := range preview.Errors {
+		lines[e.Line] = true
+	}
+	for _, line := range []int{2, 3, 4, 5, 6, 7, 9, 10, 12} {
+		assert.True(t, lines[line], "expected an error on line %d", line)
+	}
+	assert.False(t, lines[8])
+}
+
+func TestPreviewImport_OverAllocated(t *testing.T) {
+	contest := importContest(model.ContestStatusActive)
+	participants := []model.ContestParticipant{{UserID: "owner@b.com", Role: model.ParticipantRoleOwner, MaxSquares: 60}}
+	repo, pRepo := importSetup(t, contest, participants)
+
+	preview, err := importSvc(t, repo, pRepo).PreviewImport(context.Background(), contest.ID,
+		strings.NewReader("email,role,max_squares\na@b.com,participant,30\nb@b.com,participant,30\n"), "owner@b.com")
+	require.NoError(t, err)
+	require.Len(t, preview.Errors, 1)
+	assert.Equal(t, 0, preview.Errors[0].Line)
+	assert.Equal(t, 120, preview.TotalAllocated)
+}
+
+func TestPreviewImport_SquaresAfterLock(t *testing.T) {
+	contest := importContest(model.ContestStatusQ1)
+	repo, pRepo := importSetup(t, contest, nil)
+
+	preview, err := importSvc(t, repo, pRepo).PreviewImport(context.Background(), contest.ID,
+		strings.NewReader("email,role,max_squares,row,col\na@b.com,participant,1,0,0\n"), "owner@b.com")
+	require.NoError(t, err)
+	require.Len(t, preview.Errors, 1)
+	assert.Equal(t, 2, preview.Errors[0].Line)
+}
+
+func TestPreviewImport_BannedUser(t *testing.T) {
+	contest := importContest(model.ContestStatusActive)
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, contest.ID).Return(contest, nil)
+	pRepo := mocks.NewParticipantRepository(t)
+	pRepo.EXPECT().GetAllByContestID(mock.Anything, contest.ID).Return(nil, nil)
+	pRepo.EXPECT().GetBans(mock.Anything, contest.ID).Return([]model.ContestBan{{ContestID: contest.ID, UserID: "Banned@b.com"}}, nil)
+
+	preview, err := importSvc(t, repo, pRepo).PreviewImport(context.Background(), contest.ID,
+		strings.NewReader("email,role,max_squares,row,col\nbanned@b.com,participant,1,0,0\nok@b.com,participant,1,,\n"), "owner@b.com")
+	require.NoError(t, err)
+	require.Len(t, preview.Errors, 1)
+	assert.Equal(t, 2, preview.Errors[0].Line)
+	require.Len(t, preview.Participants, 1)
+	assert.Equal(t, "ok@b.com", preview.Participants[0].UserID)
+	assert.Empty(t, preview.Squares)
+}
+
+func TestPreviewImport_InvalidFile(t *testing.T) {
+	cases := map[string]string{
+		"empty":            "",
+		"missing column":   "email,role\na@b.com,participant\n",
+		"row without col":  "email,role,max_squares,row\na@b.com,participant,1,1\n",
+		"header only":      "email,role,max_squares\n",
+		"unbalanced quote": "email,role,max_squares\n\"a@b.com,participant,1\n",
+	}
+
+	for name, csv := range cases {
+		t.Run(name, func(t *testing.T) {
+			contest := importContest(model.ContestStatusActive)
+			repo, pRepo := importSetup(t, contest, nil)
+
+			_, err := importSvc(t, repo, pRepo).PreviewImport(context.Background(), contest.ID, strings.NewReader(csv), "owner@b.com")
+			assert.ErrorIs(t, err, errs.ErrInvalidImportFile)
+		})
+	}
+}
+
+func TestPreviewImport_Finalized(t *testing.T) {
+	contest := importContest(model.ContestStatusFinished)
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, contest.ID).Return(contest, nil)
+
+	_, err := importSvc(t, repo, mocks.NewParticipantRepository(t)).
+		PreviewImport(context.Background(), contest.ID, strings.NewReader("email,role,max_squares\n"), "owner@b.com")
+	assert.ErrorIs(t, err, errs.ErrContestFinalized)
+}
+
+func TestPreviewImport_NotOwner(t *testing.T) {
+	contest := importContest(model.ContestStatusActive)
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, contest.ID).Return(contest, nil)
+	pSvc := mocks.NewParticipantService(t)
+	pSvc.EXPECT().Authorize(mock.Anything, contest.ID, "u", service.ActionManageInvites).Return(errs.ErrInsufficientRole)
+
+	_, err := service.NewParticipantImportService(repo, mocks.NewParticipantRepository(t), mocks.NewUserRepository(t), pSvc, anyNats()).
+		PreviewImport(context.Background(), contest.ID, strings.NewReader("email,role,max_squares\n"), "u")
+	assert.ErrorIs(t, err, errs.ErrInsufficientRole)
+}
+
+func TestApplyImport_Success(t *testing.T) {
+	contest := importContest(model.ContestStatusActive)
+	participants := []model.ContestParticipant{
+		{ID: uuid.New(), UserID: "owner@b.com", Role: model.ParticipantRoleOwner},
+		{ID: uuid.New(), UserID: "a@b.com", Role: model.ParticipantRoleParticipant, MaxSquares: 1},
+	}
+	repo, pRepo := importSetup(t, contest, participants)
+	pRepo.EXPECT().Import(mock.Anything, contest.ID, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ uuid.UUID, added, updated []model.ContestParticipant, claims []model.Square) ([]model.Square, error) {
+			require.Len(t, added, 1)
+			assert.Equal(t, "new@b.com", added[0].UserID)
+			require.Len(t, updated, 1)
+			assert.Equal(t, participants[1].ID, updated[0].ID)
+			assert.Equal(t, 4, updated[0].MaxSquares)
+			require.Len(t, claims, 1)
+			// no profile yet, so the initials come from the email
+			assert.Equal(t, "N", claims[0].Value)
+			return claims, nil
+		})
+
+	nats := mocks.NewNatsService(t)
+	published := make(chan struct{}, 2)
+	nats.EXPECT().PublishParticipantAdded(contest.ID, mock.Anything).RunAndReturn(func(uuid.UUID, *model.ContestParticipant) error {
+		published <- struct{}{}
+		return nil
+	})
+	nats.EXPECT().PublishSquaresUpdate(contest.ID, "owner@b.com", mock.Anything).RunAndReturn(func(uuid.UUID, string, []model.Square) error {
+		published <- struct{}{}
+		return nil
+	})
+	uRepo := mocks.NewUserRepository(t)
+	uRepo.EXPECT().GetByEmail(mock.Anything, "new@b.com").Return(nil, gorm.ErrRecordNotFound)
+
+	csv := "email,role,max_squares,row,col\nnew@b.com,participant,2,9,9\na@b.com,participant,4,,\n"
+	preview, err := service.NewParticipantImportService(repo, pRepo, uRepo, okAuth(t), nats).
+		ApplyImport(context.Background(), contest.ID, strings.NewReader(csv), "owner@b.com")
+	require.NoError(t, err)
+	assert.True(t, preview.Applied)
+
+	<-published
+	<-published
+}
+
+func TestApplyImport_Rejected(t *testing.T) {
+	contest := importContest(model.ContestStatusActive)
+	repo, pRepo := importSetup(t, contest, nil)
+
+	preview, err := importSvc(t, repo, pRepo).ApplyImport(context.Background(), contest.ID,
+		strings.NewReader("email,role,max_squares\nbad,participant,1\n"), "owner@b.com")
+	assert.ErrorIs(t, err, errs.ErrImportRejected)
+	require.NotNil(t, preview)
+	assert.False(t, preview.Applied)
+	assert.Len(t, preview.Errors, 1)
+}
+
+func TestApplyImport_ClaimRace(t *testing.T) {
+	contest := importContest(model.ContestStatusActive)
+	repo, pRepo := importSetup(t, contest, nil)
+	pRepo.EXPECT().Import(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errs.ErrSquareAlreadyClaimed)
+
+	_, err := importSvc(t, repo, pRepo).ApplyImport(context.Background(), contest.ID,
+		strings.NewReader("email,role,max_squares,row,col\na@b.com,participant,1,0,0\n"), "owner@b.com")
+	assert.ErrorIs(t, err, errs.ErrSquareAlreadyClaimed)
+}
+
+func TestApplyImport_PoolRace(t *testing.T) {
+	contest := importContest(model.ContestStatusActive)
+	repo, pRepo := importSetup(t, contest, nil)
+	pRepo.EXPECT().Import(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errs.ErrNotEnoughSquares)
+
+	_, err := importSvc(t, repo, pRepo).ApplyImport(context.Background(), contest.ID,
+		strings.NewReader("email,role,max_squares\na@b.com,participant,1\n"), "owner@b.com")
+	assert.ErrorIs(t, err, errs.ErrNotEnoughSquares)
+}
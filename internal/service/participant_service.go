@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/maxmorhardt/squares-api/internal/errs"
@@ -16,10 +17,16 @@ import (
 
 type ParticipantService interface {
 	GetParticipants(ctx context.Context, contestID uuid.UUID, user string) ([]model.ContestParticipant, error)
+	GetPaymentSummary(ctx context.Context, contestID uuid.UUID, user string) (*model.PaymentSummary, error)
 	GetParticipantsInternal(ctx context.Context, contestID uuid.UUID) ([]model.ContestParticipant, error)
-	GetMyContests(ctx context.Context, user, search string) ([]model.Contest, error)
+	GetMyContests(ctx context.Context, user, search string, orgID *uuid.UUID) ([]model.Contest, error)
 	UpdateParticipant(ctx context.Context, contestID uuid.UUID, targetUserID string, req *model.UpdateParticipantRequest, user string) (*model.ContestParticipant, error)
 	RemoveParticipant(ctx context.Context, contestID uuid.UUID, targetUserID, user string) error
+	UpdatePayment(ctx context.Context, contestID uuid.UUID, targetUserID string, req *model.UpdatePaymentRequest, user string) (*model.ContestParticipant, error)
+	UpdateSquareStyle(ctx context.Context, contestID uuid.UUID, req *model.UpdateSquareStyleRequest, user string) (*model.ContestParticipant, error)
+	BanParticipant(ctx context.Context, contestID uuid.UUID, req *model.BanParticipantRequest, user string) (*model.ContestBan, error)
+	GetBans(ctx context.Context, contestID uuid.UUID, user string) ([]model.ContestBan, error)
+	UnbanUser(ctx context.Context, contestID uuid.UUID, targetUserID, user string) error
 	Authorize(ctx context.Context, contestID uuid.UUID, userID string, act Action) error
 }
 
@@ -87,6 +94,16 @@ func (s *participantService) Authorize(ctx context.Context, contestID uuid.UUID,
 		}
 
 		if visibility == model.ContestVisibilityPublic {
+			// public contests are open to anyone except users the organiser has banned
+			banned, err := s.participantRepo.IsBanned(ctx, contestID, userID)
+			if err != nil {
+				log.Error("failed to check contest ban for authorization", "contest_id", contestID, "user_id", userID, "error", err)
+				return errs.ErrDatabaseUnavailable
+			}
+			if banned {
+				return errs.ErrBannedFromContest
+			}
+
 			return nil
 		}
 	}
@@ -94,7 +111,7 @@ func (s *participantService) Authorize(ctx context.Context, contestID uuid.UUID,
 	participant, err := s.participantRepo.GetByContestAndUser(ctx, contestID, userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errs.ErrNotParticipant
+			return s.authorizeOrgAdmin(ctx, contestID, userID, act, errs.ErrNotParticipant)
 		}
 		log.Error("failed to get participant for authorization", "contest_id", contestID, "user_id", userID, "error", err)
 		return errs.ErrDatabaseUnavailable
@@ -102,7 +119,28 @@ func (s *participantService) Authorize(ctx context.Context, contestID uuid.UUID,
 
 	perms, exists := rolePermissions[participant.Role]
 	if !exists || !perms[act] {
-		return errs.ErrInsufficientRole
+		return s.authorizeOrgAdmin(ctx, contestID, userID, act, errs.ErrInsufficientRole)
+	}
+
+	return nil
+}
+
+// admins of the owning organization manage its contests without joining them; claiming a square
+// still needs a participant row since that is where the square limit lives
+func (s *participantService) authorizeOrgAdmin(ctx context.Context, contestID uuid.UUID, userID string, act Action, denied error) error {
+	log := util.LoggerFromContext(ctx)
+
+	if act == ActionClaimSquare {
+		return denied
+	}
+
+	isAdmin, err := s.contestRepo.IsOrgAdmin(ctx, contestID, userID)
+	if err != nil {
+		log.Error("failed to check organization admin for authorization", "contest_id", contestID, "user_id", userID, "error", err)
+		return errs.ErrDatabaseUnavailable
+	}
+	if !isAdmin {
+		return denied
 	}
 
 	return nil
@@ -117,7 +155,47 @@ func (s *participantService) GetParticipants(ctx context.Context, contestID uuid
 		return nil, err
 	}
 
-	return s.fetchParticipants(ctx, contestID)
+	participants, err := s.fetchParticipants(ctx, contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	// who has paid is between the organisers and the money; everyone else sees the roster only
+	if s.Authorize(ctx, contestID, user, ActionManageInvites) != nil {
+		for i := range participants {
+			participants[i].Paid = false
+			participants[i].AmountPaidCents = 0
+			participants[i].PaidAt = nil
+		}
+	}
+
+	return participants, nil
+}
+
+func (s *participantService) GetPaymentSummary(ctx context.Context, contestID uuid.UUID, user string) (*model.PaymentSummary, error) {
+	log := util.LoggerFromContext(ctx)
+
+	if err := s.Authorize(ctx, contestID, user, ActionManageInvites); err != nil {
+		return nil, err
+	}
+
+	participants, err := s.fetchParticipants(ctx, contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the summary counts squares, so it needs the grid as well as the roster
+	contest, err := s.contestRepo.GetByID(ctx, contestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		log.Error("failed to get contest for payment summary", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	summary := summarizePayments(contest, participants)
+	return &summary, nil
 }
 
 func (s *participantService) GetParticipantsInternal(ctx context.Context, contestID uuid.UUID) ([]model.ContestParticipant, error) {
@@ -137,10 +215,10 @@ func (s *participantService) fetchParticipants(ctx context.Context, contestID uu
 	return participants, nil
 }
 
-func (s *participantService) GetMyContests(ctx context.Context, user, search string) ([]model.Contest, error) {
+func (s *participantService) GetMyContests(ctx context.Context, user, search string, orgID *uuid.UUID) ([]model.Contest, error) {
 	log := util.LoggerFromContext(ctx)
 
-	contests, err := s.contestRepo.GetAllByParticipantUserID(ctx, user, strings.TrimSpace(search))
+	contests, err := s.contestRepo.GetAllByParticipantUserID(ctx, user, strings.TrimSpace(search), orgID)
 	if err != nil {
 		log.Error("failed to get user contests", "user", user, "error", err)
 		return nil, errs.ErrDatabaseUnavailable
@@ -326,6 +404,285 @@ func (s *participantService) RemoveParticipant(ctx context.Context, contestID uu
 	return nil
 }
 
+// ====================
+// Payments
+// ====================
+
+func (s *participantService) UpdatePayment(ctx context.Context, contestID uuid.UUID, targetUserID string, req *model.UpdatePaymentRequest, user string) (*model.ContestParticipant, error) {
+	log := util.LoggerFromContext(ctx)
+
+	contest, err := s.contestRepo.GetByID(ctx, contestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		log.Error("failed to get contest", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	// buy-ins are often settled after the game, so only deleted contests are closed to payment changes
+	if contest.Status == model.ContestStatusDeleted {
+		log.Warn("cannot record payment for deleted contest", "contest_id", contestID)
+		return nil, errs.ErrContestFinalized
+	}
+
+	if err := s.Authorize(ctx, contestID, user, ActionManageInvites); err != nil {
+		return nil, err
+	}
+
+	participant, err := s.participantRepo.GetByContestAndUser(ctx, contestID, targetUserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotParticipant
+		}
+		log.Error("failed to get participant", "contest_id", contestID, "user_id", targetUserID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	switch {
+	case *req.Paid && !participant.Paid:
+		now := time.Now()
+		participant.PaidAt = &now
+	case !*req.Paid:
+		participant.PaidAt = nil
+		participant.AmountPaidCents = 0
+	}
+	participant.Paid = *req.Paid
+
+	if req.AmountPaidCents != nil {
+		participant.AmountPaidCents = *req.AmountPaidCents
+	}
+
+	if err := s.participantRepo.Update(ctx, participant); err != nil {
+		log.Error("failed to update participant payment", "contest_id", contestID, "user_id", targetUserID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	log.Info("participant payment updated", "contest_id", contestID, "target_user", targetUserID, "paid", participant.Paid, "amount_cents", participant.AmountPaidCents)
+	return participant, nil
+}
+
+func (s *participantService) UpdateSquareStyle(ctx context.Context, contestID uuid.UUID, req *model.UpdateSquareStyleRequest, user string) (*model.ContestParticipant, error) {
+	log := util.LoggerFromContext(ctx)
+
+	if req.DisplayValue == nil && req.Color == nil && !req.ClearDisplayValue && !req.ClearColor {
+		log.Warn("square style update has no fields", "contest_id", contestID)
+		return nil, errs.ErrEmptySquareStyleUpdate
+	}
+
+	contest, err := s.contestRepo.GetByID(ctx, contestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		log.Error("failed to get contest", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	// finished boards are history; restyling them would rewrite what everyone saw
+	if contest.Status.IsTerminal() {
+		log.Warn("cannot restyle squares in terminal contest", "contest_id", contestID, "status", contest.Status)
+		return nil, errs.ErrContestFinalized
+	}
+
+	participant, err := s.participantRepo.GetByContestAndUser(ctx, contestID, user)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotParticipant
+		}
+		log.Error("failed to get participant", "contest_id", contestID, "user", user, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	if req.DisplayValue != nil {
+		participant.DisplayValue = req.DisplayValue
+	}
+	if req.ClearDisplayValue {
+		participant.DisplayValue = nil
+	}
+	if req.Color != nil {
+		participant.Color = req.Color
+	}
+	if req.ClearColor {
+		participant.Color = nil
+	}
+
+	squares, err := s.participantRepo.UpdateSquareStyle(ctx, participant)
+	if err != nil {
+		log.Error("failed to update square style", "contest_id", contestID, "user", user, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	if len(squares) > 0 {
+		go func() {
+			if err := s.natsService.PublishSquaresUpdate(contestID, user, squares); err != nil {
+				log.Error("failed to publish squares update after style change", "contest_id", contestID, "count", len(squares), "error", err)
+			}
+		}()
+	}
+
+	log.Info("square style updated", "contest_id", contestID, "user", user, "restyled_squares", len(squares))
+	return participant, nil
+}
+
+// ====================
+// Bans
+// ====================
+
+func (s *participantService) BanParticipant(ctx context.Context, contestID uuid.UUID, req *model.BanParticipantRequest, user string) (*model.ContestBan, error) {
+	log := util.LoggerFromContext(ctx)
+
+	contest, err := s.contestRepo.GetByID(ctx, contestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		log.Error("failed to get contest", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	if contest.Status.IsTerminal() {
+		log.Warn("cannot ban from a finalized contest", "contest_id", contestID, "status", contest.Status)
+		return nil, errs.ErrContestFinalized
+	}
+
+	if err := s.Authorize(ctx, contestID, user, ActionManageInvites); err != nil {
+		return nil, err
+	}
+
+	targetUserID := strings.TrimSpace(req.UserID)
+	if strings.EqualFold(targetUserID, user) {
+		return nil, errs.ErrCannotBanSelf
+	}
+	if strings.EqualFold(targetUserID, contest.Owner) {
+		return nil, errs.ErrCannotBanOwner
+	}
+
+	banned, err := s.participantRepo.IsBanned(ctx, contestID, targetUserID)
+	if err != nil {
+		log.Error("failed to check existing ban", "contest_id", contestID, "user_id", targetUserID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+	if banned {
+		return nil, errs.ErrAlreadyBanned
+	}
+
+	// users who never joined can be banned pre-emptively; members have their squares dealt with too
+	participant, err := s.participantRepo.GetByContestAndUser(ctx, contestID, targetUserID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error("failed to get participant", "contest_id", contestID, "user_id", targetUserID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	squaresMode := ""
+	if participant != nil {
+		if participant.Role == model.ParticipantRoleOwner {
+			return nil, errs.ErrCannotBanOwner
+		}
+		// use the stored casing so square ownership and the membership row match exactly
+		targetUserID = participant.UserID
+
+		if squaresMode, err = bannedSquaresMode(ctx, contest, req.Squares); err != nil {
+			return nil, err
+		}
+	}
+
+	ban := &model.ContestBan{
+		ContestID: contestID,
+		UserID:    targetUserID,
+		Reason:    strings.TrimSpace(req.Reason),
+		BannedBy:  user,
+	}
+	changed, err := s.participantRepo.Ban(ctx, ban, squaresMode)
+	if err != nil {
+		if errors.Is(err, errs.ErrAlreadyBanned) {
+			return nil, err
+		}
+		log.Error("failed to ban user", "contest_id", contestID, "user_id", targetUserID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	if len(changed) > 0 {
+		go func() {
+			if err := s.natsService.PublishSquaresUpdate(contestID, user, changed); err != nil {
+				log.Error("failed to publish squares for banned user", "contest_id", contestID, "error", err)
+			}
+		}()
+	}
+
+	if participant != nil {
+		metrics.IncParticipantRemoved()
+
+		go func() {
+			if err := s.natsService.PublishParticipantRemoved(contestID, user, participant); err != nil {
+				log.Error("failed to publish participant removed", "contest_id", contestID, "user_id", targetUserID, "error", err)
+			}
+		}()
+	}
+
+	go func() {
+		if err := s.natsService.PublishParticipantBanned(contestID, user, targetUserID); err != nil {
+			log.Error("failed to publish participant banned", "contest_id", contestID, "user_id", targetUserID, "error", err)
+		}
+	}()
+
+	log.Info("user banned from contest", "contest_id", contestID, "target_user", targetUserID, "squares", req.Squares)
+	return ban, nil
+}
+
+// defaults mirror RemoveParticipant: clear before kickoff, ghost once scoring depends on the grid
+// ghost by default once the game is underway; clearing after kickoff would change who wins quarters already scored
+func bannedSquaresMode(ctx context.Context, contest *model.Contest, mode string) (string, error) {
+	if mode == "" {
+		mode = model.BanSquaresGhost
+		if contest.Status == model.ContestStatusActive {
+			mode = model.BanSquaresClear
+		}
+	}
+
+	if mode == model.BanSquaresClear && contest.Status != model.ContestStatusActive {
+		util.LoggerFromContext(ctx).Warn("cannot clear banned user squares when contest is not active", "contest_id", contest.ID, "status", contest.Status)
+		return "", errs.ErrSquareNotEditable
+	}
+
+	return mode, nil
+}
+
+func (s *participantService) GetBans(ctx context.Context, contestID uuid.UUID, user string) ([]model.ContestBan, error) {
+	log := util.LoggerFromContext(ctx)
+
+	if err := s.Authorize(ctx, contestID, user, ActionManageInvites); err != nil {
+		return nil, err
+	}
+
+	bans, err := s.participantRepo.GetBans(ctx, contestID)
+	if err != nil {
+		log.Error("failed to get contest bans", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	return bans, nil
+}
+
+func (s *participantService) UnbanUser(ctx context.Context, contestID uuid.UUID, targetUserID, user string) error {
+	log := util.LoggerFromContext(ctx)
+
+	if err := s.Authorize(ctx, contestID, user, ActionManageInvites); err != nil {
+		return err
+	}
+
+	if err := s.participantRepo.DeleteBan(ctx, contestID, targetUserID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errs.ErrBanNotFound
+		}
+		log.Error("failed to lift contest ban", "contest_id", contestID, "user_id", targetUserID, "error", err)
+		return errs.ErrDatabaseUnavailable
+	}
+
+	log.Info("contest ban lifted", "contest_id", contestID, "target_user", targetUserID)
+	return nil
+}
+
 func (s *participantService) releaseParticipantSquares(ctx context.Context, contestID uuid.UUID, userID string, ghost bool) error {
 	log := util.LoggerFromContext(ctx)
 
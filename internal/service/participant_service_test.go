@@ -19,11 +19,23 @@ import (
 func TestAuthorize_PublicViewSkipsParticipantLookup(t *testing.T) {
 	c := mocks.NewContestRepository(t)
 	c.EXPECT().GetVisibilityByID(mock.Anything, mock.Anything).Return(model.ContestVisibilityPublic, nil)
+	p := mocks.NewParticipantRepository(t)
+	p.EXPECT().IsBanned(mock.Anything, mock.Anything, "anyone").Return(false, nil)
 
-	svc := service.NewParticipantService(mocks.NewParticipantRepository(t), c, anyNats())
+	svc := service.NewParticipantService(p, c, anyNats())
 	assert.NoError(t, svc.Authorize(context.Background(), uuid.New(), "anyone", service.ActionView))
 }
 
+func TestAuthorize_PublicViewRejectsBannedUser(t *testing.T) {
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetVisibilityByID(mock.Anything, mock.Anything).Return(model.ContestVisibilityPublic, nil)
+	p := mocks.NewParticipantRepository(t)
+	p.EXPECT().IsBanned(mock.Anything, mock.Anything, "troll").Return(true, nil)
+
+	svc := service.NewParticipantService(p, c, anyNats())
+	assert.ErrorIs(t, svc.Authorize(context.Background(), uuid.New(), "troll", service.ActionView), errs.ErrBannedFromContest)
+}
+
 func TestAuthorize_VisibilityNotFound(t *testing.T) {
 	c := mocks.NewContestRepository(t)
 	c.EXPECT().GetVisibilityByID(mock.Anything, mock.Anything).Return(model.ContestVisibility(""), gorm.ErrRecordNotFound)
@@ -42,6 +54,7 @@ func TestAuthorize_VisibilityDBError(t *testing.T) {
 
 func TestAuthorize_NotParticipant(t *testing.T) {
 	c := mocks.NewContestRepository(t)
+	c.EXPECT().IsOrgAdmin(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
 	c.EXPECT().GetVisibilityByID(mock.Anything, mock.Anything).Return(model.ContestVisibilityPrivate, nil)
 	p := mocks.NewParticipantRepository(t)
 	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
@@ -61,13 +74,43 @@ func TestAuthorize_ParticipantDBError(t *testing.T) {
 }
 
 func TestAuthorize_InsufficientRole(t *testing.T) {
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().IsOrgAdmin(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
 	p := mocks.NewParticipantRepository(t)
 	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, mock.Anything).Return(&model.ContestParticipant{Role: model.ParticipantRoleViewer}, nil)
 
-	svc := service.NewParticipantService(p, mocks.NewContestRepository(t), anyNats())
+	svc := service.NewParticipantService(p, c, anyNats())
 	assert.ErrorIs(t, svc.Authorize(context.Background(), uuid.New(), "u", service.ActionEditContest), errs.ErrInsufficientRole)
 }
 
+func TestAuthorize_OrgAdminManagesWithoutJoining(t *testing.T) {
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().IsOrgAdmin(mock.Anything, mock.Anything, "admin").Return(true, nil)
+	p := mocks.NewParticipantRepository(t)
+	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, "admin").Return(nil, gorm.ErrRecordNotFound)
+
+	svc := service.NewParticipantService(p, c, anyNats())
+	assert.NoError(t, svc.Authorize(context.Background(), uuid.New(), "admin", service.ActionEditContest))
+}
+
+func TestAuthorize_OrgAdminCannotClaimWithoutJoining(t *testing.T) {
+	p := mocks.NewParticipantRepository(t)
+	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, "admin").Return(nil, gorm.ErrRecordNotFound)
+
+	svc := service.NewParticipantService(p, mocks.NewContestRepository(t), anyNats())
+	assert.ErrorIs(t, svc.Authorize(context.Background(), uuid.New(), "admin", service.ActionClaimSquare), errs.ErrNotParticipant)
+}
+
+func TestAuthorize_OrgAdminCheckDBError(t *testing.T) {
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().IsOrgAdmin(mock.Anything, mock.Anything, mock.Anything).Return(false, errors.New("boom"))
+	p := mocks.NewParticipantRepository(t)
+	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, mock.Anything).Return(&model.ContestParticipant{Role: model.ParticipantRoleViewer}, nil)
+
+	svc := service.NewParticipantService(p, c, anyNats())
+	assert.ErrorIs(t, svc.Authorize(context.Background(), uuid.New(), "u", service.ActionDeleteContest), errs.ErrDatabaseUnavailable)
+}
+
 func TestAuthorize_OwnerCanDelete(t *testing.T) {
 	p := mocks.NewParticipantRepository(t)
 	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, mock.Anything).Return(&model.ContestParticipant{Role: model.ParticipantRoleOwner}, nil)
@@ -78,6 +121,7 @@ func TestAuthorize_OwnerCanDelete(t *testing.T) {
 
 func TestGetParticipants_AuthorizeFails(t *testing.T) {
 	c := mocks.NewContestRepository(t)
+	c.EXPECT().IsOrgAdmin(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
 	c.EXPECT().GetVisibilityByID(mock.Anything, mock.Anything).Return(model.ContestVisibilityPrivate, nil)
 	p := mocks.NewParticipantRepository(t)
 	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
@@ -92,7 +136,10 @@ func TestGetParticipants_Success(t *testing.T) {
 	want := []model.ContestParticipant{{ContestID: contestID, UserID: "u"}}
 	c := mocks.NewContestRepository(t)
 	c.EXPECT().GetVisibilityByID(mock.Anything, mock.Anything).Return(model.ContestVisibilityPublic, nil)
+	c.EXPECT().IsOrgAdmin(mock.Anything, mock.Anything, "u").Return(false, nil)
 	p := mocks.NewParticipantRepository(t)
+	p.EXPECT().IsBanned(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, "u").Return(nil, gorm.ErrRecordNotFound)
 	p.EXPECT().GetAllByContestID(mock.Anything, mock.Anything).Return(want, nil)
 
 	svc := service.NewParticipantService(p, c, anyNats())
@@ -124,20 +171,20 @@ func TestGetParticipantsInternal_Error(t *testing.T) {
 func TestGetMyContests_Success(t *testing.T) {
 	want := []model.Contest{{Name: "c1"}}
 	c := mocks.NewContestRepository(t)
-	c.EXPECT().GetAllByParticipantUserID(mock.Anything, "u", "search").Return(want, nil)
+	c.EXPECT().GetAllByParticipantUserID(mock.Anything, "u", "search", (*uuid.UUID)(nil)).Return(want, nil)
 
 	svc := service.NewParticipantService(mocks.NewParticipantRepository(t), c, anyNats())
-	got, err := svc.GetMyContests(context.Background(), "u", " search ")
+	got, err := svc.GetMyContests(context.Background(), "u", " search ", nil)
 	require.NoError(t, err)
 	assert.Equal(t, want, got)
 }
 
 func TestGetMyContests_Error(t *testing.T) {
 	c := mocks.NewContestRepository(t)
-	c.EXPECT().GetAllByParticipantUserID(mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+	c.EXPECT().GetAllByParticipantUserID(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
 
 	svc := service.NewParticipantService(mocks.NewParticipantRepository(t), c, anyNats())
-	_, err := svc.GetMyContests(context.Background(), "u", "")
+	_, err := svc.GetMyContests(context.Background(), "u", "", nil)
 	assert.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
 }
 
@@ -258,6 +305,7 @@ func TestUpdateParticipant_ContestDBError(t *testing.T) {
 
 func TestUpdateParticipant_AuthorizeFails(t *testing.T) {
 	c := mocks.NewContestRepository(t)
+	c.EXPECT().IsOrgAdmin(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
 	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
 	p := mocks.NewParticipantRepository(t)
 	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, "caller").Return(nil, gorm.ErrRecordNotFound)
@@ -433,6 +481,7 @@ func TestRemoveParticipant_ContestDBError(t *testing.T) {
 
 func TestRemoveParticipant_AuthorizeFails(t *testing.T) {
 	c := mocks.NewContestRepository(t)
+	c.EXPECT().IsOrgAdmin(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
 	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
 	p := mocks.NewParticipantRepository(t)
 	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, "caller").Return(nil, gorm.ErrRecordNotFound)
@@ -444,6 +493,7 @@ func TestRemoveParticipant_AuthorizeFails(t *testing.T) {
 
 func TestRemoveParticipant_UnauthorizedCallerCannotLearnOwnerIdentity(t *testing.T) {
 	c := mocks.NewContestRepository(t)
+	c.EXPECT().IsOrgAdmin(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
 	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
 	p := mocks.NewParticipantRepository(t)
 	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, "caller").Return(&model.ContestParticipant{Role: model.ParticipantRoleParticipant}, nil)
@@ -557,3 +607,140 @@ func TestRemoveParticipant_DeleteFails(t *testing.T) {
 	err := svc.RemoveParticipant(context.Background(), uuid.New(), "target", "owner")
 	assert.Error(t, err)
 }
+
+func banMocks(t *testing.T, contest *model.Contest, target *model.ContestParticipant) (*mocks.ContestRepository, *mocks.ParticipantRepository) {
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(contest, nil)
+	p := mocks.NewParticipantRepository(t)
+	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, "owner").Return(&model.ContestParticipant{Role: model.ParticipantRoleOwner}, nil)
+	p.EXPECT().IsBanned(mock.Anything, mock.Anything, "target").Return(false, nil)
+	if target != nil {
+		p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, "target").Return(target, nil)
+	} else {
+		p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, "target").Return(nil, gorm.ErrRecordNotFound)
+	}
+	return c, p
+}
+
+func TestBanParticipant_ClearsSquaresBeforeKickoff(t *testing.T) {
+	contest := &model.Contest{Status: model.ContestStatusActive, Owner: "owner"}
+	c, p := banMocks(t, contest, &model.ContestParticipant{UserID: "target", Role: model.ParticipantRoleParticipant})
+	p.EXPECT().Ban(mock.Anything, mock.MatchedBy(func(b *model.ContestBan) bool {
+		return b.UserID == "target" && b.BannedBy == "owner" && b.Reason == "spam"
+	}), model.BanSquaresClear).Return([]model.Square{{Row: 1, Col: 1}}, nil)
+
+	svc := service.NewParticipantService(p, c, anyNats())
+	ban, err := svc.BanParticipant(context.Background(), uuid.New(), &model.BanParticipantRequest{UserID: "target", Reason: " spam "}, "owner")
+	require.NoError(t, err)
+	assert.Equal(t, "target", ban.UserID)
+}
+
+func TestBanParticipant_GhostsSquaresWhenRequested(t *testing.T) {
+	contest := &model.Contest{Status: model.ContestStatusActive, Owner: "owner"}
+	c, p := banMocks(t, contest, &model.ContestParticipant{UserID: "target", Role: model.ParticipantRoleParticipant})
+	p.EXPECT().Ban(mock.Anything, mock.Anything, model.BanSquaresGhost).Return([]model.Square{{Owner: model.GhostUser}}, nil)
+
+	svc := service.NewParticipantService(p, c, anyNats())
+	_, err := svc.BanParticipant(context.Background(), uuid.New(), &model.BanParticipantRequest{UserID: "target", Squares: model.BanSquaresGhost}, "owner")
+	require.NoError(t, err)
+}
+
+func TestBanParticipant_GhostsSquaresAfterKickoffByDefault(t *testing.T) {
+	contest := &model.Contest{Status: model.ContestStatusQ2, Owner: "owner"}
+	c, p := banMocks(t, contest, &model.ContestParticipant{UserID: "target", Role: model.ParticipantRoleParticipant})
+	p.EXPECT().Ban(mock.Anything, mock.Anything, model.BanSquaresGhost).Return(nil, nil)
+
+	svc := service.NewParticipantService(p, c, anyNats())
+	_, err := svc.BanParticipant(context.Background(), uuid.New(), &model.BanParticipantRequest{UserID: "target"}, "owner")
+	require.NoError(t, err)
+}
+
+func TestBanParticipant_CannotClearAfterKickoff(t *testing.T) {
+	contest := &model.Contest{Status: model.ContestStatusQ2, Owner: "owner"}
+	c, p := banMocks(t, contest, &model.ContestParticipant{UserID: "target", Role: model.ParticipantRoleParticipant})
+
+	svc := service.NewParticipantService(p, c, anyNats())
+	_, err := svc.BanParticipant(context.Background(), uuid.New(), &model.BanParticipantRequest{UserID: "target", Squares: model.BanSquaresClear}, "owner")
+	assert.ErrorIs(t, err, errs.ErrSquareNotEditable)
+}
+
+func TestBanParticipant_NonMemberBannedPreemptively(t *testing.T) {
+	c, p := banMocks(t, &model.Contest{Status: model.ContestStatusActive, Owner: "owner"}, nil)
+	p.EXPECT().Ban(mock.Anything, mock.Anything, "").Return(nil, nil)
+
+	svc := service.NewParticipantService(p, c, anyNats())
+	_, err := svc.BanParticipant(context.Background(), uuid.New(), &model.BanParticipantRequest{UserID: "target"}, "owner")
+	require.NoError(t, err)
+}
+
+func TestBanParticipant_BanFailureLeavesSquares(t *testing.T) {
+	c, p := banMocks(t, &model.Contest{Status: model.ContestStatusActive, Owner: "owner"}, &model.ContestParticipant{UserID: "target", Role: model.ParticipantRoleParticipant})
+	p.EXPECT().Ban(mock.Anything, mock.Anything, model.BanSquaresClear).Return(nil, errors.New("db down"))
+
+	svc := service.NewParticipantService(p, c, mocks.NewNatsService(t))
+	_, err := svc.BanParticipant(context.Background(), uuid.New(), &model.BanParticipantRequest{UserID: "target"}, "owner")
+	assert.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
+}
+
+func TestBanParticipant_Rejected(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		target string
+		want   error
+	}{
+		{name: "self", target: "Owner", want: errs.ErrCannotBanSelf},
+		{name: "contest owner", target: "creator", want: errs.ErrCannotBanOwner},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := mocks.NewContestRepository(t)
+			c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive, Owner: "creator"}, nil)
+			p := mocks.NewParticipantRepository(t)
+			p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, "owner").Return(&model.ContestParticipant{Role: model.ParticipantRoleOwner}, nil)
+
+			svc := service.NewParticipantService(p, c, anyNats())
+			_, err := svc.BanParticipant(context.Background(), uuid.New(), &model.BanParticipantRequest{UserID: tc.target}, "owner")
+			assert.ErrorIs(t, err, tc.want)
+		})
+	}
+}
+
+func TestBanParticipant_AlreadyBanned(t *testing.T) {
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive, Owner: "owner"}, nil)
+	p := mocks.NewParticipantRepository(t)
+	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, "owner").Return(&model.ContestParticipant{Role: model.ParticipantRoleOwner}, nil)
+	p.EXPECT().IsBanned(mock.Anything, mock.Anything, "target").Return(true, nil)
+
+	svc := service.NewParticipantService(p, c, anyNats())
+	_, err := svc.BanParticipant(context.Background(), uuid.New(), &model.BanParticipantRequest{UserID: "target"}, "owner")
+	assert.ErrorIs(t, err, errs.ErrAlreadyBanned)
+}
+
+func TestBanParticipant_Terminal(t *testing.T) {
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusFinished}, nil)
+
+	svc := service.NewParticipantService(mocks.NewParticipantRepository(t), c, anyNats())
+	_, err := svc.BanParticipant(context.Background(), uuid.New(), &model.BanParticipantRequest{UserID: "target"}, "owner")
+	assert.ErrorIs(t, err, errs.ErrContestFinalized)
+}
+
+func TestUnbanUser_NotFound(t *testing.T) {
+	p := mocks.NewParticipantRepository(t)
+	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, "owner").Return(&model.ContestParticipant{Role: model.ParticipantRoleOwner}, nil)
+	p.EXPECT().DeleteBan(mock.Anything, mock.Anything, "target").Return(gorm.ErrRecordNotFound)
+
+	svc := service.NewParticipantService(p, mocks.NewContestRepository(t), anyNats())
+	assert.ErrorIs(t, svc.UnbanUser(context.Background(), uuid.New(), "target", "owner"), errs.ErrBanNotFound)
+}
+
+func TestGetBans_RequiresOwner(t *testing.T) {
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().IsOrgAdmin(mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	p := mocks.NewParticipantRepository(t)
+	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, "u").Return(&model.ContestParticipant{Role: model.ParticipantRoleParticipant}, nil)
+
+	svc := service.NewParticipantService(p, c, anyNats())
+	_, err := svc.GetBans(context.Background(), uuid.New(), "u")
+	assert.ErrorIs(t, err, errs.ErrInsufficientRole)
+}
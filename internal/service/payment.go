@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/repository"
+)
+
+// the contest owner collects the money, so only other participants can owe for squares
+func owesForSquares(p *model.ContestParticipant) bool {
+	return p.Role != model.ParticipantRoleOwner && !p.Paid
+}
+
+// square owners still marked unpaid, sorted; ghost and house squares have no participant behind them
+func unpaidSquareOwners(contest *model.Contest, participants []model.ContestParticipant) []string {
+	byUser := make(map[string]*model.ContestParticipant, len(participants))
+	for i := range participants {
+		byUser[participants[i].UserID] = &participants[i]
+	}
+
+	seen := make(map[string]bool)
+	var unpaid []string
+	for _, sq := range contest.Squares {
+		p, ok := byUser[sq.Owner]
+		if !ok || seen[sq.Owner] || !owesForSquares(p) {
+			continue
+		}
+		seen[sq.Owner] = true
+		unpaid = append(unpaid, sq.Owner)
+	}
+
+	slices.Sort(unpaid)
+	return unpaid
+}
+
+func summarizePayments(contest *model.Contest, participants []model.ContestParticipant) model.PaymentSummary {
+	squares := make(map[string]int)
+	for _, sq := range contest.Squares {
+		if sq.Owner != "" {
+			squares[sq.Owner]++
+		}
+	}
+
+	summary := model.PaymentSummary{UnpaidUserIDs: []string{}}
+	for i := range participants {
+		p := &participants[i]
+		summary.CollectedCents += p.AmountPaidCents
+		if p.Role == model.ParticipantRoleOwner || squares[p.UserID] == 0 {
+			continue
+		}
+
+		summary.SquareOwners++
+		if p.Paid {
+			summary.Paid++
+			continue
+		}
+		summary.Unpaid++
+		summary.UnpaidSquares += squares[p.UserID]
+		summary.UnpaidUserIDs = append(summary.UnpaidUserIDs, p.UserID)
+	}
+
+	slices.Sort(summary.UnpaidUserIDs)
+	return summary
+}
+
+// random fill never hands squares to someone the payment policy would hold the lock for
+func payingParticipants(contest *model.Contest, participants []model.ContestParticipant) []model.ContestParticipant {
+	if contest.PaymentPolicy == "" || contest.PaymentPolicy == model.PaymentPolicyNone {
+		return participants
+	}
+
+	return slices.DeleteFunc(slices.Clone(participants), func(p model.ContestParticipant) bool {
+		return owesForSquares(&p)
+	})
+}
+
+// runs before planFill on every path that locks the grid: require holds the lock while anyone owes,
+// clear_unpaid blanks the unpaid owners' squares on the loaded contest so the fill policy can deal with them.
+// nothing is written here; the start clears the returned owners in the same transaction that locks the grid
+func settlePayments(
+	ctx context.Context,
+	contest *model.Contest,
+	participantRepo repository.ParticipantRepository,
+) (unpaid []string, cleared []model.Square, paid bool, err error) {
+	if contest.PaymentPolicy != model.PaymentPolicyRequire && contest.PaymentPolicy != model.PaymentPolicyClearUnpaid {
+		return nil, nil, true, nil
+	}
+
+	participants, err := participantRepo.GetAllByContestID(ctx, contest.ID)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	unpaid = unpaidSquareOwners(contest, participants)
+	if len(unpaid) == 0 {
+		return nil, nil, true, nil
+	}
+
+	if contest.PaymentPolicy == model.PaymentPolicyRequire {
+		return unpaid, nil, false, nil
+	}
+
+	for i := range contest.Squares {
+		sq := &contest.Squares[i]
+		if !slices.Contains(unpaid, sq.Owner) {
+			continue
+		}
+		sq.Value, sq.Owner, sq.OwnerName, sq.Color = "", "", "", ""
+		sq.Version++
+		cleared = append(cleared, *sq)
+	}
+
+	return unpaid, cleared, true, nil
+}
+
+// where the squares the payment policy cleared ended up once the fill policy ran, for broadcasting
+func settledSquares(contest *model.Contest, cleared []model.Square) []model.Square {
+	settled := make([]model.Square, 0, len(cleared))
+	for _, c := range cleared {
+		for _, sq := range contest.Squares {
+			if sq.ID == c.ID {
+				settled = append(settled, sq)
+				break
+			}
+		}
+	}
+	return settled
+}
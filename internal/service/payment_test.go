@@ -0,0 +1,273 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/mocks"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func paymentRoster(paid bool) []model.ContestParticipant {
+	return []model.ContestParticipant{
+		{UserID: "alice", Role: model.ParticipantRoleOwner},
+		{UserID: "bob", Role: model.ParticipantRoleParticipant, Paid: paid, AmountPaidCents: 1000},
+	}
+}
+
+func paymentContest(policy model.PaymentPolicy) *model.Contest {
+	return &model.Contest{
+		ID:            uuid.New(),
+		Status:        model.ContestStatusActive,
+		PaymentPolicy: policy,
+		Squares:       []model.Square{{ID: uuid.New(), Owner: "alice"}, {ID: uuid.New(), Owner: "bob"}, {ID: uuid.New(), Owner: "bob"}},
+	}
+}
+
+func TestStartContest_RequirePaymentBlocksUnpaid(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(paymentContest(model.PaymentPolicyRequire), nil)
+	pRepo := mocks.NewParticipantRepository(t)
+	pRepo.EXPECT().GetAllByContestID(mock.Anything, mock.Anything).Return(paymentRoster(false), nil)
+
+	_, err := contestSvc(repo, pRepo, mocks.NewParticipantService(t)).StartContest(context.Background(), uuid.New(), "alice")
+	assert.ErrorIs(t, err, errs.ErrUnpaidSquareOwners)
+}
+
+func TestStartContest_RequirePaymentAllPaid(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(paymentContest(model.PaymentPolicyRequire), nil)
+	repo.EXPECT().Update(mock.Anything, mock.Anything).Return(nil)
+	pRepo := mocks.NewParticipantRepository(t)
+	pRepo.EXPECT().GetAllByContestID(mock.Anything, mock.Anything).Return(paymentRoster(true), nil)
+
+	got, err := contestSvc(repo, pRepo, mocks.NewParticipantService(t)).StartContest(context.Background(), uuid.New(), "alice")
+	require.NoError(t, err)
+	assert.Equal(t, model.ContestStatusQ1, got.Status)
+}
+
+func TestStartContest_ClearUnpaidHandsSquaresToFillPolicy(t *testing.T) {
+	contest := paymentContest(model.PaymentPolicyClearUnpaid)
+	contest.FillPolicy = model.FillPolicyHouse
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(contest, nil)
+	// bob's squares are cleared in the same transaction that starts the contest
+	repo.EXPECT().StartWithSquares(mock.Anything, mock.Anything, []string{"bob"}, mock.MatchedBy(func(sqs []model.Square) bool {
+		return len(sqs) == 2 && sqs[0].Owner == model.HouseUser && sqs[1].Owner == model.HouseUser
+	})).Return(nil)
+	pRepo := mocks.NewParticipantRepository(t)
+	pRepo.EXPECT().GetAllByContestID(mock.Anything, mock.Anything).Return(paymentRoster(false), nil)
+
+	got, err := contestSvc(repo, pRepo, mocks.NewParticipantService(t)).StartContest(context.Background(), uuid.New(), "alice")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", got.Squares[0].Owner)
+	assert.Equal(t, model.HouseUser, got.Squares[2].Owner)
+}
+
+func TestStartContest_ClearUnpaidKeepsSquaresWhenNotReady(t *testing.T) {
+	contest := paymentContest(model.PaymentPolicyClearUnpaid)
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(contest, nil)
+	pRepo := mocks.NewParticipantRepository(t)
+	pRepo.EXPECT().GetAllByContestID(mock.Anything, mock.Anything).Return(paymentRoster(false), nil)
+
+	// no fill policy, so clearing bob would leave holes; nothing is written
+	_, err := contestSvc(repo, pRepo, mocks.NewParticipantService(t)).StartContest(context.Background(), uuid.New(), "alice")
+	assert.ErrorIs(t, err, errs.ErrContestNotReady)
+}
+
+func TestLockDueContests_ClearUnpaidNotReadyKeepsSquares(t *testing.T) {
+	contest := *paymentContest(model.PaymentPolicyClearUnpaid)
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetDueForLock(mock.Anything, mock.Anything).Return([]model.Contest{contest}, nil)
+	// the skipped lock saves the schedule only; squares aren't part of the contest update
+	repo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(c *model.Contest) bool {
+		return c.Status == model.ContestStatusActive && c.LockAt == nil
+	})).Return(nil)
+	pRepo := mocks.NewParticipantRepository(t)
+	pRepo.EXPECT().GetAllByContestID(mock.Anything, mock.Anything).Return(paymentRoster(false), nil)
+
+	started, err := contestSvc(repo, pRepo, mocks.NewParticipantService(t)).LockDueContests(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, started)
+}
+
+func TestStartContest_RandomFillSkipsUnpaidParticipants(t *testing.T) {
+	contest := paymentContest(model.PaymentPolicyRequire)
+	contest.FillPolicy = model.FillPolicyRandom
+	contest.Squares = []model.Square{{ID: uuid.New(), Owner: "alice"}, {ID: uuid.New()}}
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(contest, nil)
+	repo.EXPECT().StartWithSquares(mock.Anything, mock.Anything, mock.Anything, mock.MatchedBy(func(sqs []model.Square) bool {
+		return len(sqs) == 1 && sqs[0].Owner == "carol"
+	})).Return(nil)
+	pRepo := mocks.NewParticipantRepository(t)
+	pRepo.EXPECT().GetAllByContestID(mock.Anything, mock.Anything).Return([]model.ContestParticipant{
+		{UserID: "alice", Role: model.ParticipantRoleOwner, MaxSquares: 1},
+		{UserID: "bob", Role: model.ParticipantRoleParticipant, MaxSquares: 5},
+		{UserID: "carol", Role: model.ParticipantRoleParticipant, MaxSquares: 5, Paid: true},
+	}, nil)
+
+	_, err := contestSvc(repo, pRepo, mocks.NewParticipantService(t)).StartContest(context.Background(), uuid.New(), "alice")
+	require.NoError(t, err)
+}
+
+func TestLockDueContests_UnpaidClearsSchedule(t *testing.T) {
+	contest := *paymentContest(model.PaymentPolicyRequire)
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetDueForLock(mock.Anything, mock.Anything).Return([]model.Contest{contest}, nil)
+	repo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(c *model.Contest) bool {
+		return c.Status == model.ContestStatusActive && c.LockAt == nil
+	})).Return(nil)
+	pRepo := mocks.NewParticipantRepository(t)
+	pRepo.EXPECT().GetAllByContestID(mock.Anything, mock.Anything).Return(paymentRoster(false), nil)
+
+	started, err := contestSvc(repo, pRepo, mocks.NewParticipantService(t)).LockDueContests(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, started)
+}
+
+func TestGameService_SyncGame_UnpaidHoldsAutoStart(t *testing.T) {
+	gameID := uuid.New()
+	g := mocks.NewGameRepository(t)
+	g.EXPECT().GetByID(mock.Anything, gameID).Return(liveGame(gameID), nil)
+
+	contest := *paymentContest(model.PaymentPolicyRequire)
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByGameID(mock.Anything, gameID).Return([]model.Contest{contest}, nil)
+	pRepo := mocks.NewParticipantRepository(t)
+	pRepo.EXPECT().GetAllByContestID(mock.Anything, mock.Anything).Return(paymentRoster(false), nil)
+	// no Update expected: bob still owes
+	n := mocks.NewNatsService(t)
+	n.EXPECT().PublishContestLocked(contest.ID, mock.Anything, mock.Anything).Return(nil).Once()
+
+	// the owner hears about it on the first sync only
+	svc := service.NewGameService(g, c, pRepo, mocks.NewUserRepository(t), n, anyAnalytics())
+	require.NoError(t, svc.SyncGame(context.Background(), gameID))
+	require.NoError(t, svc.SyncGame(context.Background(), gameID))
+}
+
+func TestGameService_SyncGame_ClearUnpaidAtKickoff(t *testing.T) {
+	gameID := uuid.New()
+	g := mocks.NewGameRepository(t)
+	g.EXPECT().GetByID(mock.Anything, gameID).Return(liveGame(gameID), nil)
+
+	contest := *paymentContest(model.PaymentPolicyClearUnpaid)
+	contest.FillPolicy = model.FillPolicyHouse
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByGameID(mock.Anything, gameID).Return([]model.Contest{contest}, nil)
+	c.EXPECT().StartWithSquares(mock.Anything, mock.Anything, []string{"bob"}, mock.MatchedBy(func(sqs []model.Square) bool {
+		return len(sqs) == 2 && sqs[0].Owner == model.HouseUser
+	})).Return(nil).Once()
+	pRepo := mocks.NewParticipantRepository(t)
+	pRepo.EXPECT().GetAllByContestID(mock.Anything, mock.Anything).Return(paymentRoster(false), nil)
+	n := mocks.NewNatsService(t)
+	n.EXPECT().PublishContestUpdate(contest.ID, mock.Anything, mock.Anything).Return(nil)
+	// the broadcast shows where bob's squares ended up, not a blank grid
+	n.EXPECT().PublishSquaresUpdate(contest.ID, mock.Anything, mock.MatchedBy(func(sqs []model.Square) bool {
+		return len(sqs) == 2 && sqs[0].Owner == model.HouseUser && sqs[1].Owner == model.HouseUser
+	})).Return(nil)
+
+	svc := service.NewGameService(g, c, pRepo, mocks.NewUserRepository(t), n, anyAnalytics())
+	require.NoError(t, svc.SyncGame(context.Background(), gameID))
+}
+
+func TestGetPaymentSummary(t *testing.T) {
+	contest := paymentContest(model.PaymentPolicyNone)
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, contest.ID).Return(contest, nil)
+	p := mocks.NewParticipantRepository(t)
+	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, "alice").Return(&model.ContestParticipant{Role: model.ParticipantRoleOwner}, nil)
+	p.EXPECT().GetAllByContestID(mock.Anything, contest.ID).Return(append(paymentRoster(false),
+		model.ContestParticipant{UserID: "carol", Role: model.ParticipantRoleParticipant, Paid: true, AmountPaidCents: 2500},
+		model.ContestParticipant{UserID: "dave", Role: model.ParticipantRoleViewer},
+	), nil)
+
+	got, err := service.NewParticipantService(p, c, anyNats()).GetPaymentSummary(context.Background(), contest.ID, "alice")
+	require.NoError(t, err)
+	// carol paid but holds no squares, so only bob counts as a square owner
+	assert.Equal(t, &model.PaymentSummary{
+		SquareOwners:   1,
+		Unpaid:         1,
+		CollectedCents: 3500,
+		UnpaidSquares:  2,
+		UnpaidUserIDs:  []string{"bob"},
+	}, got)
+}
+
+func TestGetPaymentSummary_NotOwner(t *testing.T) {
+	p := mocks.NewParticipantRepository(t)
+	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, "bob").Return(&model.ContestParticipant{Role: model.ParticipantRoleParticipant}, nil)
+
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().IsOrgAdmin(mock.Anything, mock.Anything, "bob").Return(false, nil)
+
+	_, err := service.NewParticipantService(p, c, anyNats()).GetPaymentSummary(context.Background(), uuid.New(), "bob")
+	assert.ErrorIs(t, err, errs.ErrInsufficientRole)
+}
+
+func TestGetParticipants_HidesPaymentsFromParticipants(t *testing.T) {
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetVisibilityByID(mock.Anything, mock.Anything).Return(model.ContestVisibilityPrivate, nil)
+	c.EXPECT().IsOrgAdmin(mock.Anything, mock.Anything, "bob").Return(false, nil)
+	p := mocks.NewParticipantRepository(t)
+	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, "bob").Return(&model.ContestParticipant{Role: model.ParticipantRoleParticipant}, nil)
+	p.EXPECT().GetAllByContestID(mock.Anything, mock.Anything).Return(paymentRoster(true), nil)
+
+	got, err := service.NewParticipantService(p, c, anyNats()).GetParticipants(context.Background(), uuid.New(), "bob")
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.False(t, got[1].Paid)
+	assert.Zero(t, got[1].AmountPaidCents)
+}
+
+func paymentMocks(t *testing.T, status model.ContestStatus, target *model.ContestParticipant) (*mocks.ContestRepository, *mocks.ParticipantRepository) {
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: status}, nil)
+	p := mocks.NewParticipantRepository(t)
+	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, "alice").Return(&model.ContestParticipant{Role: model.ParticipantRoleOwner}, nil)
+	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, "bob").Return(target, nil)
+	return c, p
+}
+
+func TestUpdatePayment_MarksPaid(t *testing.T) {
+	c, p := paymentMocks(t, model.ContestStatusFinished, &model.ContestParticipant{UserID: "bob", Role: model.ParticipantRoleParticipant})
+	p.EXPECT().Update(mock.Anything, mock.Anything).Return(nil)
+
+	paid, amount := true, 2000
+	got, err := service.NewParticipantService(p, c, anyNats()).
+		UpdatePayment(context.Background(), uuid.New(), "bob", &model.UpdatePaymentRequest{Paid: &paid, AmountPaidCents: &amount}, "alice")
+	require.NoError(t, err)
+	assert.True(t, got.Paid)
+	assert.Equal(t, 2000, got.AmountPaidCents)
+	assert.NotNil(t, got.PaidAt)
+}
+
+func TestUpdatePayment_MarkUnpaidResetsAmount(t *testing.T) {
+	c, p := paymentMocks(t, model.ContestStatusActive, &model.ContestParticipant{UserID: "bob", Paid: true, AmountPaidCents: 2000})
+	p.EXPECT().Update(mock.Anything, mock.Anything).Return(nil)
+
+	paid := false
+	got, err := service.NewParticipantService(p, c, anyNats()).
+		UpdatePayment(context.Background(), uuid.New(), "bob", &model.UpdatePaymentRequest{Paid: &paid}, "alice")
+	require.NoError(t, err)
+	assert.False(t, got.Paid)
+	assert.Zero(t, got.AmountPaidCents)
+	assert.Nil(t, got.PaidAt)
+}
+
+func TestUpdatePayment_DeletedContest(t *testing.T) {
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusDeleted}, nil)
+
+	paid := true
+	_, err := service.NewParticipantService(mocks.NewParticipantRepository(t), c, anyNats()).
+		UpdatePayment(context.Background(), uuid.New(), "bob", &model.UpdatePaymentRequest{Paid: &paid}, "alice")
+	assert.ErrorIs(t, err, errs.ErrContestFinalized)
+}
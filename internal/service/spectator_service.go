@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/metrics"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/repository"
+	"github.com/maxmorhardt/squares-api/internal/util"
+	"gorm.io/gorm"
+)
+
+type SpectatorService interface {
+	CreateToken(ctx context.Context, contestID uuid.UUID, req *model.CreateSpectatorTokenRequest, user string) (*model.ContestSpectatorToken, error)
+	GetTokensByContestID(ctx context.Context, contestID uuid.UUID, user string) ([]model.ContestSpectatorToken, error)
+	RevokeToken(ctx context.Context, contestID, tokenID uuid.UUID, user string) error
+	ValidateToken(ctx context.Context, token string) (*model.ContestSpectatorToken, error)
+	GetSnapshot(ctx context.Context, token string) (*model.Contest, error)
+}
+
+type spectatorService struct {
+	spectatorRepo      repository.SpectatorRepository
+	contestRepo        repository.ContestRepository
+	participantService ParticipantService
+	natsService        NatsService
+}
+
+func NewSpectatorService(
+	spectatorRepo repository.SpectatorRepository,
+	contestRepo repository.ContestRepository,
+	participantService ParticipantService,
+	natsService NatsService,
+) SpectatorService {
+	return &spectatorService{
+		spectatorRepo:      spectatorRepo,
+		contestRepo:        contestRepo,
+		participantService: participantService,
+		natsService:        natsService,
+	}
+}
+
+func (s *spectatorService) CreateToken(ctx context.Context, contestID uuid.UUID, req *model.CreateSpectatorTokenRequest, user string) (*model.ContestSpectatorToken, error) {
+	log := util.LoggerFromContext(ctx)
+
+	// finished contests can still be shared so family can see who won; deleted ones are not found
+	if _, err := s.contestRepo.GetByID(ctx, contestID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		log.Error("failed to get contest", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	// sharing a read-only link is managed by the same people who manage invites
+	if err := s.participantService.Authorize(ctx, contestID, user, ActionManageInvites); err != nil {
+		return nil, err
+	}
+
+	token := &model.ContestSpectatorToken{
+		ContestID: contestID,
+		Label:     req.Label,
+		CreatedBy: user,
+	}
+
+	if req.ExpiresIn > 0 {
+		expiresAt := time.Now().Add(time.Duration(req.ExpiresIn) * time.Minute)
+		token.ExpiresAt = &expiresAt
+	}
+
+	if err := s.spectatorRepo.Create(ctx, token); err != nil {
+		log.Error("failed to create spectator token", "contest_id", contestID, "error", err)
+		return nil, err
+	}
+
+	metrics.IncSpectatorTokenCreated()
+	log.Info("spectator token created", "spectator_token_id", token.ID, "contest_id", contestID)
+	return token, nil
+}
+
+func (s *spectatorService) GetTokensByContestID(ctx context.Context, contestID uuid.UUID, user string) ([]model.ContestSpectatorToken, error) {
+	log := util.LoggerFromContext(ctx)
+
+	if err := s.participantService.Authorize(ctx, contestID, user, ActionManageInvites); err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.spectatorRepo.GetAllByContestID(ctx, contestID)
+	if err != nil {
+		log.Error("failed to get spectator tokens", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	log.Info("retrieved spectator tokens by contest", "contest_id", contestID, "count", len(tokens))
+	return tokens, nil
+}
+
+func (s *spectatorService) RevokeToken(ctx context.Context, contestID, tokenID uuid.UUID, user string) error {
+	log := util.LoggerFromContext(ctx)
+
+	if err := s.participantService.Authorize(ctx, contestID, user, ActionManageInvites); err != nil {
+		return err
+	}
+
+	if err := s.spectatorRepo.Delete(ctx, contestID, tokenID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errs.ErrSpectatorTokenNotFound
+		}
+		log.Error("failed to delete spectator token", "spectator_token_id", tokenID, "error", err)
+		return errs.ErrDatabaseUnavailable
+	}
+
+	// open spectator sockets on this link close as soon as they see the revocation
+	go func() {
+		if err := s.natsService.PublishSpectatorRevoked(contestID, tokenID, user); err != nil {
+			log.Error("failed to publish spectator revoked", "contest_id", contestID, "spectator_token_id", tokenID, "error", err)
+		}
+	}()
+
+	log.Info("spectator token revoked", "spectator_token_id", tokenID, "contest_id", contestID)
+	return nil
+}
+
+func (s *spectatorService) ValidateToken(ctx context.Context, token string) (*model.ContestSpectatorToken, error) {
+	log := util.LoggerFromContext(ctx)
+
+	spectatorToken, err := s.spectatorRepo.GetByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrSpectatorTokenNotFound
+		}
+		log.Error("failed to get spectator token", "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	if spectatorToken.IsExpired() {
+		return nil, errs.ErrSpectatorTokenExpired
+	}
+
+	return spectatorToken, nil
+}
+
+func (s *spectatorService) GetSnapshot(ctx context.Context, token string) (*model.Contest, error) {
+	log := util.LoggerFromContext(ctx)
+
+	spectatorToken, err := s.ValidateToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	contest, err := s.contestRepo.GetByID(ctx, spectatorToken.ContestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		log.Error("failed to get contest for spectator", "contest_id", spectatorToken.ContestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	util.SynthesizeFromGame(contest)
+	util.RedactContest(contest)
+
+	log.Info("retrieved spectator snapshot", "contest_id", contest.ID, "spectator_token_id", spectatorToken.ID)
+	return contest, nil
+}
@@ -0,0 +1,181 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/mocks"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func spectatorSvc(s *mocks.SpectatorRepository, c *mocks.ContestRepository, pSvc *mocks.ParticipantService) service.SpectatorService {
+	return service.NewSpectatorService(s, c, pSvc, anyNats())
+}
+
+func TestCreateSpectatorToken_Success(t *testing.T) {
+	contestID := uuid.New()
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, contestID).Return(&model.Contest{ID: contestID, Status: model.ContestStatusFinished}, nil)
+	pSvc := mocks.NewParticipantService(t)
+	pSvc.EXPECT().Authorize(mock.Anything, contestID, "owner", service.ActionManageInvites).Return(nil)
+	s := mocks.NewSpectatorRepository(t)
+	s.EXPECT().Create(mock.Anything, mock.MatchedBy(func(tok *model.ContestSpectatorToken) bool {
+		return tok.ContestID == contestID && tok.Label == "Grandma" && tok.CreatedBy == "owner" &&
+			tok.ExpiresAt != nil && time.Until(*tok.ExpiresAt) > 59*time.Minute
+	})).Return(nil)
+
+	token, err := spectatorSvc(s, c, pSvc).CreateToken(context.Background(), contestID, &model.CreateSpectatorTokenRequest{Label: "Grandma", ExpiresIn: 60}, "owner")
+	require.NoError(t, err)
+	assert.Equal(t, "Grandma", token.Label)
+}
+
+func TestCreateSpectatorToken_NoExpiry(t *testing.T) {
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{}, nil)
+	s := mocks.NewSpectatorRepository(t)
+	s.EXPECT().Create(mock.Anything, mock.MatchedBy(func(tok *model.ContestSpectatorToken) bool { return tok.ExpiresAt == nil })).Return(nil)
+
+	_, err := spectatorSvc(s, c, okAuth(t)).CreateToken(context.Background(), uuid.New(), &model.CreateSpectatorTokenRequest{}, "owner")
+	require.NoError(t, err)
+}
+
+func TestCreateSpectatorToken_ContestNotFound(t *testing.T) {
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := spectatorSvc(mocks.NewSpectatorRepository(t), c, mocks.NewParticipantService(t)).
+		CreateToken(context.Background(), uuid.New(), &model.CreateSpectatorTokenRequest{}, "owner")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestCreateSpectatorToken_Unauthorized(t *testing.T) {
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{}, nil)
+	pSvc := mocks.NewParticipantService(t)
+	pSvc.EXPECT().Authorize(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errs.ErrInsufficientRole)
+
+	_, err := spectatorSvc(mocks.NewSpectatorRepository(t), c, pSvc).
+		CreateToken(context.Background(), uuid.New(), &model.CreateSpectatorTokenRequest{}, "viewer")
+	assert.ErrorIs(t, err, errs.ErrInsufficientRole)
+}
+
+func TestGetSpectatorTokens(t *testing.T) {
+	contestID := uuid.New()
+	s := mocks.NewSpectatorRepository(t)
+	s.EXPECT().GetAllByContestID(mock.Anything, contestID).Return([]model.ContestSpectatorToken{{Label: "a"}, {Label: "b"}}, nil)
+
+	tokens, err := spectatorSvc(s, mocks.NewContestRepository(t), okAuth(t)).GetTokensByContestID(context.Background(), contestID, "owner")
+	require.NoError(t, err)
+	assert.Len(t, tokens, 2)
+}
+
+func TestGetSpectatorTokens_DBError(t *testing.T) {
+	s := mocks.NewSpectatorRepository(t)
+	s.EXPECT().GetAllByContestID(mock.Anything, mock.Anything).Return(nil, errors.New("boom"))
+
+	_, err := spectatorSvc(s, mocks.NewContestRepository(t), okAuth(t)).GetTokensByContestID(context.Background(), uuid.New(), "owner")
+	assert.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
+}
+
+func TestRevokeSpectatorToken_PublishesRevocation(t *testing.T) {
+	contestID, tokenID := uuid.New(), uuid.New()
+	s := mocks.NewSpectatorRepository(t)
+	s.EXPECT().Delete(mock.Anything, contestID, tokenID).Return(nil)
+
+	published := make(chan uuid.UUID, 1)
+	nats := mocks.NewNatsService(t)
+	nats.EXPECT().PublishSpectatorRevoked(contestID, tokenID, "owner").
+		Run(func(_, id uuid.UUID, _ string) { published <- id }).Return(nil)
+
+	err := service.NewSpectatorService(s, mocks.NewContestRepository(t), okAuth(t), nats).RevokeToken(context.Background(), contestID, tokenID, "owner")
+	require.NoError(t, err)
+
+	select {
+	case id := <-published:
+		assert.Equal(t, tokenID, id)
+	case <-time.After(time.Second):
+		t.Fatal("revocation was not published")
+	}
+}
+
+func TestRevokeSpectatorToken_NotFound(t *testing.T) {
+	s := mocks.NewSpectatorRepository(t)
+	s.EXPECT().Delete(mock.Anything, mock.Anything, mock.Anything).Return(gorm.ErrRecordNotFound)
+
+	err := spectatorSvc(s, mocks.NewContestRepository(t), okAuth(t)).RevokeToken(context.Background(), uuid.New(), uuid.New(), "owner")
+	assert.ErrorIs(t, err, errs.ErrSpectatorTokenNotFound)
+}
+
+func TestRevokeSpectatorToken_Unauthorized(t *testing.T) {
+	pSvc := mocks.NewParticipantService(t)
+	pSvc.EXPECT().Authorize(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errs.ErrNotParticipant)
+
+	err := spectatorSvc(mocks.NewSpectatorRepository(t), mocks.NewContestRepository(t), pSvc).RevokeToken(context.Background(), uuid.New(), uuid.New(), "u")
+	assert.ErrorIs(t, err, errs.ErrNotParticipant)
+}
+
+func TestValidateSpectatorToken(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	for _, tc := range []struct {
+		name  string
+		token *model.ContestSpectatorToken
+		err   error
+		want  error
+	}{
+		{name: "valid", token: &model.ContestSpectatorToken{Token: "tok"}},
+		{name: "expired", token: &model.ContestSpectatorToken{Token: "tok", ExpiresAt: &past}, want: errs.ErrSpectatorTokenExpired},
+		{name: "revoked", err: gorm.ErrRecordNotFound, want: errs.ErrSpectatorTokenNotFound},
+		{name: "db error", err: errors.New("boom"), want: errs.ErrDatabaseUnavailable},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := mocks.NewSpectatorRepository(t)
+			s.EXPECT().GetByToken(mock.Anything, "tok").Return(tc.token, tc.err)
+
+			_, err := spectatorSvc(s, mocks.NewContestRepository(t), mocks.NewParticipantService(t)).ValidateToken(context.Background(), "tok")
+			if tc.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetSpectatorSnapshot_RedactsEmails(t *testing.T) {
+	contestID := uuid.New()
+	s := mocks.NewSpectatorRepository(t)
+	s.EXPECT().GetByToken(mock.Anything, "tok").Return(&model.ContestSpectatorToken{ContestID: contestID}, nil)
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, contestID).Return(&model.Contest{
+		ID: contestID, Owner: "owner@example.com", Visibility: model.ContestVisibilityPrivate,
+		Squares:        []model.Square{{Value: "AB", Owner: "a@example.com", OwnerName: "Alice"}},
+		QuarterResults: []model.QuarterResult{{Quarter: 1, Winner: "a@example.com", WinnerName: "Alice"}},
+	}, nil)
+
+	// spectator links work on private contests and never touch participant authorization
+	contest, err := spectatorSvc(s, c, mocks.NewParticipantService(t)).GetSnapshot(context.Background(), "tok")
+	require.NoError(t, err)
+	assert.Empty(t, contest.Owner)
+	assert.Empty(t, contest.Squares[0].Owner)
+	assert.Equal(t, "Alice", contest.Squares[0].OwnerName)
+	assert.Empty(t, contest.QuarterResults[0].Winner)
+}
+
+func TestGetSpectatorSnapshot_ContestDeleted(t *testing.T) {
+	s := mocks.NewSpectatorRepository(t)
+	s.EXPECT().GetByToken(mock.Anything, "tok").Return(&model.ContestSpectatorToken{ContestID: uuid.New()}, nil)
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := spectatorSvc(s, c, mocks.NewParticipantService(t)).GetSnapshot(context.Background(), "tok")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
@@ -0,0 +1,102 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/mocks"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func styleContestRepo(t *testing.T, status model.ContestStatus) *mocks.ContestRepository {
+	t.Helper()
+	c := mocks.NewContestRepository(t)
+	c.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: status}, nil)
+	return c
+}
+
+func TestUpdateSquareStyle_SetsOverrides(t *testing.T) {
+	value, color := "MM2", "teal"
+	p := mocks.NewParticipantRepository(t)
+	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, "mom@b.com").
+		Return(&model.ContestParticipant{UserID: "mom@b.com"}, nil)
+	p.EXPECT().UpdateSquareStyle(mock.Anything, mock.MatchedBy(func(cp *model.ContestParticipant) bool {
+		return *cp.DisplayValue == "MM2" && *cp.Color == "teal"
+	})).Return([]model.Square{{ID: uuid.New(), Value: "MM2", Color: "teal"}}, nil)
+
+	svc := service.NewParticipantService(p, styleContestRepo(t, model.ContestStatusActive), anyNats())
+	got, err := svc.UpdateSquareStyle(context.Background(), uuid.New(), &model.UpdateSquareStyleRequest{DisplayValue: &value, Color: &color}, "mom@b.com")
+
+	require.NoError(t, err)
+	assert.Equal(t, "MM2", *got.DisplayValue)
+	assert.Equal(t, "teal", *got.Color)
+}
+
+func TestUpdateSquareStyle_ClearFallsBackToDefaults(t *testing.T) {
+	value, color := "MM2", "teal"
+	p := mocks.NewParticipantRepository(t)
+	p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, "mom@b.com").
+		Return(&model.ContestParticipant{UserID: "mom@b.com", DisplayValue: &value, Color: &color}, nil)
+	p.EXPECT().UpdateSquareStyle(mock.Anything, mock.MatchedBy(func(cp *model.ContestParticipant) bool {
+		return cp.DisplayValue == nil && *cp.Color == "teal"
+	})).Return(nil, nil)
+
+	svc := service.NewParticipantService(p, styleContestRepo(t, model.ContestStatusActive), anyNats())
+	got, err := svc.UpdateSquareStyle(context.Background(), uuid.New(), &model.UpdateSquareStyleRequest{ClearDisplayValue: true}, "mom@b.com")
+
+	require.NoError(t, err)
+	assert.Nil(t, got.DisplayValue)
+}
+
+func TestUpdateSquareStyle_Rejected(t *testing.T) {
+	t.Run("empty body", func(t *testing.T) {
+		svc := service.NewParticipantService(mocks.NewParticipantRepository(t), mocks.NewContestRepository(t), anyNats())
+
+		_, err := svc.UpdateSquareStyle(context.Background(), uuid.New(), &model.UpdateSquareStyleRequest{}, "mom@b.com")
+		assert.ErrorIs(t, err, errs.ErrEmptySquareStyleUpdate)
+	})
+
+	t.Run("finished contest", func(t *testing.T) {
+		svc := service.NewParticipantService(mocks.NewParticipantRepository(t), styleContestRepo(t, model.ContestStatusFinished), anyNats())
+
+		_, err := svc.UpdateSquareStyle(context.Background(), uuid.New(), &model.UpdateSquareStyleRequest{ClearColor: true}, "mom@b.com")
+		assert.ErrorIs(t, err, errs.ErrContestFinalized)
+	})
+
+	t.Run("not a participant", func(t *testing.T) {
+		p := mocks.NewParticipantRepository(t)
+		p.EXPECT().GetByContestAndUser(mock.Anything, mock.Anything, "stranger@b.com").Return(nil, gorm.ErrRecordNotFound)
+		svc := service.NewParticipantService(p, styleContestRepo(t, model.ContestStatusActive), anyNats())
+
+		_, err := svc.UpdateSquareStyle(context.Background(), uuid.New(), &model.UpdateSquareStyleRequest{ClearColor: true}, "stranger@b.com")
+		assert.ErrorIs(t, err, errs.ErrNotParticipant)
+	})
+}
+
+func TestStartContest_RandomFillUsesSquareStyle(t *testing.T) {
+	value, color := "MM2", "pink"
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{
+		Status:     model.ContestStatusActive,
+		FillPolicy: model.FillPolicyRandom,
+		Squares:    []model.Square{{ID: uuid.New(), Owner: "alice"}, {ID: uuid.New()}},
+	}, nil)
+	pRepo := mocks.NewParticipantRepository(t)
+	pRepo.EXPECT().GetAllByContestID(mock.Anything, mock.Anything).Return([]model.ContestParticipant{
+		{UserID: "alice", Role: model.ParticipantRoleOwner, MaxSquares: 2, DisplayValue: &value, Color: &color},
+	}, nil)
+	repo.EXPECT().StartWithSquares(mock.Anything, mock.Anything, mock.Anything, mock.MatchedBy(func(sqs []model.Square) bool {
+		return len(sqs) == 1 && sqs[0].Owner == "alice" && sqs[0].Value == "MM2" && sqs[0].Color == "pink"
+	})).Return(nil)
+
+	_, err := contestSvc(repo, pRepo, mocks.NewParticipantService(t)).
+		StartContest(context.Background(), uuid.New(), "u")
+	require.NoError(t, err)
+}
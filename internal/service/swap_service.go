@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/repository"
+	"github.com/maxmorhardt/squares-api/internal/util"
+	"gorm.io/gorm"
+)
+
+type SwapService interface {
+	GetPendingSwaps(ctx context.Context, contestID uuid.UUID, user string) ([]model.SquareSwap, error)
+	RequestSwap(ctx context.Context, contestID uuid.UUID, req *model.CreateSwapRequest, user string) (*model.SquareSwap, error)
+	AcceptSwap(ctx context.Context, contestID, swapID uuid.UUID, user string) ([]model.Square, error)
+	DeclineSwap(ctx context.Context, contestID, swapID uuid.UUID, user string) (*model.SquareSwap, error)
+}
+
+type swapService struct {
+	contestRepo        repository.ContestRepository
+	participantService ParticipantService
+	natsService        NatsService
+}
+
+func NewSwapService(
+	contestRepo repository.ContestRepository,
+	participantService ParticipantService,
+	natsService NatsService,
+) SwapService {
+	return &swapService{
+		contestRepo:        contestRepo,
+		participantService: participantService,
+		natsService:        natsService,
+	}
+}
+
+func (s *swapService) GetPendingSwaps(ctx context.Context, contestID uuid.UUID, user string) ([]model.SquareSwap, error) {
+	log := util.LoggerFromContext(ctx)
+
+	if err := s.participantService.Authorize(ctx, contestID, user, ActionClaimSquare); err != nil {
+		log.Warn("user not authorized to view swaps", "contest_id", contestID, "user", user)
+		return nil, err
+	}
+
+	swaps, err := s.contestRepo.GetPendingSwaps(ctx, contestID, user)
+	if err != nil {
+		log.Error("failed to get pending swaps", "contest_id", contestID, "user", user, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	return swaps, nil
+}
+
+func (s *swapService) RequestSwap(ctx context.Context, contestID uuid.UUID, req *model.CreateSwapRequest, user string) (*model.SquareSwap, error) {
+	log := util.LoggerFromContext(ctx)
+
+	contest, err := s.activeContest(ctx, contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.participantService.Authorize(ctx, contestID, user, ActionClaimSquare); err != nil {
+		log.Warn("user not authorized to request swaps", "contest_id", contestID, "user", user)
+		return nil, errs.ErrUnauthorizedSquareEdit
+	}
+
+	var from, to *model.Square
+	for i := range contest.Squares {
+		switch contest.Squares[i].ID {
+		case req.FromSquareID:
+			from = &contest.Squares[i]
+		case req.ToSquareID:
+			to = &contest.Squares[i]
+		}
+	}
+
+	if from == nil || to == nil {
+		log.Warn("swap square not found in contest", "contest_id", contestID, "from_square_id", req.FromSquareID, "to_square_id", req.ToSquareID)
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	if from.Owner != user {
+		log.Warn("user does not own the offered square", "square_id", from.ID, "owner", from.Owner, "user", user)
+		return nil, errs.ErrUnauthorizedSquareEdit
+	}
+
+	if to.Owner == "" || to.Owner == user {
+		log.Warn("swap target is not held by another participant", "square_id", to.ID, "owner", to.Owner)
+		return nil, errs.ErrInvalidSwap
+	}
+
+	// the house and ghost hold squares but can never accept
+	if err := s.participantService.Authorize(ctx, contestID, to.Owner, ActionClaimSquare); err != nil {
+		log.Warn("swap target owner cannot take part in swaps", "square_id", to.ID, "owner", to.Owner)
+		return nil, errs.ErrInvalidSwap
+	}
+
+	swap := &model.SquareSwap{
+		ContestID:    contestID,
+		FromSquareID: from.ID,
+		ToSquareID:   to.ID,
+		Requester:    user,
+		Recipient:    to.Owner,
+		Status:       model.SwapStatusPending,
+	}
+	if err := s.contestRepo.CreateSwap(ctx, swap); err != nil {
+		log.Error("failed to create swap request", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	log.Info("swap requested", "contest_id", contestID, "swap_id", swap.ID, "requester", user, "recipient", swap.Recipient)
+	return swap, nil
+}
+
+func (s *swapService) AcceptSwap(ctx context.Context, contestID, swapID uuid.UUID, user string) ([]model.Square, error) {
+	log := util.LoggerFromContext(ctx)
+
+	swap, err := s.pendingSwap(ctx, contestID, swapID)
+	if err != nil {
+		return nil, err
+	}
+
+	if swap.Recipient != user {
+		log.Warn("only the recipient can accept a swap", "swap_id", swapID, "recipient", swap.Recipient, "user", user)
+		return nil, errs.ErrUnauthorizedSwap
+	}
+
+	if _, err := s.activeContest(ctx, contestID); err != nil {
+		return nil, err
+	}
+
+	squares, err := s.contestRepo.AcceptSwap(ctx, swap)
+	if err != nil {
+		if errors.Is(err, errs.ErrSwapNotPending) || errors.Is(err, errs.ErrSwapOutdated) {
+			log.Warn("swap could not be applied", "swap_id", swapID, "error", err)
+			return nil, err
+		}
+		log.Error("failed to accept swap", "swap_id", swapID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	go func() {
+		if err := s.natsService.PublishSquaresUpdate(contestID, user, squares); err != nil {
+			log.Error("failed to publish swap", "contest_id", contestID, "swap_id", swapID, "error", err)
+		}
+	}()
+
+	log.Info("swap accepted", "contest_id", contestID, "swap_id", swapID, "requester", swap.Requester, "recipient", swap.Recipient)
+	return squares, nil
+}
+
+func (s *swapService) DeclineSwap(ctx context.Context, contestID, swapID uuid.UUID, user string) (*model.SquareSwap, error) {
+	log := util.LoggerFromContext(ctx)
+
+	swap, err := s.pendingSwap(ctx, contestID, swapID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the recipient declines; the requester withdraws
+	var status model.SwapStatus
+	switch user {
+	case swap.Recipient:
+		status = model.SwapStatusDeclined
+	case swap.Requester:
+		status = model.SwapStatusCancelled
+	default:
+		log.Warn("user is not part of swap", "swap_id", swapID, "user", user)
+		return nil, errs.ErrUnauthorizedSwap
+	}
+
+	if err := s.contestRepo.CloseSwap(ctx, swap, status); err != nil {
+		if errors.Is(err, errs.ErrSwapNotPending) {
+			return nil, err
+		}
+		log.Error("failed to close swap", "swap_id", swapID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	log.Info("swap closed", "contest_id", contestID, "swap_id", swapID, "status", status, "user", user)
+	return swap, nil
+}
+
+func (s *swapService) activeContest(ctx context.Context, contestID uuid.UUID) (*model.Contest, error) {
+	log := util.LoggerFromContext(ctx)
+
+	contest, err := s.contestRepo.GetByID(ctx, contestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		log.Error("failed to get contest for swap", "contest_id", contestID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	// squares only change hands before the grid locks
+	if contest.Status != model.ContestStatusActive {
+		log.Warn("cannot swap squares when contest is not active", "contest_id", contestID, "contest_status", contest.Status)
+		return nil, errs.ErrSquareNotEditable
+	}
+
+	return contest, nil
+}
+
+func (s *swapService) pendingSwap(ctx context.Context, contestID, swapID uuid.UUID) (*model.SquareSwap, error) {
+	log := util.LoggerFromContext(ctx)
+
+	swap, err := s.contestRepo.GetSwap(ctx, contestID, swapID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrSwapNotFound
+		}
+		log.Error("failed to get swap", "swap_id", swapID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	if swap.Status != model.SwapStatusPending {
+		return nil, errs.ErrSwapNotPending
+	}
+
+	return swap, nil
+}
@@ -0,0 +1,183 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/errs"
+	"github.com/maxmorhardt/squares-api/internal/mocks"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func swapSvc(repo *mocks.ContestRepository, pSvc *mocks.ParticipantService) service.SwapService {
+	return service.NewSwapService(repo, pSvc, anyNats())
+}
+
+func swapContest(from, to uuid.UUID, toOwner string) *model.Contest {
+	return &model.Contest{
+		Status: model.ContestStatusActive,
+		Squares: []model.Square{
+			{ID: from, Owner: "u"},
+			{ID: to, Owner: toOwner},
+		},
+	}
+}
+
+func TestRequestSwap_NotActive(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusQ1}, nil)
+
+	_, err := swapSvc(repo, mocks.NewParticipantService(t)).
+		RequestSwap(context.Background(), uuid.New(), &model.CreateSwapRequest{FromSquareID: uuid.New(), ToSquareID: uuid.New()}, "u")
+	assert.ErrorIs(t, err, errs.ErrSquareNotEditable)
+}
+
+func TestRequestSwap_SquareNotFound(t *testing.T) {
+	from := uuid.New()
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(swapContest(from, uuid.New(), "v"), nil)
+
+	_, err := swapSvc(repo, okAuth(t)).
+		RequestSwap(context.Background(), uuid.New(), &model.CreateSwapRequest{FromSquareID: from, ToSquareID: uuid.New()}, "u")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestRequestSwap_NotOwnSquare(t *testing.T) {
+	from, to := uuid.New(), uuid.New()
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(swapContest(from, to, "v"), nil)
+
+	_, err := swapSvc(repo, okAuth(t)).
+		RequestSwap(context.Background(), uuid.New(), &model.CreateSwapRequest{FromSquareID: to, ToSquareID: from}, "v2")
+	assert.ErrorIs(t, err, errs.ErrUnauthorizedSquareEdit)
+}
+
+func TestRequestSwap_UnclaimedTarget(t *testing.T) {
+	from, to := uuid.New(), uuid.New()
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(swapContest(from, to, ""), nil)
+
+	_, err := swapSvc(repo, okAuth(t)).
+		RequestSwap(context.Background(), uuid.New(), &model.CreateSwapRequest{FromSquareID: from, ToSquareID: to}, "u")
+	assert.ErrorIs(t, err, errs.ErrInvalidSwap)
+}
+
+func TestRequestSwap_HouseTarget(t *testing.T) {
+	from, to := uuid.New(), uuid.New()
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(swapContest(from, to, model.HouseUser), nil)
+	pSvc := mocks.NewParticipantService(t)
+	pSvc.EXPECT().Authorize(mock.Anything, mock.Anything, "u", service.ActionClaimSquare).Return(nil)
+	pSvc.EXPECT().Authorize(mock.Anything, mock.Anything, model.HouseUser, service.ActionClaimSquare).Return(errs.ErrNotParticipant)
+
+	_, err := swapSvc(repo, pSvc).
+		RequestSwap(context.Background(), uuid.New(), &model.CreateSwapRequest{FromSquareID: from, ToSquareID: to}, "u")
+	assert.ErrorIs(t, err, errs.ErrInvalidSwap)
+}
+
+func TestRequestSwap_Success(t *testing.T) {
+	from, to := uuid.New(), uuid.New()
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(swapContest(from, to, "v"), nil)
+	repo.EXPECT().CreateSwap(mock.Anything, mock.Anything).Return(nil)
+
+	got, err := swapSvc(repo, okAuth(t)).
+		RequestSwap(context.Background(), uuid.New(), &model.CreateSwapRequest{FromSquareID: from, ToSquareID: to}, "u")
+	require.NoError(t, err)
+	assert.Equal(t, "u", got.Requester)
+	assert.Equal(t, "v", got.Recipient)
+	assert.Equal(t, model.SwapStatusPending, got.Status)
+}
+
+func TestAcceptSwap_NotFound(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetSwap(mock.Anything, mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := swapSvc(repo, mocks.NewParticipantService(t)).
+		AcceptSwap(context.Background(), uuid.New(), uuid.New(), "v")
+	assert.ErrorIs(t, err, errs.ErrSwapNotFound)
+}
+
+func TestAcceptSwap_NotRecipient(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetSwap(mock.Anything, mock.Anything, mock.Anything).
+		Return(&model.SquareSwap{Requester: "u", Recipient: "v", Status: model.SwapStatusPending}, nil)
+
+	_, err := swapSvc(repo, mocks.NewParticipantService(t)).
+		AcceptSwap(context.Background(), uuid.New(), uuid.New(), "u")
+	assert.ErrorIs(t, err, errs.ErrUnauthorizedSwap)
+}
+
+func TestAcceptSwap_AlreadyClosed(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetSwap(mock.Anything, mock.Anything, mock.Anything).
+		Return(&model.SquareSwap{Requester: "u", Recipient: "v", Status: model.SwapStatusDeclined}, nil)
+
+	_, err := swapSvc(repo, mocks.NewParticipantService(t)).
+		AcceptSwap(context.Background(), uuid.New(), uuid.New(), "v")
+	assert.ErrorIs(t, err, errs.ErrSwapNotPending)
+}
+
+func TestAcceptSwap_Outdated(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetSwap(mock.Anything, mock.Anything, mock.Anything).
+		Return(&model.SquareSwap{Requester: "u", Recipient: "v", Status: model.SwapStatusPending}, nil)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
+	repo.EXPECT().AcceptSwap(mock.Anything, mock.Anything).Return(nil, errs.ErrSwapOutdated)
+
+	_, err := swapSvc(repo, mocks.NewParticipantService(t)).
+		AcceptSwap(context.Background(), uuid.New(), uuid.New(), "v")
+	assert.ErrorIs(t, err, errs.ErrSwapOutdated)
+}
+
+func TestAcceptSwap_Success(t *testing.T) {
+	swapped := []model.Square{{ID: uuid.New(), Owner: "v"}, {ID: uuid.New(), Owner: "u"}}
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetSwap(mock.Anything, mock.Anything, mock.Anything).
+		Return(&model.SquareSwap{Requester: "u", Recipient: "v", Status: model.SwapStatusPending}, nil)
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(&model.Contest{Status: model.ContestStatusActive}, nil)
+	repo.EXPECT().AcceptSwap(mock.Anything, mock.Anything).Return(swapped, nil)
+
+	got, err := swapSvc(repo, mocks.NewParticipantService(t)).
+		AcceptSwap(context.Background(), uuid.New(), uuid.New(), "v")
+	require.NoError(t, err)
+	assert.Equal(t, swapped, got)
+}
+
+func TestDeclineSwap_ByRecipient(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetSwap(mock.Anything, mock.Anything, mock.Anything).
+		Return(&model.SquareSwap{Requester: "u", Recipient: "v", Status: model.SwapStatusPending}, nil)
+	repo.EXPECT().CloseSwap(mock.Anything, mock.Anything, model.SwapStatusDeclined).Return(nil)
+
+	_, err := swapSvc(repo, mocks.NewParticipantService(t)).
+		DeclineSwap(context.Background(), uuid.New(), uuid.New(), "v")
+	require.NoError(t, err)
+}
+
+func TestDeclineSwap_ByRequester(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetSwap(mock.Anything, mock.Anything, mock.Anything).
+		Return(&model.SquareSwap{Requester: "u", Recipient: "v", Status: model.SwapStatusPending}, nil)
+	repo.EXPECT().CloseSwap(mock.Anything, mock.Anything, model.SwapStatusCancelled).Return(nil)
+
+	_, err := swapSvc(repo, mocks.NewParticipantService(t)).
+		DeclineSwap(context.Background(), uuid.New(), uuid.New(), "u")
+	require.NoError(t, err)
+}
+
+func TestDeclineSwap_Outsider(t *testing.T) {
+	repo := mocks.NewContestRepository(t)
+	repo.EXPECT().GetSwap(mock.Anything, mock.Anything, mock.Anything).
+		Return(&model.SquareSwap{Requester: "u", Recipient: "v", Status: model.SwapStatusPending}, nil)
+
+	_, err := swapSvc(repo, mocks.NewParticipantService(t)).
+		DeclineSwap(context.Background(), uuid.New(), uuid.New(), "w")
+	assert.ErrorIs(t, err, errs.ErrUnauthorizedSwap)
+}
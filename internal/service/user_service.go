@@ -2,21 +2,40 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/google/uuid"
 	"github.com/maxmorhardt/squares-api/internal/errs"
 	"github.com/maxmorhardt/squares-api/internal/model"
 	"github.com/maxmorhardt/squares-api/internal/repository"
 	"github.com/maxmorhardt/squares-api/internal/util"
+	"gorm.io/gorm"
 )
 
 const (
 	revocationCacheTTL  = 15 * time.Second
 	revocationCacheSize = 10000
+
+	// the request size limit caps bodies at 1MB, so this leaves room for the multipart envelope
+	maxAvatarBytes     = 512 << 10
+	recentContestLimit = 5
 )
 
+// sniffed from the bytes, never taken from the client's content type
+var avatarContentTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
 type revocationKey struct {
 	email    string
 	issuedAt int64
@@ -24,7 +43,11 @@ type revocationKey struct {
 
 type UserService interface {
 	GetProfile(ctx context.Context, email, defaultDisplayName string) (*model.User, error)
-	UpdateProfile(ctx context.Context, email, initials string) (*model.User, error)
+	UpdateProfile(ctx context.Context, email string, req *model.UpdateUserProfileRequest) (*model.User, error)
+	SetAvatar(ctx context.Context, email string, data []byte) (*model.User, error)
+	DeleteAvatar(ctx context.Context, email string) error
+	GetAvatar(ctx context.Context, key string) (*model.UserAvatar, error)
+	GetPublicProfile(ctx context.Context, userID uuid.UUID, viewer string) (*model.PublicProfileResponse, error)
 	GetStats(ctx context.Context, email string) (*model.UserStatsResponse, error)
 	GetActiveContests(ctx context.Context, email string) ([]model.UserActiveContest, error)
 	DeleteAccount(ctx context.Context, email string) error
@@ -34,14 +57,18 @@ type UserService interface {
 
 type userService struct {
 	repo        repository.UserRepository
+	friendRepo  repository.FriendRepository
+	avatars     repository.AvatarStore
 	natsService NatsService
 	oidc        *oidc.IDTokenVerifier
 	revocation  *util.TTLCache[revocationKey, bool]
 }
 
-func NewUserService(repo repository.UserRepository, natsService NatsService, oidcVerifier *oidc.IDTokenVerifier) UserService {
+func NewUserService(repo repository.UserRepository, friendRepo repository.FriendRepository, avatars repository.AvatarStore, natsService NatsService, oidcVerifier *oidc.IDTokenVerifier) UserService {
 	return &userService{
 		repo:        repo,
+		friendRepo:  friendRepo,
+		avatars:     avatars,
 		natsService: natsService,
 		oidc:        oidcVerifier,
 		revocation:  util.NewTTLCache[revocationKey, bool](revocationCacheSize, revocationCacheTTL),
@@ -61,21 +88,26 @@ func (s *userService) GetProfile(ctx context.Context, email, defaultDisplayName
 	return user, nil
 }
 
-func (s *userService) UpdateProfile(ctx context.Context, email, initials string) (*model.User, error) {
+func (s *userService) UpdateProfile(ctx context.Context, email string, req *model.UpdateUserProfileRequest) (*model.User, error) {
 	log := util.LoggerFromContext(ctx)
 
-	user, squares, err := s.repo.UpdateProfile(ctx, email, initials)
+	if req.DefaultInitials == nil && req.DisplayName == nil && req.ProfileVisibility == nil {
+		log.Warn("profile update has no fields")
+		return nil, errs.ErrEmptyProfileUpdate
+	}
+
+	user, squares, err := s.repo.UpdateProfile(ctx, email, req)
 	if err != nil {
 		log.Error("failed to update user profile", "error", err)
 		return nil, errs.ErrDatabaseUnavailable
 	}
 
-	// broadcast the new initials so live contest views update without a refresh
+	// broadcast the new initials and name so live contest views update without a refresh
 	for i := range squares {
 		square := squares[i]
 		go func() {
 			if err := s.natsService.PublishSquareUpdate(square.ContestID, email, &square); err != nil {
-				log.Error("failed to publish square update after initials change", "contest_id", square.ContestID, "square_id", square.ID, "error", err)
+				log.Error("failed to publish square update after profile change", "contest_id", square.ContestID, "square_id", square.ID, "error", err)
 			}
 		}()
 	}
@@ -84,6 +116,189 @@ func (s *userService) UpdateProfile(ctx context.Context, email, initials string)
 	return user, nil
 }
 
+func (s *userService) SetAvatar(ctx context.Context, email string, data []byte) (*model.User, error) {
+	log := util.LoggerFromContext(ctx)
+
+	if len(data) > maxAvatarBytes {
+		log.Warn("avatar too large", "size", len(data))
+		return nil, errs.ErrAvatarTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	if !avatarContentTypes[contentType] {
+		log.Warn("unsupported avatar format", "content_type", contentType)
+		return nil, errs.ErrUnsupportedAvatar
+	}
+
+	user, err := s.getUser(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	// a fresh key per upload retires the old url along with the old image
+	key, err := newAvatarKey()
+	if err != nil {
+		log.Error("failed to generate avatar key", "error", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := s.avatars.Put(ctx, &model.UserAvatar{UserID: user.ID, ContentType: contentType, Data: data, UpdatedAt: now}); err != nil {
+		log.Error("failed to store avatar", "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	user, err = s.repo.SetAvatarVersion(ctx, email, &key, &now)
+	if err != nil {
+		log.Error("failed to record avatar update", "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	log.Info("avatar updated", "size", len(data), "content_type", contentType)
+	return user, nil
+}
+
+func (s *userService) DeleteAvatar(ctx context.Context, email string) error {
+	log := util.LoggerFromContext(ctx)
+
+	user, err := s.getUser(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	if user.AvatarUpdatedAt == nil {
+		log.Warn("no avatar to delete")
+		return errs.ErrAvatarNotFound
+	}
+
+	if err := s.avatars.Delete(ctx, user.ID); err != nil {
+		log.Error("failed to delete avatar", "error", err)
+		return errs.ErrDatabaseUnavailable
+	}
+
+	if _, err := s.repo.SetAvatarVersion(ctx, email, nil, nil); err != nil {
+		log.Error("failed to clear avatar update", "error", err)
+		return errs.ErrDatabaseUnavailable
+	}
+
+	log.Info("avatar deleted")
+	return nil
+}
+
+// the key is only handed out where the profile is visible, so holding it is enough to see the image
+func (s *userService) GetAvatar(ctx context.Context, key string) (*model.UserAvatar, error) {
+	log := util.LoggerFromContext(ctx)
+
+	user, err := s.repo.GetByAvatarKey(ctx, key)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("avatar key not found")
+			return nil, errs.ErrAvatarNotFound
+		}
+
+		log.Error("failed to get user by avatar key", "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	avatar, err := s.avatars.Get(ctx, user.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("avatar not found", "user_id", user.ID)
+			return nil, errs.ErrAvatarNotFound
+		}
+
+		log.Error("failed to get avatar", "user_id", user.ID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	return avatar, nil
+}
+
+func (s *userService) GetPublicProfile(ctx context.Context, userID uuid.UUID, viewer string) (*model.PublicProfileResponse, error) {
+	log := util.LoggerFromContext(ctx)
+
+	user, err := s.visibleUser(ctx, userID, viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.repo.GetStats(ctx, user.Email)
+	if err != nil {
+		log.Error("failed to get stats for public profile", "user_id", userID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	recent, err := s.repo.GetRecentContests(ctx, user.Email, viewer, recentContestLimit)
+	if err != nil {
+		log.Error("failed to get recent contests for public profile", "user_id", userID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	log.Info("retrieved public profile", "user_id", userID)
+	return &model.PublicProfileResponse{
+		ID:             user.ID.String(),
+		DisplayName:    user.DisplayName,
+		AvatarURL:      user.AvatarURL(),
+		MemberSince:    user.CreatedAt.Format(time.RFC3339),
+		Stats:          *stats,
+		RecentContests: recent,
+	}, nil
+}
+
+func (s *userService) getUser(ctx context.Context, email string) (*model.User, error) {
+	log := util.LoggerFromContext(ctx)
+
+	user, err := s.repo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("user not found")
+			return nil, errs.ErrUserNotFound
+		}
+
+		log.Error("failed to get user", "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	return user, nil
+}
+
+// hidden profiles look exactly like missing ones so their existence doesn't leak
+func (s *userService) visibleUser(ctx context.Context, userID uuid.UUID, viewer string) (*model.User, error) {
+	log := util.LoggerFromContext(ctx)
+
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("user not found", "user_id", userID)
+			return nil, errs.ErrUserNotFound
+		}
+
+		log.Error("failed to get user", "user_id", userID, "error", err)
+		return nil, errs.ErrDatabaseUnavailable
+	}
+
+	if strings.EqualFold(user.Email, viewer) {
+		return user, nil
+	}
+
+	switch user.ProfileVisibility {
+	case model.ProfileVisibilityPublic:
+		return user, nil
+	case model.ProfileVisibilityFriends:
+		friends, err := s.friendRepo.AreFriends(ctx, user.Email, viewer)
+		if err != nil {
+			log.Error("failed to check friendship for profile", "user_id", userID, "error", err)
+			return nil, errs.ErrDatabaseUnavailable
+		}
+		if friends {
+			return user, nil
+		}
+	}
+
+	log.Warn("profile hidden from viewer", "user_id", userID, "visibility", user.ProfileVisibility)
+	return nil, errs.ErrUserNotFound
+}
+
 func (s *userService) GetStats(ctx context.Context, email string) (*model.UserStatsResponse, error) {
 	log := util.LoggerFromContext(ctx)
 
@@ -177,3 +392,11 @@ func (s *userService) IsTokenValid(ctx context.Context, claims *model.Claims) (b
 
 	return !revoked, nil
 }
+
+func newAvatarKey() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
@@ -14,14 +14,26 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 func newUserService(t *testing.T) (service.UserService, *mocks.UserRepository) {
 	t.Helper()
 	repo := mocks.NewUserRepository(t)
-	return service.NewUserService(repo, anyNats(), nil), repo
+	return service.NewUserService(repo, &mocks.FriendRepository{}, &mocks.AvatarStore{}, anyNats(), nil), repo
 }
 
+func newProfileService(t *testing.T) (service.UserService, *mocks.UserRepository, *mocks.FriendRepository, *mocks.AvatarStore) {
+	t.Helper()
+	repo := mocks.NewUserRepository(t)
+	friends := mocks.NewFriendRepository(t)
+	avatars := mocks.NewAvatarStore(t)
+	return service.NewUserService(repo, friends, avatars, anyNats(), nil), repo, friends, avatars
+}
+
+// smallest valid png header; enough for content sniffing
+var pngBytes = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
 func TestUserService_IsTokenValid(t *testing.T) {
 	future := time.Now().Add(time.Hour).Unix()
 	valid := &model.Claims{Email: "a@b.com", EmailVerified: true, IssuedAt: 100, Expire: future}
@@ -110,27 +122,203 @@ func TestUserService_GetProfile_Error(t *testing.T) {
 
 func TestUserService_UpdateProfile_Success(t *testing.T) {
 	svc, repo := newUserService(t)
-	squares := []model.Square{{ID: uuid.New(), ContestID: uuid.New(), Owner: "a@b.com", Value: "MM"}}
-	repo.EXPECT().UpdateProfile(mock.Anything, "a@b.com", "MM").
-		Return(&model.User{Email: "a@b.com", DefaultInitials: "MM"}, squares, nil)
+	initials, name := "MM", "Maxwell"
+	req := &model.UpdateUserProfileRequest{DefaultInitials: &initials, DisplayName: &name}
+	squares := []model.Square{{ID: uuid.New(), ContestID: uuid.New(), Owner: "a@b.com", Value: "MM", OwnerName: "Maxwell"}}
+	repo.EXPECT().UpdateProfile(mock.Anything, "a@b.com", req).
+		Return(&model.User{Email: "a@b.com", DisplayName: "Maxwell", DefaultInitials: "MM"}, squares, nil)
 
-	user, err := svc.UpdateProfile(context.Background(), "a@b.com", "MM")
+	user, err := svc.UpdateProfile(context.Background(), "a@b.com", req)
 
 	require.NoError(t, err)
 	assert.Equal(t, "MM", user.DefaultInitials)
+	assert.Equal(t, "Maxwell", user.DisplayName)
+}
+
+func TestUserService_UpdateProfile_Empty(t *testing.T) {
+	svc, _ := newUserService(t)
+
+	user, err := svc.UpdateProfile(context.Background(), "a@b.com", &model.UpdateUserProfileRequest{})
+
+	require.ErrorIs(t, err, errs.ErrEmptyProfileUpdate)
+	assert.Nil(t, user)
 }
 
 func TestUserService_UpdateProfile_Error(t *testing.T) {
 	svc, repo := newUserService(t)
-	repo.EXPECT().UpdateProfile(mock.Anything, "a@b.com", "MM").
+	initials := "MM"
+	repo.EXPECT().UpdateProfile(mock.Anything, "a@b.com", mock.Anything).
 		Return(nil, nil, errors.New("db down"))
 
-	user, err := svc.UpdateProfile(context.Background(), "a@b.com", "MM")
+	user, err := svc.UpdateProfile(context.Background(), "a@b.com", &model.UpdateUserProfileRequest{DefaultInitials: &initials})
 
 	require.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
 	assert.Nil(t, user)
 }
 
+func TestUserService_SetAvatar(t *testing.T) {
+	t.Run("stores sniffed png", func(t *testing.T) {
+		svc, repo, _, avatars := newProfileService(t)
+		id := uuid.New()
+		repo.EXPECT().GetByEmail(mock.Anything, "a@b.com").Return(&model.User{ID: id, Email: "a@b.com"}, nil)
+		avatars.EXPECT().Put(mock.Anything, mock.MatchedBy(func(a *model.UserAvatar) bool {
+			return a.UserID == id && a.ContentType == "image/png"
+		})).Return(nil)
+		// a fresh unguessable key comes with every upload
+		repo.EXPECT().SetAvatarVersion(mock.Anything, "a@b.com", mock.MatchedBy(func(key *string) bool {
+			return key != nil && len(*key) == 32
+		}), mock.Anything).Return(&model.User{ID: id, Email: "a@b.com"}, nil)
+
+		user, err := svc.SetAvatar(context.Background(), "a@b.com", pngBytes)
+
+		require.NoError(t, err)
+		assert.Equal(t, id, user.ID)
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		svc, _, _, _ := newProfileService(t)
+
+		_, err := svc.SetAvatar(context.Background(), "a@b.com", append(pngBytes, make([]byte, 512<<10)...))
+
+		require.ErrorIs(t, err, errs.ErrAvatarTooLarge)
+	})
+
+	t.Run("not an image", func(t *testing.T) {
+		svc, _, _, _ := newProfileService(t)
+
+		_, err := svc.SetAvatar(context.Background(), "a@b.com", []byte("<svg xmlns='http://www.w3.org/2000/svg'></svg>"))
+
+		require.ErrorIs(t, err, errs.ErrUnsupportedAvatar)
+	})
+}
+
+func TestUserService_DeleteAvatar(t *testing.T) {
+	t.Run("deletes", func(t *testing.T) {
+		svc, repo, _, avatars := newProfileService(t)
+		id := uuid.New()
+		now := time.Now()
+		repo.EXPECT().GetByEmail(mock.Anything, "a@b.com").Return(&model.User{ID: id, Email: "a@b.com", AvatarUpdatedAt: &now}, nil)
+		avatars.EXPECT().Delete(mock.Anything, id).Return(nil)
+		repo.EXPECT().SetAvatarVersion(mock.Anything, "a@b.com", (*string)(nil), (*time.Time)(nil)).Return(&model.User{ID: id}, nil)
+
+		require.NoError(t, svc.DeleteAvatar(context.Background(), "a@b.com"))
+	})
+
+	t.Run("nothing to delete", func(t *testing.T) {
+		svc, repo, _, _ := newProfileService(t)
+		repo.EXPECT().GetByEmail(mock.Anything, "a@b.com").Return(&model.User{ID: uuid.New(), Email: "a@b.com"}, nil)
+
+		require.ErrorIs(t, svc.DeleteAvatar(context.Background(), "a@b.com"), errs.ErrAvatarNotFound)
+	})
+}
+
+func TestUserService_GetPublicProfile(t *testing.T) {
+	id := uuid.New()
+	target := func(v model.ProfileVisibility) *model.User {
+		return &model.User{ID: id, Email: "target@b.com", DisplayName: "Target", ProfileVisibility: v, CreatedAt: time.Date(2026, 7, 11, 0, 0, 0, 0, time.UTC)}
+	}
+
+	t.Run("public", func(t *testing.T) {
+		svc, repo, _, _ := newProfileService(t)
+		repo.EXPECT().GetByID(mock.Anything, id).Return(target(model.ProfileVisibilityPublic), nil)
+		repo.EXPECT().GetStats(mock.Anything, "target@b.com").Return(&model.UserStatsResponse{QuarterWins: 2}, nil)
+		repo.EXPECT().GetRecentContests(mock.Anything, "target@b.com", "viewer@b.com", 5).
+			Return([]model.PublicProfileContest{{Name: "Super Bowl"}}, nil)
+
+		profile, err := svc.GetPublicProfile(context.Background(), id, "viewer@b.com")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Target", profile.DisplayName)
+		assert.Equal(t, "2026-07-11T00:00:00Z", profile.MemberSince)
+		assert.Equal(t, int64(2), profile.Stats.QuarterWins)
+		require.Len(t, profile.RecentContests, 1)
+	})
+
+	t.Run("friends only to a friend", func(t *testing.T) {
+		svc, repo, friends, _ := newProfileService(t)
+		repo.EXPECT().GetByID(mock.Anything, id).Return(target(model.ProfileVisibilityFriends), nil)
+		friends.EXPECT().AreFriends(mock.Anything, "target@b.com", "viewer@b.com").Return(true, nil)
+		repo.EXPECT().GetStats(mock.Anything, "target@b.com").Return(&model.UserStatsResponse{}, nil)
+		repo.EXPECT().GetRecentContests(mock.Anything, "target@b.com", "viewer@b.com", 5).Return(nil, nil)
+
+		_, err := svc.GetPublicProfile(context.Background(), id, "viewer@b.com")
+
+		require.NoError(t, err)
+	})
+
+	t.Run("friends only to a stranger", func(t *testing.T) {
+		svc, repo, friends, _ := newProfileService(t)
+		repo.EXPECT().GetByID(mock.Anything, id).Return(target(model.ProfileVisibilityFriends), nil)
+		friends.EXPECT().AreFriends(mock.Anything, "target@b.com", "viewer@b.com").Return(false, nil)
+
+		_, err := svc.GetPublicProfile(context.Background(), id, "viewer@b.com")
+
+		require.ErrorIs(t, err, errs.ErrUserNotFound)
+	})
+
+	t.Run("private to someone else", func(t *testing.T) {
+		svc, repo, _, _ := newProfileService(t)
+		repo.EXPECT().GetByID(mock.Anything, id).Return(target(model.ProfileVisibilityPrivate), nil)
+
+		_, err := svc.GetPublicProfile(context.Background(), id, "viewer@b.com")
+
+		require.ErrorIs(t, err, errs.ErrUserNotFound)
+	})
+
+	t.Run("private to its owner", func(t *testing.T) {
+		svc, repo, _, _ := newProfileService(t)
+		repo.EXPECT().GetByID(mock.Anything, id).Return(target(model.ProfileVisibilityPrivate), nil)
+		repo.EXPECT().GetStats(mock.Anything, "target@b.com").Return(&model.UserStatsResponse{}, nil)
+		repo.EXPECT().GetRecentContests(mock.Anything, "target@b.com", "Target@b.com", 5).Return(nil, nil)
+
+		_, err := svc.GetPublicProfile(context.Background(), id, "Target@b.com")
+
+		require.NoError(t, err)
+	})
+
+	t.Run("missing user", func(t *testing.T) {
+		svc, repo, _, _ := newProfileService(t)
+		repo.EXPECT().GetByID(mock.Anything, id).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.GetPublicProfile(context.Background(), id, "viewer@b.com")
+
+		require.ErrorIs(t, err, errs.ErrUserNotFound)
+	})
+}
+
+func TestUserService_GetAvatar(t *testing.T) {
+	t.Run("by key", func(t *testing.T) {
+		svc, repo, _, avatars := newProfileService(t)
+		id := uuid.New()
+		repo.EXPECT().GetByAvatarKey(mock.Anything, "k1").Return(&model.User{ID: id}, nil)
+		avatars.EXPECT().Get(mock.Anything, id).Return(&model.UserAvatar{UserID: id, ContentType: "image/png"}, nil)
+
+		avatar, err := svc.GetAvatar(context.Background(), "k1")
+
+		require.NoError(t, err)
+		assert.Equal(t, id, avatar.UserID)
+	})
+
+	t.Run("unknown or retired key", func(t *testing.T) {
+		svc, repo, _, _ := newProfileService(t)
+		repo.EXPECT().GetByAvatarKey(mock.Anything, "old").Return(nil, gorm.ErrRecordNotFound)
+
+		avatar, err := svc.GetAvatar(context.Background(), "old")
+
+		require.ErrorIs(t, err, errs.ErrAvatarNotFound)
+		assert.Nil(t, avatar)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		svc, repo, _, _ := newProfileService(t)
+		repo.EXPECT().GetByAvatarKey(mock.Anything, "k1").Return(nil, errors.New("db down"))
+
+		_, err := svc.GetAvatar(context.Background(), "k1")
+
+		require.ErrorIs(t, err, errs.ErrDatabaseUnavailable)
+	})
+}
+
 func TestUserService_GetStats_Success(t *testing.T) {
 	svc, repo := newUserService(t)
 	repo.EXPECT().GetStats(mock.Anything, "a@b.com").
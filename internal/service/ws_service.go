@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
+	"github.com/maxmorhardt/squares-api/internal/errs"
 	"github.com/maxmorhardt/squares-api/internal/metrics"
 	"github.com/maxmorhardt/squares-api/internal/model"
 	"github.com/maxmorhardt/squares-api/internal/util"
@@ -27,25 +29,40 @@ const (
 
 type WebSocketService interface {
 	HandleWebSocketConnection(ctx context.Context, contest *model.Contest, participants []model.ContestParticipant, conn *websocket.Conn)
+	HandleSpectatorConnection(ctx context.Context, contest *model.Contest, spectatorToken *model.ContestSpectatorToken, conn *websocket.Conn)
 }
 
 type websocketService struct {
 	nats               *nats.Conn
 	userService        UserService
 	participantService ParticipantService
+	spectatorService   SpectatorService
 }
 
-func NewWebSocketService(nc *nats.Conn, userService UserService, participantService ParticipantService) WebSocketService {
-	return &websocketService{nats: nc, userService: userService, participantService: participantService}
+func NewWebSocketService(nc *nats.Conn, userService UserService, participantService ParticipantService, spectatorService SpectatorService) WebSocketService {
+	return &websocketService{nats: nc, userService: userService, participantService: participantService, spectatorService: spectatorService}
 }
 
 func (s *websocketService) HandleWebSocketConnection(ctx context.Context, contest *model.Contest, participants []model.ContestParticipant, conn *websocket.Conn) {
+	s.serve(ctx, contest, participants, nil, conn)
+}
+
+// spectators get the same stream read-only: no chat, no roster, and no emails
+func (s *websocketService) HandleSpectatorConnection(ctx context.Context, contest *model.Contest, spectatorToken *model.ContestSpectatorToken, conn *websocket.Conn) {
+	util.RedactContest(contest)
+	s.serve(ctx, contest, nil, spectatorToken, conn)
+}
+
+func (s *websocketService) serve(ctx context.Context, contest *model.Contest, participants []model.ContestParticipant, spectator *model.ContestSpectatorToken, conn *websocket.Conn) {
 	log := util.LoggerFromContext(ctx)
 	contestID := contest.ID
 
 	// generate connection id and update context
 	connectionID := uuid.New()
 	log = log.With("connection_id", connectionID)
+	if spectator != nil {
+		log = log.With("spectator_token_id", spectator.ID)
+	}
 	ctx = context.WithValue(ctx, model.ConnectionIDKey, connectionID)
 
 	// subscribe to NATS subject for contest updates before notifying client
@@ -122,11 +139,11 @@ func (s *websocketService) HandleWebSocketConnection(ctx context.Context, contes
 	defer cancel()
 
 	// start message handlers
-	go s.handleIncomingMessages(ctx, cancel, conn, contestID, log)
-	s.handleOutgoingMessages(ctx, conn, pingChecker, jwtChecker, natsChecker, contestID, connectionID, natsChan, log, sub)
+	go s.handleIncomingMessages(ctx, cancel, conn, contestID, spectator != nil, log)
+	s.handleOutgoingMessages(ctx, conn, pingChecker, jwtChecker, natsChecker, contestID, connectionID, spectator, natsChan, log, sub)
 }
 
-func (s *websocketService) handleIncomingMessages(ctx context.Context, cancel context.CancelFunc, conn *websocket.Conn, contestID uuid.UUID, log *slog.Logger) {
+func (s *websocketService) handleIncomingMessages(ctx context.Context, cancel context.CancelFunc, conn *websocket.Conn, contestID uuid.UUID, readOnly bool, log *slog.Logger) {
 	defer cancel()
 
 	for {
@@ -142,6 +159,11 @@ func (s *websocketService) handleIncomingMessages(ctx context.Context, cancel co
 
 		metrics.IncWSMessageReceived()
 
+		// read-only sockets are still read so pongs and closes are noticed
+		if readOnly {
+			continue
+		}
+
 		var chatMsg model.WSChatMessage
 		if err := json.Unmarshal(rawMsg, &chatMsg); err != nil {
 			log.Warn("failed to unmarshal incoming ws message", "error", err)
@@ -199,6 +221,7 @@ func (s *websocketService) handleOutgoingMessages(
 	natsChecker *time.Ticker,
 	contestID uuid.UUID,
 	connectionID uuid.UUID,
+	spectator *model.ContestSpectatorToken,
 	natsChan <-chan *nats.Msg,
 	log *slog.Logger,
 	sub *nats.Subscription,
@@ -223,8 +246,35 @@ func (s *websocketService) handleOutgoingMessages(
 				continue
 			}
 
-			// a contest going private kicks anyone who was only watching via public access
-			if s.shouldCloseOnVisibility(ctx, &updateData, log) {
+			if spectator != nil {
+				if updateData.Type == model.SpectatorRevokedType && updateData.Message == spectator.ID.String() {
+					log.Warn("spectator link revoked, closing connection")
+					metrics.RecordWSDisconnect(model.WSDisconnectSpectatorRevoked)
+					if err := sendWebSocketMessage(conn, log, model.NewDisconnectedMessage(contestID, connectionID)); err != nil {
+						log.Info("failed to send disconnected message", "error", err)
+					}
+					_ = conn.Close()
+					return
+				}
+				if !util.RedactWSUpdate(&updateData) {
+					continue
+				}
+			} else if updateData.Type == model.SpectatorRevokedType {
+				continue
+			}
+
+			if spectator == nil && isBannedUser(ctx, &updateData) {
+				log.Warn("user banned from contest, closing connection")
+				metrics.RecordWSDisconnect(model.WSDisconnectBanned)
+				if err := sendWebSocketMessage(conn, log, model.NewDisconnectedMessage(contestID, connectionID)); err != nil {
+					log.Info("failed to send disconnected message", "error", err)
+				}
+				_ = conn.Close()
+				return
+			}
+
+			// a contest going private kicks anyone who was only watching via public access; spectator links outlive visibility
+			if spectator == nil && s.shouldCloseOnVisibility(ctx, &updateData, log) {
 				log.Warn("contest went private, closing connection for non-participant")
 				metrics.RecordWSDisconnect(model.WSDisconnectVisibilityRevoked)
 				if err := sendWebSocketMessage(conn, log, model.NewDisconnectedMessage(contestID, connectionID)); err != nil {
@@ -248,11 +298,17 @@ func (s *websocketService) handleOutgoingMessages(
 				return
 			}
 
-		// validate jwt token periodically
+		// validate jwt token, or the spectator link, periodically
 		case <-jwtChecker.C:
-			if s.shouldCloseConnection(ctx, log) {
+			shouldClose, reason := false, model.WSDisconnectTokenExpired
+			if spectator != nil {
+				shouldClose, reason = s.shouldCloseSpectator(ctx, spectator.Token, log), model.WSDisconnectSpectatorRevoked
+			} else {
+				shouldClose = s.shouldCloseConnection(ctx, log)
+			}
+			if shouldClose {
 				log.Warn("closing connection due to token validation failure")
-				metrics.RecordWSDisconnect(model.WSDisconnectTokenExpired)
+				metrics.RecordWSDisconnect(reason)
 				if err := sendWebSocketMessage(conn, log, model.NewDisconnectedMessage(contestID, connectionID)); err != nil {
 					log.Info("failed to send disconnected message", "error", err)
 				}
@@ -325,6 +381,16 @@ func (s *websocketService) shouldCloseOnVisibility(ctx context.Context, update *
 	return false
 }
 
+// every socket sees the ban broadcast; only the banned user's own connections act on it
+func isBannedUser(ctx context.Context, update *model.WSUpdate) bool {
+	if update.Type != model.ParticipantBannedType {
+		return false
+	}
+
+	claims := util.ClaimsFromContext(ctx)
+	return claims != nil && strings.EqualFold(claims.Email, update.Message)
+}
+
 func (s *websocketService) shouldCloseConnection(ctx context.Context, log *slog.Logger) bool {
 	claims := util.ClaimsFromContext(ctx)
 	valid, err := s.userService.IsTokenValid(ctx, claims)
@@ -339,3 +405,17 @@ func (s *websocketService) shouldCloseConnection(ctx context.Context, log *slog.
 
 	return false
 }
+
+// catches expiry, and revocations whose nats event was missed; transient errors keep the socket open
+func (s *websocketService) shouldCloseSpectator(ctx context.Context, token string, log *slog.Logger) bool {
+	_, err := s.spectatorService.ValidateToken(ctx, token)
+	if errors.Is(err, errs.ErrSpectatorTokenNotFound) || errors.Is(err, errs.ErrSpectatorTokenExpired) {
+		log.Info("closing spectator websocket, link no longer valid", "error", err)
+		return true
+	}
+	if err != nil {
+		log.Error("failed to validate spectator link for websocket", "error", err)
+	}
+
+	return false
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
+	"github.com/maxmorhardt/squares-api/internal/errs"
 	"github.com/maxmorhardt/squares-api/internal/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -36,8 +37,17 @@ func (f fakeParticipantService) Authorize(context.Context, uuid.UUID, string, Ac
 	return f.authErr
 }
 
+type fakeSpectatorService struct {
+	SpectatorService
+	err error
+}
+
+func (f fakeSpectatorService) ValidateToken(context.Context, string) (*model.ContestSpectatorToken, error) {
+	return &model.ContestSpectatorToken{}, f.err
+}
+
 func TestNewWebSocketService(t *testing.T) {
-	require.NotNil(t, NewWebSocketService(nil, &fakeUserService{}, &fakeParticipantService{}))
+	require.NotNil(t, NewWebSocketService(nil, &fakeUserService{}, &fakeParticipantService{}, &fakeSpectatorService{}))
 }
 
 func TestShouldCloseOnVisibility(t *testing.T) {
@@ -75,6 +85,19 @@ func TestShouldCloseOnVisibility(t *testing.T) {
 	assert.True(t, authorized.shouldCloseOnVisibility(context.Background(), privateUpdate, log))
 }
 
+func TestIsBannedUser(t *testing.T) {
+	contestID := uuid.New()
+	ban := model.NewParticipantBannedMessage(contestID, "owner@example.com", "troll@example.com")
+	ctxFor := func(email string) context.Context {
+		return context.WithValue(context.Background(), model.ClaimsKey, &model.Claims{Email: email})
+	}
+
+	assert.True(t, isBannedUser(ctxFor("Troll@Example.com"), ban), "banned user's sockets close")
+	assert.False(t, isBannedUser(ctxFor("other@example.com"), ban), "everyone else stays connected")
+	assert.False(t, isBannedUser(context.Background(), ban), "no claims -> nothing to match")
+	assert.False(t, isBannedUser(ctxFor("troll@example.com"), &model.WSUpdate{Type: model.ChatMessageType, Message: "troll@example.com"}))
+}
+
 func TestShouldCloseConnection(t *testing.T) {
 	log := slog.Default()
 	ctx := context.Background()
@@ -89,6 +112,23 @@ func TestShouldCloseConnection(t *testing.T) {
 	assert.False(t, dbErr.shouldCloseConnection(ctx, log), "db error -> keep open, don't drop on transient failure")
 }
 
+func TestShouldCloseSpectator(t *testing.T) {
+	log := slog.Default()
+	ctx := context.Background()
+
+	keep := &websocketService{spectatorService: &fakeSpectatorService{}}
+	assert.False(t, keep.shouldCloseSpectator(ctx, "tok", log), "valid link -> keep open")
+
+	revoked := &websocketService{spectatorService: &fakeSpectatorService{err: errs.ErrSpectatorTokenNotFound}}
+	assert.True(t, revoked.shouldCloseSpectator(ctx, "tok", log), "revoked link -> close")
+
+	expired := &websocketService{spectatorService: &fakeSpectatorService{err: errs.ErrSpectatorTokenExpired}}
+	assert.True(t, expired.shouldCloseSpectator(ctx, "tok", log), "expired link -> close")
+
+	dbErr := &websocketService{spectatorService: &fakeSpectatorService{err: errs.ErrDatabaseUnavailable}}
+	assert.False(t, dbErr.shouldCloseSpectator(ctx, "tok", log), "db error -> keep open")
+}
+
 func TestHandleChatMessage(t *testing.T) {
 	s := &websocketService{}
 	log := slog.Default()
@@ -4,3 +4,6 @@ import _ "embed"
 
 //go:embed contact_email.html
 var ContactEmailHTML string
+
+//go:embed invite_email.html
+var InviteEmailHTML string
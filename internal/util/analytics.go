@@ -0,0 +1,152 @@
+package util
+
+import (
+	"encoding/json"
+
+	"github.com/maxmorhardt/squares-api/internal/model"
+)
+
+// each quarter pays an equal share of the pot
+const quarterPayoutShare = 0.25
+
+type digitFrequencyDataset struct {
+	Games    int64 `json:"games"`
+	Quarters []struct {
+		Quarter int           `json:"quarter"`
+		Counts  [10][10]int64 `json:"counts"`
+	} `json:"quarters"`
+}
+
+func ParseDigitFrequencies(data []byte) (freqs []model.DigitFrequency, games int64, err error) {
+	var dataset digitFrequencyDataset
+	if err := json.Unmarshal(data, &dataset); err != nil {
+		return nil, 0, err
+	}
+
+	for _, q := range dataset.Quarters {
+		for home := range 10 {
+			for away := range 10 {
+				freqs = append(freqs, model.DigitFrequency{
+					Quarter:   q.Quarter,
+					HomeDigit: home,
+					AwayDigit: away,
+					Count:     q.Counts[home][away],
+				})
+			}
+		}
+	}
+
+	return freqs, dataset.Games, nil
+}
+
+func DigitProbabilities(freqs []model.DigitFrequency, sampleGames int64) *model.DigitProbabilityTable {
+	// add-one smoothing keeps rare digit pairs from reading as impossible
+	var counts [4][10][10]float64
+	var totals [4]float64
+	for q := range 4 {
+		for home := range 10 {
+			for away := range 10 {
+				counts[q][home][away] = 1
+			}
+		}
+		totals[q] = 100
+	}
+
+	for _, f := range freqs {
+		if f.Quarter < 1 || f.Quarter > 4 || !validDigit(f.HomeDigit) || !validDigit(f.AwayDigit) || f.Count <= 0 {
+			continue
+		}
+		counts[f.Quarter-1][f.HomeDigit][f.AwayDigit] += float64(f.Count)
+		totals[f.Quarter-1] += float64(f.Count)
+	}
+
+	table := &model.DigitProbabilityTable{SampleGames: sampleGames}
+	for q := range 4 {
+		for home := range 10 {
+			for away := range 10 {
+				table.Quarters[q][home][away] = counts[q][home][away] / totals[q]
+			}
+		}
+	}
+
+	return table
+}
+
+func validDigit(d int) bool {
+	return d >= 0 && d <= 9
+}
+
+// sets per-square analytics; before labels are drawn every square has the same odds
+func AnnotateSquareAnalytics(c *model.Contest, table *model.DigitProbabilityTable) (labelsAssigned bool, err error) {
+	xLabels, yLabels, err := ParseLabels(c)
+	if err != nil {
+		return false, err
+	}
+	labelsAssigned = labelsDrawn(xLabels) && labelsDrawn(yLabels)
+
+	for i := range c.Squares {
+		sq := &c.Squares[i]
+		probs := make([]float64, 4)
+		for q := range 4 {
+			if labelsAssigned && sq.Row < len(yLabels) && sq.Col < len(xLabels) {
+				probs[q] = table.Quarters[q][xLabels[sq.Col]][yLabels[sq.Row]]
+			} else {
+				probs[q] = 0.01
+			}
+		}
+
+		analytics := &model.SquareAnalytics{WinProbabilities: probs}
+		for _, p := range probs {
+			analytics.ExpectedWins += p
+			analytics.ExpectedPayout += p * quarterPayoutShare
+		}
+		sq.Analytics = analytics
+	}
+
+	return labelsAssigned, nil
+}
+
+func labelsDrawn(labels []int8) bool {
+	if len(labels) != 10 {
+		return false
+	}
+	for _, l := range labels {
+		if l < 0 || l > 9 {
+			return false
+		}
+	}
+	return true
+}
+
+func ContestAnalytics(c *model.Contest, table *model.DigitProbabilityTable) (*model.ContestAnalyticsResponse, error) {
+	labelsAssigned, err := AnnotateSquareAnalytics(c, table)
+	if err != nil {
+		return nil, err
+	}
+
+	xLabels, yLabels, _ := ParseLabels(c)
+	resp := &model.ContestAnalyticsResponse{
+		ContestID:      c.ID,
+		LabelsAssigned: labelsAssigned,
+		SampleGames:    table.SampleGames,
+		Squares:        make([]model.SquareAnalyticsEntry, 0, len(c.Squares)),
+	}
+
+	for _, sq := range c.Squares {
+		entry := model.SquareAnalyticsEntry{
+			SquareID:  sq.ID,
+			Row:       sq.Row,
+			Col:       sq.Col,
+			Owner:     sq.Owner,
+			Analytics: *sq.Analytics,
+		}
+		if labelsAssigned {
+			home, away := int(xLabels[sq.Col]), int(yLabels[sq.Row])
+			entry.HomeDigit = &home
+			entry.AwayDigit = &away
+		}
+		resp.Squares = append(resp.Squares, entry)
+	}
+
+	return resp, nil
+}
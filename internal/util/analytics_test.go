@@ -0,0 +1,89 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDigitFrequencies_Seed(t *testing.T) {
+	freqs, games, err := ParseDigitFrequencies(seed.NFLDigitFrequenciesJSON)
+	require.NoError(t, err)
+	assert.Len(t, freqs, 400)
+	assert.Positive(t, games)
+}
+
+func TestParseDigitFrequencies_Invalid(t *testing.T) {
+	_, _, err := ParseDigitFrequencies([]byte("{"))
+	assert.Error(t, err)
+}
+
+func TestDigitProbabilities(t *testing.T) {
+	table := DigitProbabilities([]model.DigitFrequency{
+		{Quarter: 1, HomeDigit: 7, AwayDigit: 0, Count: 100},
+		// out of range rows are ignored
+		{Quarter: 5, HomeDigit: 0, AwayDigit: 0, Count: 100},
+		{Quarter: 1, HomeDigit: 10, AwayDigit: 0, Count: 100},
+	}, 100)
+
+	assert.InDelta(t, 101.0/200.0, table.Quarters[0][7][0], 1e-9)
+	// smoothing keeps unseen pairs possible
+	assert.InDelta(t, 1.0/200.0, table.Quarters[0][1][1], 1e-9)
+	assert.InDelta(t, 0.01, table.Quarters[1][0][0], 1e-9)
+	assert.Equal(t, int64(100), table.SampleGames)
+}
+
+func TestAnnotateSquareAnalytics_LabelsDrawn(t *testing.T) {
+	c := startedContest(model.ContestStatusQ1)
+	table := DigitProbabilities([]model.DigitFrequency{{Quarter: 4, HomeDigit: 7, AwayDigit: 3, Count: 100}}, 100)
+
+	drawn, err := AnnotateSquareAnalytics(c, table)
+	require.NoError(t, err)
+	assert.True(t, drawn)
+
+	// identity labels put home 7 in col 7 and away 3 in row 3
+	for _, sq := range c.Squares {
+		require.NotNil(t, sq.Analytics)
+		if sq.Row == 3 && sq.Col == 7 {
+			assert.InDelta(t, 101.0/200.0, sq.Analytics.WinProbabilities[3], 1e-9)
+			assert.Greater(t, sq.Analytics.ExpectedPayout, 0.25*101.0/200.0)
+		}
+	}
+}
+
+func TestAnnotateSquareAnalytics_LabelsNotDrawn(t *testing.T) {
+	c := startedContest(model.ContestStatusActive)
+	c.XLabels, c.YLabels = InitialLabels()
+
+	drawn, err := AnnotateSquareAnalytics(c, DigitProbabilities(nil, 0))
+	require.NoError(t, err)
+	assert.False(t, drawn)
+	for _, sq := range c.Squares {
+		assert.Equal(t, []float64{0.01, 0.01, 0.01, 0.01}, sq.Analytics.WinProbabilities)
+		assert.InDelta(t, 0.01, sq.Analytics.ExpectedPayout, 1e-9)
+	}
+}
+
+func TestContestAnalytics(t *testing.T) {
+	c := startedContest(model.ContestStatusQ1)
+
+	resp, err := ContestAnalytics(c, DigitProbabilities(nil, 7))
+	require.NoError(t, err)
+	assert.Equal(t, c.ID, resp.ContestID)
+	assert.Equal(t, int64(7), resp.SampleGames)
+	require.Len(t, resp.Squares, 100)
+	require.NotNil(t, resp.Squares[12].HomeDigit)
+	assert.Equal(t, 2, *resp.Squares[12].HomeDigit)
+	assert.Equal(t, 1, *resp.Squares[12].AwayDigit)
+}
+
+func TestContestAnalytics_BadLabels(t *testing.T) {
+	c := startedContest(model.ContestStatusQ1)
+	c.XLabels = []byte("nope")
+
+	_, err := ContestAnalytics(c, DigitProbabilities(nil, 0))
+	assert.Error(t, err)
+}
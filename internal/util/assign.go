@@ -0,0 +1,63 @@
+package util
+
+import (
+	cryptorand "crypto/rand"
+	"math/big"
+
+	"github.com/maxmorhardt/squares-api/internal/model"
+)
+
+// hands each unclaimed square to a random open slot, so participants with more squares left get proportionally more
+func AssignRemainingSquares(c *model.Contest, participants []model.ContestParticipant) (assigned []model.Square, ok bool, err error) {
+	claimed := make(map[string]int)
+	var empty []model.Square
+	for _, sq := range c.Squares {
+		if sq.Owner == "" {
+			empty = append(empty, sq)
+			continue
+		}
+		claimed[sq.Owner]++
+	}
+
+	if len(empty) == 0 {
+		return nil, true, nil
+	}
+
+	// one slot per square each participant could still claim
+	var slots []string
+	for _, p := range participants {
+		if p.Role == model.ParticipantRoleViewer {
+			continue
+		}
+		for range p.MaxSquares - claimed[p.UserID] {
+			slots = append(slots, p.UserID)
+		}
+	}
+
+	if len(slots) < len(empty) {
+		return nil, false, nil
+	}
+
+	if err := shuffle(slots); err != nil {
+		return nil, false, err
+	}
+
+	for i := range empty {
+		empty[i].Owner = slots[i]
+	}
+
+	return empty, true, nil
+}
+
+func shuffle[T any](items []T) error {
+	// fisher-yates shuffle with a cryptographic source
+	for i := len(items) - 1; i > 0; i-- {
+		n, err := cryptorand.Int(cryptorand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return err
+		}
+		j := int(n.Int64())
+		items[i], items[j] = items[j], items[i]
+	}
+	return nil
+}
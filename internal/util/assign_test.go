@@ -0,0 +1,67 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func partlyClaimedContest(claimed map[string]int) *model.Contest {
+	c := startedContest(model.ContestStatusActive)
+	i := 0
+	for owner, n := range claimed {
+		for range n {
+			c.Squares[i].Owner = owner
+			i++
+		}
+	}
+	for ; i < len(c.Squares); i++ {
+		c.Squares[i].Owner = ""
+		c.Squares[i].OwnerName = ""
+	}
+	return c
+}
+
+func TestAssignRemainingSquares_RespectsLimits(t *testing.T) {
+	c := partlyClaimedContest(map[string]int{"alice": 40})
+	participants := []model.ContestParticipant{
+		{UserID: "alice", Role: model.ParticipantRoleOwner, MaxSquares: 50},
+		{UserID: "bob", Role: model.ParticipantRoleParticipant, MaxSquares: 60},
+		{UserID: "viewer", Role: model.ParticipantRoleViewer, MaxSquares: 100},
+	}
+
+	assigned, ok, err := AssignRemainingSquares(c, participants)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Len(t, assigned, 60)
+
+	counts := map[string]int{}
+	for _, sq := range assigned {
+		counts[sq.Owner]++
+	}
+	// alice has 10 left and bob 60, so together they cover the 60 empty squares without exceeding either limit
+	assert.LessOrEqual(t, counts["alice"], 10)
+	assert.LessOrEqual(t, counts["bob"], 60)
+	assert.Zero(t, counts["viewer"])
+}
+
+func TestAssignRemainingSquares_NotEnoughCapacity(t *testing.T) {
+	c := partlyClaimedContest(map[string]int{"alice": 10})
+	participants := []model.ContestParticipant{{UserID: "alice", Role: model.ParticipantRoleOwner, MaxSquares: 20}}
+
+	assigned, ok, err := AssignRemainingSquares(c, participants)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Nil(t, assigned)
+}
+
+func TestAssignRemainingSquares_AlreadyFull(t *testing.T) {
+	c := startedContest(model.ContestStatusActive)
+
+	assigned, ok, err := AssignRemainingSquares(c, nil)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Empty(t, assigned)
+}
@@ -43,3 +43,9 @@ func SetGinContextValue(c *gin.Context, key model.CTXKey, value any) {
 	c.Set(key, value)
 	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), key, value))
 }
+
+// the contest version the client sent in If-Match, if any
+func ExpectedVersionFromContext(ctx context.Context) (int, bool) {
+	version, ok := ctx.Value(model.IfMatchKey).(int)
+	return version, ok
+}
@@ -0,0 +1,25 @@
+package util
+
+import (
+	"strconv"
+	"strings"
+)
+
+func FormatETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// accepts the strong or weak form of an etag produced by FormatETag
+func ParseETag(tag string) (int, bool) {
+	tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, false
+	}
+
+	version, err := strconv.Atoi(tag[1 : len(tag)-1])
+	if err != nil || version < 1 {
+		return 0, false
+	}
+
+	return version, true
+}
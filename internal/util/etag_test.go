@@ -0,0 +1,34 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatETag(t *testing.T) {
+	assert.Equal(t, `"7"`, FormatETag(7))
+}
+
+func TestParseETag(t *testing.T) {
+	cases := []struct {
+		tag     string
+		version int
+		ok      bool
+	}{
+		{`"7"`, 7, true},
+		{`W/"12"`, 12, true},
+		{` "3" `, 3, true},
+		{`7`, 0, false},
+		{`"abc"`, 0, false},
+		{`"0"`, 0, false},
+		{`""`, 0, false},
+		{`*`, 0, false},
+	}
+
+	for _, tc := range cases {
+		version, ok := ParseETag(tc.tag)
+		assert.Equal(t, tc.ok, ok, tc.tag)
+		assert.Equal(t, tc.version, version, tc.tag)
+	}
+}
@@ -140,6 +140,60 @@ func QuarterResultFor(c *model.Contest, quarter, homeScore, awayScore int) (*mod
 	}, nil
 }
 
+// carries an unowned win forward when the contest rolls over; previous holds the earlier quarters' results
+func ApplyRollover(c *model.Contest, result *model.QuarterResult, previous []model.QuarterResult) {
+	if !c.RollsOver() {
+		return
+	}
+
+	// count the unbroken run of rolled-over quarters leading into this one
+	rolled := make(map[int]bool, len(previous))
+	for _, p := range previous {
+		rolled[p.Quarter] = p.RolledOver
+	}
+	for q := result.Quarter - 1; q >= 1 && rolled[q]; q-- {
+		result.CarriedOver++
+	}
+
+	if result.Winner != "" {
+		return
+	}
+
+	if result.Quarter < 4 {
+		result.RolledOver = true
+		return
+	}
+
+	// the final quarter has nowhere to roll, so the pot goes to the next owned square
+	if owner, ownerName, ok := fallbackOwner(c, result.WinnerRow, result.WinnerCol); ok {
+		result.Winner = owner
+		result.WinnerName = ownerName
+		result.Fallback = true
+	}
+}
+
+// walks the grid row by row from the winning square, wrapping at the end
+func fallbackOwner(c *model.Contest, row, col int) (owner, ownerName string, ok bool) {
+	const gridSize = 10
+
+	var grid [gridSize * gridSize]*model.Square
+	for i := range c.Squares {
+		sq := &c.Squares[i]
+		if sq.Row >= 0 && sq.Row < gridSize && sq.Col >= 0 && sq.Col < gridSize {
+			grid[sq.Row*gridSize+sq.Col] = sq
+		}
+	}
+
+	start := row*gridSize + col
+	for step := 1; step < len(grid); step++ {
+		sq := grid[(start+step)%len(grid)]
+		if sq != nil && sq.Owner != "" {
+			return sq.Owner, sq.OwnerName, true
+		}
+	}
+	return "", "", false
+}
+
 func SynthesizeFromGame(c *model.Contest) {
 	if c.Game == nil {
 		return
@@ -155,6 +209,9 @@ func SynthesizeFromGame(c *model.Contest) {
 	}
 
 	sort.Slice(results, func(i, j int) bool { return results[i].Quarter < results[j].Quarter })
+	for i := range results {
+		ApplyRollover(c, &results[i], results[:i])
+	}
 	c.QuarterResults = results
 }
 
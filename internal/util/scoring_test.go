@@ -77,6 +77,113 @@ func TestSynthesizeFromGame_NoGame(t *testing.T) {
 	assert.Empty(t, c.QuarterResults)
 }
 
+func TestApplyRollover_Disabled(t *testing.T) {
+	c := startedContest(model.ContestStatusQ1)
+	c.Squares[37].Owner = ""
+	r, err := QuarterResultFor(c, 1, 7, 3)
+	require.NoError(t, err)
+
+	ApplyRollover(c, r, nil)
+	assert.Empty(t, r.Winner)
+	assert.False(t, r.RolledOver)
+}
+
+func TestApplyRollover_CarriesForward(t *testing.T) {
+	c := startedContest(model.ContestStatusQ3)
+	c.Rollover = true
+	c.Squares[37].Owner = ""
+
+	q2, err := QuarterResultFor(c, 2, 7, 3)
+	require.NoError(t, err)
+	ApplyRollover(c, q2, []model.QuarterResult{{Quarter: 1, RolledOver: true}})
+	assert.True(t, q2.RolledOver)
+	assert.Equal(t, 1, q2.CarriedOver)
+
+	// an owned winner collects both earlier prizes
+	q3, err := QuarterResultFor(c, 3, 14, 10)
+	require.NoError(t, err)
+	ApplyRollover(c, q3, []model.QuarterResult{{Quarter: 1, RolledOver: true}, *q2})
+	assert.False(t, q3.RolledOver)
+	assert.Equal(t, 2, q3.CarriedOver)
+	assert.Equal(t, "u", q3.Winner)
+}
+
+func TestApplyRollover_RunBrokenByWinner(t *testing.T) {
+	c := startedContest(model.ContestStatusQ3)
+	c.Rollover = true
+	r, err := QuarterResultFor(c, 3, 14, 10)
+	require.NoError(t, err)
+
+	ApplyRollover(c, r, []model.QuarterResult{{Quarter: 1, RolledOver: true}, {Quarter: 2}})
+	assert.Zero(t, r.CarriedOver)
+}
+
+func TestApplyRollover_FinalQuarterFallsBack(t *testing.T) {
+	c := startedContest(model.ContestStatusQ4)
+	c.FillPolicy = model.FillPolicyRollover
+	c.Squares[37].Owner = ""
+	c.Squares[38].Owner = ""
+	c.Squares[39].Owner = "next"
+	c.Squares[39].OwnerName = "Next"
+
+	r, err := QuarterResultFor(c, 4, 7, 3)
+	require.NoError(t, err)
+	ApplyRollover(c, r, []model.QuarterResult{{Quarter: 3, RolledOver: true}})
+
+	assert.False(t, r.RolledOver)
+	assert.True(t, r.Fallback)
+	assert.Equal(t, 1, r.CarriedOver)
+	assert.Equal(t, "next", r.Winner)
+	assert.Equal(t, "Next", r.WinnerName)
+	// the score's square is still the one that hit
+	assert.Equal(t, 3, r.WinnerRow)
+	assert.Equal(t, 7, r.WinnerCol)
+}
+
+func TestApplyRollover_FinalQuarterWrapsGrid(t *testing.T) {
+	c := startedContest(model.ContestStatusQ4)
+	c.Rollover = true
+	for i := range c.Squares {
+		c.Squares[i].Owner = ""
+	}
+	c.Squares[0].Owner = "first"
+
+	r, err := QuarterResultFor(c, 4, 9, 9)
+	require.NoError(t, err)
+	ApplyRollover(c, r, nil)
+	assert.Equal(t, "first", r.Winner)
+}
+
+func TestApplyRollover_FinalQuarterEmptyGrid(t *testing.T) {
+	c := startedContest(model.ContestStatusQ4)
+	c.Rollover = true
+	for i := range c.Squares {
+		c.Squares[i].Owner = ""
+	}
+
+	r, err := QuarterResultFor(c, 4, 7, 3)
+	require.NoError(t, err)
+	ApplyRollover(c, r, nil)
+	assert.Empty(t, r.Winner)
+	assert.False(t, r.Fallback)
+}
+
+func TestSynthesizeFromGame_Rollover(t *testing.T) {
+	c := startedContest(model.ContestStatusQ3)
+	c.Rollover = true
+	c.Squares[37].Owner = ""
+	c.Game = &model.Game{ID: uuid.New(), Scores: []model.GameScore{
+		{Quarter: 2, HomeScore: 14, AwayScore: 10},
+		{Quarter: 1, HomeScore: 7, AwayScore: 3},
+	}}
+
+	SynthesizeFromGame(c)
+
+	require.Len(t, c.QuarterResults, 2)
+	assert.True(t, c.QuarterResults[0].RolledOver)
+	assert.Equal(t, 1, c.QuarterResults[1].CarriedOver)
+}
+
 func TestRandomizedLabels(t *testing.T) {
 	xJSON, yJSON, err := RandomizedLabels()
 	require.NoError(t, err)
@@ -0,0 +1,54 @@
+package util
+
+import "github.com/maxmorhardt/squares-api/internal/model"
+
+// strips participant emails in place; display names and initials stay so the board still reads
+func RedactContest(c *model.Contest) {
+	c.Owner = ""
+	c.CreatedBy, c.UpdatedBy = "", ""
+	for i := range c.Squares {
+		RedactSquare(&c.Squares[i])
+	}
+	for i := range c.QuarterResults {
+		RedactQuarterResult(&c.QuarterResults[i])
+	}
+}
+
+func RedactSquare(sq *model.Square) {
+	sq.Owner = ""
+	sq.CreatedBy, sq.UpdatedBy = "", ""
+}
+
+func RedactQuarterResult(r *model.QuarterResult) {
+	r.Winner = ""
+	r.CreatedBy, r.UpdatedBy = "", ""
+}
+
+// reports whether a spectator may see the update, redacting it in place when they may
+func RedactWSUpdate(u *model.WSUpdate) bool {
+	// chat and roster changes are only meaningful with the emails they carry
+	switch u.Type {
+	case model.ChatMessageType, model.ParticipantAddedType, model.ParticipantRemovedType, model.ParticipantBannedType, model.SpectatorRevokedType:
+		return false
+	}
+
+	if u.UpdatedBy != "system" {
+		u.UpdatedBy = ""
+	}
+	u.Participants, u.Participant = nil, nil
+
+	if u.Square != nil {
+		RedactSquare(u.Square)
+	}
+	for i := range u.Squares {
+		RedactSquare(&u.Squares[i])
+	}
+	if u.Contest != nil {
+		RedactContest(u.Contest)
+	}
+	if u.QuarterResult != nil {
+		RedactQuarterResult(u.QuarterResult)
+	}
+
+	return true
+}
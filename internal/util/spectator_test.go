@@ -0,0 +1,66 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactContest(t *testing.T) {
+	c := &model.Contest{
+		Owner: "owner@example.com", CreatedBy: "owner@example.com", UpdatedBy: "owner@example.com",
+		Squares:        []model.Square{{Value: "AB", Owner: "a@example.com", OwnerName: "Alice", CreatedBy: "a@example.com"}},
+		QuarterResults: []model.QuarterResult{{Winner: "a@example.com", WinnerName: "Alice", UpdatedBy: "owner@example.com"}},
+	}
+
+	RedactContest(c)
+
+	assert.Empty(t, c.Owner)
+	assert.Empty(t, c.CreatedBy)
+	assert.Empty(t, c.Squares[0].Owner)
+	assert.Empty(t, c.Squares[0].CreatedBy)
+	assert.Equal(t, "AB", c.Squares[0].Value, "initials still show on the board")
+	assert.Equal(t, "Alice", c.Squares[0].OwnerName)
+	assert.Empty(t, c.QuarterResults[0].Winner)
+	assert.Equal(t, "Alice", c.QuarterResults[0].WinnerName)
+}
+
+func TestRedactWSUpdate(t *testing.T) {
+	contestID := uuid.New()
+
+	for _, dropped := range []*model.WSUpdate{
+		model.NewChatMessage(contestID, "a@example.com", "hi"),
+		model.NewParticipantAddedMessage(contestID, &model.ContestParticipant{UserID: "a@example.com"}),
+		model.NewParticipantRemovedMessage(contestID, "owner@example.com", &model.ContestParticipant{UserID: "a@example.com"}),
+		model.NewSpectatorRevokedMessage(contestID, uuid.New(), "owner@example.com"),
+		model.NewParticipantBannedMessage(contestID, "owner@example.com", "a@example.com"),
+	} {
+		assert.False(t, RedactWSUpdate(dropped), "%s is never sent to spectators", dropped.Type)
+	}
+
+	square := model.NewSquareUpdateMessage(contestID, "a@example.com", &model.Square{Value: "AB", Owner: "a@example.com"})
+	assert.True(t, RedactWSUpdate(square))
+	assert.Empty(t, square.UpdatedBy)
+	assert.Empty(t, square.Square.Owner)
+	assert.Equal(t, "AB", square.Square.Value)
+
+	squares := model.NewSquaresUpdateMessage(contestID, "a@example.com", []model.Square{{Owner: "a@example.com"}, {Owner: "b@example.com"}})
+	assert.True(t, RedactWSUpdate(squares))
+	assert.Empty(t, squares.Squares[0].Owner)
+	assert.Empty(t, squares.Squares[1].Owner)
+
+	result := model.NewQuarterResultUpdateMessage(contestID, "owner@example.com", &model.QuarterResult{Winner: "a@example.com", WinnerName: "Alice"})
+	assert.True(t, RedactWSUpdate(result))
+	assert.Empty(t, result.QuarterResult.Winner)
+
+	locked := model.NewContestLockedMessage(contestID, &model.Contest{Owner: "owner@example.com"}, "locked")
+	assert.True(t, RedactWSUpdate(locked))
+	assert.Equal(t, "system", locked.UpdatedBy, "system updates keep their sender")
+	assert.Empty(t, locked.Contest.Owner)
+
+	connected := model.NewConnectedMessage(contestID, uuid.New(), &model.Contest{}, []model.ContestParticipant{{UserID: "a@example.com"}})
+	assert.True(t, RedactWSUpdate(connected))
+	assert.Nil(t, connected.Participants)
+}
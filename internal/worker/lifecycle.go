@@ -0,0 +1,107 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/maxmorhardt/squares-api/internal/metrics"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/maxmorhardt/squares-api/internal/service"
+	"github.com/maxmorhardt/squares-api/internal/util"
+	"gorm.io/gorm"
+)
+
+type lifecycleRunner struct {
+	db                 *gorm.DB
+	contestService     service.ContestService
+	idempotencyService service.IdempotencyService
+	interval           time.Duration
+	lockKey            int64
+}
+
+func NewLifecycleRunner(db *gorm.DB, contestService service.ContestService, idempotencyService service.IdempotencyService, cfg model.LifecycleConfig) Runner {
+	return &lifecycleRunner{
+		db:                 db,
+		contestService:     contestService,
+		idempotencyService: idempotencyService,
+		interval:           cfg.Interval,
+		lockKey:            cfg.LockKey,
+	}
+}
+
+func (r *lifecycleRunner) Start(ctx context.Context) {
+	ctx = util.ContextWithLogger(ctx, util.LoggerFromContext(ctx).With("job", "lifecycle"))
+	go r.loop(ctx)
+}
+
+func (r *lifecycleRunner) loop(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	// run once at startup so locks that passed during a deploy fire right away
+	r.runGuarded(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.runGuarded(ctx)
+		}
+	}
+}
+
+func (r *lifecycleRunner) runGuarded(ctx context.Context) {
+	log := util.LoggerFromContext(ctx)
+
+	// scheduled transitions must only fire from one replica
+	withAdvisoryLock(ctx, r.db, r.lockKey, func(ctx context.Context) {
+		// a failed lock pass still leaves the cleanup to run
+		started, err := r.contestService.LockDueContests(ctx)
+		if err != nil {
+			log.Error("lifecycle job failed", "error", err)
+			metrics.IncLifecycleRun(false)
+		} else {
+			metrics.IncLifecycleRun(true)
+
+			// stay silent in steady state; only surface actual transitions
+			if started > 0 {
+				log.Info("started contests at their scheduled lock", "count", started)
+			}
+		}
+
+		r.purge(ctx)
+	})
+}
+
+// cleanup steps are independent; one failing shouldn't skip the other
+func (r *lifecycleRunner) purge(ctx context.Context) {
+	log := util.LoggerFromContext(ctx)
+
+	// deleted contests stay restorable until their window passes
+	if _, err := r.contestService.PurgeDeletedContests(ctx); err != nil {
+		log.Error("deleted contest purge failed", "error", err)
+	}
+
+	if _, err := r.contestService.ArchiveFinishedContests(ctx); err != nil {
+		log.Error("contest archival failed", "error", err)
+	}
+
+	if _, err := r.contestService.ReleaseExpiredReservations(ctx); err != nil {
+		log.Error("square reservation release failed", "error", err)
+	}
+
+	if _, err := r.contestService.PurgeInviteEvents(ctx); err != nil {
+		log.Error("invite event purge failed", "error", err)
+	}
+
+	// stored idempotent responses are only replayed for a day
+	purged, err := r.idempotencyService.PurgeExpired(ctx)
+	if err != nil {
+		log.Error("idempotency key purge failed", "error", err)
+		return
+	}
+	if purged > 0 {
+		log.Info("purged expired idempotency keys", "count", purged)
+	}
+}
@@ -0,0 +1,115 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/maxmorhardt/squares-api/internal/mocks"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func mockLifecycleRunner(t *testing.T, contestSvc *mocks.ContestService, idempotencySvc *mocks.IdempotencyService) (*lifecycleRunner, sqlmock.Sqlmock) {
+	t.Helper()
+
+	sqlDB, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB, PreferSimpleProtocol: true}),
+		&gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	r := NewLifecycleRunner(gdb, contestSvc, idempotencySvc, model.LifecycleConfig{Interval: time.Hour, LockKey: 2}).(*lifecycleRunner)
+	return r, dbMock
+}
+
+func TestLifecycleRunner_RunGuarded_LockAcquired(t *testing.T) {
+	contestSvc := mocks.NewContestService(t)
+	contestSvc.EXPECT().LockDueContests(mock.Anything).Return(2, nil)
+	contestSvc.EXPECT().PurgeDeletedContests(mock.Anything).Return(1, nil)
+	contestSvc.EXPECT().ArchiveFinishedContests(mock.Anything).Return(1, nil)
+	contestSvc.EXPECT().ReleaseExpiredReservations(mock.Anything).Return(2, nil)
+	contestSvc.EXPECT().PurgeInviteEvents(mock.Anything).Return(4, nil)
+	idempotencySvc := mocks.NewIdempotencyService(t)
+	idempotencySvc.EXPECT().PurgeExpired(mock.Anything).Return(3, nil)
+
+	r, dbMock := mockLifecycleRunner(t, contestSvc, idempotencySvc)
+	dbMock.ExpectQuery(`pg_try_advisory_lock`).WithArgs(int64(2)).WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+	dbMock.ExpectExec(`pg_advisory_unlock`).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	r.runGuarded(context.Background())
+
+	assert.NoError(t, dbMock.ExpectationsWereMet())
+}
+
+func TestLifecycleRunner_RunGuarded_JobError(t *testing.T) {
+	contestSvc := mocks.NewContestService(t)
+	contestSvc.EXPECT().LockDueContests(mock.Anything).Return(0, errors.New("db down"))
+	// cleanup still runs after a failed lock pass
+	contestSvc.EXPECT().PurgeDeletedContests(mock.Anything).Return(0, nil)
+	contestSvc.EXPECT().ArchiveFinishedContests(mock.Anything).Return(0, nil)
+	contestSvc.EXPECT().ReleaseExpiredReservations(mock.Anything).Return(0, nil)
+	contestSvc.EXPECT().PurgeInviteEvents(mock.Anything).Return(0, nil)
+	idempotencySvc := mocks.NewIdempotencyService(t)
+	idempotencySvc.EXPECT().PurgeExpired(mock.Anything).Return(0, nil)
+
+	r, dbMock := mockLifecycleRunner(t, contestSvc, idempotencySvc)
+	dbMock.ExpectQuery(`pg_try_advisory_lock`).WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+	dbMock.ExpectExec(`pg_advisory_unlock`).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	r.runGuarded(context.Background())
+
+	// the lock is released even when the job fails
+	assert.NoError(t, dbMock.ExpectationsWereMet())
+}
+
+func TestLifecycleRunner_Purge_ErrorsDontSkipLaterSteps(t *testing.T) {
+	contestSvc := mocks.NewContestService(t)
+	contestSvc.EXPECT().PurgeDeletedContests(mock.Anything).Return(0, errors.New("db down"))
+	contestSvc.EXPECT().ArchiveFinishedContests(mock.Anything).Return(0, errors.New("db down"))
+	contestSvc.EXPECT().ReleaseExpiredReservations(mock.Anything).Return(0, errors.New("db down"))
+	contestSvc.EXPECT().PurgeInviteEvents(mock.Anything).Return(0, errors.New("db down"))
+	idempotencySvc := mocks.NewIdempotencyService(t)
+	idempotencySvc.EXPECT().PurgeExpired(mock.Anything).Return(0, nil)
+
+	r, _ := mockLifecycleRunner(t, contestSvc, idempotencySvc)
+	r.purge(context.Background())
+}
+
+func TestLifecycleRunner_RunGuarded_LockNotAcquired(t *testing.T) {
+	// no LockDueContests expectation: another replica holds the lock
+	r, dbMock := mockLifecycleRunner(t, mocks.NewContestService(t), mocks.NewIdempotencyService(t))
+	dbMock.ExpectQuery(`pg_try_advisory_lock`).WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(false))
+
+	r.runGuarded(context.Background())
+
+	assert.NoError(t, dbMock.ExpectationsWereMet())
+}
+
+func TestLifecycleRunner_Loop_StopsOnContextCancel(t *testing.T) {
+	r, _ := mockLifecycleRunner(t, mocks.NewContestService(t), mocks.NewIdempotencyService(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		r.loop(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("loop did not stop on context cancel")
+	}
+}
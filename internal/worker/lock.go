@@ -0,0 +1,48 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/maxmorhardt/squares-api/internal/util"
+	"gorm.io/gorm"
+)
+
+// runs fn only if this replica wins the advisory lock for key
+func withAdvisoryLock(ctx context.Context, db *gorm.DB, key int64, fn func(ctx context.Context)) {
+	log := util.LoggerFromContext(ctx)
+
+	// pin a single connection so the advisory lock lives on one session
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Error("failed to get sql db for advisory lock", "error", err)
+		return
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		log.Error("failed to acquire connection for advisory lock", "error", err)
+		return
+	}
+	defer func() { _ = conn.Close() }()
+
+	var locked bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&locked); err != nil {
+		log.Error("failed to acquire advisory lock", "error", err)
+		return
+	}
+	// another replica holds the lock, so skip this turn
+	if !locked {
+		return
+	}
+	defer func() {
+		// unlock on a fresh context so shutdown cancellation can't leave it held
+		unlockCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := conn.ExecContext(unlockCtx, "SELECT pg_advisory_unlock($1)", key); err != nil {
+			log.Error("failed to release advisory lock", "error", err)
+		}
+	}()
+
+	fn(ctx)
+}
@@ -0,0 +1,118 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/maxmorhardt/squares-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const racerCount = 12
+
+func TestClaimConcurrency(t *testing.T) {
+	contest, status := createContest(t, ownerToken, ownerUser, "Race Bowl", "Chiefs", "Eagles", 10)
+	require.Equal(t, http.StatusOK, status)
+	contestID := contest.ID
+
+	invite, status := createInvite(t, contestID, ownerToken, model.CreateInviteRequest{MaxSquares: 3, Role: "participant"})
+	require.Equal(t, http.StatusOK, status)
+
+	racers := make([]string, racerCount)
+	for i := range racers {
+		racers[i] = mintToken(fmt.Sprintf("racer%d@example.com", i))
+		require.Equal(t, http.StatusCreated, redeemInvite(t, invite.Token, racers[i]))
+	}
+
+	board, status := getContest(t, contestID)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, board.Squares, 100)
+
+	t.Run("one winner per square", func(t *testing.T) {
+		target := board.Squares[0].ID
+		contenders := racers[1:]
+
+		codes := raceClaims(t, contestID, contenders, func(int) uuid.UUID { return target })
+
+		assert.Equal(t, 1, codes[http.StatusOK], "exactly one racer should win the square")
+		assert.Equal(t, len(contenders)-1, codes[http.StatusConflict], "every other racer should get a conflict")
+	})
+
+	t.Run("one user cannot exceed their limit with parallel claims", func(t *testing.T) {
+		// racers[0] sits out the other races so their whole limit is still available
+		greedy := make([]string, 8)
+		for i := range greedy {
+			greedy[i] = racers[0]
+		}
+
+		codes := raceClaims(t, contestID, greedy, func(i int) uuid.UUID { return board.Squares[10+i].ID })
+
+		assert.Equal(t, 3, codes[http.StatusOK], "only the participant's limit worth of claims should land")
+		assert.Equal(t, len(greedy)-3, codes[http.StatusBadRequest])
+	})
+
+	t.Run("board stays consistent under contention", func(t *testing.T) {
+		// everyone fights over the same handful of squares several times over
+		var hammer []string
+		for range 4 {
+			hammer = append(hammer, racers[1:]...)
+		}
+
+		codes := raceClaims(t, contestID, hammer, func(i int) uuid.UUID { return board.Squares[40+i%5].ID })
+		assert.Zero(t, codes[http.StatusInternalServerError])
+
+		final, status := getContest(t, contestID)
+		require.Equal(t, http.StatusOK, status)
+
+		// squares come back in no particular order, so look the contested ones up by id
+		owned := map[string]int{}
+		byID := make(map[uuid.UUID]model.Square, len(final.Squares))
+		for _, sq := range final.Squares {
+			byID[sq.ID] = sq
+			if sq.Owner != "" {
+				owned[sq.Owner]++
+			}
+		}
+		for owner, count := range owned {
+			assert.LessOrEqual(t, count, 3, "%s holds more squares than their limit", owner)
+		}
+		for i := range 5 {
+			id := board.Squares[40+i].ID
+			require.Contains(t, byID, id)
+			assert.NotEmpty(t, byID[id].Owner, "contested square %s should end up claimed", id)
+		}
+	})
+}
+
+// fires every claim at once and tallies the response codes
+func raceClaims(t *testing.T, contestID uuid.UUID, tokens []string, squareFor func(i int) uuid.UUID) map[int]int {
+	t.Helper()
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		start = make(chan struct{})
+		codes = map[int]int{}
+	)
+
+	for i, token := range tokens {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			code, _ := doRequest(t, http.MethodPost, fmt.Sprintf("/contests/%s/squares/%s/claim", contestID, squareFor(i)), token, nil)
+
+			mu.Lock()
+			codes[code]++
+			mu.Unlock()
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+	return codes
+}